@@ -0,0 +1,177 @@
+// Command gc-agent monitors the GC of another Go process from a sidecar.
+//
+// The target must serve expvar (/debug/vars) or pprof (/debug/pprof/heap)
+// endpoints. The agent samples them on an interval, runs a Monitor over the
+// samples and serves the results:
+//
+//	/metrics  Prometheus text format
+//	/health   health check JSON, 503 when critical
+//
+// With -tui it also draws a live dashboard of the target's GC in the
+// terminal, instead of logging.
+//
+// Secrets are read from the environment rather than flags, which other
+// users can see in the process list: critical alerts are paged to PagerDuty
+// when $GC_AGENT_PAGERDUTY_KEY holds an Events API v2 routing key, and the
+// SMTP password comes from $GC_AGENT_SMTP_PASSWORD.
+//
+// Usage:
+//
+//	gc-agent -target http://localhost:6060 -listen :9090 \
+//		-rule "heap_growth_rate > 5MB/s over 5m"
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/tui"
+)
+
+func main() {
+	target := flag.String("target", "http://localhost:6060", "base URL of the target's debug endpoints")
+	format := flag.String("format", "expvar", "target endpoint format: expvar or pprof")
+	listen := flag.String("listen", ":9090", "address to serve /metrics and /health on")
+	interval := flag.Duration("interval", time.Second, "sampling interval")
+	jitter := flag.Float64("jitter", 0.1, "fraction of the interval to randomly spread samples by, so replicas don't sample in lockstep")
+	maxSamples := flag.Int("max-samples", 1000, "maximum samples to keep in memory")
+	memoryLimit := flag.Uint64("memory-limit", 0, "target memory limit in bytes for OOM forecasting (0: disabled)")
+	webhookURL := flag.String("webhook", "", "URL to POST alerts to as JSON (optional)")
+	smtpAddr := flag.String("smtp", "", "SMTP server host:port to email alerts through (optional)")
+	smtpUser := flag.String("smtp-user", "", "SMTP username; the password is read from $GC_AGENT_SMTP_PASSWORD")
+	emailFrom := flag.String("email-from", "", "sender address of alert emails")
+	emailTo := flag.String("email-to", "", "comma-separated recipients of alert emails")
+	dashboard := flag.Bool("tui", false, "draw a live dashboard in the terminal instead of logging")
+	healthProfile := flag.String("health-profile", "default", "health scoring profile: default, latency-critical, throughput-batch or memory-constrained")
+	var rules []gcanalyzer.AlertRule
+	flag.Func("rule", `alert rule in addition to the defaults, e.g. "p99_pause > 200ms for 3 windows" (repeatable)`, func(expr string) error {
+		rule, err := gcanalyzer.ParseAlertRule(expr)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rule)
+		return nil
+	})
+	labels := make(map[string]string)
+	flag.Func("label", `label identifying the target, e.g. "service=api", on its metrics and alerts (repeatable)`, func(label string) error {
+		name, value, ok := strings.Cut(label, "=")
+		if !ok {
+			return fmt.Errorf("expected name=value, got %q", label)
+		}
+		labels[name] = value
+		return nil
+	})
+	flag.Parse()
+
+	sample, err := gcanalyzer.RemoteSampler(*target, gcanalyzer.RemoteFormat(*format))
+	if err != nil {
+		log.Fatalf("gc-agent: %v", err)
+	}
+
+	var webhook *gcanalyzer.WebhookConfig
+	if *webhookURL != "" {
+		webhook = &gcanalyzer.WebhookConfig{
+			URL: *webhookURL,
+			OnError: func(a *gcanalyzer.Alert, err error) {
+				log.Printf("gc-agent: webhook: %s: %v", a.Message, err)
+			},
+		}
+	}
+
+	var pagerDuty *gcanalyzer.PagerDutyConfig
+	if key := os.Getenv("GC_AGENT_PAGERDUTY_KEY"); key != "" {
+		pagerDuty = &gcanalyzer.PagerDutyConfig{
+			RoutingKey: key,
+			OnError: func(a *gcanalyzer.Alert, err error) {
+				log.Printf("gc-agent: pagerduty: %s: %v", a.Message, err)
+			},
+		}
+	}
+
+	var email *gcanalyzer.EmailConfig
+	if *smtpAddr != "" {
+		email = &gcanalyzer.EmailConfig{
+			Addr:     *smtpAddr,
+			Username: *smtpUser,
+			Password: os.Getenv("GC_AGENT_SMTP_PASSWORD"),
+			From:     *emailFrom,
+			To:       strings.Split(*emailTo, ","),
+			OnError: func(a *gcanalyzer.Alert, err error) {
+				log.Printf("gc-agent: email: %s: %v", a.Message, err)
+			},
+		}
+	}
+
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{
+		Interval:       *interval,
+		IntervalJitter: *jitter,
+		MaxSamples:     *maxSamples,
+		MemoryLimit:    *memoryLimit,
+		AlertWebhook:   webhook,
+		PagerDuty:      pagerDuty,
+		AlertEmail:     email,
+		HealthProfile:  gcanalyzer.HealthProfile(*healthProfile),
+		Labels:         labels,
+		Sampler: func(ctx context.Context) (*gcanalyzer.GCMetrics, error) {
+			m, err := sample(ctx)
+			if err != nil {
+				log.Printf("gc-agent: sample: %v", err)
+			}
+			return m, err
+		},
+		OnAlert: func(a *gcanalyzer.Alert) {
+			log.Printf("gc-agent: alert [%s] %s (%.2f, threshold %.2f)", a.Severity, a.Message, a.Value, a.Threshold)
+		},
+	})
+
+	for _, rule := range rules {
+		if err := monitor.AddAlertRule(rule); err != nil {
+			log.Fatalf("gc-agent: %v", err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := monitor.Start(ctx); err != nil {
+		log.Fatalf("gc-agent: %v", err)
+	}
+	defer monitor.Stop()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", monitor.MetricsHandler())
+	mux.Handle("/health", monitor.HealthHandler())
+	server := &http.Server{Addr: *listen, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("gc-agent: monitoring %s, serving on %s", *target, *listen)
+	if *dashboard {
+		log.SetOutput(io.Discard)
+		go func() {
+			if err := tui.Run(ctx, os.Stdout, monitor, nil); err != nil {
+				stop()
+			}
+		}()
+	}
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.SetOutput(os.Stderr)
+		log.Fatalf("gc-agent: %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+// Command gc-regress fails a CI pipeline when GC behavior regressed between
+// two captures, such as samples saved by a benchmark run on the main branch
+// and on a pull request.
+//
+// Both files hold samples written by gcanalyzer.WriteMetrics or a capture
+// bundle; files ending in .jsonl are read as JSON Lines. The command prints
+// the comparison and exits with status 1 when a metric grew beyond its
+// tolerance, or 2 when a file cannot be read or analyzed.
+//
+// Usage:
+//
+//	gc-regress -p99-pause 0.1 baseline.json current.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func main() {
+	defaults := gcanalyzer.DefaultRegressionPolicy()
+	var policy gcanalyzer.RegressionPolicy
+	flag.Float64Var(&policy.P95Pause, "p95-pause", defaults.P95Pause, "allowed relative growth of the P95 pause (0: unchecked)")
+	flag.Float64Var(&policy.P99Pause, "p99-pause", defaults.P99Pause, "allowed relative growth of the P99 pause (0: unchecked)")
+	flag.Float64Var(&policy.GCFrequency, "gc-frequency", defaults.GCFrequency, "allowed relative growth of GC frequency (0: unchecked)")
+	flag.Float64Var(&policy.AllocRate, "alloc-rate", defaults.AllocRate, "allowed relative growth of the allocation rate (0: unchecked)")
+	flag.DurationVar(&policy.MinPauseIncrease, "min-pause-increase", defaults.MinPauseIncrease, "ignore pause regressions smaller than this")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] baseline current\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	baseline, err := analyzeFile(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gc-regress: %v\n", err)
+		os.Exit(2)
+	}
+	current, err := analyzeFile(flag.Arg(1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gc-regress: %v\n", err)
+		os.Exit(2)
+	}
+
+	fmt.Printf("%-14s %14s %14s\n", "metric", "baseline", "current")
+	fmt.Printf("%-14s %14v %14v\n", "p95_pause", baseline.P95PauseTime.Round(time.Microsecond), current.P95PauseTime.Round(time.Microsecond))
+	fmt.Printf("%-14s %14v %14v\n", "p99_pause", baseline.P99PauseTime.Round(time.Microsecond), current.P99PauseTime.Round(time.Microsecond))
+	fmt.Printf("%-14s %13.2f/s %13.2f/s\n", "gc_frequency", baseline.GCFrequency, current.GCFrequency)
+	fmt.Printf("%-14s %14s %14s\n", "alloc_rate", types.FormatBytesRate(baseline.AllocRate), types.FormatBytesRate(current.AllocRate))
+
+	if err := gcanalyzer.CheckRegression(baseline, current, policy); err != nil {
+		fmt.Fprintf(os.Stderr, "gc-regress: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("no GC regressions")
+}
+
+// analyzeFile loads and analyzes the samples in path
+func analyzeFile(path string) (*gcanalyzer.GCAnalysis, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	format := gcanalyzer.FormatJSON
+	if filepath.Ext(path) == ".jsonl" {
+		format = gcanalyzer.FormatJSONL
+	}
+	metrics, err := gcanalyzer.LoadMetrics(f, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	analysis, err := gcanalyzer.Analyze(metrics)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return analysis, nil
+}
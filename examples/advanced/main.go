@@ -6,11 +6,11 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"runtime"
 	"sync"
 	"time"
 
 	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/tuning"
 	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
 )
 
@@ -266,75 +266,32 @@ func generateAdvancedReports() {
 	}
 }
 
-// compareGCPerformance demonstrates before/after performance comparison
+// compareGCPerformance demonstrates comparing GOGC values in the running process.
+// GOGC is applied with debug.SetGCPercent: setting the GOGC environment
+// variable after startup has no effect.
 func compareGCPerformance() {
 	fmt.Println("   Comparing GC performance with different GOGC values...")
 
-	originalGOGC := os.Getenv("GOGC")
-	defer func() {
-		if originalGOGC != "" {
-			os.Setenv("GOGC", originalGOGC)
-		}
-	}()
-
-	gogcValues := []string{"100", "200", "50"}
-	results := make(map[string]*gcanalyzer.GCAnalysis)
-
-	for _, gogc := range gogcValues {
-		fmt.Printf("     Testing with GOGC=%s\n", gogc)
-
-		// Set GOGC value
-		os.Setenv("GOGC", gogc)
-		runtime.GC() // Force GC to apply new settings
-
-		// Collect metrics
-		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-		go generateConsistentWorkload(ctx)
-
-		metrics, err := gcanalyzer.CollectForDuration(ctx, 2*time.Second, 250*time.Millisecond)
-		cancel()
-
-		if err != nil {
-			log.Printf("Failed to collect metrics for GOGC=%s: %v", gogc, err)
-			continue
-		}
-
-		if len(metrics) < 2 {
-			fmt.Printf("       Insufficient data\n")
-			continue
-		}
-
-		analysis, err := gcanalyzer.Analyze(metrics)
-		if err != nil {
-			log.Printf("Failed to analyze GOGC=%s: %v", gogc, err)
-			continue
-		}
-
-		results[gogc] = analysis
-
-		fmt.Printf("       GC Freq: %.2f/s, Avg Pause: %v, GC Overhead: %.2f%%\n",
-			analysis.GCFrequency,
-			analysis.AvgPauseTime.Round(time.Microsecond),
-			analysis.GCOverhead)
-	}
-
-	// Find best performing configuration
-	var bestGOGC string
-	var bestScore float64
-
-	for gogc, analysis := range results {
-		// Simple scoring: lower frequency + lower overhead + lower pause times = better
-		score := 100.0 - (analysis.GCFrequency*10 + analysis.GCOverhead + float64(analysis.AvgPauseTime.Nanoseconds())/1000000)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go generateConsistentWorkload(ctx)
 
-		if bestGOGC == "" || score > bestScore {
-			bestGOGC = gogc
-			bestScore = score
-		}
+	result, err := tuning.Sweep(ctx, []int{50, 100, 200}, 2*time.Second)
+	if err != nil {
+		log.Printf("GOGC sweep failed: %v", err)
+		return
 	}
 
-	if bestGOGC != "" {
-		fmt.Printf("     🏆 Best performing GOGC value: %s (score: %.2f)\n", bestGOGC, bestScore)
+	for _, stage := range result.Stages {
+		fmt.Printf("     GOGC=%d: GC Freq: %.2f/s, P99 Pause: %v, GC Overhead: %.2f%%, Max Heap: %s (cost %.2f)\n",
+			stage.GOGC,
+			stage.Analysis.GCFrequency,
+			stage.Analysis.P99PauseTime.Round(time.Microsecond),
+			stage.Analysis.GCOverhead,
+			types.FormatBytes(stage.Analysis.MaxHeapSize),
+			stage.Cost)
 	}
+	fmt.Printf("     🏆 Best performing GOGC value: %d\n", result.Best().GOGC)
 }
 
 // Workload generators for different patterns
@@ -0,0 +1,49 @@
+package alerting
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// Conditions tracks alerts from checks evaluated outside rules, such as
+// anomaly detection and forecasts, so that they report firing, escalation
+// and recovery transitions the way rules do instead of alerting on every
+// sample. It is safe for concurrent use.
+type Conditions struct {
+	mu     sync.Mutex
+	firing map[string]*types.Alert // condition key -> last alert raised
+}
+
+// NewConditions creates an empty condition tracker
+func NewConditions() *Conditions {
+	return &Conditions{firing: make(map[string]*types.Alert)}
+}
+
+// Update records the state of the keyed condition: alert while it holds, nil
+// once it does not. It returns the alert to raise: alert when the condition
+// starts holding or its severity rises, a resolved copy of the last alert
+// raised when it stops holding, and nil otherwise.
+func (c *Conditions) Update(key string, alert *types.Alert) *types.Alert {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	firing := c.firing[key]
+	if alert == nil {
+		if firing == nil {
+			return nil
+		}
+		delete(c.firing, key)
+		resolved := *firing
+		resolved.Resolved = true
+		resolved.Timestamp = time.Now()
+		return &resolved
+	}
+
+	if firing != nil && types.Severity(alert.Severity).Rank() <= types.Severity(firing.Severity).Rank() {
+		return nil
+	}
+	c.firing[key] = alert
+	return alert
+}
@@ -0,0 +1,45 @@
+package alerting
+
+import (
+	"testing"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestConditions_Update(t *testing.T) {
+	c := NewConditions()
+	alert := func(severity string) *types.Alert {
+		return &types.Alert{Type: "memory", Severity: severity, Message: "Memory limit approaching"}
+	}
+
+	steps := []struct {
+		alert *types.Alert
+		want  string // "" for nothing raised
+	}{
+		{nil, ""},
+		{alert("warning"), "warning"},
+		{alert("warning"), ""}, // still holding
+		{alert("critical"), "critical"},
+		{alert("warning"), ""}, // lower severities do not re-alert
+		{nil, "resolved critical"},
+		{nil, ""},
+		{alert("warning"), "warning"},
+	}
+	for i, step := range steps {
+		got := ""
+		if a := c.Update("oom", step.alert); a != nil {
+			got = a.Severity
+			if a.Resolved {
+				got = "resolved " + got
+			}
+		}
+		if got != step.want {
+			t.Errorf("Step %d: raised %q, want %q", i, got, step.want)
+		}
+	}
+
+	// Conditions are tracked independently
+	if a := c.Update("seasonal", alert("warning")); a == nil {
+		t.Error("A second condition should fire independently")
+	}
+}
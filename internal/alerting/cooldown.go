@@ -0,0 +1,114 @@
+package alerting
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// Cooldown suppresses alerts that repeat an alert type raised shortly
+// before, such as a condition that flaps around its threshold. An alert of a
+// type is suppressed for the type's cooldown period after the last one that
+// was raised, unless its severity is higher. Resolutions of suppressed alerts
+// are suppressed too. Since rules only alert when their condition starts
+// holding, a suppressed alert that has not resolved by the end of the
+// cooldown is returned by Expired for delivery. It is safe for concurrent use.
+type Cooldown struct {
+	fallback time.Duration
+	periods  map[string]time.Duration
+
+	mu         sync.Mutex
+	last       map[string]*types.Alert   // alert type -> last alert raised
+	suppressed map[alertKey]*types.Alert // suppressed alerts still firing
+}
+
+// alertKey identifies the alert a resolution resolves
+type alertKey struct {
+	rule, alertType, message string
+}
+
+// NewCooldown creates a cooldown of period for each alert type, overridden
+// per type by periods. A zero period disables the cooldown for that type.
+func NewCooldown(period time.Duration, periods map[string]time.Duration) *Cooldown {
+	return &Cooldown{
+		fallback:   period,
+		periods:    periods,
+		last:       make(map[string]*types.Alert),
+		suppressed: make(map[alertKey]*types.Alert),
+	}
+}
+
+// period returns the cooldown period of an alert type
+func (c *Cooldown) period(alertType string) time.Duration {
+	if period, ok := c.periods[alertType]; ok {
+		return period
+	}
+	return c.fallback
+}
+
+// Allow reports whether an alert should be raised, recording it if so
+func (c *Cooldown) Allow(alert *types.Alert) bool {
+	period := c.period(alert.Type)
+	if period <= 0 {
+		return true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := alertKey{alert.Rule, alert.Type, alert.Message}
+	if alert.Resolved {
+		if c.suppressed[key] != nil {
+			delete(c.suppressed, key)
+			return false
+		}
+		return true
+	}
+
+	last := c.last[alert.Type]
+	if last != nil && alert.Timestamp.Sub(last.Timestamp) < period &&
+		types.Severity(alert.Severity).Rank() <= types.Severity(last.Severity).Rank() {
+		c.suppressed[key] = alert
+		return false
+	}
+	delete(c.suppressed, key)
+	c.last[alert.Type] = alert
+	return true
+}
+
+// Expired returns copies, timestamped now, of the suppressed alerts that are
+// still firing once their type's cooldown has run out, oldest first, and
+// records them as raised. Like Allow, it lets one alert of a type through
+// per cooldown period.
+func (c *Cooldown) Expired(now time.Time) []*types.Alert {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.suppressed) == 0 {
+		return nil
+	}
+	pending := make([]alertKey, 0, len(c.suppressed))
+	for key := range c.suppressed {
+		pending = append(pending, key)
+	}
+	slices.SortFunc(pending, func(a, b alertKey) int {
+		return c.suppressed[a].Timestamp.Compare(c.suppressed[b].Timestamp)
+	})
+
+	var alerts []*types.Alert
+	for _, key := range pending {
+		alert := c.suppressed[key]
+		if last := c.last[alert.Type]; last != nil && now.Sub(last.Timestamp) < c.period(alert.Type) {
+			continue
+		}
+		delivered := *alert
+		delivered.Suppressed = false
+		delivered.Timestamp = now
+		delete(c.suppressed, key)
+		c.last[alert.Type] = &delivered
+		alerts = append(alerts, &delivered)
+	}
+	return alerts
+}
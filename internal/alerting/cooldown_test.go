@@ -0,0 +1,65 @@
+package alerting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestCooldown_Allow(t *testing.T) {
+	c := NewCooldown(time.Minute, map[string]time.Duration{"overhead": 0})
+	start := time.Unix(1700000000, 0)
+	alert := func(alertType, severity string, at time.Duration, resolved bool) *types.Alert {
+		return &types.Alert{Type: alertType, Severity: severity, Rule: alertType + " rule", Timestamp: start.Add(at), Resolved: resolved}
+	}
+
+	steps := []struct {
+		alert *types.Alert
+		want  bool
+	}{
+		{alert("pause", "warning", 0, false), true},
+		{alert("pause", "warning", time.Second, true), true},
+		{alert("pause", "warning", 10*time.Second, false), false}, // flapping
+		{alert("pause", "warning", 11*time.Second, true), false},  // its resolution too
+		{alert("pause", "critical", 20*time.Second, false), true}, // escalation
+		{alert("pause", "warning", 90*time.Second, false), true},  // after the cooldown
+		{alert("overhead", "warning", 0, false), true},
+		{alert("overhead", "warning", time.Second, false), true}, // no cooldown for the type
+	}
+	for i, step := range steps {
+		if got := c.Allow(step.alert); got != step.want {
+			t.Errorf("Step %d: Allow(%s %s, resolved %v) = %v, want %v",
+				i, step.alert.Type, step.alert.Severity, step.alert.Resolved, got, step.want)
+		}
+	}
+}
+
+func TestCooldown_Expired(t *testing.T) {
+	c := NewCooldown(time.Minute, nil)
+	start := time.Unix(1700000000, 0)
+	alert := func(rule string, at time.Duration, resolved bool) *types.Alert {
+		return &types.Alert{Type: "memory", Severity: "warning", Rule: rule, Timestamp: start.Add(at), Resolved: resolved}
+	}
+
+	c.Allow(alert("heap", 0, false))
+	c.Allow(alert("growth", time.Second, false)) // suppressed, keeps firing
+	c.Allow(alert("leak", 2*time.Second, false)) // suppressed, then resolves
+	c.Allow(alert("leak", 3*time.Second, true))
+
+	if got := c.Expired(start.Add(30 * time.Second)); len(got) != 0 {
+		t.Errorf("Expired() during the cooldown = %d alerts, want none", len(got))
+	}
+	got := c.Expired(start.Add(time.Minute))
+	if len(got) != 1 || got[0].Rule != "growth" || !got[0].Timestamp.Equal(start.Add(time.Minute)) {
+		t.Fatalf("Expired() = %+v, want the growth alert now", got)
+	}
+	if got := c.Expired(start.Add(2 * time.Minute)); len(got) != 0 {
+		t.Errorf("Expired() delivered %d alerts twice", len(got))
+	}
+
+	// The delivered alert's resolution is no longer suppressed
+	if !c.Allow(alert("growth", 2*time.Minute, true)) {
+		t.Error("Resolution of a delivered alert was suppressed")
+	}
+}
@@ -0,0 +1,119 @@
+package alerting
+
+import "strings"
+
+// Derivatives of sample metrics, written as a function of the metric, e.g.
+// "slope(heap_alloc)". They fit a least-squares line through the metric's
+// per-sample values over the rule's lookback, so rules on them need Over.
+const (
+	// DerivativeSlope is the fitted line's slope: the metric's change per
+	// second, in the metric's unit per second
+	DerivativeSlope = "slope"
+
+	// DerivativeChange is the fitted line's relative change across the
+	// lookback as a fraction, e.g. 1 (100%) when the metric doubled
+	DerivativeChange = "change"
+)
+
+// Slope returns the metric name of the per-second slope of metric
+func Slope(metric string) string {
+	return DerivativeSlope + "(" + metric + ")"
+}
+
+// Change returns the metric name of the relative change of metric
+func Change(metric string) string {
+	return DerivativeChange + "(" + metric + ")"
+}
+
+// lookupMetric returns the definition of a metric or a derivative of one
+func lookupMetric(name string) (metricDef, bool) {
+	if def, ok := metricDefs[name]; ok {
+		return def, true
+	}
+
+	fn, rest, ok := strings.Cut(name, "(")
+	inner, ok2 := strings.CutSuffix(rest, ")")
+	if !ok || !ok2 {
+		return metricDef{}, false
+	}
+	innerDef, ok := metricDefs[inner]
+	if !ok || innerDef.event {
+		return metricDef{}, false
+	}
+
+	switch fn {
+	case DerivativeSlope:
+		return metricDef{innerDef.alertType, innerDef.unit, false, true, true, func(w *window) (float64, bool) {
+			fit, ok := w.fit(innerDef)
+			return fit.slope, ok
+		}}, true
+	case DerivativeChange:
+		return metricDef{innerDef.alertType, unitFraction, false, false, true, func(w *window) (float64, bool) {
+			fit, ok := w.fit(innerDef)
+			if !ok {
+				return 0, false
+			}
+			start, end := fit.at(fit.start), fit.at(fit.end)
+			if start <= 0 {
+				return 0, false
+			}
+			return end/start - 1, true
+		}}, true
+	}
+	return metricDef{}, false
+}
+
+// line is a least-squares fit y = slope*x + intercept over x in [start, end]
+type line struct {
+	slope, intercept float64
+	start, end       float64
+}
+
+func (l line) at(x float64) float64 {
+	return l.slope*x + l.intercept
+}
+
+// fit fits a line through the metric's values at each sample of the
+// lookback after the first, each computed since the sample before it, with x
+// in seconds since the base. There is no fit with fewer than two values.
+func (w *window) fit(def metricDef) (line, bool) {
+	if len(w.samples) < 3 {
+		return line{}, false
+	}
+
+	xs := make([]float64, 0, len(w.samples)-1)
+	ys := make([]float64, 0, len(w.samples)-1)
+	for i := 1; i < len(w.samples); i++ {
+		y, ok := def.value(&window{latest: w.samples[i], base: w.samples[i-1], events: w.events})
+		if !ok {
+			continue
+		}
+		xs = append(xs, w.samples[i].Timestamp.Sub(w.samples[0].Timestamp).Seconds())
+		ys = append(ys, y)
+	}
+	if len(xs) < 2 {
+		return line{}, false
+	}
+
+	var meanX, meanY float64
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX /= float64(len(xs))
+	meanY /= float64(len(ys))
+
+	var sxx, sxy float64
+	for i := range xs {
+		dx := xs[i] - meanX
+		sxx += dx * dx
+		sxy += dx * (ys[i] - meanY)
+	}
+	if sxx == 0 {
+		return line{}, false
+	}
+
+	l := line{slope: sxy / sxx, start: xs[0], end: xs[len(xs)-1]}
+	l.intercept = meanY - l.slope*meanX
+	return l, true
+}
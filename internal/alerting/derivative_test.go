@@ -0,0 +1,47 @@
+package alerting
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestEngine_Derivatives(t *testing.T) {
+	tests := []struct {
+		expr  string
+		value float64
+	}{
+		// The heap grows 1 MB per one-second sample
+		{"slope(heap_alloc) > 512KB/s over 10s", 1 << 20},
+		// Sample i runs 8+i GCs, so the frequency doubles from 9/s to 18/s
+		// over the first 10 seconds
+		{"change(gc_frequency) > 50% over 10s", 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			e := mustEngine(t, tt.expr)
+
+			var fired []*types.Alert
+			numGC := uint32(0)
+			for i := 0; i <= 12; i++ {
+				if i > 0 {
+					numGC += uint32(8 + i)
+				}
+				alerts := e.ObserveSample(sample(i, numGC, uint64(100+i)<<20))
+				if len(alerts) > 0 && i < 10 {
+					t.Errorf("Fired at sample %d, before the lookback is covered", i)
+				}
+				fired = append(fired, alerts...)
+			}
+
+			if len(fired) != 1 {
+				t.Fatalf("Got %d alerts, want 1", len(fired))
+			}
+			if math.Abs(fired[0].Value-tt.value) > 1e-6*tt.value {
+				t.Errorf("Value = %v, want %v", fired[0].Value, tt.value)
+			}
+		})
+	}
+}
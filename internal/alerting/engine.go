@@ -0,0 +1,260 @@
+package alerting
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// Engine evaluates rules as samples and events arrive. It keeps just enough
+// history to cover the longest rule lookback. It is safe for concurrent use.
+type Engine struct {
+	mu      sync.Mutex
+	rules   []*ruleState
+	samples []*types.GCMetrics
+	events  []*types.GCEvent
+	keep    time.Duration // longest lookback of any rule
+}
+
+// ruleState tracks a rule's progress towards firing
+type ruleState struct {
+	rule   Rule
+	def    metricDef
+	streak int          // consecutive windows the condition has held
+	level  int          // 0 when not firing, 1 firing, 2 firing at the critical threshold
+	fired  *types.Alert // the last alert raised while firing
+}
+
+// NewEngine creates an engine evaluating rules.
+// Returns ErrInvalidAlertRule if a rule is malformed.
+func NewEngine(rules []Rule) (*Engine, error) {
+	e := &Engine{}
+	for _, r := range rules {
+		if err := e.Add(r); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// Add registers a rule. Returns ErrInvalidAlertRule if it is malformed.
+func (e *Engine) Add(r Rule) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	if r.Name == "" {
+		r.Name = r.String()
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	def, _ := lookupMetric(r.Metric)
+	e.rules = append(e.rules, &ruleState{rule: r, def: def})
+	e.keep = max(e.keep, r.Over)
+	for _, c := range r.And {
+		e.keep = max(e.keep, c.Over)
+	}
+	return nil
+}
+
+// Rules returns the registered rules
+func (e *Engine) Rules() []Rule {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	rules := make([]Rule, len(e.rules))
+	for i, s := range e.rules {
+		rules[i] = s.rule
+	}
+	return rules
+}
+
+// ObserveSample evaluates the sample metric rules against a new sample,
+// returning the alerts raised and resolved. Rules on the skipped metrics are not evaluated
+// for this sample and keep their state.
+func (e *Engine) ObserveSample(m *types.GCMetrics, skip ...string) []*types.Alert {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.samples = append(e.samples, m)
+	// Keep the newest sample at least the longest lookback old as the base
+	// of that lookback, and the previous sample for the default window
+	for len(e.samples) > 2 && m.Timestamp.Sub(e.samples[1].Timestamp) >= e.keep {
+		e.samples = e.samples[1:]
+	}
+	oldest := e.samples[0].NumGC
+	e.events = slices.DeleteFunc(e.events, func(ev *types.GCEvent) bool { return ev.Sequence <= oldest })
+
+	var alerts []*types.Alert
+	for _, s := range e.rules {
+		if s.def.event || slices.Contains(skip, s.rule.Metric) {
+			continue
+		}
+		if a := s.evaluate(e.window(s.rule.Over), e.conditionsHold(&s.rule)); a != nil {
+			a.Metric = m
+			alerts = append(alerts, a)
+		}
+	}
+	return alerts
+}
+
+// ObserveEvent evaluates the event metric rules against a new GC event,
+// returning the alerts raised and resolved. Gap markers are ignored.
+func (e *Engine) ObserveEvent(ev *types.GCEvent) []*types.Alert {
+	if ev.IsGap() {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.samples) == 0 || ev.Sequence > e.samples[0].NumGC {
+		e.events = append(e.events, ev)
+	}
+
+	var alerts []*types.Alert
+	for _, s := range e.rules {
+		if !s.def.event {
+			continue
+		}
+		if a := s.evaluate(&window{event: ev}, true); a != nil {
+			a.Event = ev
+			alerts = append(alerts, a)
+		}
+	}
+	return alerts
+}
+
+// window returns the window of the latest sample with the lookback over,
+// based at the newest sample at least over older, or at the previous sample
+// when over is zero. The base is nil when history is too short.
+func (e *Engine) window(over time.Duration) *window {
+	n := len(e.samples)
+	w := &window{latest: e.samples[n-1], events: e.events}
+	if n < 2 {
+		return w
+	}
+	base := n - 2
+	if over > 0 {
+		latest := w.latest.Timestamp
+		for base >= 0 && latest.Sub(e.samples[base].Timestamp) < over {
+			base--
+		}
+		if base < 0 {
+			return w
+		}
+	}
+	w.base, w.samples = e.samples[base], e.samples[base:]
+	return w
+}
+
+// conditionsHold reports whether a rule's further conditions all hold for
+// the latest sample
+func (e *Engine) conditionsHold(r *Rule) bool {
+	for _, c := range r.And {
+		def, _ := lookupMetric(c.Metric)
+		value, ok := def.value(e.window(cmp.Or(c.Over, r.Over)))
+		if !ok || !crosses(c.Op, value, c.Threshold) {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluate advances the rule's state with one window, returning an alert
+// when it fires, and a resolved alert when a firing rule's condition clears.
+// also reports whether the rule's further conditions hold. Windows without a
+// value clear the condition.
+func (s *ruleState) evaluate(w *window, also bool) *types.Alert {
+	r := &s.rule
+	threshold := r.Threshold
+	if s.fired != nil && r.Clear != 0 {
+		threshold = r.Clear
+	}
+	value, ok := s.def.value(w)
+	if !ok || !also || !r.crosses(value, threshold) {
+		firing := s.fired
+		s.streak, s.level, s.fired = 0, 0, nil
+		if firing == nil {
+			return nil
+		}
+		resolved := *firing
+		resolved.Resolved = true
+		resolved.Value = report(value, s.def.unit)
+		resolved.Timestamp = time.Now()
+		return &resolved
+	}
+
+	s.streak++
+	if s.streak < max(r.For, 1) {
+		return nil
+	}
+
+	level, severity := 1, r.Severity
+	if severity == "" {
+		severity = SeverityWarning
+	}
+	if r.Critical != 0 && r.crosses(value, r.Critical) {
+		level, severity = 2, SeverityCritical
+	}
+	if level <= s.level {
+		return nil
+	}
+	s.level = level
+
+	s.fired = &types.Alert{
+		Type:      cmp.Or(r.Type, s.def.alertType),
+		Severity:  severity,
+		Message:   cmp.Or(r.Message, r.Name),
+		Value:     report(value, s.def.unit),
+		Threshold: report(r.Threshold, s.def.unit),
+		Rule:      r.Name,
+		Timestamp: time.Now(),
+	}
+	alert := *s.fired
+	return &alert
+}
+
+// window is the data a rule is evaluated against: a sample with the base of
+// its lookback, the samples and events in between, or a single event
+type window struct {
+	latest  *types.GCMetrics
+	base    *types.GCMetrics
+	samples []*types.GCMetrics // base to latest
+	events  []*types.GCEvent
+	event   *types.GCEvent
+}
+
+// rate returns the per-second change of a counter over the lookback
+func (w *window) rate(counter func(*types.GCMetrics) float64) (float64, bool) {
+	if w.base == nil {
+		return 0, false
+	}
+	dt := w.latest.Timestamp.Sub(w.base.Timestamp).Seconds()
+	if dt <= 0 {
+		return 0, false
+	}
+	return (counter(w.latest) - counter(w.base)) / dt, true
+}
+
+// pauses applies stat to the sorted pauses of the cycles completed during
+// the lookback; there is no value when none completed
+func (w *window) pauses(stat func(sorted []time.Duration) time.Duration) (float64, bool) {
+	if w.base == nil {
+		return 0, false
+	}
+	var durations []time.Duration
+	for _, ev := range w.events {
+		if ev.Sequence > w.base.NumGC && ev.Sequence <= w.latest.NumGC {
+			durations = append(durations, ev.Duration)
+		}
+	}
+	if len(durations) == 0 {
+		return 0, false
+	}
+	slices.Sort(durations)
+	return stat(durations).Seconds(), true
+}
@@ -0,0 +1,257 @@
+package alerting
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// sample creates a sample i seconds after a fixed start
+func sample(i int, numGC uint32, heap uint64) *types.GCMetrics {
+	return &types.GCMetrics{
+		NumGC:     numGC,
+		HeapAlloc: heap,
+		Timestamp: time.Unix(1700000000, 0).Add(time.Duration(i) * time.Second),
+	}
+}
+
+func mustEngine(t *testing.T, exprs ...string) *Engine {
+	t.Helper()
+	e, err := NewEngine(nil)
+	if err != nil {
+		t.Fatalf("NewEngine() error: %v", err)
+	}
+	for _, expr := range exprs {
+		r, err := ParseRule(expr)
+		if err != nil {
+			t.Fatalf("ParseRule(%q) error: %v", expr, err)
+		}
+		if err := e.Add(r); err != nil {
+			t.Fatalf("Add() error: %v", err)
+		}
+	}
+	return e
+}
+
+func TestEngine_ConsecutiveWindows(t *testing.T) {
+	e := mustEngine(t, "p99_pause > 200ms for 3 consecutive windows")
+
+	var fired, resolved []int
+	for i := 0; i < 10; i++ {
+		pause := 300 * time.Millisecond
+		if i == 4 {
+			pause = time.Millisecond // breaks the streak
+		}
+		e.ObserveEvent(&types.GCEvent{Sequence: uint32(i + 1), Duration: pause})
+		for _, a := range e.ObserveSample(sample(i, uint32(i+1), 0)) {
+			if a.Resolved {
+				resolved = append(resolved, i)
+				continue
+			}
+			if a.Type != "pause" || a.Value != 300 || a.Threshold != 200 || a.Metric == nil {
+				t.Errorf("Unexpected alert %+v", a)
+			}
+			fired = append(fired, i)
+		}
+	}
+
+	// Sample 0 has no window; 1-3 hold, 4 clears, 5-7 hold again
+	if len(fired) != 2 || fired[0] != 3 || fired[1] != 7 {
+		t.Errorf("Rule fired at samples %v, want [3 7]", fired)
+	}
+	if len(resolved) != 1 || resolved[0] != 4 {
+		t.Errorf("Rule resolved at samples %v, want [4]", resolved)
+	}
+}
+
+func TestEngine_Lookback(t *testing.T) {
+	e := mustEngine(t, "heap_growth_rate > 5MB/s over 5s")
+
+	// 1 MB/s for 10s, then 10 MB/s
+	heap := uint64(0)
+	var firedAt []int
+	for i := 0; i < 20; i++ {
+		if i < 10 {
+			heap += 1 << 20
+		} else {
+			heap += 10 << 20
+		}
+		if alerts := e.ObserveSample(sample(i, 0, heap)); len(alerts) > 0 {
+			firedAt = append(firedAt, i)
+		}
+	}
+
+	// The 5s average first exceeds 5 MB/s at sample 12: (2*1 + 3*10)/5 = 6.4
+	if len(firedAt) != 1 || firedAt[0] != 12 {
+		t.Errorf("Rule fired at %v, want once at 12", firedAt)
+	}
+	if len(e.samples) > 7 {
+		t.Errorf("Engine keeps %d samples for a 5s lookback", len(e.samples))
+	}
+}
+
+func TestEngine_CriticalEscalation(t *testing.T) {
+	e, err := NewEngine(DefaultRules())
+	if err != nil {
+		t.Fatalf("NewEngine() error: %v", err)
+	}
+
+	var severities []string
+	for i, pause := range []time.Duration{200, 300, 700, 800, 10, 900} {
+		for _, a := range e.ObserveEvent(&types.GCEvent{Sequence: uint32(i + 1), Duration: pause * time.Millisecond}) {
+			if a.Event == nil || a.Type != "pause" {
+				t.Errorf("Unexpected alert %+v", a)
+			}
+			if a.Resolved {
+				severities = append(severities, "resolved "+a.Severity)
+			} else {
+				severities = append(severities, a.Severity)
+			}
+		}
+	}
+
+	want := []string{"warning", "critical", "resolved critical", "critical"}
+	if len(severities) != len(want) {
+		t.Fatalf("Alerts = %v, want %v", severities, want)
+	}
+	for i := range want {
+		if severities[i] != want[i] {
+			t.Errorf("Alerts = %v, want %v", severities, want)
+		}
+	}
+}
+
+func TestEngine_Hysteresis(t *testing.T) {
+	e := mustEngine(t, "gc_cpu_fraction > 25% clear 20%")
+
+	var transitions []string
+	for i, cpu := range []float64{0.26, 0.24, 0.22, 0.26, 0.19, 0.24, 0.26} {
+		m := sample(i, uint32(i+1), 0)
+		m.GCCPUFraction = cpu
+		for _, a := range e.ObserveSample(m) {
+			if a.Resolved {
+				transitions = append(transitions, "resolved")
+			} else {
+				transitions = append(transitions, "fired")
+			}
+		}
+	}
+
+	// Values between 20% and 25% neither clear a firing rule nor fire it
+	want := []string{"fired", "resolved", "fired"}
+	if len(transitions) != len(want) {
+		t.Fatalf("Transitions = %v, want %v", transitions, want)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("Transitions = %v, want %v", transitions, want)
+		}
+	}
+}
+
+func TestEngine_Composite(t *testing.T) {
+	e := mustEngine(t, "gc_frequency > 5/s and gc_cpu_fraction > 10% and heap_growth_rate > 0B/s")
+
+	type step struct {
+		gcs  uint32 // GCs since the previous sample
+		cpu  float64
+		heap uint64
+	}
+	var fired []int
+	numGC := uint32(0)
+	for i, st := range []step{
+		{0, 0, 100},
+		{10, 0.2, 100},  // heap flat
+		{10, 0.05, 200}, // overhead low
+		{2, 0.2, 300},   // frequency low
+		{10, 0.2, 400},  // all hold
+	} {
+		numGC += st.gcs
+		m := sample(i, numGC, st.heap)
+		m.GCCPUFraction = st.cpu
+		for _, a := range e.ObserveSample(m) {
+			if a.Resolved {
+				continue
+			}
+			if a.Type != "frequency" || a.Value != 10 || a.Threshold != 5 {
+				t.Errorf("Unexpected alert %+v", a)
+			}
+			fired = append(fired, i)
+		}
+	}
+
+	if len(fired) != 1 || fired[0] != 4 {
+		t.Errorf("Fired at samples %v, want only 4 where every condition holds", fired)
+	}
+}
+
+func TestEngine_Skip(t *testing.T) {
+	e, _ := NewEngine(DefaultRules())
+	m := sample(0, 1, 0)
+	m.GCCPUFraction = 0.5
+
+	if alerts := e.ObserveSample(m, MetricGCCPUFraction); len(alerts) != 0 {
+		t.Errorf("Skipped rule raised %+v", alerts)
+	}
+	alerts := e.ObserveSample(m)
+	if len(alerts) != 1 || alerts[0].Type != "overhead" || alerts[0].Value != 50 || alerts[0].Threshold != 25 {
+		t.Errorf("Expected one overhead alert at 50%%, got %+v", alerts)
+	}
+}
+
+func TestEngine_GapMarkersIgnored(t *testing.T) {
+	e, _ := NewEngine(DefaultRules())
+	if alerts := e.ObserveEvent(&types.GCEvent{Sequence: 1, Missed: 300}); alerts != nil {
+		t.Errorf("Gap marker raised %+v", alerts)
+	}
+}
+
+func TestDefaultRules_FrequencyAndHeapGrowth(t *testing.T) {
+	e, _ := NewEngine(DefaultRules())
+
+	// 20 GCs/s and 20 MB/s of heap growth, one sample per second
+	var fired []string
+	for i := 0; i <= 90; i++ {
+		for _, a := range e.ObserveSample(sample(i, uint32(20*i), uint64(i)*20*1024*1024)) {
+			if !a.Resolved {
+				fired = append(fired, fmt.Sprintf("%s@%d", a.Type, i))
+			}
+		}
+	}
+
+	// Neither rule has a value until a full window of history exists
+	want := []string{"frequency@60", "memory@60"}
+	if !slices.Equal(fired, want) {
+		t.Errorf("Fired %v, want %v", fired, want)
+	}
+}
+
+func TestDefaultRules_SawtoothHeap(t *testing.T) {
+	e, _ := NewEngine(DefaultRules())
+
+	// A 1 GB live set at GOGC=100: HeapAlloc climbs to 2 GB and drops back
+	// with a GC every 7 seconds, while the live heap stays put
+	const live = 1 << 30
+	for i := 0; i <= 180; i++ {
+		m := sample(i, uint32(i/7), live+uint64(i%7)*live/6)
+		m.HeapLive = live
+		if alerts := e.ObserveSample(m); len(alerts) != 0 {
+			t.Fatalf("Sawtooth heap raised %+v at sample %d", alerts[0], i)
+		}
+	}
+}
+
+func TestDefaultRules_SteadyState(t *testing.T) {
+	e, _ := NewEngine(DefaultRules())
+
+	// 1 GC/s with a heap oscillating around 100 MB
+	for i := 0; i <= 120; i++ {
+		heap := uint64(100+10*(i%2)) * 1024 * 1024
+		if alerts := e.ObserveSample(sample(i, uint32(i), heap)); len(alerts) != 0 {
+			t.Fatalf("Steady state raised %+v at sample %d", alerts, i)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package alerting
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// History retains the most recent alerts raised, marking them resolved as
+// their resolutions arrive. It is safe for concurrent use.
+type History struct {
+	mu      sync.Mutex
+	size    int
+	records []*types.AlertRecord // oldest first
+}
+
+// NewHistory creates a history retaining up to size alerts, at least one
+func NewHistory(size int) *History {
+	return &History{size: max(size, 1)}
+}
+
+// Record adds a raised alert, or marks the active alerts a resolution
+// resolves: those with the same rule, type and message, which covers every
+// severity a firing condition escalated through
+func (h *History) Record(alert *types.Alert) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if alert.Resolved {
+		resolvedAt := alert.Timestamp
+		for _, r := range h.records {
+			if r.Active() && r.Alert.Rule == alert.Rule && r.Alert.Type == alert.Type && r.Alert.Message == alert.Message {
+				r.ResolvedAt = &resolvedAt
+			}
+		}
+		return
+	}
+
+	if len(h.records) >= h.size {
+		n := copy(h.records, h.records[len(h.records)-h.size+1:])
+		clear(h.records[n:])
+		h.records = h.records[:n]
+	}
+	h.records = append(h.records, &types.AlertRecord{Alert: alert})
+}
+
+// Since returns copies of the alerts raised at or after since, oldest first
+func (h *History) Since(since time.Time) []*types.AlertRecord {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var records []*types.AlertRecord
+	for _, r := range h.records {
+		if r.Alert.Timestamp.Before(since) {
+			continue
+		}
+		record := *r
+		records = append(records, &record)
+	}
+	return records
+}
@@ -0,0 +1,58 @@
+package alerting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestHistory_RecordAndResolve(t *testing.T) {
+	h := NewHistory(10)
+	start := time.Unix(1700000000, 0)
+	alert := func(rule, severity string, at time.Duration, resolved bool) *types.Alert {
+		return &types.Alert{Type: "pause", Severity: severity, Message: "Long pause", Rule: rule, Timestamp: start.Add(at), Resolved: resolved}
+	}
+
+	h.Record(alert("pause > 100ms", "warning", 0, false))
+	h.Record(alert("pause > 100ms", "critical", time.Second, false))
+	h.Record(alert("p99_pause > 50ms", "warning", 2*time.Second, false))
+	h.Record(alert("pause > 100ms", "critical", 3*time.Second, true))
+
+	records := h.Since(time.Time{})
+	if len(records) != 3 {
+		t.Fatalf("Got %d records, want 3", len(records))
+	}
+	// The resolution covers both severities of the escalated rule
+	for i, wantActive := range []bool{false, false, true} {
+		if records[i].Active() != wantActive {
+			t.Errorf("Record %d active = %v, want %v", i, records[i].Active(), wantActive)
+		}
+	}
+	if at := records[0].ResolvedAt; at == nil || !at.Equal(start.Add(3*time.Second)) {
+		t.Errorf("ResolvedAt = %v", at)
+	}
+
+	if got := h.Since(start.Add(time.Second)); len(got) != 2 || got[0].Alert.Severity != "critical" {
+		t.Errorf("Since() = %d records, want the last 2", len(got))
+	}
+
+	// Returned records are copies
+	records[2].ResolvedAt = &start
+	if !h.Since(time.Time{})[2].Active() {
+		t.Error("Modifying a returned record changed the history")
+	}
+}
+
+func TestHistory_Bounded(t *testing.T) {
+	h := NewHistory(3)
+	start := time.Unix(1700000000, 0)
+	for i := 0; i < 5; i++ {
+		h.Record(&types.Alert{Type: "memory", Value: float64(i), Timestamp: start.Add(time.Duration(i) * time.Second)})
+	}
+
+	records := h.Since(time.Time{})
+	if len(records) != 3 || records[0].Alert.Value != 2 || records[2].Alert.Value != 4 {
+		t.Errorf("Expected the 3 newest alerts, got %d", len(records))
+	}
+}
@@ -0,0 +1,73 @@
+package alerting
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// Level maps an alert severity or health status to a log level: critical
+// to Error, warning to Warn, and anything else to Info
+func Level(severity string) slog.Level {
+	switch types.Severity(severity) {
+	case types.SeverityCritical:
+		return slog.LevelError
+	case types.SeverityWarning:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// LogAlert writes a record for alert, at the level of its severity, or Info
+// once it has resolved
+func LogAlert(logger *slog.Logger, alert *types.Alert) {
+	level := Level(alert.Severity)
+	if alert.Resolved {
+		level = slog.LevelInfo
+	}
+
+	attrs := []slog.Attr{
+		slog.String("type", alert.Type),
+		slog.String("severity", alert.Severity),
+		slog.Float64("value", alert.Value),
+		slog.Float64("threshold", alert.Threshold),
+	}
+	if alert.Rule != "" {
+		attrs = append(attrs, slog.String("rule", alert.Rule))
+	}
+	if alert.Resolved {
+		attrs = append(attrs, slog.Bool("resolved", true))
+	}
+	if len(alert.Labels) > 0 {
+		attrs = append(attrs, labelsAttr(alert.Labels))
+	}
+	logger.LogAttrs(context.Background(), level, alert.Message, attrs...)
+}
+
+// LogHealthTransition writes a record for a change of health status from
+// previous, at the level of the new status
+func LogHealthTransition(logger *slog.Logger, previous string, status *types.HealthCheckStatus, labels map[string]string) {
+	attrs := []slog.Attr{
+		slog.String("status", status.Status),
+		slog.String("previous", previous),
+		slog.Int("score", status.Score),
+		slog.String("summary", status.Summary),
+	}
+	if len(labels) > 0 {
+		attrs = append(attrs, labelsAttr(labels))
+	}
+	logger.LogAttrs(context.Background(), Level(status.Status), "GC health status changed", attrs...)
+}
+
+// labelsAttr groups labels under "labels", ordered by name
+func labelsAttr(labels map[string]string) slog.Attr {
+	args := make([]any, 0, len(labels))
+	for _, name := range slices.Sorted(maps.Keys(labels)) {
+		args = append(args, slog.String(name, labels[name]))
+	}
+	return slog.Group("labels", args...)
+}
@@ -0,0 +1,92 @@
+package alerting
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// logRecords decodes the JSON records written by a slog.JSONHandler
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var record map[string]any
+		if err := dec.Decode(&record); err != nil {
+			t.Fatalf("Invalid log record: %v", err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLevel(t *testing.T) {
+	for severity, want := range map[string]slog.Level{
+		"critical": slog.LevelError,
+		"warning":  slog.LevelWarn,
+		"info":     slog.LevelInfo,
+		"healthy":  slog.LevelInfo,
+	} {
+		if got := Level(severity); got != want {
+			t.Errorf("Level(%q) = %v, want %v", severity, got, want)
+		}
+	}
+}
+
+func TestLogAlert(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	alert := &types.Alert{
+		Type:      "pause",
+		Severity:  "critical",
+		Message:   "GC pause too long",
+		Value:     150,
+		Threshold: 100,
+		Rule:      "pause > 100ms",
+		Labels:    map[string]string{"service": "checkout"},
+	}
+	LogAlert(logger, alert)
+	resolved := *alert
+	resolved.Resolved = true
+	LogAlert(logger, &resolved)
+
+	records := logRecords(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	raised := records[0]
+	if raised["level"] != "ERROR" || raised["msg"] != "GC pause too long" || raised["type"] != "pause" ||
+		raised["rule"] != "pause > 100ms" || raised["value"] != 150.0 {
+		t.Errorf("Unexpected alert record: %v", raised)
+	}
+	if labels, _ := raised["labels"].(map[string]any); labels["service"] != "checkout" {
+		t.Errorf("Alert record labels = %v", raised["labels"])
+	}
+	if records[1]["level"] != "INFO" || records[1]["resolved"] != true {
+		t.Errorf("Resolution should be logged at INFO with resolved=true, got %v", records[1])
+	}
+}
+
+func TestLogHealthTransition(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	LogHealthTransition(logger, "healthy", &types.HealthCheckStatus{Status: "warning", Score: 65, Summary: "GC health needs attention"}, nil)
+
+	records := logRecords(t, &buf)
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	r := records[0]
+	if r["level"] != "WARN" || r["status"] != "warning" || r["previous"] != "healthy" || r["score"] != 65.0 {
+		t.Errorf("Unexpected health record: %v", r)
+	}
+	if _, ok := r["labels"]; ok {
+		t.Error("Records without labels should have no labels group")
+	}
+}
@@ -0,0 +1,202 @@
+package alerting
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// ParseRule parses a rule expression of the form
+//
+//	<metric> <op> <threshold> [critical <threshold>] [clear <threshold>] [over <duration>]
+//		[and <metric> <op> <threshold> [over <duration>]]... [for <n> [consecutive] [windows|events]]
+//
+// e.g. "p99_pause > 200ms for 3 consecutive windows",
+// "gc_cpu_fraction > 25% clear 20%", "heap_growth_rate > 5MB/s over 5m" or
+// "gc_frequency > 5/s and gc_cpu_fraction > 10% and heap_growth_rate > 0B/s over 1m".
+// Thresholds take a unit matching the metric: durations ("200ms"), byte
+// sizes ("512MB", 1024-based), byte rates ("5MB/s"), percentages ("25%") or
+// plain numbers. The expression becomes the rule's name.
+func ParseRule(expr string) (Rule, error) {
+	fields := strings.Fields(expr)
+	clauses := [][]string{nil}
+	for _, f := range fields {
+		if f == "and" {
+			clauses = append(clauses, nil)
+			continue
+		}
+		clauses[len(clauses)-1] = append(clauses[len(clauses)-1], f)
+	}
+
+	first := clauses[0]
+	if len(first) < 3 {
+		return Rule{}, fmt.Errorf("%w: %q: want <metric> <op> <threshold>", types.ErrInvalidAlertRule, expr)
+	}
+
+	r := Rule{Name: strings.Join(fields, " "), Metric: first[0], Op: first[1]}
+	def, ok := lookupMetric(r.Metric)
+	if !ok {
+		return Rule{}, fmt.Errorf("%w: unknown metric %q", types.ErrInvalidAlertRule, r.Metric)
+	}
+
+	var err error
+	if r.Threshold, err = def.parse(first[2]); err != nil {
+		return Rule{}, err
+	}
+
+	err = parseOptions(first[3:], func(keyword, value string) (err error) {
+		switch keyword {
+		case "critical":
+			r.Critical, err = def.parse(value)
+		case "clear":
+			r.Clear, err = def.parse(value)
+		case "over":
+			r.Over, err = parseLookback(value)
+		case "for":
+			r.For, err = parseCount(value)
+		default:
+			err = fmt.Errorf("%w: unexpected %q", types.ErrInvalidAlertRule, keyword)
+		}
+		return err
+	})
+	if err != nil {
+		return Rule{}, err
+	}
+
+	for _, clause := range clauses[1:] {
+		if len(clause) < 3 {
+			return Rule{}, fmt.Errorf("%w: %q: want <metric> <op> <threshold> after and", types.ErrInvalidAlertRule, expr)
+		}
+		c := Condition{Metric: clause[0], Op: clause[1]}
+		def, ok := lookupMetric(c.Metric)
+		if !ok {
+			return Rule{}, fmt.Errorf("%w: unknown metric %q", types.ErrInvalidAlertRule, c.Metric)
+		}
+		if c.Threshold, err = def.parse(clause[2]); err != nil {
+			return Rule{}, err
+		}
+
+		err = parseOptions(clause[3:], func(keyword, value string) (err error) {
+			switch keyword {
+			case "over":
+				c.Over, err = parseLookback(value)
+			case "for":
+				r.For, err = parseCount(value)
+			default:
+				err = fmt.Errorf("%w: unexpected %q", types.ErrInvalidAlertRule, keyword)
+			}
+			return err
+		})
+		if err != nil {
+			return Rule{}, err
+		}
+		r.And = append(r.And, c)
+	}
+
+	if err := r.Validate(); err != nil {
+		return Rule{}, err
+	}
+	return r, nil
+}
+
+// parseOptions passes each keyword and its value to option, skipping the
+// optional words after a window count
+func parseOptions(rest []string, option func(keyword, value string) error) error {
+	for len(rest) > 0 {
+		if len(rest) < 2 {
+			return fmt.Errorf("%w: %q is missing a value", types.ErrInvalidAlertRule, rest[0])
+		}
+		keyword, value := rest[0], rest[1]
+		rest = rest[2:]
+		if err := option(keyword, value); err != nil {
+			return err
+		}
+
+		if keyword == "for" {
+			if len(rest) > 0 && rest[0] == "consecutive" {
+				rest = rest[1:]
+			}
+			if len(rest) > 0 && (rest[0] == "windows" || rest[0] == "events") {
+				rest = rest[1:]
+			}
+		}
+	}
+	return nil
+}
+
+// parseLookback parses a positive duration
+func parseLookback(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%w: invalid lookback %q", types.ErrInvalidAlertRule, s)
+	}
+	return d, nil
+}
+
+// parseCount parses a positive window count
+func parseCount(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%w: invalid window count %q", types.ErrInvalidAlertRule, s)
+	}
+	return n, nil
+}
+
+// parseValue parses a threshold written in the notation for unit u, returning
+// it in the metric's base unit
+func parseValue(s string, u unit) (float64, error) {
+	invalid := fmt.Errorf("%w: invalid threshold %q", types.ErrInvalidAlertRule, s)
+	switch u {
+	case unitFraction:
+		if pct, ok := strings.CutSuffix(s, "%"); ok {
+			v, err := parseNumber(pct)
+			if err != nil {
+				return 0, invalid
+			}
+			return v / 100, nil
+		}
+	case unitSeconds:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, invalid
+		}
+		return d.Seconds(), nil
+	case unitBytes, unitByteRate:
+		if u == unitByteRate {
+			var ok bool
+			if s, ok = strings.CutSuffix(s, "/s"); !ok {
+				return 0, invalid
+			}
+		}
+		for _, bs := range byteSuffixes {
+			if num, ok := strings.CutSuffix(s, bs.suffix); ok {
+				v, err := parseNumber(num)
+				if err != nil {
+					return 0, invalid
+				}
+				return v * bs.size, nil
+			}
+		}
+	case unitRate:
+		s = strings.TrimSuffix(s, "/s")
+	}
+
+	v, err := parseNumber(s)
+	if err != nil {
+		return 0, invalid
+	}
+	return v, nil
+}
+
+// parseNumber parses a finite decimal number
+func parseNumber(s string) (float64, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, strconv.ErrSyntax
+	}
+	return v, nil
+}
@@ -0,0 +1,139 @@
+package alerting
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		expr string
+		want Rule
+	}{
+		{
+			"p99_pause > 200ms for 3 consecutive windows",
+			Rule{Metric: MetricP99Pause, Op: OpGreater, Threshold: 0.2, For: 3},
+		},
+		{
+			"heap_growth_rate > 5MB/s over 5m",
+			Rule{Metric: MetricHeapGrowthRate, Op: OpGreater, Threshold: 5 << 20, Over: 5 * time.Minute},
+		},
+		{
+			"pause >= 100ms critical 500ms",
+			Rule{Metric: MetricPause, Op: OpGreaterEqual, Threshold: 0.1, Critical: 0.5},
+		},
+		{
+			"gc_cpu_fraction > 25%",
+			Rule{Metric: MetricGCCPUFraction, Op: OpGreater, Threshold: 0.25},
+		},
+		{
+			"gc_cpu_fraction > 25% clear 20%",
+			Rule{Metric: MetricGCCPUFraction, Op: OpGreater, Threshold: 0.25, Clear: 0.2},
+		},
+		{
+			"heap_alloc <= 1.5GB for 2",
+			Rule{Metric: MetricHeapAlloc, Op: OpLessEqual, Threshold: 1.5 * (1 << 30), For: 2},
+		},
+		{
+			"gc_frequency > 10/s",
+			Rule{Metric: MetricGCFrequency, Op: OpGreater, Threshold: 10},
+		},
+		{
+			"change(gc_frequency) >= 100% over 5m",
+			Rule{Metric: Change(MetricGCFrequency), Op: OpGreaterEqual, Threshold: 1, Over: 5 * time.Minute},
+		},
+		{
+			"slope(heap_alloc) > 5MB/s over 10m",
+			Rule{Metric: Slope(MetricHeapAlloc), Op: OpGreater, Threshold: 5 << 20, Over: 10 * time.Minute},
+		},
+		{
+			"slope(gc_frequency) > 0.1/s/s over 1m",
+			Rule{Metric: Slope(MetricGCFrequency), Op: OpGreater, Threshold: 0.1, Over: time.Minute},
+		},
+		{
+			"gc_cpu_fraction > 10% and change(gc_frequency) > 50% over 5m",
+			Rule{
+				Metric: MetricGCCPUFraction, Op: OpGreater, Threshold: 0.1,
+				And: []Condition{{Metric: Change(MetricGCFrequency), Op: OpGreater, Threshold: 0.5, Over: 5 * time.Minute}},
+			},
+		},
+		{
+			"gc_frequency > 5/s over 1m and gc_cpu_fraction > 10% and heap_growth_rate > 0B/s over 5m for 2 windows",
+			Rule{
+				Metric: MetricGCFrequency, Op: OpGreater, Threshold: 5, Over: time.Minute, For: 2,
+				And: []Condition{
+					{Metric: MetricGCCPUFraction, Op: OpGreater, Threshold: 0.1},
+					{Metric: MetricHeapGrowthRate, Op: OpGreater, Threshold: 0, Over: 5 * time.Minute},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseRule(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseRule() error: %v", err)
+			}
+			tt.want.Name = tt.expr
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRule() = %+v, want %+v", got, tt.want)
+			}
+
+			// String round-trips through the parser
+			again, err := ParseRule(got.String())
+			if err != nil {
+				t.Fatalf("ParseRule(%q) error: %v", got.String(), err)
+			}
+			again.Name = got.Name
+			if !reflect.DeepEqual(again, got) {
+				t.Errorf("Round trip via %q = %+v, want %+v", got.String(), again, got)
+			}
+		})
+	}
+}
+
+func TestParseRule_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"p99_pause >",
+		"latency > 1s",
+		"p99_pause ~ 1s",
+		"p99_pause > 200",
+		"heap_growth_rate > 5MB",
+		"gc_cpu_fraction > NaN",
+		"pause > 500ms critical 100ms",
+		"heap_alloc < 1GB clear 512MB",
+		"p99_pause > 1s for",
+		"p99_pause > 1s for 0",
+		"p99_pause > 1s over -5m",
+		"p99_pause > 1s until 5m",
+		"gc_frequency > 5/s and",
+		"gc_frequency > 5/s and gc_cpu_fraction > 10% critical 20%",
+		"gc_frequency > 5/s and pause > 100ms",
+		"pause > 100ms and gc_frequency > 5/s",
+		"gc_frequency > 5/s and latency > 1s",
+		"slope(heap_alloc) > 5MB/s",
+		"slope(heap_alloc) > 5MB over 1m",
+		"slope(pause) > 1ms/s over 1m",
+		"slope(slope(heap_alloc)) > 1MB/s/s over 1m",
+		"rate(heap_alloc) > 5MB/s over 1m",
+		"gc_cpu_fraction > 10% and change(gc_frequency) > 50%",
+	} {
+		if _, err := ParseRule(expr); !errors.Is(err, types.ErrInvalidAlertRule) {
+			t.Errorf("ParseRule(%q) error = %v, want ErrInvalidAlertRule", expr, err)
+		}
+	}
+}
+
+func TestDefaultRules_Valid(t *testing.T) {
+	for _, r := range DefaultRules() {
+		if err := r.Validate(); err != nil {
+			t.Errorf("Default rule %s: %v", r.String(), err)
+		}
+	}
+}
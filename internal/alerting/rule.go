@@ -0,0 +1,421 @@
+// Package alerting evaluates declarative threshold rules against GC samples
+// and events, producing alerts.
+package alerting
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// Metrics rules can be written against. Sample metrics are evaluated once
+// per sample; event metrics once per GC event.
+const (
+	MetricGCCPUFraction  = "gc_cpu_fraction"  // fraction of CPU spent in GC since start, 0-1
+	MetricGCFrequency    = "gc_frequency"     // GCs per second
+	MetricHeapAlloc      = "heap_alloc"       // bytes
+	MetricHeapGrowthRate = "heap_growth_rate" // bytes per second, of the live heap when sampled
+	MetricAllocRate      = "alloc_rate"       // bytes per second
+	MetricAvgPause       = "avg_pause"        // seconds, over the window's events
+	MetricP99Pause       = "p99_pause"        // seconds, over the window's events
+	MetricMaxPause       = "max_pause"        // seconds, over the window's events
+	MetricPause          = "pause"            // seconds; an event metric
+)
+
+// Comparison operators
+const (
+	OpGreater      = ">"
+	OpGreaterEqual = ">="
+	OpLess         = "<"
+	OpLessEqual    = "<="
+)
+
+// Alert severities
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Rule raises an alert when a metric crosses a threshold. Thresholds are in
+// the metric's base unit: seconds, bytes, bytes per second, GCs per second or
+// a 0-1 fraction.
+//
+// A composite rule also requires every condition in And to hold, e.g. a high
+// GC frequency and a high GC CPU overhead at the same time, which is less
+// prone to false positives than either threshold alone.
+//
+// A rule fires once its condition has held for For consecutive windows, and
+// again only after the condition has cleared, or when the value crosses the
+// critical threshold as well. A firing rule resolves once the value no longer
+// crosses Clear, so a value hovering around the threshold does not flap.
+type Rule struct {
+	// Name identifies the rule in alerts (default: the rule's expression)
+	Name string
+
+	Metric    string
+	Op        string
+	Threshold float64
+
+	// Critical, when set, raises the severity to critical once the value
+	// crosses it too; otherwise alerts have Severity
+	Critical float64
+
+	// Clear, when set, is the threshold a firing rule resolves at, short of
+	// Threshold (default: Threshold)
+	Clear float64
+
+	// Severity of alerts below the critical threshold (default: warning)
+	Severity string
+
+	// Over is the lookback that rates and pause statistics are computed
+	// over, ending at the latest sample (default: since the previous sample).
+	// Ignored for event metrics.
+	Over time.Duration
+
+	// And lists further conditions that must hold at the same time. Only
+	// rules on sample metrics can have them.
+	And []Condition
+
+	// For is the number of consecutive windows (samples, or events for event
+	// metrics) the condition must hold before the rule fires (default: 1)
+	For int
+
+	// Type and Message fill in the alert (default: the metric's alert type
+	// and the rule's expression)
+	Type    string
+	Message string
+}
+
+// Condition is a further threshold a composite rule requires
+type Condition struct {
+	Metric    string
+	Op        string
+	Threshold float64
+
+	// Over is the lookback of rates and pause statistics (default: the rule's)
+	Over time.Duration
+}
+
+// unit is how a metric's values are parsed, formatted and reported
+type unit int
+
+const (
+	unitFraction unit = iota // 0-1, reported as a percentage
+	unitSeconds              // reported in milliseconds
+	unitBytes
+	unitByteRate
+	unitRate
+)
+
+// metricDef describes a metric rules can be written against
+type metricDef struct {
+	alertType  string
+	unit       unit
+	event      bool
+	perSecond  bool // values are in unit per second
+	derivative bool // computed over a lookback, which rules must set
+	value      func(w *window) (float64, bool)
+}
+
+// parse parses a threshold in the metric's notation
+func (d *metricDef) parse(s string) (float64, error) {
+	if d.perSecond {
+		var ok bool
+		if s, ok = strings.CutSuffix(s, "/s"); !ok {
+			return 0, fmt.Errorf("%w: invalid threshold %q", types.ErrInvalidAlertRule, s)
+		}
+	}
+	return parseValue(s, d.unit)
+}
+
+// format formats a threshold in the notation parse reads
+func (d *metricDef) format(v float64) string {
+	if d.perSecond {
+		return formatValue(v, d.unit) + "/s"
+	}
+	return formatValue(v, d.unit)
+}
+
+var metricDefs = map[string]metricDef{
+	MetricGCCPUFraction: {"overhead", unitFraction, false, false, false, func(w *window) (float64, bool) {
+		return w.latest.GCCPUFraction, true
+	}},
+	MetricGCFrequency: {"frequency", unitRate, false, false, false, func(w *window) (float64, bool) {
+		return w.rate(func(m *types.GCMetrics) float64 { return float64(m.NumGC) })
+	}},
+	MetricHeapAlloc: {"memory", unitBytes, false, false, false, func(w *window) (float64, bool) {
+		return float64(w.latest.HeapAlloc), true
+	}},
+	MetricHeapGrowthRate: {"memory", unitByteRate, false, false, false, func(w *window) (float64, bool) {
+		// HeapAlloc swings through a whole GC cycle between samples, so its
+		// rate over a lookback is mostly sawtooth phase; the live heap only
+		// moves with the live set
+		if w.base != nil && w.base.HeapLive > 0 && w.latest.HeapLive > 0 {
+			return w.rate(func(m *types.GCMetrics) float64 { return float64(m.HeapLive) })
+		}
+		return w.rate(func(m *types.GCMetrics) float64 { return float64(m.HeapAlloc) })
+	}},
+	MetricAllocRate: {"allocation", unitByteRate, false, false, false, func(w *window) (float64, bool) {
+		return w.rate(func(m *types.GCMetrics) float64 { return float64(m.TotalAlloc) })
+	}},
+	MetricAvgPause: {"pause", unitSeconds, false, false, false, func(w *window) (float64, bool) {
+		return w.pauses(func(p []time.Duration) time.Duration {
+			var total time.Duration
+			for _, d := range p {
+				total += d
+			}
+			return total / time.Duration(len(p))
+		})
+	}},
+	MetricP99Pause: {"pause", unitSeconds, false, false, false, func(w *window) (float64, bool) {
+		return w.pauses(func(p []time.Duration) time.Duration {
+			return p[int(float64(len(p)-1)*0.99)]
+		})
+	}},
+	MetricMaxPause: {"pause", unitSeconds, false, false, false, func(w *window) (float64, bool) {
+		return w.pauses(func(p []time.Duration) time.Duration { return p[len(p)-1] })
+	}},
+	MetricPause: {"pause", unitSeconds, true, false, false, func(w *window) (float64, bool) {
+		return w.event.Duration.Seconds(), true
+	}},
+}
+
+// Metrics returns the names of the metrics rules can be written against,
+// besides their derivatives
+func Metrics() []string {
+	return []string{
+		MetricGCCPUFraction, MetricGCFrequency, MetricHeapAlloc, MetricHeapGrowthRate,
+		MetricAllocRate, MetricAvgPause, MetricP99Pause, MetricMaxPause, MetricPause,
+	}
+}
+
+// Validate checks that the rule is well-formed
+func (r *Rule) Validate() error {
+	def, ok := lookupMetric(r.Metric)
+	if !ok {
+		return fmt.Errorf("%w: unknown metric %q", types.ErrInvalidAlertRule, r.Metric)
+	}
+	if def.derivative && r.Over == 0 {
+		return fmt.Errorf("%w: %s needs a lookback (over)", types.ErrInvalidAlertRule, r.Metric)
+	}
+	if !validOp(r.Op) {
+		return fmt.Errorf("%w: unknown operator %q", types.ErrInvalidAlertRule, r.Op)
+	}
+	for _, v := range []float64{r.Threshold, r.Critical, r.Clear} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("%w: threshold is not a finite number", types.ErrInvalidAlertRule)
+		}
+	}
+	if r.Critical != 0 && !r.crosses(r.Critical, r.Threshold) {
+		return fmt.Errorf("%w: critical threshold must be beyond the threshold", types.ErrInvalidAlertRule)
+	}
+	if r.Clear != 0 && r.Clear != r.Threshold && r.crosses(r.Clear, r.Threshold) {
+		return fmt.Errorf("%w: clear threshold must not be beyond the threshold", types.ErrInvalidAlertRule)
+	}
+	switch r.Severity {
+	case "", SeverityInfo, SeverityWarning, SeverityCritical:
+	default:
+		return fmt.Errorf("%w: unknown severity %q", types.ErrInvalidAlertRule, r.Severity)
+	}
+	if r.Over < 0 || r.For < 0 {
+		return fmt.Errorf("%w: negative window", types.ErrInvalidAlertRule)
+	}
+	if len(r.And) > 0 && def.event {
+		return fmt.Errorf("%w: %s is an event metric; composite rules take sample metrics", types.ErrInvalidAlertRule, r.Metric)
+	}
+	for i := range r.And {
+		if err := r.And[i].Validate(); err != nil {
+			return err
+		}
+		if c := &r.And[i]; c.Over == 0 && r.Over == 0 {
+			if def, _ := lookupMetric(c.Metric); def.derivative {
+				return fmt.Errorf("%w: %s needs a lookback (over)", types.ErrInvalidAlertRule, c.Metric)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks that the condition is well-formed
+func (c *Condition) Validate() error {
+	def, ok := lookupMetric(c.Metric)
+	if !ok {
+		return fmt.Errorf("%w: unknown metric %q", types.ErrInvalidAlertRule, c.Metric)
+	}
+	if def.event {
+		return fmt.Errorf("%w: %s is an event metric; composite rules take sample metrics", types.ErrInvalidAlertRule, c.Metric)
+	}
+	if !validOp(c.Op) {
+		return fmt.Errorf("%w: unknown operator %q", types.ErrInvalidAlertRule, c.Op)
+	}
+	if math.IsNaN(c.Threshold) || math.IsInf(c.Threshold, 0) {
+		return fmt.Errorf("%w: threshold is not a finite number", types.ErrInvalidAlertRule)
+	}
+	if c.Over < 0 {
+		return fmt.Errorf("%w: negative window", types.ErrInvalidAlertRule)
+	}
+	return nil
+}
+
+// validOp reports whether op is a comparison operator
+func validOp(op string) bool {
+	switch op {
+	case OpGreater, OpGreaterEqual, OpLess, OpLessEqual:
+		return true
+	}
+	return false
+}
+
+// crosses reports whether value satisfies the rule's operator against threshold
+func (r *Rule) crosses(value, threshold float64) bool {
+	return crosses(r.Op, value, threshold)
+}
+
+// crosses reports whether value satisfies op against threshold
+func crosses(op string, value, threshold float64) bool {
+	switch op {
+	case OpGreater:
+		return value > threshold
+	case OpGreaterEqual:
+		return value >= threshold
+	case OpLess:
+		return value < threshold
+	default:
+		return value <= threshold
+	}
+}
+
+// String returns the rule as an expression ParseRule accepts
+func (r *Rule) String() string {
+	def, _ := lookupMetric(r.Metric)
+
+	var b strings.Builder
+	b.WriteString(r.Metric)
+	b.WriteByte(' ')
+	b.WriteString(r.Op)
+	b.WriteByte(' ')
+	b.WriteString(def.format(r.Threshold))
+	if r.Critical != 0 {
+		b.WriteString(" critical ")
+		b.WriteString(def.format(r.Critical))
+	}
+	if r.Clear != 0 {
+		b.WriteString(" clear ")
+		b.WriteString(def.format(r.Clear))
+	}
+	if r.Over > 0 {
+		b.WriteString(" over ")
+		b.WriteString(r.Over.String())
+	}
+	for _, c := range r.And {
+		b.WriteString(" and ")
+		b.WriteString(c.Metric)
+		b.WriteByte(' ')
+		b.WriteString(c.Op)
+		b.WriteByte(' ')
+		cdef, _ := lookupMetric(c.Metric)
+		b.WriteString(cdef.format(c.Threshold))
+		if c.Over > 0 {
+			b.WriteString(" over ")
+			b.WriteString(c.Over.String())
+		}
+	}
+	if r.For > 1 {
+		b.WriteString(" for ")
+		b.WriteString(strconv.Itoa(r.For))
+		if def.event {
+			b.WriteString(" events")
+		} else {
+			b.WriteString(" windows")
+		}
+	}
+	return b.String()
+}
+
+// report scales a value to the unit alerts report it in
+func report(v float64, u unit) float64 {
+	switch u {
+	case unitFraction:
+		return v * 100
+	case unitSeconds:
+		return v * 1e3
+	default:
+		return v
+	}
+}
+
+// byteSuffixes are the accepted byte size suffixes, largest first
+var byteSuffixes = []struct {
+	suffix string
+	size   float64
+}{
+	{"TB", 1 << 40}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1},
+}
+
+// formatValue formats a threshold in the notation parseValue reads
+func formatValue(v float64, u unit) string {
+	num := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+	switch u {
+	case unitFraction:
+		return num(v*100) + "%"
+	case unitSeconds:
+		return time.Duration(v * float64(time.Second)).String()
+	case unitBytes, unitByteRate:
+		s := num(v) + "B"
+		for _, bs := range byteSuffixes {
+			if math.Abs(v) >= bs.size {
+				s = num(v/bs.size) + bs.suffix
+				break
+			}
+		}
+		if u == unitByteRate {
+			s += "/s"
+		}
+		return s
+	default:
+		return num(v) + "/s"
+	}
+}
+
+// DefaultRules returns the rules monitors start with: GC CPU overhead above
+// ThresholdGCCPUFractionAlert until it falls to ThresholdGCCPUFractionClear,
+// pauses above ThresholdPauseWarning, critical above ThresholdPauseCritical,
+// and GC frequency above ThresholdGCFrequencyHigh and heap growth above
+// ThresholdHeapGrowthRateHigh, both over the last DefaultAlertWindow
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			Metric:    MetricGCCPUFraction,
+			Op:        OpGreater,
+			Threshold: types.ThresholdGCCPUFractionAlert,
+			Clear:     types.ThresholdGCCPUFractionClear,
+			Message:   "High GC CPU overhead detected",
+		},
+		{
+			Metric:    MetricPause,
+			Op:        OpGreater,
+			Threshold: types.ThresholdPauseWarning.Seconds(),
+			Critical:  types.ThresholdPauseCritical.Seconds(),
+			Message:   "Long GC pause time detected",
+		},
+		{
+			Metric:    MetricGCFrequency,
+			Op:        OpGreater,
+			Threshold: types.ThresholdGCFrequencyHigh,
+			Over:      types.DefaultAlertWindow,
+			Message:   "High GC frequency detected",
+		},
+		{
+			Metric:    MetricHeapGrowthRate,
+			Op:        OpGreater,
+			Threshold: types.ThresholdHeapGrowthRateHigh,
+			Over:      types.DefaultAlertWindow,
+			Message:   "High heap growth rate detected",
+		},
+	}
+}
@@ -0,0 +1,240 @@
+package alerting
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// Burn-rate alerting defaults, after the multiwindow, multi-burn-rate alerts
+// of the Google SRE workbook: a fast burn spends 2% of a 30-day budget in an
+// hour, a slow burn 5% in six hours
+const (
+	DefaultSLOWindow     = time.Minute
+	DefaultFastBurn      = 14.4
+	DefaultFastBurnRange = time.Hour
+	DefaultSlowBurn      = 6
+	DefaultSlowBurnRange = 6 * time.Hour
+
+	// shortRangeDivisor relates the short lookback that must also be
+	// burning, so alerts resolve soon after the burn stops, to the long one
+	shortRangeDivisor = 12
+)
+
+// PauseSLO is a pause time objective: the fraction of windows whose longest
+// GC pause stays below MaxPause, e.g. 99% of one-minute windows below 50ms.
+// The error budget is the remaining fraction; the burn rate is how many times
+// faster than the objective allows it is being spent.
+type PauseSLO struct {
+	// MaxPause is the longest pause a good window may have
+	MaxPause time.Duration
+
+	// Objective is the fraction of windows that must be good, e.g. 0.99
+	Objective float64
+
+	// Window is the length of the windows, aligned to the clock (default: 1m)
+	Window time.Duration
+
+	// FastBurn is the burn rate over FastBurnRange, and over a twelfth of it,
+	// that raises a critical alert (default: 14.4 over 1h)
+	FastBurn      float64
+	FastBurnRange time.Duration
+
+	// SlowBurn is the burn rate over SlowBurnRange, and over a twelfth of it,
+	// that raises a warning (default: 6 over 6h)
+	SlowBurn      float64
+	SlowBurnRange time.Duration
+}
+
+// Validate checks that the objective is well-formed
+func (s *PauseSLO) Validate() error {
+	if s.MaxPause <= 0 {
+		return fmt.Errorf("%w: max pause must be positive", types.ErrInvalidSLO)
+	}
+	if !(s.Objective > 0 && s.Objective < 1) {
+		return fmt.Errorf("%w: objective %v must be between 0 and 1", types.ErrInvalidSLO, s.Objective)
+	}
+	if s.Window < 0 || s.FastBurn < 0 || s.FastBurnRange < 0 || s.SlowBurn < 0 || s.SlowBurnRange < 0 {
+		return fmt.Errorf("%w: negative window or burn rate", types.ErrInvalidSLO)
+	}
+	return nil
+}
+
+// String describes the objective, e.g. "99% of 1m0s windows with max pause < 50ms"
+func (s *PauseSLO) String() string {
+	return strconv.FormatFloat(s.Objective*100, 'f', -1, 64) + "% of " + s.Window.String() +
+		" windows with max pause < " + s.MaxPause.String()
+}
+
+// sloWindow is a closed window
+type sloWindow struct {
+	start time.Time
+	bad   bool
+}
+
+// SLOTracker classifies windows against a pause SLO as samples and GC events
+// arrive and raises burn-rate alerts. It is safe for concurrent use.
+type SLOTracker struct {
+	slo PauseSLO
+
+	mu      sync.Mutex
+	current time.Time                   // start of the open window; zero before the first sample
+	pauses  map[time.Time]time.Duration // window start -> longest pause, for open and later windows
+	windows []sloWindow                 // closed windows within SlowBurnRange, oldest first
+	burning *Conditions
+}
+
+// NewSLOTracker creates a tracker for slo, applying defaults for unset
+// fields. Returns ErrInvalidSLO if slo is malformed.
+func NewSLOTracker(slo PauseSLO) (*SLOTracker, error) {
+	if err := slo.Validate(); err != nil {
+		return nil, err
+	}
+	slo.Window = cmp.Or(slo.Window, DefaultSLOWindow)
+	slo.FastBurn = cmp.Or(slo.FastBurn, DefaultFastBurn)
+	slo.FastBurnRange = cmp.Or(slo.FastBurnRange, DefaultFastBurnRange)
+	slo.SlowBurn = cmp.Or(slo.SlowBurn, DefaultSlowBurn)
+	slo.SlowBurnRange = cmp.Or(slo.SlowBurnRange, DefaultSlowBurnRange)
+	return &SLOTracker{slo: slo, pauses: make(map[time.Time]time.Duration), burning: NewConditions()}, nil
+}
+
+// SLO returns the objective with defaults applied
+func (t *SLOTracker) SLO() PauseSLO {
+	return t.slo
+}
+
+// ObserveEvent records a GC pause in the window it ended in. Gap markers
+// are ignored.
+func (t *SLOTracker) ObserveEvent(ev *types.GCEvent) {
+	if ev.IsGap() {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	start := ev.EndTime.Truncate(t.slo.Window)
+	if start.Before(t.current) {
+		// The window has been closed
+		return
+	}
+	t.pauses[start] = max(t.pauses[start], ev.Duration)
+}
+
+// ObserveSample closes the windows that ended by the sample's timestamp,
+// returning the burn-rate alert to raise when the burn starts, escalates or
+// stops. Windows in which no sample was taken are skipped as having no data.
+func (t *SLOTracker) ObserveSample(m *types.GCMetrics) []*types.Alert {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	start := m.Timestamp.Truncate(t.slo.Window)
+	if !start.After(t.current) {
+		return nil
+	}
+	first := t.current.IsZero()
+	if !first {
+		t.windows = append(t.windows, sloWindow{start: t.current, bad: t.pauses[t.current] >= t.slo.MaxPause})
+	}
+	t.current = start
+	for w := range t.pauses {
+		if w.Before(start) {
+			delete(t.pauses, w)
+		}
+	}
+	if first {
+		return nil
+	}
+
+	oldest := start.Add(-t.slo.SlowBurnRange)
+	for len(t.windows) > 0 && t.windows[0].start.Before(oldest) {
+		t.windows = t.windows[1:]
+	}
+
+	var alert *types.Alert
+	fast, slow := t.burns(t.slo.FastBurnRange, t.slo.FastBurn), t.burns(t.slo.SlowBurnRange, t.slo.SlowBurn)
+	switch {
+	case fast:
+		alert = t.alert(SeverityCritical, "fast", t.slo.FastBurnRange, t.slo.FastBurn, m)
+	case slow:
+		alert = t.alert(SeverityWarning, "slow", t.slo.SlowBurnRange, t.slo.SlowBurn, m)
+	}
+	if a := t.burning.Update("burn", alert); a != nil {
+		if a.Resolved {
+			a.Value, a.Metric = t.burnRate(t.slo.FastBurnRange), m
+		}
+		return []*types.Alert{a}
+	}
+	return nil
+}
+
+// burns reports whether the burn rate exceeds threshold over both the
+// lookback and its short counterpart
+func (t *SLOTracker) burns(lookback time.Duration, threshold float64) bool {
+	return t.burnRate(lookback) >= threshold && t.burnRate(lookback/shortRangeDivisor) >= threshold
+}
+
+// burnRate returns the error budget burn rate over the closed windows within
+// lookback, zero without any
+func (t *SLOTracker) burnRate(lookback time.Duration) float64 {
+	total, bad := t.count(lookback)
+	if total == 0 {
+		return 0
+	}
+	return float64(bad) / float64(total) / (1 - t.slo.Objective)
+}
+
+// count returns the closed windows within lookback of the open one, and how
+// many of them were bad
+func (t *SLOTracker) count(lookback time.Duration) (total, bad int) {
+	oldest := t.current.Add(-max(lookback, t.slo.Window))
+	for i := len(t.windows) - 1; i >= 0 && !t.windows[i].start.Before(oldest); i-- {
+		total++
+		if t.windows[i].bad {
+			bad++
+		}
+	}
+	return total, bad
+}
+
+// alert creates a burn-rate alert
+func (t *SLOTracker) alert(severity, speed string, lookback time.Duration, threshold float64, m *types.GCMetrics) *types.Alert {
+	return &types.Alert{
+		Type:      "slo",
+		Severity:  severity,
+		Message:   "Pause SLO error budget burning " + speed + ": " + t.slo.String(),
+		Value:     t.burnRate(lookback),
+		Threshold: threshold,
+		Rule:      t.slo.String(),
+		Metric:    m,
+		Timestamp: time.Now(),
+	}
+}
+
+// Status reports compliance and burn rates over the closed windows
+func (t *SLOTracker) Status() *types.PauseSLOStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	total, bad := t.count(t.slo.SlowBurnRange)
+	status := &types.PauseSLOStatus{
+		Objective:         t.slo.Objective,
+		MaxPause:          t.slo.MaxPause,
+		Window:            t.slo.Window,
+		Windows:           total,
+		BadWindows:        bad,
+		Compliance:        1,
+		FastBurnRate:      t.burnRate(t.slo.FastBurnRange),
+		FastBurnThreshold: t.slo.FastBurn,
+		SlowBurnRate:      t.burnRate(t.slo.SlowBurnRange),
+		SlowBurnThreshold: t.slo.SlowBurn,
+	}
+	if total > 0 {
+		status.Compliance = 1 - float64(bad)/float64(total)
+		status.BudgetSpent = float64(bad) / (float64(total) * (1 - t.slo.Objective))
+	}
+	return status
+}
@@ -0,0 +1,123 @@
+package alerting
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestPauseSLO_Validate(t *testing.T) {
+	for _, slo := range []PauseSLO{
+		{Objective: 0.99},
+		{MaxPause: 50 * time.Millisecond},
+		{MaxPause: 50 * time.Millisecond, Objective: 1},
+		{MaxPause: 50 * time.Millisecond, Objective: 0.99, Window: -time.Minute},
+	} {
+		if _, err := NewSLOTracker(slo); !errors.Is(err, types.ErrInvalidSLO) {
+			t.Errorf("NewSLOTracker(%+v) = %v, want ErrInvalidSLO", slo, err)
+		}
+	}
+
+	tracker, err := NewSLOTracker(PauseSLO{MaxPause: 50 * time.Millisecond, Objective: 0.99})
+	if err != nil {
+		t.Fatalf("NewSLOTracker() error: %v", err)
+	}
+	slo := tracker.SLO()
+	if slo.Window != DefaultSLOWindow || slo.FastBurn != DefaultFastBurn || slo.SlowBurnRange != DefaultSlowBurnRange {
+		t.Errorf("Defaults not applied: %+v", slo)
+	}
+	if got, want := slo.String(), "99% of 1m0s windows with max pause < 50ms"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestSLOTracker_BurnRate(t *testing.T) {
+	// A 10% budget: half the windows of the last 10m and the latest one bad
+	// burn fast, a fifth of the last hour and of the last 5m burn slowly
+	tracker, err := NewSLOTracker(PauseSLO{
+		MaxPause:      50 * time.Millisecond,
+		Objective:     0.9,
+		FastBurn:      5,
+		FastBurnRange: 10 * time.Minute,
+		SlowBurn:      2,
+		SlowBurnRange: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewSLOTracker() error: %v", err)
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker.ObserveSample(&types.GCMetrics{Timestamp: start})
+
+	type step struct {
+		bad  bool
+		want string // "" for nothing raised
+	}
+	steps := make([]step, 10) // good windows
+	steps = append(steps, []step{
+		{true, ""},
+		{true, ""},
+		{true, "warning"},
+		{true, ""},
+		{true, "critical"},
+		{false, ""}, // no longer burning fast, still slowly
+		{false, ""},
+		{false, ""},
+		{false, ""},
+		{false, "resolved critical"},
+	}...)
+
+	for i, step := range steps {
+		window := start.Add(time.Duration(i) * time.Minute)
+		pause := time.Millisecond
+		if step.bad {
+			pause = 100 * time.Millisecond
+		}
+		tracker.ObserveEvent(&types.GCEvent{EndTime: window.Add(30 * time.Second), Duration: pause})
+
+		got := ""
+		for _, a := range tracker.ObserveSample(&types.GCMetrics{Timestamp: window.Add(time.Minute + time.Second)}) {
+			got = a.Severity
+			if a.Resolved {
+				got = "resolved " + got
+			}
+			if a.Type != "slo" {
+				t.Errorf("Step %d: alert type %q, want slo", i, a.Type)
+			}
+		}
+		if got != step.want {
+			t.Errorf("Step %d: raised %q, want %q", i, got, step.want)
+		}
+	}
+
+	status := tracker.Status()
+	if status.Windows != len(steps) || status.BadWindows != 5 {
+		t.Errorf("Status counted %d/%d bad windows, want 5/%d", status.BadWindows, status.Windows, len(steps))
+	}
+	if want := 1 - 5.0/float64(len(steps)); status.Compliance != want {
+		t.Errorf("Compliance = %v, want %v", status.Compliance, want)
+	}
+	if math.Abs(status.FastBurnRate-5) > 1e-9 {
+		t.Errorf("FastBurnRate = %v, want 5 with half the last 10m bad", status.FastBurnRate)
+	}
+}
+
+func TestSLOTracker_SkipsWindowsWithoutSamples(t *testing.T) {
+	tracker, err := NewSLOTracker(PauseSLO{MaxPause: 50 * time.Millisecond, Objective: 0.99})
+	if err != nil {
+		t.Fatalf("NewSLOTracker() error: %v", err)
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker.ObserveSample(&types.GCMetrics{Timestamp: start})
+	tracker.ObserveSample(&types.GCMetrics{Timestamp: start.Add(30 * time.Second)})
+	// Nothing was sampled for the next ten minutes
+	tracker.ObserveSample(&types.GCMetrics{Timestamp: start.Add(11 * time.Minute)})
+
+	if status := tracker.Status(); status.Windows != 1 || status.Compliance != 1 {
+		t.Errorf("Status = %+v, want one good window", status)
+	}
+}
@@ -2,10 +2,13 @@ package analysis
 
 import (
 	"cmp"
+	"math"
 	"slices"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/i18n"
 	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
 )
 
@@ -42,38 +45,123 @@ func putDurationSlice(s *[]time.Duration) {
 type Analyzer struct {
 	metrics []*types.GCMetrics
 	events  []*types.GCEvent
+	missed  uint32 // cycles covered only by gap markers
+	resets  []types.CounterReset
+	opts    Options
+}
+
+// Options configures optional analysis inputs
+type Options struct {
+	// Runtime is the runtime configuration the metrics were captured under.
+	// It is recorded on the resulting analysis so drift can be detected later.
+	Runtime *types.RuntimeInfo
+
+	// GCTrace holds events parsed from a GODEBUG=gctrace=1 log covering the
+	// same window as the metrics. They are merged with the in-process events by
+	// GC sequence number, preferring gctrace for pause and phase data.
+	GCTrace []*types.GCEvent
+
+	// SizeClasses is the sampled allocation profile grouped by size class,
+	// usually from types.ReadSizeClassDistribution. It drives object reuse
+	// recommendations for the dominant class.
+	SizeClasses *types.SizeClassDistribution
+
+	// AllocSites ranks the code locations allocating the most over the same
+	// window, usually from types.AttributeAllocSites. The allocation rate
+	// recommendation points at the top site.
+	AllocSites *types.AllocSiteReport
+
+	// Regions holds allocations attributed to tagged code regions, usually
+	// from a region tracker. They are recorded on the analysis as is.
+	Regions []types.RegionStats
+
+	// RequestImpact holds the GC impact of individual requests, usually from
+	// a request tracker. It is recorded on the analysis as is.
+	RequestImpact *types.RequestImpact
+
+	// Labels identify the monitored process. They are recorded on the
+	// analysis as is.
+	Labels map[string]string
+
+	// RobustStats adds the median and median absolute deviation of pause
+	// times and heap size to the analysis, which a few extreme values can't
+	// skew the way they skew the averages.
+	RobustStats bool
+
+	// ApdexTarget is the pause satisfying the pause Apdex score; pauses up
+	// to types.ApdexToleratingFactor times it are tolerated. Zero means
+	// types.DefaultPauseApdexTarget.
+	ApdexTarget time.Duration
+
+	// MMUWindows are the window sizes of the minimum mutator utilization
+	// curve. Nil means types.DefaultMMUWindows.
+	MMUWindows []time.Duration
+
+	// Interval is the longest interval the samples were taken at, e.g. the
+	// maximum of an adaptive interval. Sampling gaps are measured against it;
+	// zero measures them against the median interval.
+	Interval time.Duration
 }
 
 // New creates a new analyzer with the provided metrics.
 // Returns an Analyzer that can perform comprehensive GC analysis.
+// Metrics spanning a process restart are stitched into one series; the
+// restarts are listed in GCAnalysis.CounterResets.
 func New(metrics []*types.GCMetrics) *Analyzer {
-	return &Analyzer{
-		metrics: metrics,
-	}
+	a := &Analyzer{}
+	a.metrics, a.resets = stitchResets(metrics)
+	return a
 }
 
 // NewWithEvents creates a new analyzer with metrics and events.
 // Events provide more detailed pause time information for analysis.
+// Gap markers among the events are counted as missed cycles.
 func NewWithEvents(metrics []*types.GCMetrics, events []*types.GCEvent) *Analyzer {
-	return &Analyzer{
-		metrics: metrics,
-		events:  events,
+	a := New(metrics)
+	a.events, a.missed = splitGaps(events)
+	return a
+}
+
+// NewWithOptions creates a new analyzer with metrics, events and options.
+// A nil opts is equivalent to calling NewWithEvents.
+func NewWithOptions(metrics []*types.GCMetrics, events []*types.GCEvent, opts *Options) *Analyzer {
+	a := NewWithEvents(metrics, events)
+	if opts != nil {
+		a.opts = *opts
+		a.events = mergeEvents(metrics, a.events, opts.GCTrace)
 	}
+	return a
+}
+
+// NewFromSource creates an analyzer over the samples and events read from
+// src until it is exhausted. A nil opts is equivalent to calling NewWithEvents.
+func NewFromSource(src types.Source, opts *Options) (*Analyzer, error) {
+	metrics, events, err := types.ReadSource(src)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithOptions(metrics, events, opts), nil
 }
 
 // Analyze performs comprehensive GC analysis
 func (a *Analyzer) Analyze() (*types.GCAnalysis, error) {
 	if len(a.metrics) < 2 {
-		return nil, types.ErrInsufficientData
+		return nil, &types.InsufficientDataError{Op: "analyze", Samples: len(a.metrics), Required: 2}
 	}
 
 	first := a.metrics[0]
 	last := a.metrics[len(a.metrics)-1]
 
 	analysis := &types.GCAnalysis{
-		Period:    last.Timestamp.Sub(first.Timestamp),
-		StartTime: first.Timestamp,
-		EndTime:   last.Timestamp,
+		Period:        last.Timestamp.Sub(first.Timestamp),
+		StartTime:     first.Timestamp,
+		EndTime:       last.Timestamp,
+		Runtime:       a.opts.Runtime,
+		SizeClasses:   a.opts.SizeClasses,
+		AllocSites:    a.opts.AllocSites,
+		Regions:       a.opts.Regions,
+		RequestImpact: a.opts.RequestImpact,
+		Labels:        a.opts.Labels,
 	}
 
 	// Analyze GC frequency
@@ -81,19 +169,86 @@ func (a *Analyzer) Analyze() (*types.GCAnalysis, error) {
 
 	// Analyze pause times
 	a.analyzePauseTimes(analysis)
+	a.analyzePauseHistogram(analysis)
+	a.analyzeLitePauses(analysis)
+	analysis.PauseApdex = a.pauseApdex()
+	analysis.MMU = a.analyzeMMU()
+	analysis.PauseOutliers = a.analyzePauseOutliers()
+	analysis.MissedEvents = a.missed
+	analysis.PauseQuantiles = last.PauseQuantiles
+	analysis.Phases = a.analyzePhases()
 
 	// Analyze memory usage
 	a.analyzeMemoryUsage(analysis)
+	if a.opts.RobustStats {
+		analysis.Robust = a.robustStats()
+	}
 
 	// Analyze allocation patterns
 	a.analyzeAllocations(analysis)
 
 	// Calculate efficiency metrics
 	a.calculateEfficiencyMetrics(analysis)
+	analysis.GCCPU = a.analyzeGCCPU()
+	analysis.ProcessCPU = a.analyzeProcessCPU(analysis.GCCPU, analysis.Period)
+	analysis.RSS = a.analyzeRSS()
+	analysis.Paging = a.analyzePaging()
+
+	// Relate memory use to the container limit and project when it is reached,
+	// and to GOMEMLIMIT
+	a.analyzeMemoryLimit(analysis)
+	analysis.SoftLimit = a.analyzeSoftLimit()
+
+	// Break the window down by application phase label
+	analysis.RegionBreakdown = a.analyzeRegions()
+	analysis.TuningChanges = a.detectTuningChanges()
+	analysis.CounterResets = a.resets
+
+	// Detect periodic workloads, then memory leaks from the post-GC heap floor
+	analysis.Periodicity = a.detectPeriodicity()
+	analysis.LeakDetection = a.detectLeak(analysis.Periodicity)
+	analysis.Reclamation = a.analyzeReclamation()
 
 	// Generate recommendations
 	a.generateRecommendations(analysis)
 
+	// Note data quality problems behind the numbers
+	analysis.Warnings = append(a.sampleWarnings(), pauseWarnings(analysis)...)
+
+	return analysis, nil
+}
+
+// AnalyzeLite computes GC frequency, rates and averages only. It skips the
+// pause-slice scan and percentile sort along with every derived analysis, so
+// its cost is a single pass over the samples; Min, Max, P95 and P99 pause
+// times and recommendations are left unset.
+func (a *Analyzer) AnalyzeLite() (*types.GCAnalysis, error) {
+	if len(a.metrics) < 2 {
+		return nil, &types.InsufficientDataError{Op: "analyze lite", Samples: len(a.metrics), Required: 2}
+	}
+
+	first := a.metrics[0]
+	last := a.metrics[len(a.metrics)-1]
+
+	analysis := &types.GCAnalysis{
+		Period:    last.Timestamp.Sub(first.Timestamp),
+		StartTime: first.Timestamp,
+		EndTime:   last.Timestamp,
+		Runtime:   a.opts.Runtime,
+		Labels:    a.opts.Labels,
+
+		CounterResets: a.resets,
+	}
+
+	a.analyzeGCFrequency(analysis)
+	if gcCount := last.NumGC - first.NumGC; gcCount > 0 && last.PauseTotalNs >= first.PauseTotalNs {
+		analysis.AvgPauseTime = time.Duration(last.PauseTotalNs-first.PauseTotalNs) / time.Duration(gcCount)
+	}
+	a.analyzeMemoryUsage(analysis)
+	a.analyzeAllocations(analysis)
+	a.calculateEfficiencyMetrics(analysis)
+	analysis.Warnings = a.sampleWarnings()
+
 	return analysis, nil
 }
 
@@ -116,6 +271,13 @@ func (a *Analyzer) analyzeGCFrequency(analysis *types.GCAnalysis) {
 	if gcCount > 0 {
 		analysis.AvgGCInterval = analysis.Period / time.Duration(gcCount)
 	}
+
+	if last.NumForcedGC >= first.NumForcedGC {
+		analysis.ForcedGCCount = last.NumForcedGC - first.NumForcedGC
+	}
+	if gcCount > 0 {
+		analysis.ForcedGCRatio = float64(analysis.ForcedGCCount) / float64(gcCount)
+	}
 }
 
 // analyzePauseTimes analyzes GC pause time statistics.
@@ -147,8 +309,7 @@ func (a *Analyzer) analyzePauseTimes(analysis *types.GCAnalysis) {
 		total += event.Duration
 	}
 
-	// Use cmp.Compare for cleaner sorting (Go 1.21+)
-	slices.SortFunc(durations, cmp.Compare)
+	sortDurations(durations)
 
 	analysis.AvgPauseTime = total / time.Duration(n)
 	analysis.MinPauseTime = durations[0]
@@ -222,8 +383,7 @@ func (a *Analyzer) analyzePauseTimesFromMetrics(analysis *types.GCAnalysis) {
 	}
 
 	if len(pauses) > 0 {
-		// Use cmp.Compare for cleaner sorting
-		slices.SortFunc(pauses, cmp.Compare)
+		sortDurations(pauses)
 
 		n := len(pauses)
 		analysis.MinPauseTime = pauses[0]
@@ -240,25 +400,10 @@ func (a *Analyzer) analyzeMemoryUsage(analysis *types.GCAnalysis) {
 		return
 	}
 
-	var totalHeap uint64
-	minHeap := a.metrics[0].HeapAlloc
-	maxHeap := a.metrics[0].HeapAlloc
-
-	for _, metrics := range a.metrics {
-		heapSize := metrics.HeapAlloc
-		totalHeap += heapSize
-
-		if heapSize < minHeap {
-			minHeap = heapSize
-		}
-		if heapSize > maxHeap {
-			maxHeap = heapSize
-		}
-	}
-
-	analysis.AvgHeapSize = totalHeap / uint64(n)
-	analysis.MinHeapSize = minHeap
-	analysis.MaxHeapSize = maxHeap
+	heap := aggregateHeap(a.metrics)
+	analysis.AvgHeapSize = heap.total / uint64(n)
+	analysis.MinHeapSize = heap.min
+	analysis.MaxHeapSize = heap.max
 
 	// Calculate heap growth rate
 	if n >= 2 {
@@ -270,6 +415,29 @@ func (a *Analyzer) analyzeMemoryUsage(analysis *types.GCAnalysis) {
 			analysis.HeapGrowthRate = float64(heapGrowth) / periodSeconds
 		}
 	}
+
+	a.analyzeLiveHeap(analysis)
+}
+
+// analyzeLiveHeap summarizes the live heap series. HeapAlloc swings between
+// the live set and the GC goal depending on where in the cycle a sample falls,
+// while the live heap only moves when the retained set does.
+func (a *Analyzer) analyzeLiveHeap(analysis *types.GCAnalysis) {
+	if !hasLiveHeap(a.metrics) {
+		return
+	}
+
+	var total uint64
+	for _, m := range a.metrics {
+		total += m.HeapLive
+	}
+	analysis.AvgLiveHeap = total / uint64(len(a.metrics))
+
+	if periodSeconds := analysis.Period.Seconds(); periodSeconds > 0 {
+		first := a.metrics[0]
+		last := a.metrics[len(a.metrics)-1]
+		analysis.LiveHeapGrowthRate = (float64(last.HeapLive) - float64(first.HeapLive)) / periodSeconds
+	}
 }
 
 // analyzeAllocations analyzes allocation patterns
@@ -298,123 +466,361 @@ func (a *Analyzer) calculateEfficiencyMetrics(analysis *types.GCAnalysis) {
 		return
 	}
 
-	// Calculate average GC CPU fraction
+	analysis.GCOverhead = a.gcOverhead()
+
+	// Calculate memory efficiency (heap in use vs heap allocated)
+	if analysis.AvgHeapSize > 0 {
+		var totalHeapSys uint64
+		for _, metrics := range a.metrics {
+			totalHeapSys += metrics.HeapSys
+		}
+		avgHeapSys := totalHeapSys / uint64(n)
+
+		if avgHeapSys > 0 {
+			analysis.MemoryEfficiency = (float64(analysis.AvgHeapSize) / float64(avgHeapSys)) * 100
+		}
+	}
+}
+
+// gcOverhead returns the percentage of CPU time spent in GC over the window.
+// GCCPUFraction averages over the process's whole lifetime, so a window of
+// heavy GC late in a long-running process barely moves it; the runtime/metrics
+// CPU classes are cumulative seconds, and their deltas between the first and
+// last sample cover exactly the window. Samples without them fall back to the
+// average GCCPUFraction.
+func (a *Analyzer) gcOverhead() float64 {
+	first := a.metrics[0]
+	last := a.metrics[len(a.metrics)-1]
+	if total := last.TotalCPU - first.TotalCPU; first.TotalCPU > 0 && total > 0 && last.GCTotalCPU >= first.GCTotalCPU {
+		return (last.GCTotalCPU - first.GCTotalCPU) / total * 100
+	}
+
 	var totalGCCPUFraction float64
 	validSamples := 0
-
 	for _, metrics := range a.metrics {
 		if metrics.GCCPUFraction >= 0 {
 			totalGCCPUFraction += metrics.GCCPUFraction
 			validSamples++
 		}
 	}
+	if validSamples == 0 {
+		return 0
+	}
+	return (totalGCCPUFraction / float64(validSamples)) * 100
+}
 
-	if validSamples > 0 {
-		analysis.GCOverhead = (totalGCCPUFraction / float64(validSamples)) * 100
+// analyzeProcessCPU relates GC CPU time to the process's OS-reported CPU time.
+// The CPU available to the process comes from the runtime/metrics total, or
+// GOMAXPROCS × period when only the recorded runtime info is known.
+// Returns nil unless both ends of the window carry process CPU time.
+func (a *Analyzer) analyzeProcessCPU(gc *types.GCCPUBreakdown, period time.Duration) *types.ProcessCPUAnalysis {
+	if len(a.metrics) < 2 {
+		return nil
 	}
+	first := a.metrics[0]
+	last := a.metrics[len(a.metrics)-1]
 
-	// Calculate memory efficiency (heap in use vs heap allocated)
-	if analysis.AvgHeapSize > 0 {
-		var totalHeapSys uint64
-		for _, metrics := range a.metrics {
-			totalHeapSys += metrics.HeapSys
-		}
-		avgHeapSys := totalHeapSys / uint64(n)
+	if first.ProcessCPUSeconds <= 0 || last.ProcessCPUSeconds <= first.ProcessCPUSeconds {
+		return nil
+	}
 
-		if avgHeapSys > 0 {
-			analysis.MemoryEfficiency = (float64(analysis.AvgHeapSize) / float64(avgHeapSys)) * 100
+	p := &types.ProcessCPUAnalysis{
+		ProcessSeconds: last.ProcessCPUSeconds - first.ProcessCPUSeconds,
+	}
+	if gc != nil {
+		p.GCSeconds = gc.TotalSeconds
+		// The runtime counts idle-P marking that the OS may not, so cap the share
+		p.GCShare = min(p.GCSeconds/p.ProcessSeconds, 1)
+	}
+
+	available := last.TotalCPU - first.TotalCPU
+	if available <= 0 && a.opts.Runtime != nil && a.opts.Runtime.GOMAXPROCS > 0 {
+		available = float64(a.opts.Runtime.GOMAXPROCS) * period.Seconds()
+	}
+	// A container CPU limit below GOMAXPROCS throttles the process first
+	if k := a.kubernetes(); k != nil && k.CPULimit > 0 {
+		if limited := k.CPULimit * period.Seconds(); available <= 0 || limited < available {
+			available = limited
+			p.CPULimit = k.CPULimit
 		}
 	}
+	if available > 0 {
+		p.Utilization = p.ProcessSeconds / available
+		p.Saturated = p.Utilization > types.ThresholdCPUSaturation
+	}
+
+	return p
+}
+
+// analyzeGCCPU splits GC CPU time over the window using the cumulative
+// runtime/metrics counters of the first and last samples.
+// Returns nil when the samples carry no GC CPU data.
+func (a *Analyzer) analyzeGCCPU() *types.GCCPUBreakdown {
+	if len(a.metrics) < 2 {
+		return nil
+	}
+	first := a.metrics[0]
+	last := a.metrics[len(a.metrics)-1]
+
+	total := last.GCTotalCPU - first.GCTotalCPU
+	if total <= 0 {
+		return nil
+	}
+
+	background := (last.GCMarkDedicatedCPU - first.GCMarkDedicatedCPU) +
+		(last.GCMarkIdleCPU - first.GCMarkIdleCPU)
+
+	cpu := &types.GCCPUBreakdown{
+		TotalSeconds:    total,
+		AssistShare:     (last.GCMarkAssistCPU - first.GCMarkAssistCPU) / total,
+		BackgroundShare: background / total,
+		PauseShare:      (last.GCPauseCPU - first.GCPauseCPU) / total,
+	}
+	if last.GCCyclesAutomatic >= first.GCCyclesAutomatic {
+		cpu.AutomaticCycles = last.GCCyclesAutomatic - first.GCCyclesAutomatic
+	}
+	if last.GCCyclesForced >= first.GCCyclesForced {
+		cpu.ForcedCycles = last.GCCyclesForced - first.GCCyclesForced
+	}
+
+	return cpu
 }
 
-// generateRecommendations generates performance improvement recommendations
+// generateRecommendations generates performance improvement recommendations.
+// Each recommendation is classified by how far the observed value is past its
+// threshold, and the result is ordered with the most severe first.
 func (a *Analyzer) generateRecommendations(analysis *types.GCAnalysis) {
 	// Pre-allocate with estimated capacity
-	recommendations := make([]string, 0, 8)
+	recs := make([]types.Recommendation, 0, 8)
+
+	add := func(key i18n.Key, severity types.Severity, evidence string) {
+		recs = append(recs, newRecommendation(key, severity, evidence))
+	}
 
 	// High GC frequency recommendations
 	if analysis.GCFrequency > types.ThresholdGCFrequencyHigh {
-		recommendations = append(recommendations,
-			"High GC frequency detected. Consider reducing allocation rate or increasing GOGC value.")
+		add(i18n.RecHighGCFrequency,
+			types.ClassifySeverity(analysis.GCFrequency, types.ThresholdGCFrequencyHigh),
+			exceeds("GC frequency", perSecond(analysis.GCFrequency), perSecond(types.ThresholdGCFrequencyHigh)))
 	}
 
-	// Long pause time recommendations
+	// Forced GC recommendations: runtime.GC calls bypass GOGC pacing and stop
+	// the world for a full cycle regardless of heap size
+	if analysis.ForcedGCCount >= types.MinForcedGCs && analysis.ForcedGCRatio > types.ThresholdForcedGCRatioHigh {
+		add(i18n.RecFrequentForcedGC,
+			types.ClassifySeverity(analysis.ForcedGCRatio, types.ThresholdForcedGCRatioHigh),
+			exceeds("forced GC share", percent(analysis.ForcedGCRatio), percent(types.ThresholdForcedGCRatioHigh)))
+	}
+
+	// Long pause time recommendations. Pauses stretched by OS paging are a
+	// host memory problem that GC tuning won't fix, so they are only noted.
+	hostPaging := analysis.Paging != nil && analysis.Paging.Coincident
+	pauseSeverity := func(observed, threshold time.Duration) types.Severity {
+		if hostPaging {
+			return types.SeverityInfo
+		}
+		return types.ClassifySeverity(float64(observed), float64(threshold))
+	}
 	if analysis.AvgPauseTime > types.ThresholdAvgPauseLong {
-		recommendations = append(recommendations,
-			"Long GC pause times detected. Consider reducing heap size or optimizing allocation patterns.")
+		add(i18n.RecLongPause,
+			pauseSeverity(analysis.AvgPauseTime, types.ThresholdAvgPauseLong),
+			exceeds("average pause", roundMicro(analysis.AvgPauseTime), roundMicro(types.ThresholdAvgPauseLong)))
 	}
 
 	if analysis.P99PauseTime > types.ThresholdP99PauseVeryLong {
-		recommendations = append(recommendations,
-			"Very long P99 pause times detected. This may impact application responsiveness.")
+		add(i18n.RecVeryLongP99Pause,
+			pauseSeverity(analysis.P99PauseTime, types.ThresholdP99PauseVeryLong),
+			exceeds("P99 pause", roundMicro(analysis.P99PauseTime), roundMicro(types.ThresholdP99PauseVeryLong)))
+	}
+
+	// Pauses coinciding with OS paging: the host is short of memory
+	if hostPaging {
+		add(i18n.RecHostPaging,
+			types.ClassifySeverity(analysis.Paging.MajorFaultRate, types.ThresholdMajorFaultRate),
+			analysis.Paging.Evidence())
 	}
 
 	// Memory growth recommendations
 	if analysis.HeapGrowthRate > types.ThresholdHeapGrowthRateHigh {
-		recommendations = append(recommendations,
-			"High heap growth rate detected. Check for memory leaks or excessive allocations.")
+		severity := types.ClassifySeverity(analysis.HeapGrowthRate, types.ThresholdHeapGrowthRateHigh)
+		if periodicWithoutLeak(analysis) {
+			// Growth measured part-way through a recurring cycle is expected
+			severity = types.SeverityInfo
+		}
+		if analysis.AvgLiveHeap > 0 && analysis.LiveHeapGrowthRate < types.ThresholdHeapGrowthRateHigh {
+			// The live set is steady; the growth is garbage awaiting the next GC
+			severity = types.SeverityInfo
+		}
+		add(i18n.RecHighHeapGrowth, severity,
+			exceeds("heap growth", types.FormatBytesRate(analysis.HeapGrowthRate), types.FormatBytesRate(types.ThresholdHeapGrowthRateHigh)))
 	}
 
 	// High GC overhead recommendations
 	if analysis.GCOverhead > types.ThresholdGCOverheadHigh {
-		recommendations = append(recommendations,
-			"High GC overhead detected. Consider optimizing allocation patterns or tuning GC parameters.")
+		severity := types.ClassifySeverity(analysis.GCOverhead, types.ThresholdGCOverheadHigh)
+		if cpu := analysis.ProcessCPU; cpu != nil && cpu.Utilization > 0 && cpu.Utilization < types.ThresholdCPUUtilizationLow {
+			// GC work is mostly absorbed by otherwise idle CPU
+			severity = types.SeverityInfo
+		}
+		add(i18n.RecHighGCOverhead, severity,
+			exceeds("GC overhead", percent(analysis.GCOverhead/100), percent(types.ThresholdGCOverheadHigh/100)))
+	}
+
+	// CPU saturation: GC work competes directly with the application
+	if cpu := analysis.ProcessCPU; cpu != nil && cpu.Saturated && cpu.GCShare > types.ThresholdGCShareSaturated {
+		add(i18n.RecCPUSaturatedGC,
+			types.ClassifySeverity(cpu.GCShare, types.ThresholdGCShareSaturated),
+			exceeds("GC share of process CPU", percent(cpu.GCShare), percent(types.ThresholdGCShareSaturated))+
+				", process CPU "+percent(cpu.Utilization))
 	}
 
-	// Low memory efficiency recommendations
+	// Low memory efficiency recommendations (lower is worse, so invert the ratio)
 	if analysis.MemoryEfficiency > 0 && analysis.MemoryEfficiency < types.ThresholdMemoryEfficiencyLow {
-		recommendations = append(recommendations,
-			"Low memory efficiency detected. Consider reducing heap fragmentation or optimizing data structures.")
+		add(i18n.RecLowMemoryEfficiency,
+			types.ClassifySeverity(types.ThresholdMemoryEfficiencyLow, analysis.MemoryEfficiency),
+			"memory efficiency "+percent(analysis.MemoryEfficiency/100)+" (minimum "+percent(types.ThresholdMemoryEfficiencyLow/100)+")")
 	}
 
-	// Allocation rate recommendations
+	// Mark assist recommendations: assists stall mutator goroutines, unlike
+	// background marking which only consumes spare CPU
+	if cpu := analysis.GCCPU; cpu != nil && cpu.AssistShare > types.ThresholdMarkAssistShareHigh {
+		add(i18n.RecHighMarkAssist,
+			types.ClassifySeverity(cpu.AssistShare, types.ThresholdMarkAssistShareHigh),
+			exceeds("mark assist share of GC CPU", percent(cpu.AssistShare), percent(types.ThresholdMarkAssistShareHigh)))
+	}
+
+	// Allocation rate recommendations, naming the top allocation site if known
 	if analysis.AllocRate > types.ThresholdAllocationRateHigh {
-		recommendations = append(recommendations,
-			"High allocation rate detected. Consider object pooling or reducing temporary object creation.")
+		severity := types.ClassifySeverity(analysis.AllocRate, types.ThresholdAllocationRateHigh)
+		evidence := exceeds("allocation rate", types.FormatBytesRate(analysis.AllocRate), types.FormatBytesRate(types.ThresholdAllocationRateHigh))
+		if site := analysis.AllocSites.Top(); site != nil {
+			topSite := site.Function + " @ " + site.Location() + ", " + strconv.FormatFloat(site.Share*100, 'f', 0, 64) + "%"
+			rec := newRecommendation(i18n.RecHighAllocationRate, severity, evidence+", top site "+topSite)
+			rec.Message += " (" + topSite + ")"
+			recs = append(recs, rec)
+		} else {
+			add(i18n.RecHighAllocationRate, severity, evidence)
+		}
 	}
 
-	// Memory leak detection
-	if len(a.metrics) >= types.MinSamplesForTrendAnalysis {
-		recentGrowth := a.calculateRecentGrowthTrend()
-		if recentGrowth > types.ThresholdConsistentGrowth {
-			recommendations = append(recommendations,
-				"Consistent memory growth detected. Investigate potential memory leaks.")
+	// Object reuse recommendations for the size class dominating allocation volume
+	if dominant := analysis.SizeClasses.Dominant(); dominant != nil && dominant.Share >= types.ThresholdSizeClassDominant {
+		severity := types.SeverityInfo
+		if analysis.AllocRate > types.ThresholdAllocationRateHigh {
+			severity = types.SeverityWarning
+		}
+		evidence := dominant.Class + " objects " + percent(dominant.Share) + " of allocated bytes"
+		switch dominant.Class {
+		case types.SizeClassLarge:
+			add(i18n.RecReuseLargeBuffers, severity, evidence)
+		default:
+			add(i18n.RecPoolSmallObjects, severity, evidence)
 		}
 	}
 
-	analysis.Recommendations = recommendations
-}
+	// Non-Go memory growth: GC tuning cannot reclaim it
+	if rss := analysis.RSS; rss != nil && rss.NonGoGrowing && rss.AvgRSS > 0 {
+		growth := rss.NonGoGrowth * analysis.Period.Seconds() / float64(rss.AvgRSS)
+		add(i18n.RecNonGoMemoryGrowth, types.ClassifySeverity(growth, types.ThresholdNonGoGrowth),
+			"non-Go memory "+types.FormatBytesRate(rss.NonGoGrowth)+", "+percent(growth)+" of RSS over the period")
+	}
 
-// calculateRecentGrowthTrend calculates the recent memory growth trend
-func (a *Analyzer) calculateRecentGrowthTrend() float64 {
-	n := len(a.metrics)
-	if n < types.MinSamplesForTrendAnalysis {
-		return 0
+	// Container memory limit: the kernel OOM-kills the process at the limit,
+	// and without GOMEMLIMIT below it the GC doesn't know the limit exists
+	if analysis.MemoryLimit > 0 {
+		nearLimit := analysis.MemoryLimitUsage > types.ThresholdMemoryLimitUsageHigh
+		if nearLimit {
+			add(i18n.RecNearMemoryLimit, types.SeverityWarning,
+				exceeds("memory limit usage", percent(analysis.MemoryLimitUsage), percent(types.ThresholdMemoryLimitUsageHigh))+
+					" of "+types.FormatBytes(analysis.MemoryLimit))
+		}
+		if rt := analysis.Runtime; rt != nil && (rt.GOMemLimit == 0 || rt.GOMemLimit > analysis.MemoryLimit) {
+			severity := types.SeverityInfo
+			if nearLimit {
+				severity = types.SeverityWarning
+			}
+			evidence := "GOMEMLIMIT unset"
+			if rt.GOMemLimit > 0 {
+				evidence = "GOMEMLIMIT " + types.FormatBytes(rt.GOMemLimit)
+			}
+			add(i18n.RecSetGOMemLimit, severity, evidence+", container limit "+types.FormatBytes(analysis.MemoryLimit))
+		}
 	}
 
-	// Look at the last MinSamplesForTrendAnalysis samples to detect trend
-	startIdx := n - types.MinSamplesForTrendAnalysis
-	recent := a.metrics[startIdx:]
+	// Pressure against GOMEMLIMIT: the GC runs more often to stay below it,
+	// back to back when the live heap alone nearly fills it
+	if s := analysis.SoftLimit; s != nil && s.Pressured {
+		if s.DeathSpiral {
+			add(i18n.RecGCDeathSpiral, types.SeverityCritical, s.Evidence())
+		} else {
+			add(i18n.RecSoftLimitPressure, types.ClassifySeverity(s.NearLimitShare, types.ThresholdSoftLimitPressure), s.Evidence())
+		}
+	}
 
-	var totalGrowth float64
-	growthPoints := 0
+	// GOMAXPROCS above the container CPU limit: GC workers sized for
+	// GOMAXPROCS get the process throttled by the CPU quota
+	if rt := analysis.Runtime; rt != nil && rt.Kubernetes != nil && rt.Kubernetes.CPULimit > 0 &&
+		float64(rt.GOMAXPROCS) > math.Ceil(rt.Kubernetes.CPULimit) {
+		add(i18n.RecGOMAXPROCSAboveCPULimit, types.SeverityWarning,
+			"GOMAXPROCS "+strconv.Itoa(rt.GOMAXPROCS)+", CPU limit "+strconv.FormatFloat(rt.Kubernetes.CPULimit, 'f', 2, 64))
+	}
 
-	for i := 1; i < len(recent); i++ {
-		prev := recent[i-1].HeapAlloc
-		curr := recent[i].HeapAlloc
+	// Memory leak detection
+	if leak := analysis.LeakDetection; leak != nil && leak.Suspected {
+		severity := types.ClassifySeverity(leak.RelativeGrowth, types.ThresholdConsistentGrowth)
+		if leak.Confidence != types.ConfidenceHigh && severity == types.SeverityCritical {
+			// Without strong statistical backing, don't escalate to critical
+			severity = types.SeverityWarning
+		}
+		rec := newRecommendation(i18n.RecConsistentGrowth, severity, leak.Evidence())
+		rec.Message += " (" + leak.Evidence() + ")"
+		recs = append(recs, rec)
+	}
 
-		if prev > 0 {
-			growth := (float64(curr) - float64(prev)) / float64(prev)
-			totalGrowth += growth
-			growthPoints++
+	// Declining reclamation: each cycle frees less while more of the heap
+	// survives, which points at a leak even when the heap size holds steady
+	if r := analysis.Reclamation; r != nil && r.Declining {
+		severity := types.ClassifySeverity(-r.ReclaimedTrend, types.ThresholdReclamationDecline)
+		if r.RSquared < types.LeakHighConfidenceR2 && severity == types.SeverityCritical {
+			severity = types.SeverityWarning
 		}
+		add(i18n.RecDecliningReclamation, severity, r.Evidence())
 	}
 
-	if growthPoints > 0 {
-		return totalGrowth / float64(growthPoints)
+	setRecommendations(analysis, recs)
+}
+
+// kubernetes returns the pod context of the analyzed process, if known
+func (a *Analyzer) kubernetes() *types.KubernetesInfo {
+	if a.opts.Runtime == nil {
+		return nil
+	}
+	return a.opts.Runtime.Kubernetes
+}
+
+// periodicWithoutLeak reports whether the heap follows a periodic pattern whose
+// floor is not growing from one period to the next
+func periodicWithoutLeak(analysis *types.GCAnalysis) bool {
+	if analysis.Periodicity == nil || !analysis.Periodicity.Detected {
+		return false
+	}
+	return analysis.LeakDetection == nil || !analysis.LeakDetection.Suspected
+}
+
+// setRecommendations orders recs by severity (most severe first, otherwise
+// preserving detection order) and stores both the detailed and string views.
+func setRecommendations(analysis *types.GCAnalysis, recs []types.Recommendation) {
+	slices.SortStableFunc(recs, func(x, y types.Recommendation) int {
+		return cmp.Compare(y.Severity.Rank(), x.Severity.Rank())
+	})
+
+	messages := make([]string, len(recs))
+	for i, rec := range recs {
+		messages[i] = rec.Message
 	}
 
-	return 0
+	analysis.RecommendationDetails = recs
+	analysis.Recommendations = messages
 }
 
 // GetPauseTimeDistribution returns pause time distribution data
@@ -469,6 +875,7 @@ func (a *Analyzer) GetMemoryTrend() []types.MemoryPoint {
 			HeapAlloc: metrics.HeapAlloc,
 			HeapSys:   metrics.HeapSys,
 			HeapInuse: metrics.HeapInuse,
+			HeapLive:  metrics.HeapLive,
 		}
 	}
 
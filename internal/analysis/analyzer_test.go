@@ -1,9 +1,13 @@
 package analysis
 
 import (
+	"errors"
+	"math"
+	"slices"
 	"testing"
 	"time"
 
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/i18n"
 	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
 )
 
@@ -84,6 +88,124 @@ func TestNewWithEvents(t *testing.T) {
 	}
 }
 
+func TestNewWithOptions_Runtime(t *testing.T) {
+	metrics := createTestMetrics(5, time.Now(), time.Second)
+	info := &types.RuntimeInfo{GoVersion: "go1.23.0", GOGC: 100}
+
+	result, err := NewWithOptions(metrics, nil, &Options{Runtime: info}).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if result.Runtime != info {
+		t.Errorf("Expected analysis to record runtime info, got %+v", result.Runtime)
+	}
+
+	result, err = NewWithOptions(metrics, nil, nil).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if result.Runtime != nil {
+		t.Errorf("Expected no runtime info without options, got %+v", result.Runtime)
+	}
+}
+
+func TestAnalyze_ForcedGC(t *testing.T) {
+	msg := i18n.T(i18n.English, i18n.RecFrequentForcedGC)
+
+	tests := []struct {
+		name      string
+		forced    uint32 // forced GCs per sample, out of 5 GCs per sample
+		wantCount uint32
+		wantRec   bool
+	}{
+		{"none forced", 0, 0, false},
+		{"mostly forced", 2, 8, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := createTestMetrics(5, time.Now(), time.Second)
+			for i, m := range metrics {
+				m.NumForcedGC = uint32(i) * tt.forced
+			}
+
+			result, err := New(metrics).Analyze()
+			if err != nil {
+				t.Fatalf("Analyze() error: %v", err)
+			}
+			if result.ForcedGCCount != tt.wantCount {
+				t.Errorf("ForcedGCCount = %d, want %d", result.ForcedGCCount, tt.wantCount)
+			}
+			if want := float64(tt.wantCount) / 20; math.Abs(result.ForcedGCRatio-want) > 1e-9 {
+				t.Errorf("ForcedGCRatio = %v, want %v", result.ForcedGCRatio, want)
+			}
+			if got := slices.Contains(result.Recommendations, msg); got != tt.wantRec {
+				t.Errorf("forced GC recommendation present = %v, want %v", got, tt.wantRec)
+			}
+		})
+	}
+}
+
+func TestAnalyze_ForcedGC_OneOff(t *testing.T) {
+	metrics := createTestMetrics(2, time.Now(), time.Second)
+	metrics[1].NumGC = metrics[0].NumGC + 2
+	metrics[1].NumForcedGC = 2
+
+	result, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if slices.Contains(result.Recommendations, i18n.T(i18n.English, i18n.RecFrequentForcedGC)) {
+		t.Error("A couple of forced GCs should not produce a recommendation")
+	}
+}
+
+func TestAnalyze_SizeClassRecommendation(t *testing.T) {
+	metrics := createTestMetrics(5, time.Now(), time.Second)
+
+	tests := []struct {
+		name   string
+		shares [3]float64
+		want   i18n.Key
+	}{
+		{"small dominant", [3]float64{0.1, 0.8, 0.1}, i18n.RecPoolSmallObjects},
+		{"large dominant", [3]float64{0.05, 0.15, 0.8}, i18n.RecReuseLargeBuffers},
+		{"no dominant", [3]float64{0.3, 0.4, 0.3}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dist := &types.SizeClassDistribution{}
+			for i, class := range []string{types.SizeClassTiny, types.SizeClassSmall, types.SizeClassLarge} {
+				dist.Buckets = append(dist.Buckets, types.SizeClassBucket{
+					Class: class,
+					Bytes: uint64(tt.shares[i] * 1000),
+					Share: tt.shares[i],
+				})
+			}
+
+			result, err := NewWithOptions(metrics, nil, &Options{SizeClasses: dist}).Analyze()
+			if err != nil {
+				t.Fatalf("Analyze() error: %v", err)
+			}
+			if result.SizeClasses != dist {
+				t.Error("Expected analysis to record the size class distribution")
+			}
+
+			found := map[string]bool{}
+			for _, rec := range result.Recommendations {
+				found[rec] = true
+			}
+			for _, key := range []i18n.Key{i18n.RecPoolSmallObjects, i18n.RecReuseLargeBuffers} {
+				msg := i18n.T(i18n.English, key)
+				if found[msg] != (key == tt.want) {
+					t.Errorf("recommendation %s present = %v, want %v", key, found[msg], key == tt.want)
+				}
+			}
+		})
+	}
+}
+
 func TestAnalyze_InsufficientData(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -98,9 +220,13 @@ func TestAnalyze_InsufficientData(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			analyzer := New(tt.metrics)
 			_, err := analyzer.Analyze()
-			if err != types.ErrInsufficientData {
+			if !errors.Is(err, types.ErrInsufficientData) {
 				t.Errorf("Expected ErrInsufficientData, got %v", err)
 			}
+			var dataErr *types.InsufficientDataError
+			if !errors.As(err, &dataErr) || dataErr.Op != "analyze" || dataErr.Samples != len(tt.metrics) || dataErr.Required != 2 {
+				t.Errorf("Expected an InsufficientDataError with the sample count, got %#v", err)
+			}
 		})
 	}
 }
@@ -144,6 +270,48 @@ func TestAnalyze_Success(t *testing.T) {
 	}
 }
 
+func TestAnalyzeLite(t *testing.T) {
+	baseTime := time.Now()
+	metrics := createTestMetrics(10, baseTime, time.Second)
+
+	full, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	lite, err := New(metrics).AnalyzeLite()
+	if err != nil {
+		t.Fatalf("AnalyzeLite() error: %v", err)
+	}
+
+	if lite.Period != full.Period {
+		t.Errorf("Period = %v, want %v", lite.Period, full.Period)
+	}
+	if lite.GCFrequency != full.GCFrequency {
+		t.Errorf("GCFrequency = %f, want %f", lite.GCFrequency, full.GCFrequency)
+	}
+	if lite.AllocRate != full.AllocRate {
+		t.Errorf("AllocRate = %f, want %f", lite.AllocRate, full.AllocRate)
+	}
+	if lite.AvgHeapSize != full.AvgHeapSize || lite.GCOverhead != full.GCOverhead {
+		t.Errorf("averages differ: lite %d/%f, full %d/%f",
+			lite.AvgHeapSize, lite.GCOverhead, full.AvgHeapSize, full.GCOverhead)
+	}
+	if lite.AvgPauseTime != full.AvgPauseTime {
+		t.Errorf("AvgPauseTime = %v, want %v", lite.AvgPauseTime, full.AvgPauseTime)
+	}
+	if lite.P99PauseTime != 0 || lite.MaxPauseTime != 0 {
+		t.Errorf("lite analysis should skip pause percentiles, got P99 %v, max %v",
+			lite.P99PauseTime, lite.MaxPauseTime)
+	}
+	if len(lite.Recommendations) != 0 {
+		t.Errorf("lite analysis should skip recommendations, got %d", len(lite.Recommendations))
+	}
+
+	if _, err := New(metrics[:1]).AnalyzeLite(); !errors.Is(err, types.ErrInsufficientData) {
+		t.Errorf("AnalyzeLite() with one sample error = %v, want ErrInsufficientData", err)
+	}
+}
+
 func TestAnalyze_WithEvents(t *testing.T) {
 	baseTime := time.Now()
 	metrics := createTestMetrics(10, baseTime, time.Second)
@@ -348,6 +516,69 @@ func TestGenerateRecommendations(t *testing.T) {
 	}
 }
 
+func TestGenerateRecommendations_SeverityOrder(t *testing.T) {
+	analysis := &types.GCAnalysis{
+		GCFrequency:      types.ThresholdGCFrequencyHigh * 1.1,  // info
+		GCOverhead:       types.ThresholdGCOverheadHigh * 3,     // critical
+		AllocRate:        types.ThresholdAllocationRateHigh * 2, // warning
+		MemoryEfficiency: 90,
+	}
+
+	New(nil).generateRecommendations(analysis)
+
+	want := []types.Severity{types.SeverityCritical, types.SeverityWarning, types.SeverityInfo}
+	if len(analysis.RecommendationDetails) != len(want) {
+		t.Fatalf("Expected %d recommendations, got %d", len(want), len(analysis.RecommendationDetails))
+	}
+	for i, rec := range analysis.RecommendationDetails {
+		if rec.Severity != want[i] {
+			t.Errorf("Recommendation %d severity = %q, want %q", i, rec.Severity, want[i])
+		}
+		if analysis.Recommendations[i] != rec.Message {
+			t.Errorf("Recommendations[%d] should match detailed message order", i)
+		}
+	}
+}
+
+func TestGenerateRecommendations_Structured(t *testing.T) {
+	analysis := &types.GCAnalysis{
+		GCFrequency:      types.ThresholdGCFrequencyHigh * 2.5,
+		MemoryEfficiency: 90,
+	}
+
+	New(nil).generateRecommendations(analysis)
+
+	if len(analysis.RecommendationDetails) != 1 {
+		t.Fatalf("Expected 1 recommendation, got %d", len(analysis.RecommendationDetails))
+	}
+	rec := analysis.RecommendationDetails[0]
+	if rec.Code != types.CodeHighGCFrequency {
+		t.Errorf("Code = %q, want %q", rec.Code, types.CodeHighGCFrequency)
+	}
+	if rec.Title == "" || rec.Detail == "" || rec.SuggestedAction == "" {
+		t.Errorf("Recommendation should have a title, detail and suggested action: %+v", rec)
+	}
+	if rec.Evidence != "GC frequency 25.0/s (threshold 10.0/s)" {
+		t.Errorf("Evidence = %q", rec.Evidence)
+	}
+	if rec.String() != "GC001: High GC frequency" {
+		t.Errorf("String() = %q", rec.String())
+	}
+}
+
+func TestRecommendationInfos_UniqueCodes(t *testing.T) {
+	seen := make(map[types.RecommendationCode]i18n.Key)
+	for key, info := range recommendationInfos {
+		if info.code == "" || info.title == "" || info.action == "" {
+			t.Errorf("%s is missing its code, title or action", key)
+		}
+		if other, ok := seen[info.code]; ok {
+			t.Errorf("%s and %s share code %s", key, other, info.code)
+		}
+		seen[info.code] = key
+	}
+}
+
 // Benchmark tests
 func BenchmarkAnalyze(b *testing.B) {
 	metrics := createTestMetrics(100, time.Now(), time.Second)
@@ -389,3 +620,248 @@ func BenchmarkGetMemoryTrend(b *testing.B) {
 		_ = analyzer.GetMemoryTrend()
 	}
 }
+
+func TestAnalyzeGCCPU(t *testing.T) {
+	metrics := createTestMetrics(5, time.Now(), time.Second)
+	if cpu := New(metrics).analyzeGCCPU(); cpu != nil {
+		t.Errorf("Expected nil breakdown without runtime/metrics data, got %+v", cpu)
+	}
+
+	first, last := metrics[0], metrics[len(metrics)-1]
+	first.GCTotalCPU, last.GCTotalCPU = 1.0, 3.0
+	first.GCMarkAssistCPU, last.GCMarkAssistCPU = 0.1, 1.1
+	first.GCMarkDedicatedCPU, last.GCMarkDedicatedCPU = 0.5, 1.2
+	first.GCMarkIdleCPU, last.GCMarkIdleCPU = 0.2, 0.4
+	first.GCPauseCPU, last.GCPauseCPU = 0.2, 0.3
+	first.GCCyclesAutomatic, last.GCCyclesAutomatic = 10, 30
+	first.GCCyclesForced, last.GCCyclesForced = 1, 2
+
+	analysis, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	cpu := analysis.GCCPU
+	if cpu == nil {
+		t.Fatal("Expected GC CPU breakdown")
+	}
+	if math.Abs(cpu.AssistShare-0.5) > 1e-9 || math.Abs(cpu.BackgroundShare-0.45) > 1e-9 {
+		t.Errorf("AssistShare = %v, BackgroundShare = %v, want 0.5 and 0.45", cpu.AssistShare, cpu.BackgroundShare)
+	}
+	if cpu.AutomaticCycles != 20 || cpu.ForcedCycles != 1 {
+		t.Errorf("Cycles = %d automatic, %d forced, want 20 and 1", cpu.AutomaticCycles, cpu.ForcedCycles)
+	}
+
+	found := false
+	for _, rec := range analysis.Recommendations {
+		if rec == i18n.T(i18n.English, i18n.RecHighMarkAssist) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected mark assist recommendation, got %v", analysis.Recommendations)
+	}
+}
+
+func TestAnalyzeProcessCPU(t *testing.T) {
+	metrics := createTestMetrics(5, time.Now(), time.Second)
+	if p := New(metrics).analyzeProcessCPU(nil, 4*time.Second); p != nil {
+		t.Errorf("Expected nil without process CPU samples, got %+v", p)
+	}
+
+	// 4s window on 4 Ps: 16 CPU-seconds available, 15 used, 3 of them by GC
+	first, last := metrics[0], metrics[len(metrics)-1]
+	first.ProcessCPUSeconds, last.ProcessCPUSeconds = 10, 25
+	first.TotalCPU, last.TotalCPU = 100, 116
+	first.GCTotalCPU, last.GCTotalCPU = 1, 4
+
+	analysis, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	p := analysis.ProcessCPU
+	if p == nil {
+		t.Fatal("Expected process CPU analysis")
+	}
+	if p.ProcessSeconds != 15 || p.GCSeconds != 3 || math.Abs(p.GCShare-0.2) > 1e-9 {
+		t.Errorf("ProcessCPU = %+v, want 15s process, 3s GC, 0.2 share", p)
+	}
+	if math.Abs(p.Utilization-15.0/16.0) > 1e-9 || !p.Saturated {
+		t.Errorf("Utilization = %v, Saturated = %v, want %v and true", p.Utilization, p.Saturated, 15.0/16.0)
+	}
+	if !slices.Contains(analysis.Recommendations, i18n.T(i18n.English, i18n.RecCPUSaturatedGC)) {
+		t.Errorf("Expected CPU saturation recommendation, got %v", analysis.Recommendations)
+	}
+}
+
+func TestAnalyzeProcessCPU_RuntimeFallback(t *testing.T) {
+	metrics := createTestMetrics(5, time.Now(), time.Second)
+	metrics[0].ProcessCPUSeconds, metrics[4].ProcessCPUSeconds = 1, 3
+
+	// No runtime/metrics total: available CPU is GOMAXPROCS × period = 8s
+	opts := &Options{Runtime: &types.RuntimeInfo{GOMAXPROCS: 2}}
+	p := NewWithOptions(metrics, nil, opts).analyzeProcessCPU(nil, 4*time.Second)
+	if p == nil || p.Utilization != 0.25 || p.Saturated {
+		t.Errorf("ProcessCPU = %+v, want 0.25 utilization", p)
+	}
+}
+
+func TestRecommendations_IdleCPUDowngradesGCOverhead(t *testing.T) {
+	analysis := &types.GCAnalysis{
+		GCOverhead: 2 * types.ThresholdGCOverheadHigh,
+		ProcessCPU: &types.ProcessCPUAnalysis{ProcessSeconds: 1, Utilization: 0.2},
+	}
+	New(nil).generateRecommendations(analysis)
+
+	for _, rec := range analysis.RecommendationDetails {
+		if rec.Message == i18n.T(i18n.English, i18n.RecHighGCOverhead) {
+			if rec.Severity != types.SeverityInfo {
+				t.Errorf("GC overhead on a mostly idle CPU should be info, got %s", rec.Severity)
+			}
+			return
+		}
+	}
+	t.Error("Expected GC overhead recommendation")
+}
+
+func TestGCOverhead_CPUClasses(t *testing.T) {
+	metrics := createTestMetrics(10, time.Now(), time.Second)
+	for i, m := range metrics {
+		// The lifetime fraction stays at 1% while GC takes 2 of every 8
+		// CPU seconds in the window
+		m.GCCPUFraction = 0.01
+		m.TotalCPU = 1000 + float64(i)*8
+		m.GCTotalCPU = 10 + float64(i)*2
+	}
+
+	analysis, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if analysis.GCOverhead != 25 {
+		t.Errorf("GCOverhead = %v, want 25 from the CPU class deltas", analysis.GCOverhead)
+	}
+
+	// Without CPU classes, the lifetime fraction is all there is
+	for _, m := range metrics {
+		m.TotalCPU, m.GCTotalCPU = 0, 0
+	}
+	analysis, err = New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if math.Abs(analysis.GCOverhead-1) > 1e-9 {
+		t.Errorf("GCOverhead = %v, want 1 from GCCPUFraction", analysis.GCOverhead)
+	}
+}
+
+func TestAnalyzeRSS(t *testing.T) {
+	metrics := createTestMetrics(12, time.Now(), time.Second)
+	if r := New(metrics).analyzeRSS(); r != nil {
+		t.Errorf("Expected nil without RSS samples, got %+v", r)
+	}
+
+	// Go-managed memory stays at 64MB while RSS climbs 4MB per sample
+	for i, m := range metrics {
+		m.Sys = 72 << 20
+		m.HeapReleased = 8 << 20
+		m.ProcessRSS = uint64(80<<20 + i*4<<20)
+	}
+
+	analysis, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	r := analysis.RSS
+	if r == nil {
+		t.Fatal("Expected RSS analysis")
+	}
+	if r.AvgGoManaged != 64<<20 || r.MaxRSS != 124<<20 {
+		t.Errorf("RSS = %+v, want 64MB Go-managed and 124MB max RSS", r)
+	}
+	if !r.NonGoGrowing || math.Abs(r.NonGoGrowth-4<<20) > 1 {
+		t.Errorf("NonGoGrowing = %v, NonGoGrowth = %v, want growth of 4MB/s", r.NonGoGrowing, r.NonGoGrowth)
+	}
+	if !slices.Contains(analysis.Recommendations, i18n.T(i18n.English, i18n.RecNonGoMemoryGrowth)) {
+		t.Errorf("Expected non-Go memory recommendation, got %v", analysis.Recommendations)
+	}
+}
+
+func TestAnalyzeRSS_FlatNonGoMemory(t *testing.T) {
+	// RSS grows, but only because Go-managed memory grows with it
+	metrics := createTestMetrics(12, time.Now(), time.Second)
+	for i, m := range metrics {
+		m.Sys = uint64(64<<20 + i*4<<20)
+		m.ProcessRSS = m.Sys + 16<<20
+	}
+
+	r := New(metrics).analyzeRSS()
+	if r == nil {
+		t.Fatal("Expected RSS analysis")
+	}
+	if r.NonGoGrowing || r.AvgNonGo != 16<<20 {
+		t.Errorf("RSS = %+v, want steady 16MB non-Go memory", r)
+	}
+}
+
+func TestAnalyzeProcessCPU_ContainerCPULimit(t *testing.T) {
+	metrics := createTestMetrics(5, time.Now(), time.Second)
+	metrics[0].ProcessCPUSeconds, metrics[4].ProcessCPUSeconds = 1, 8
+
+	// 16 Ps on the node, but the pod may use only 2 cores: 7s of 8s available
+	opts := &Options{Runtime: &types.RuntimeInfo{
+		GOMAXPROCS: 16,
+		Kubernetes: &types.KubernetesInfo{CPULimit: 2},
+	}}
+	analysis, err := NewWithOptions(metrics, nil, opts).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	p := analysis.ProcessCPU
+	if p == nil || p.Utilization != 7.0/8.0 || p.CPULimit != 2 {
+		t.Errorf("ProcessCPU = %+v, want 0.875 utilization against a 2 core limit", p)
+	}
+	if !slices.Contains(analysis.Recommendations, i18n.T(i18n.English, i18n.RecGOMAXPROCSAboveCPULimit)) {
+		t.Errorf("Expected GOMAXPROCS recommendation, got %v", analysis.Recommendations)
+	}
+
+	// Without a cgroup limit in the samples, the pod memory limit applies
+	opts.Runtime.Kubernetes.MemoryLimit = 8 << 20
+	analysis, _ = NewWithOptions(metrics, nil, opts).Analyze()
+	if analysis.MemoryLimit != 8<<20 {
+		t.Errorf("MemoryLimit = %d, want the pod memory limit", analysis.MemoryLimit)
+	}
+}
+
+func TestAnalyze_AllocSiteRecommendation(t *testing.T) {
+	// 1 MB per 5ms sample is 200 MB/s, above the high allocation rate threshold
+	metrics := createTestMetrics(5, time.Now(), 5*time.Millisecond)
+	sites := &types.AllocSiteReport{
+		Sites: []types.AllocSite{
+			{Function: "main.decode", File: "/src/app/decode.go", Line: 42, Bytes: 750, Share: 0.75},
+			{Function: "main.handle", File: "/src/app/handler.go", Line: 7, Bytes: 250, Share: 0.25},
+		},
+		Window:     time.Second,
+		TotalBytes: 1000,
+	}
+
+	result, err := NewWithOptions(metrics, nil, &Options{AllocSites: sites}).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if result.AllocSites != sites {
+		t.Error("Expected analysis to record the allocation sites")
+	}
+
+	want := i18n.T(i18n.English, i18n.RecHighAllocationRate) + " (main.decode @ decode.go:42, 75%)"
+	if !slices.Contains(result.Recommendations, want) {
+		t.Errorf("Expected %q, got %v", want, result.Recommendations)
+	}
+
+	result, err = NewWithOptions(metrics, nil, nil).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if !slices.Contains(result.Recommendations, i18n.T(i18n.English, i18n.RecHighAllocationRate)) {
+		t.Errorf("Expected the plain recommendation without sites, got %v", result.Recommendations)
+	}
+}
@@ -0,0 +1,191 @@
+package analysis
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// series is a derived per-interval metric; idx[i] is the index of the sample
+// that closes the interval for ys[i]
+type series struct {
+	idx []int
+	ys  []float64
+}
+
+// DetectChangepoints finds samples where the allocation rate or the average
+// pause per GC shifted to a significantly different level, e.g. after a deploy.
+// Series are split recursively at the point that best separates two segment
+// means; a split is kept when the shift is both large (ThresholdChangepointShift)
+// and statistically clear (ThresholdChangepointScore).
+// Results are ordered by time. Returns ErrInsufficientData when there are too
+// few samples to form two segments.
+func (a *Analyzer) DetectChangepoints() ([]types.Changepoint, error) {
+	if required := 2*types.MinChangepointSegment + 1; len(a.metrics) < required {
+		return nil, &types.InsufficientDataError{Op: "detect changepoints", Samples: len(a.metrics), Required: required}
+	}
+
+	var result []types.Changepoint
+	result = append(result, a.changepoints(types.ChangepointAllocRate, a.allocRateSeries())...)
+	result = append(result, a.changepoints(types.ChangepointAvgPause, a.avgPauseSeries())...)
+
+	slices.SortStableFunc(result, func(x, y types.Changepoint) int {
+		return cmp.Compare(x.Index, y.Index)
+	})
+	return result, nil
+}
+
+// allocRateSeries returns bytes allocated per second for each sample interval
+func (a *Analyzer) allocRateSeries() series {
+	var s series
+	for i := 1; i < len(a.metrics); i++ {
+		prev, cur := a.metrics[i-1], a.metrics[i]
+		dt := cur.Timestamp.Sub(prev.Timestamp).Seconds()
+		if dt <= 0 || cur.TotalAlloc < prev.TotalAlloc {
+			continue
+		}
+		s.idx = append(s.idx, i)
+		s.ys = append(s.ys, float64(cur.TotalAlloc-prev.TotalAlloc)/dt)
+	}
+	return s
+}
+
+// avgPauseSeries returns the average pause per GC cycle for each sample
+// interval in which at least one GC completed
+func (a *Analyzer) avgPauseSeries() series {
+	var s series
+	for i := 1; i < len(a.metrics); i++ {
+		prev, cur := a.metrics[i-1], a.metrics[i]
+		if cur.NumGC <= prev.NumGC || cur.PauseTotalNs < prev.PauseTotalNs {
+			continue
+		}
+		s.idx = append(s.idx, i)
+		s.ys = append(s.ys, float64(cur.PauseTotalNs-prev.PauseTotalNs)/float64(cur.NumGC-prev.NumGC))
+	}
+	return s
+}
+
+// changepoints runs binary segmentation over s and converts the splits
+func (a *Analyzer) changepoints(metric string, s series) []types.Changepoint {
+	var splits []int
+	segment(s.ys, newPrefixSums(s.ys), 0, len(s.ys), &splits)
+	slices.Sort(splits)
+
+	result := make([]types.Changepoint, 0, len(splits))
+	for i, k := range splits {
+		// Report means of the neighbouring segments, not the whole series
+		lo, hi := 0, len(s.ys)
+		if i > 0 {
+			lo = splits[i-1]
+		}
+		if i+1 < len(splits) {
+			hi = splits[i+1]
+		}
+		before, _ := meanVar(s.ys[lo:k])
+		after, _ := meanVar(s.ys[k:hi])
+
+		cp := types.Changepoint{
+			Metric:    metric,
+			Index:     s.idx[k],
+			Timestamp: a.metrics[s.idx[k]].Timestamp,
+			Before:    before,
+			After:     after,
+		}
+		if before > 0 {
+			cp.Change = (after - before) / before
+		}
+		result = append(result, cp)
+	}
+	return result
+}
+
+// segment finds the best split of ys[lo:hi] and, if it is significant,
+// records it and recurses into both halves. p holds the prefix sums of ys.
+func segment(ys []float64, p *prefixSums, lo, hi int, splits *[]int) {
+	minSeg := types.MinChangepointSegment
+	if hi-lo < 2*minSeg {
+		return
+	}
+
+	// Choose the split that minimizes the total within-segment squared error
+	best, bestCost := -1, math.Inf(1)
+	for k := lo + minSeg; k <= hi-minSeg; k++ {
+		cost := p.sse(lo, k) + p.sse(k, hi)
+		if cost < bestCost {
+			best, bestCost = k, cost
+		}
+	}
+	if best < 0 || !significantShift(ys[lo:best], ys[best:hi]) {
+		return
+	}
+
+	*splits = append(*splits, best)
+	segment(ys, p, lo, best, splits)
+	segment(ys, p, best, hi, splits)
+}
+
+// prefixSums holds running sums of a series and of its squares, so the
+// squared error of any segment takes O(1) to compute
+type prefixSums struct {
+	sum, sq []float64 // sum[i] and sq[i] cover ys[:i]
+}
+
+// newPrefixSums builds the prefix sums of ys, centred on their mean so that
+// squaring large values such as allocation rates keeps their precision
+func newPrefixSums(ys []float64) *prefixSums {
+	mean, _ := meanVar(ys)
+	p := &prefixSums{sum: make([]float64, len(ys)+1), sq: make([]float64, len(ys)+1)}
+	for i, y := range ys {
+		d := y - mean
+		p.sum[i+1] = p.sum[i] + d
+		p.sq[i+1] = p.sq[i] + d*d
+	}
+	return p
+}
+
+// sse returns the sum of squared deviations of ys[lo:hi] from its own mean
+func (p *prefixSums) sse(lo, hi int) float64 {
+	s := p.sum[hi] - p.sum[lo]
+	return max(p.sq[hi]-p.sq[lo]-s*s/float64(hi-lo), 0)
+}
+
+// significantShift reports whether the means of a and b differ by at least
+// ThresholdChangepointShift relative to a, with a Welch t-score of at least
+// ThresholdChangepointScore
+func significantShift(a, b []float64) bool {
+	m1, v1 := meanVar(a)
+	m2, v2 := meanVar(b)
+	diff := math.Abs(m2 - m1)
+	if diff == 0 {
+		return false
+	}
+
+	if m1 != 0 && diff/math.Abs(m1) < types.ThresholdChangepointShift {
+		return false
+	}
+
+	se := math.Sqrt(v1/float64(len(a)) + v2/float64(len(b)))
+	if se == 0 {
+		// Both segments are flat at different levels
+		return true
+	}
+	return diff/se >= types.ThresholdChangepointScore
+}
+
+// meanVar returns the mean and population variance of ys
+func meanVar(ys []float64) (mean, variance float64) {
+	if len(ys) == 0 {
+		return 0, 0
+	}
+	for _, y := range ys {
+		mean += y
+	}
+	mean /= float64(len(ys))
+	for _, y := range ys {
+		d := y - mean
+		variance += d * d
+	}
+	return mean, variance / float64(len(ys))
+}
@@ -0,0 +1,122 @@
+package analysis
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// createShiftMetrics creates samples one second apart whose allocation rate
+// changes from rateBefore to rateAfter at sample shiftAt. Every interval
+// completes one GC with a constant 100µs pause.
+func createShiftMetrics(count, shiftAt int, rateBefore, rateAfter uint64) []*types.GCMetrics {
+	baseTime := time.Now()
+	metrics := make([]*types.GCMetrics, count)
+	var total uint64
+	for i := range metrics {
+		if i > 0 {
+			rate := rateBefore
+			if i >= shiftAt {
+				rate = rateAfter
+			}
+			// Small deterministic jitter so segments are not perfectly flat
+			total += rate + uint64(i%3)*1024
+		}
+		metrics[i] = &types.GCMetrics{
+			NumGC:        uint32(i),
+			PauseTotalNs: uint64(i) * 100000,
+			TotalAlloc:   total,
+			Timestamp:    baseTime.Add(time.Duration(i) * time.Second),
+		}
+	}
+	return metrics
+}
+
+func TestDetectChangepoints_AllocRateShift(t *testing.T) {
+	metrics := createShiftMetrics(40, 20, 10*1024*1024, 30*1024*1024)
+
+	cps, err := New(metrics).DetectChangepoints()
+	if err != nil {
+		t.Fatalf("DetectChangepoints() error: %v", err)
+	}
+	if len(cps) != 1 {
+		t.Fatalf("Expected 1 changepoint, got %d: %+v", len(cps), cps)
+	}
+
+	cp := cps[0]
+	if cp.Metric != types.ChangepointAllocRate {
+		t.Errorf("Metric = %q, want %q", cp.Metric, types.ChangepointAllocRate)
+	}
+	if cp.Index != 20 || !cp.Timestamp.Equal(metrics[20].Timestamp) {
+		t.Errorf("Changepoint at index %d, want 20", cp.Index)
+	}
+	if cp.Change < 1.9 || cp.Change > 2.1 {
+		t.Errorf("Change = %v, want ~2 (tripled rate)", cp.Change)
+	}
+}
+
+func TestDetectChangepoints_Stable(t *testing.T) {
+	metrics := createShiftMetrics(40, 40, 10*1024*1024, 10*1024*1024)
+
+	cps, err := New(metrics).DetectChangepoints()
+	if err != nil {
+		t.Fatalf("DetectChangepoints() error: %v", err)
+	}
+	if len(cps) != 0 {
+		t.Errorf("Expected no changepoints for a stable series, got %+v", cps)
+	}
+}
+
+func TestDetectChangepoints_PauseShift(t *testing.T) {
+	metrics := createShiftMetrics(30, 30, 10*1024*1024, 10*1024*1024)
+	// Pauses jump from 100µs to 1ms per GC from sample 15 on
+	for i := 15; i < len(metrics); i++ {
+		metrics[i].PauseTotalNs = metrics[14].PauseTotalNs + uint64(i-14)*1000000
+	}
+
+	cps, err := New(metrics).DetectChangepoints()
+	if err != nil {
+		t.Fatalf("DetectChangepoints() error: %v", err)
+	}
+	if len(cps) != 1 || cps[0].Metric != types.ChangepointAvgPause || cps[0].Index != 15 {
+		t.Fatalf("Expected one avg_pause changepoint at 15, got %+v", cps)
+	}
+}
+
+func TestDetectChangepoints_InsufficientData(t *testing.T) {
+	metrics := createShiftMetrics(2*types.MinChangepointSegment, 5, 1, 2)
+	if _, err := New(metrics).DetectChangepoints(); !errors.Is(err, types.ErrInsufficientData) {
+		t.Errorf("Expected ErrInsufficientData, got %v", err)
+	}
+}
+
+func TestPrefixSums_SSE(t *testing.T) {
+	// Allocation rates: large values with a small spread
+	ys := make([]float64, 50)
+	for i := range ys {
+		ys[i] = 2e9 + float64(i%7)*4096
+	}
+	p := newPrefixSums(ys)
+
+	for _, seg := range [][2]int{{0, 50}, {0, 5}, {13, 14}, {20, 41}} {
+		lo, hi := seg[0], seg[1]
+		_, variance := meanVar(ys[lo:hi])
+		want := variance * float64(hi-lo)
+		if got := p.sse(lo, hi); math.Abs(got-want) > 1e-6*want+1e-3 {
+			t.Errorf("sse(%d, %d) = %v, want %v", lo, hi, got, want)
+		}
+	}
+}
+
+func BenchmarkDetectChangepoints(b *testing.B) {
+	metrics := createShiftMetrics(20000, 10000, 10*1024*1024, 30*1024*1024)
+	analyzer := New(metrics)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = analyzer.DetectChangepoints()
+	}
+}
@@ -0,0 +1,103 @@
+package analysis
+
+import (
+	"math"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// maxDurationSeconds is the largest number of seconds representable as a time.Duration
+const maxDurationSeconds = float64(math.MaxInt64) / float64(time.Second)
+
+// ForecastOOM fits the recent memory growth trend and projects when Go-managed
+// memory (Sys minus memory released to the OS) will reach limit.
+// The limit is typically the container memory limit or GOMEMLIMIT.
+// Returns ErrInvalidMemoryLimit for a zero limit and ErrInsufficientData when
+// fewer than MinSamplesForForecast samples are available.
+func (a *Analyzer) ForecastOOM(limit uint64) (*types.OOMForecast, error) {
+	if limit == 0 {
+		return nil, types.ErrInvalidMemoryLimit
+	}
+	if len(a.metrics) < types.MinSamplesForForecast {
+		return nil, &types.InsufficientDataError{Op: "forecast OOM", Samples: len(a.metrics), Required: types.MinSamplesForForecast}
+	}
+
+	// Only the recent window matters for projection
+	recent := a.metrics
+	if len(recent) > types.OOMForecastWindow {
+		recent = recent[len(recent)-types.OOMForecastWindow:]
+	}
+
+	origin := recent[0].Timestamp
+	xs := make([]float64, len(recent))
+	ys := make([]float64, len(recent))
+	for i, m := range recent {
+		xs[i] = m.Timestamp.Sub(origin).Seconds()
+		ys[i] = float64(memoryInUse(m))
+	}
+
+	fit := linearRegression(xs, ys)
+	last := recent[len(recent)-1]
+	current := memoryInUse(last)
+
+	forecast := &types.OOMForecast{
+		Limit:        limit,
+		CurrentUsage: current,
+		GrowthRate:   fit.Slope,
+		RSquared:     fit.RSquared,
+		EstimatedAt:  last.Timestamp,
+	}
+
+	if current >= limit {
+		forecast.Exceeded = true
+		forecast.WillExceed = true
+		return forecast, nil
+	}
+
+	if fit.Slope > 0 {
+		seconds := float64(limit-current) / fit.Slope
+		// Projections beyond the representable duration range are treated as "never"
+		if seconds < maxDurationSeconds {
+			forecast.TimeToLimit = time.Duration(seconds * float64(time.Second))
+			forecast.WillExceed = true
+		}
+	}
+
+	return forecast, nil
+}
+
+// analyzeMemoryLimit records the container memory limit reported by the
+// latest sample (or the pod spec), how much of it is in use, and when it will
+// be reached
+func (a *Analyzer) analyzeMemoryLimit(analysis *types.GCAnalysis) {
+	last := a.metrics[len(a.metrics)-1]
+	limit := last.CgroupMemoryLimit
+	if k := a.kubernetes(); limit == 0 && k != nil {
+		limit = k.MemoryLimit
+	}
+	if limit == 0 {
+		return
+	}
+
+	// The kernel charges resident memory against the limit; without RSS,
+	// Go-managed memory is the closest estimate
+	usage := last.ProcessRSS
+	if usage == 0 {
+		usage = memoryInUse(last)
+	}
+	analysis.MemoryLimit = limit
+	analysis.MemoryLimitUsage = float64(usage) / float64(limit)
+
+	if forecast, err := a.ForecastOOM(limit); err == nil {
+		analysis.OOMForecast = forecast
+	}
+}
+
+// memoryInUse returns the Go-managed memory that counts against a memory limit
+func memoryInUse(m *types.GCMetrics) uint64 {
+	if m.HeapReleased > m.Sys {
+		return 0
+	}
+	return m.Sys - m.HeapReleased
+}
@@ -0,0 +1,119 @@
+package analysis
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/i18n"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// createGrowthMetrics creates samples whose Sys grows by step bytes per interval
+func createGrowthMetrics(count int, start, step uint64, interval time.Duration) []*types.GCMetrics {
+	baseTime := time.Now()
+	metrics := make([]*types.GCMetrics, count)
+	for i := 0; i < count; i++ {
+		metrics[i] = &types.GCMetrics{
+			Sys:       start + uint64(i)*step,
+			Timestamp: baseTime.Add(time.Duration(i) * interval),
+		}
+	}
+	return metrics
+}
+
+func TestForecastOOM(t *testing.T) {
+	const mb = 1024 * 1024
+
+	// 1 MB/s growth, 100 MB used after 10 samples, 200 MB limit => ~100s
+	metrics := createGrowthMetrics(11, 90*mb, mb, time.Second)
+	forecast, err := New(metrics).ForecastOOM(200 * mb)
+	if err != nil {
+		t.Fatalf("ForecastOOM() error: %v", err)
+	}
+
+	if !forecast.WillExceed {
+		t.Fatal("WillExceed should be true for growing memory")
+	}
+	if forecast.Exceeded {
+		t.Error("Exceeded should be false below the limit")
+	}
+	if forecast.TimeToLimit < 99*time.Second || forecast.TimeToLimit > 101*time.Second {
+		t.Errorf("TimeToLimit = %v, want ~100s", forecast.TimeToLimit)
+	}
+	if forecast.RSquared < 0.99 {
+		t.Errorf("RSquared = %v, want ~1 for linear growth", forecast.RSquared)
+	}
+}
+
+func TestForecastOOM_NotGrowing(t *testing.T) {
+	metrics := createGrowthMetrics(10, 100*1024*1024, 0, time.Second)
+	forecast, err := New(metrics).ForecastOOM(200 * 1024 * 1024)
+	if err != nil {
+		t.Fatalf("ForecastOOM() error: %v", err)
+	}
+	if forecast.WillExceed || forecast.TimeToLimit != 0 {
+		t.Errorf("Flat memory should not be forecast to exceed, got %+v", forecast)
+	}
+}
+
+func TestForecastOOM_AlreadyExceeded(t *testing.T) {
+	metrics := createGrowthMetrics(5, 300*1024*1024, 1024, time.Second)
+	forecast, err := New(metrics).ForecastOOM(200 * 1024 * 1024)
+	if err != nil {
+		t.Fatalf("ForecastOOM() error: %v", err)
+	}
+	if !forecast.Exceeded {
+		t.Error("Exceeded should be true when usage is above the limit")
+	}
+}
+
+func TestForecastOOM_Errors(t *testing.T) {
+	if _, err := New(createGrowthMetrics(10, 1, 1, time.Second)).ForecastOOM(0); !errors.Is(err, types.ErrInvalidMemoryLimit) {
+		t.Errorf("Expected ErrInvalidMemoryLimit, got %v", err)
+	}
+	if _, err := New(createGrowthMetrics(2, 1, 1, time.Second)).ForecastOOM(100); !errors.Is(err, types.ErrInsufficientData) {
+		t.Errorf("Expected ErrInsufficientData, got %v", err)
+	}
+}
+
+func TestAnalyzeMemoryLimit(t *testing.T) {
+	const mb = 1024 * 1024
+
+	metrics := createGrowthMetrics(11, 90*mb, mb, time.Second)
+	analysis, err := NewWithOptions(metrics, nil, &Options{Runtime: &types.RuntimeInfo{}}).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if analysis.MemoryLimit != 0 || analysis.OOMForecast != nil {
+		t.Errorf("Expected no limit or forecast without a container limit, got %d, %+v", analysis.MemoryLimit, analysis.OOMForecast)
+	}
+
+	// 100 MB in use against a 110 MB container limit, RSS taking precedence
+	for _, m := range metrics {
+		m.CgroupMemoryLimit = 110 * mb
+	}
+	metrics[len(metrics)-1].ProcessRSS = 104 * mb
+	analysis, err = NewWithOptions(metrics, nil, &Options{Runtime: &types.RuntimeInfo{}}).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if analysis.MemoryLimit != 110*mb || analysis.MemoryLimitUsage < 0.94 || analysis.MemoryLimitUsage > 0.95 {
+		t.Errorf("MemoryLimit = %d, MemoryLimitUsage = %v, want 110MB and ~0.945", analysis.MemoryLimit, analysis.MemoryLimitUsage)
+	}
+	if f := analysis.OOMForecast; f == nil || f.Limit != 110*mb || !f.WillExceed {
+		t.Errorf("Expected a forecast against the container limit, got %+v", f)
+	}
+	for _, key := range []i18n.Key{i18n.RecNearMemoryLimit, i18n.RecSetGOMemLimit} {
+		if !slices.Contains(analysis.Recommendations, i18n.T(i18n.English, key)) {
+			t.Errorf("Expected %s recommendation, got %v", key, analysis.Recommendations)
+		}
+	}
+
+	// GOMEMLIMIT below the container limit needs no advice
+	analysis, _ = NewWithOptions(metrics, nil, &Options{Runtime: &types.RuntimeInfo{GOMemLimit: 99 * mb}}).Analyze()
+	if slices.Contains(analysis.Recommendations, i18n.T(i18n.English, i18n.RecSetGOMemLimit)) {
+		t.Error("GOMEMLIMIT recommendation should not be given when it is below the container limit")
+	}
+}
@@ -0,0 +1,154 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// detectLeak fits a linear regression over the post-GC heap floor.
+// Heap samples taken right after a GC cycle reflect the live set rather than
+// garbage waiting to be collected, so a steady upward slope in them is a much
+// stronger leak signal than raw HeapAlloc growth.
+// When the heap follows a periodic pattern, the lowest floor of the first and
+// last full period must also have grown, so a window ending at the top of a
+// batch cycle is not mistaken for a leak.
+// Returns nil when there are fewer than MinSamplesForTrendAnalysis samples.
+func (a *Analyzer) detectLeak(periodicity *types.PeriodicityAnalysis) *types.LeakAnalysis {
+	if len(a.metrics) < types.MinSamplesForTrendAnalysis {
+		return nil
+	}
+
+	points, source := heapFloor(a.metrics)
+	xs, ys := heapSeries(points, source)
+
+	fit := linearRegression(xs, ys)
+	leak := &types.LeakAnalysis{
+		Slope:    fit.Slope,
+		RSquared: fit.RSquared,
+		Samples:  len(points),
+		Source:   source,
+	}
+
+	// Relative growth of the fitted floor over the observed window
+	span := xs[len(xs)-1]
+	if start := fit.Intercept; start > 0 && span > 0 {
+		leak.RelativeGrowth = fit.Slope * span / start
+	}
+
+	leak.Confidence = leakConfidence(fit.RSquared, len(points), source)
+	leak.Suspected = fit.Slope > 0 &&
+		leak.RelativeGrowth > types.ThresholdConsistentGrowth &&
+		leak.Confidence != types.ConfidenceLow
+
+	if periodicity != nil && periodicity.Detected {
+		leak.Periodic = true
+		leak.CycleGrowth = cycleFloorGrowth(points, source, periodicity.Period)
+		leak.Suspected = leak.Suspected && leak.CycleGrowth > types.ThresholdConsistentGrowth
+	}
+
+	return leak
+}
+
+// heapFloor returns the samples used to track the live heap and where their
+// values come from: the runtime's own live heap when every sample reports it,
+// else the post-GC floor when enough GC cycles were observed, otherwise every
+// sample
+func heapFloor(metrics []*types.GCMetrics) ([]*types.GCMetrics, string) {
+	if hasLiveHeap(metrics) {
+		// Every sample reports the live set as of the last GC, so none of
+		// them is taken mid-cycle
+		return metrics, types.LeakSourceLiveHeap
+	}
+
+	points := postGCFloor(metrics)
+	if len(points) < types.MinSamplesForTrendAnalysis {
+		// Not enough GC cycles observed; fall back to every sample
+		return metrics, types.LeakSourceAllSamples
+	}
+	return points, types.LeakSourcePostGC
+}
+
+// hasLiveHeap reports whether every sample carries the runtime's live heap
+func hasLiveHeap(metrics []*types.GCMetrics) bool {
+	for _, m := range metrics {
+		if m.HeapLive == 0 {
+			return false
+		}
+	}
+	return len(metrics) > 0
+}
+
+// floorValue returns the heap floor value of a sample for the given source
+func floorValue(m *types.GCMetrics, source string) uint64 {
+	if source == types.LeakSourceLiveHeap {
+		return m.HeapLive
+	}
+	return m.HeapInuse
+}
+
+// heapSeries converts samples to seconds since the first sample and their
+// floor values
+func heapSeries(points []*types.GCMetrics, source string) (xs, ys []float64) {
+	origin := points[0].Timestamp
+	xs = make([]float64, len(points))
+	ys = make([]float64, len(points))
+	for i, m := range points {
+		xs[i] = m.Timestamp.Sub(origin).Seconds()
+		ys[i] = float64(floorValue(m, source))
+	}
+	return xs, ys
+}
+
+// cycleFloorGrowth returns the relative growth of the lowest floor value
+// between the first and the last full period of the window
+func cycleFloorGrowth(points []*types.GCMetrics, source string, period time.Duration) float64 {
+	first := points[0].Timestamp
+	last := points[len(points)-1].Timestamp
+
+	var firstMin, lastMin uint64
+	for _, m := range points {
+		v := floorValue(m, source)
+		if m.Timestamp.Sub(first) < period && (firstMin == 0 || v < firstMin) {
+			firstMin = v
+		}
+		if last.Sub(m.Timestamp) < period && (lastMin == 0 || v < lastMin) {
+			lastMin = v
+		}
+	}
+
+	if firstMin == 0 {
+		return 0
+	}
+	return (float64(lastMin) - float64(firstMin)) / float64(firstMin)
+}
+
+// postGCFloor returns the samples taken after at least one GC cycle completed
+// since the previous sample
+func postGCFloor(metrics []*types.GCMetrics) []*types.GCMetrics {
+	points := make([]*types.GCMetrics, 0, len(metrics))
+	for i := 1; i < len(metrics); i++ {
+		if metrics[i].NumGC > metrics[i-1].NumGC {
+			points = append(points, metrics[i])
+		}
+	}
+	return points
+}
+
+// leakConfidence maps goodness of fit and sample count to a confidence level
+func leakConfidence(rSquared float64, samples int, source string) types.Confidence {
+	confidence := types.ConfidenceLow
+	switch {
+	case rSquared >= types.LeakHighConfidenceR2 && samples >= 2*types.MinSamplesForTrendAnalysis:
+		confidence = types.ConfidenceHigh
+	case rSquared >= types.LeakMediumConfidenceR2:
+		confidence = types.ConfidenceMedium
+	}
+
+	// Raw samples include pre-GC peaks, so cap confidence at medium
+	if source == types.LeakSourceAllSamples && confidence == types.ConfidenceHigh {
+		confidence = types.ConfidenceMedium
+	}
+
+	return confidence
+}
@@ -0,0 +1,162 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/i18n"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// createSawtoothMetrics creates samples where the heap rises between GCs and
+// drops to a floor after each GC. The floor grows by floorStep per cycle.
+func createSawtoothMetrics(cycles int, floor, floorStep uint64) []*types.GCMetrics {
+	const samplesPerCycle = 4
+	baseTime := time.Now()
+	metrics := make([]*types.GCMetrics, 0, cycles*samplesPerCycle)
+
+	for c := 0; c < cycles; c++ {
+		cycleFloor := floor + uint64(c)*floorStep
+		for s := 0; s < samplesPerCycle; s++ {
+			i := c*samplesPerCycle + s
+			metrics = append(metrics, &types.GCMetrics{
+				NumGC:     uint32(c + 1), // a GC completes right before the first sample of each cycle
+				HeapInuse: cycleFloor + uint64(s)*8*1024*1024,
+				Timestamp: baseTime.Add(time.Duration(i) * time.Second),
+			})
+		}
+	}
+	return metrics
+}
+
+func TestDetectLeak_GrowingFloor(t *testing.T) {
+	metrics := createSawtoothMetrics(25, 64*1024*1024, 1024*1024)
+
+	leak := New(metrics).detectLeak(nil)
+	if leak == nil {
+		t.Fatal("detectLeak() returned nil")
+	}
+	if leak.Source != types.LeakSourcePostGC {
+		t.Errorf("Source = %q, want %q", leak.Source, types.LeakSourcePostGC)
+	}
+	if !leak.Suspected {
+		t.Errorf("Growing post-GC floor should be suspected as a leak: %+v", leak)
+	}
+	if leak.Confidence != types.ConfidenceHigh {
+		t.Errorf("Confidence = %q, want high for a clean linear floor", leak.Confidence)
+	}
+	if leak.RSquared < 0.99 {
+		t.Errorf("RSquared = %v, want ~1", leak.RSquared)
+	}
+}
+
+func TestDetectLeak_StableSawtooth(t *testing.T) {
+	// Large pre-GC peaks but a constant floor: not a leak
+	metrics := createSawtoothMetrics(25, 64*1024*1024, 0)
+
+	leak := New(metrics).detectLeak(nil)
+	if leak == nil {
+		t.Fatal("detectLeak() returned nil")
+	}
+	if leak.Suspected {
+		t.Errorf("Stable floor should not be suspected as a leak: %+v", leak)
+	}
+}
+
+func TestDetectLeak_InsufficientData(t *testing.T) {
+	if leak := New(createSawtoothMetrics(1, 1024, 0)).detectLeak(nil); leak != nil {
+		t.Errorf("Expected nil for too few samples, got %+v", leak)
+	}
+}
+
+func TestAnalyze_LeakRecommendationEvidence(t *testing.T) {
+	metrics := createSawtoothMetrics(25, 64*1024*1024, 1024*1024)
+
+	analysis, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+
+	found := false
+	for _, rec := range analysis.RecommendationDetails {
+		if rec.Message == "Consistent memory growth detected. Investigate potential memory leaks. ("+analysis.LeakDetection.Evidence()+")" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected leak recommendation with evidence, got %v", analysis.Recommendations)
+	}
+}
+
+// createMidCycleMetrics creates samples taken within one long GC cycle: the
+// heap climbs towards the goal while the live heap grows by liveStep per sample
+func createMidCycleMetrics(n int, liveStep uint64) []*types.GCMetrics {
+	baseTime := time.Now()
+	metrics := make([]*types.GCMetrics, n)
+	for i := range metrics {
+		metrics[i] = &types.GCMetrics{
+			NumGC:     1,
+			HeapAlloc: 64*1024*1024 + uint64(i)*16*1024*1024,
+			HeapInuse: 64*1024*1024 + uint64(i)*16*1024*1024,
+			HeapLive:  32*1024*1024 + uint64(i)*liveStep,
+			Timestamp: baseTime.Add(time.Duration(i) * time.Second),
+		}
+	}
+	return metrics
+}
+
+func TestDetectLeak_LiveHeap(t *testing.T) {
+	// Without the live heap, a heap climbing mid-cycle looks like a leak
+	metrics := createMidCycleMetrics(30, 0)
+	for _, m := range metrics {
+		m.HeapLive = 0
+	}
+	if leak := New(metrics).detectLeak(nil); leak.Source != types.LeakSourceAllSamples || !leak.Suspected {
+		t.Fatalf("Expected the all-samples fallback to flag mid-cycle growth, got %+v", leak)
+	}
+
+	leak := New(createMidCycleMetrics(30, 0)).detectLeak(nil)
+	if leak.Source != types.LeakSourceLiveHeap {
+		t.Errorf("Source = %q, want %q", leak.Source, types.LeakSourceLiveHeap)
+	}
+	if leak.Suspected {
+		t.Errorf("A steady live heap should not be suspected as a leak: %+v", leak)
+	}
+
+	leak = New(createMidCycleMetrics(30, 1024*1024)).detectLeak(nil)
+	if !leak.Suspected || leak.Confidence != types.ConfidenceHigh {
+		t.Errorf("A growing live heap should be a high confidence leak: %+v", leak)
+	}
+}
+
+func TestAnalyze_LiveHeap(t *testing.T) {
+	// 16 MB/s of heap growth with a steady live set is garbage, not retention
+	result, err := New(createMidCycleMetrics(30, 0)).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if result.AvgLiveHeap != 32*1024*1024 || result.LiveHeapGrowthRate != 0 {
+		t.Errorf("AvgLiveHeap = %d, LiveHeapGrowthRate = %v", result.AvgLiveHeap, result.LiveHeapGrowthRate)
+	}
+	if severity := recSeverity(result, i18n.RecHighHeapGrowth); severity != types.SeverityInfo {
+		t.Errorf("Heap growth with a steady live set should be info, got %q", severity)
+	}
+
+	result, err = New(createMidCycleMetrics(30, 1024*1024)).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if result.LiveHeapGrowthRate != 1024*1024 {
+		t.Errorf("LiveHeapGrowthRate = %v, want 1 MB/s", result.LiveHeapGrowthRate)
+	}
+}
+
+// recSeverity returns the severity of the recommendation for key, or "" if absent
+func recSeverity(result *types.GCAnalysis, key i18n.Key) types.Severity {
+	for _, rec := range result.RecommendationDetails {
+		if rec.Message == i18n.T(i18n.English, key) {
+			return rec.Severity
+		}
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package analysis
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// mergeEvents reconciles in-process events with events parsed from a gctrace
+// log covering the same window. Events are matched by GC sequence number:
+// gctrace supplies pause, phase and per-cycle heap data, while in-process
+// events keep their timestamps, which do not depend on knowing the process
+// start time. Trace events outside the cycles observed by metrics are dropped.
+// The inputs are not modified.
+func mergeEvents(metrics []*types.GCMetrics, events, trace []*types.GCEvent) []*types.GCEvent {
+	if len(trace) == 0 {
+		return events
+	}
+
+	var lo, hi uint32
+	if len(metrics) > 0 {
+		lo, hi = metrics[0].NumGC, metrics[len(metrics)-1].NumGC
+	}
+	inWindow := func(seq uint32) bool {
+		// Without GC counts in the metrics there is no window to restrict to
+		return hi == 0 || (seq > lo && seq <= hi)
+	}
+
+	merged := make(map[uint32]*types.GCEvent, len(events)+len(trace))
+	for _, e := range events {
+		merged[e.Sequence] = e
+	}
+
+	for _, t := range trace {
+		if !inWindow(t.Sequence) {
+			continue
+		}
+
+		e := *t
+		if existing, ok := merged[t.Sequence]; ok {
+			e.StartTime = existing.StartTime
+			e.EndTime = existing.EndTime
+			e.Region = existing.Region
+			// gctrace knows exactly whether a cycle was forced; the in-process
+			// classification can still refine an automatic cycle
+			if t.TriggerReason != types.TriggerForced &&
+				(existing.TriggerReason == types.TriggerHeapSize || existing.TriggerReason == types.TriggerPeriodic) {
+				e.TriggerReason = existing.TriggerReason
+			}
+			e.Source = types.EventSourceMerged
+		}
+		merged[t.Sequence] = &e
+	}
+
+	result := make([]*types.GCEvent, 0, len(merged))
+	for _, e := range merged {
+		result = append(result, e)
+	}
+	slices.SortFunc(result, func(x, y *types.GCEvent) int {
+		return cmp.Compare(x.Sequence, y.Sequence)
+	})
+
+	return result
+}
@@ -0,0 +1,94 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func createTraceEvents(first, count uint32) []*types.GCEvent {
+	events := make([]*types.GCEvent, 0, count)
+	for seq := first; seq < first+count; seq++ {
+		events = append(events, &types.GCEvent{
+			Sequence:      seq,
+			Duration:      2 * time.Millisecond,
+			HeapBefore:    8 * 1024 * 1024,
+			HeapLive:      2 * 1024 * 1024,
+			TriggerReason: "automatic",
+			Source:        types.EventSourceGCTrace,
+			Phases: &types.GCPhases{
+				SweepTermination: time.Millisecond,
+				ConcurrentMark:   5 * time.Millisecond,
+				MarkTermination:  time.Millisecond,
+			},
+		})
+	}
+	return events
+}
+
+func TestMergeEvents(t *testing.T) {
+	baseTime := time.Now()
+	metrics := []*types.GCMetrics{
+		{NumGC: 10, Timestamp: baseTime},
+		{NumGC: 13, Timestamp: baseTime.Add(time.Second)},
+	}
+	end := baseTime.Add(500 * time.Millisecond)
+	events := []*types.GCEvent{
+		{Sequence: 11, EndTime: end, Duration: time.Millisecond, TriggerReason: "heap_size", Source: types.EventSourceRuntime},
+	}
+	// Sequences 9..14: 9, 10 and 14 fall outside the metrics window
+	trace := createTraceEvents(9, 6)
+
+	merged := mergeEvents(metrics, events, trace)
+	if len(merged) != 3 {
+		t.Fatalf("Expected 3 events in window, got %d", len(merged))
+	}
+	for i, want := range []uint32{11, 12, 13} {
+		if merged[i].Sequence != want {
+			t.Errorf("merged[%d].Sequence = %d, want %d", i, merged[i].Sequence, want)
+		}
+	}
+
+	m := merged[0]
+	if m.Source != types.EventSourceMerged {
+		t.Errorf("Source = %q, want merged", m.Source)
+	}
+	if m.Duration != 2*time.Millisecond || m.Phases == nil {
+		t.Errorf("Merged event should take pause and phases from gctrace: %+v", m)
+	}
+	if !m.EndTime.Equal(end) || m.TriggerReason != "heap_size" {
+		t.Errorf("Merged event should keep in-process timing and trigger: %+v", m)
+	}
+	if events[0].Source != types.EventSourceRuntime {
+		t.Error("mergeEvents should not modify its inputs")
+	}
+}
+
+func TestNewWithOptions_GCTrace(t *testing.T) {
+	metrics := createTestMetrics(5, time.Now(), time.Second)
+	last := metrics[len(metrics)-1].NumGC
+	trace := createTraceEvents(metrics[0].NumGC+1, last-metrics[0].NumGC)
+
+	analysis, err := NewWithOptions(metrics, nil, &Options{GCTrace: trace}).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if analysis.AvgPauseTime != 2*time.Millisecond {
+		t.Errorf("AvgPauseTime = %v, want pause data from gctrace", analysis.AvgPauseTime)
+	}
+	if analysis.Phases == nil {
+		t.Fatal("Expected phase breakdown from gctrace events")
+	}
+	if analysis.Phases.AvgConcurrentMark != 5*time.Millisecond || analysis.Phases.Cycles != len(trace) {
+		t.Errorf("Phases = %+v", analysis.Phases)
+	}
+	if analysis.Phases.SweepTerminationShare != 0.5 || analysis.Phases.MarkTerminationShare != 0.5 {
+		t.Errorf("STW shares = %v/%v, want 0.5/0.5",
+			analysis.Phases.SweepTerminationShare, analysis.Phases.MarkTerminationShare)
+	}
+	if got := analysis.Phases.STWShare; math.Abs(got-2.0/7.0) > 1e-9 {
+		t.Errorf("STWShare = %v, want %v", got, 2.0/7.0)
+	}
+}
@@ -0,0 +1,108 @@
+package analysis
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// pauseSpan is a stop-the-world pause, in nanoseconds since the Unix epoch
+type pauseSpan struct {
+	start, end int64
+}
+
+// analyzeMMU computes the minimum mutator utilization for each window size:
+// of every window-long span within the analysis period, the one that GC
+// pauses took the largest share of. Averages can't tell a few long pauses
+// from many short ones; the MMU curve shows how long a span must be before
+// the application is guaranteed a given share of it.
+// Windows longer than the analysis period are skipped. Returns nil without
+// events.
+func (a *Analyzer) analyzeMMU() []types.MMUPoint {
+	pauses := a.pauseSpans()
+	if len(pauses) == 0 {
+		return nil
+	}
+
+	// The period covers the samples and every pause
+	from, to := pauses[0].start, pauses[len(pauses)-1].end
+	for _, p := range pauses {
+		to = max(to, p.end)
+	}
+	if len(a.metrics) > 0 {
+		from = min(from, a.metrics[0].Timestamp.UnixNano())
+		to = max(to, a.metrics[len(a.metrics)-1].Timestamp.UnixNano())
+	}
+
+	windows := a.opts.MMUWindows
+	if windows == nil {
+		windows = types.DefaultMMUWindows
+	}
+
+	curve := make([]types.MMUPoint, 0, len(windows))
+	for _, w := range windows {
+		if w <= 0 || int64(w) > to-from {
+			continue
+		}
+		paused := maxPausedIn(pauses, int64(w), from, to)
+		curve = append(curve, types.MMUPoint{
+			Window:      w,
+			Utilization: 1 - float64(paused)/float64(w),
+		})
+	}
+	return curve
+}
+
+// pauseSpans returns the events' pauses sorted by start, with overlapping
+// pauses merged
+func (a *Analyzer) pauseSpans() []pauseSpan {
+	spans := make([]pauseSpan, 0, len(a.events))
+	for _, e := range a.events {
+		if e.Duration <= 0 {
+			continue
+		}
+		start := e.StartTime.UnixNano()
+		spans = append(spans, pauseSpan{start: start, end: start + int64(e.Duration)})
+	}
+	slices.SortFunc(spans, func(x, y pauseSpan) int {
+		return cmp.Compare(x.start, y.start)
+	})
+
+	merged := spans[:0]
+	for _, s := range spans {
+		if n := len(merged); n > 0 && s.start <= merged[n-1].end {
+			merged[n-1].end = max(merged[n-1].end, s.end)
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
+
+// maxPausedIn returns the most pause time any window-long span within
+// [from, to] overlaps. The maximum is reached by a span starting where a
+// pause starts or ending where one ends, so only those are checked.
+func maxPausedIn(pauses []pauseSpan, window, from, to int64) int64 {
+	var most int64
+	check := func(start int64) {
+		start = min(max(start, from), to-window)
+		most = max(most, pausedIn(pauses, start, start+window))
+	}
+	for _, p := range pauses {
+		check(p.start)
+		check(p.end - window)
+	}
+	return most
+}
+
+// pausedIn returns the pause time overlapping [start, end)
+func pausedIn(pauses []pauseSpan, start, end int64) int64 {
+	i := sort.Search(len(pauses), func(i int) bool { return pauses[i].end > start })
+	var paused int64
+	for ; i < len(pauses) && pauses[i].start < end; i++ {
+		paused += min(pauses[i].end, end) - max(pauses[i].start, start)
+	}
+	return paused
+}
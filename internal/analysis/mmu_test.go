@@ -0,0 +1,64 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestAnalyzeMMU(t *testing.T) {
+	baseTime := time.Unix(1_700_000_000, 0)
+	metrics := createTestMetrics(11, baseTime, time.Second)
+	// A 5ms pause every second, and one 2ms pause right after the one at 5s
+	events := make([]*types.GCEvent, 0, 11)
+	for i := range 10 {
+		events = append(events, &types.GCEvent{
+			Sequence:  uint32(i + 1),
+			StartTime: baseTime.Add(time.Duration(i)*time.Second + 500*time.Millisecond),
+			Duration:  5 * time.Millisecond,
+		})
+	}
+	events = append(events, &types.GCEvent{
+		Sequence:  11,
+		StartTime: baseTime.Add(5*time.Second + 508*time.Millisecond),
+		Duration:  2 * time.Millisecond,
+	})
+
+	curve := NewWithEvents(metrics, events).analyzeMMU()
+	want := []types.MMUPoint{
+		{Window: time.Millisecond, Utilization: 0},        // inside a pause
+		{Window: 10 * time.Millisecond, Utilization: 0.3}, // 5ms + 2ms pauses 3ms apart
+		{Window: 100 * time.Millisecond, Utilization: 0.93},
+		{Window: time.Second, Utilization: 0.993}, // pauses a second apart never share a window
+	}
+	if len(curve) != len(want) {
+		t.Fatalf("analyzeMMU() = %+v, want %d points", curve, len(want))
+	}
+	for i, p := range curve {
+		if p.Window != want[i].Window || math.Abs(p.Utilization-want[i].Utilization) > 1e-9 {
+			t.Errorf("MMU[%d] = %v at %v, want %v", i, p.Utilization, p.Window, want[i].Utilization)
+		}
+	}
+}
+
+func TestAnalyzeMMU_Windows(t *testing.T) {
+	baseTime := time.Unix(1_700_000_000, 0)
+	events := []*types.GCEvent{
+		{Sequence: 1, StartTime: baseTime.Add(time.Second), Duration: time.Millisecond},
+	}
+	opts := &Options{MMUWindows: []time.Duration{2 * time.Millisecond, time.Minute}}
+
+	// The minute-long window doesn't fit into the 2s period
+	curve := NewWithOptions(createTestMetrics(3, baseTime, time.Second), events, opts).analyzeMMU()
+	if len(curve) != 1 || curve[0].Window != 2*time.Millisecond || curve[0].Utilization != 0.5 {
+		t.Errorf("analyzeMMU() = %+v, want 50%% at 2ms only", curve)
+	}
+}
+
+func TestAnalyzeMMU_NoEvents(t *testing.T) {
+	if curve := New(createTestMetrics(5, time.Now(), time.Second)).analyzeMMU(); curve != nil {
+		t.Errorf("analyzeMMU() = %+v, want nil without events", curve)
+	}
+}
@@ -0,0 +1,81 @@
+package analysis
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// analyzePauseOutliers lists the longest pauses among the events and flags
+// those more than ThresholdPauseOutlierIQR interquartile ranges above the
+// 75th percentile. Each listed pause carries the heap size and allocation
+// rate around it, which tell a pause caused by a large heap from one caused
+// by an allocation burst.
+// Returns nil when there are fewer than MinPausesForOutliers events.
+func (a *Analyzer) analyzePauseOutliers() *types.PauseOutliers {
+	if len(a.events) < types.MinPausesForOutliers {
+		return nil
+	}
+
+	durations := make([]time.Duration, len(a.events))
+	for i, e := range a.events {
+		durations[i] = e.Duration
+	}
+	sortDurations(durations)
+	p25 := durations[percentileIndex(len(durations), 0.25)]
+	p75 := durations[percentileIndex(len(durations), 0.75)]
+	threshold := p75 + time.Duration(types.ThresholdPauseOutlierIQR*float64(p75-p25))
+
+	longest := slices.Clone(a.events)
+	slices.SortStableFunc(longest, func(x, y *types.GCEvent) int {
+		return cmp.Compare(y.Duration, x.Duration)
+	})
+	longest = longest[:min(len(longest), types.TopPauseCount)]
+
+	outliers := &types.PauseOutliers{
+		Threshold: threshold,
+		Longest:   make([]types.PauseOutlier, len(longest)),
+	}
+	for _, d := range durations {
+		if d > threshold {
+			outliers.Count++
+		}
+	}
+	for i, e := range longest {
+		outliers.Longest[i] = a.pauseContext(e, threshold)
+	}
+	return outliers
+}
+
+// pauseContext describes an event's pause with the heap size and allocation
+// rate of the sample interval it falls in
+func (a *Analyzer) pauseContext(e *types.GCEvent, threshold time.Duration) types.PauseOutlier {
+	p := types.PauseOutlier{
+		Sequence: e.Sequence,
+		Time:     e.StartTime,
+		Duration: e.Duration,
+		HeapSize: e.HeapBefore,
+		Outlier:  e.Duration > threshold,
+	}
+	if len(a.metrics) < 2 {
+		return p
+	}
+
+	// The interval [i, i+1] containing the pause, clamped to the samples
+	i := sort.Search(len(a.metrics), func(i int) bool {
+		return a.metrics[i].Timestamp.After(e.StartTime)
+	}) - 1
+	i = min(max(i, 0), len(a.metrics)-2)
+	before, after := a.metrics[i], a.metrics[i+1]
+
+	if p.HeapSize == 0 {
+		p.HeapSize = before.HeapAlloc
+	}
+	if dt := after.Timestamp.Sub(before.Timestamp).Seconds(); dt > 0 && after.TotalAlloc >= before.TotalAlloc {
+		p.AllocRate = float64(after.TotalAlloc-before.TotalAlloc) / dt
+	}
+	return p
+}
@@ -0,0 +1,92 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// createPauseEvents creates one event per second with the given pauses
+func createPauseEvents(baseTime time.Time, pauses ...time.Duration) []*types.GCEvent {
+	events := make([]*types.GCEvent, len(pauses))
+	for i, d := range pauses {
+		events[i] = &types.GCEvent{
+			Sequence:  uint32(i + 1),
+			StartTime: baseTime.Add(time.Duration(i)*time.Second + 500*time.Millisecond),
+			Duration:  d,
+		}
+	}
+	return events
+}
+
+func TestAnalyzePauseOutliers(t *testing.T) {
+	baseTime := time.Now()
+	pauses := make([]time.Duration, 20)
+	for i := range pauses {
+		pauses[i] = time.Duration(100+i) * time.Microsecond
+	}
+	pauses[7] = 5 * time.Millisecond
+	pauses[15] = 2 * time.Millisecond
+
+	metrics := createTestMetrics(21, baseTime, time.Second)
+	a := NewWithEvents(metrics, createPauseEvents(baseTime, pauses...))
+	o := a.analyzePauseOutliers()
+	if o == nil {
+		t.Fatal("analyzePauseOutliers() returned nil")
+	}
+
+	if o.Count != 2 {
+		t.Errorf("Count = %d, want 2 outliers", o.Count)
+	}
+	if len(o.Longest) != types.TopPauseCount {
+		t.Fatalf("Longest has %d pauses, want %d", len(o.Longest), types.TopPauseCount)
+	}
+	for i, want := range []uint32{8, 16} {
+		if p := o.Longest[i]; p.Sequence != want || !p.Outlier {
+			t.Errorf("Longest[%d] = %+v, want outlier GC #%d", i, p, want)
+		}
+	}
+	if o.Longest[2].Outlier {
+		t.Errorf("Longest[2] = %+v, want a typical pause", o.Longest[2])
+	}
+
+	// GC #8 falls between samples 7 and 8
+	p := o.Longest[0]
+	if p.HeapSize != metrics[7].HeapAlloc {
+		t.Errorf("HeapSize = %d, want %d from the preceding sample", p.HeapSize, metrics[7].HeapAlloc)
+	}
+	wantRate := float64(metrics[8].TotalAlloc - metrics[7].TotalAlloc)
+	if p.AllocRate != wantRate {
+		t.Errorf("AllocRate = %v, want %v", p.AllocRate, wantRate)
+	}
+}
+
+func TestAnalyzePauseOutliers_HeapBefore(t *testing.T) {
+	baseTime := time.Now()
+	pauses := make([]time.Duration, types.MinPausesForOutliers)
+	for i := range pauses {
+		pauses[i] = 100 * time.Microsecond
+	}
+	events := createPauseEvents(baseTime, pauses...)
+	events[0].HeapBefore = 42 * 1024 * 1024
+
+	o := NewWithEvents(createTestMetrics(12, baseTime, time.Second), events).analyzePauseOutliers()
+	if o == nil {
+		t.Fatal("analyzePauseOutliers() returned nil")
+	}
+	if o.Count != 0 {
+		t.Errorf("Count = %d, want no outliers among equal pauses", o.Count)
+	}
+	if p := o.Longest[0]; p.Sequence != 1 || p.HeapSize != 42*1024*1024 {
+		t.Errorf("Longest[0] = %+v, want GC #1 with the heap before the cycle", p)
+	}
+}
+
+func TestAnalyzePauseOutliers_TooFewEvents(t *testing.T) {
+	baseTime := time.Now()
+	events := createPauseEvents(baseTime, time.Millisecond, time.Millisecond, 10*time.Millisecond)
+	if o := NewWithEvents(createTestMetrics(5, baseTime, time.Second), events).analyzePauseOutliers(); o != nil {
+		t.Errorf("analyzePauseOutliers() = %+v, want nil", o)
+	}
+}
@@ -0,0 +1,108 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// pagingInterval is a sample interval with GC cycles in it
+type pagingInterval struct {
+	gcs    uint32
+	pause  time.Duration
+	paging bool
+}
+
+// analyzePaging relates the pause per GC over each sample interval to OS
+// paging in it: an interval counts as paging when major page faults reach
+// ThresholdMajorFaultRate or swap usage grows. Returns nil when the samples
+// carry no paging data, and a zero analysis for a host that was sampled but
+// never paged.
+func (a *Analyzer) analyzePaging() *types.PagingAnalysis {
+	var (
+		p                 types.PagingAnalysis
+		sampled           bool
+		total, pagingTime time.Duration
+		faults            uint64
+		intervals         []pagingInterval
+		totalGCs          uint32
+		totalPause        time.Duration
+	)
+	for _, m := range a.metrics {
+		// Captures without the flag only show paging by its counters
+		if m.PagingSampled || m.MajorPageFaults > 0 || m.SwapUsed > 0 {
+			sampled = true
+		}
+		p.PeakSwap = max(p.PeakSwap, m.SwapUsed)
+	}
+	if !sampled {
+		return nil
+	}
+
+	for i := 1; i < len(a.metrics); i++ {
+		prev, cur := a.metrics[i-1], a.metrics[i]
+		dt := cur.Timestamp.Sub(prev.Timestamp)
+		if dt <= 0 {
+			continue
+		}
+		total += dt
+
+		// The fault counter restarts with the process
+		var delta uint64
+		if cur.MajorPageFaults >= prev.MajorPageFaults {
+			delta = cur.MajorPageFaults - prev.MajorPageFaults
+		}
+		faults += delta
+		paging := float64(delta)/dt.Seconds() >= types.ThresholdMajorFaultRate || cur.SwapUsed > prev.SwapUsed
+		if paging {
+			pagingTime += dt
+		}
+
+		if cur.NumGC <= prev.NumGC || cur.PauseTotalNs < prev.PauseTotalNs {
+			continue
+		}
+		iv := pagingInterval{
+			gcs:    cur.NumGC - prev.NumGC,
+			pause:  time.Duration(cur.PauseTotalNs - prev.PauseTotalNs),
+			paging: paging,
+		}
+		intervals = append(intervals, iv)
+		totalGCs += iv.gcs
+		totalPause += iv.pause
+	}
+	if total == 0 {
+		return &p
+	}
+	p.MajorFaultRate = float64(faults) / total.Seconds()
+	p.PagingShare = float64(pagingTime) / float64(total)
+	if totalGCs == 0 {
+		return &p
+	}
+
+	slow := float64(totalPause) / float64(totalGCs) * types.PagingSlowPauseFactor
+	var pagingGCs, otherGCs uint32
+	var pagingPause, otherPause time.Duration
+	for _, iv := range intervals {
+		if iv.paging {
+			pagingGCs += iv.gcs
+			pagingPause += iv.pause
+		} else {
+			otherGCs += iv.gcs
+			otherPause += iv.pause
+		}
+		if float64(iv.pause)/float64(iv.gcs) >= slow {
+			p.SlowIntervals++
+			if iv.paging {
+				p.SlowWhilePaging++
+			}
+		}
+	}
+	if pagingGCs > 0 {
+		p.AvgPausePaging = pagingPause / time.Duration(pagingGCs)
+	}
+	if otherGCs > 0 {
+		p.AvgPauseOther = otherPause / time.Duration(otherGCs)
+	}
+	p.Coincident = p.SlowWhilePaging > 0 && 2*p.SlowWhilePaging >= p.SlowIntervals
+	return &p
+}
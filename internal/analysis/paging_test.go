@@ -0,0 +1,115 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// createPagingMetrics creates one sample per second with one GC cycle per
+// interval. Interval i pauses pauses[i] and incurs faults[i] major faults.
+func createPagingMetrics(pauses []time.Duration, faults []uint64) []*types.GCMetrics {
+	baseTime := time.Now()
+	metrics := make([]*types.GCMetrics, len(pauses)+1)
+	metrics[0] = &types.GCMetrics{MajorPageFaults: 1, Timestamp: baseTime}
+	for i := range pauses {
+		prev := metrics[i]
+		metrics[i+1] = &types.GCMetrics{
+			NumGC:           prev.NumGC + 1,
+			PauseTotalNs:    prev.PauseTotalNs + uint64(pauses[i]),
+			MajorPageFaults: prev.MajorPageFaults + faults[i],
+			Timestamp:       baseTime.Add(time.Duration(i+1) * time.Second),
+		}
+	}
+	return metrics
+}
+
+func TestAnalyzePaging_Coincident(t *testing.T) {
+	pauses := []time.Duration{time.Millisecond, time.Millisecond, 20 * time.Millisecond, time.Millisecond,
+		time.Millisecond, 20 * time.Millisecond, time.Millisecond, time.Millisecond}
+	faults := []uint64{0, 0, 100, 0, 0, 60, 0, 0}
+	metrics := createPagingMetrics(pauses, faults)
+	metrics[6].SwapUsed = 64 * uint64(types.MB)
+
+	p := New(metrics).analyzePaging()
+	if p == nil {
+		t.Fatal("analyzePaging() returned nil")
+	}
+	if p.MajorFaultRate != 20 || p.PeakSwap != 64*uint64(types.MB) {
+		t.Errorf("Fault rate/peak swap = %v, %d", p.MajorFaultRate, p.PeakSwap)
+	}
+	// Swap growth in interval 5 counts as paging as well
+	if p.PagingShare != 0.25 {
+		t.Errorf("PagingShare = %v, want 0.25", p.PagingShare)
+	}
+	if p.AvgPausePaging != 20*time.Millisecond || p.AvgPauseOther != time.Millisecond {
+		t.Errorf("Pause paging/other = %v, %v", p.AvgPausePaging, p.AvgPauseOther)
+	}
+	if p.SlowIntervals != 2 || p.SlowWhilePaging != 2 || !p.Coincident {
+		t.Errorf("Expected coinciding slow intervals: %+v", p)
+	}
+
+	analysis, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	found := false
+	for _, rec := range analysis.RecommendationDetails {
+		switch rec.Code {
+		case types.CodeHostPaging:
+			found = true
+			if rec.Severity != types.SeverityWarning || rec.Evidence != p.Evidence() {
+				t.Errorf("Recommendation = %+v", rec)
+			}
+		case types.CodeLongPause, types.CodeVeryLongP99Pause:
+			if rec.Severity != types.SeverityInfo {
+				t.Errorf("Pauses caused by paging should only be noted, got %+v", rec)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Expected a host paging recommendation, got %v", analysis.Recommendations)
+	}
+}
+
+func TestAnalyzePaging_NotCoincident(t *testing.T) {
+	// Paging in intervals whose pauses are short
+	pauses := []time.Duration{time.Millisecond, 20 * time.Millisecond, time.Millisecond, time.Millisecond}
+	faults := []uint64{50, 0, 50, 0}
+	p := New(createPagingMetrics(pauses, faults)).analyzePaging()
+	if p == nil {
+		t.Fatal("analyzePaging() returned nil")
+	}
+	if p.SlowIntervals != 1 || p.SlowWhilePaging != 0 || p.Coincident {
+		t.Errorf("Slow pauses outside paging should not be flagged: %+v", p)
+	}
+
+	// Samples without paging data
+	metrics := createPagingMetrics(pauses, faults)
+	for _, m := range metrics {
+		m.MajorPageFaults = 0
+	}
+	if p := New(metrics).analyzePaging(); p != nil {
+		t.Errorf("analyzePaging() = %+v, want nil without paging data", p)
+	}
+}
+
+func TestAnalyzePaging_SampledWithoutPaging(t *testing.T) {
+	// A host with enough memory: sampled, but no faults and no swap
+	pauses := []time.Duration{time.Millisecond, time.Millisecond, time.Millisecond}
+	metrics := createPagingMetrics(pauses, make([]uint64, len(pauses)))
+	for _, m := range metrics {
+		m.MajorPageFaults = 0
+		m.PagingSampled = true
+	}
+
+	p := New(metrics).analyzePaging()
+	if p == nil {
+		t.Fatal("analyzePaging() = nil, want an analysis for a sampled host")
+	}
+	want := types.PagingAnalysis{AvgPauseOther: time.Millisecond}
+	if *p != want {
+		t.Errorf("analyzePaging() = %+v, want no paging", p)
+	}
+}
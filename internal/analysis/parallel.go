@@ -0,0 +1,144 @@
+package analysis
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// parallelThreshold is the dataset size from which sorting and aggregation
+// are split across goroutines. Below it the coordination costs more than
+// a single pass, as with samples collected live.
+const parallelThreshold = 100_000
+
+// parallelism returns how many parts to split a dataset of n elements into
+func parallelism(n int) int {
+	if n < parallelThreshold {
+		return 1
+	}
+	return min(runtime.GOMAXPROCS(0), n/(parallelThreshold/4))
+}
+
+// splitBounds returns the boundaries of n elements split into parts
+func splitBounds(n, parts int) []int {
+	bounds := make([]int, parts+1)
+	for i := range bounds {
+		bounds[i] = i * n / parts
+	}
+	return bounds
+}
+
+// sortDurations sorts d in ascending order, in parallel for large datasets
+func sortDurations(d []time.Duration) {
+	sortDurationsParallel(d, parallelism(len(d)))
+}
+
+// sortDurationsParallel sorts parts of d concurrently, then merges them in
+// pairs until one run remains
+func sortDurationsParallel(d []time.Duration, parts int) {
+	if parts < 2 {
+		slices.Sort(d)
+		return
+	}
+
+	bounds := splitBounds(len(d), parts)
+	var wg sync.WaitGroup
+	for i := range parts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slices.Sort(d[bounds[i]:bounds[i+1]])
+		}()
+	}
+	wg.Wait()
+
+	src, dst := d, make([]time.Duration, len(d))
+	for len(bounds) > 2 {
+		next := []int{0}
+		for i := 0; i+1 < len(bounds); i += 2 {
+			if i+2 == len(bounds) {
+				// Odd run out, carried over to the next round as is
+				copy(dst[bounds[i]:], src[bounds[i]:bounds[i+1]])
+				next = append(next, bounds[i+1])
+				break
+			}
+			lo, mid, hi := bounds[i], bounds[i+1], bounds[i+2]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				mergeDurations(dst[lo:hi], src[lo:mid], src[mid:hi])
+			}()
+			next = append(next, hi)
+		}
+		wg.Wait()
+		src, dst, bounds = dst, src, next
+	}
+	if &src[0] != &d[0] {
+		copy(d, src)
+	}
+}
+
+// mergeDurations merges the sorted runs a and b into dst
+func mergeDurations(dst, a, b []time.Duration) {
+	i, j, k := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		if b[j] < a[i] {
+			dst[k] = b[j]
+			j++
+		} else {
+			dst[k] = a[i]
+			i++
+		}
+		k++
+	}
+	k += copy(dst[k:], a[i:])
+	copy(dst[k:], b[j:])
+}
+
+// heapAggregate holds the total, minimum and maximum HeapAlloc of samples
+type heapAggregate struct {
+	total, min, max uint64
+}
+
+// aggregateHeap aggregates HeapAlloc over metrics, which must not be empty,
+// in parallel for large datasets
+func aggregateHeap(metrics []*types.GCMetrics) heapAggregate {
+	parts := parallelism(len(metrics))
+	if parts < 2 {
+		return aggregateHeapRange(metrics)
+	}
+
+	bounds := splitBounds(len(metrics), parts)
+	results := make([]heapAggregate, parts)
+	var wg sync.WaitGroup
+	for i := range parts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = aggregateHeapRange(metrics[bounds[i]:bounds[i+1]])
+		}()
+	}
+	wg.Wait()
+
+	agg := results[0]
+	for _, r := range results[1:] {
+		agg.total += r.total
+		agg.min = min(agg.min, r.min)
+		agg.max = max(agg.max, r.max)
+	}
+	return agg
+}
+
+// aggregateHeapRange aggregates HeapAlloc over metrics sequentially
+func aggregateHeapRange(metrics []*types.GCMetrics) heapAggregate {
+	agg := heapAggregate{min: metrics[0].HeapAlloc, max: metrics[0].HeapAlloc}
+	for _, m := range metrics {
+		agg.total += m.HeapAlloc
+		agg.min = min(agg.min, m.HeapAlloc)
+		agg.max = max(agg.max, m.HeapAlloc)
+	}
+	return agg
+}
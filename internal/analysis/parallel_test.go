@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func randomDurations(n int) []time.Duration {
+	r := rand.New(rand.NewPCG(1, 2))
+	d := make([]time.Duration, n)
+	for i := range d {
+		d[i] = time.Duration(r.Int64N(int64(time.Second)))
+	}
+	return d
+}
+
+func TestSortDurationsParallel(t *testing.T) {
+	for _, n := range []int{1, 2, 7, 1000, 1001} {
+		for parts := 1; parts <= 7; parts++ {
+			if parts > n {
+				continue
+			}
+			d := randomDurations(n)
+			want := slices.Clone(d)
+			slices.Sort(want)
+
+			sortDurationsParallel(d, parts)
+			if !slices.Equal(d, want) {
+				t.Errorf("n=%d parts=%d: not sorted", n, parts)
+			}
+		}
+	}
+}
+
+func TestAggregateHeap(t *testing.T) {
+	metrics := make([]*types.GCMetrics, parallelThreshold+3)
+	for i := range metrics {
+		metrics[i] = &types.GCMetrics{HeapAlloc: uint64(1000 + i%977)}
+	}
+	metrics[12345].HeapAlloc = 1
+	metrics[54321].HeapAlloc = 1 << 40
+
+	got := aggregateHeap(metrics)
+	want := aggregateHeapRange(metrics)
+	if got != want {
+		t.Errorf("aggregateHeap() = %+v, want %+v", got, want)
+	}
+	if got.min != 1 || got.max != 1<<40 {
+		t.Errorf("min/max = %d/%d, want 1/%d", got.min, got.max, uint64(1)<<40)
+	}
+}
+
+// BenchmarkSortDurations compares sequential and parallel sorting of a large
+// dataset; the parallel run scales with GOMAXPROCS
+func BenchmarkSortDurations(b *testing.B) {
+	data := randomDurations(4 * parallelThreshold)
+	d := make([]time.Duration, len(data))
+	run := func(parts int) func(*testing.B) {
+		return func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(d, data)
+				sortDurationsParallel(d, parts)
+			}
+		}
+	}
+	b.Run("sequential", run(1))
+	b.Run("parallel", run(runtime.GOMAXPROCS(0)))
+}
@@ -0,0 +1,139 @@
+package analysis
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// analyzePauseHistogram summarizes the pauses recorded by the runtime's pause
+// histogram between the first and last sample. Events and the PauseNs ring
+// buffer give exact durations but lose cycles when more than 256 complete
+// between samples, or when samples are taken without pause data; in that case
+// the pause percentiles and maximum are raised to the histogram's, so no
+// pause goes unreported.
+func (a *Analyzer) analyzePauseHistogram(analysis *types.GCAnalysis) {
+	first := a.metrics[0]
+	last := a.metrics[len(a.metrics)-1]
+
+	if first.PauseHistogram == nil {
+		return
+	}
+	window := last.PauseHistogram.Sub(first.PauseHistogram)
+	if window.Total() == 0 {
+		return
+	}
+
+	dist := &types.PauseDistribution{
+		Pauses: window.Total(),
+		P50:    window.Quantile(0.50),
+		P95:    window.Quantile(0.95),
+		P99:    window.Quantile(0.99),
+		Max:    window.Max(),
+	}
+	analysis.PauseDistribution = dist
+
+	if last.NumGC <= first.NumGC || a.eventsCover(first.NumGC, last.NumGC) {
+		return
+	}
+	dist.EventsIncomplete = true
+	analysis.P95PauseTime = max(analysis.P95PauseTime, dist.P95)
+	analysis.P99PauseTime = max(analysis.P99PauseTime, dist.P99)
+	analysis.MaxPauseTime = max(analysis.MaxPauseTime, dist.Max)
+}
+
+// analyzeLitePauses handles windows without individual pause times, such as
+// lite samples without events. It estimates a lower bound on the longest
+// pause from the PauseTotalNs deltas, and marks the pause statistics still
+// zero, because no histogram covered them either, as unavailable so they
+// don't read as zero-length pauses.
+func (a *Analyzer) analyzeLitePauses(analysis *types.GCAnalysis) {
+	first := a.metrics[0]
+	last := a.metrics[len(a.metrics)-1]
+	if len(a.events) > 0 || last.NumGC <= first.NumGC || slices.ContainsFunc(a.metrics, hasPauses) {
+		return
+	}
+
+	for i := 1; i < len(a.metrics); i++ {
+		prev, cur := a.metrics[i-1], a.metrics[i]
+		if cur.NumGC > prev.NumGC && cur.PauseTotalNs >= prev.PauseTotalNs {
+			avg := time.Duration((cur.PauseTotalNs - prev.PauseTotalNs) / uint64(cur.NumGC-prev.NumGC))
+			analysis.IntervalMaxAvgPause = max(analysis.IntervalMaxAvgPause, avg)
+		}
+	}
+
+	for _, f := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"min_pause_time", analysis.MinPauseTime},
+		{"max_pause_time", analysis.MaxPauseTime},
+		{"p95_pause_time", analysis.P95PauseTime},
+		{"p99_pause_time", analysis.P99PauseTime},
+	} {
+		if f.value == 0 {
+			analysis.Unavailable = append(analysis.Unavailable, f.name)
+		}
+	}
+}
+
+// hasPauses reports whether a sample carries any pause from the PauseNs ring
+// buffer
+func hasPauses(m *types.GCMetrics) bool {
+	return slices.ContainsFunc(m.PauseNs, func(ns uint64) bool { return ns > 0 })
+}
+
+// splitGaps separates gap markers from recorded events, returning the events
+// and the number of cycles the markers stand in for. The input is not modified.
+func splitGaps(events []*types.GCEvent) ([]*types.GCEvent, uint32) {
+	if !slices.ContainsFunc(events, (*types.GCEvent).IsGap) {
+		return events, 0
+	}
+	recorded := make([]*types.GCEvent, 0, len(events))
+	var missed uint32
+	for _, e := range events {
+		if e.IsGap() {
+			missed += e.Missed
+			continue
+		}
+		recorded = append(recorded, e)
+	}
+	return recorded, missed
+}
+
+// eventsCover reports whether there is an event for every GC cycle after
+// cycle from up to and including cycle to
+func (a *Analyzer) eventsCover(from, to uint32) bool {
+	seen := make(map[uint32]bool, to-from)
+	for _, event := range a.events {
+		if event.Sequence > from && event.Sequence <= to {
+			seen[event.Sequence] = true
+		}
+	}
+	return len(seen) == int(to-from)
+}
+
+// pauseApdex scores the events' pauses against the Apdex target.
+// Returns nil without events.
+func (a *Analyzer) pauseApdex() *types.PauseApdex {
+	if len(a.events) == 0 {
+		return nil
+	}
+
+	target := cmp.Or(a.opts.ApdexTarget, types.DefaultPauseApdexTarget)
+	apdex := &types.PauseApdex{Target: target}
+	for _, e := range a.events {
+		switch {
+		case e.Duration <= target:
+			apdex.Satisfied++
+		case e.Duration <= types.ApdexToleratingFactor*target:
+			apdex.Tolerating++
+		default:
+			apdex.Frustrated++
+		}
+	}
+	apdex.Score = (float64(apdex.Satisfied) + float64(apdex.Tolerating)/2) / float64(len(a.events))
+	return apdex
+}
@@ -0,0 +1,202 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// withPauseHistograms gives each sample a cumulative histogram that gains
+// perSample 1-2ms pauses and one 8ms+ pause per sample
+func withPauseHistograms(metrics []*types.GCMetrics, perSample uint64) {
+	buckets := []float64{0, 0.001, 0.002, 0.004, 0.008, math.MaxFloat64}
+	for i, m := range metrics {
+		n := uint64(i)
+		m.PauseHistogram = &types.PauseHistogram{
+			Counts:  []uint64{0, n * perSample, 0, 0, n},
+			Buckets: buckets,
+		}
+	}
+}
+
+func TestAnalyze_PauseHistogram_EventsIncomplete(t *testing.T) {
+	metrics := createTestMetrics(5, time.Now(), time.Second)
+	withPauseHistograms(metrics, 99)
+
+	// Events cover only the first cycles of the window
+	result, err := NewWithEvents(metrics, createTestEvents(12, time.Now())).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+
+	d := result.PauseDistribution
+	if d == nil {
+		t.Fatal("Expected a pause distribution")
+	}
+	if d.Pauses != 400 {
+		t.Errorf("Pauses = %d, want 400", d.Pauses)
+	}
+	if !d.EventsIncomplete {
+		t.Error("Events missing cycles should be flagged")
+	}
+	if result.MaxPauseTime != 8*time.Millisecond {
+		t.Errorf("MaxPauseTime = %v, want the histogram's 8ms", result.MaxPauseTime)
+	}
+	if result.P99PauseTime < 2*time.Millisecond {
+		t.Errorf("P99PauseTime = %v, want at least the histogram's 2ms", result.P99PauseTime)
+	}
+}
+
+func TestAnalyze_PauseHistogram_EventsComplete(t *testing.T) {
+	metrics := createTestMetrics(5, time.Now(), time.Second)
+	withPauseHistograms(metrics, 4)
+
+	// createTestMetrics runs cycles 10 to 30; events cover every one of them
+	result, err := NewWithEvents(metrics, createTestEvents(30, time.Now())).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+
+	d := result.PauseDistribution
+	if d == nil || d.EventsIncomplete {
+		t.Fatalf("Expected a complete pause distribution, got %+v", d)
+	}
+	if result.MaxPauseTime >= d.Max {
+		t.Errorf("MaxPauseTime = %v should come from the events, not the histogram", result.MaxPauseTime)
+	}
+}
+
+func TestAnalyze_PauseHistogram_Absent(t *testing.T) {
+	result, err := New(createTestMetrics(5, time.Now(), time.Second)).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if result.PauseDistribution != nil {
+		t.Errorf("Expected no pause distribution without histograms, got %+v", result.PauseDistribution)
+	}
+}
+
+func TestAnalyze_GapMarkers(t *testing.T) {
+	metrics := createTestMetrics(5, time.Now(), time.Second)
+	events := createTestEvents(10, time.Now())
+	events = append(events, &types.GCEvent{Sequence: 11, Missed: 300, TriggerReason: types.TriggerUnknown})
+
+	result, err := NewWithEvents(metrics, events).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if result.MissedEvents != 300 {
+		t.Errorf("MissedEvents = %d, want 300", result.MissedEvents)
+	}
+	if result.MinPauseTime != events[0].Duration {
+		t.Errorf("MinPauseTime = %v; gap markers should not count as pauses", result.MinPauseTime)
+	}
+	if len(events) != 11 {
+		t.Error("Analysis should not modify the events")
+	}
+}
+
+func TestAnalyze_PauseQuantiles(t *testing.T) {
+	metrics := createTestMetrics(3, time.Now(), time.Second)
+	metrics[2].PauseQuantiles = &types.PauseQuantiles{NumGC: 30, Pauses: 30, P99: time.Millisecond}
+
+	result, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if result.PauseQuantiles != metrics[2].PauseQuantiles {
+		t.Errorf("PauseQuantiles = %+v, want the last sample's", result.PauseQuantiles)
+	}
+}
+
+func TestPauseApdex(t *testing.T) {
+	baseTime := time.Now()
+	events := createPauseEvents(baseTime,
+		500*time.Microsecond, time.Millisecond, 800*time.Microsecond, 200*time.Microsecond, // satisfied
+		2*time.Millisecond, 4*time.Millisecond, // tolerating
+		5*time.Millisecond, 50*time.Millisecond, // frustrated
+	)
+
+	apdex := NewWithEvents(nil, events).pauseApdex()
+	if apdex == nil {
+		t.Fatal("pauseApdex() returned nil")
+	}
+	if apdex.Target != types.DefaultPauseApdexTarget {
+		t.Errorf("Target = %v, want the default %v", apdex.Target, types.DefaultPauseApdexTarget)
+	}
+	if apdex.Satisfied != 4 || apdex.Tolerating != 2 || apdex.Frustrated != 2 {
+		t.Errorf("Counts = %d/%d/%d, want 4/2/2", apdex.Satisfied, apdex.Tolerating, apdex.Frustrated)
+	}
+	if apdex.Score != 0.625 {
+		t.Errorf("Score = %v, want (4 + 2/2) / 8 = 0.625", apdex.Score)
+	}
+
+	apdex = NewWithOptions(nil, events, &Options{ApdexTarget: 10 * time.Millisecond}).pauseApdex()
+	if apdex.Satisfied != 7 || apdex.Tolerating != 0 || apdex.Frustrated != 1 {
+		t.Errorf("Counts with a 10ms target = %d/%d/%d, want 7/0/1", apdex.Satisfied, apdex.Tolerating, apdex.Frustrated)
+	}
+}
+
+func TestPauseApdex_NoEvents(t *testing.T) {
+	analysis, err := New(createTestMetrics(10, time.Now(), time.Second)).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if analysis.PauseApdex != nil {
+		t.Errorf("PauseApdex = %+v, want nil without events", analysis.PauseApdex)
+	}
+}
+
+func TestAnalyze_LitePauses(t *testing.T) {
+	metrics := createTestMetrics(5, time.Now(), time.Second)
+	for _, m := range metrics {
+		m.PauseNs, m.PauseEnd = nil, nil
+	}
+	// 5 cycles per sample; the last interval pauses 2ms more than the others
+	metrics[4].PauseTotalNs += 2_000_000
+
+	analysis, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	for _, field := range []string{"min_pause_time", "max_pause_time", "p95_pause_time", "p99_pause_time"} {
+		if !analysis.IsUnavailable(field) {
+			t.Errorf("IsUnavailable(%q) = false, want true; Unavailable = %v", field, analysis.Unavailable)
+		}
+	}
+	if want := 500 * time.Microsecond; analysis.IntervalMaxAvgPause != want {
+		t.Errorf("IntervalMaxAvgPause = %v, want %v", analysis.IntervalMaxAvgPause, want)
+	}
+	if analysis.AvgPauseTime == 0 {
+		t.Error("AvgPauseTime = 0, want the average from PauseTotalNs")
+	}
+}
+
+func TestAnalyze_LitePauses_WithHistogram(t *testing.T) {
+	metrics := createTestMetrics(5, time.Now(), time.Second)
+	for _, m := range metrics {
+		m.PauseNs, m.PauseEnd = nil, nil
+	}
+	withPauseHistograms(metrics, 4)
+
+	analysis, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if analysis.IsUnavailable("p99_pause_time") {
+		t.Errorf("Unavailable = %v, want the histogram's percentiles available", analysis.Unavailable)
+	}
+}
+
+func TestAnalyze_LitePauses_FullMetrics(t *testing.T) {
+	analysis, err := New(createTestMetrics(5, time.Now(), time.Second)).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if len(analysis.Unavailable) != 0 || analysis.IntervalMaxAvgPause != 0 {
+		t.Errorf("Unavailable = %v, IntervalMaxAvgPause = %v, want neither with pause buffers",
+			analysis.Unavailable, analysis.IntervalMaxAvgPause)
+	}
+}
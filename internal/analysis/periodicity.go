@@ -0,0 +1,55 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// detectPeriodicity looks for a recurring pattern in the heap floor, such as an
+// hourly batch job, using the autocorrelation of the detrended series.
+// The post-GC floor is used so the GC sawtooth itself is not reported.
+// Returns nil when there are fewer than MinSamplesForPeriodicity samples.
+func (a *Analyzer) detectPeriodicity() *types.PeriodicityAnalysis {
+	points, source := heapFloor(a.metrics)
+	n := len(points)
+	if n < types.MinSamplesForPeriodicity {
+		return nil
+	}
+
+	xs, ys := heapSeries(points, source)
+
+	// Remove the linear trend so a leak does not mask (or fake) a cycle
+	fit := linearRegression(xs, ys)
+	residuals := make([]float64, n)
+	for i := range ys {
+		residuals[i] = ys[i] - (fit.Slope*xs[i] + fit.Intercept)
+	}
+
+	// At least two full periods must fit in the window
+	acf := autocorrelation(residuals, n/2)
+
+	// Skip the initial decay: the period is the highest peak after the
+	// autocorrelation first turns negative
+	start := 1
+	for start < len(acf) && acf[start] >= 0 {
+		start++
+	}
+
+	result := &types.PeriodicityAnalysis{Samples: n}
+	bestLag := 0
+	for lag := start; lag < len(acf); lag++ {
+		if acf[lag] > result.Strength {
+			result.Strength = acf[lag]
+			bestLag = lag
+		}
+	}
+
+	if bestLag > 0 && result.Strength >= types.ThresholdPeriodicStrength {
+		spacing := (xs[n-1] - xs[0]) / float64(n-1)
+		result.Detected = true
+		result.Period = time.Duration(spacing * float64(bestLag) * float64(time.Second))
+	}
+
+	return result
+}
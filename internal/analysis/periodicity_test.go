@@ -0,0 +1,122 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/i18n"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// createBatchMetrics creates samples where a GC completes before every sample
+// and the heap floor ramps up by ramp per sample over each period before
+// dropping back, like a recurring batch job. The floor of each period grows
+// by periodStep.
+func createBatchMetrics(samples, period int, interval time.Duration, ramp, periodStep uint64) []*types.GCMetrics {
+	const floor = 64 * 1024 * 1024
+	baseTime := time.Now()
+	metrics := make([]*types.GCMetrics, samples)
+	for i := range metrics {
+		heap := floor + uint64(i/period)*periodStep + uint64(i%period)*ramp
+		metrics[i] = &types.GCMetrics{
+			NumGC:     uint32(i + 1),
+			HeapAlloc: heap,
+			HeapInuse: heap,
+			Timestamp: baseTime.Add(time.Duration(i) * interval),
+		}
+	}
+	return metrics
+}
+
+func TestDetectPeriodicity(t *testing.T) {
+	metrics := createBatchMetrics(65, 10, time.Minute, 4*1024*1024, 0)
+
+	p := New(metrics).detectPeriodicity()
+	if p == nil {
+		t.Fatal("detectPeriodicity() returned nil")
+	}
+	if !p.Detected {
+		t.Fatalf("Expected periodic workload to be detected: %+v", p)
+	}
+	if p.Period != 10*time.Minute {
+		t.Errorf("Period = %v, want 10m", p.Period)
+	}
+	if got, want := p.Summary(), "periodic workload detected, period ≈ 10m"; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+}
+
+func TestDetectPeriodicity_Linear(t *testing.T) {
+	metrics := createSawtoothMetrics(25, 64*1024*1024, 1024*1024)
+
+	p := New(metrics).detectPeriodicity()
+	if p == nil {
+		t.Fatal("detectPeriodicity() returned nil")
+	}
+	if p.Detected {
+		t.Errorf("Linear floor growth should not be periodic: %+v", p)
+	}
+}
+
+func TestDetectPeriodicity_InsufficientData(t *testing.T) {
+	metrics := createBatchMetrics(types.MinSamplesForPeriodicity-1, 5, time.Minute, 4*1024*1024, 0)
+	if p := New(metrics).detectPeriodicity(); p != nil {
+		t.Errorf("Expected nil with too few samples, got %+v", p)
+	}
+}
+
+func TestAnalyze_PeriodicWorkloadNotLeak(t *testing.T) {
+	// The window ends near the top of a batch cycle, so raw heap growth looks steep
+	metrics := createBatchMetrics(29, 10, time.Second, 64*1024*1024, 0)
+
+	analysis, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if analysis.Periodicity == nil || !analysis.Periodicity.Detected {
+		t.Fatalf("Expected periodicity to be detected: %+v", analysis.Periodicity)
+	}
+	leak := analysis.LeakDetection
+	if leak == nil || !leak.Periodic {
+		t.Fatalf("Expected leak analysis to account for periodicity: %+v", leak)
+	}
+	if leak.Suspected {
+		t.Errorf("Periodic workload should not be reported as a leak: %+v", leak)
+	}
+
+	if analysis.HeapGrowthRate <= types.ThresholdHeapGrowthRateHigh {
+		t.Fatalf("Test data should exceed the heap growth threshold, got %v", analysis.HeapGrowthRate)
+	}
+	found := false
+	for _, rec := range analysis.RecommendationDetails {
+		if rec.Message != i18n.T(i18n.English, i18n.RecHighHeapGrowth) {
+			continue
+		}
+		found = true
+		if rec.Severity != types.SeverityInfo {
+			t.Errorf("Heap growth within a periodic workload should be informational, got %q", rec.Severity)
+		}
+	}
+	if !found {
+		t.Error("Expected a heap growth recommendation")
+	}
+}
+
+func TestAnalyze_PeriodicWorkloadWithLeak(t *testing.T) {
+	metrics := createBatchMetrics(65, 10, time.Minute, 4*1024*1024, 8*1024*1024)
+
+	analysis, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	leak := analysis.LeakDetection
+	if leak == nil {
+		t.Fatal("Expected leak analysis")
+	}
+	if leak.Periodic && leak.CycleGrowth <= types.ThresholdConsistentGrowth {
+		t.Errorf("CycleGrowth = %v, want growth between periods", leak.CycleGrowth)
+	}
+	if !leak.Suspected {
+		t.Errorf("Growing floor across periods should be suspected as a leak: %+v", leak)
+	}
+}
@@ -0,0 +1,44 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// analyzePhases breaks GC cycles down into their stop-the-world and
+// concurrent phases using the events that carry phase data.
+// Returns nil when no event has phase data.
+func (a *Analyzer) analyzePhases() *types.PhaseBreakdown {
+	var sweep, mark, markTerm time.Duration
+	cycles := 0
+	for _, e := range a.events {
+		if e.Phases == nil {
+			continue
+		}
+		sweep += e.Phases.SweepTermination
+		mark += e.Phases.ConcurrentMark
+		markTerm += e.Phases.MarkTermination
+		cycles++
+	}
+
+	if cycles == 0 {
+		return nil
+	}
+
+	n := time.Duration(cycles)
+	breakdown := &types.PhaseBreakdown{
+		AvgSweepTermination: sweep / n,
+		AvgConcurrentMark:   mark / n,
+		AvgMarkTermination:  markTerm / n,
+		Cycles:              cycles,
+	}
+
+	if stw := sweep + markTerm; stw > 0 {
+		breakdown.SweepTerminationShare = float64(sweep) / float64(stw)
+		breakdown.MarkTerminationShare = float64(markTerm) / float64(stw)
+		breakdown.STWShare = float64(stw) / float64(stw+mark)
+	}
+
+	return breakdown
+}
@@ -0,0 +1,95 @@
+package analysis
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/i18n"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// sampleWarnings describes data quality problems in the samples themselves:
+// too few of them for trends, timestamps that go backwards or repeat, long
+// gaps in sampling, and process restarts
+func (a *Analyzer) sampleWarnings() []string {
+	var warnings []string
+	if n := len(a.metrics); n < types.MinSamplesForTrendAnalysis {
+		warnings = append(warnings, warning(i18n.WarnFewSamples, "samples: "+strconv.Itoa(n)))
+	}
+
+	anomalies := 0
+	for i := 1; i < len(a.metrics); i++ {
+		if !a.metrics[i].Timestamp.After(a.metrics[i-1].Timestamp) {
+			anomalies++
+		}
+	}
+	if anomalies > 0 {
+		warnings = append(warnings, warning(i18n.WarnClockAnomaly, "intervals: "+strconv.Itoa(anomalies)))
+	}
+	if gap := a.longestGap(); gap > 0 {
+		warnings = append(warnings, warning(i18n.WarnSampleGaps, "longest gap: "+gap.String()))
+	}
+
+	if n := len(a.resets); n > 0 {
+		warnings = append(warnings, warning(i18n.WarnCounterResets, "restarts: "+strconv.Itoa(n)))
+	}
+	return warnings
+}
+
+// longestGap returns the longest sample interval of at least
+// ThresholdSampleGapFactor times the expected interval: Options.Interval
+// when set, otherwise the median interval. Intervals spanning a restart,
+// which are warned about separately, and those next to compacted samples,
+// which retention tiers space out on purpose, are not counted. Returns 0
+// when there is none.
+func (a *Analyzer) longestGap() time.Duration {
+	intervals := make([]time.Duration, 0, len(a.metrics))
+	for i := 1; i < len(a.metrics); i++ {
+		prev, cur := a.metrics[i-1], a.metrics[i]
+		dt := cur.Timestamp.Sub(prev.Timestamp)
+		if dt <= 0 || prev.CompactedSamples > 0 || cur.CompactedSamples > 0 ||
+			slices.ContainsFunc(a.resets, func(r types.CounterReset) bool { return r.Index == i }) {
+			continue
+		}
+		intervals = append(intervals, dt)
+	}
+
+	expected := a.opts.Interval
+	if expected <= 0 {
+		if len(intervals) < 3 {
+			return 0
+		}
+		sorted := slices.Sorted(slices.Values(intervals))
+		expected = sorted[len(sorted)/2]
+	}
+
+	var longest time.Duration
+	for _, dt := range intervals {
+		if dt >= types.ThresholdSampleGapFactor*expected {
+			longest = max(longest, dt)
+		}
+	}
+	return longest
+}
+
+// pauseWarnings describes missing or incomplete pause data: cycles ran but
+// no individual pause was recorded, e.g. from lite samples without events,
+// or cycles were missed between samples
+func pauseWarnings(analysis *types.GCAnalysis) []string {
+	var warnings []string
+	if analysis.IsUnavailable("p99_pause_time") {
+		warnings = append(warnings, i18n.T(i18n.English, i18n.WarnNoPauseData))
+	}
+	if analysis.MissedEvents > 0 {
+		warnings = append(warnings, warning(i18n.WarnMissedEvents,
+			"missed cycles: "+strconv.FormatUint(uint64(analysis.MissedEvents), 10)))
+	}
+	return warnings
+}
+
+// warning returns the English message for key followed by a parenthesized
+// detail, which reports keep as is when translating the message
+func warning(key i18n.Key, detail string) string {
+	return i18n.T(i18n.English, key) + " (" + detail + ")"
+}
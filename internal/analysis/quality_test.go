@@ -0,0 +1,125 @@
+package analysis
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/i18n"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// hasWarning reports whether warnings include the message for key
+func hasWarning(warnings []string, key i18n.Key) bool {
+	return slices.ContainsFunc(warnings, func(w string) bool {
+		return strings.HasPrefix(w, i18n.T(i18n.English, key))
+	})
+}
+
+func TestAnalyze_Warnings(t *testing.T) {
+	metrics := createTestMetrics(20, time.Now(), time.Second)
+	analysis, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if len(analysis.Warnings) != 0 {
+		t.Errorf("Warnings = %q, want none for clean data", analysis.Warnings)
+	}
+
+	// Few lite samples, one repeated timestamp and a long gap
+	metrics = createTestMetrics(6, time.Now(), time.Second)
+	for _, m := range metrics {
+		m.PauseNs = nil
+	}
+	metrics[2].Timestamp = metrics[1].Timestamp
+	for _, m := range metrics[4:] {
+		m.Timestamp = m.Timestamp.Add(time.Minute)
+	}
+	analysis, err = New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	want := []string{
+		"Few samples were analyzed; rates and trends may be unreliable (samples: 6)",
+		"Sample timestamps go backwards or repeat; intervals around them are skipped (intervals: 1)",
+		"Sampling stopped for long stretches; rates are averaged over the gaps (longest gap: 1m1s)",
+		i18n.T(i18n.English, i18n.WarnNoPauseData),
+	}
+	if !slices.Equal(analysis.Warnings, want) {
+		t.Errorf("Warnings = %q, want %q", analysis.Warnings, want)
+	}
+
+	// The lite analysis leaves pauses out by design, so doesn't warn about them
+	lite, err := New(metrics).AnalyzeLite()
+	if err != nil {
+		t.Fatalf("AnalyzeLite() error: %v", err)
+	}
+	if len(lite.Warnings) != 3 || hasWarning(lite.Warnings, i18n.WarnNoPauseData) {
+		t.Errorf("AnalyzeLite() warnings = %q", lite.Warnings)
+	}
+}
+
+func TestAnalyze_WarningsIntentionalGaps(t *testing.T) {
+	// Retention tiers average older samples a minute apart
+	metrics := createTestMetrics(20, time.Now(), time.Second)
+	for i, m := range metrics[:5] {
+		m.CompactedSamples = 60
+		m.Timestamp = metrics[5].Timestamp.Add(time.Duration(i-5) * time.Minute)
+	}
+	analysis, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if hasWarning(analysis.Warnings, i18n.WarnSampleGaps) {
+		t.Errorf("Warnings = %q, want no gap warning for compacted samples", analysis.Warnings)
+	}
+
+	// An adaptive interval tightens to 250ms under load and relaxes to 5s
+	adaptive := append(createTestMetrics(15, time.Now(), 250*time.Millisecond),
+		createTestMetrics(10, time.Now().Add(4*time.Second), 5*time.Second)...)
+	for i, m := range adaptive {
+		m.NumGC = uint32(10 + i*5)
+		m.TotalAlloc = uint64(5+i) * uint64(types.MB)
+		m.Mallocs = uint64(1000 + i*500)
+		m.PauseTotalNs = uint64(1000000 + i*500000)
+	}
+	analysis, err = NewWithOptions(adaptive, nil, &Options{Interval: 5 * time.Second}).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if hasWarning(analysis.Warnings, i18n.WarnSampleGaps) {
+		t.Errorf("Warnings = %q, want no gap warning within the adaptive interval", analysis.Warnings)
+	}
+
+	// A real gap is still measured against the interval
+	for _, m := range adaptive[20:] {
+		m.Timestamp = m.Timestamp.Add(2 * time.Minute)
+	}
+	analysis, err = NewWithOptions(adaptive, nil, &Options{Interval: 5 * time.Second}).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if !slices.Contains(analysis.Warnings, "Sampling stopped for long stretches; rates are averaged over the gaps (longest gap: 2m5s)") {
+		t.Errorf("Warnings = %q, want a 2m5s gap", analysis.Warnings)
+	}
+}
+
+func TestAnalyze_WarningsMissedAndRestarts(t *testing.T) {
+	baseTime := time.Now()
+	metrics := append(createTestMetrics(10, baseTime, time.Second),
+		createTestMetrics(10, baseTime.Add(time.Minute), time.Second)...)
+	events := []*types.GCEvent{{Sequence: 12, Missed: 5}}
+
+	analysis, err := NewWithEvents(metrics, events).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if !hasWarning(analysis.Warnings, i18n.WarnCounterResets) || !hasWarning(analysis.Warnings, i18n.WarnMissedEvents) {
+		t.Errorf("Warnings = %q, want restart and missed cycle warnings", analysis.Warnings)
+	}
+	// The restart interval is not reported as a sampling gap as well
+	if hasWarning(analysis.Warnings, i18n.WarnSampleGaps) || hasWarning(analysis.Warnings, i18n.WarnFewSamples) {
+		t.Errorf("Warnings = %q", analysis.Warnings)
+	}
+}
@@ -0,0 +1,63 @@
+package analysis
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// analyzeReclamation follows how much each GC cycle reclaimed and how much of
+// the heap survived it. A heap that keeps its size while each cycle frees
+// less of it is filling with live objects: the cycles' survival ratio rises
+// as their reclaimed bytes fall, which a steady heap size hides.
+// Returns nil when fewer than MinSamplesForTrendAnalysis events carry the
+// heap before the cycle and the live heap it left.
+func (a *Analyzer) analyzeReclamation() *types.ReclamationAnalysis {
+	cycles := make([]*types.GCEvent, 0, len(a.events))
+	for _, e := range a.events {
+		if e.HeapBefore > 0 && e.HeapLive > 0 {
+			cycles = append(cycles, e)
+		}
+	}
+	if len(cycles) < types.MinSamplesForTrendAnalysis {
+		return nil
+	}
+	slices.SortFunc(cycles, func(x, y *types.GCEvent) int {
+		return cmp.Compare(x.Sequence, y.Sequence)
+	})
+
+	first := cycles[0].Sequence
+	xs := make([]float64, len(cycles))
+	reclaimed := make([]float64, len(cycles))
+	survival := make([]float64, len(cycles))
+	var totalReclaimed, totalSurvival float64
+	for i, e := range cycles {
+		xs[i] = float64(e.Sequence - first)
+		reclaimed[i] = float64(e.HeapReleased)
+		survival[i] = min(float64(e.HeapLive)/float64(e.HeapBefore), 1)
+		totalReclaimed += reclaimed[i]
+		totalSurvival += survival[i]
+	}
+
+	n := float64(len(cycles))
+	span := xs[len(xs)-1]
+	reclaimedFit := linearRegression(xs, reclaimed)
+	survivalFit := linearRegression(xs, survival)
+
+	r := &types.ReclamationAnalysis{
+		Cycles:           len(cycles),
+		AvgReclaimed:     uint64(totalReclaimed / n),
+		AvgSurvivalRatio: totalSurvival / n,
+		RSquared:         reclaimedFit.RSquared,
+		SurvivalTrend:    survivalFit.Slope * span,
+	}
+	if start := reclaimedFit.Intercept; start > 0 {
+		r.ReclaimedTrend = reclaimedFit.Slope * span / start
+	}
+
+	r.Declining = r.ReclaimedTrend < -types.ThresholdReclamationDecline &&
+		r.SurvivalTrend > 0 &&
+		r.RSquared >= types.LeakMediumConfidenceR2
+	return r
+}
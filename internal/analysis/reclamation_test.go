@@ -0,0 +1,106 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// createReclaimEvents creates GC events triggered at a steady heap of before
+// bytes, whose live heap grows by liveStep per cycle
+func createReclaimEvents(cycles int, before, live, liveStep uint64) []*types.GCEvent {
+	baseTime := time.Now()
+	events := make([]*types.GCEvent, cycles)
+	for i := range events {
+		l := live + uint64(i)*liveStep
+		events[i] = &types.GCEvent{
+			Sequence:     uint32(i + 1),
+			StartTime:    baseTime.Add(time.Duration(i) * time.Second),
+			Duration:     100 * time.Microsecond,
+			HeapBefore:   before,
+			HeapAfter:    l,
+			HeapLive:     l,
+			HeapReleased: before - l,
+		}
+	}
+	return events
+}
+
+func TestAnalyzeReclamation_Declining(t *testing.T) {
+	const mb = uint64(types.MB)
+	events := createReclaimEvents(20, 100*mb, 20*mb, 2*mb)
+
+	r := NewWithEvents(nil, events).analyzeReclamation()
+	if r == nil {
+		t.Fatal("analyzeReclamation() returned nil")
+	}
+	if r.Cycles != 20 {
+		t.Errorf("Cycles = %d, want 20", r.Cycles)
+	}
+	// Reclaimed drops from 80 MB to 42 MB and survival rises from 0.2 to 0.58
+	if r.ReclaimedTrend > -0.47 || r.ReclaimedTrend < -0.48 {
+		t.Errorf("ReclaimedTrend = %v, want about -0.475", r.ReclaimedTrend)
+	}
+	if r.SurvivalTrend < 0.379 || r.SurvivalTrend > 0.381 {
+		t.Errorf("SurvivalTrend = %v, want 0.38", r.SurvivalTrend)
+	}
+	if r.AvgReclaimed != 61*mb {
+		t.Errorf("AvgReclaimed = %d, want 61 MB", r.AvgReclaimed)
+	}
+	if !r.Declining {
+		t.Errorf("Shrinking reclamation should be flagged: %+v", r)
+	}
+}
+
+func TestAnalyzeReclamation_Steady(t *testing.T) {
+	const mb = uint64(types.MB)
+	events := createReclaimEvents(20, 100*mb, 40*mb, 0)
+
+	r := NewWithEvents(nil, events).analyzeReclamation()
+	if r == nil {
+		t.Fatal("analyzeReclamation() returned nil")
+	}
+	if r.Declining || r.ReclaimedTrend != 0 {
+		t.Errorf("Steady reclamation should not be flagged: %+v", r)
+	}
+	if math.Abs(r.AvgSurvivalRatio-0.4) > 1e-9 {
+		t.Errorf("AvgSurvivalRatio = %v, want 0.4", r.AvgSurvivalRatio)
+	}
+}
+
+func TestAnalyzeReclamation_WithoutHeapData(t *testing.T) {
+	events := createReclaimEvents(20, 0, 0, 0)
+	if r := NewWithEvents(nil, events).analyzeReclamation(); r != nil {
+		t.Errorf("analyzeReclamation() = %+v, want nil without heap data", r)
+	}
+
+	events = createReclaimEvents(types.MinSamplesForTrendAnalysis-1, 100, 50, 1)
+	if r := NewWithEvents(nil, events).analyzeReclamation(); r != nil {
+		t.Errorf("analyzeReclamation() = %+v, want nil with too few cycles", r)
+	}
+}
+
+func TestAnalyze_DecliningReclamationRecommendation(t *testing.T) {
+	const mb = uint64(types.MB)
+	events := createReclaimEvents(20, 100*mb, 20*mb, 2*mb)
+
+	analysis, err := NewWithEvents(createTestMetrics(20, time.Now(), time.Second), events).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if analysis.Reclamation == nil || !analysis.Reclamation.Declining {
+		t.Fatalf("Reclamation = %+v, want declining", analysis.Reclamation)
+	}
+
+	for _, rec := range analysis.RecommendationDetails {
+		if rec.Code == types.CodeDecliningReclamation {
+			if rec.Evidence != analysis.Reclamation.Evidence() {
+				t.Errorf("Evidence = %q, want %q", rec.Evidence, analysis.Reclamation.Evidence())
+			}
+			return
+		}
+	}
+	t.Errorf("Expected a declining reclamation recommendation, got %v", analysis.Recommendations)
+}
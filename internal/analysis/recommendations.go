@@ -0,0 +1,184 @@
+package analysis
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/i18n"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// recommendationInfo describes a kind of recommendation beyond its message
+type recommendationInfo struct {
+	code   types.RecommendationCode
+	title  string
+	detail string
+	action string
+}
+
+// recommendationInfos maps each recommendation message to its code, title,
+// detail and suggested action
+var recommendationInfos = map[i18n.Key]recommendationInfo{
+	i18n.RecHighGCFrequency: {
+		code:   types.CodeHighGCFrequency,
+		title:  "High GC frequency",
+		detail: "The collector runs more often than a steady workload needs, spending CPU on GC cycles.",
+		action: "Reduce the allocation rate or increase GOGC.",
+	},
+	i18n.RecFrequentForcedGC: {
+		code:   types.CodeFrequentForcedGC,
+		title:  "Frequent forced GC",
+		detail: "Explicit runtime.GC calls force full cycles regardless of heap size, bypassing GOGC pacing.",
+		action: "Remove runtime.GC calls from application code.",
+	},
+	i18n.RecLongPause: {
+		code:   types.CodeLongPause,
+		title:  "Long GC pauses",
+		detail: "Average GC pauses are long enough to add noticeable latency.",
+		action: "Reduce the heap size or optimize allocation patterns.",
+	},
+	i18n.RecVeryLongP99Pause: {
+		code:   types.CodeVeryLongP99Pause,
+		title:  "Very long P99 pauses",
+		detail: "The slowest GC pauses are long enough to impact responsiveness.",
+		action: "Reduce the heap size or optimize allocation patterns.",
+	},
+	i18n.RecHighHeapGrowth: {
+		code:   types.CodeHighHeapGrowth,
+		title:  "High heap growth",
+		detail: "The heap grows quickly over the analysis period.",
+		action: "Check for memory leaks or excessive allocations.",
+	},
+	i18n.RecHighGCOverhead: {
+		code:   types.CodeHighGCOverhead,
+		title:  "High GC overhead",
+		detail: "A large share of CPU time is spent in garbage collection.",
+		action: "Optimize allocation patterns or tune GOGC/GOMEMLIMIT.",
+	},
+	i18n.RecCPUSaturatedGC: {
+		code:   types.CodeCPUSaturatedGC,
+		title:  "GC competes with a CPU-saturated process",
+		detail: "The process is CPU-bound and GC takes a significant share of its CPU time.",
+		action: "Reduce the allocation rate, raise GOGC/GOMEMLIMIT or provision more CPU.",
+	},
+	i18n.RecLowMemoryEfficiency: {
+		code:   types.CodeLowMemoryEfficiency,
+		title:  "Low memory efficiency",
+		detail: "Much of the memory obtained from the OS is not in use by the heap.",
+		action: "Reduce heap fragmentation or optimize data structures.",
+	},
+	i18n.RecHighMarkAssist: {
+		code:   types.CodeHighMarkAssist,
+		title:  "High mark assist share",
+		detail: "Goroutines are drafted into GC marking on the request path, stalling them.",
+		action: "Reduce allocation in hot paths or give the GC more headroom with GOGC/GOMEMLIMIT.",
+	},
+	i18n.RecHighAllocationRate: {
+		code:   types.CodeHighAllocationRate,
+		title:  "High allocation rate",
+		detail: "The application allocates memory fast enough to drive frequent GC cycles.",
+		action: "Pool objects or reduce temporary object creation.",
+	},
+	i18n.RecPoolSmallObjects: {
+		code:   types.CodePoolSmallObjects,
+		title:  "Small objects dominate allocation",
+		detail: "Most allocated bytes are in objects of up to 32 KB.",
+		action: "Reuse short-lived objects of the hottest types with sync.Pool.",
+	},
+	i18n.RecReuseLargeBuffers: {
+		code:   types.CodeReuseLargeBuffers,
+		title:  "Large objects dominate allocation",
+		detail: "Most allocated bytes are in objects over 32 KB, which bypass the per-P allocation caches.",
+		action: "Reuse buffers across requests, e.g. pre-sized slices or pooled bytes.Buffer values.",
+	},
+	i18n.RecNonGoMemoryGrowth: {
+		code:   types.CodeNonGoMemoryGrowth,
+		title:  "Non-Go memory growth",
+		detail: "Process RSS grows outside Go-managed memory, which GC tuning cannot reclaim.",
+		action: "Look for cgo, mmap or native allocations that are never freed.",
+	},
+	i18n.RecNearMemoryLimit: {
+		code:   types.CodeNearMemoryLimit,
+		title:  "Near container memory limit",
+		detail: "Memory usage is close to the container limit, where the kernel OOM-kills the process.",
+		action: "Reduce the live heap or raise the limit.",
+	},
+	i18n.RecSetGOMemLimit: {
+		code:   types.CodeSetGOMemLimit,
+		title:  "GOMEMLIMIT not set below container limit",
+		detail: "The GC paces itself without regard to the container memory limit.",
+		action: "Set GOMEMLIMIT to about 90% of the container limit.",
+	},
+	i18n.RecGOMAXPROCSAboveCPULimit: {
+		code:   types.CodeGOMAXPROCSAboveCPULimit,
+		title:  "GOMAXPROCS above CPU limit",
+		detail: "GC workers sized for GOMAXPROCS exhaust the CPU quota, throttling the process and stretching pauses.",
+		action: "Set GOMAXPROCS to the CPU limit.",
+	},
+	i18n.RecConsistentGrowth: {
+		code:   types.CodeConsistentGrowth,
+		title:  "Suspected memory leak",
+		detail: "The heap remaining after each GC grows steadily.",
+		action: "Investigate potential memory leaks, e.g. with heap profiles taken some time apart.",
+	},
+	i18n.RecDecliningReclamation: {
+		code:   types.CodeDecliningReclamation,
+		title:  "GC cycles reclaim progressively less",
+		detail: "Each GC cycle frees fewer bytes while a growing share of the heap survives it, so the live set is growing.",
+		action: "Compare heap profiles taken some time apart to find the objects that accumulate.",
+	},
+	i18n.RecSoftLimitPressure: {
+		code:   types.CodeSoftLimitPressure,
+		title:  "Memory usage near GOMEMLIMIT",
+		detail: "Memory usage stays close to the soft memory limit, so the GC runs more often than GOGC alone would to stay below it.",
+		action: "Raise GOMEMLIMIT to the suggested value, keeping it below the container limit, or reduce the live heap.",
+	},
+	i18n.RecGCDeathSpiral: {
+		code:   types.CodeGCDeathSpiral,
+		title:  "GC death spiral near GOMEMLIMIT",
+		detail: "The live heap nearly fills the soft memory limit, so GC cycles run back to back and take up to half the CPU.",
+		action: "Raise GOMEMLIMIT well above the live heap or reduce the live heap; the limit is too low for this workload.",
+	},
+	i18n.RecHostPaging: {
+		code:   types.CodeHostPaging,
+		title:  "GC pauses coincide with OS paging",
+		detail: "The slowest GC pauses occur while the process incurs major page faults or is being swapped out, so the host is short of memory.",
+		action: "Give the host or container more memory or move memory-hungry neighbours off it; tuning GOGC won't shorten these pauses.",
+	},
+}
+
+// newRecommendation builds the recommendation for the catalog message key,
+// with evidence describing the observed values behind it
+func newRecommendation(key i18n.Key, severity types.Severity, evidence string) types.Recommendation {
+	info := recommendationInfos[key]
+	return types.Recommendation{
+		Code:            info.code,
+		Severity:        severity,
+		Title:           info.title,
+		Detail:          info.detail,
+		SuggestedAction: info.action,
+		Evidence:        evidence,
+		Message:         i18n.T(i18n.English, key),
+	}
+}
+
+// exceeds formats evidence of an observed value against its threshold,
+// e.g. "GC frequency 25.0/s (threshold 10.0/s)"
+func exceeds(metric, observed, threshold string) string {
+	return metric + " " + observed + " (threshold " + threshold + ")"
+}
+
+// percent formats a 0-1 share as a percentage
+func percent(share float64) string {
+	return strconv.FormatFloat(share*100, 'f', 1, 64) + "%"
+}
+
+// perSecond formats a frequency
+func perSecond(f float64) string {
+	return strconv.FormatFloat(f, 'f', 1, 64) + "/s"
+}
+
+// roundMicro rounds a duration for evidence strings
+func roundMicro(d time.Duration) string {
+	return d.Round(time.Microsecond).String()
+}
@@ -0,0 +1,85 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// regionAccumulator sums the sample intervals of one region label
+type regionAccumulator struct {
+	samples  int
+	duration time.Duration
+	gcs      uint32
+	pauseNs  uint64
+	alloc    uint64
+	heap     uint64
+}
+
+// analyzeRegions breaks the analysis down by the region label of each sample.
+// Each interval between consecutive samples is attributed to the label of the
+// sample ending it, so recurring phases such as a nightly batch window are
+// combined without counting the time between them.
+// Returns nil when no sample carries a label.
+func (a *Analyzer) analyzeRegions() []types.RegionBreakdown {
+	labeled := false
+	for _, m := range a.metrics {
+		if m.Region != "" {
+			labeled = true
+			break
+		}
+	}
+	if !labeled {
+		return nil
+	}
+
+	var order []string
+	acc := make(map[string]*regionAccumulator)
+	for i := 1; i < len(a.metrics); i++ {
+		prev, cur := a.metrics[i-1], a.metrics[i]
+		dt := cur.Timestamp.Sub(prev.Timestamp)
+		if dt <= 0 {
+			continue
+		}
+
+		r, ok := acc[cur.Region]
+		if !ok {
+			r = &regionAccumulator{}
+			acc[cur.Region] = r
+			order = append(order, cur.Region)
+		}
+		r.samples++
+		r.duration += dt
+		r.heap += cur.HeapAlloc
+		if cur.NumGC >= prev.NumGC {
+			r.gcs += cur.NumGC - prev.NumGC
+		}
+		if cur.PauseTotalNs >= prev.PauseTotalNs {
+			r.pauseNs += cur.PauseTotalNs - prev.PauseTotalNs
+		}
+		if cur.TotalAlloc >= prev.TotalAlloc {
+			r.alloc += cur.TotalAlloc - prev.TotalAlloc
+		}
+	}
+
+	breakdown := make([]types.RegionBreakdown, 0, len(order))
+	for _, name := range order {
+		r := acc[name]
+		seconds := r.duration.Seconds()
+		rb := types.RegionBreakdown{
+			Region:      name,
+			Samples:     r.samples,
+			Duration:    r.duration,
+			GCCount:     r.gcs,
+			GCFrequency: float64(r.gcs) / seconds,
+			AvgHeapSize: r.heap / uint64(r.samples),
+			AllocRate:   float64(r.alloc) / seconds,
+		}
+		if r.gcs > 0 {
+			rb.AvgPauseTime = time.Duration(r.pauseNs / uint64(r.gcs))
+		}
+		breakdown = append(breakdown, rb)
+	}
+
+	return breakdown
+}
@@ -0,0 +1,56 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnalyzeRegions(t *testing.T) {
+	metrics := createTestMetrics(7, time.Now(), time.Second)
+	regions := []string{"startup", "startup", "startup", "steady", "steady", "startup", "steady"}
+	for i, m := range metrics {
+		m.Region = regions[i]
+	}
+
+	result, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+
+	breakdown := result.RegionBreakdown
+	if len(breakdown) != 2 {
+		t.Fatalf("RegionBreakdown has %d entries, want 2: %+v", len(breakdown), breakdown)
+	}
+
+	// The first sample only opens the window, so startup gets intervals 1, 2 and 5
+	startup, steady := breakdown[0], breakdown[1]
+	if startup.Region != "startup" || startup.Samples != 3 || startup.Duration != 3*time.Second {
+		t.Errorf("startup = %+v", startup)
+	}
+	if steady.Region != "steady" || steady.Samples != 3 || steady.Duration != 3*time.Second {
+		t.Errorf("steady = %+v", steady)
+	}
+
+	// createTestMetrics advances NumGC by 5 and TotalAlloc by 1 MB per second
+	if startup.GCCount != 15 || startup.GCFrequency != 5 {
+		t.Errorf("startup GCs = %d (%.2f/s), want 15 (5/s)", startup.GCCount, startup.GCFrequency)
+	}
+	if startup.AllocRate != 1024*1024 {
+		t.Errorf("startup AllocRate = %v, want 1 MB/s", startup.AllocRate)
+	}
+	if startup.AvgPauseTime != 100*time.Microsecond {
+		t.Errorf("startup AvgPauseTime = %v, want 100µs", startup.AvgPauseTime)
+	}
+}
+
+func TestAnalyzeRegions_Unlabeled(t *testing.T) {
+	metrics := createTestMetrics(5, time.Now(), time.Second)
+
+	result, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if result.RegionBreakdown != nil {
+		t.Errorf("Unlabeled samples should produce no breakdown, got %+v", result.RegionBreakdown)
+	}
+}
@@ -0,0 +1,45 @@
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// CheckRegression compares current against baseline and returns a
+// *types.RegressionError listing every metric that grew beyond the policy's
+// tolerance, or nil when none did. Metrics that were zero in the baseline have
+// no relative change and are not checked. Returns ErrInsufficientData when
+// either analysis is missing.
+func CheckRegression(baseline, current *types.GCAnalysis, policy types.RegressionPolicy) error {
+	if baseline == nil || current == nil {
+		return fmt.Errorf("%w: check regression needs a baseline and a current analysis", types.ErrInsufficientData)
+	}
+
+	minPause := float64(policy.MinPauseIncrease)
+	checks := []struct {
+		delta     types.MetricDelta
+		tolerance float64
+		minChange float64 // smallest absolute increase that counts
+	}{
+		{metricDelta(types.DeltaP95Pause, float64(baseline.P95PauseTime), float64(current.P95PauseTime)), policy.P95Pause, minPause},
+		{metricDelta(types.DeltaP99Pause, float64(baseline.P99PauseTime), float64(current.P99PauseTime)), policy.P99Pause, minPause},
+		{metricDelta(types.DeltaGCFrequency, baseline.GCFrequency, current.GCFrequency), policy.GCFrequency, 0},
+		{metricDelta(types.DeltaAllocRate, baseline.AllocRate, current.AllocRate), policy.AllocRate, 0},
+	}
+
+	var regressions []types.Regression
+	for _, c := range checks {
+		if c.tolerance <= 0 || c.delta.Before <= 0 {
+			continue
+		}
+		if c.delta.Change > c.tolerance && c.delta.After-c.delta.Before >= c.minChange {
+			regressions = append(regressions, types.Regression{MetricDelta: c.delta, Tolerance: c.tolerance})
+		}
+	}
+
+	if len(regressions) == 0 {
+		return nil
+	}
+	return &types.RegressionError{Regressions: regressions}
+}
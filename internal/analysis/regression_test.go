@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestCheckRegression(t *testing.T) {
+	baseline := &types.GCAnalysis{
+		P95PauseTime: 5 * time.Millisecond,
+		P99PauseTime: 10 * time.Millisecond,
+		GCFrequency:  2,
+		AllocRate:    10 * 1024 * 1024,
+	}
+	policy := types.DefaultRegressionPolicy()
+
+	same := *baseline
+	same.GCFrequency = 2.2 // +10%, within tolerance
+	if err := CheckRegression(baseline, &same, policy); err != nil {
+		t.Errorf("CheckRegression() within tolerance error = %v", err)
+	}
+
+	worse := *baseline
+	worse.P99PauseTime = 15 * time.Millisecond
+	worse.AllocRate = 20 * 1024 * 1024
+	err := CheckRegression(baseline, &worse, policy)
+	if !errors.Is(err, types.ErrRegression) {
+		t.Fatalf("CheckRegression() error = %v, want ErrRegression", err)
+	}
+	var regErr *types.RegressionError
+	if !errors.As(err, &regErr) || len(regErr.Regressions) != 2 {
+		t.Fatalf("Expected a RegressionError with 2 regressions, got %v", err)
+	}
+	if r := regErr.Regressions[0]; r.Metric != types.DeltaP99Pause || r.Change != 0.5 {
+		t.Errorf("First regression = %+v, want p99_pause +50%%", r)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "p99_pause +50.0% (10ms -> 15ms, tolerance 20.0%)") ||
+		!strings.Contains(msg, "alloc_rate +100.0%") {
+		t.Errorf("Error() = %q", msg)
+	}
+}
+
+func TestCheckRegression_PauseNoiseAndDisabled(t *testing.T) {
+	baseline := &types.GCAnalysis{P99PauseTime: 100 * time.Microsecond, GCFrequency: 1}
+	current := &types.GCAnalysis{P99PauseTime: 300 * time.Microsecond, GCFrequency: 5}
+
+	// +200µs is below the default 1ms floor, and the frequency check is off
+	policy := types.DefaultRegressionPolicy()
+	policy.GCFrequency = 0
+	if err := CheckRegression(baseline, current, policy); err != nil {
+		t.Errorf("CheckRegression() error = %v, want nil", err)
+	}
+
+	policy.MinPauseIncrease = 0
+	if err := CheckRegression(baseline, current, policy); !errors.Is(err, types.ErrRegression) {
+		t.Errorf("CheckRegression() without a pause floor error = %v, want ErrRegression", err)
+	}
+}
+
+func TestCheckRegression_MissingAnalysis(t *testing.T) {
+	if err := CheckRegression(nil, &types.GCAnalysis{}, types.DefaultRegressionPolicy()); !errors.Is(err, types.ErrInsufficientData) {
+		t.Errorf("CheckRegression(nil) error = %v, want ErrInsufficientData", err)
+	}
+}
@@ -0,0 +1,79 @@
+package analysis
+
+import (
+	"slices"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// stitchResets detects counter resets, where a cumulative counter such as
+// NumGC or TotalAlloc drops because the process restarted, and rebases the
+// counters of every later sample onto the last values before the reset, so
+// deltas across the series stay non-negative instead of wrapping. The
+// interval spanning a reset is credited with what the new process counted
+// before its first sample. Synthetic samples, such as injected chaos, run
+// ahead of the real counters, so they are rebased but neither detect resets
+// nor supply offsets. Rebased samples are copies; the input is not modified.
+func stitchResets(metrics []*types.GCMetrics) ([]*types.GCMetrics, []types.CounterReset) {
+	var (
+		resets   []types.CounterReset
+		offset   types.GCMetrics
+		stitched = metrics
+		prev     *types.GCMetrics // last real sample
+	)
+	for i, cur := range metrics {
+		if !cur.Synthetic {
+			if prev != nil && counterReset(prev, cur) {
+				if resets == nil {
+					stitched = slices.Clone(metrics)
+				}
+				resets = append(resets, types.CounterReset{
+					Index:     i,
+					Timestamp: cur.Timestamp,
+					Gap:       cur.Timestamp.Sub(prev.Timestamp),
+				})
+				addCounters(&offset, prev)
+			}
+			prev = cur
+		}
+		if resets != nil {
+			m := cur.Clone()
+			addCounters(m, &offset)
+			stitched[i] = m
+		}
+	}
+	return stitched, resets
+}
+
+// counterReset reports whether a counter that only grows within a process
+// dropped between two samples
+func counterReset(prev, cur *types.GCMetrics) bool {
+	return cur.NumGC < prev.NumGC ||
+		cur.TotalAlloc < prev.TotalAlloc ||
+		cur.Mallocs < prev.Mallocs ||
+		cur.PauseTotalNs < prev.PauseTotalNs
+}
+
+// addCounters adds the cumulative counters of src to dst. Gauges and
+// settings are left alone.
+func addCounters(dst, src *types.GCMetrics) {
+	dst.NumGC += src.NumGC
+	dst.NumForcedGC += src.NumForcedGC
+	dst.PauseTotalNs += src.PauseTotalNs
+	dst.TotalAlloc += src.TotalAlloc
+	dst.Lookups += src.Lookups
+	dst.Mallocs += src.Mallocs
+	dst.Frees += src.Frees
+
+	dst.GCMarkAssistCPU += src.GCMarkAssistCPU
+	dst.GCMarkDedicatedCPU += src.GCMarkDedicatedCPU
+	dst.GCMarkIdleCPU += src.GCMarkIdleCPU
+	dst.GCPauseCPU += src.GCPauseCPU
+	dst.GCTotalCPU += src.GCTotalCPU
+	dst.TotalCPU += src.TotalCPU
+	dst.GCCyclesAutomatic += src.GCCyclesAutomatic
+	dst.GCCyclesForced += src.GCCyclesForced
+
+	dst.ProcessCPUSeconds += src.ProcessCPUSeconds
+	dst.MajorPageFaults += src.MajorPageFaults
+}
@@ -0,0 +1,108 @@
+package analysis
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestStitchResets(t *testing.T) {
+	baseTime := time.Now()
+	before := createTestMetrics(5, baseTime, time.Second)
+	after := createTestMetrics(5, baseTime.Add(9*time.Second), time.Second)
+	metrics := append(before, after...)
+
+	a := New(metrics)
+	if len(a.resets) != 1 {
+		t.Fatalf("resets = %+v, want one", a.resets)
+	}
+	reset := a.resets[0]
+	if reset.Index != 5 || reset.Gap != 5*time.Second || !reset.Timestamp.Equal(after[0].Timestamp) {
+		t.Errorf("reset = %+v, want sample 5 after a 5s gap", reset)
+	}
+
+	// Later samples continue from the last sample before the restart
+	last := a.metrics[len(a.metrics)-1]
+	if last.NumGC != 30+30 || last.TotalAlloc != 2*9*uint64(types.MB) {
+		t.Errorf("Stitched NumGC/TotalAlloc = %d, %d", last.NumGC, last.TotalAlloc)
+	}
+	for i := 1; i < len(a.metrics); i++ {
+		if a.metrics[i].NumGC < a.metrics[i-1].NumGC || a.metrics[i].PauseTotalNs < a.metrics[i-1].PauseTotalNs {
+			t.Errorf("Counters still drop at sample %d", i)
+		}
+	}
+	if after[4].NumGC != 30 || a.metrics[0] != before[0] {
+		t.Error("stitchResets should copy rebased samples and keep the others")
+	}
+
+	analysis, err := a.Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if len(analysis.CounterResets) != 1 {
+		t.Errorf("CounterResets = %+v, want one", analysis.CounterResets)
+	}
+	// 50 cycles over 13 seconds, rather than a wrapped negative delta
+	if want := 50.0 / 13; analysis.GCFrequency != want {
+		t.Errorf("GCFrequency = %v, want %v", analysis.GCFrequency, want)
+	}
+}
+
+func TestStitchResets_NoReset(t *testing.T) {
+	metrics := createTestMetrics(5, time.Now(), time.Second)
+	stitched, resets := stitchResets(metrics)
+	if resets != nil || &stitched[0] != &metrics[0] {
+		t.Errorf("stitchResets() without a reset should return the input, got %d resets", len(resets))
+	}
+}
+
+// withChaos returns metrics with n synthetic samples inserted after index at,
+// continuing from that sample with counters running ahead of the real ones
+func withChaos(metrics []*types.GCMetrics, at, n int) []*types.GCMetrics {
+	out := slices.Clone(metrics[:at+1])
+	for i := 1; i <= n; i++ {
+		m := metrics[at].Clone()
+		m.Synthetic = true
+		m.NumGC += uint32(100 * i)
+		m.TotalAlloc += uint64(i) * 100 * uint64(types.MB)
+		m.Mallocs += uint64(10000 * i)
+		m.PauseTotalNs += uint64(i) * uint64(time.Second)
+		m.Timestamp = m.Timestamp.Add(time.Duration(i) * 100 * time.Millisecond)
+		out = append(out, m)
+	}
+	return append(out, metrics[at+1:]...)
+}
+
+func TestStitchResets_Synthetic(t *testing.T) {
+	metrics := withChaos(createTestMetrics(6, time.Now(), time.Second), 2, 3)
+
+	stitched, resets := stitchResets(metrics)
+	if resets != nil {
+		t.Errorf("resets = %+v, want none: chaos samples are not a restart", resets)
+	}
+	if &stitched[0] != &metrics[0] {
+		t.Error("stitchResets() without a reset should return the input")
+	}
+}
+
+func TestStitchResets_SyntheticThenRestart(t *testing.T) {
+	baseTime := time.Now()
+	before := createTestMetrics(5, baseTime, time.Second)
+	after := createTestMetrics(5, baseTime.Add(9*time.Second), time.Second)
+	metrics := withChaos(append(before, after...), 4, 2)
+
+	stitched, resets := stitchResets(metrics)
+	if len(resets) != 1 || resets[0].Index != 7 || resets[0].Gap != 5*time.Second {
+		t.Fatalf("resets = %+v, want one at sample 7, 5s after the last real sample", resets)
+	}
+	// The offset is the last real sample's, not the chaos sample's
+	last := stitched[len(stitched)-1]
+	if last.NumGC != 30+30 || last.TotalAlloc != 2*9*uint64(types.MB) {
+		t.Errorf("Stitched NumGC/TotalAlloc = %d, %d", last.NumGC, last.TotalAlloc)
+	}
+	if stitched[5] != metrics[5] {
+		t.Error("Chaos samples before the restart should not be rebased")
+	}
+}
@@ -0,0 +1,70 @@
+package analysis
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// robustStats computes the median and median absolute deviation of pause
+// times and heap size. Pauses come from events when available, otherwise
+// from the samples' pause buffers like analyzePauseTimesFromMetrics.
+func (a *Analyzer) robustStats() *types.RobustStats {
+	var pauses []float64
+	if len(a.events) > 0 {
+		pauses = make([]float64, len(a.events))
+		for i, e := range a.events {
+			pauses[i] = float64(e.Duration)
+		}
+	} else {
+		for _, m := range a.metrics {
+			for _, ns := range m.PauseNs {
+				if ns > 0 {
+					pauses = append(pauses, float64(ns))
+				}
+			}
+		}
+	}
+
+	heap := make([]float64, len(a.metrics))
+	for i, m := range a.metrics {
+		heap[i] = float64(m.HeapAlloc)
+	}
+
+	stats := &types.RobustStats{Pauses: len(pauses)}
+	if len(pauses) > 0 {
+		median, mad := medianMAD(pauses)
+		stats.MedianPause = time.Duration(math.Round(median))
+		stats.PauseMAD = time.Duration(math.Round(mad))
+	}
+	if len(heap) > 0 {
+		median, mad := medianMAD(heap)
+		stats.MedianHeap = uint64(math.Round(median))
+		stats.HeapMAD = uint64(math.Round(mad))
+	}
+	return stats
+}
+
+// medianMAD returns the median of values and the median of their absolute
+// deviations from it. values must not be empty; it is reordered.
+func medianMAD(values []float64) (median, mad float64) {
+	slices.Sort(values)
+	median = sortedMedian(values)
+	for i, v := range values {
+		values[i] = math.Abs(v - median)
+	}
+	slices.Sort(values)
+	return median, sortedMedian(values)
+}
+
+// sortedMedian returns the median of sorted values, averaging the middle two
+// when their number is even
+func sortedMedian(sorted []float64) float64 {
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMedianMAD(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    []float64
+		median    float64
+		deviation float64
+	}{
+		{"odd", []float64{5, 1, 3}, 3, 2},
+		{"even", []float64{4, 1, 3, 2}, 2.5, 1},
+		{"one", []float64{7}, 7, 0},
+		{"outlier", []float64{10, 11, 9, 10, 2000}, 10, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			median, mad := medianMAD(tt.values)
+			if median != tt.median || mad != tt.deviation {
+				t.Errorf("medianMAD() = %v, %v, want %v, %v", median, mad, tt.median, tt.deviation)
+			}
+		})
+	}
+}
+
+func TestAnalyze_RobustStats(t *testing.T) {
+	baseTime := time.Now()
+	metrics := createTestMetrics(20, baseTime, time.Second)
+	pauses := make([]time.Duration, 100)
+	for i := range pauses {
+		pauses[i] = 100 * time.Microsecond
+		if i%2 == 1 {
+			pauses[i] = 110 * time.Microsecond
+		}
+	}
+	pauses[51] = 2 * time.Second
+	events := createPauseEvents(baseTime, pauses...)
+
+	analysis, err := NewWithOptions(metrics, events, &Options{RobustStats: true}).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	rs := analysis.Robust
+	if rs == nil {
+		t.Fatal("Robust = nil, want median statistics")
+	}
+	if analysis.AvgPauseTime < 20*time.Millisecond {
+		t.Errorf("AvgPauseTime = %v, want it skewed by the 2s pause", analysis.AvgPauseTime)
+	}
+	if rs.MedianPause != 105*time.Microsecond || rs.PauseMAD != 5*time.Microsecond || rs.Pauses != 100 {
+		t.Errorf("Robust pauses = %v (MAD %v, n=%d), want 105µs (MAD 5µs, n=100)", rs.MedianPause, rs.PauseMAD, rs.Pauses)
+	}
+
+	// HeapAlloc rises by 512 KB per sample
+	wantMedian := (metrics[9].HeapAlloc + metrics[10].HeapAlloc) / 2
+	if rs.MedianHeap != wantMedian || rs.HeapMAD != 5*512*1024 {
+		t.Errorf("Robust heap = %d (MAD %d), want %d (MAD %d)", rs.MedianHeap, rs.HeapMAD, wantMedian, 5*512*1024)
+	}
+}
+
+func TestAnalyze_RobustStatsOptional(t *testing.T) {
+	analysis, err := New(createTestMetrics(10, time.Now(), time.Second)).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if analysis.Robust != nil {
+		t.Errorf("Robust = %+v, want nil unless requested", analysis.Robust)
+	}
+}
@@ -0,0 +1,67 @@
+package analysis
+
+import (
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// analyzeRSS compares resident memory with Go-managed memory (Sys minus
+// released heap) and fits a line through the difference to spot non-Go
+// memory growth. Returns nil when fewer than two samples carry RSS.
+func (a *Analyzer) analyzeRSS() *types.RSSAnalysis {
+	var xs, ys []float64
+	var sumRSS, sumGo, maxRSS uint64
+	var first, last *types.GCMetrics
+	for _, m := range a.metrics {
+		if m.ProcessRSS == 0 {
+			continue
+		}
+		if first == nil {
+			first = m
+		}
+		goManaged := m.Sys - min(m.HeapReleased, m.Sys)
+		sumRSS += m.ProcessRSS
+		sumGo += goManaged
+		maxRSS = max(maxRSS, m.ProcessRSS)
+
+		xs = append(xs, m.Timestamp.Sub(first.Timestamp).Seconds())
+		ys = append(ys, float64(m.ProcessRSS)-float64(goManaged))
+		last = m
+	}
+
+	n := uint64(len(xs))
+	if n < 2 {
+		return nil
+	}
+
+	r := &types.RSSAnalysis{
+		AvgRSS:       sumRSS / n,
+		MaxRSS:       maxRSS,
+		AvgGoManaged: sumGo / n,
+		Sys: types.SysBreakdown{
+			Heap:     last.HeapSys,
+			Stack:    last.StackSys,
+			MSpan:    last.MSpanSys,
+			MCache:   last.MCacheSys,
+			BuckHash: last.BuckHashSys,
+			GC:       last.GCSys,
+			Other:    last.OtherSys,
+			Released: last.HeapReleased,
+		},
+	}
+	if r.AvgRSS > r.AvgGoManaged {
+		r.AvgNonGo = r.AvgRSS - r.AvgGoManaged
+		r.NonGoShare = float64(r.AvgNonGo) / float64(r.AvgRSS)
+	}
+
+	fit := linearRegression(xs, ys)
+	r.NonGoGrowth = fit.Slope
+	r.RSquared = fit.RSquared
+
+	span := xs[len(xs)-1]
+	r.NonGoGrowing = len(xs) >= types.MinSamplesForTrendAnalysis &&
+		fit.Slope > 0 &&
+		fit.RSquared >= types.LeakMediumConfidenceR2 &&
+		fit.Slope*span/float64(r.AvgRSS) > types.ThresholdNonGoGrowth
+
+	return r
+}
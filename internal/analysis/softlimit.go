@@ -0,0 +1,108 @@
+package analysis
+
+import (
+	"math"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// analyzeSoftLimit compares memory usage with GOMEMLIMIT over each sample
+// interval, and GC frequency and CPU near the limit with below it. The limit
+// comes from the samples when they record it, otherwise from the runtime
+// metadata. Returns nil when no limit is set.
+func (a *Analyzer) analyzeSoftLimit() *types.SoftLimitAnalysis {
+	var (
+		s                  types.SoftLimitAnalysis
+		total, near, below time.Duration
+		nearGCs, belowGCs  uint32
+		nearGCCPU, nearCPU float64
+	)
+	for i := 1; i < len(a.metrics); i++ {
+		prev, cur := a.metrics[i-1], a.metrics[i]
+		dt := cur.Timestamp.Sub(prev.Timestamp)
+		limit := a.softLimitAt(cur)
+		if dt <= 0 || limit == 0 {
+			continue
+		}
+		total += dt
+		s.Limit = limit
+
+		usage := memoryInUse(cur)
+		if ratio := float64(usage) / float64(limit); ratio > s.PeakRatio {
+			s.PeakUsage, s.PeakRatio = usage, ratio
+		}
+
+		var gcs uint32
+		if cur.NumGC >= prev.NumGC {
+			gcs = cur.NumGC - prev.NumGC
+		}
+		if float64(usage) < types.ThresholdSoftLimitNear*float64(limit) {
+			below += dt
+			belowGCs += gcs
+			continue
+		}
+		near += dt
+		nearGCs += gcs
+		if cur.TotalCPU > prev.TotalCPU && cur.GCTotalCPU >= prev.GCTotalCPU {
+			nearGCCPU += cur.GCTotalCPU - prev.GCTotalCPU
+			nearCPU += cur.TotalCPU - prev.TotalCPU
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	s.NearLimitShare = float64(near) / float64(total)
+	if near > 0 {
+		s.GCFrequencyNear = float64(nearGCs) / near.Seconds()
+	}
+	if below > 0 {
+		s.GCFrequencyBelow = float64(belowGCs) / below.Seconds()
+	}
+	if nearCPU > 0 {
+		s.GCCPUNear = nearGCCPU / nearCPU
+	}
+
+	s.DeathSpiral = near > 0 && (s.GCCPUNear >= types.ThresholdDeathSpiralGCCPU ||
+		s.GCFrequencyBelow > 0 && s.GCFrequencyNear >= types.ThresholdDeathSpiralFrequency*s.GCFrequencyBelow)
+	s.Pressured = s.DeathSpiral || s.NearLimitShare >= types.ThresholdSoftLimitPressure
+	if s.Pressured {
+		s.SuggestedLimit = a.suggestSoftLimit(s.Limit, s.PeakUsage)
+	}
+	return &s
+}
+
+// softLimitAt returns the GOMEMLIMIT in effect for a sample, or 0 when none
+// is set
+func (a *Analyzer) softLimitAt(m *types.GCMetrics) uint64 {
+	limit := m.GOMemLimit
+	if limit == 0 && a.opts.Runtime != nil {
+		limit = a.opts.Runtime.GOMemLimit
+	}
+	if limit >= math.MaxInt64 {
+		return 0
+	}
+	return limit
+}
+
+// suggestSoftLimit returns a limit the peak usage takes SoftLimitTargetUsage
+// of, rounded up to a megabyte and capped where the container limit leaves
+// room for non-heap memory. Returns 0 when that is no higher than limit.
+func (a *Analyzer) suggestSoftLimit(limit, peak uint64) uint64 {
+	const mb = uint64(types.MB)
+	suggested := uint64(math.Ceil(float64(peak)/types.SoftLimitTargetUsage/float64(mb))) * mb
+
+	container := a.metrics[len(a.metrics)-1].CgroupMemoryLimit
+	if k := a.kubernetes(); container == 0 && k != nil {
+		container = k.MemoryLimit
+	}
+	if container > 0 {
+		suggested = min(suggested, uint64(float64(container)*types.ThresholdMemoryLimitUsageHigh))
+	}
+
+	if suggested <= limit {
+		return 0
+	}
+	return suggested
+}
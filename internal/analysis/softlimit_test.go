@@ -0,0 +1,139 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// createSoftLimitMetrics creates one sample per second under a GOMEMLIMIT of
+// limit bytes. Interval i ends with usage[i] bytes in use after gcs[i] cycles.
+func createSoftLimitMetrics(limit uint64, usage []uint64, gcs []uint32) []*types.GCMetrics {
+	baseTime := time.Now()
+	metrics := make([]*types.GCMetrics, len(usage)+1)
+	metrics[0] = &types.GCMetrics{Sys: usage[0], GOMemLimit: limit, Timestamp: baseTime}
+	for i := range usage {
+		metrics[i+1] = &types.GCMetrics{
+			NumGC:      metrics[i].NumGC + gcs[i],
+			Sys:        usage[i],
+			GOMemLimit: limit,
+			Timestamp:  baseTime.Add(time.Duration(i+1) * time.Second),
+		}
+	}
+	return metrics
+}
+
+// splitUsage returns n intervals, the first half at near bytes with nearGCs
+// cycles each and the rest at below bytes with one cycle each
+func splitUsage(n int, near, below uint64, nearGCs uint32) ([]uint64, []uint32) {
+	usage := make([]uint64, n)
+	gcs := make([]uint32, n)
+	for i := range usage {
+		usage[i], gcs[i] = below, 1
+		if i < n/2 {
+			usage[i], gcs[i] = near, nearGCs
+		}
+	}
+	return usage, gcs
+}
+
+func TestAnalyzeSoftLimit_Pressure(t *testing.T) {
+	const mb = uint64(types.MB)
+	usage, gcs := splitUsage(10, 95*mb, 70*mb, 2)
+	metrics := createSoftLimitMetrics(100*mb, usage, gcs)
+
+	s := New(metrics).analyzeSoftLimit()
+	if s == nil {
+		t.Fatal("analyzeSoftLimit() returned nil")
+	}
+	if s.Limit != 100*mb || s.PeakUsage != 95*mb || math.Abs(s.PeakRatio-0.95) > 1e-9 {
+		t.Errorf("Limit/peak = %d, %d, %v", s.Limit, s.PeakUsage, s.PeakRatio)
+	}
+	if s.NearLimitShare != 0.5 || s.GCFrequencyNear != 2 || s.GCFrequencyBelow != 1 {
+		t.Errorf("Near share/frequencies = %v, %v, %v", s.NearLimitShare, s.GCFrequencyNear, s.GCFrequencyBelow)
+	}
+	if !s.Pressured || s.DeathSpiral {
+		t.Errorf("Expected pressure without a death spiral: %+v", s)
+	}
+	// 95 MB at 80% of the suggested limit, rounded up to a megabyte
+	if s.SuggestedLimit != 119*mb {
+		t.Errorf("SuggestedLimit = %s, want 119.0 MB", types.FormatBytes(s.SuggestedLimit))
+	}
+
+	analysis, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	for _, rec := range analysis.RecommendationDetails {
+		if rec.Code == types.CodeSoftLimitPressure {
+			if rec.Severity != types.SeverityWarning || rec.Evidence != s.Evidence() {
+				t.Errorf("Recommendation = %+v", rec)
+			}
+			return
+		}
+	}
+	t.Errorf("Expected a soft limit pressure recommendation, got %v", analysis.Recommendations)
+}
+
+func TestAnalyzeSoftLimit_DeathSpiral(t *testing.T) {
+	const mb = uint64(types.MB)
+	usage, gcs := splitUsage(10, 98*mb, 60*mb, 20)
+	metrics := createSoftLimitMetrics(100*mb, usage, gcs)
+	metrics[len(metrics)-1].CgroupMemoryLimit = 120 * mb
+
+	s := New(metrics).analyzeSoftLimit()
+	if s == nil || !s.DeathSpiral || !s.Pressured {
+		t.Fatalf("Expected a death spiral: %+v", s)
+	}
+	// Capped at 90% of the container limit
+	if s.SuggestedLimit != 108*mb {
+		t.Errorf("SuggestedLimit = %s, want 108.0 MB", types.FormatBytes(s.SuggestedLimit))
+	}
+
+	// GC CPU near the limit flags a death spiral on its own
+	usage, gcs = splitUsage(10, 98*mb, 60*mb, 1)
+	metrics = createSoftLimitMetrics(100*mb, usage, gcs)
+	for i, m := range metrics {
+		m.TotalCPU = float64(i)
+		m.GCTotalCPU = 0.45 * float64(min(i, 5))
+	}
+	s = New(metrics).analyzeSoftLimit()
+	if s == nil || !s.DeathSpiral || math.Abs(s.GCCPUNear-0.45) > 1e-9 {
+		t.Errorf("Expected a death spiral from GC CPU: %+v", s)
+	}
+
+	analysis, err := New(metrics).Analyze()
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	for _, rec := range analysis.RecommendationDetails {
+		if rec.Code == types.CodeSoftLimitPressure {
+			t.Errorf("A death spiral should replace the pressure recommendation, got %+v", rec)
+		}
+		if rec.Code == types.CodeGCDeathSpiral && rec.Severity != types.SeverityCritical {
+			t.Errorf("Death spiral severity = %s, want critical", rec.Severity)
+		}
+	}
+}
+
+func TestAnalyzeSoftLimit_Limit(t *testing.T) {
+	const mb = uint64(types.MB)
+	usage, gcs := splitUsage(10, 50*mb, 40*mb, 1)
+
+	if s := New(createSoftLimitMetrics(math.MaxInt64, usage, gcs)).analyzeSoftLimit(); s != nil {
+		t.Errorf("analyzeSoftLimit() = %+v, want nil without a limit", s)
+	}
+
+	// Samples without the limit fall back to the runtime metadata
+	metrics := createSoftLimitMetrics(0, usage, gcs)
+	a := NewWithOptions(metrics, nil, &Options{Runtime: &types.RuntimeInfo{GOMemLimit: 100 * mb}})
+	s := a.analyzeSoftLimit()
+	if s == nil || s.Limit != 100*mb {
+		t.Fatalf("analyzeSoftLimit() = %+v, want the runtime limit", s)
+	}
+	if s.Pressured || s.NearLimitShare != 0 || s.SuggestedLimit != 0 {
+		t.Errorf("Usage well below the limit should not be flagged: %+v", s)
+	}
+}
@@ -0,0 +1,90 @@
+package analysis
+
+// regression holds the result of an ordinary least squares fit y = Slope*x + Intercept
+type regression struct {
+	Slope     float64
+	Intercept float64
+	RSquared  float64
+}
+
+// linearRegression fits a straight line through the given points.
+// xs and ys must have the same length; fewer than two points yield a zero result.
+func linearRegression(xs, ys []float64) regression {
+	n := len(xs)
+	if n < 2 || len(ys) != n {
+		return regression{}
+	}
+
+	var sumX, sumY float64
+	for i := 0; i < n; i++ {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX := sumX / float64(n)
+	meanY := sumY / float64(n)
+
+	var sxx, sxy, syy float64
+	for i := 0; i < n; i++ {
+		dx := xs[i] - meanX
+		dy := ys[i] - meanY
+		sxx += dx * dx
+		sxy += dx * dy
+		syy += dy * dy
+	}
+
+	if sxx == 0 {
+		return regression{Intercept: meanY}
+	}
+
+	r := regression{
+		Slope: sxy / sxx,
+	}
+	r.Intercept = meanY - r.Slope*meanX
+
+	if syy > 0 {
+		r.RSquared = (sxy * sxy) / (sxx * syy)
+	} else {
+		// All y values identical: the flat line is a perfect fit
+		r.RSquared = 1
+	}
+
+	return r
+}
+
+// autocorrelation returns the sample autocorrelation of ys for lags 0..maxLag.
+// The result at lag 0 is 1 unless the series is constant, in which case all
+// values are zero.
+func autocorrelation(ys []float64, maxLag int) []float64 {
+	n := len(ys)
+	if n == 0 {
+		return nil
+	}
+	maxLag = min(maxLag, n-1)
+
+	var mean float64
+	for _, y := range ys {
+		mean += y
+	}
+	mean /= float64(n)
+
+	var variance float64
+	for _, y := range ys {
+		d := y - mean
+		variance += d * d
+	}
+
+	acf := make([]float64, maxLag+1)
+	if variance == 0 {
+		return acf
+	}
+
+	for lag := 0; lag <= maxLag; lag++ {
+		var sum float64
+		for i := 0; i+lag < n; i++ {
+			sum += (ys[i] - mean) * (ys[i+lag] - mean)
+		}
+		acf[lag] = sum / variance
+	}
+
+	return acf
+}
@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLinearRegression(t *testing.T) {
+	tests := []struct {
+		name      string
+		xs, ys    []float64
+		slope     float64
+		intercept float64
+		r2        float64
+	}{
+		{"perfect line", []float64{0, 1, 2, 3}, []float64{1, 3, 5, 7}, 2, 1, 1},
+		{"flat", []float64{0, 1, 2}, []float64{5, 5, 5}, 0, 5, 1},
+		{"single point", []float64{1}, []float64{1}, 0, 0, 0},
+		{"identical x", []float64{2, 2, 2}, []float64{1, 2, 3}, 0, 2, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := linearRegression(tt.xs, tt.ys)
+			if math.Abs(r.Slope-tt.slope) > 1e-9 {
+				t.Errorf("Slope = %v, want %v", r.Slope, tt.slope)
+			}
+			if math.Abs(r.Intercept-tt.intercept) > 1e-9 {
+				t.Errorf("Intercept = %v, want %v", r.Intercept, tt.intercept)
+			}
+			if math.Abs(r.RSquared-tt.r2) > 1e-9 {
+				t.Errorf("RSquared = %v, want %v", r.RSquared, tt.r2)
+			}
+		})
+	}
+}
+
+func TestLinearRegression_Noisy(t *testing.T) {
+	xs := []float64{0, 1, 2, 3, 4, 5}
+	ys := []float64{0, 3, 1, 4, 2, 5}
+
+	r := linearRegression(xs, ys)
+	if r.Slope <= 0 {
+		t.Errorf("Slope should be positive, got %v", r.Slope)
+	}
+	if r.RSquared <= 0 || r.RSquared >= 1 {
+		t.Errorf("RSquared should be between 0 and 1 for noisy data, got %v", r.RSquared)
+	}
+}
+
+func TestAutocorrelation(t *testing.T) {
+	ys := []float64{1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3}
+	acf := autocorrelation(ys, 6)
+
+	if len(acf) != 7 {
+		t.Fatalf("len(acf) = %d, want 7", len(acf))
+	}
+	if math.Abs(acf[0]-1) > 1e-9 {
+		t.Errorf("acf[0] = %v, want 1", acf[0])
+	}
+	if acf[3] <= acf[1] || acf[3] <= acf[2] {
+		t.Errorf("acf should peak at the period (3): %v", acf)
+	}
+
+	if acf := autocorrelation([]float64{5, 5, 5}, 2); acf[0] != 0 || acf[1] != 0 {
+		t.Errorf("Constant series should have zero autocorrelation, got %v", acf)
+	}
+}
@@ -0,0 +1,41 @@
+package analysis
+
+import (
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// detectTuningChanges lists the samples where GOGC or GOMEMLIMIT differs
+// from the last sample that recorded it. Samples without the setting, such
+// as those from older captures, are skipped rather than reported as changes.
+func (a *Analyzer) detectTuningChanges() []types.TuningChange {
+	var changes []types.TuningChange
+	var gogc int
+	var memLimit uint64
+	for i, m := range a.metrics {
+		if m.GOGC != 0 {
+			if gogc != 0 && m.GOGC != gogc {
+				changes = append(changes, types.TuningChange{
+					Setting:   "GOGC",
+					Index:     i,
+					Timestamp: m.Timestamp,
+					Before:    types.FormatGOGC(gogc),
+					After:     types.FormatGOGC(m.GOGC),
+				})
+			}
+			gogc = m.GOGC
+		}
+		if m.GOMemLimit != 0 {
+			if memLimit != 0 && m.GOMemLimit != memLimit {
+				changes = append(changes, types.TuningChange{
+					Setting:   "GOMEMLIMIT",
+					Index:     i,
+					Timestamp: m.Timestamp,
+					Before:    types.FormatMemLimit(memLimit),
+					After:     types.FormatMemLimit(m.GOMemLimit),
+				})
+			}
+			memLimit = m.GOMemLimit
+		}
+	}
+	return changes
+}
@@ -0,0 +1,46 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDetectTuningChanges(t *testing.T) {
+	metrics := createTestMetrics(6, time.Now(), time.Second)
+	for _, m := range metrics {
+		m.GOGC = 100
+		m.GOMemLimit = math.MaxInt64
+	}
+	metrics[2].GOGC = 0 // not recorded
+	metrics[3].GOGC = 200
+	metrics[4].GOGC = 200
+	metrics[4].GOMemLimit = 512 << 20
+	metrics[5].GOGC = -1
+	metrics[5].GOMemLimit = 512 << 20
+
+	changes := New(metrics).detectTuningChanges()
+	want := []string{
+		"GOGC: 100 → 200",
+		"GOMEMLIMIT: none → 512.0 MB",
+		"GOGC: 200 → off",
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("detectTuningChanges() = %v, want %v", changes, want)
+	}
+	for i, w := range want {
+		if got := changes[i].String(); got != w {
+			t.Errorf("changes[%d] = %q, want %q", i, got, w)
+		}
+	}
+	if changes[0].Index != 3 || !changes[0].Timestamp.Equal(metrics[3].Timestamp) {
+		t.Errorf("changes[0] = %+v, want sample 3", changes[0])
+	}
+
+	for _, m := range metrics {
+		m.GOGC, m.GOMemLimit = 0, 0
+	}
+	if changes := New(metrics).detectTuningChanges(); changes != nil {
+		t.Errorf("detectTuningChanges() without tuning data = %v, want nil", changes)
+	}
+}
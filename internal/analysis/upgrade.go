@@ -0,0 +1,173 @@
+package analysis
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// goReleaseNotes summarizes GC-relevant runtime changes by Go 1.x minor version
+var goReleaseNotes = map[int]string{
+	19: "Go 1.19 added the GOMEMLIMIT soft memory limit; if a limit is set, the GC runs more often as usage approaches it.",
+	20: "Go 1.20 reorganized GC internal data structures, reducing memory overhead and improving CPU performance by up to 2%.",
+	21: "Go 1.21 tuned the GC, which can reduce tail latency by up to 40% and slightly reduce memory use, at a small throughput cost for some programs.",
+	22: "Go 1.22 keeps type-based GC metadata next to heap objects, typically improving CPU performance by 1-3% and reducing memory overhead by about 1%.",
+	24: "Go 1.24 reduced runtime CPU overhead by 2-3% on average (Swiss-table maps, more efficient small object allocation, a new runtime mutex).",
+	25: "Go 1.25 makes GOMAXPROCS container-aware by default and ships the experimental Green Tea GC behind GOEXPERIMENT=greenteagc.",
+	26: "Go 1.26 enables the Green Tea GC by default, which reduces GC CPU overhead for many heap-heavy programs.",
+}
+
+// CompareUpgrade analyzes two capture bundles recorded under different Go
+// versions and reports how pause times, GC overhead and process RSS changed,
+// with notes on the GC-relevant runtime changes between the two versions.
+// Returns ErrMissingRuntimeInfo when a bundle has no runtime metadata and
+// ErrSameGoVersion when both were recorded under the same Go version.
+func CompareUpgrade(before, after *types.Bundle) (*types.UpgradeComparison, error) {
+	if before == nil || after == nil || before.Runtime == nil || after.Runtime == nil {
+		return nil, types.ErrMissingRuntimeInfo
+	}
+	if before.Runtime.GoVersion == after.Runtime.GoVersion {
+		return nil, types.ErrSameGoVersion
+	}
+	return compareBundles(before, after)
+}
+
+// CompareBuilds is CompareUpgrade for any two builds, such as before and
+// after a dependency upgrade: the Go version may be the same. The comparison
+// lists the main module and dependency versions that differ between the
+// bundles' build information.
+// Returns ErrMissingRuntimeInfo when a bundle has no runtime metadata.
+func CompareBuilds(before, after *types.Bundle) (*types.UpgradeComparison, error) {
+	if before == nil || after == nil || before.Runtime == nil || after.Runtime == nil {
+		return nil, types.ErrMissingRuntimeInfo
+	}
+	return compareBundles(before, after)
+}
+
+// compareBundles analyzes both bundles and compares the results
+func compareBundles(before, after *types.Bundle) (*types.UpgradeComparison, error) {
+	beforeAnalysis, err := NewWithOptions(before.Metrics, before.Events, &Options{Runtime: before.Runtime}).Analyze()
+	if err != nil {
+		return nil, err
+	}
+	afterAnalysis, err := NewWithOptions(after.Metrics, after.Events, &Options{Runtime: after.Runtime}).Analyze()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &types.UpgradeComparison{
+		FromVersion: before.Runtime.GoVersion,
+		ToVersion:   after.Runtime.GoVersion,
+		FromLabel:   before.Label,
+		ToLabel:     after.Label,
+		Before:      beforeAnalysis,
+		After:       afterAnalysis,
+		Deltas: []types.MetricDelta{
+			metricDelta(types.DeltaAvgPause, float64(beforeAnalysis.AvgPauseTime), float64(afterAnalysis.AvgPauseTime)),
+			metricDelta(types.DeltaP99Pause, float64(beforeAnalysis.P99PauseTime), float64(afterAnalysis.P99PauseTime)),
+			metricDelta(types.DeltaGCOverhead, beforeAnalysis.GCOverhead, afterAnalysis.GCOverhead),
+			metricDelta(types.DeltaGCFrequency, beforeAnalysis.GCFrequency, afterAnalysis.GCFrequency),
+			memoryDelta(before.Metrics, after.Metrics),
+		},
+		Changes: types.DiffBuilds(before.Build, after.Build),
+	}
+
+	c.Notes = upgradeNotes(before.Runtime, after.Runtime)
+	return c, nil
+}
+
+// metricDelta builds a MetricDelta with its relative change
+func metricDelta(metric string, before, after float64) types.MetricDelta {
+	d := types.MetricDelta{Metric: metric, Before: before, After: after}
+	if before != 0 {
+		d.Change = (after - before) / before
+	}
+	return d
+}
+
+// memoryDelta compares the average process RSS when both captures sampled it,
+// and otherwise the Go runtime's memory, which misses non-Go memory
+func memoryDelta(before, after []*types.GCMetrics) types.MetricDelta {
+	if from, to := avgRSS(before), avgRSS(after); from > 0 && to > 0 {
+		return metricDelta(types.DeltaRSS, from, to)
+	}
+	return metricDelta(types.DeltaRuntimeMemory, avgMemoryInUse(before), avgMemoryInUse(after))
+}
+
+// avgRSS returns the average ProcessRSS of the samples that recorded it, or 0
+// when none did
+func avgRSS(metrics []*types.GCMetrics) float64 {
+	var total float64
+	var n int
+	for _, m := range metrics {
+		if m.ProcessRSS > 0 {
+			total += float64(m.ProcessRSS)
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return total / float64(n)
+}
+
+// avgMemoryInUse returns the average Go-managed memory across samples
+func avgMemoryInUse(metrics []*types.GCMetrics) float64 {
+	if len(metrics) == 0 {
+		return 0
+	}
+	var total float64
+	for _, m := range metrics {
+		total += float64(memoryInUse(m))
+	}
+	return total / float64(len(metrics))
+}
+
+// upgradeNotes returns release notes for every Go version crossed between
+// from and to, plus a caveat when other GC settings changed at the same time
+func upgradeNotes(from, to *types.RuntimeInfo) []string {
+	var notes []string
+
+	fromMinor, okFrom := goMinorVersion(from.GoVersion)
+	toMinor, okTo := goMinorVersion(to.GoVersion)
+	if okFrom && okTo {
+		lo, hi := fromMinor, toMinor
+		if toMinor < fromMinor {
+			lo, hi = toMinor, fromMinor
+			notes = append(notes, "This is a downgrade; the changes below were reverted.")
+		}
+		for v := lo + 1; v <= hi; v++ {
+			if note, ok := goReleaseNotes[v]; ok {
+				notes = append(notes, note)
+			}
+		}
+	}
+
+	for _, d := range types.DetectConfigDrift(from, to) {
+		if d.Setting == "Go version" {
+			continue
+		}
+		notes = append(notes, d.String()+"; differences may not be caused by the upgrade alone.")
+	}
+
+	return notes
+}
+
+// goMinorVersion extracts the minor version from strings such as "go1.22.3"
+// or "go1.23rc1". Returns false for development builds.
+func goMinorVersion(version string) (int, bool) {
+	rest, ok := strings.CutPrefix(version, "go1.")
+	if !ok {
+		return 0, false
+	}
+	end := 0
+	for end < len(rest) && rest[end] >= '0' && rest[end] <= '9' {
+		end++
+	}
+	minor, err := strconv.Atoi(rest[:end])
+	if err != nil {
+		return 0, false
+	}
+	return minor, true
+}
@@ -0,0 +1,186 @@
+package analysis
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func createUpgradeBundle(version string, pauseScale uint64) *types.Bundle {
+	metrics := createTestMetrics(5, time.Now(), time.Second)
+	for i, m := range metrics {
+		m.PauseTotalNs = uint64(i) * 1000000 * pauseScale
+		for j := range m.PauseNs[:10] {
+			m.PauseNs[j] *= pauseScale
+		}
+	}
+	return &types.Bundle{
+		FormatVersion: types.BundleFormatVersion,
+		Runtime:       &types.RuntimeInfo{GoVersion: version, GOGC: 100, GOMAXPROCS: 8},
+		Metrics:       metrics,
+	}
+}
+
+func TestCompareUpgrade(t *testing.T) {
+	before := createUpgradeBundle("go1.20.14", 2)
+	after := createUpgradeBundle("go1.22.1", 1)
+
+	c, err := CompareUpgrade(before, after)
+	if err != nil {
+		t.Fatalf("CompareUpgrade() error: %v", err)
+	}
+	if c.FromVersion != "go1.20.14" || c.ToVersion != "go1.22.1" {
+		t.Errorf("Versions = %s -> %s", c.FromVersion, c.ToVersion)
+	}
+
+	var avgPause *types.MetricDelta
+	for i := range c.Deltas {
+		if c.Deltas[i].Metric == types.DeltaAvgPause {
+			avgPause = &c.Deltas[i]
+		}
+	}
+	if avgPause == nil || !avgPause.Improved() || avgPause.Change > -0.4 {
+		t.Errorf("Expected average pause to improve by ~50%%, got %+v", avgPause)
+	}
+
+	notes := strings.Join(c.Notes, "\n")
+	if !strings.Contains(notes, "Go 1.21") || !strings.Contains(notes, "Go 1.22") {
+		t.Errorf("Notes should cover Go 1.21 and 1.22, got:\n%s", notes)
+	}
+	if strings.Contains(notes, "Go 1.20 ") {
+		t.Errorf("Notes should not include the starting version, got:\n%s", notes)
+	}
+}
+
+func TestCompareUpgrade_RSS(t *testing.T) {
+	// findDelta returns the comparison's delta for metric, or nil
+	findDelta := func(c *types.UpgradeComparison, metric string) *types.MetricDelta {
+		for i := range c.Deltas {
+			if c.Deltas[i].Metric == metric {
+				return &c.Deltas[i]
+			}
+		}
+		return nil
+	}
+
+	before := createUpgradeBundle("go1.21.0", 1)
+	after := createUpgradeBundle("go1.22.0", 1)
+	c, err := CompareUpgrade(before, after)
+	if err != nil {
+		t.Fatalf("CompareUpgrade() error: %v", err)
+	}
+	if findDelta(c, types.DeltaRSS) != nil || findDelta(c, types.DeltaRuntimeMemory) == nil {
+		t.Errorf("Without RSS, deltas = %+v, want runtime memory only", c.Deltas)
+	}
+
+	for _, m := range before.Metrics {
+		m.ProcessRSS = 200 * uint64(types.MB)
+	}
+	for _, m := range after.Metrics {
+		m.ProcessRSS = 150 * uint64(types.MB)
+	}
+	// A sample without RSS doesn't drag the average down
+	after.Metrics[0].ProcessRSS = 0
+	c, err = CompareUpgrade(before, after)
+	if err != nil {
+		t.Fatalf("CompareUpgrade() error: %v", err)
+	}
+	rss := findDelta(c, types.DeltaRSS)
+	if rss == nil || rss.Before != float64(200*types.MB) || rss.After != float64(150*types.MB) || rss.Change != -0.25 {
+		t.Errorf("RSS delta = %+v, want 200 MB -> 150 MB", rss)
+	}
+	if findDelta(c, types.DeltaRuntimeMemory) != nil {
+		t.Errorf("With RSS, deltas = %+v, want no runtime memory fallback", c.Deltas)
+	}
+}
+
+func TestCompareUpgrade_SettingsChanged(t *testing.T) {
+	before := createUpgradeBundle("go1.24.0", 1)
+	after := createUpgradeBundle("go1.25.0", 1)
+	after.Runtime.GOGC = 200
+
+	c, err := CompareUpgrade(before, after)
+	if err != nil {
+		t.Fatalf("CompareUpgrade() error: %v", err)
+	}
+	if notes := strings.Join(c.Notes, "\n"); !strings.Contains(notes, "GOGC: recorded 100, current 200") {
+		t.Errorf("Notes should warn about the GOGC change, got:\n%s", notes)
+	}
+}
+
+func TestCompareUpgrade_Errors(t *testing.T) {
+	b := createUpgradeBundle("go1.22.0", 1)
+
+	if _, err := CompareUpgrade(b, b); !errors.Is(err, types.ErrSameGoVersion) {
+		t.Errorf("Expected ErrSameGoVersion, got %v", err)
+	}
+
+	noRuntime := createUpgradeBundle("go1.23.0", 1)
+	noRuntime.Runtime = nil
+	if _, err := CompareUpgrade(b, noRuntime); !errors.Is(err, types.ErrMissingRuntimeInfo) {
+		t.Errorf("Expected ErrMissingRuntimeInfo, got %v", err)
+	}
+}
+
+func TestCompareBuilds(t *testing.T) {
+	before := createUpgradeBundle("go1.22.0", 2)
+	before.Label = "v1.4.0"
+	before.Build = &types.BuildInfo{Version: "v1.4.0", Deps: map[string]string{"golang.org/x/net": "v0.20.0"}}
+	after := createUpgradeBundle("go1.22.0", 1)
+	after.Label = "v1.5.0"
+	after.Build = &types.BuildInfo{Version: "v1.5.0", Deps: map[string]string{"golang.org/x/net": "v0.21.0"}}
+
+	if _, err := CompareUpgrade(before, after); !errors.Is(err, types.ErrSameGoVersion) {
+		t.Fatalf("CompareUpgrade() error = %v, want ErrSameGoVersion", err)
+	}
+
+	c, err := CompareBuilds(before, after)
+	if err != nil {
+		t.Fatalf("CompareBuilds() error: %v", err)
+	}
+	if c.FromLabel != "v1.4.0" || c.ToLabel != "v1.5.0" {
+		t.Errorf("Labels = %s -> %s", c.FromLabel, c.ToLabel)
+	}
+	want := []types.BuildChange{
+		{Component: "version", Before: "v1.4.0", After: "v1.5.0"},
+		{Component: "golang.org/x/net", Before: "v0.20.0", After: "v0.21.0"},
+	}
+	if len(c.Changes) != len(want) {
+		t.Fatalf("Changes = %v, want %v", c.Changes, want)
+	}
+	for i, w := range want {
+		if c.Changes[i] != w {
+			t.Errorf("Changes[%d] = %+v, want %+v", i, c.Changes[i], w)
+		}
+	}
+	if len(c.Notes) != 0 {
+		t.Errorf("Notes = %v, want none without a version or settings change", c.Notes)
+	}
+
+	if _, err := CompareBuilds(before, nil); !errors.Is(err, types.ErrMissingRuntimeInfo) {
+		t.Errorf("Expected ErrMissingRuntimeInfo, got %v", err)
+	}
+}
+
+func TestGoMinorVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		minor   int
+		ok      bool
+	}{
+		{"go1.22.3", 22, true},
+		{"go1.23rc1", 23, true},
+		{"go1.9", 9, true},
+		{"devel go1.24-abcdef", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		minor, ok := goMinorVersion(tt.version)
+		if minor != tt.minor || ok != tt.ok {
+			t.Errorf("goMinorVersion(%q) = %d, %v, want %d, %v", tt.version, minor, ok, tt.minor, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Package baseline learns what normal GC behavior looks like for a
+// long-running process so alerts can be raised on deviations from it rather
+// than on static thresholds.
+package baseline
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// minutesPerDay is the number of minute-of-day buckets in a seasonal model
+const minutesPerDay = 24 * 60
+
+// bucket holds running statistics (Welford) for one minute of the day
+type bucket struct {
+	count   uint64
+	mean    float64
+	m2      float64
+	days    int
+	lastDay int // day number of the most recent observation
+}
+
+// add folds a value observed on the given day into the bucket
+func (b *bucket) add(v float64, day int) {
+	b.count++
+	delta := v - b.mean
+	b.mean += delta / float64(b.count)
+	b.m2 += delta * (v - b.mean)
+
+	if b.days == 0 || day != b.lastDay {
+		b.days++
+		b.lastDay = day
+	}
+}
+
+// stdDev returns the sample standard deviation of the bucket
+func (b *bucket) stdDev() float64 {
+	if b.count < 2 {
+		return 0
+	}
+	return math.Sqrt(b.m2 / float64(b.count-1))
+}
+
+// Seasonal is a minute-of-day baseline model: for each series it keeps the
+// mean and variance of the values observed at each minute of the day, so a
+// nightly batch job is compared against previous nights rather than against
+// the quiet daytime. It is safe for concurrent use.
+type Seasonal struct {
+	mu      sync.Mutex
+	loc     *time.Location
+	minDays int
+	series  map[string]*[minutesPerDay]bucket
+}
+
+// NewSeasonal creates an empty seasonal model. Minutes of the day are taken
+// in loc; a nil loc uses time.Local.
+func NewSeasonal(loc *time.Location) *Seasonal {
+	if loc == nil {
+		loc = time.Local
+	}
+	return &Seasonal{
+		loc:     loc,
+		minDays: types.MinSeasonalDays,
+		series:  make(map[string]*[minutesPerDay]bucket),
+	}
+}
+
+// slot returns the minute of the day and the day number of t
+func (s *Seasonal) slot(t time.Time) (minute, day int) {
+	t = t.In(s.loc)
+	y, m, d := t.Date()
+	day = int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+	return t.Hour()*60 + t.Minute(), day
+}
+
+// Observe adds a value of series seen at time t to the model
+func (s *Seasonal) Observe(series string, t time.Time, v float64) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return
+	}
+	minute, day := s.slot(t)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buckets, ok := s.series[series]
+	if !ok {
+		buckets = new([minutesPerDay]bucket)
+		s.series[series] = buckets
+	}
+	buckets[minute].add(v, day)
+}
+
+// Check compares a value of series at time t with the baseline for that
+// minute of the day. ok is false while the minute has been observed on fewer
+// than MinSeasonalDays days, in which case callers should fall back to static
+// thresholds.
+func (s *Seasonal) Check(series string, t time.Time, v float64) (dev types.SeasonalDeviation, ok bool) {
+	minute, _ := s.slot(t)
+
+	s.mu.Lock()
+	buckets, found := s.series[series]
+	var b bucket
+	if found {
+		b = buckets[minute]
+	}
+	s.mu.Unlock()
+
+	if b.days < s.minDays {
+		return types.SeasonalDeviation{}, false
+	}
+
+	// A perfectly steady minute would make any change infinitely anomalous
+	std := max(b.stdDev(), math.Abs(b.mean)*types.SeasonalMinRelativeStdDev)
+	if std == 0 {
+		// Only zeros seen so far: no scale to judge a deviation by
+		return types.SeasonalDeviation{}, false
+	}
+
+	return types.SeasonalDeviation{
+		Series:      series,
+		MinuteOfDay: minute,
+		Value:       v,
+		Expected:    b.mean,
+		StdDev:      std,
+		ZScore:      (v - b.mean) / std,
+	}, true
+}
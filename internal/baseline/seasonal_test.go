@@ -0,0 +1,103 @@
+package baseline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// observeDays feeds value(minute) for every minute of the given number of days
+func observeDays(s *Seasonal, start time.Time, days int, value func(minute int) float64) {
+	for d := 0; d < days; d++ {
+		for minute := 0; minute < minutesPerDay; minute++ {
+			t := start.AddDate(0, 0, d).Add(time.Duration(minute) * time.Minute)
+			// Small day-to-day jitter so buckets have a spread
+			s.Observe(types.SeriesGCFrequency, t, value(minute)*(1+0.02*float64(d%2)))
+		}
+	}
+}
+
+func TestSeasonal_ColdStart(t *testing.T) {
+	s := NewSeasonal(time.UTC)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	observeDays(s, start, types.MinSeasonalDays-1, func(int) float64 { return 1 })
+
+	if _, ok := s.Check(types.SeriesGCFrequency, start, 100); ok {
+		t.Error("Check() should not be ready before MinSeasonalDays days")
+	}
+	if _, ok := s.Check(types.SeriesHeapAlloc, start, 100); ok {
+		t.Error("Check() should not be ready for an unseen series")
+	}
+}
+
+func TestSeasonal_NightlyBatch(t *testing.T) {
+	s := NewSeasonal(time.UTC)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// A nightly batch between 02:00 and 03:00 runs 20 GCs/s; otherwise 1 GC/s
+	batch := func(minute int) float64 {
+		if minute >= 120 && minute < 180 {
+			return 20
+		}
+		return 1
+	}
+	observeDays(s, start, 4, batch)
+	day := start.AddDate(0, 0, 4)
+
+	// The nightly batch is normal at 02:30...
+	dev, ok := s.Check(types.SeriesGCFrequency, day.Add(150*time.Minute), 20)
+	if !ok {
+		t.Fatal("Check() should be ready after 4 days")
+	}
+	if dev.Anomalous() || dev.MinuteOfDay != 150 {
+		t.Errorf("Batch-hour GC frequency should be normal, got %+v", dev)
+	}
+
+	// ...but the same rate at noon is not
+	dev, ok = s.Check(types.SeriesGCFrequency, day.Add(12*time.Hour), 20)
+	if !ok || !dev.Anomalous() || dev.ZScore <= 0 {
+		t.Errorf("Noon burst should be anomalous, got %+v (ok=%v)", dev, ok)
+	}
+
+	// A quiet batch window is anomalous too
+	dev, ok = s.Check(types.SeriesGCFrequency, day.Add(150*time.Minute), 1)
+	if !ok || !dev.Anomalous() || dev.ZScore >= 0 {
+		t.Errorf("Missing batch should be anomalous, got %+v (ok=%v)", dev, ok)
+	}
+}
+
+func TestSeasonal_DaysCountedOnce(t *testing.T) {
+	s := NewSeasonal(time.UTC)
+	at := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	// Many observations on the same day and minute still count as one day
+	for i := 0; i < 100; i++ {
+		s.Observe(types.SeriesHeapAlloc, at.Add(time.Duration(i)*100*time.Millisecond), 1)
+	}
+	if _, ok := s.Check(types.SeriesHeapAlloc, at, 1); ok {
+		t.Error("Check() should count days, not observations")
+	}
+}
+
+func TestSeasonal_Location(t *testing.T) {
+	loc := time.FixedZone("UTC+9", 9*60*60)
+	s := NewSeasonal(loc)
+
+	// 00:00 UTC is 09:00 in UTC+9
+	minute, _ := s.slot(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	if minute != 9*60 {
+		t.Errorf("slot() minute = %d, want %d", minute, 9*60)
+	}
+}
+
+func TestSeasonal_ZeroBaseline(t *testing.T) {
+	s := NewSeasonal(time.UTC)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for d := 0; d < types.MinSeasonalDays; d++ {
+		s.Observe(types.SeriesGCFrequency, start.AddDate(0, 0, d), 0)
+	}
+	if _, ok := s.Check(types.SeriesGCFrequency, start, 5); ok {
+		t.Error("An all-zero baseline has no scale and should not be used")
+	}
+}
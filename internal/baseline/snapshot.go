@@ -0,0 +1,109 @@
+package baseline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/ingest"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// Snapshot exports the learned state of every series, minutes in order
+func (s *Seasonal) Snapshot() *types.BaselineSnapshot {
+	snap := &types.BaselineSnapshot{
+		FormatVersion: types.BaselineFormatVersion,
+		Location:      s.loc.String(),
+		ExportedAt:    time.Now(),
+		Series:        make(map[string][]types.BaselineBucket),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name, buckets := range s.series {
+		var learned []types.BaselineBucket
+		for minute := range buckets {
+			b := &buckets[minute]
+			if b.count == 0 {
+				continue
+			}
+			std := max(b.stdDev(), math.Abs(b.mean)*types.SeasonalMinRelativeStdDev)
+			learned = append(learned, types.BaselineBucket{
+				Minute:  minute,
+				Count:   b.count,
+				Mean:    b.mean,
+				M2:      b.m2,
+				Days:    b.days,
+				LastDay: b.lastDay,
+				Lower:   b.mean - types.ThresholdSeasonalZScore*std,
+				Upper:   b.mean + types.ThresholdSeasonalZScore*std,
+			})
+		}
+		snap.Series[name] = learned
+	}
+
+	return snap
+}
+
+// Restore replaces the learned state with a snapshot. The snapshot is
+// validated first; on error the model is left unchanged. Minutes of the day
+// keep the model's own location, so restoring a snapshot taken in another
+// time zone shifts the seasonal pattern.
+func (s *Seasonal) Restore(snap *types.BaselineSnapshot) error {
+	if snap == nil {
+		return types.ErrInvalidBaseline
+	}
+	if snap.FormatVersion < 1 || snap.FormatVersion > types.BaselineFormatVersion {
+		return fmt.Errorf("%w: unsupported format version %d", types.ErrInvalidBaseline, snap.FormatVersion)
+	}
+	// Each series costs a full day of buckets however few it lists
+	if len(snap.Series) > types.MaxBaselineSeries {
+		return fmt.Errorf("%w: %d series exceeds the limit of %d", types.ErrInvalidBaseline, len(snap.Series), types.MaxBaselineSeries)
+	}
+
+	series := make(map[string]*[minutesPerDay]bucket, len(snap.Series))
+	for name, learned := range snap.Series {
+		buckets := new([minutesPerDay]bucket)
+		for _, lb := range learned {
+			if lb.Minute < 0 || lb.Minute >= minutesPerDay {
+				return fmt.Errorf("%w: %s minute %d out of range", types.ErrInvalidBaseline, name, lb.Minute)
+			}
+			if lb.M2 < 0 || math.IsNaN(lb.Mean) || math.IsNaN(lb.M2) {
+				return fmt.Errorf("%w: %s minute %d has invalid statistics", types.ErrInvalidBaseline, name, lb.Minute)
+			}
+			buckets[lb.Minute] = bucket{
+				count:   lb.Count,
+				mean:    lb.Mean,
+				m2:      lb.M2,
+				days:    lb.Days,
+				lastDay: lb.LastDay,
+			}
+		}
+		series[name] = buckets
+	}
+
+	s.mu.Lock()
+	s.series = series
+	s.mu.Unlock()
+	return nil
+}
+
+// Write encodes a snapshot as indented JSON
+func Write(w io.Writer, snap *types.BaselineSnapshot) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(snap)
+}
+
+// Read decodes a snapshot written by Write, up to types.MaxBaselineSize
+func Read(r io.Reader) (*types.BaselineSnapshot, error) {
+	var snap types.BaselineSnapshot
+	if err := json.NewDecoder(ingest.NewReader(context.Background(), r, types.MaxBaselineSize)).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("%w: %w", types.ErrInvalidBaseline, err)
+	}
+	return &snap, nil
+}
@@ -0,0 +1,140 @@
+package baseline
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	s := NewSeasonal(time.UTC)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	observeDays(s, start, 4, func(minute int) float64 { return float64(1 + minute%7) })
+
+	var buf bytes.Buffer
+	if err := Write(&buf, s.Snapshot()); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	snap, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+
+	restored := NewSeasonal(time.UTC)
+	if err := restored.Restore(snap); err != nil {
+		t.Fatalf("Restore() error: %v", err)
+	}
+
+	at := start.AddDate(0, 0, 4).Add(90 * time.Minute)
+	want, ok1 := s.Check(types.SeriesGCFrequency, at, 50)
+	got, ok2 := restored.Check(types.SeriesGCFrequency, at, 50)
+	if !ok1 || !ok2 {
+		t.Fatalf("Check() ready = %v/%v, want both true", ok1, ok2)
+	}
+	if got != want {
+		t.Errorf("Restored baseline Check() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSnapshot_Bounds(t *testing.T) {
+	s := NewSeasonal(time.UTC)
+	at := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	s.Observe(types.SeriesHeapAlloc, at, 100)
+
+	snap := s.Snapshot()
+	learned := snap.Series[types.SeriesHeapAlloc]
+	if len(learned) != 1 {
+		t.Fatalf("Snapshot() exported %d minutes, want only the observed one", len(learned))
+	}
+	b := learned[0]
+	if b.Minute != 8*60 || b.Count != 1 || b.Mean != 100 {
+		t.Errorf("bucket = %+v", b)
+	}
+	if b.Lower >= b.Mean || b.Upper <= b.Mean {
+		t.Errorf("bounds [%v, %v] should bracket the mean %v", b.Lower, b.Upper, b.Mean)
+	}
+	if snap.Location != "UTC" || snap.FormatVersion != types.BaselineFormatVersion {
+		t.Errorf("snapshot header = %q v%d", snap.Location, snap.FormatVersion)
+	}
+}
+
+func TestRestore_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		snap *types.BaselineSnapshot
+	}{
+		{"nil", nil},
+		{"version", &types.BaselineSnapshot{FormatVersion: types.BaselineFormatVersion + 1}},
+		{"minute", &types.BaselineSnapshot{
+			FormatVersion: types.BaselineFormatVersion,
+			Series:        map[string][]types.BaselineBucket{types.SeriesHeapAlloc: {{Minute: minutesPerDay}}},
+		}},
+		{"m2", &types.BaselineSnapshot{
+			FormatVersion: types.BaselineFormatVersion,
+			Series:        map[string][]types.BaselineBucket{types.SeriesHeapAlloc: {{Minute: 1, M2: -1}}},
+		}},
+		{"series", &types.BaselineSnapshot{
+			FormatVersion: types.BaselineFormatVersion,
+			Series:        manySeries(types.MaxBaselineSeries + 1),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSeasonal(time.UTC)
+			at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			s.Observe(types.SeriesHeapAlloc, at, 1)
+
+			if err := s.Restore(tt.snap); !errors.Is(err, types.ErrInvalidBaseline) {
+				t.Errorf("Restore() error = %v, want ErrInvalidBaseline", err)
+			}
+			if len(s.Snapshot().Series[types.SeriesHeapAlloc]) != 1 {
+				t.Error("A failed Restore() should leave the model unchanged")
+			}
+		})
+	}
+}
+
+func TestRead_Invalid(t *testing.T) {
+	if _, err := Read(strings.NewReader("{not json")); !errors.Is(err, types.ErrInvalidBaseline) {
+		t.Errorf("Read() error = %v, want ErrInvalidBaseline", err)
+	}
+}
+
+// manySeries returns n distinct empty series
+func manySeries(n int) map[string][]types.BaselineBucket {
+	series := make(map[string][]types.BaselineBucket, n)
+	for i := range n {
+		series[strconv.Itoa(i)] = nil
+	}
+	return series
+}
+
+func FuzzRead(f *testing.F) {
+	s := NewSeasonal(time.UTC)
+	s.Observe(types.SeriesHeapAlloc, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), 1)
+	var buf bytes.Buffer
+	if err := Write(&buf, s.Snapshot()); err != nil {
+		f.Fatal(err)
+	}
+	f.Add(buf.Bytes())
+	f.Add([]byte(`{"format_version": 1, "series": {"heap_alloc": [{"minute": 1440}]}}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		snap, err := Read(bytes.NewReader(data))
+		if err != nil {
+			if !errors.Is(err, types.ErrInvalidBaseline) {
+				t.Fatalf("Read() error = %v, want ErrInvalidBaseline", err)
+			}
+			return
+		}
+		if err := NewSeasonal(time.UTC).Restore(snap); err != nil && !errors.Is(err, types.ErrInvalidBaseline) {
+			t.Fatalf("Restore() error = %v, want ErrInvalidBaseline", err)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package bundle
+
+import (
+	"bufio"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"io"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/ingest"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// binaryFormatVersion is the current binary series format version
+const binaryFormatVersion = 1
+
+// binarySeries is the gob-encoded form of a metric and event series. Gob
+// writes integers as varints and omits zero fields, so pause arrays that are
+// mostly empty cost a fraction of their JSON size and decode much faster.
+type binarySeries struct {
+	FormatVersion int
+	Metrics       []*types.GCMetrics
+	Events        []*types.GCEvent
+}
+
+// WriteBinary encodes metrics and events in the compact binary format.
+// Neither may contain nil entries.
+func WriteBinary(w io.Writer, metrics []*types.GCMetrics, events []*types.GCEvent) error {
+	bw := bufio.NewWriter(w)
+	series := binarySeries{FormatVersion: binaryFormatVersion, Metrics: metrics, Events: events}
+	if err := gob.NewEncoder(bw).Encode(&series); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// ReadBinary decodes a series written by WriteBinary, bounded by ctx and
+// types.MaxBundleSize. Errors wrap types.ErrInvalidMetrics.
+func ReadBinary(ctx context.Context, r io.Reader) ([]*types.GCMetrics, []*types.GCEvent, error) {
+	var series binarySeries
+	if err := gob.NewDecoder(ingest.NewReader(ctx, r, types.MaxBundleSize)).Decode(&series); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", types.ErrInvalidMetrics, err)
+	}
+	if series.FormatVersion < 1 || series.FormatVersion > binaryFormatVersion {
+		return nil, nil, fmt.Errorf("%w: unsupported format version %d", types.ErrInvalidMetrics, series.FormatVersion)
+	}
+	return series.Metrics, series.Events, nil
+}
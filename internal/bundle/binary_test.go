@@ -0,0 +1,114 @@
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// seriesForTest returns n samples with full pause arrays, like those collected
+// by default, and one event per sample
+func seriesForTest(n int) ([]*types.GCMetrics, []*types.GCEvent) {
+	now := time.Now()
+	metrics := make([]*types.GCMetrics, n)
+	events := make([]*types.GCEvent, n)
+	for i := range n {
+		m := &types.GCMetrics{
+			NumGC:     uint32(i),
+			HeapAlloc: uint64(1<<20 + i*4096),
+			PauseNs:   make([]uint64, 256),
+			PauseEnd:  make([]uint64, 256),
+			Timestamp: now.Add(time.Duration(i) * time.Second),
+		}
+		for j := range min(i, 256) {
+			m.PauseNs[j] = uint64(100_000 + j*37)
+			m.PauseEnd[j] = uint64(now.UnixNano()) + uint64(j)*1e9
+		}
+		metrics[i] = m
+		events[i] = &types.GCEvent{Sequence: uint32(i), Duration: 150 * time.Microsecond, TriggerReason: "automatic"}
+	}
+	return metrics, events
+}
+
+func TestWriteReadBinary(t *testing.T) {
+	metrics, events := seriesForTest(300)
+
+	var buf bytes.Buffer
+	if err := WriteBinary(&buf, metrics, events); err != nil {
+		t.Fatalf("WriteBinary() error: %v", err)
+	}
+
+	var js bytes.Buffer
+	if err := WriteMetrics(&js, metrics, FormatJSONL); err != nil {
+		t.Fatalf("WriteMetrics() error: %v", err)
+	}
+	if buf.Len() >= js.Len()/2 {
+		t.Errorf("Binary encoding is %d bytes, want well under JSONL's %d", buf.Len(), js.Len())
+	}
+
+	gotMetrics, gotEvents, err := ReadBinary(context.Background(), &buf)
+	if err != nil {
+		t.Fatalf("ReadBinary() error: %v", err)
+	}
+	if len(gotMetrics) != 300 || len(gotEvents) != 300 {
+		t.Fatalf("ReadBinary() = %d metrics, %d events", len(gotMetrics), len(gotEvents))
+	}
+	last := gotMetrics[299]
+	if last.HeapAlloc != metrics[299].HeapAlloc || last.PauseNs[255] != metrics[299].PauseNs[255] ||
+		!last.Timestamp.Equal(metrics[299].Timestamp) {
+		t.Errorf("Sample not preserved: %+v", last)
+	}
+	if gotEvents[299].Duration != 150*time.Microsecond || gotEvents[299].TriggerReason != "automatic" {
+		t.Errorf("Event not preserved: %+v", gotEvents[299])
+	}
+}
+
+func TestReadBinary_Invalid(t *testing.T) {
+	if _, _, err := ReadBinary(context.Background(), strings.NewReader(`{"format_version": 1}`)); !errors.Is(err, types.ErrInvalidMetrics) {
+		t.Errorf("ReadBinary(JSON) error = %v, want ErrInvalidMetrics", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteBinary(&buf, nil, nil); err != nil {
+		t.Fatalf("WriteBinary() error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := ReadBinary(ctx, &buf); !errors.Is(err, types.ErrInvalidMetrics) || !errors.Is(err, context.Canceled) {
+		t.Errorf("ReadBinary() error = %v, want ErrInvalidMetrics and context.Canceled", err)
+	}
+}
+
+// BenchmarkLoad compares decoding 10k samples from JSONL and binary
+func BenchmarkLoad(b *testing.B) {
+	metrics, events := seriesForTest(10_000)
+	var js, bin bytes.Buffer
+	if err := WriteMetrics(&js, metrics, FormatJSONL); err != nil {
+		b.Fatal(err)
+	}
+	if err := WriteBinary(&bin, metrics, events); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("jsonl", func(b *testing.B) {
+		b.SetBytes(int64(js.Len()))
+		for i := 0; i < b.N; i++ {
+			if _, err := ReadMetrics(context.Background(), bytes.NewReader(js.Bytes()), FormatJSONL); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("binary", func(b *testing.B) {
+		b.SetBytes(int64(bin.Len()))
+		for i := 0; i < b.N; i++ {
+			if _, _, err := ReadBinary(context.Background(), bytes.NewReader(bin.Bytes())); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
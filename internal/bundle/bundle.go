@@ -0,0 +1,65 @@
+// Package bundle reads and writes capture bundles: JSON documents holding
+// metrics, events and the runtime configuration they were recorded under.
+// It also reads and writes plain metrics files in JSON and JSONL, and metric
+// and event series in a compact binary encoding.
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/ingest"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// New creates a bundle from collected data, recording the current runtime
+// and build
+func New(label string, metrics []*types.GCMetrics, events []*types.GCEvent) *types.Bundle {
+	return &types.Bundle{
+		FormatVersion: types.BundleFormatVersion,
+		Label:         label,
+		Runtime:       types.CurrentRuntimeInfo(),
+		Build:         types.CurrentBuildInfo(),
+		Metrics:       metrics,
+		Events:        events,
+	}
+}
+
+// Write encodes b as indented JSON
+func Write(w io.Writer, b *types.Bundle) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(b)
+}
+
+// Read decodes a bundle and checks that its format version is supported
+func Read(r io.Reader) (*types.Bundle, error) {
+	return ReadContext(context.Background(), r)
+}
+
+// ReadContext is Read bounded by ctx and types.MaxBundleSize. Errors wrap
+// types.ErrInvalidBundle, together with the cause when the input was too
+// large or ctx ended.
+func ReadContext(ctx context.Context, r io.Reader) (*types.Bundle, error) {
+	var b types.Bundle
+	if err := json.NewDecoder(ingest.NewReader(ctx, r, types.MaxBundleSize)).Decode(&b); err != nil {
+		return nil, fmt.Errorf("%w: %w", types.ErrInvalidBundle, err)
+	}
+	if b.FormatVersion < 1 || b.FormatVersion > types.BundleFormatVersion {
+		return nil, fmt.Errorf("%w: unsupported format version %d", types.ErrInvalidBundle, b.FormatVersion)
+	}
+	// Analyses assume every sample is present
+	for i, m := range b.Metrics {
+		if m == nil {
+			return nil, fmt.Errorf("%w: metrics[%d] is null", types.ErrInvalidBundle, i)
+		}
+	}
+	for i, e := range b.Events {
+		if e == nil {
+			return nil, fmt.Errorf("%w: events[%d] is null", types.ErrInvalidBundle, i)
+		}
+	}
+	return &b, nil
+}
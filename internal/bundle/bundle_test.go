@@ -0,0 +1,111 @@
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestWriteRead(t *testing.T) {
+	metrics := []*types.GCMetrics{{NumGC: 1, HeapAlloc: 1024, Timestamp: time.Now()}}
+	b := New("build-42", metrics, nil)
+
+	var buf bytes.Buffer
+	if err := Write(&buf, b); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+	if got.Label != "build-42" || got.FormatVersion != types.BundleFormatVersion {
+		t.Errorf("Read() = %+v", got)
+	}
+	if got.Runtime == nil || got.Runtime.GoVersion != b.Runtime.GoVersion {
+		t.Errorf("Runtime metadata not preserved: %+v", got.Runtime)
+	}
+	if b.Build != nil && (got.Build == nil || got.Build.Path != b.Build.Path) {
+		t.Errorf("Build information not preserved: %+v", got.Build)
+	}
+	if len(got.Metrics) != 1 || got.Metrics[0].HeapAlloc != 1024 {
+		t.Errorf("Metrics not preserved: %+v", got.Metrics)
+	}
+}
+
+func TestRead_Invalid(t *testing.T) {
+	inputs := []string{
+		"not json",
+		`{"metrics": []}`,
+		`{"format_version": 99, "metrics": []}`,
+		`{"format_version": 1, "metrics": [null]}`,
+		`{"format_version": 1, "metrics": [], "events": [{}, null]}`,
+	}
+	for _, input := range inputs {
+		if _, err := Read(strings.NewReader(input)); !errors.Is(err, types.ErrInvalidBundle) {
+			t.Errorf("Read(%q) error = %v, want ErrInvalidBundle", input, err)
+		}
+	}
+}
+
+func TestReadContext_Limits(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := ReadContext(ctx, strings.NewReader(`{"format_version": 1, "metrics": []}`))
+	if !errors.Is(err, types.ErrInvalidBundle) || !errors.Is(err, context.Canceled) {
+		t.Errorf("ReadContext() error = %v, want ErrInvalidBundle and context.Canceled", err)
+	}
+
+	// An endless JSON string stops at the size limit instead of exhausting memory
+	endless := io.MultiReader(strings.NewReader(`{"label": "`), infinite('a'))
+	_, err = Read(endless)
+	if !errors.Is(err, types.ErrInvalidBundle) || !errors.Is(err, types.ErrInputTooLarge) {
+		t.Errorf("Read() error = %v, want ErrInvalidBundle and ErrInputTooLarge", err)
+	}
+}
+
+// infinite is an endless stream of one byte
+type infinite byte
+
+func (b infinite) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(b)
+	}
+	return len(p), nil
+}
+
+func FuzzRead(f *testing.F) {
+	var buf bytes.Buffer
+	metrics := []*types.GCMetrics{{NumGC: 1, HeapAlloc: 1024, Timestamp: time.Unix(1700000000, 0)}}
+	events := []*types.GCEvent{{Sequence: 1, Duration: time.Millisecond}}
+	if err := Write(&buf, New("seed", metrics, events)); err != nil {
+		f.Fatal(err)
+	}
+	f.Add(buf.Bytes())
+	f.Add([]byte(`{"format_version": 1, "metrics": [null]}`))
+	f.Add([]byte(`{"format_version": 1}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		b, err := Read(bytes.NewReader(data))
+		if err != nil {
+			if !errors.Is(err, types.ErrInvalidBundle) {
+				t.Fatalf("Read() error = %v, want ErrInvalidBundle", err)
+			}
+			return
+		}
+		if b.FormatVersion < 1 || b.FormatVersion > types.BundleFormatVersion {
+			t.Fatalf("Read() accepted format version %d", b.FormatVersion)
+		}
+		for _, m := range b.Metrics {
+			if m == nil {
+				t.Fatal("Read() accepted a null sample")
+			}
+		}
+	})
+}
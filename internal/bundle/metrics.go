@@ -0,0 +1,109 @@
+package bundle
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/ingest"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// Format is the encoding of a metrics file
+type Format string
+
+// Metrics file formats
+const (
+	FormatJSON  Format = "json"  // a capture bundle or a JSON array of samples
+	FormatJSONL Format = "jsonl" // one JSON sample per line
+)
+
+// WriteMetrics encodes metrics in format, an indented JSON array for
+// FormatJSON. An empty format means FormatJSON.
+func WriteMetrics(w io.Writer, metrics []*types.GCMetrics, format Format) error {
+	switch format {
+	case "", FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(metrics)
+	case FormatJSONL:
+		bw := bufio.NewWriter(w)
+		encoder := json.NewEncoder(bw)
+		for _, m := range metrics {
+			if err := encoder.Encode(m); err != nil {
+				return err
+			}
+		}
+		return bw.Flush()
+	default:
+		return fmt.Errorf("%w: %q", types.ErrUnknownFormat, format)
+	}
+}
+
+// ReadMetrics decodes samples written by WriteMetrics, or the samples of a
+// capture bundle for FormatJSON, bounded by ctx and types.MaxBundleSize.
+// An empty format means FormatJSON. Errors other than ErrUnknownFormat wrap
+// types.ErrInvalidMetrics.
+func ReadMetrics(ctx context.Context, r io.Reader, format Format) ([]*types.GCMetrics, error) {
+	var metrics []*types.GCMetrics
+	var err error
+	switch format {
+	case "", FormatJSON:
+		metrics, err = readJSON(ctx, r)
+	case FormatJSONL:
+		metrics, err = readJSONL(ctx, r)
+	default:
+		return nil, fmt.Errorf("%w: %q", types.ErrUnknownFormat, format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", types.ErrInvalidMetrics, err)
+	}
+	// Analyses assume every sample is present
+	for i, m := range metrics {
+		if m == nil {
+			return nil, fmt.Errorf("%w: sample %d is null", types.ErrInvalidMetrics, i)
+		}
+	}
+	return metrics, nil
+}
+
+// readJSON decodes a JSON array of samples or a capture bundle
+func readJSON(ctx context.Context, r io.Reader) ([]*types.GCMetrics, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(ingest.NewReader(ctx, r, types.MaxBundleSize)).Decode(&raw); err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '{' {
+		b, err := ReadContext(ctx, bytes.NewReader(trimmed))
+		if err != nil {
+			return nil, err
+		}
+		return b.Metrics, nil
+	}
+	var metrics []*types.GCMetrics
+	if err := json.Unmarshal(raw, &metrics); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// readJSONL decodes one sample per line until the end of input
+func readJSONL(ctx context.Context, r io.Reader) ([]*types.GCMetrics, error) {
+	decoder := json.NewDecoder(ingest.NewReader(ctx, r, types.MaxBundleSize))
+	var metrics []*types.GCMetrics
+	for {
+		var m *types.GCMetrics
+		err := decoder.Decode(&m)
+		if errors.Is(err, io.EOF) {
+			return metrics, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", len(metrics)+1, err)
+		}
+		metrics = append(metrics, m)
+	}
+}
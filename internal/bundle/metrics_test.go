@@ -0,0 +1,79 @@
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestWriteReadMetrics(t *testing.T) {
+	now := time.Now()
+	metrics := []*types.GCMetrics{
+		{NumGC: 1, HeapAlloc: 1024, Timestamp: now},
+		{NumGC: 2, HeapAlloc: 2048, Timestamp: now.Add(time.Second)},
+	}
+
+	for _, format := range []Format{"", FormatJSON, FormatJSONL} {
+		var buf bytes.Buffer
+		if err := WriteMetrics(&buf, metrics, format); err != nil {
+			t.Fatalf("WriteMetrics(%q) error: %v", format, err)
+		}
+		if format == FormatJSONL && strings.Count(buf.String(), "\n") != len(metrics) {
+			t.Errorf("JSONL output should hold one sample per line, got:\n%s", buf.String())
+		}
+
+		got, err := ReadMetrics(context.Background(), &buf, format)
+		if err != nil {
+			t.Fatalf("ReadMetrics(%q) error: %v", format, err)
+		}
+		if len(got) != 2 || got[1].HeapAlloc != 2048 || !got[1].Timestamp.Equal(now.Add(time.Second)) {
+			t.Errorf("ReadMetrics(%q) = %+v", format, got)
+		}
+	}
+}
+
+func TestReadMetrics_Bundle(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, New("prod", []*types.GCMetrics{{NumGC: 7}}, nil)); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	got, err := ReadMetrics(context.Background(), &buf, FormatJSON)
+	if err != nil {
+		t.Fatalf("ReadMetrics() error: %v", err)
+	}
+	if len(got) != 1 || got[0].NumGC != 7 {
+		t.Errorf("ReadMetrics() = %+v", got)
+	}
+}
+
+func TestReadMetrics_Invalid(t *testing.T) {
+	tests := []struct {
+		input  string
+		format Format
+	}{
+		{"not json", FormatJSON},
+		{`[{"num_gc": 1}, null]`, FormatJSON},
+		{`{"format_version": 99, "metrics": []}`, FormatJSON},
+		{"{\"num_gc\": 1}\nnull\n", FormatJSONL},
+		{"{\"num_gc\": 1}\n{\"num_gc\":", FormatJSONL},
+	}
+	for _, tt := range tests {
+		_, err := ReadMetrics(context.Background(), strings.NewReader(tt.input), tt.format)
+		if !errors.Is(err, types.ErrInvalidMetrics) {
+			t.Errorf("ReadMetrics(%q, %q) error = %v, want ErrInvalidMetrics", tt.input, tt.format, err)
+		}
+	}
+
+	if _, err := ReadMetrics(context.Background(), strings.NewReader("[]"), "csv"); !errors.Is(err, types.ErrUnknownFormat) {
+		t.Errorf("ReadMetrics(csv) error = %v, want ErrUnknownFormat", err)
+	}
+	if err := WriteMetrics(&bytes.Buffer{}, nil, "csv"); !errors.Is(err, types.ErrUnknownFormat) {
+		t.Errorf("WriteMetrics(csv) error = %v, want ErrUnknownFormat", err)
+	}
+}
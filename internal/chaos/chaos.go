@@ -0,0 +1,137 @@
+// Package chaos generates synthetic pathological GC series used to validate
+// alert rules, notifiers and dashboards before a real incident.
+package chaos
+
+import (
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// Scenario identifies a synthetic failure pattern
+type Scenario string
+
+// Supported scenarios
+const (
+	// Leak grows the live heap by a fixed step on every sample
+	Leak Scenario = "leak"
+	// PauseStorm produces one GC per sample with a pause above the critical threshold
+	PauseStorm Scenario = "pause_storm"
+	// Thrash runs many GCs per sample with GC CPU usage well above the alert threshold
+	Thrash Scenario = "thrash"
+)
+
+// Scenario tuning
+const (
+	leakStep          = 64 * 1024 * 1024 // live heap growth per sample
+	stormPause        = 750 * time.Millisecond
+	thrashGCsPerStep  = 20
+	thrashCPUFraction = 0.6
+	thrashPause       = 2 * time.Millisecond
+)
+
+// Scenarios returns all supported scenarios
+func Scenarios() []Scenario {
+	return []Scenario{Leak, PauseStorm, Thrash}
+}
+
+// Step is one synthetic sample and the GC events completed since the previous one
+type Step struct {
+	Metrics *types.GCMetrics
+	Events  []*types.GCEvent
+}
+
+// Generator produces successive synthetic samples for a scenario
+type Generator struct {
+	scenario Scenario
+	interval time.Duration
+	current  types.GCMetrics
+}
+
+// NewGenerator creates a generator that continues from base, so cumulative
+// counters keep increasing. A nil base starts from an empty 64 MB heap.
+// Returns ErrUnknownScenario for unsupported scenarios.
+func NewGenerator(scenario Scenario, base *types.GCMetrics, interval time.Duration) (*Generator, error) {
+	switch scenario {
+	case Leak, PauseStorm, Thrash:
+	default:
+		return nil, types.ErrUnknownScenario
+	}
+
+	if interval <= 0 {
+		interval = types.DefaultCollectionInterval
+	}
+
+	g := &Generator{scenario: scenario, interval: interval}
+	if base != nil {
+		g.current = *base.Clone()
+	} else {
+		g.current = types.GCMetrics{
+			HeapAlloc: 64 * 1024 * 1024,
+			HeapInuse: 64 * 1024 * 1024,
+			HeapLive:  64 * 1024 * 1024,
+			HeapSys:   128 * 1024 * 1024,
+			Sys:       160 * 1024 * 1024,
+			NextGC:    128 * 1024 * 1024,
+			Timestamp: time.Now(),
+		}
+	}
+	// Pause history is not simulated; events carry pause durations instead
+	g.current.PauseNs = nil
+	// Region labels are applied by the collector the samples are injected into
+	g.current.Region = ""
+	g.current.PauseEnd = nil
+	return g, nil
+}
+
+// Next advances the simulation by one collection interval
+func (g *Generator) Next() Step {
+	m := &g.current
+	m.Timestamp = m.Timestamp.Add(g.interval)
+	m.Synthetic = true
+
+	var gcs int
+	var pause time.Duration
+	switch g.scenario {
+	case Leak:
+		gcs, pause = 1, time.Millisecond
+		m.HeapAlloc += leakStep
+		m.HeapInuse += leakStep
+		m.HeapLive += leakStep
+		m.HeapSys += leakStep
+		m.Sys += leakStep
+		m.NextGC = 2 * m.HeapAlloc
+		m.TotalAlloc += 2 * leakStep
+		m.GCCPUFraction = 0.05
+	case PauseStorm:
+		gcs, pause = 1, stormPause
+		m.TotalAlloc += 16 * 1024 * 1024
+		m.GCCPUFraction = 0.2
+	case Thrash:
+		gcs, pause = thrashGCsPerStep, thrashPause
+		m.TotalAlloc += thrashGCsPerStep * m.NextGC
+		m.HeapAlloc = m.NextGC
+		m.GCCPUFraction = thrashCPUFraction
+	}
+
+	step := Step{Events: make([]*types.GCEvent, 0, gcs)}
+	for i := 0; i < gcs; i++ {
+		m.NumGC++
+		m.PauseTotalNs += uint64(pause)
+		end := m.Timestamp.Add(-time.Duration(gcs-1-i) * (g.interval / time.Duration(gcs)))
+		step.Events = append(step.Events, &types.GCEvent{
+			Sequence:      m.NumGC,
+			StartTime:     end.Add(-pause),
+			EndTime:       end,
+			Duration:      pause,
+			HeapBefore:    m.HeapAlloc,
+			HeapAfter:     m.HeapInuse,
+			TriggerReason: types.TriggerHeapSize,
+			Source:        types.EventSourceSynthetic,
+		})
+	}
+	m.LastGC = m.Timestamp
+
+	step.Metrics = m.Clone()
+	return step
+}
@@ -0,0 +1,71 @@
+package chaos
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestNewGenerator_UnknownScenario(t *testing.T) {
+	if _, err := NewGenerator("meltdown", nil, time.Second); !errors.Is(err, types.ErrUnknownScenario) {
+		t.Errorf("Expected ErrUnknownScenario, got %v", err)
+	}
+}
+
+func TestGenerator_CountersMonotonic(t *testing.T) {
+	base := &types.GCMetrics{NumGC: 42, PauseTotalNs: 1000, TotalAlloc: 1 << 30, NextGC: 64 << 20, Timestamp: time.Now()}
+
+	for _, scenario := range Scenarios() {
+		t.Run(string(scenario), func(t *testing.T) {
+			gen, err := NewGenerator(scenario, base, time.Second)
+			if err != nil {
+				t.Fatalf("NewGenerator() error: %v", err)
+			}
+
+			prev := base
+			for i := 0; i < 5; i++ {
+				step := gen.Next()
+				m := step.Metrics
+				if !m.Synthetic {
+					t.Error("Generated samples should be marked synthetic")
+				}
+				if m.NumGC <= prev.NumGC || m.TotalAlloc <= prev.TotalAlloc || m.PauseTotalNs <= prev.PauseTotalNs {
+					t.Fatalf("Cumulative counters must increase: prev %+v, got %+v", prev, m)
+				}
+				if !m.Timestamp.After(prev.Timestamp) {
+					t.Fatal("Timestamps must increase")
+				}
+				if len(step.Events) != int(m.NumGC-prev.NumGC) {
+					t.Errorf("Expected one event per GC, got %d events for %d GCs", len(step.Events), m.NumGC-prev.NumGC)
+				}
+				prev = m
+			}
+		})
+	}
+
+	if base.NumGC != 42 {
+		t.Error("NewGenerator should not modify base")
+	}
+}
+
+func TestGenerator_Scenarios(t *testing.T) {
+	leak, _ := NewGenerator(Leak, nil, time.Second)
+	first := leak.Next().Metrics.HeapInuse
+	if second := leak.Next().Metrics.HeapInuse; second <= first {
+		t.Errorf("Leak scenario should grow the heap: %d -> %d", first, second)
+	}
+
+	storm, _ := NewGenerator(PauseStorm, nil, time.Second)
+	for _, e := range storm.Next().Events {
+		if e.Duration <= types.ThresholdPauseCritical {
+			t.Errorf("Pause storm event pause %v should exceed critical threshold", e.Duration)
+		}
+	}
+
+	thrash, _ := NewGenerator(Thrash, nil, time.Second)
+	if m := thrash.Next().Metrics; m.GCCPUFraction <= types.ThresholdGCCPUFractionAlert {
+		t.Errorf("Thrash scenario GC CPU fraction %v should exceed alert threshold", m.GCCPUFraction)
+	}
+}
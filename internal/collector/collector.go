@@ -2,6 +2,9 @@ package collector
 
 import (
 	"context"
+	"maps"
+	"math/rand/v2"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -12,22 +15,81 @@ import (
 // Collector is responsible for collecting GC metrics over time.
 // It provides thread-safe metric collection with configurable intervals
 // and supports callback functions for real-time monitoring.
+//
+// Reads never block: samples and events are kept in lock-free ring buffers
+// of MaxSamples entries each, and mu only serializes writers.
 type Collector struct {
-	mu         sync.RWMutex
+	mu         sync.Mutex
 	running    atomic.Bool
-	metrics    []*types.GCMetrics
-	events     []*types.GCEvent
+	paused     atomic.Bool
+	metrics    atomic.Pointer[ring[types.GCMetrics]]
+	events     atomic.Pointer[ring[types.GCEvent]]
 	interval   time.Duration
+	current    atomic.Int64 // interval in use, in nanoseconds
+	jitter     float64
 	maxSamples int
 	stopCh     chan struct{}
 	wg         sync.WaitGroup // Added for graceful shutdown
 
+	// lifecycle serializes Start and StopE, so a StopE always closes the
+	// stop channel of the run it marked stopped. It guards stopCh.
+	lifecycle sync.Mutex
+
 	// Callbacks
 	onMetricCollected func(*types.GCMetrics)
 	onGCEvent         func(*types.GCEvent)
 
 	// useLiteMetrics controls whether to use lightweight metrics collection
 	useLiteMetrics bool
+
+	// pooled takes samples with pooled pause slices, released on eviction.
+	// poolMu excludes releases while readers copy samples.
+	pooled bool
+	poolMu sync.RWMutex
+
+	// processCPU enables OS-level process CPU sampling
+	processCPU bool
+	// processRSS enables OS-level resident set size sampling
+	processRSS bool
+
+	// paging enables major page fault and swap sampling
+	paging bool
+	// pauseQuantiles enables debug.ReadGCStats pause quantile sampling
+	pauseQuantiles bool
+	// notifyGC takes a sample as soon as a GC cycle completes
+	notifyGC bool
+
+	// sampler replaces local runtime sampling when set
+	sampler func(context.Context) (*types.GCMetrics, error)
+
+	// adaptive, when set, varies the interval with GC activity
+	adaptive *types.AdaptiveInterval
+
+	// retention, when set, compacts samples older than its full-resolution
+	// period into tiers
+	retention *types.RetentionPolicy
+	tiers     atomic.Pointer[[]*tier]
+
+	// labels identify the monitored process
+	labels map[string]string
+
+	// logger receives diagnostics about callback panics, failed samples and
+	// missed GC cycles
+	logger types.Logger
+
+	// runtimeInfo records the runtime configuration at the time collection started
+	runtimeInfo atomic.Pointer[types.RuntimeInfo]
+
+	// regions is the stack of active region labels; the last entry is applied
+	// to new samples and events
+	regions      []regionLabel
+	nextRegionID uint64
+}
+
+// regionLabel is an entry in the active region stack
+type regionLabel struct {
+	id   uint64
+	name string
 }
 
 // Config holds configuration for the collector
@@ -38,12 +100,76 @@ type Config struct {
 	// Maximum number of samples to keep in memory (default: 1000)
 	MaxSamples int
 
+	// IntervalJitter spreads each wait between samples uniformly over the
+	// interval ± this fraction of it, e.g. 0.1 for 900ms-1.1s at 1s, so
+	// replicas started together don't sample in lockstep with each other or
+	// with periodic workloads. Values outside [0, 1) are ignored.
+	IntervalJitter float64
+
+	// AdaptiveInterval, when set, replaces Interval: collection starts at its
+	// Max and tightens to its Min while GC frequency or allocation rate is
+	// above its thresholds. An invalid setting is ignored, see
+	// AdaptiveInterval.Validate.
+	AdaptiveInterval *types.AdaptiveInterval
+
+	// Retention, when set, keeps samples at full resolution only for its
+	// FullResolution period, within MaxSamples, and averages older samples
+	// into its tiers, so GetMetrics can span hours in bounded memory. An
+	// invalid policy is ignored, see RetentionPolicy.Validate.
+	Retention *types.RetentionPolicy
+
+	// Labels identify the monitored process, e.g. its service and version,
+	// see types.ValidateLabels
+	Labels map[string]string
+
+	// Logger receives diagnostics about callback panics, failed samples and
+	// GC cycles missed between samples (default: types.NopLogger)
+	Logger types.Logger
+
 	// Callback functions
 	OnMetricCollected func(*types.GCMetrics)
-	OnGCEvent         func(*types.GCEvent)
+	OnGCEvent         func(*types.GCEvent) // also receives gap markers, see GCEvent.IsGap
 
 	// UseLiteMetrics uses lightweight metrics without pause slice data (saves ~4KB per sample)
 	UseLiteMetrics bool
+
+	// PooledMetrics takes samples with pause slices from a pool and returns
+	// them once the sample leaves the MaxSamples window, so steady-state
+	// collection allocates almost nothing. GetMetrics and GetLatestMetrics
+	// then return copies; samples passed to OnMetricCollected stay valid
+	// until MaxSamples newer samples have been collected, so Clone them to
+	// keep them longer. Ignored with UseLiteMetrics or a Sampler.
+	PooledMetrics bool
+
+	// ProcessCPU samples the process's OS-reported CPU time with each metric,
+	// so GC CPU can be related to actual CPU usage (unsupported on non-unix platforms)
+	ProcessCPU bool
+
+	// ProcessRSS samples the process's resident set size with each metric, so
+	// it can be compared with Go-managed memory (Linux only)
+	ProcessRSS bool
+
+	// Paging samples the process's major page faults (unix) and swap usage
+	// (Linux) with each metric, so long pauses can be related to OS memory
+	// pressure
+	Paging bool
+
+	// PauseQuantiles reads pause quantiles with debug.ReadGCStats for each
+	// sample, covering recent pauses whether or not events were detected
+	PauseQuantiles bool
+
+	// NotifyGC also takes a sample as soon as each GC cycle completes, so
+	// OnGCEvent fires within milliseconds rather than at the next interval.
+	// Samples are taken at most every 10ms, and each one counts towards
+	// MaxSamples, so frequent GCs shorten the retained window. Ignored with
+	// a Sampler.
+	NotifyGC bool
+
+	// Sampler, when set, produces each sample instead of reading the local
+	// runtime, e.g. to monitor another process. Ticks where it fails are
+	// skipped. Process CPU, RSS and cgroup sampling describe the local process
+	// and are not applied to its samples.
+	Sampler func(context.Context) (*types.GCMetrics, error)
 }
 
 // New creates a new GC metrics collector
@@ -62,33 +188,68 @@ func New(config *Config) *Collector {
 		maxSamples = types.DefaultMaxSamples
 	}
 
-	return &Collector{
+	c := &Collector{
 		interval:          interval,
 		maxSamples:        maxSamples,
-		metrics:           make([]*types.GCMetrics, 0, min(maxSamples, 256)), // Reasonable initial capacity
-		events:            make([]*types.GCEvent, 0, min(maxSamples, 256)),
 		stopCh:            make(chan struct{}),
 		onMetricCollected: config.OnMetricCollected,
 		onGCEvent:         config.OnGCEvent,
 		useLiteMetrics:    config.UseLiteMetrics,
+		pooled:            config.PooledMetrics && !config.UseLiteMetrics && config.Sampler == nil,
+		processCPU:        config.ProcessCPU,
+		processRSS:        config.ProcessRSS,
+		paging:            config.Paging,
+		pauseQuantiles:    config.PauseQuantiles,
+		notifyGC:          config.NotifyGC,
+		sampler:           config.Sampler,
+		labels:            maps.Clone(config.Labels),
+		logger:            config.Logger,
 	}
+	if c.logger == nil {
+		c.logger = types.NopLogger()
+	}
+	if config.IntervalJitter > 0 && config.IntervalJitter < 1 {
+		c.jitter = config.IntervalJitter
+	}
+	if a := config.AdaptiveInterval; a != nil && a.Validate() == nil {
+		adaptive := a.WithDefaults()
+		c.adaptive = &adaptive
+		c.interval = adaptive.Max
+	}
+	c.current.Store(int64(c.interval))
+	if config.Retention != nil && config.Retention.Validate() == nil {
+		policy := *config.Retention
+		policy.Tiers = slices.Clone(policy.Tiers)
+		c.retention = &policy
+	}
+	c.metrics.Store(newRing[types.GCMetrics](maxSamples))
+	c.events.Store(newRing[types.GCEvent](maxSamples))
+	c.resetTiers()
+	return c
 }
 
 // Start begins collecting GC metrics.
 // Returns ErrCollectorAlreadyRunning if the collector is already running.
-// The collector will stop when the context is canceled or Stop() is called.
+// The collector will stop when the context is canceled or Stop() is called,
+// and can then be started again, keeping the collected history.
 func (c *Collector) Start(ctx context.Context) error {
+	c.lifecycle.Lock()
+	defer c.lifecycle.Unlock()
+
 	if !c.running.CompareAndSwap(false, true) {
 		return types.ErrCollectorAlreadyRunning
 	}
 
-	// Reset stop channel for potential restart
-	c.mu.Lock()
-	c.stopCh = make(chan struct{})
-	c.mu.Unlock()
+	// Each run gets its own stop channel, so a restart never reuses a closed one
+	stopCh := make(chan struct{})
+	c.stopCh = stopCh
+	if c.sampler == nil {
+		// The runtime configuration is unknown for samples from elsewhere
+		c.runtimeInfo.Store(types.CurrentRuntimeInfo())
+	}
 
 	c.wg.Add(1)
-	go c.collectLoop(ctx)
+	go c.collectLoop(ctx, stopCh)
 
 	return nil
 }
@@ -96,16 +257,55 @@ func (c *Collector) Start(ctx context.Context) error {
 // Stop stops collecting GC metrics and waits for the collection loop to finish.
 // It is safe to call Stop multiple times.
 func (c *Collector) Stop() {
+	_ = c.StopE()
+}
+
+// StopE is Stop, but returns ErrCollectorNotRunning if the collector was not
+// running, because it was never started, was already stopped, or its
+// context was canceled
+func (c *Collector) StopE() error {
+	c.lifecycle.Lock()
+	defer c.lifecycle.Unlock()
+
 	if !c.running.CompareAndSwap(true, false) {
-		return
+		return types.ErrCollectorNotRunning
 	}
-
-	c.mu.Lock()
 	close(c.stopCh)
-	c.mu.Unlock()
 
-	// Wait for the collection loop to finish
+	// Wait for the collection loop to finish before another Start can run
 	c.wg.Wait()
+	return nil
+}
+
+// Interval returns the collection interval in use, which varies with GC
+// activity under an adaptive interval
+func (c *Collector) Interval() time.Duration {
+	return time.Duration(c.current.Load())
+}
+
+// MaxInterval returns the longest interval the collector samples at: the
+// configured interval, or the maximum of an adaptive interval
+func (c *Collector) MaxInterval() time.Duration {
+	return c.interval
+}
+
+// Pause stops taking samples, keeping the collected history, until Resume
+// is called, e.g. to exclude a noisy startup or bulk load phase. GC cycles
+// completed while paused are not recorded as events. Pausing persists
+// across Stop and Start; Inject and InjectEvent still record.
+func (c *Collector) Pause() {
+	c.paused.Store(true)
+}
+
+// Resume resumes taking samples after Pause. The first sample after
+// resuming becomes the baseline for detecting GC events.
+func (c *Collector) Resume() {
+	c.paused.Store(false)
+}
+
+// IsPaused returns whether sampling is paused
+func (c *Collector) IsPaused() bool {
+	return c.paused.Load()
 }
 
 // IsRunning returns whether the collector is currently running
@@ -113,45 +313,139 @@ func (c *Collector) IsRunning() bool {
 	return c.running.Load()
 }
 
-// GetMetrics returns a copy of all collected metrics
-func (c *Collector) GetMetrics() []*types.GCMetrics {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+// Inject records an externally produced sample as if it had been collected,
+// including the OnMetricCollected callback. It works whether or not the
+// collector is running.
+func (c *Collector) Inject(metrics *types.GCMetrics) {
+	c.addMetrics(metrics)
+	c.metricCollected(metrics)
+}
 
-	if len(c.metrics) == 0 {
-		return nil
+// InjectEvent records an externally produced GC event, including the
+// OnGCEvent callback
+func (c *Collector) InjectEvent(event *types.GCEvent) {
+	c.addEvent(event)
+	if c.onGCEvent != nil {
+		defer c.recoverCallback("OnGCEvent")
+		c.onGCEvent(event)
 	}
+}
 
-	result := make([]*types.GCMetrics, len(c.metrics))
-	copy(result, c.metrics)
-	return result
+// metricCollected calls the OnMetricCollected callback
+func (c *Collector) metricCollected(metrics *types.GCMetrics) {
+	if c.onMetricCollected != nil {
+		defer c.recoverCallback("OnMetricCollected")
+		c.onMetricCollected(metrics)
+	}
 }
 
-// GetEvents returns a copy of all collected GC events
-func (c *Collector) GetEvents() []*types.GCEvent {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+// recoverCallback logs a panic in the named callback instead of letting it
+// stop collection. It must be deferred.
+func (c *Collector) recoverCallback(name string) {
+	if r := recover(); r != nil {
+		c.logger.Error("callback panicked", "callback", name, "panic", r)
+	}
+}
 
-	if len(c.events) == 0 {
-		return nil
+// PushRegion makes name the active region label for samples and events
+// recorded from now on, until the returned pop function is called. Regions
+// nest: popping restores the label that was active before. Pop may be called
+// out of order and more than once.
+func (c *Collector) PushRegion(name string) (pop func()) {
+	c.mu.Lock()
+	c.nextRegionID++
+	id := c.nextRegionID
+	c.regions = append(c.regions, regionLabel{id: id, name: name})
+	c.mu.Unlock()
+
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.regions = slices.DeleteFunc(c.regions, func(r regionLabel) bool { return r.id == id })
+	}
+}
+
+// ActiveRegion returns the current region label, or "" when none is active
+func (c *Collector) ActiveRegion() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.activeRegionLocked()
+}
+
+// activeRegionLocked returns the current region label. c.mu must be held.
+func (c *Collector) activeRegionLocked() string {
+	if len(c.regions) == 0 {
+		return ""
+	}
+	return c.regions[len(c.regions)-1].name
+}
+
+// Labels returns a copy of the labels identifying the monitored process
+func (c *Collector) Labels() map[string]string {
+	return maps.Clone(c.labels)
+}
+
+// RuntimeInfo returns the runtime configuration recorded when collection last started.
+// Returns nil if the collector has never been started.
+func (c *Collector) RuntimeInfo() *types.RuntimeInfo {
+	return c.runtimeInfo.Load()
+}
+
+// GetMetrics returns a copy of all collected metrics, oldest first. With a
+// retention policy, samples beyond its full-resolution period are replaced
+// by the compacted samples of its tiers. With pooled metrics, the samples
+// are copies too.
+func (c *Collector) GetMetrics() []*types.GCMetrics {
+	if !c.pooled {
+		return c.viewMetrics()
 	}
 
-	result := make([]*types.GCEvent, len(c.events))
-	copy(result, c.events)
-	return result
+	c.poolMu.RLock()
+	defer c.poolMu.RUnlock()
+	metrics := c.viewMetrics()
+	for i, m := range metrics {
+		metrics[i] = m.Clone()
+	}
+	return metrics
+}
+
+// ViewMetrics calls fn with the collected metrics as GetMetrics returns
+// them, but without copying pooled samples. fn must not modify or retain
+// the samples, nor call GetMetrics or GetLatestMetrics.
+func (c *Collector) ViewMetrics(fn func([]*types.GCMetrics)) {
+	if c.pooled {
+		c.poolMu.RLock()
+		defer c.poolMu.RUnlock()
+	}
+	fn(c.viewMetrics())
+}
+
+// viewMetrics returns the collected metrics without copying the samples
+func (c *Collector) viewMetrics() []*types.GCMetrics {
+	metrics := c.metrics.Load().all()
+	if tiers := c.tiers.Load(); tiers != nil {
+		return c.retained(metrics, *tiers)
+	}
+	return metrics
+}
+
+// GetEvents returns a copy of all collected GC events
+func (c *Collector) GetEvents() []*types.GCEvent {
+	return c.events.Load().all()
 }
 
 // GetLatestMetrics returns a copy of the most recent metrics sample
 func (c *Collector) GetLatestMetrics() *types.GCMetrics {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-
-	if len(c.metrics) == 0 {
+	if c.pooled {
+		c.poolMu.RLock()
+		defer c.poolMu.RUnlock()
+	}
+	latest := c.metrics.Load().latest()
+	if latest == nil {
 		return nil
 	}
 
-	// Return a deep copy to prevent race conditions
-	latest := c.metrics[len(c.metrics)-1]
+	// Return a deep copy so callers can't modify the shared sample
 	return latest.Clone()
 }
 
@@ -160,97 +454,241 @@ func (c *Collector) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// Clear references for GC
-	clear(c.metrics)
-	clear(c.events)
-	c.metrics = c.metrics[:0]
-	c.events = c.events[:0]
+	// Readers may still be copying from the old rings, so start from fresh
+	// ones rather than clearing them in place
+	c.metrics.Store(newRing[types.GCMetrics](c.maxSamples))
+	c.events.Store(newRing[types.GCEvent](c.maxSamples))
+	c.resetTiers()
 }
 
-// MetricCount returns the current number of collected metrics
+// resetTiers starts the retention tiers afresh, if there is a policy
+func (c *Collector) resetTiers() {
+	if c.retention != nil {
+		tiers := newTiers(c.retention)
+		c.tiers.Store(&tiers)
+	}
+}
+
+// MetricCount returns the current number of collected metrics, as
+// GetMetrics would return them
 func (c *Collector) MetricCount() int {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	return len(c.metrics)
+	if c.retention != nil {
+		return len(c.viewMetrics())
+	}
+	return c.metrics.Load().len()
 }
 
 // EventCount returns the current number of collected events
 func (c *Collector) EventCount() int {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	return len(c.events)
+	return c.events.Load().len()
 }
 
-// collectLoop runs the collection loop.
-// It handles context cancellation and stop signals gracefully.
-func (c *Collector) collectLoop(ctx context.Context) {
+// collectLoop runs the collection loop until ctx is canceled or stopCh is
+// closed. Cancellation marks the collector not running.
+func (c *Collector) collectLoop(ctx context.Context, stopCh <-chan struct{}) {
 	defer c.wg.Done()
 
-	ticker := time.NewTicker(c.interval)
+	c.current.Store(int64(c.interval))
+	ticker := time.NewTicker(c.jittered(c.interval))
 	defer ticker.Stop()
 
-	var lastGCCount uint32
+	var last *types.GCMetrics
+	var cgroupLimit uint64
+	var cgroupReadAt time.Time
+
+	sample := func() {
+		if c.paused.Load() {
+			// Resume from a fresh baseline, so cycles completed while paused
+			// are not recorded as events
+			last = nil
+			return
+		}
+
+		var metrics *types.GCMetrics
+		switch {
+		case c.sampler != nil:
+			var err error
+			if metrics, err = c.sampler(ctx); err != nil || metrics == nil {
+				if err != nil && ctx.Err() == nil {
+					c.logger.Warn("GC sample failed", "error", err)
+				}
+				return
+			}
+		case c.useLiteMetrics:
+			metrics = types.NewGCMetricsLite()
+		case c.pooled:
+			metrics = types.NewGCMetricsPooled()
+		default:
+			metrics = types.NewGCMetrics()
+		}
+		if c.sampler == nil {
+			c.sampleProcess(metrics)
+			if c.pauseQuantiles {
+				metrics.PauseQuantiles = types.ReadPauseQuantiles()
+			}
+			// Container limits rarely change, so the cgroup files are re-read periodically
+			if now := time.Now(); now.Sub(cgroupReadAt) >= cgroupRefreshInterval {
+				cgroupLimit, _ = types.ReadCgroupMemoryLimit()
+				cgroupReadAt = now
+			}
+			metrics.CgroupMemoryLimit = cgroupLimit
+		}
+
+		// Detect new GC events
+		if last != nil && metrics.NumGC > last.NumGC {
+			c.detectGCEvents(last, metrics)
+		}
+		if c.adaptive != nil && last != nil {
+			c.adapt(ticker, last, metrics)
+		}
+		last = metrics
+
+		c.addMetrics(metrics)
+		c.metricCollected(metrics)
+	}
+
+	// Nil channels never fire, leaving collection to the ticker alone
+	var gcDone <-chan struct{}
+	var pending <-chan time.Time
+	if c.notifyGC && c.sampler == nil {
+		n := newGCNotifier()
+		defer n.Stop()
+		gcDone = n.C
+
+		// Take a baseline right away so the first cycle yields an event
+		sample()
+	}
 
 	for {
 		select {
 		case <-ctx.Done():
 			c.running.Store(false)
 			return
-		case <-c.stopCh:
+		case <-stopCh:
 			return
 		case <-ticker.C:
-			var metrics *types.GCMetrics
-			if c.useLiteMetrics {
-				metrics = types.NewGCMetricsLite()
-			} else {
-				metrics = types.NewGCMetrics()
+			sample()
+			if c.jitter > 0 {
+				ticker.Reset(c.jittered(c.Interval()))
 			}
-
-			// Detect new GC events
-			if lastGCCount > 0 && metrics.NumGC > lastGCCount {
-				c.detectGCEvents(lastGCCount, metrics)
+		case <-gcDone:
+			// Bound the sampling rate under rapid GCs by deferring the sample;
+			// cycles completing meanwhile are picked up by it
+			if pending != nil {
+				continue
 			}
-			lastGCCount = metrics.NumGC
-
-			c.addMetrics(metrics)
-
-			// Call callback if provided
-			if c.onMetricCollected != nil {
-				c.onMetricCollected(metrics)
+			if last != nil {
+				if wait := minNotifySpacing - time.Since(last.Timestamp); wait > 0 {
+					pending = time.After(wait)
+					continue
+				}
 			}
+			sample()
+		case <-pending:
+			pending = nil
+			sample()
 		}
 	}
 }
 
+// jittered returns interval spread by the configured jitter fraction
+func (c *Collector) jittered(interval time.Duration) time.Duration {
+	if c.jitter == 0 {
+		return interval
+	}
+	spread := c.jitter * (2*rand.Float64() - 1)
+	return max(interval+time.Duration(spread*float64(interval)), time.Millisecond)
+}
+
+// adapt resets the ticker to the adaptive interval for the GC frequency and
+// allocation rate between two samples
+func (c *Collector) adapt(ticker *time.Ticker, prev, current *types.GCMetrics) {
+	elapsed := current.Timestamp.Sub(prev.Timestamp).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	var gcFrequency, allocationRate float64
+	if current.NumGC >= prev.NumGC {
+		gcFrequency = float64(current.NumGC-prev.NumGC) / elapsed
+	}
+	if current.TotalAlloc >= prev.TotalAlloc {
+		allocationRate = float64(current.TotalAlloc-prev.TotalAlloc) / elapsed
+	}
+
+	interval := c.Interval()
+	if next := c.adaptive.Next(interval, gcFrequency, allocationRate); next != interval {
+		ticker.Reset(next)
+		c.current.Store(int64(next))
+	}
+}
+
+// sampleProcess adds the enabled OS-level process measurements to a sample
+func (c *Collector) sampleProcess(metrics *types.GCMetrics) {
+	if c.processCPU {
+		metrics.ProcessCPUSeconds, _ = types.ReadProcessCPU()
+	}
+	if c.processRSS {
+		metrics.ProcessRSS, _ = types.ReadProcessRSS()
+	}
+	if c.paging {
+		var faultsOK, swapOK bool
+		metrics.MajorPageFaults, faultsOK = types.ReadMajorPageFaults()
+		metrics.SwapUsed, swapOK = types.ReadProcessSwap()
+		metrics.PagingSampled = faultsOK || swapOK
+	}
+}
+
 // addMetrics adds a metrics sample to the collection
 func (c *Collector) addMetrics(metrics *types.GCMetrics) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.metrics = append(c.metrics, metrics)
+	if metrics.Region == "" {
+		metrics.Region = c.activeRegionLocked()
+	}
 
-	// Keep only the last maxSamples samples using efficient trimming
-	if len(c.metrics) > c.maxSamples {
-		excess := len(c.metrics) - c.maxSamples
-		// Zero out removed elements to allow GC
-		for i := 0; i < excess; i++ {
-			c.metrics[i] = nil
+	if evicted := c.metrics.Load().add(metrics); evicted != nil && c.pooled {
+		// Wait for readers copying the sample before recycling its slices
+		c.poolMu.Lock()
+		evicted.Release()
+		c.poolMu.Unlock()
+	}
+	if tiers := c.tiers.Load(); tiers != nil {
+		for _, t := range *tiers {
+			t.add(metrics)
 		}
-		c.metrics = c.metrics[excess:]
 	}
 }
 
-// detectGCEvents detects and records GC events
-func (c *Collector) detectGCEvents(lastGCCount uint32, current *types.GCMetrics) {
+// detectGCEvents detects and records the GC events completed between two samples.
+// The pause buffer only holds the most recent cycles; when more completed
+// since the previous sample, the overwritten ones are recorded as a single
+// gap marker instead of being read back from stale entries.
+func (c *Collector) detectGCEvents(prev, current *types.GCMetrics) {
 	// Skip if no pause data available (lite mode)
 	if len(current.PauseNs) == 0 {
 		return
 	}
 
-	newGCCount := current.NumGC - lastGCCount
+	newGCCount := current.NumGC - prev.NumGC
 	pauseLen := uint32(len(current.PauseNs))
 
-	for i := uint32(0); i < newGCCount; i++ {
+	var first uint32
+	if newGCCount > pauseLen {
+		first = newGCCount - pauseLen
+		c.logger.Warn("GC cycles missed: more completed between samples than the pause buffer holds",
+			"missed", first, "interval", current.Timestamp.Sub(prev.Timestamp))
+		c.InjectEvent(&types.GCEvent{
+			Sequence:      prev.NumGC + 1,
+			StartTime:     prev.Timestamp,
+			EndTime:       current.Timestamp,
+			TriggerReason: types.TriggerUnknown,
+			Source:        types.EventSourceRuntime,
+			Missed:        first,
+		})
+	}
+
+	for i := first; i < newGCCount; i++ {
 		// Get pause time for this GC with wraparound handling
 		pauseIndex := (current.NumGC - newGCCount + i) % pauseLen
 		pauseNs := current.PauseNs[pauseIndex]
@@ -260,21 +698,54 @@ func (c *Collector) detectGCEvents(lastGCCount uint32, current *types.GCMetrics)
 		endTime := time.Unix(0, int64(endNs))
 		startTime := endTime.Add(-time.Duration(pauseNs))
 
+		// The previous cycle's end, for telling periodic GCs apart; unknown
+		// for the oldest cycle still buffered after an overflow
+		var prevEnd time.Time
+		if seq := current.NumGC - newGCCount + i; seq > 0 && (first == 0 || i > first) {
+			prevEnd = time.Unix(0, int64(current.PauseEnd[(seq-1)%pauseLen]))
+		}
+
 		event := &types.GCEvent{
 			Sequence:      current.NumGC - newGCCount + i + 1,
 			StartTime:     startTime,
 			EndTime:       endTime,
 			Duration:      time.Duration(pauseNs),
-			TriggerReason: guessTriggerReason(current),
+			TriggerReason: classifyTrigger(prev, current, prevEnd, endTime),
+			Source:        types.EventSourceRuntime,
 		}
+		estimateHeap(event, prev, current, i, newGCCount)
 
-		c.addEvent(event)
+		c.InjectEvent(event)
+	}
+}
 
-		// Call callback if provided
-		if c.onGCEvent != nil {
-			c.onGCEvent(event)
-		}
+// estimateHeap fills in the heap before and after the k-th of n cycles
+// completed between two samples, and the bytes it reclaimed. Samples only
+// show the heap goal and live heap at their own time: the heap before a
+// cycle is taken as the goal it was triggered against, and the heap after
+// as the live heap it marked. Cycles in between get values interpolated
+// from both samples. The heap after is unknown without HeapLive.
+func estimateHeap(event *types.GCEvent, prev, current *types.GCMetrics, k, n uint32) {
+	event.HeapBefore = interpolate(prev.NextGC, current.NextGC, k, n)
+	if prev.HeapLive == 0 || current.HeapLive == 0 {
+		return
+	}
+	event.HeapLive = interpolate(prev.HeapLive, current.HeapLive, k+1, n)
+	event.HeapAfter = event.HeapLive
+	if event.HeapBefore > event.HeapLive {
+		event.HeapReleased = event.HeapBefore - event.HeapLive
+	}
+}
+
+// interpolate returns the value k/n of the way from a to b
+func interpolate(a, b uint64, k, n uint32) uint64 {
+	if k == 0 {
+		return a
 	}
+	if k >= n {
+		return b
+	}
+	return uint64(float64(a) + (float64(b)-float64(a))*float64(k)/float64(n))
 }
 
 // addEvent adds a GC event to the collection
@@ -282,40 +753,55 @@ func (c *Collector) addEvent(event *types.GCEvent) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.events = append(c.events, event)
-
-	// Keep only the last maxSamples events
-	if len(c.events) > c.maxSamples {
-		excess := len(c.events) - c.maxSamples
-		// Zero out removed elements to allow GC
-		for i := 0; i < excess; i++ {
-			c.events[i] = nil
-		}
-		c.events = c.events[excess:]
+	if event.Region == "" {
+		event.Region = c.activeRegionLocked()
 	}
-}
 
-// guessTriggerReason attempts to guess the GC trigger reason
-func guessTriggerReason(metrics *types.GCMetrics) string {
-	// This is a heuristic-based approach
-	// In practice, Go doesn't expose the actual trigger reason
+	c.events.Load().add(event)
+}
 
-	if metrics.HeapAlloc >= metrics.NextGC {
-		return "heap_size"
+// periodicGCInterval is the runtime's forced GC period (runtime.forcegcperiod)
+const periodicGCInterval = 2 * time.Minute
+
+// cgroupRefreshInterval is how often the container memory limit is re-read
+const cgroupRefreshInterval = time.Minute
+
+// classifyTrigger determines why a GC cycle completed between two samples
+// was started. Forced cycles are identified from the forced cycle counters:
+// when every cycle in the window was forced, or none was, the answer is exact.
+// Automatic cycles that began more than the periodic GC interval after the
+// previous one, with the heap still below its goal, were started by the
+// periodic trigger; the rest by the heap goal.
+func classifyTrigger(prev, current *types.GCMetrics, prevEnd, end time.Time) string {
+	total := current.NumGC - prev.NumGC
+	forced := forcedCycles(prev, current)
+
+	switch {
+	case forced >= uint64(total):
+		return types.TriggerForced
+	case forced > 0:
+		// Counters don't say which of the window's cycles were forced
+		return types.TriggerUnknown
+	case !prevEnd.IsZero() && end.Sub(prevEnd) >= periodicGCInterval && prev.HeapAlloc < prev.NextGC:
+		return types.TriggerPeriodic
+	default:
+		return types.TriggerHeapSize
 	}
+}
 
-	// Force GC detection (when NextGC is very low compared to heap)
-	if metrics.NextGC < metrics.HeapAlloc/2 {
-		return "forced"
+// forcedCycles returns the number of forced GC cycles between two samples,
+// preferring the runtime/metrics counter over MemStats.NumForcedGC
+func forcedCycles(prev, current *types.GCMetrics) uint64 {
+	if current.GCCyclesAutomatic+current.GCCyclesForced > 0 {
+		if current.GCCyclesForced < prev.GCCyclesForced {
+			return 0
+		}
+		return current.GCCyclesForced - prev.GCCyclesForced
 	}
-
-	// Periodic GC (when it's been a while since last GC)
-	timeSinceLastGC := time.Since(metrics.LastGC)
-	if timeSinceLastGC > 2*time.Minute {
-		return "periodic"
+	if current.NumForcedGC < prev.NumForcedGC {
+		return 0
 	}
-
-	return "automatic"
+	return uint64(current.NumForcedGC - prev.NumForcedGC)
 }
 
 // CollectOnce collects a single GC metrics sample
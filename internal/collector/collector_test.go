@@ -2,7 +2,12 @@ package collector
 
 import (
 	"context"
+	"errors"
+	"runtime"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -156,6 +161,107 @@ func TestCollector_ContextCancellation(t *testing.T) {
 	}
 }
 
+func TestCollector_StopE(t *testing.T) {
+	c := New(&Config{Interval: 10 * time.Millisecond})
+	if err := c.StopE(); !errors.Is(err, types.ErrCollectorNotRunning) {
+		t.Errorf("StopE() before Start = %v, want ErrCollectorNotRunning", err)
+	}
+
+	_ = c.Start(context.Background())
+	if err := c.StopE(); err != nil {
+		t.Errorf("StopE() error: %v", err)
+	}
+	if err := c.StopE(); !errors.Is(err, types.ErrCollectorNotRunning) {
+		t.Errorf("Second StopE() = %v, want ErrCollectorNotRunning", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = c.Start(ctx)
+	cancel()
+	deadline := time.Now().Add(time.Second)
+	for c.IsRunning() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if err := c.StopE(); !errors.Is(err, types.ErrCollectorNotRunning) {
+		t.Errorf("StopE() after cancellation = %v, want ErrCollectorNotRunning", err)
+	}
+}
+
+func TestCollector_ConcurrentStartStop(t *testing.T) {
+	c := New(&Config{Interval: time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for range 200 {
+					_ = c.Start(ctx)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for range 200 {
+					_ = c.StopE()
+				}
+			}()
+		}
+		wg.Wait()
+		// A stop that closed another run's channel would leave this run's
+		// loop going, and the final Stop would never return
+		c.Stop()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Concurrent Start and StopE left a collection loop running")
+	}
+	if c.IsRunning() {
+		t.Error("Collector should not be running after Stop")
+	}
+}
+
+func TestCollector_Restart(t *testing.T) {
+	c := New(&Config{Interval: 5 * time.Millisecond})
+	waitForSamples := func(n int) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for c.MetricCount() < n {
+			if time.Now().After(deadline) {
+				t.Fatalf("Collected %d samples, want %d", c.MetricCount(), n)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// Start→Stop→Start, then restart after cancellation, keeping history
+	for i, n := range []int{2, 4, 6} {
+		ctx, cancel := context.WithCancel(context.Background())
+		if err := c.Start(ctx); err != nil {
+			t.Fatalf("Start() %d error: %v", i, err)
+		}
+		waitForSamples(n)
+		if i == 1 {
+			cancel()
+			deadline := time.Now().Add(time.Second)
+			for c.IsRunning() && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+			}
+		}
+		c.Stop()
+		cancel()
+	}
+	if c.IsRunning() {
+		t.Error("Expected the collector to be stopped")
+	}
+}
+
 func TestCollector_GetMetrics_Empty(t *testing.T) {
 	c := New(nil)
 	metrics := c.GetMetrics()
@@ -364,41 +470,196 @@ func TestCollectForDuration_ZeroInterval(t *testing.T) {
 	}
 }
 
-func TestGuessTriggerReason(t *testing.T) {
+func TestClassifyTrigger(t *testing.T) {
+	now := time.Now()
+	prev := &types.GCMetrics{
+		NumGC:             10,
+		HeapAlloc:         50 * 1024 * 1024,
+		NextGC:            100 * 1024 * 1024,
+		GCCyclesAutomatic: 8,
+		GCCyclesForced:    2,
+	}
+
 	tests := []struct {
 		name     string
-		metrics  *types.GCMetrics
+		current  *types.GCMetrics
+		prevEnd  time.Time
 		expected string
 	}{
 		{
-			name: "heap_size trigger",
-			metrics: &types.GCMetrics{
-				HeapAlloc: 100 * 1024 * 1024,
-				NextGC:    50 * 1024 * 1024,
-			},
-			expected: "heap_size",
+			name:     "heap goal",
+			current:  &types.GCMetrics{NumGC: 11, GCCyclesAutomatic: 9, GCCyclesForced: 2},
+			prevEnd:  now.Add(-time.Second),
+			expected: types.TriggerHeapSize,
 		},
 		{
-			name: "automatic trigger",
-			metrics: &types.GCMetrics{
-				HeapAlloc: 50 * 1024 * 1024,
-				NextGC:    100 * 1024 * 1024,
-				LastGC:    time.Now(),
-			},
-			expected: "automatic",
+			name:     "forced",
+			current:  &types.GCMetrics{NumGC: 12, GCCyclesAutomatic: 8, GCCyclesForced: 4},
+			prevEnd:  now.Add(-time.Second),
+			expected: types.TriggerForced,
+		},
+		{
+			name:     "mixed window",
+			current:  &types.GCMetrics{NumGC: 12, GCCyclesAutomatic: 9, GCCyclesForced: 3},
+			prevEnd:  now.Add(-time.Second),
+			expected: types.TriggerUnknown,
+		},
+		{
+			name:     "periodic",
+			current:  &types.GCMetrics{NumGC: 11, GCCyclesAutomatic: 9, GCCyclesForced: 2},
+			prevEnd:  now.Add(-3 * time.Minute),
+			expected: types.TriggerPeriodic,
+		},
+		{
+			name:     "forced without runtime/metrics counters",
+			current:  &types.GCMetrics{NumGC: 11, NumForcedGC: 1},
+			prevEnd:  now.Add(-3 * time.Minute),
+			expected: types.TriggerForced,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := guessTriggerReason(tt.metrics)
-			if result != tt.expected {
-				t.Errorf("guessTriggerReason() = %s, want %s", result, tt.expected)
+			p := *prev
+			if tt.current.GCCyclesAutomatic == 0 {
+				p.GCCyclesAutomatic, p.GCCyclesForced = 0, 0
+			}
+			if got := classifyTrigger(&p, tt.current, tt.prevEnd, now); got != tt.expected {
+				t.Errorf("classifyTrigger() = %s, want %s", got, tt.expected)
 			}
 		})
 	}
 }
 
+func TestCollector_ForcedGCEvents(t *testing.T) {
+	c := New(&Config{Interval: 10 * time.Millisecond, MaxSamples: 100})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+
+	// Make sure a baseline sample exists before forcing GCs
+	time.Sleep(30 * time.Millisecond)
+	runtime.GC()
+	time.Sleep(50 * time.Millisecond)
+	c.Stop()
+
+	// Other GCs may run concurrently, so only require that the forced one is recognized
+	for _, e := range c.GetEvents() {
+		if e.TriggerReason == types.TriggerForced || e.TriggerReason == types.TriggerUnknown {
+			return
+		}
+	}
+	t.Errorf("runtime.GC cycle not classified as forced, events: %d", c.EventCount())
+}
+
+func TestCollector_PushRegion(t *testing.T) {
+	c := New(nil)
+
+	popOuter := c.PushRegion("startup")
+	c.Inject(&types.GCMetrics{})
+	popInner := c.PushRegion("warmup")
+	c.InjectEvent(&types.GCEvent{})
+	popInner()
+	popInner() // popping twice is a no-op
+	c.Inject(&types.GCMetrics{})
+	popOuter()
+	c.Inject(&types.GCMetrics{Region: "preset"})
+	c.Inject(&types.GCMetrics{})
+
+	var got []string
+	for _, m := range c.GetMetrics() {
+		got = append(got, m.Region)
+	}
+	want := []string{"startup", "startup", "preset", ""}
+	if !slices.Equal(got, want) {
+		t.Errorf("metric regions = %q, want %q", got, want)
+	}
+	if events := c.GetEvents(); len(events) != 1 || events[0].Region != "warmup" {
+		t.Errorf("event should be labeled with the innermost region, got %+v", events)
+	}
+	if c.ActiveRegion() != "" {
+		t.Errorf("ActiveRegion() = %q after popping all regions", c.ActiveRegion())
+	}
+}
+
+func TestCollector_PushRegion_OutOfOrder(t *testing.T) {
+	c := New(nil)
+	popA := c.PushRegion("a")
+	popB := c.PushRegion("b")
+
+	popA()
+	if got := c.ActiveRegion(); got != "b" {
+		t.Errorf("ActiveRegion() = %q, want b", got)
+	}
+	popB()
+	if got := c.ActiveRegion(); got != "" {
+		t.Errorf("ActiveRegion() = %q, want empty", got)
+	}
+}
+
+func TestCollector_ProcessCPU(t *testing.T) {
+	if _, ok := types.ReadProcessCPU(); !ok {
+		t.Skip("process CPU sampling not supported on this platform")
+	}
+
+	c := New(&Config{Interval: 10 * time.Millisecond, ProcessCPU: true})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	<-ctx.Done()
+	c.Stop()
+
+	latest := c.GetLatestMetrics()
+	if latest == nil || latest.ProcessCPUSeconds <= 0 {
+		t.Errorf("Expected samples to carry process CPU time, got %+v", latest)
+	}
+}
+
+func TestCollector_ProcessRSS(t *testing.T) {
+	if _, ok := types.ReadProcessRSS(); !ok {
+		t.Skip("process RSS sampling not supported on this platform")
+	}
+
+	c := New(&Config{Interval: 10 * time.Millisecond, ProcessRSS: true})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	<-ctx.Done()
+	c.Stop()
+
+	latest := c.GetLatestMetrics()
+	if latest == nil || latest.ProcessRSS == 0 {
+		t.Errorf("Expected samples to carry process RSS, got %+v", latest)
+	}
+}
+
+func TestCollector_Paging(t *testing.T) {
+	if _, ok := types.ReadMajorPageFaults(); !ok {
+		t.Skip("paging sampling not supported on this platform")
+	}
+
+	c := New(&Config{Interval: 10 * time.Millisecond, Paging: true})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	<-ctx.Done()
+	c.Stop()
+
+	// A process that never faulted still reports that it was sampled
+	latest := c.GetLatestMetrics()
+	if latest == nil || !latest.PagingSampled {
+		t.Errorf("Expected samples to be marked as paging sampled, got %+v", latest)
+	}
+}
+
 // Concurrency test
 func TestCollector_ConcurrentAccess(t *testing.T) {
 	c := New(&Config{
@@ -463,3 +724,518 @@ func BenchmarkCollector_GetMetrics(b *testing.B) {
 	b.StopTimer()
 	c.Stop()
 }
+
+func TestCollector_Inject(t *testing.T) {
+	var metricCalls, eventCalls int
+	c := New(&Config{
+		OnMetricCollected: func(*types.GCMetrics) { metricCalls++ },
+		OnGCEvent:         func(*types.GCEvent) { eventCalls++ },
+	})
+
+	c.InjectEvent(&types.GCEvent{Sequence: 1})
+	c.Inject(&types.GCMetrics{NumGC: 1, Synthetic: true})
+
+	if c.MetricCount() != 1 || c.EventCount() != 1 {
+		t.Errorf("Expected 1 metric and 1 event, got %d and %d", c.MetricCount(), c.EventCount())
+	}
+	if metricCalls != 1 || eventCalls != 1 {
+		t.Errorf("Expected callbacks to run once each, got %d and %d", metricCalls, eventCalls)
+	}
+}
+
+func TestRing(t *testing.T) {
+	r := newRing[int](10)
+	if r.all() != nil || r.latest() != nil || r.len() != 0 {
+		t.Fatal("Expected an empty ring")
+	}
+
+	var views [][]*int
+	for i := 0; i < 100; i++ {
+		v := i
+		r.add(&v)
+		views = append(views, r.all())
+	}
+
+	all := r.all()
+	if r.len() != 10 || len(all) != 10 || *all[0] != 90 || *all[9] != 99 {
+		t.Errorf("Ring kept %d entries starting at %d, want 90..99", len(all), *all[0])
+	}
+	if *r.latest() != 99 {
+		t.Errorf("Expected latest 99, got %d", *r.latest())
+	}
+	// Earlier copies must be unaffected by later appends overwriting slots
+	for i, v := range views {
+		if last := *v[len(v)-1]; last != i {
+			t.Errorf("View %d ends with %d after later appends", i, last)
+		}
+	}
+}
+
+func TestRing_ConcurrentReads(t *testing.T) {
+	r := newRing[int](8)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 10000; i++ {
+			v := i
+			r.add(&v)
+		}
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		all := r.all()
+		for i := 1; i < len(all); i++ {
+			if *all[i] != *all[i-1]+1 {
+				t.Fatalf("Read entries out of order: %d after %d", *all[i], *all[i-1])
+			}
+		}
+	}
+}
+
+func TestCollector_SnapshotIsStable(t *testing.T) {
+	c := New(&Config{MaxSamples: 3})
+	for i := 0; i < 3; i++ {
+		c.Inject(&types.GCMetrics{NumGC: uint32(i)})
+	}
+	before := c.GetMetrics()
+
+	for i := 3; i < 10; i++ {
+		c.Inject(&types.GCMetrics{NumGC: uint32(i)})
+	}
+	c.Clear()
+
+	for i, m := range before {
+		if m == nil || m.NumGC != uint32(i) {
+			t.Errorf("Earlier snapshot changed at %d: %+v", i, m)
+		}
+	}
+	if c.MetricCount() != 0 {
+		t.Errorf("Expected no metrics after Clear, got %d", c.MetricCount())
+	}
+}
+
+// rwMutexStore mirrors the collector's former RWMutex-guarded storage, as a
+// baseline for BenchmarkCollector_Contention
+type rwMutexStore struct {
+	mu      sync.RWMutex
+	metrics []*types.GCMetrics
+	max     int
+}
+
+func (s *rwMutexStore) add(m *types.GCMetrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metrics = append(s.metrics, m)
+	if len(s.metrics) > s.max {
+		s.metrics[0] = nil
+		s.metrics = s.metrics[1:]
+	}
+}
+
+func (s *rwMutexStore) latest() *types.GCMetrics {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.metrics) == 0 {
+		return nil
+	}
+	return s.metrics[len(s.metrics)-1].Clone()
+}
+
+func (s *rwMutexStore) count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.metrics)
+}
+
+// BenchmarkCollector_Contention measures reader throughput while a writer
+// records samples continuously, as with many endpoints polling a collector
+// sampling at a high frequency
+func BenchmarkCollector_Contention(b *testing.B) {
+	sample := &types.GCMetrics{NumGC: 1, HeapAlloc: 1 << 20}
+
+	run := func(b *testing.B, add func(*types.GCMetrics), read func()) {
+		for i := 0; i < 100; i++ {
+			add(sample)
+		}
+
+		stop := make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					add(sample)
+				}
+			}
+		}()
+
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				read()
+			}
+		})
+		b.StopTimer()
+		close(stop)
+		wg.Wait()
+	}
+
+	b.Run("rwmutex", func(b *testing.B) {
+		s := &rwMutexStore{max: 1000}
+		run(b, s.add, func() {
+			_ = s.latest()
+			_ = s.count()
+		})
+	})
+
+	b.Run("snapshot", func(b *testing.B) {
+		c := New(&Config{MaxSamples: 1000})
+		run(b, c.addMetrics, func() {
+			_ = c.GetLatestMetrics()
+			_ = c.MetricCount()
+		})
+	})
+}
+
+func TestDetectGCEvents_EstimatesHeap(t *testing.T) {
+	const mb = uint64(types.MB)
+	now := time.Now()
+	prev := &types.GCMetrics{NumGC: 10, NextGC: 8 * mb, HeapLive: 2 * mb, Timestamp: now}
+	current := &types.GCMetrics{
+		NumGC:     12,
+		NextGC:    12 * mb,
+		HeapLive:  6 * mb,
+		PauseNs:   make([]uint64, types.PauseBufferSize),
+		PauseEnd:  make([]uint64, types.PauseBufferSize),
+		Timestamp: now.Add(time.Second),
+	}
+
+	c := New(&Config{MaxSamples: 1000})
+	c.detectGCEvents(prev, current)
+
+	events := c.GetEvents()
+	if len(events) != 2 {
+		t.Fatalf("Got %d events, want 2", len(events))
+	}
+	// The first cycle was triggered against the earlier goal and left the
+	// live heap halfway to the later one; the second left the later one
+	want := []struct{ before, live uint64 }{
+		{8 * mb, 4 * mb},
+		{10 * mb, 6 * mb},
+	}
+	for i, e := range events {
+		if e.HeapBefore != want[i].before || e.HeapAfter != want[i].live || e.HeapLive != want[i].live {
+			t.Errorf("Event %d heap = %d->%d->%d, want %d->%d->%d", e.Sequence,
+				e.HeapBefore, e.HeapAfter, e.HeapLive, want[i].before, want[i].live, want[i].live)
+		}
+		if e.HeapReleased != want[i].before-want[i].live {
+			t.Errorf("Event %d HeapReleased = %d, want %d", e.Sequence, e.HeapReleased, want[i].before-want[i].live)
+		}
+	}
+}
+
+func TestDetectGCEvents_PauseBufferOverflow(t *testing.T) {
+	now := time.Now()
+	prev := &types.GCMetrics{NumGC: 100, Timestamp: now}
+	current := &types.GCMetrics{
+		NumGC:     100 + types.PauseBufferSize + 44,
+		PauseNs:   make([]uint64, types.PauseBufferSize),
+		PauseEnd:  make([]uint64, types.PauseBufferSize),
+		Timestamp: now.Add(time.Second),
+	}
+	// The buffer holds the last 256 cycles, indexed by (cycle-1) % 256
+	for seq := current.NumGC - types.PauseBufferSize + 1; seq <= current.NumGC; seq++ {
+		current.PauseNs[(seq-1)%types.PauseBufferSize] = uint64(seq)
+		current.PauseEnd[(seq-1)%types.PauseBufferSize] = uint64(now.UnixNano()) + uint64(seq)
+	}
+
+	var callbacks int
+	c := New(&Config{MaxSamples: 1000, OnGCEvent: func(*types.GCEvent) { callbacks++ }})
+	c.detectGCEvents(prev, current)
+
+	events := c.GetEvents()
+	if len(events) != types.PauseBufferSize+1 || callbacks != len(events) {
+		t.Fatalf("Got %d events and %d callbacks, want a gap marker and %d events",
+			len(events), callbacks, types.PauseBufferSize)
+	}
+
+	gap := events[0]
+	if !gap.IsGap() || gap.Sequence != 101 || gap.Missed != 44 || gap.Duration != 0 {
+		t.Errorf("Gap marker = %+v, want 44 missed cycles from 101", gap)
+	}
+	for _, e := range events[1:] {
+		if e.IsGap() || time.Duration(e.Sequence) != e.Duration {
+			t.Fatalf("Event %d has the pause of another cycle: %v", e.Sequence, e.Duration)
+		}
+	}
+	if events[1].Sequence != 145 || events[len(events)-1].Sequence != current.NumGC {
+		t.Errorf("Events span %d-%d, want 145-%d", events[1].Sequence, events[len(events)-1].Sequence, current.NumGC)
+	}
+}
+
+func TestCollector_PauseQuantiles(t *testing.T) {
+	runtime.GC()
+	c := New(&Config{Interval: 10 * time.Millisecond, PauseQuantiles: true})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	c.Stop()
+
+	metrics := c.GetMetrics()
+	if len(metrics) == 0 {
+		t.Fatal("Expected samples")
+	}
+	if metrics[len(metrics)-1].PauseQuantiles == nil {
+		t.Error("Samples should carry pause quantiles when enabled")
+	}
+}
+
+func TestCollector_AdaptiveInterval(t *testing.T) {
+	var busy atomic.Bool
+	var numGC uint32
+	c := New(&Config{
+		AdaptiveInterval: &types.AdaptiveInterval{Min: 5 * time.Millisecond, Max: 40 * time.Millisecond, GCFrequency: 100},
+		Sampler: func(context.Context) (*types.GCMetrics, error) {
+			if busy.Load() {
+				numGC += 10 // at least 250 GCs per second
+			}
+			return &types.GCMetrics{NumGC: numGC, Timestamp: time.Now()}, nil
+		},
+	})
+	if got := c.Interval(); got != 40*time.Millisecond {
+		t.Fatalf("Expected to start at Max, got %v", got)
+	}
+
+	waitFor := func(want time.Duration) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for c.Interval() != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("Interval stayed at %v, want %v", c.Interval(), want)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer c.Stop()
+
+	busy.Store(true)
+	waitFor(5 * time.Millisecond)
+	busy.Store(false)
+	waitFor(40 * time.Millisecond)
+}
+
+func TestCollector_Jittered(t *testing.T) {
+	c := New(&Config{IntervalJitter: 0.2})
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 100; i++ {
+		d := c.jittered(time.Second)
+		if d < 800*time.Millisecond || d > 1200*time.Millisecond {
+			t.Fatalf("jittered(1s) = %v, want within ±20%%", d)
+		}
+		seen[d] = true
+	}
+	if len(seen) < 2 {
+		t.Error("Expected jittered intervals to vary")
+	}
+
+	for _, jitter := range []float64{0, -0.5, 1} {
+		if d := New(&Config{IntervalJitter: jitter}).jittered(time.Second); d != time.Second {
+			t.Errorf("IntervalJitter %v: jittered(1s) = %v, want 1s", jitter, d)
+		}
+	}
+}
+
+func TestCollector_PauseResume(t *testing.T) {
+	var numGC atomic.Uint32
+	c := New(&Config{
+		Interval: 5 * time.Millisecond,
+		Sampler: func(context.Context) (*types.GCMetrics, error) {
+			return &types.GCMetrics{
+				NumGC:     numGC.Load(),
+				PauseNs:   make([]uint64, 256),
+				PauseEnd:  make([]uint64, 256),
+				Timestamp: time.Now(),
+			}, nil
+		},
+	})
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer c.Stop()
+
+	waitFor := func(cond func() bool, what string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatalf("Timed out waiting for %s", what)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	waitFor(func() bool { return c.MetricCount() >= 2 }, "samples")
+	c.Pause()
+	if !c.IsPaused() || !c.IsRunning() {
+		t.Fatal("Expected a running, paused collector")
+	}
+	time.Sleep(10 * time.Millisecond) // let an in-flight sample land
+	count := c.MetricCount()
+	numGC.Add(3) // cycles of the excluded phase
+	time.Sleep(30 * time.Millisecond)
+	if got := c.MetricCount(); got != count {
+		t.Errorf("Collected %d samples while paused", got-count)
+	}
+
+	c.Resume()
+	waitFor(func() bool { return c.MetricCount() >= count+2 }, "samples after resuming")
+	if events := c.EventCount(); events != 0 {
+		t.Errorf("Recorded %d events for cycles completed while paused", events)
+	}
+
+	numGC.Add(1)
+	waitFor(func() bool { return c.EventCount() == 1 }, "an event after resuming")
+}
+
+func TestCollector_PooledMetrics(t *testing.T) {
+	c := New(&Config{MaxSamples: 10, PooledMetrics: true})
+	for i := 0; i < 20; i++ {
+		c.addMetrics(types.NewGCMetricsPooled())
+	}
+
+	metrics := c.GetMetrics()
+	latest := c.GetLatestMetrics()
+	// Evicting the remaining samples recycles their slices
+	for i := 0; i < 10; i++ {
+		c.addMetrics(types.NewGCMetricsPooled())
+	}
+	for _, m := range append(metrics, latest) {
+		if len(m.PauseNs) != 256 || len(m.PauseEnd) != 256 {
+			t.Fatal("Copies returned by the collector must keep their pause data")
+		}
+	}
+
+	pooled := testing.AllocsPerRun(100, func() { c.addMetrics(types.NewGCMetricsPooled()) })
+	unpooled := testing.AllocsPerRun(100, func() { c.addMetrics(types.NewGCMetrics()) })
+	t.Logf("allocations per sample: %v pooled, %v unpooled", pooled, unpooled)
+	if pooled >= unpooled {
+		t.Errorf("Pooled samples allocate %v times, unpooled %v", pooled, unpooled)
+	}
+}
+
+func TestCollector_PooledMetricsConcurrentReads(t *testing.T) {
+	c := New(&Config{Interval: time.Millisecond, MaxSamples: 4, PooledMetrics: true})
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer c.Stop()
+
+	deadline := time.Now().Add(100 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		for _, m := range c.GetMetrics() {
+			if len(m.PauseNs) != 256 {
+				t.Fatal("Read a recycled sample")
+			}
+		}
+		c.ViewMetrics(func(metrics []*types.GCMetrics) {
+			for _, m := range metrics {
+				_ = m.PauseNs[0]
+			}
+		})
+	}
+}
+
+// recordingLogger keeps the messages logged at each level
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []string // "LEVEL msg"
+}
+
+func (l *recordingLogger) log(level, msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, level+" "+msg)
+}
+
+func (l *recordingLogger) Debug(msg string, _ ...any) { l.log("DEBUG", msg) }
+func (l *recordingLogger) Info(msg string, _ ...any)  { l.log("INFO", msg) }
+func (l *recordingLogger) Warn(msg string, _ ...any)  { l.log("WARN", msg) }
+func (l *recordingLogger) Error(msg string, _ ...any) { l.log("ERROR", msg) }
+
+func (l *recordingLogger) has(entry string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Contains(l.entries, entry)
+}
+
+func TestCollector_CallbackPanic(t *testing.T) {
+	logger := &recordingLogger{}
+	c := New(&Config{
+		Logger:            logger,
+		OnMetricCollected: func(*types.GCMetrics) { panic("metric callback") },
+		OnGCEvent:         func(*types.GCEvent) { panic("event callback") },
+	})
+
+	c.Inject(&types.GCMetrics{NumGC: 1})
+	c.InjectEvent(&types.GCEvent{Sequence: 1})
+
+	if c.MetricCount() != 1 || c.EventCount() != 1 {
+		t.Errorf("Samples should be recorded despite panicking callbacks, got %d and %d", c.MetricCount(), c.EventCount())
+	}
+	if len(logger.entries) != 2 || !logger.has("ERROR callback panicked") {
+		t.Errorf("Expected both panics logged, got %v", logger.entries)
+	}
+}
+
+func TestCollector_LogsSamplerErrors(t *testing.T) {
+	logger := &recordingLogger{}
+	c := New(&Config{
+		Interval: 5 * time.Millisecond,
+		Logger:   logger,
+		Sampler: func(context.Context) (*types.GCMetrics, error) {
+			return nil, errors.New("connection refused")
+		},
+	})
+
+	if err := c.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	c.Stop()
+
+	if !logger.has("WARN GC sample failed") {
+		t.Errorf("Expected failed samples logged, got %v", logger.entries)
+	}
+}
+
+func TestDetectGCEvents_LogsMissedCycles(t *testing.T) {
+	logger := &recordingLogger{}
+	c := New(&Config{Logger: logger})
+	prev := &types.GCMetrics{NumGC: 1}
+	current := &types.GCMetrics{
+		NumGC:    1 + types.PauseBufferSize + 1,
+		PauseNs:  make([]uint64, types.PauseBufferSize),
+		PauseEnd: make([]uint64, types.PauseBufferSize),
+	}
+	c.detectGCEvents(prev, current)
+
+	if len(logger.entries) != 1 || !strings.HasPrefix(logger.entries[0], "WARN GC cycles missed") {
+		t.Errorf("Expected one missed cycles warning, got %v", logger.entries)
+	}
+}
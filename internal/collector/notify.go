@@ -0,0 +1,58 @@
+package collector
+
+import (
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// minNotifySpacing is the minimum time between samples triggered by GC
+// notifications, since each runtime sample briefly stops the world
+const minNotifySpacing = 10 * time.Millisecond
+
+// gcNotifier signals on C after GC cycles complete. It keeps a sentinel
+// object with a finalizer: the finalizer runs once a cycle has found the
+// sentinel unreachable, and re-arms with a fresh sentinel for the next one.
+// Signals are coalesced while the receiver is busy.
+type gcNotifier struct {
+	C       chan struct{}
+	stopped atomic.Bool
+}
+
+// gcSentinel is the object whose collection marks a completed cycle. It
+// holds a pointer so it is never placed in the tiny allocator, whose blocks
+// may keep finalizers from running.
+type gcSentinel struct {
+	n *gcNotifier
+}
+
+// newGCNotifier creates an armed notifier; call Stop when done with it
+func newGCNotifier() *gcNotifier {
+	n := &gcNotifier{C: make(chan struct{}, 1)}
+	n.arm()
+	return n
+}
+
+// arm allocates a new sentinel for the next cycle to collect
+func (n *gcNotifier) arm() {
+	runtime.SetFinalizer(&gcSentinel{n: n}, (*gcSentinel).collected)
+}
+
+// collected runs on the finalizer goroutine after the sentinel's cycle
+func (s *gcSentinel) collected() {
+	n := s.n
+	if n.stopped.Load() {
+		return
+	}
+	select {
+	case n.C <- struct{}{}:
+	default:
+	}
+	n.arm()
+}
+
+// Stop disarms the notifier: the pending sentinel is collected without a
+// signal and not replaced
+func (n *gcNotifier) Stop() {
+	n.stopped.Store(true)
+}
@@ -0,0 +1,68 @@
+package collector
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestGCNotifier(t *testing.T) {
+	n := newGCNotifier()
+	defer n.Stop()
+
+	for i := 0; i < 3; i++ {
+		runtime.GC()
+		select {
+		case <-n.C:
+		case <-time.After(time.Second):
+			t.Fatalf("No notification after GC %d", i+1)
+		}
+	}
+}
+
+func TestGCNotifier_Stop(t *testing.T) {
+	n := newGCNotifier()
+	n.Stop()
+
+	runtime.GC()
+	runtime.GC()
+	select {
+	case <-n.C:
+		t.Error("Stopped notifier should not signal")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestCollector_NotifyGC(t *testing.T) {
+	events := make(chan *types.GCEvent, 16)
+	c := New(&Config{
+		Interval:  time.Hour, // only notifications can produce events
+		NotifyGC:  true,
+		OnGCEvent: func(e *types.GCEvent) { events <- e },
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer c.Stop()
+
+	// Wait for the baseline sample before collecting
+	deadline := time.Now().Add(time.Second)
+	for c.MetricCount() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	runtime.GC()
+	select {
+	case e := <-events:
+		if e.Sequence == 0 {
+			t.Errorf("Unexpected event %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnGCEvent did not fire after a GC with notifications enabled")
+	}
+}
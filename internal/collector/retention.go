@@ -0,0 +1,118 @@
+package collector
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// numGauges is the number of fields gauges returns
+const numGauges = 19
+
+// gauges returns the point-in-time fields of a sample, which compaction
+// averages. The remaining fields are cumulative or configuration, and a
+// compacted sample takes them from the last sample of its window so rates
+// computed across compacted samples stay exact.
+func gauges(m *types.GCMetrics) [numGauges]*uint64 {
+	return [numGauges]*uint64{
+		&m.Alloc, &m.Sys,
+		&m.HeapAlloc, &m.HeapSys, &m.HeapIdle, &m.HeapInuse, &m.HeapReleased, &m.HeapObjects, &m.HeapLive,
+		&m.StackInuse, &m.StackSys,
+		&m.MSpanSys, &m.MCacheSys, &m.BuckHashSys, &m.GCSys, &m.OtherSys,
+		&m.NextGC, &m.ProcessRSS, &m.SwapUsed,
+	}
+}
+
+// tier compacts samples into averages over windows of its resolution
+type tier struct {
+	types.RetentionTier
+	compacted *ring[types.GCMetrics]
+
+	// The window being accumulated, only accessed with the collector's mu held
+	start       time.Time
+	count       int
+	sums        [numGauges]float64
+	cpuFraction float64
+	last        *types.GCMetrics
+}
+
+// newTiers creates the tiers of a retention policy
+func newTiers(policy *types.RetentionPolicy) []*tier {
+	tiers := make([]*tier, len(policy.Tiers))
+	for i, t := range policy.Tiers {
+		tiers[i] = &tier{
+			RetentionTier: t,
+			compacted:     newRing[types.GCMetrics](int(t.Retention/t.Resolution) + 1),
+		}
+	}
+	return tiers
+}
+
+// add accumulates a sample, compacting the previous window once the sample
+// falls outside it
+func (t *tier) add(m *types.GCMetrics) {
+	start := m.Timestamp.Truncate(t.Resolution)
+	if t.count > 0 && !start.Equal(t.start) {
+		t.flush()
+	}
+
+	t.start = start
+	for i, g := range gauges(m) {
+		t.sums[i] += float64(*g)
+	}
+	t.cpuFraction += m.GCCPUFraction
+	t.count++
+	t.last = m
+}
+
+// flush appends the average of the accumulated window and resets it
+func (t *tier) flush() {
+	n := float64(t.count)
+	avg := t.last.Clone()
+	avg.PauseNs, avg.PauseEnd = nil, nil
+	for i, g := range gauges(avg) {
+		*g = uint64(math.Round(t.sums[i] / n))
+	}
+	avg.GCCPUFraction = t.cpuFraction / n
+	avg.CompactedSamples = t.count
+	t.compacted.add(avg)
+
+	t.count = 0
+	t.sums = [numGauges]float64{}
+	t.cpuFraction = 0
+	t.last = nil
+}
+
+// retained returns the samples the retention policy keeps given the
+// full-resolution samples held: those within FullResolution of the newest,
+// preceded by each tier's compacted samples from before the finer ones
+func (c *Collector) retained(metrics []*types.GCMetrics, tiers []*tier) []*types.GCMetrics {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	newest := metrics[len(metrics)-1].Timestamp
+	metrics = metrics[searchTime(metrics, newest.Add(-c.retention.FullResolution)):]
+	boundary := metrics[0].Timestamp
+	for _, t := range tiers {
+		compacted := t.compacted.all()
+		from := searchTime(compacted, newest.Add(-t.Retention))
+		to := searchTime(compacted, boundary)
+		if from >= to {
+			continue
+		}
+		metrics = append(compacted[from:to:to], metrics...)
+		boundary = compacted[from].Timestamp
+	}
+	return metrics
+}
+
+// searchTime returns the index of the first sample taken at or after t
+func searchTime(metrics []*types.GCMetrics, t time.Time) int {
+	i, _ := slices.BinarySearchFunc(metrics, t, func(m *types.GCMetrics, t time.Time) int {
+		return m.Timestamp.Compare(t)
+	})
+	return i
+}
@@ -0,0 +1,97 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestCollector_Retention(t *testing.T) {
+	c := New(&Config{
+		MaxSamples: 100,
+		Retention: &types.RetentionPolicy{
+			FullResolution: 10 * time.Second,
+			Tiers: []types.RetentionTier{
+				{Resolution: 10 * time.Second, Retention: time.Minute},
+				{Resolution: time.Minute, Retention: 10 * time.Minute},
+			},
+		},
+	})
+
+	// One sample per second for 10 minutes, with heap alternating 100/200
+	// and one GC every second
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 600; i++ {
+		c.Inject(&types.GCMetrics{
+			Timestamp: start.Add(time.Duration(i) * time.Second),
+			NumGC:     uint32(i),
+			HeapAlloc: uint64(100 + 100*(i%2)),
+			PauseNs:   make([]uint64, 256),
+		})
+	}
+
+	metrics := c.GetMetrics()
+	if c.MetricCount() != len(metrics) {
+		t.Errorf("MetricCount() = %d, want %d", c.MetricCount(), len(metrics))
+	}
+	for i := 1; i < len(metrics); i++ {
+		if !metrics[i].Timestamp.After(metrics[i-1].Timestamp) {
+			t.Fatalf("Samples out of order at %d: %v after %v", i, metrics[i].Timestamp, metrics[i-1].Timestamp)
+		}
+	}
+
+	var full, tens, minutes int
+	for _, m := range metrics {
+		switch m.CompactedSamples {
+		case 0:
+			full++
+		case 10:
+			tens++
+		case 60:
+			minutes++
+		default:
+			t.Errorf("Unexpected compacted sample of %d samples", m.CompactedSamples)
+		}
+		if m.CompactedSamples > 0 {
+			if m.HeapAlloc != 150 {
+				t.Errorf("Compacted HeapAlloc = %d, want the average 150", m.HeapAlloc)
+			}
+			if m.PauseNs != nil {
+				t.Error("Expected compacted samples to drop pause data")
+			}
+			// Counters come from the window's last sample
+			if want := uint32(m.Timestamp.Sub(start) / time.Second); m.NumGC != want {
+				t.Errorf("Compacted NumGC = %d, want %d", m.NumGC, want)
+			}
+		}
+	}
+	if full != 11 || tens == 0 || minutes == 0 {
+		t.Errorf("Got %d full, %d 10s and %d 1m samples", full, tens, minutes)
+	}
+	if len(metrics) >= 100 {
+		t.Errorf("Expected compaction to keep fewer than MaxSamples samples, got %d", len(metrics))
+	}
+	if span := metrics[len(metrics)-1].Timestamp.Sub(metrics[0].Timestamp); span < 8*time.Minute {
+		t.Errorf("Expected history spanning most of 10 minutes, got %v", span)
+	}
+
+	c.Clear()
+	if got := c.GetMetrics(); got != nil {
+		t.Errorf("Expected no samples after Clear, got %d", len(got))
+	}
+}
+
+func TestCollector_RetentionInvalidIgnored(t *testing.T) {
+	c := New(&Config{
+		MaxSamples: 5,
+		Retention:  &types.RetentionPolicy{FullResolution: time.Second, Tiers: []types.RetentionTier{{}}},
+	})
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		c.Inject(&types.GCMetrics{Timestamp: start.Add(time.Duration(i) * time.Second)})
+	}
+	if got := len(c.GetMetrics()); got != 5 {
+		t.Errorf("Expected the MaxSamples latest samples, got %d", got)
+	}
+}
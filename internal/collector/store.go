@@ -0,0 +1,93 @@
+package collector
+
+import (
+	"sync/atomic"
+)
+
+// ring is a fixed-size ring buffer of the most recent entries. Appends are
+// O(1) and never allocate or re-slice; the oldest entry is overwritten once
+// the ring is full.
+//
+// Appends must be serialized by the caller, but reads never lock: each slot
+// carries the sequence number of the entry it holds, stamped after the entry
+// is stored and cleared before it is replaced, so a reader that sees the
+// same expected stamp before and after loading an entry knows the entry was
+// not overwritten in between. Readers that lose that race retry from the
+// latest position.
+type ring[T any] struct {
+	entries []atomic.Pointer[T]
+	stamps  []atomic.Uint64 // sequence number + 1 of each slot's entry; 0 while being replaced
+	next    atomic.Uint64   // sequence number of the next append
+}
+
+// newRing creates a ring holding up to size entries, at least one
+func newRing[T any](size int) *ring[T] {
+	size = max(size, 1)
+	return &ring[T]{
+		entries: make([]atomic.Pointer[T], size),
+		stamps:  make([]atomic.Uint64, size),
+	}
+}
+
+// add appends v, overwriting the oldest entry once the ring is full.
+// Returns the overwritten entry, or nil.
+func (r *ring[T]) add(v *T) (evicted *T) {
+	seq := r.next.Load()
+	i := seq % uint64(len(r.entries))
+	r.stamps[i].Store(0)
+	evicted = r.entries[i].Swap(v)
+	r.stamps[i].Store(seq + 1)
+	r.next.Store(seq + 1)
+	return evicted
+}
+
+// len returns the number of entries held
+func (r *ring[T]) len() int {
+	return int(min(r.next.Load(), uint64(len(r.entries))))
+}
+
+// load returns the entry with sequence number seq, if it is still held
+func (r *ring[T]) load(seq uint64) (*T, bool) {
+	i := seq % uint64(len(r.entries))
+	if r.stamps[i].Load() != seq+1 {
+		return nil, false
+	}
+	v := r.entries[i].Load()
+	return v, r.stamps[i].Load() == seq+1
+}
+
+// all returns the entries held, oldest first, or nil if there are none
+func (r *ring[T]) all() []*T {
+	for {
+		end := r.next.Load()
+		if end == 0 {
+			return nil
+		}
+		start := end - min(end, uint64(len(r.entries)))
+
+		out := make([]*T, 0, end-start)
+		for seq := start; seq < end; seq++ {
+			v, ok := r.load(seq)
+			if !ok {
+				break
+			}
+			out = append(out, v)
+		}
+		if len(out) == int(end-start) {
+			return out
+		}
+	}
+}
+
+// latest returns the most recent entry, or nil if there is none
+func (r *ring[T]) latest() *T {
+	for {
+		end := r.next.Load()
+		if end == 0 {
+			return nil
+		}
+		if v, ok := r.load(end - 1); ok {
+			return v
+		}
+	}
+}
@@ -0,0 +1,425 @@
+// Package config loads monitoring configuration from YAML or JSON files, so
+// thresholds, alert rules and notifier settings can be tuned without
+// recompiling the service that embeds the monitor.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/alerting"
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/webhook"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// MaxFileSize bounds the configuration files Load reads
+const MaxFileSize = 1 << 20
+
+// File is the schema of a configuration file. Keys are snake_case; durations
+// are strings such as "500ms" and sizes strings such as "512MB" or byte
+// counts. Omitted settings keep the monitor's defaults.
+type File struct {
+	Interval         Duration `json:"interval"`
+	IntervalJitter   float64  `json:"interval_jitter"`
+	MaxSamples       int      `json:"max_samples"`
+	MaxAlerts        int      `json:"max_alerts"`
+	MemoryLimit      ByteSize `json:"memory_limit"`
+	SnapshotInterval Duration `json:"snapshot_interval"`
+	ProcessCPU       bool     `json:"process_cpu"`
+	ProcessRSS       bool     `json:"process_rss"`
+	Paging           bool     `json:"paging"`
+	PauseQuantiles   bool     `json:"pause_quantiles"`
+	NotifyGC         bool     `json:"notify_gc"`
+	SeasonalBaseline bool     `json:"seasonal_baseline"`
+	SeasonalLocation string   `json:"seasonal_location"` // IANA time zone, e.g. "Asia/Seoul"
+
+	Labels map[string]string `json:"labels"` // e.g. service, version and region
+
+	Alerts   Alerts    `json:"alerts"`
+	PauseSLO *PauseSLO `json:"pause_slo"`
+	Health   Health    `json:"health"`
+	Exporter *Exporter `json:"exporter"`
+}
+
+// Alerts configures alert rules and notifiers
+type Alerts struct {
+	DisableDefaults bool                `json:"disable_defaults"`
+	Rules           []string            `json:"rules"` // rule expressions, see alerting.ParseRule
+	Cooldown        Duration            `json:"cooldown"`
+	Cooldowns       map[string]Duration `json:"cooldowns"` // per alert type
+	Webhook         *Webhook            `json:"webhook"`
+	PagerDuty       *PagerDuty          `json:"pagerduty"`
+	Email           *Email              `json:"email"`
+}
+
+// Webhook configures the alert webhook
+type Webhook struct {
+	URL        string            `json:"url"`
+	Headers    map[string]string `json:"headers"`
+	Template   string            `json:"template"`
+	Timeout    Duration          `json:"timeout"`
+	MaxRetries int               `json:"max_retries"`
+	Backoff    Duration          `json:"backoff"`
+	QueueSize  int               `json:"queue_size"`
+}
+
+// PagerDuty configures PagerDuty incidents
+type PagerDuty struct {
+	RoutingKey    string `json:"routing_key"`
+	RoutingKeyEnv string `json:"routing_key_env"` // environment variable holding the routing key
+	Source        string `json:"source"`
+	MinSeverity   string `json:"min_severity"`
+}
+
+// Email configures alert emails
+type Email struct {
+	Addr        string   `json:"addr"`
+	Username    string   `json:"username"`
+	PasswordEnv string   `json:"password_env"` // environment variable holding the password
+	From        string   `json:"from"`
+	To          []string `json:"to"`
+	Subject     string   `json:"subject"`
+	Body        string   `json:"body"`
+	MinSeverity string   `json:"min_severity"`
+	Timeout     Duration `json:"timeout"`
+	MaxRetries  int      `json:"max_retries"`
+}
+
+// PauseSLO configures the pause SLO, see alerting.PauseSLO
+type PauseSLO struct {
+	MaxPause      Duration `json:"max_pause"`
+	Objective     float64  `json:"objective"`
+	Window        Duration `json:"window"`
+	FastBurn      float64  `json:"fast_burn"`
+	FastBurnRange Duration `json:"fast_burn_range"`
+	SlowBurn      float64  `json:"slow_burn"`
+	SlowBurnRange Duration `json:"slow_burn_range"`
+}
+
+// Health configures health check scoring: a profile, then overrides
+type Health struct {
+	Profile      string           `json:"profile"`
+	Thresholds   HealthThresholds `json:"thresholds"`
+	Penalties    HealthPenalties  `json:"penalties"`
+	HealthyScore *int             `json:"healthy_score"`
+	WarningScore *int             `json:"warning_score"`
+}
+
+// HealthThresholds overrides the profile's health check thresholds
+type HealthThresholds struct {
+	GCFrequency      *float64  `json:"gc_frequency"`
+	AvgPause         *Duration `json:"avg_pause"`
+	P99Pause         *Duration `json:"p99_pause"`
+	GCOverhead       *float64  `json:"gc_overhead"`
+	MemoryEfficiency *float64  `json:"memory_efficiency"`
+	AllocationRate   *ByteSize `json:"allocation_rate"` // per second
+	OOMForecast      *Duration `json:"oom_forecast"`
+	MemoryLimitUsage *float64  `json:"memory_limit_usage"`
+}
+
+// HealthPenalties overrides the profile's health check penalties
+type HealthPenalties struct {
+	GCFrequency      *int `json:"gc_frequency"`
+	AvgPause         *int `json:"avg_pause"`
+	P99Pause         *int `json:"p99_pause"`
+	GCOverhead       *int `json:"gc_overhead"`
+	MemoryEfficiency *int `json:"memory_efficiency"`
+	AllocationRate   *int `json:"allocation_rate"`
+	OOMForecast      *int `json:"oom_forecast"`
+	MemoryLimitUsage *int `json:"memory_limit_usage"`
+}
+
+// Exporter configures the HTTP endpoints serving metrics and health
+type Exporter struct {
+	Listen      string `json:"listen"`       // default: ":9090"
+	MetricsPath string `json:"metrics_path"` // default: "/metrics"
+	HealthPath  string `json:"health_path"`  // default: "/health"
+}
+
+// Load reads a configuration file: JSON when its extension is .json or its
+// content starts with "{", YAML otherwise. Unknown keys are rejected so typos
+// do not silently fall back to defaults. Returns ErrInvalidConfig if the file
+// is malformed.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxFileSize {
+		return nil, fmt.Errorf("%w: %s is larger than %d bytes", types.ErrInputTooLarge, path, MaxFileSize)
+	}
+
+	f, err := Parse(data, strings.EqualFold(filepath.Ext(path), ".json"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Parse parses a configuration file's content, as JSON if isJSON is set or
+// it starts with "{". Returns ErrInvalidConfig if it is malformed.
+func Parse(data []byte, isJSON bool) (*File, error) {
+	if !isJSON && !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		doc, err := parseYAML(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", types.ErrInvalidConfig, err)
+		}
+		if doc == nil {
+			doc = map[string]any{}
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, fmt.Errorf("%w: %v", types.ErrInvalidConfig, err)
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var f File
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("%w: %v", types.ErrInvalidConfig, err)
+	}
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// validate checks the settings that do not depend on building the monitor
+func (f *File) validate() error {
+	if f.Interval < 0 || f.SnapshotInterval < 0 || f.Alerts.Cooldown < 0 {
+		return fmt.Errorf("%w: negative interval or cooldown", types.ErrInvalidConfig)
+	}
+	if f.SeasonalLocation != "" {
+		if _, err := time.LoadLocation(f.SeasonalLocation); err != nil {
+			return fmt.Errorf("%w: seasonal_location: %v", types.ErrInvalidConfig, err)
+		}
+	}
+	return nil
+}
+
+// Location returns the seasonal baseline's time zone, nil for the default
+func (f *File) Location() *time.Location {
+	if f.SeasonalLocation == "" {
+		return nil
+	}
+	// Validated by Parse
+	loc, _ := time.LoadLocation(f.SeasonalLocation)
+	return loc
+}
+
+// Cooldowns returns the per-type alert cooldowns
+func (f *File) Cooldowns() map[string]time.Duration {
+	if f.Alerts.Cooldowns == nil {
+		return nil
+	}
+	cooldowns := make(map[string]time.Duration, len(f.Alerts.Cooldowns))
+	for alertType, d := range f.Alerts.Cooldowns {
+		cooldowns[alertType] = time.Duration(d)
+	}
+	return cooldowns
+}
+
+// Rules parses the alert rule expressions. Returns ErrInvalidAlertRule if one
+// is malformed.
+func (f *File) Rules() ([]alerting.Rule, error) {
+	rules := make([]alerting.Rule, 0, len(f.Alerts.Rules))
+	for _, expr := range f.Alerts.Rules {
+		rule, err := alerting.ParseRule(expr)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Webhook returns the alert webhook configuration, nil without one
+func (f *File) Webhook() *webhook.Config {
+	w := f.Alerts.Webhook
+	if w == nil {
+		return nil
+	}
+	return &webhook.Config{
+		URL:        w.URL,
+		Headers:    w.Headers,
+		Template:   w.Template,
+		Timeout:    time.Duration(w.Timeout),
+		MaxRetries: w.MaxRetries,
+		Backoff:    time.Duration(w.Backoff),
+		QueueSize:  w.QueueSize,
+	}
+}
+
+// PagerDuty returns the PagerDuty configuration, nil without one
+func (f *File) PagerDuty() *webhook.PagerDutyConfig {
+	p := f.Alerts.PagerDuty
+	if p == nil {
+		return nil
+	}
+	key := p.RoutingKey
+	if p.RoutingKeyEnv != "" {
+		key = os.Getenv(p.RoutingKeyEnv)
+	}
+	return &webhook.PagerDutyConfig{RoutingKey: key, Source: p.Source, MinSeverity: p.MinSeverity}
+}
+
+// Email returns the alert email configuration, nil without one
+func (f *File) Email() *webhook.EmailConfig {
+	e := f.Alerts.Email
+	if e == nil {
+		return nil
+	}
+	var password string
+	if e.PasswordEnv != "" {
+		password = os.Getenv(e.PasswordEnv)
+	}
+	return &webhook.EmailConfig{
+		Addr:        e.Addr,
+		Username:    e.Username,
+		Password:    password,
+		From:        e.From,
+		To:          e.To,
+		Subject:     e.Subject,
+		Body:        e.Body,
+		MinSeverity: e.MinSeverity,
+		Timeout:     time.Duration(e.Timeout),
+		MaxRetries:  e.MaxRetries,
+	}
+}
+
+// SLO returns the pause SLO, nil without one
+func (f *File) SLO() *alerting.PauseSLO {
+	s := f.PauseSLO
+	if s == nil {
+		return nil
+	}
+	return &alerting.PauseSLO{
+		MaxPause:      time.Duration(s.MaxPause),
+		Objective:     s.Objective,
+		Window:        time.Duration(s.Window),
+		FastBurn:      s.FastBurn,
+		FastBurnRange: time.Duration(s.FastBurnRange),
+		SlowBurn:      s.SlowBurn,
+		SlowBurnRange: time.Duration(s.SlowBurnRange),
+	}
+}
+
+// HealthCheck returns the health check config: the profile with the
+// file's overrides applied. Returns ErrUnknownHealthProfile for
+// unsupported profiles.
+func (f *File) HealthCheck() (*types.HealthCheckConfig, error) {
+	h := f.Health
+	config, err := types.HealthCheckProfile(types.HealthProfile(h.Profile))
+	if err != nil {
+		return nil, err
+	}
+
+	t := h.Thresholds
+	setFloat(&config.GCFrequencyHigh, t.GCFrequency)
+	setDuration(&config.AvgPauseLong, t.AvgPause)
+	setDuration(&config.P99PauseVeryLong, t.P99Pause)
+	setFloat(&config.GCOverheadHigh, t.GCOverhead)
+	setFloat(&config.MemoryEfficiencyLow, t.MemoryEfficiency)
+	if t.AllocationRate != nil {
+		config.AllocationRateHigh = float64(*t.AllocationRate)
+	}
+	setDuration(&config.OOMForecastWarning, t.OOMForecast)
+	setFloat(&config.MemoryLimitUsageHigh, t.MemoryLimitUsage)
+
+	p := h.Penalties
+	setInt(&config.PenaltyGCFrequency, p.GCFrequency)
+	setInt(&config.PenaltyAvgPause, p.AvgPause)
+	setInt(&config.PenaltyP99Pause, p.P99Pause)
+	setInt(&config.PenaltyGCOverhead, p.GCOverhead)
+	setInt(&config.PenaltyMemoryEfficiency, p.MemoryEfficiency)
+	setInt(&config.PenaltyAllocationRate, p.AllocationRate)
+	setInt(&config.PenaltyOOMForecast, p.OOMForecast)
+	setInt(&config.PenaltyMemoryLimitUsage, p.MemoryLimitUsage)
+
+	setInt(&config.HealthyScore, h.HealthyScore)
+	setInt(&config.WarningScore, h.WarningScore)
+	return &config, nil
+}
+
+func setFloat(dst *float64, v *float64) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+func setInt(dst *int, v *int) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+func setDuration(dst *time.Duration, v *Duration) {
+	if v != nil {
+		*dst = time.Duration(*v)
+	}
+}
+
+// Duration is a time.Duration written as a string such as "1m30s"
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"1s\", got %s", data)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// ByteSize is a size in bytes, written as a count or a string with a unit
+// such as "512MB" or "1GiB". Decimal and binary units are both powers of 1024.
+type ByteSize uint64
+
+// byteUnits are the size suffixes, longest first so "MiB" is not read as "B"
+var byteUnits = []struct {
+	suffix string
+	size   uint64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+	{"KB", 1 << 10}, {"MB", 1 << 20}, {"GB", 1 << 30}, {"TB", 1 << 40},
+	{"B", 1},
+}
+
+// UnmarshalJSON parses a byte count or size string
+func (b *ByteSize) UnmarshalJSON(data []byte) error {
+	var n uint64
+	if err := json.Unmarshal(data, &n); err == nil {
+		*b = ByteSize(n)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("size must be a byte count or a string such as \"512MB\", got %s", data)
+	}
+
+	s = strings.TrimSpace(s)
+	unit := uint64(1)
+	for _, u := range byteUnits {
+		if strings.HasSuffix(s, u.suffix) {
+			s, unit = strings.TrimSpace(strings.TrimSuffix(s, u.suffix)), u.size
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 {
+		return fmt.Errorf("invalid size %s", data)
+	}
+	*b = ByteSize(v * float64(unit))
+	return nil
+}
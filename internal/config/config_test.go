@@ -0,0 +1,124 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestParse(t *testing.T) {
+	t.Setenv("TEST_SMTP_PASSWORD", "secret")
+	doc := `
+interval: 2s
+memory_limit: 512MiB
+seasonal_location: UTC
+alerts:
+  rules:
+    - p99_pause > 200ms for 3 windows
+  cooldowns:
+    memory: 10m
+  email:
+    addr: smtp.example.com:587
+    password_env: TEST_SMTP_PASSWORD
+    from: gc@example.com
+    to: [oncall@example.com]
+pause_slo:
+  max_pause: 50ms
+  objective: 0.99
+health:
+  profile: latency-critical
+  thresholds:
+    avg_pause: 5ms
+    allocation_rate: 200MB
+  penalties:
+    gc_overhead: 40
+`
+	f, err := Parse([]byte(doc), false)
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if time.Duration(f.Interval) != 2*time.Second || f.MemoryLimit != 512<<20 || f.Location() != time.UTC {
+		t.Errorf("Unexpected settings %+v", f)
+	}
+	if got := f.Cooldowns()["memory"]; got != 10*time.Minute {
+		t.Errorf("Memory cooldown = %v, want 10m", got)
+	}
+
+	rules, err := f.Rules()
+	if err != nil || len(rules) != 1 || rules[0].Metric != "p99_pause" {
+		t.Errorf("Rules() = %+v, %v", rules, err)
+	}
+	if email := f.Email(); email == nil || email.Password != "secret" || email.To[0] != "oncall@example.com" {
+		t.Errorf("Email() = %+v", email)
+	}
+	if slo := f.SLO(); slo == nil || slo.MaxPause != 50*time.Millisecond || slo.Objective != 0.99 {
+		t.Errorf("SLO() = %+v", slo)
+	}
+	if f.Webhook() != nil || f.PagerDuty() != nil {
+		t.Error("Expected no webhook or PagerDuty without their sections")
+	}
+
+	health, err := f.HealthCheck()
+	if err != nil {
+		t.Fatalf("HealthCheck() error: %v", err)
+	}
+	profile, _ := types.HealthCheckProfile(types.HealthProfileLatencyCritical)
+	if health.AvgPauseLong != 5*time.Millisecond || health.AllocationRateHigh != 200<<20 ||
+		health.PenaltyGCOverhead != 40 || health.P99PauseVeryLong != profile.P99PauseVeryLong {
+		t.Errorf("HealthCheck() = %+v, want the profile with overrides", health)
+	}
+}
+
+func TestParse_JSON(t *testing.T) {
+	f, err := Parse([]byte(`{"max_samples": 50, "memory_limit": 1073741824, "exporter": {"listen": ":8080"}}`), true)
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if f.MaxSamples != 50 || f.MemoryLimit != 1<<30 || f.Exporter == nil || f.Exporter.Listen != ":8080" {
+		t.Errorf("Unexpected settings %+v", f)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, doc := range []string{
+		"intervall: 1s\n",           // unknown key
+		"interval: 1\n",             // durations are strings
+		"interval: -1s\n",           // negative
+		"memory_limit: lots\n",      // not a size
+		"seasonal_location: Mars\n", // unknown zone
+		"max_samples: [1]\n",
+		"a: 1\n  b: 2\n",
+	} {
+		if _, err := Parse([]byte(doc), false); !errors.Is(err, types.ErrInvalidConfig) {
+			t.Errorf("Parse(%q) = %v, want ErrInvalidConfig", doc, err)
+		}
+	}
+
+	f, err := Parse([]byte("health:\n  profile: realtime\n"), false)
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if _, err := f.HealthCheck(); !errors.Is(err, types.ErrUnknownHealthProfile) {
+		t.Errorf("Expected ErrUnknownHealthProfile, got %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gc.json")
+	if err := os.WriteFile(path, []byte(`{"interval": "5s"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Load(path)
+	if err != nil || time.Duration(f.Interval) != 5*time.Second {
+		t.Errorf("Load() = %+v, %v", f, err)
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.yaml")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected os.ErrNotExist, got %v", err)
+	}
+}
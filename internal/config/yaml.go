@@ -0,0 +1,355 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// yamlNumber matches the scalars decoded as numbers
+var yamlNumber = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+
+// yamlLine is a line of a YAML document
+type yamlLine struct {
+	num    int    // 1-based line number
+	indent int    // leading spaces
+	text   string // content without indentation or comment
+	raw    string // the line as written, for block scalars
+}
+
+// yamlParser parses the block-style subset of YAML that configuration files
+// need: nested mappings and sequences, plain, quoted and literal block (|)
+// scalars, single-line flow sequences and mappings of scalars, and comments.
+// Anchors, tags, multi-document streams and multi-line flow collections are
+// not supported. Values decode as encoding/json decodes them into an any,
+// with numbers as json.Number.
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// parseYAML parses a YAML document
+func parseYAML(data []byte) (any, error) {
+	p := &yamlParser{}
+	for i, raw := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimLeft(raw, " ")
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed in indentation", i+1)
+		}
+		p.lines = append(p.lines, yamlLine{
+			num:    i + 1,
+			indent: len(raw) - len(trimmed),
+			text:   strings.TrimSpace(stripComment(trimmed)),
+			raw:    raw,
+		})
+	}
+
+	p.skipBlank()
+	if p.pos == len(p.lines) {
+		return nil, nil
+	}
+	if p.lines[p.pos].text == "---" {
+		p.pos++
+		p.skipBlank()
+	}
+	if p.pos == len(p.lines) {
+		return nil, nil
+	}
+	v, err := p.parseBlock(p.lines[p.pos].indent)
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlank()
+	if p.pos < len(p.lines) {
+		return nil, fmt.Errorf("line %d: unexpected content after the document", p.lines[p.pos].num)
+	}
+	return v, nil
+}
+
+// skipBlank advances past empty and comment-only lines
+func (p *yamlParser) skipBlank() {
+	for p.pos < len(p.lines) && p.lines[p.pos].text == "" {
+		p.pos++
+	}
+}
+
+// next returns the next non-blank line, if any
+func (p *yamlParser) next() (yamlLine, bool) {
+	p.skipBlank()
+	if p.pos == len(p.lines) {
+		return yamlLine{}, false
+	}
+	return p.lines[p.pos], true
+}
+
+// isSeqItem reports whether a line is a sequence item
+func isSeqItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// parseBlock parses the mapping or sequence starting at the current line
+func (p *yamlParser) parseBlock(indent int) (any, error) {
+	line, _ := p.next()
+	if isSeqItem(line.text) {
+		return p.parseSeq(indent)
+	}
+	return p.parseMap(indent)
+}
+
+// parseMap parses the mapping whose keys are at indent
+func (p *yamlParser) parseMap(indent int) (map[string]any, error) {
+	m := make(map[string]any)
+	for {
+		line, ok := p.next()
+		if !ok || line.indent < indent {
+			return m, nil
+		}
+		if line.indent > indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", line.num)
+		}
+		if isSeqItem(line.text) {
+			return nil, fmt.Errorf("line %d: sequence item in a mapping", line.num)
+		}
+
+		key, rest, ok := splitKey(line.text)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", line.num)
+		}
+		if _, dup := m[key]; dup {
+			return nil, fmt.Errorf("line %d: duplicate key %q", line.num, key)
+		}
+		p.pos++
+
+		v, err := p.parseValue(line, rest)
+		if err != nil {
+			return nil, err
+		}
+		m[key] = v
+	}
+}
+
+// parseSeq parses the sequence whose items are at indent
+func (p *yamlParser) parseSeq(indent int) ([]any, error) {
+	s := []any{}
+	for {
+		line, ok := p.next()
+		if !ok || line.indent < indent || (line.indent == indent && !isSeqItem(line.text)) {
+			return s, nil
+		}
+		if line.indent > indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", line.num)
+		}
+
+		content := strings.TrimSpace(strings.TrimPrefix(line.text, "-"))
+		if _, _, isKey := splitKey(content); isKey {
+			// "- key: value" starts a mapping indented to its first key
+			offset := strings.Index(line.raw[line.indent+1:], content) + 1
+			p.lines[p.pos] = yamlLine{num: line.num, indent: line.indent + offset, text: content, raw: line.raw}
+			m, err := p.parseMap(line.indent + offset)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, m)
+			continue
+		}
+
+		p.pos++
+		v, err := p.parseValue(line, content)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, v)
+	}
+}
+
+// parseValue parses the value following a key or sequence dash on line:
+// rest, or the block nested below line when rest is empty
+func (p *yamlParser) parseValue(line yamlLine, rest string) (any, error) {
+	switch rest {
+	case "":
+		next, ok := p.next()
+		switch {
+		case ok && next.indent > line.indent:
+			return p.parseBlock(next.indent)
+		case ok && next.indent == line.indent && isSeqItem(next.text) && !isSeqItem(line.text):
+			// Sequences may sit at the same indentation as their key
+			return p.parseSeq(next.indent)
+		}
+		return nil, nil
+	case "|", "|-":
+		return p.parseLiteral(line.indent, rest == "|-"), nil
+	}
+	v, err := parseScalar(rest)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", line.num, err)
+	}
+	return v, nil
+}
+
+// parseLiteral parses a literal block scalar indented deeper than indent,
+// keeping its line breaks. The final line break is kept unless strip is set.
+func (p *yamlParser) parseLiteral(indent int, strip bool) string {
+	var lines []string
+	blockIndent := -1
+	for ; p.pos < len(p.lines); p.pos++ {
+		line := p.lines[p.pos]
+		if strings.TrimSpace(line.raw) == "" {
+			lines = append(lines, "")
+			continue
+		}
+		if line.indent <= indent {
+			break
+		}
+		if blockIndent < 0 {
+			blockIndent = line.indent
+		}
+		lines = append(lines, line.raw[min(blockIndent, line.indent):])
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	s := strings.Join(lines, "\n")
+	if !strip && len(lines) > 0 {
+		s += "\n"
+	}
+	return s
+}
+
+// splitKey splits "key: value" at the first colon followed by a space or
+// the end of the line, outside quotes
+func splitKey(text string) (key, rest string, ok bool) {
+	if text == "" || text[0] == '[' || text[0] == '{' {
+		return "", "", false
+	}
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 {
+				quote = c
+			}
+		case c == ':' && (i+1 == len(text) || text[i+1] == ' '):
+			k, err := parseScalar(strings.TrimSpace(text[:i]))
+			if err != nil {
+				return "", "", false
+			}
+			return fmt.Sprint(k), strings.TrimSpace(text[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+// stripComment removes a trailing comment: a # at the start of the line or
+// after a space, outside quotes
+func stripComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || text[i-1] == ' ' || text[i-1] == '[' || text[i-1] == '{' || text[i-1] == ',' {
+				quote = c
+			}
+		case c == '#' && (i == 0 || text[i-1] == ' '):
+			return text[:i]
+		}
+	}
+	return text
+}
+
+// parseScalar parses a plain, quoted or single-line flow scalar
+func parseScalar(s string) (any, error) {
+	switch {
+	case s == "" || s == "~" || s == "null":
+		return nil, nil
+	case s == "true":
+		return true, nil
+	case s == "false":
+		return false, nil
+	case yamlNumber.MatchString(s):
+		return json.Number(s), nil
+	case s[0] == '"':
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid double-quoted string %s", s)
+		}
+		return v, nil
+	case s[0] == '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' {
+			return nil, fmt.Errorf("invalid single-quoted string %s", s)
+		}
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	case s[0] == '[':
+		if s[len(s)-1] != ']' {
+			return nil, fmt.Errorf("unterminated flow sequence %s", s)
+		}
+		items := []any{}
+		for _, item := range splitFlow(s[1 : len(s)-1]) {
+			v, err := parseScalar(item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		}
+		return items, nil
+	case s[0] == '{':
+		if s[len(s)-1] != '}' {
+			return nil, fmt.Errorf("unterminated flow mapping %s", s)
+		}
+		m := make(map[string]any)
+		for _, item := range splitFlow(s[1 : len(s)-1]) {
+			key, rest, ok := splitKey(item)
+			if !ok {
+				return nil, fmt.Errorf("expected \"key: value\" in flow mapping, got %q", item)
+			}
+			v, err := parseScalar(rest)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = v
+		}
+		return m, nil
+	}
+	return s, nil
+}
+
+// splitFlow splits the items of a flow collection at commas outside quotes
+func splitFlow(s string) []string {
+	var items []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			items = append(items, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if last := strings.TrimSpace(s[start:]); last != "" || len(items) > 0 {
+		items = append(items, last)
+	}
+	return items
+}
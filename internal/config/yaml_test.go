@@ -0,0 +1,78 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	doc := `# GC monitoring
+interval: 500ms   # sample twice a second
+max_samples: 2000
+notify_gc: true
+name: "quoted # not a comment"
+single: 'it''s'
+empty:
+alerts:
+  rules:
+  - p99_pause > 200ms for 3 windows
+  - "heap_alloc > 1GB"
+  cooldowns: {memory: 10m, pause: 1m}
+  to: [a@example.com, "b@example.com"]
+  nested:
+    - url: http://hooks.example.com/a
+      timeout: 5s
+    - url: http://hooks.example.com/b
+body: |
+  Alert: {{.Alert.Message}}
+
+    indented
+subject: |-
+  no trailing newline
+last: 1.5
+`
+	got, err := parseYAML([]byte(doc))
+	if err != nil {
+		t.Fatalf("parseYAML() error: %v", err)
+	}
+	want := map[string]any{
+		"interval":    "500ms",
+		"max_samples": json.Number("2000"),
+		"notify_gc":   true,
+		"name":        "quoted # not a comment",
+		"single":      "it's",
+		"empty":       nil,
+		"alerts": map[string]any{
+			"rules":     []any{"p99_pause > 200ms for 3 windows", "heap_alloc > 1GB"},
+			"cooldowns": map[string]any{"memory": "10m", "pause": "1m"},
+			"to":        []any{"a@example.com", "b@example.com"},
+			"nested": []any{
+				map[string]any{"url": "http://hooks.example.com/a", "timeout": "5s"},
+				map[string]any{"url": "http://hooks.example.com/b"},
+			},
+		},
+		"body":    "Alert: {{.Alert.Message}}\n\n  indented\n",
+		"subject": "no trailing newline",
+		"last":    json.Number("1.5"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseYAML() =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestParseYAML_Errors(t *testing.T) {
+	for _, doc := range []string{
+		"a: 1\n  b: 2\n",
+		"a: 1\na: 2\n",
+		"- a\nb: 1\n",
+		"a:\n\t- b\n",
+		"a: [1, 2\n",
+		"a: \"unterminated\n",
+		"just text\n",
+	} {
+		if _, err := parseYAML([]byte(doc)); err == nil {
+			t.Errorf("parseYAML(%q) should fail", doc)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Package gctrace parses the GC trace lines the Go runtime prints to stderr
+// when running with GODEBUG=gctrace=1.
+package gctrace
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/ingest"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+const (
+	// maxLineLength bounds a gctrace line; real ones are under 200 bytes, and
+	// longer lines of interleaved program output are skipped
+	maxLineLength = 64 * 1024
+
+	// maxPhase bounds a single GC phase; larger values are corrupt and would
+	// overflow when phases are summed
+	maxPhase = 24 * time.Hour
+)
+
+// Parse reads gctrace output and returns one event per GC cycle.
+// Lines that are not gctrace lines (regular program output, scavenger lines)
+// are skipped. gctrace reports times relative to process start, so start is
+// used to compute absolute event times.
+func Parse(r io.Reader, start time.Time) ([]*types.GCEvent, error) {
+	return ParseContext(context.Background(), r, start)
+}
+
+// ParseContext is Parse bounded by ctx and types.MaxGCTraceSize. Malformed
+// gctrace lines fail with an error wrapping types.ErrInvalidGCTrace and the
+// line number.
+func ParseContext(ctx context.Context, r io.Reader, start time.Time) ([]*types.GCEvent, error) {
+	var events []*types.GCEvent
+
+	br := bufio.NewReaderSize(ingest.NewReader(ctx, r, types.MaxGCTraceSize), maxLineLength)
+	for lineNo := 1; ; lineNo++ {
+		raw, err := readLine(br)
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+
+		if line := string(bytes.TrimSpace(raw)); strings.HasPrefix(line, "gc ") {
+			event, perr := ParseLine(line, start)
+			if perr != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, perr)
+			}
+			events = append(events, event)
+		}
+
+		if err == io.EOF {
+			return events, nil
+		}
+	}
+}
+
+// readLine reads the next line without its terminator. Lines longer than the
+// reader's buffer are consumed and returned empty, since they can't be
+// gctrace lines. Returns io.EOF with the last line.
+func readLine(br *bufio.Reader) ([]byte, error) {
+	line, err := br.ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) {
+		for errors.Is(err, bufio.ErrBufferFull) {
+			_, err = br.ReadSlice('\n')
+		}
+		line = nil
+	}
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return bytes.TrimSuffix(line, []byte("\n")), err
+}
+
+// ParseLine parses a single gctrace line such as
+//
+//	gc 1 @0.012s 2%: 0.015+0.89+0.003 ms clock, 0.12+0.45/0.67/0+0.024 ms cpu, 4->4->0 MB, 5 MB goal, 8 P
+func ParseLine(line string, start time.Time) (*types.GCEvent, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 7 || fields[0] != "gc" || !strings.HasPrefix(fields[2], "@") {
+		return nil, types.ErrInvalidGCTrace
+	}
+
+	seq, err := strconv.ParseUint(fields[1], 10, 32)
+	if err != nil {
+		return nil, types.ErrInvalidGCTrace
+	}
+
+	offset, err := time.ParseDuration(strings.TrimPrefix(fields[2], "@"))
+	if err != nil || offset < 0 {
+		return nil, types.ErrInvalidGCTrace
+	}
+
+	// Wall-clock phases: sweep termination + concurrent mark + mark termination
+	clock := strings.Split(fields[4], "+")
+	if len(clock) != 3 || fields[5] != "ms" {
+		return nil, types.ErrInvalidGCTrace
+	}
+	var phases [3]time.Duration
+	for i, v := range clock {
+		if phases[i], err = parseMillis(v); err != nil {
+			return nil, types.ErrInvalidGCTrace
+		}
+	}
+
+	event := &types.GCEvent{
+		Sequence:  uint32(seq),
+		StartTime: start.Add(offset),
+		Phases: &types.GCPhases{
+			SweepTermination: phases[0],
+			ConcurrentMark:   phases[1],
+			MarkTermination:  phases[2],
+		},
+		TriggerReason: types.TriggerAutomatic,
+		Source:        types.EventSourceGCTrace,
+	}
+	event.EndTime = event.StartTime.Add(phases[0] + phases[1] + phases[2])
+	// Only the two stop-the-world phases pause the program
+	event.Duration = phases[0] + phases[2]
+
+	for i := 7; i < len(fields)-1; i++ {
+		switch {
+		case strings.Contains(fields[i], "->"):
+			// heap at GC start -> heap at GC end -> live heap
+			heaps := strings.Split(fields[i], "->")
+			if len(heaps) != 3 {
+				return nil, types.ErrInvalidGCTrace
+			}
+			unit := strings.TrimSuffix(fields[i+1], ",")
+			if event.HeapBefore, err = parseSize(heaps[0], unit); err != nil {
+				return nil, types.ErrInvalidGCTrace
+			}
+			if event.HeapAfter, err = parseSize(heaps[1], unit); err != nil {
+				return nil, types.ErrInvalidGCTrace
+			}
+			if event.HeapLive, err = parseSize(heaps[2], unit); err != nil {
+				return nil, types.ErrInvalidGCTrace
+			}
+		case i+2 < len(fields) && strings.HasPrefix(fields[i+2], "goal"):
+			if event.HeapGoal, err = parseSize(fields[i], fields[i+1]); err != nil {
+				return nil, types.ErrInvalidGCTrace
+			}
+		}
+	}
+
+	if event.HeapBefore > event.HeapLive {
+		event.HeapReleased = event.HeapBefore - event.HeapLive
+	}
+
+	if strings.HasSuffix(line, "(forced)") {
+		event.TriggerReason = types.TriggerForced
+	}
+
+	return event, nil
+}
+
+// parseMillis parses a decimal millisecond value of at most maxPhase
+func parseMillis(s string) (time.Duration, error) {
+	ms, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	// The negated comparison also rejects NaN
+	if !(ms >= 0 && ms <= float64(maxPhase/time.Millisecond)) {
+		return 0, types.ErrInvalidGCTrace
+	}
+	return time.Duration(ms * float64(time.Millisecond)), nil
+}
+
+// parseSize parses a size printed by the runtime with the given unit (B, KB, MB)
+func parseSize(s, unit string) (uint64, error) {
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	var multiplier uint64
+	switch unit {
+	case "B":
+		multiplier = 1
+	case "KB":
+		multiplier = uint64(types.KB)
+	case "MB":
+		multiplier = uint64(types.MB)
+	case "GB":
+		multiplier = uint64(types.GB)
+	default:
+		return 0, types.ErrInvalidGCTrace
+	}
+	if v > math.MaxUint64/multiplier {
+		return 0, types.ErrInvalidGCTrace
+	}
+	return v * multiplier, nil
+}
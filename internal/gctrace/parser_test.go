@@ -0,0 +1,166 @@
+package gctrace
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestParseLine(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	line := "gc 7 @1.500s 2%: 0.015+0.89+0.003 ms clock, 0.12+0.45/0.67/0+0.024 ms cpu, 4->5->2 MB, 6 MB goal, 0 MB stacks, 0 MB globals, 8 P"
+
+	event, err := ParseLine(line, start)
+	if err != nil {
+		t.Fatalf("ParseLine() error: %v", err)
+	}
+
+	if event.Sequence != 7 {
+		t.Errorf("Sequence = %d, want 7", event.Sequence)
+	}
+	if want := start.Add(1500 * time.Millisecond); !event.StartTime.Equal(want) {
+		t.Errorf("StartTime = %v, want %v", event.StartTime, want)
+	}
+	if want := 18 * time.Microsecond; event.Duration != want {
+		t.Errorf("Duration = %v, want %v (STW phases only)", event.Duration, want)
+	}
+	if event.Phases == nil || event.Phases.ConcurrentMark != 890*time.Microsecond {
+		t.Errorf("Phases = %+v, want concurrent mark 890µs", event.Phases)
+	}
+	if event.HeapBefore != 4*uint64(types.MB) || event.HeapAfter != 5*uint64(types.MB) || event.HeapLive != 2*uint64(types.MB) {
+		t.Errorf("Heap = %d->%d->%d, want 4->5->2 MB", event.HeapBefore, event.HeapAfter, event.HeapLive)
+	}
+	if event.HeapReleased != 2*uint64(types.MB) {
+		t.Errorf("HeapReleased = %d, want 2 MB", event.HeapReleased)
+	}
+	if event.HeapGoal != 6*uint64(types.MB) {
+		t.Errorf("HeapGoal = %d, want 6 MB", event.HeapGoal)
+	}
+	if event.TriggerReason != "automatic" || event.Source != types.EventSourceGCTrace {
+		t.Errorf("TriggerReason = %q, Source = %q", event.TriggerReason, event.Source)
+	}
+}
+
+func TestParseLine_Forced(t *testing.T) {
+	line := "gc 3 @0.100s 1%: 0.010+0.20+0.002 ms clock, 0.08+0/0.1/0+0.016 ms cpu, 1->1->0 MB, 4 MB goal, 8 P (forced)"
+
+	event, err := ParseLine(line, time.Now())
+	if err != nil {
+		t.Fatalf("ParseLine() error: %v", err)
+	}
+	if event.TriggerReason != "forced" {
+		t.Errorf("TriggerReason = %q, want forced", event.TriggerReason)
+	}
+}
+
+func TestParseLine_Invalid(t *testing.T) {
+	lines := []string{
+		"gc x @0.1s 1%: 0.01+0.2+0.002 ms clock",
+		"gc 1 0.1s 1%: 0.01+0.2+0.002 ms clock",
+		"gc 1 @0.1s 1%: 0.01+0.2 ms clock",
+		"gc 1",
+		"gc 1 @-1s 1%: 0.01+0.2+0.002 ms clock, 0+0/0/0+0 ms cpu, 1->1->0 MB, 4 MB goal, 8 P",
+		"gc 1 @0.1s 1%: NaN+0.2+0.002 ms clock, 0+0/0/0+0 ms cpu, 1->1->0 MB, 4 MB goal, 8 P",
+		"gc 1 @0.1s 1%: 0.01+-0.2+0.002 ms clock, 0+0/0/0+0 ms cpu, 1->1->0 MB, 4 MB goal, 8 P",
+		"gc 1 @0.1s 1%: 1e300+0.2+0.002 ms clock, 0+0/0/0+0 ms cpu, 1->1->0 MB, 4 MB goal, 8 P",
+		"gc 1 @0.1s 1%: 0.01+0.2+0.002 ms clock, 0+0/0/0+0 ms cpu, 18446744073709551615->1->0 MB, 4 MB goal, 8 P",
+	}
+	for _, line := range lines {
+		if _, err := ParseLine(line, time.Now()); !errors.Is(err, types.ErrInvalidGCTrace) {
+			t.Errorf("ParseLine(%q) error = %v, want ErrInvalidGCTrace", line, err)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	input := strings.Join([]string{
+		"starting server",
+		"gc 1 @0.012s 2%: 0.015+0.89+0.003 ms clock, 0.12+0.45/0.67/0+0.024 ms cpu, 4->4->0 MB, 5 MB goal, 8 P",
+		"scvg: 0 MB released",
+		"gc 2 @0.050s 2%: 0.020+1.1+0.004 ms clock, 0.16+0.5/0.8/0+0.032 ms cpu, 4->5->1 MB, 5 MB goal, 8 P",
+	}, "\n")
+
+	events, err := Parse(strings.NewReader(input), time.Now())
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[0].Sequence != 1 || events[1].Sequence != 2 {
+		t.Errorf("Sequences = %d, %d, want 1, 2", events[0].Sequence, events[1].Sequence)
+	}
+
+	_, err = Parse(strings.NewReader("ok\ngc 1 @bad"), time.Now())
+	if !errors.Is(err, types.ErrInvalidGCTrace) || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Parse() error = %v, want ErrInvalidGCTrace on line 2", err)
+	}
+}
+
+func TestParse_LongLine(t *testing.T) {
+	input := strings.Repeat("x", 3*maxLineLength) + "\n" +
+		"gc 1 @0.012s 2%: 0.015+0.89+0.003 ms clock, 0.12+0.45/0.67/0+0.024 ms cpu, 4->4->0 MB, 5 MB goal, 8 P"
+
+	events, err := Parse(strings.NewReader(input), time.Now())
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("Expected the overlong line to be skipped and 1 event parsed, got %d", len(events))
+	}
+}
+
+func TestParseContext_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ParseContext(ctx, strings.NewReader("gc 1 @0.012s 2%: 0.015+0.89+0.003 ms clock"), time.Now())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ParseContext() error = %v, want context.Canceled", err)
+	}
+}
+
+func FuzzParseLine(f *testing.F) {
+	f.Add("gc 7 @1.500s 2%: 0.015+0.89+0.003 ms clock, 0.12+0.45/0.67/0+0.024 ms cpu, 4->5->2 MB, 6 MB goal, 0 MB stacks, 0 MB globals, 8 P")
+	f.Add("gc 3 @0.100s 1%: 0.010+0.20+0.002 ms clock, 0.08+0/0.1/0+0.016 ms cpu, 1->1->0 MB, 4 MB goal, 8 P (forced)")
+	f.Add("gc 1 @0.1s 1%: 0.01+0.2+0.002 ms clock")
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f.Fuzz(func(t *testing.T, line string) {
+		event, err := ParseLine(line, start)
+		if err != nil {
+			if !errors.Is(err, types.ErrInvalidGCTrace) {
+				t.Fatalf("ParseLine(%q) error = %v, want ErrInvalidGCTrace", line, err)
+			}
+			return
+		}
+		if event.Duration < 0 || event.EndTime.Before(event.StartTime) || event.StartTime.Before(start) {
+			t.Fatalf("ParseLine(%q) = inconsistent event %+v", line, event)
+		}
+	})
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add("starting server\ngc 1 @0.012s 2%: 0.015+0.89+0.003 ms clock, 0.12+0.45/0.67/0+0.024 ms cpu, 4->4->0 MB, 5 MB goal, 8 P\n")
+	f.Add("scvg: 0 MB released\r\ngc 2 @0.050s 2%: 0.020+1.1+0.004 ms clock\r\n")
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		events, err := Parse(strings.NewReader(input), time.Now())
+		if err != nil {
+			if !errors.Is(err, types.ErrInvalidGCTrace) {
+				t.Fatalf("Parse() error = %v, want ErrInvalidGCTrace", err)
+			}
+			return
+		}
+		for _, event := range events {
+			if event == nil {
+				t.Fatal("Parse() returned a nil event")
+			}
+		}
+	})
+}
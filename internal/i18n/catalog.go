@@ -0,0 +1,793 @@
+// Package i18n provides the message catalog used to localize report headings
+// and recommendation strings.
+package i18n
+
+import "strings"
+
+// Language identifies a supported output language
+type Language string
+
+// Supported languages
+const (
+	English Language = "en"
+	Korean  Language = "ko"
+)
+
+// DefaultLanguage is used when no language (or an unsupported one) is requested
+const DefaultLanguage = English
+
+// Key identifies a message in the catalog
+type Key string
+
+// Report heading keys
+const (
+	ReportTitle           Key = "report.title"
+	SummaryTitle          Key = "summary.title"
+	EventsTitle           Key = "events.title"
+	SectionEnvironment    Key = "section.environment"
+	SectionTuningChanges  Key = "section.tuning_changes"
+	SectionCounterResets  Key = "section.counter_resets"
+	SectionGCFrequency    Key = "section.gc_frequency"
+	SectionPauseTimes     Key = "section.pause_times"
+	SectionPauseBreakdown Key = "section.pause_breakdown"
+	SectionLongestPauses  Key = "section.longest_pauses"
+	SectionMMU            Key = "section.mmu"
+	SectionMemoryUsage    Key = "section.memory_usage"
+	SectionReclamation    Key = "section.reclamation"
+	SectionSoftLimit      Key = "section.soft_limit"
+	SectionAllocations    Key = "section.allocations"
+	SectionSizeClasses    Key = "section.size_classes"
+	SectionRegions        Key = "section.regions"
+	SectionAllocSites     Key = "section.alloc_sites"
+	SectionRegionLabels   Key = "section.region_labels"
+	SectionRequests       Key = "section.requests"
+	SectionRSS            Key = "section.rss"
+	SectionPaging         Key = "section.paging"
+	SectionEfficiency     Key = "section.efficiency"
+	SectionRecommendation Key = "section.recommendations"
+	SectionWarnings       Key = "section.warnings"
+	SectionConfigDrift    Key = "section.config_drift"
+	SectionRuntimeUpgrade Key = "section.runtime_upgrade"
+	SectionBuildCompare   Key = "section.build_compare"
+	SectionBuildChanges   Key = "section.build_changes"
+	SectionNotes          Key = "section.notes"
+	SectionCharts         Key = "section.charts"
+	SectionComparison     Key = "section.comparison"
+)
+
+// Report message keys
+const (
+	MsgConfigDrift      Key = "msg.config_drift"
+	MsgPeriodicWorkload Key = "msg.periodic_workload"
+	MsgPausesIncomplete Key = "msg.pauses_incomplete"
+	MsgEventsMissed     Key = "msg.events_missed"
+	MsgDeathSpiral      Key = "msg.death_spiral"
+	MsgPausesPaging     Key = "msg.pauses_paging"
+	MsgIssuesFound      Key = "msg.issues_found"
+	MsgNoIssues         Key = "msg.no_issues"
+)
+
+// Summary report label keys
+const (
+	SummaryEnvironment     Key = "summary.environment"
+	SummaryPeriod          Key = "summary.period"
+	SummaryGCFrequency     Key = "summary.gc_frequency"
+	SummaryAvgPause        Key = "summary.avg_pause"
+	SummaryMemory          Key = "summary.memory"
+	SummaryAllocRate       Key = "summary.alloc_rate"
+	SummaryEfficiency      Key = "summary.efficiency"
+	SummaryAvg             Key = "summary.avg"
+	SummaryMax             Key = "summary.max"
+	SummaryGCOverhead      Key = "summary.gc_overhead"
+	SummaryMemEfficiency   Key = "summary.memory_efficiency"
+	SummaryRecommendations Key = "summary.recommendations"
+)
+
+// Table and events report column keys
+const (
+	ColumnTimestamp  Key = "column.timestamp"
+	ColumnGCNumber   Key = "column.gc_number"
+	ColumnHeap       Key = "column.heap"
+	ColumnSys        Key = "column.sys"
+	ColumnPause      Key = "column.pause"
+	ColumnObjects    Key = "column.objects"
+	ColumnAllocRate  Key = "column.alloc_rate"
+	ColumnSequence   Key = "column.sequence"
+	ColumnStartTime  Key = "column.start_time"
+	ColumnDuration   Key = "column.duration"
+	ColumnTrigger    Key = "column.trigger"
+	ColumnHeapBefore Key = "column.heap_before"
+	ColumnHeapAfter  Key = "column.heap_after"
+	ColumnReleased   Key = "column.released"
+	ColumnMissed     Key = "column.missed"
+	ColumnMetric     Key = "column.metric"
+	ColumnBefore     Key = "column.before"
+	ColumnAfter      Key = "column.after"
+	ColumnChange     Key = "column.change"
+)
+
+// Health check keys
+const (
+	HealthNoData          Key = "health.no_data"
+	HealthUnknown         Key = "health.unknown"
+	HealthGood            Key = "health.good"
+	HealthNeedsAttention  Key = "health.needs_attention"
+	HealthIssuesDetected  Key = "health.issues_detected"
+	IssueHighGCFrequency  Key = "issue.high_gc_frequency"
+	IssueLongAvgPause     Key = "issue.long_avg_pause"
+	IssueVeryLongP99Pause Key = "issue.very_long_p99_pause"
+	IssueHighGCOverhead   Key = "issue.high_gc_overhead"
+	IssueLowMemEfficiency Key = "issue.low_memory_efficiency"
+	IssueHighAllocRate    Key = "issue.high_allocation_rate"
+	IssueNearMemoryLimit  Key = "issue.near_memory_limit"
+)
+
+// Report label keys
+const (
+	LabelAnalysisPeriod   Key = "label.analysis_period"
+	LabelFrom             Key = "label.from"
+	LabelTo               Key = "label.to"
+	LabelGCFrequency      Key = "label.gc_frequency"
+	LabelAvgGCInterval    Key = "label.avg_gc_interval"
+	LabelForcedGCs        Key = "label.forced_gcs"
+	LabelAvgPause         Key = "label.avg_pause"
+	LabelMedianPause      Key = "label.median_pause"
+	LabelPauseApdex       Key = "label.pause_apdex"
+	LabelMinPause         Key = "label.min_pause"
+	LabelMaxPause         Key = "label.max_pause"
+	LabelP95Pause         Key = "label.p95_pause"
+	LabelP99Pause         Key = "label.p99_pause"
+	LabelIntervalMaxPause Key = "label.interval_max_pause"
+	LabelSTWPauses        Key = "label.stw_pauses"
+	LabelMissedEvents     Key = "label.missed_events"
+	LabelProcessGCs       Key = "label.process_gcs"
+	LabelRecentPauses     Key = "label.recent_pauses"
+	LabelSweepTermination Key = "label.sweep_termination"
+	LabelConcurrentMark   Key = "label.concurrent_mark"
+	LabelMarkTermination  Key = "label.mark_termination"
+	LabelSTWShare         Key = "label.stw_share"
+	LabelPhaseCycles      Key = "label.phase_cycles"
+	LabelOutlierThreshold Key = "label.outlier_threshold"
+	LabelOutliers         Key = "label.outliers"
+	LabelAvgHeap          Key = "label.avg_heap"
+	LabelMedianHeap       Key = "label.median_heap"
+	LabelMinHeap          Key = "label.min_heap"
+	LabelMaxHeap          Key = "label.max_heap"
+	LabelHeapGrowthRate   Key = "label.heap_growth_rate"
+	LabelAvgLiveHeap      Key = "label.avg_live_heap"
+	LabelLiveHeapGrowth   Key = "label.live_heap_growth_rate"
+	LabelReclaimed        Key = "label.reclaimed_per_cycle"
+	LabelReclaimedTrend   Key = "label.reclaimed_trend"
+	LabelSurvivalRatio    Key = "label.survival_ratio"
+	LabelSurvivalTrend    Key = "label.survival_trend"
+	LabelReclaimCycles    Key = "label.reclaim_cycles"
+	LabelAllocRate        Key = "label.alloc_rate"
+	LabelTotalAllocs      Key = "label.total_allocs"
+	LabelTotalFrees       Key = "label.total_frees"
+	LabelSizeClassTiny    Key = "label.size_class_tiny"
+	LabelSizeClassSmall   Key = "label.size_class_small"
+	LabelSizeClassLarge   Key = "label.size_class_large"
+	LabelUntagged         Key = "label.untagged"
+	LabelRequests         Key = "label.requests"
+	LabelGCAffected       Key = "label.gc_affected"
+	LabelRequestPauses    Key = "label.request_pauses"
+	LabelRequestAllocs    Key = "label.request_allocs"
+	LabelGCOverhead       Key = "label.gc_overhead"
+	LabelMemoryEfficiency Key = "label.memory_efficiency"
+	LabelMarkAssistShare  Key = "label.mark_assist_share"
+	LabelBackgroundShare  Key = "label.background_mark_share"
+	LabelRuntimeMemory    Key = "label.runtime_memory"
+	LabelGCCPUTime        Key = "label.gc_cpu_time"
+	LabelProcessCPUUtil   Key = "label.process_cpu_utilization"
+	LabelGCShareOfProcess Key = "label.gc_share_of_process_cpu"
+	LabelAvgRSS           Key = "label.avg_rss"
+	LabelGoManaged        Key = "label.go_managed"
+	LabelNonGoMemory      Key = "label.non_go_memory"
+	LabelNonGoGrowth      Key = "label.non_go_growth"
+	LabelSysBreakdown     Key = "label.sys_breakdown"
+	LabelMemoryLimit      Key = "label.memory_limit"
+	LabelGoVersion        Key = "label.go_version"
+	LabelGOMAXPROCS       Key = "label.gomaxprocs"
+	LabelGOGC             Key = "label.gogc"
+	LabelGOMEMLIMIT       Key = "label.gomemlimit"
+	LabelTimeNearLimit    Key = "label.time_near_limit"
+	LabelGCFreqNearLimit  Key = "label.gc_frequency_near_limit"
+	LabelGCCPUNearLimit   Key = "label.gc_cpu_near_limit"
+	LabelSuggestedLimit   Key = "label.suggested_limit"
+	LabelMajorFaults      Key = "label.major_faults"
+	LabelPeakSwap         Key = "label.peak_swap"
+	LabelTimePaging       Key = "label.time_paging"
+	LabelPausePaging      Key = "label.pause_paging"
+	LabelPod              Key = "label.pod"
+	LabelCPULimit         Key = "label.cpu_limit"
+	LabelPodMemoryLimit   Key = "label.pod_memory_limit"
+	LabelHeapTrend        Key = "label.heap_trend"
+	LabelPauseChart       Key = "label.pause_chart"
+	LabelGCTimeline       Key = "label.gc_timeline"
+	UnitGCsPerSecond      Key = "unit.gcs_per_second"
+	UnitOfPause           Key = "unit.of_pause"
+	UnitPerCall           Key = "unit.per_call"
+	UnitCalls             Key = "unit.calls"
+	UnitGCs               Key = "unit.gcs"
+	UnitPaused            Key = "unit.paused"
+	UnitInUse             Key = "unit.in_use"
+	UnitPoints            Key = "unit.points"
+	UnitHeap              Key = "unit.heap"
+	UnitAlloc             Key = "unit.alloc"
+	UnitOutlier           Key = "unit.outlier"
+	UnitSatisfied         Key = "unit.satisfied"
+	UnitTolerating        Key = "unit.tolerating"
+	UnitFrustrated        Key = "unit.frustrated"
+	UnitCores             Key = "unit.cores"
+	UnitCPUs              Key = "unit.cpus"
+	UnitPeak              Key = "unit.peak"
+	UnitBelow             Key = "unit.below"
+	UnitPerSecond         Key = "unit.per_second"
+	UnitOtherwise         Key = "unit.otherwise"
+	UnitNotAvailable      Key = "unit.not_available"
+)
+
+// Comparison status keys
+const (
+	StatusImproved  Key = "status.improved"
+	StatusRegressed Key = "status.regressed"
+	StatusUnchanged Key = "status.unchanged"
+)
+
+// Severity label keys
+const (
+	SeverityInfo     Key = "severity.info"
+	SeverityWarning  Key = "severity.warning"
+	SeverityCritical Key = "severity.critical"
+)
+
+// Data quality warning keys
+const (
+	WarnFewSamples    Key = "warn.few_samples"
+	WarnNoPauseData   Key = "warn.no_pause_data"
+	WarnClockAnomaly  Key = "warn.clock_anomaly"
+	WarnSampleGaps    Key = "warn.sample_gaps"
+	WarnMissedEvents  Key = "warn.missed_events"
+	WarnCounterResets Key = "warn.counter_resets"
+)
+
+// Recommendation keys
+const (
+	RecHighGCFrequency         Key = "rec.high_gc_frequency"
+	RecFrequentForcedGC        Key = "rec.frequent_forced_gc"
+	RecLongPause               Key = "rec.long_pause"
+	RecVeryLongP99Pause        Key = "rec.very_long_p99_pause"
+	RecHighHeapGrowth          Key = "rec.high_heap_growth"
+	RecHighGCOverhead          Key = "rec.high_gc_overhead"
+	RecLowMemoryEfficiency     Key = "rec.low_memory_efficiency"
+	RecHighAllocationRate      Key = "rec.high_allocation_rate"
+	RecConsistentGrowth        Key = "rec.consistent_growth"
+	RecDecliningReclamation    Key = "rec.declining_reclamation"
+	RecSoftLimitPressure       Key = "rec.soft_limit_pressure"
+	RecGCDeathSpiral           Key = "rec.gc_death_spiral"
+	RecHostPaging              Key = "rec.host_paging"
+	RecHighMarkAssist          Key = "rec.high_mark_assist"
+	RecCPUSaturatedGC          Key = "rec.cpu_saturated_gc"
+	RecNonGoMemoryGrowth       Key = "rec.non_go_memory_growth"
+	RecNearMemoryLimit         Key = "rec.near_memory_limit"
+	RecSetGOMemLimit           Key = "rec.set_gomemlimit"
+	RecGOMAXPROCSAboveCPULimit Key = "rec.gomaxprocs_above_cpu_limit"
+	RecPoolSmallObjects        Key = "rec.pool_small_objects"
+	RecReuseLargeBuffers       Key = "rec.reuse_large_buffers"
+)
+
+// catalog holds the translations for every supported language.
+// English is the source language and must contain every key.
+var catalog = map[Language]map[Key]string{
+	English: {
+		ReportTitle:           "Go GC Analysis Report",
+		SummaryTitle:          "GC Summary Report",
+		EventsTitle:           "GC Events Report",
+		SectionEnvironment:    "Environment",
+		SectionTuningChanges:  "GC Tuning Changes",
+		SectionCounterResets:  "Process Restarts",
+		SectionGCFrequency:    "GC Frequency",
+		SectionPauseTimes:     "GC Pause Times",
+		SectionPauseBreakdown: "GC Pause Breakdown",
+		SectionLongestPauses:  "Longest GC Pauses",
+		SectionMMU:            "Minimum Mutator Utilization",
+		SectionMemoryUsage:    "Memory Usage",
+		SectionReclamation:    "GC Reclamation",
+		SectionSoftLimit:      "Soft Memory Limit",
+		SectionAllocations:    "Allocation Statistics",
+		SectionSizeClasses:    "Allocation Size Classes",
+		SectionRegions:        "Allocation by Region",
+		SectionAllocSites:     "Top Allocation Sites",
+		SectionRegionLabels:   "Breakdown by Region Label",
+		SectionRequests:       "Per-Request GC Impact",
+		SectionRSS:            "Resident Memory vs Go-Managed Memory",
+		SectionPaging:         "OS Paging",
+		SectionEfficiency:     "Efficiency Metrics",
+		SectionRecommendation: "Recommendations",
+		SectionWarnings:       "Data Quality Warnings",
+		SectionConfigDrift:    "Configuration Drift",
+		SectionRuntimeUpgrade: "Go Runtime Upgrade",
+		SectionBuildCompare:   "Build Comparison",
+		SectionBuildChanges:   "Build Changes",
+		SectionNotes:          "Notes",
+		SectionCharts:         "Charts",
+		SectionComparison:     "Analysis Comparison",
+
+		MsgConfigDrift:      "This analysis was recorded under different runtime settings than the current process; its conclusions may not apply:",
+		MsgPeriodicWorkload: "Periodic workload detected, period ≈",
+		MsgPausesIncomplete: "GC events missed cycles between samples; pause percentiles include the runtime pause histogram",
+		MsgEventsMissed:     "More GC cycles completed between samples than the runtime's pause buffer holds; shorten the collection interval to record every cycle",
+		MsgDeathSpiral:      "GC cycles run back to back near the limit (death spiral)",
+		MsgPausesPaging:     "Long GC pauses coincide with paging; the host is short of memory",
+		MsgIssuesFound:      "Issues found",
+		MsgNoIssues:         "No performance issues detected",
+
+		SummaryEnvironment:     "Environment",
+		SummaryPeriod:          "Period",
+		SummaryGCFrequency:     "GC Frequency",
+		SummaryAvgPause:        "Avg Pause",
+		SummaryMemory:          "Memory",
+		SummaryAllocRate:       "Alloc Rate",
+		SummaryEfficiency:      "Efficiency",
+		SummaryAvg:             "avg",
+		SummaryMax:             "max",
+		SummaryGCOverhead:      "GC overhead",
+		SummaryMemEfficiency:   "memory efficiency",
+		SummaryRecommendations: "recommendations",
+
+		ColumnTimestamp:  "Timestamp",
+		ColumnGCNumber:   "GC#",
+		ColumnHeap:       "Heap",
+		ColumnSys:        "Sys",
+		ColumnPause:      "Pause",
+		ColumnObjects:    "Objects",
+		ColumnAllocRate:  "Alloc/s",
+		ColumnSequence:   "Seq#",
+		ColumnStartTime:  "Start Time",
+		ColumnDuration:   "Duration",
+		ColumnTrigger:    "Trigger",
+		ColumnHeapBefore: "Heap Before",
+		ColumnHeapAfter:  "Heap After",
+		ColumnReleased:   "Released",
+		ColumnMissed:     "missed",
+		ColumnMetric:     "Metric",
+		ColumnBefore:     "Before",
+		ColumnAfter:      "After",
+		ColumnChange:     "Change",
+
+		HealthNoData:          "No analysis data available",
+		HealthUnknown:         "Unable to determine GC health status",
+		HealthGood:            "GC performance is good",
+		HealthNeedsAttention:  "GC performance needs attention",
+		HealthIssuesDetected:  "GC performance issues detected",
+		IssueHighGCFrequency:  "High GC frequency",
+		IssueLongAvgPause:     "Long average pause times",
+		IssueVeryLongP99Pause: "Very long P99 pause times",
+		IssueHighGCOverhead:   "High GC overhead",
+		IssueLowMemEfficiency: "Low memory efficiency",
+		IssueHighAllocRate:    "High allocation rate",
+		IssueNearMemoryLimit:  "Memory usage near container limit",
+
+		LabelAnalysisPeriod:   "Analysis Period",
+		LabelFrom:             "from",
+		LabelTo:               "to",
+		LabelGCFrequency:      "GC Frequency",
+		LabelAvgGCInterval:    "Average GC Interval",
+		LabelForcedGCs:        "Forced GCs",
+		LabelAvgPause:         "Average Pause",
+		LabelMedianPause:      "Median Pause",
+		LabelPauseApdex:       "Pause Apdex",
+		LabelMinPause:         "Min Pause",
+		LabelMaxPause:         "Max Pause",
+		LabelP95Pause:         "P95 Pause",
+		LabelP99Pause:         "P99 Pause",
+		LabelIntervalMaxPause: "Max Avg Pause per Interval",
+		LabelSTWPauses:        "Stop-the-World Pauses",
+		LabelMissedEvents:     "Missed GC Events",
+		LabelProcessGCs:       "GCs Since Start",
+		LabelRecentPauses:     "Recent Pause Quantiles",
+		LabelSweepTermination: "Avg Sweep Termination (STW)",
+		LabelConcurrentMark:   "Avg Concurrent Mark",
+		LabelMarkTermination:  "Avg Mark Termination (STW)",
+		LabelSTWShare:         "Stop-the-World Share of GC Cycle",
+		LabelPhaseCycles:      "Cycles with Phase Data",
+		LabelOutlierThreshold: "Outlier Threshold",
+		LabelOutliers:         "Outliers",
+		LabelAvgHeap:          "Average Heap Size",
+		LabelMedianHeap:       "Median Heap Size",
+		LabelMinHeap:          "Min Heap Size",
+		LabelMaxHeap:          "Max Heap Size",
+		LabelHeapGrowthRate:   "Heap Growth Rate",
+		LabelAvgLiveHeap:      "Average Live Heap",
+		LabelLiveHeapGrowth:   "Live Heap Growth Rate",
+		LabelReclaimed:        "Reclaimed per Cycle",
+		LabelReclaimedTrend:   "Reclaimed Trend",
+		LabelSurvivalRatio:    "Survival Ratio",
+		LabelSurvivalTrend:    "Survival Trend",
+		LabelReclaimCycles:    "Cycles with Heap Data",
+		LabelAllocRate:        "Allocation Rate",
+		LabelTotalAllocs:      "Total Allocations",
+		LabelTotalFrees:       "Total Frees",
+		LabelSizeClassTiny:    "Tiny (< 16 B)",
+		LabelSizeClassSmall:   "Small (16 B - 32 KB)",
+		LabelSizeClassLarge:   "Large (> 32 KB)",
+		LabelUntagged:         "(untagged)",
+		LabelRequests:         "Requests",
+		LabelGCAffected:       "Requests Overlapping GC",
+		LabelRequestPauses:    "GC Pause per Request",
+		LabelRequestAllocs:    "Allocated per Request",
+		LabelGCOverhead:       "GC Overhead",
+		LabelMemoryEfficiency: "Memory Efficiency",
+		LabelMarkAssistShare:  "Mark Assist Share of GC CPU",
+		LabelBackgroundShare:  "Background Mark Share of GC CPU",
+		LabelRuntimeMemory:    "Runtime Memory (Sys - Released)",
+		LabelGCCPUTime:        "GC CPU Time",
+		LabelProcessCPUUtil:   "Process CPU Utilization",
+		LabelGCShareOfProcess: "GC Share of Process CPU",
+		LabelAvgRSS:           "Average RSS",
+		LabelGoManaged:        "Go-Managed (Sys - Released)",
+		LabelNonGoMemory:      "Non-Go Memory",
+		LabelNonGoGrowth:      "Non-Go Growth",
+		LabelSysBreakdown:     "Sys Breakdown",
+		LabelMemoryLimit:      "Container Memory Limit",
+		LabelGoVersion:        "Go Version",
+		LabelGOMAXPROCS:       "GOMAXPROCS",
+		LabelGOGC:             "GOGC",
+		LabelGOMEMLIMIT:       "GOMEMLIMIT",
+		LabelTimeNearLimit:    "Time Near Limit",
+		LabelGCFreqNearLimit:  "GC Frequency Near Limit",
+		LabelGCCPUNearLimit:   "GC CPU Near Limit",
+		LabelSuggestedLimit:   "Suggested GOMEMLIMIT",
+		LabelMajorFaults:      "Major Page Faults",
+		LabelPeakSwap:         "Peak Swap",
+		LabelTimePaging:       "Time Paging",
+		LabelPausePaging:      "Pause per GC While Paging",
+		LabelPod:              "Pod",
+		LabelCPULimit:         "Pod CPU Limit",
+		LabelPodMemoryLimit:   "Pod Memory Limit",
+		LabelHeapTrend:        "Heap Trend",
+		LabelPauseChart:       "Pause Distribution",
+		LabelGCTimeline:       "GC Timeline",
+		UnitGCsPerSecond:      "GCs/second",
+		UnitOfPause:           "of pause",
+		UnitPerCall:           "/call",
+		UnitCalls:             "calls",
+		UnitGCs:               "GCs",
+		UnitPaused:            "paused",
+		UnitInUse:             "in use",
+		UnitPoints:            "pts",
+		UnitHeap:              "heap",
+		UnitAlloc:             "alloc",
+		UnitOutlier:           "outlier",
+		UnitSatisfied:         "satisfied",
+		UnitTolerating:        "tolerating",
+		UnitFrustrated:        "frustrated",
+		UnitCores:             "cores",
+		UnitCPUs:              "CPUs",
+		UnitPeak:              "peak",
+		UnitBelow:             "below",
+		UnitPerSecond:         "/s",
+		UnitOtherwise:         "otherwise",
+		UnitNotAvailable:      "n/a",
+
+		StatusImproved:  "improved",
+		StatusRegressed: "regressed",
+		StatusUnchanged: "unchanged",
+
+		SeverityInfo:     "INFO",
+		SeverityWarning:  "WARNING",
+		SeverityCritical: "CRITICAL",
+
+		WarnFewSamples:    "Few samples were analyzed; rates and trends may be unreliable",
+		WarnNoPauseData:   "GC cycles ran but no individual pauses were recorded; pause percentiles are unavailable",
+		WarnClockAnomaly:  "Sample timestamps go backwards or repeat; intervals around them are skipped",
+		WarnSampleGaps:    "Sampling stopped for long stretches; rates are averaged over the gaps",
+		WarnMissedEvents:  "GC cycles completed faster than the pause buffer could record them; pause statistics are incomplete",
+		WarnCounterResets: "The process restarted during the window; counters were stitched across the restart",
+
+		RecHighGCFrequency:         "High GC frequency detected. Consider reducing allocation rate or increasing GOGC value.",
+		RecFrequentForcedGC:        "A large share of GC cycles are forced by runtime.GC calls. Remove explicit collections from application code and let GOGC/GOMEMLIMIT pace the collector.",
+		RecLongPause:               "Long GC pause times detected. Consider reducing heap size or optimizing allocation patterns.",
+		RecVeryLongP99Pause:        "Very long P99 pause times detected. This may impact application responsiveness.",
+		RecHighHeapGrowth:          "High heap growth rate detected. Check for memory leaks or excessive allocations.",
+		RecHighGCOverhead:          "High GC overhead detected. Consider optimizing allocation patterns or tuning GC parameters.",
+		RecLowMemoryEfficiency:     "Low memory efficiency detected. Consider reducing heap fragmentation or optimizing data structures.",
+		RecHighAllocationRate:      "High allocation rate detected. Consider object pooling or reducing temporary object creation.",
+		RecConsistentGrowth:        "Consistent memory growth detected. Investigate potential memory leaks.",
+		RecDecliningReclamation:    "GC cycles reclaim progressively less memory while more of the heap survives them, a classic leak signature. Compare heap profiles taken some time apart to find what accumulates.",
+		RecSoftLimitPressure:       "Memory usage stays close to GOMEMLIMIT, so the GC runs more often to stay below it. Raise GOMEMLIMIT, keeping it below the container limit, or reduce the live heap.",
+		RecGCDeathSpiral:           "GC cycles run back to back near GOMEMLIMIT because the live heap nearly fills it (a GC death spiral). Raise GOMEMLIMIT well above the live heap or reduce the live heap.",
+		RecHostPaging:              "The slowest GC pauses coincide with OS paging, so the host is short of memory. Add memory to the host or container; tuning GOGC won't shorten these pauses.",
+		RecHighMarkAssist:          "High GC mark assist share detected. Goroutines are being drafted into GC work on the request path; reduce allocation rate in hot paths or give the GC more headroom with GOGC/GOMEMLIMIT.",
+		RecCPUSaturatedGC:          "The process is CPU-saturated and GC takes a significant share of its CPU time, so collection competes directly with application work. Reduce allocation rate, raise GOGC/GOMEMLIMIT to collect less often, or provision more CPU.",
+		RecNearMemoryLimit:         "Memory usage is close to the container memory limit; the kernel will OOM-kill the process when it is reached. Reduce the live heap or raise the limit.",
+		RecSetGOMemLimit:           "A container memory limit is set but GOMEMLIMIT is not below it, so the GC paces itself without regard to the limit. Set GOMEMLIMIT to about 90% of the container limit.",
+		RecGOMAXPROCSAboveCPULimit: "GOMAXPROCS exceeds the container CPU limit, so GC workers and parallel marking exhaust the CPU quota and the process gets throttled, stretching pauses. Set GOMAXPROCS to the CPU limit (Go 1.25+ does this automatically).",
+		RecNonGoMemoryGrowth:       "Process RSS is growing outside Go-managed memory (cgo, mmap or non-Go threads). GC tuning cannot reclaim it; look for native allocations that are never freed.",
+		RecPoolSmallObjects:        "Most allocation volume is in small objects (up to 32 KB). Reuse short-lived objects of the hottest types with sync.Pool to cut allocation rate.",
+		RecReuseLargeBuffers:       "Most allocation volume is in large objects (over 32 KB), which bypass the per-P allocation caches. Reuse buffers across requests, e.g. pre-sized slices or pooled bytes.Buffer values.",
+	},
+	Korean: {
+		ReportTitle:           "Go GC 분석 보고서",
+		SummaryTitle:          "GC 요약 보고서",
+		EventsTitle:           "GC 이벤트 보고서",
+		SectionEnvironment:    "실행 환경",
+		SectionTuningChanges:  "GC 튜닝 변경",
+		SectionCounterResets:  "프로세스 재시작",
+		SectionGCFrequency:    "GC 빈도",
+		SectionPauseTimes:     "GC 일시 정지 시간",
+		SectionPauseBreakdown: "GC 일시 정지 분석",
+		SectionLongestPauses:  "가장 긴 GC 일시 정지",
+		SectionMMU:            "최소 뮤테이터 활용률 (MMU)",
+		SectionMemoryUsage:    "메모리 사용량",
+		SectionReclamation:    "GC 회수량",
+		SectionSoftLimit:      "소프트 메모리 한도",
+		SectionAllocations:    "할당 통계",
+		SectionSizeClasses:    "할당 크기 클래스",
+		SectionRegions:        "영역별 할당",
+		SectionAllocSites:     "주요 할당 위치",
+		SectionRegionLabels:   "영역 레이블별 분석",
+		SectionRequests:       "요청별 GC 영향",
+		SectionRSS:            "상주 메모리 vs Go 관리 메모리",
+		SectionPaging:         "OS 페이징",
+		SectionEfficiency:     "효율성 지표",
+		SectionRecommendation: "권장 사항",
+		SectionWarnings:       "데이터 품질 경고",
+		SectionConfigDrift:    "설정 변경 감지",
+		SectionRuntimeUpgrade: "Go 런타임 업그레이드",
+		SectionBuildCompare:   "빌드 비교",
+		SectionBuildChanges:   "빌드 변경 사항",
+		SectionNotes:          "참고 사항",
+		SectionCharts:         "차트",
+		SectionComparison:     "분석 비교",
+
+		MsgConfigDrift:      "이 분석은 현재 프로세스와 다른 런타임 설정에서 기록되었으므로 결론이 적용되지 않을 수 있습니다:",
+		MsgPeriodicWorkload: "주기적인 워크로드 감지, 주기 ≈",
+		MsgPausesIncomplete: "샘플 사이에 누락된 GC 이벤트가 있어 정지 시간 백분위수에 런타임 정지 히스토그램을 반영했습니다",
+		MsgEventsMissed:     "샘플 사이에 완료된 GC 사이클이 런타임 정지 버퍼 크기를 넘었습니다. 모든 사이클을 기록하려면 수집 간격을 줄이세요",
+		MsgDeathSpiral:      "한도 근처에서 GC 사이클이 연달아 실행됩니다 (데스 스파이럴)",
+		MsgPausesPaging:     "긴 GC 일시 정지가 페이징과 함께 발생합니다. 호스트 메모리가 부족합니다",
+		MsgIssuesFound:      "발견된 문제",
+		MsgNoIssues:         "성능 문제가 발견되지 않았습니다",
+
+		SummaryEnvironment:     "실행 환경",
+		SummaryPeriod:          "기간",
+		SummaryGCFrequency:     "GC 빈도",
+		SummaryAvgPause:        "평균 정지",
+		SummaryMemory:          "메모리",
+		SummaryAllocRate:       "할당 속도",
+		SummaryEfficiency:      "효율성",
+		SummaryAvg:             "평균",
+		SummaryMax:             "최대",
+		SummaryGCOverhead:      "GC 오버헤드",
+		SummaryMemEfficiency:   "메모리 효율성",
+		SummaryRecommendations: "건의 권장 사항",
+
+		ColumnTimestamp:  "시각",
+		ColumnGCNumber:   "GC#",
+		ColumnHeap:       "힙",
+		ColumnSys:        "Sys",
+		ColumnPause:      "정지",
+		ColumnObjects:    "객체 수",
+		ColumnAllocRate:  "할당/초",
+		ColumnSequence:   "순번",
+		ColumnStartTime:  "시작 시각",
+		ColumnDuration:   "소요 시간",
+		ColumnTrigger:    "트리거",
+		ColumnHeapBefore: "GC 전 힙",
+		ColumnHeapAfter:  "GC 후 힙",
+		ColumnReleased:   "반환",
+		ColumnMissed:     "누락",
+		ColumnMetric:     "지표",
+		ColumnBefore:     "이전",
+		ColumnAfter:      "이후",
+		ColumnChange:     "변화",
+
+		HealthNoData:          "분석 데이터가 없습니다",
+		HealthUnknown:         "GC 상태를 판단할 수 없습니다",
+		HealthGood:            "GC 성능이 양호합니다",
+		HealthNeedsAttention:  "GC 성능에 주의가 필요합니다",
+		HealthIssuesDetected:  "GC 성능 문제가 감지되었습니다",
+		IssueHighGCFrequency:  "높은 GC 빈도",
+		IssueLongAvgPause:     "긴 평균 정지 시간",
+		IssueVeryLongP99Pause: "매우 긴 P99 정지 시간",
+		IssueHighGCOverhead:   "높은 GC 오버헤드",
+		IssueLowMemEfficiency: "낮은 메모리 효율성",
+		IssueHighAllocRate:    "높은 할당 속도",
+		IssueNearMemoryLimit:  "컨테이너 메모리 제한 근접",
+
+		LabelAnalysisPeriod:   "분석 기간",
+		LabelFrom:             "시작",
+		LabelTo:               "종료",
+		LabelGCFrequency:      "GC 빈도",
+		LabelAvgGCInterval:    "평균 GC 간격",
+		LabelForcedGCs:        "강제 GC",
+		LabelAvgPause:         "평균 정지 시간",
+		LabelMedianPause:      "정지 시간 중앙값",
+		LabelPauseApdex:       "정지 시간 Apdex",
+		LabelMinPause:         "최소 정지 시간",
+		LabelMaxPause:         "최대 정지 시간",
+		LabelP95Pause:         "P95 정지 시간",
+		LabelP99Pause:         "P99 정지 시간",
+		LabelIntervalMaxPause: "구간별 최대 평균 정지 시간",
+		LabelSTWPauses:        "STW 정지",
+		LabelMissedEvents:     "누락된 GC 이벤트",
+		LabelProcessGCs:       "시작 이후 GC 횟수",
+		LabelRecentPauses:     "최근 정지 시간 분위수",
+		LabelSweepTermination: "평균 스윕 종료 (STW)",
+		LabelConcurrentMark:   "평균 동시 마킹",
+		LabelMarkTermination:  "평균 마크 종료 (STW)",
+		LabelSTWShare:         "GC 사이클 중 STW 비율",
+		LabelPhaseCycles:      "단계 정보가 있는 사이클",
+		LabelOutlierThreshold: "이상치 기준",
+		LabelOutliers:         "이상치",
+		LabelAvgHeap:          "평균 힙 크기",
+		LabelMedianHeap:       "힙 크기 중앙값",
+		LabelMinHeap:          "최소 힙 크기",
+		LabelMaxHeap:          "최대 힙 크기",
+		LabelHeapGrowthRate:   "힙 증가율",
+		LabelAvgLiveHeap:      "평균 라이브 힙",
+		LabelLiveHeapGrowth:   "라이브 힙 증가율",
+		LabelReclaimed:        "사이클당 회수량",
+		LabelReclaimedTrend:   "회수량 추세",
+		LabelSurvivalRatio:    "생존 비율",
+		LabelSurvivalTrend:    "생존 비율 추세",
+		LabelReclaimCycles:    "힙 정보가 있는 사이클",
+		LabelAllocRate:        "할당 속도",
+		LabelTotalAllocs:      "총 할당 횟수",
+		LabelTotalFrees:       "총 해제 횟수",
+		LabelSizeClassTiny:    "초소형 (< 16 B)",
+		LabelSizeClassSmall:   "소형 (16 B - 32 KB)",
+		LabelSizeClassLarge:   "대형 (> 32 KB)",
+		LabelUntagged:         "(레이블 없음)",
+		LabelRequests:         "요청 수",
+		LabelGCAffected:       "GC와 겹친 요청",
+		LabelRequestPauses:    "요청당 GC 정지 시간",
+		LabelRequestAllocs:    "요청당 할당량",
+		LabelGCOverhead:       "GC 오버헤드",
+		LabelMemoryEfficiency: "메모리 효율성",
+		LabelMarkAssistShare:  "GC CPU 중 마크 어시스트 비율",
+		LabelBackgroundShare:  "GC CPU 중 백그라운드 마킹 비율",
+		LabelRuntimeMemory:    "런타임 메모리 (Sys - 반환)",
+		LabelGCCPUTime:        "GC CPU 시간",
+		LabelProcessCPUUtil:   "프로세스 CPU 사용률",
+		LabelGCShareOfProcess: "프로세스 CPU 중 GC 비율",
+		LabelAvgRSS:           "평균 RSS",
+		LabelGoManaged:        "Go 관리 메모리 (Sys - 반환)",
+		LabelNonGoMemory:      "Go 외부 메모리",
+		LabelNonGoGrowth:      "Go 외부 메모리 증가율",
+		LabelSysBreakdown:     "Sys 구성",
+		LabelMemoryLimit:      "컨테이너 메모리 제한",
+		LabelGoVersion:        "Go 버전",
+		LabelGOMAXPROCS:       "GOMAXPROCS",
+		LabelGOGC:             "GOGC",
+		LabelGOMEMLIMIT:       "GOMEMLIMIT",
+		LabelTimeNearLimit:    "한도 근접 시간",
+		LabelGCFreqNearLimit:  "한도 근접 시 GC 빈도",
+		LabelGCCPUNearLimit:   "한도 근접 시 GC CPU",
+		LabelSuggestedLimit:   "권장 GOMEMLIMIT",
+		LabelMajorFaults:      "메이저 페이지 폴트",
+		LabelPeakSwap:         "최대 스왑",
+		LabelTimePaging:       "페이징 시간",
+		LabelPausePaging:      "페이징 중 GC당 일시 정지",
+		LabelPod:              "파드",
+		LabelCPULimit:         "파드 CPU 제한",
+		LabelPodMemoryLimit:   "파드 메모리 제한",
+		LabelHeapTrend:        "힙 추이",
+		LabelPauseChart:       "일시 정지 분포",
+		LabelGCTimeline:       "GC 타임라인",
+		UnitGCsPerSecond:      "회/초",
+		UnitOfPause:           "일시 정지 시간 중",
+		UnitPerCall:           "/호출",
+		UnitCalls:             "회 호출",
+		UnitGCs:               "회 GC",
+		UnitPaused:            "정지",
+		UnitInUse:             "사용 중",
+		UnitPoints:            "%p",
+		UnitHeap:              "힙",
+		UnitAlloc:             "할당",
+		UnitOutlier:           "이상치",
+		UnitSatisfied:         "만족",
+		UnitTolerating:        "허용",
+		UnitFrustrated:        "불만족",
+		UnitCores:             "코어",
+		UnitCPUs:              "CPU",
+		UnitPeak:              "최대",
+		UnitBelow:             "한도 아래",
+		UnitPerSecond:         "/초",
+		UnitOtherwise:         "그 외",
+		UnitNotAvailable:      "알 수 없음",
+
+		StatusImproved:  "개선",
+		StatusRegressed: "악화",
+		StatusUnchanged: "변화 없음",
+
+		SeverityInfo:     "정보",
+		SeverityWarning:  "경고",
+		SeverityCritical: "심각",
+
+		WarnFewSamples:    "분석한 샘플이 적어 속도와 추세를 신뢰하기 어려울 수 있습니다",
+		WarnNoPauseData:   "GC 사이클이 실행되었지만 개별 일시 정지가 기록되지 않아 일시 정지 백분위수를 알 수 없습니다",
+		WarnClockAnomaly:  "샘플 타임스탬프가 거꾸로 가거나 반복되어 해당 구간을 건너뛰었습니다",
+		WarnSampleGaps:    "샘플링이 오래 중단된 구간이 있어 속도가 그 구간에 걸쳐 평균되었습니다",
+		WarnMissedEvents:  "일시 정지 버퍼가 기록할 수 있는 것보다 GC 사이클이 빨리 완료되어 일시 정지 통계가 불완전합니다",
+		WarnCounterResets: "분석 기간 중 프로세스가 재시작되어 재시작 전후의 카운터를 이어 붙였습니다",
+
+		RecHighGCFrequency:         "GC 빈도가 높습니다. 할당 속도를 줄이거나 GOGC 값을 높이는 것을 고려하세요.",
+		RecFrequentForcedGC:        "GC 사이클의 상당 부분이 runtime.GC 호출로 강제되고 있습니다. 애플리케이션 코드의 명시적 GC 호출을 제거하고 GOGC/GOMEMLIMIT이 수집 주기를 조절하도록 하세요.",
+		RecLongPause:               "GC 일시 정지 시간이 깁니다. 힙 크기를 줄이거나 할당 패턴을 최적화하는 것을 고려하세요.",
+		RecVeryLongP99Pause:        "P99 일시 정지 시간이 매우 깁니다. 애플리케이션 응답성에 영향을 줄 수 있습니다.",
+		RecHighHeapGrowth:          "힙 증가율이 높습니다. 메모리 누수나 과도한 할당이 있는지 확인하세요.",
+		RecHighGCOverhead:          "GC 오버헤드가 높습니다. 할당 패턴을 최적화하거나 GC 파라미터를 조정하는 것을 고려하세요.",
+		RecLowMemoryEfficiency:     "메모리 효율성이 낮습니다. 힙 단편화를 줄이거나 자료 구조를 최적화하는 것을 고려하세요.",
+		RecHighAllocationRate:      "할당 속도가 높습니다. 객체 풀링을 사용하거나 임시 객체 생성을 줄이는 것을 고려하세요.",
+		RecConsistentGrowth:        "메모리가 지속적으로 증가하고 있습니다. 메모리 누수 가능성을 조사하세요.",
+		RecDecliningReclamation:    "GC 사이클마다 회수되는 메모리가 점점 줄고 살아남는 힙 비율이 늘고 있습니다. 전형적인 메모리 누수 징후이니 시간 간격을 두고 힙 프로파일을 비교해 누적되는 객체를 찾으세요.",
+		RecSoftLimitPressure:       "메모리 사용량이 GOMEMLIMIT에 가깝게 유지되어 GC가 한도 아래로 유지하려고 더 자주 실행됩니다. 컨테이너 한도보다 낮은 범위에서 GOMEMLIMIT을 높이거나 라이브 힙을 줄이세요.",
+		RecGCDeathSpiral:           "라이브 힙이 GOMEMLIMIT을 거의 채워 GC 사이클이 쉬지 않고 연달아 실행됩니다(GC 데스 스파이럴). GOMEMLIMIT을 라이브 힙보다 충분히 높이거나 라이브 힙을 줄이세요.",
+		RecHostPaging:              "가장 긴 GC 일시 정지가 OS 페이징과 함께 발생하므로 호스트 메모리가 부족합니다. 호스트나 컨테이너에 메모리를 추가하세요. GOGC 조정으로는 이 일시 정지가 줄지 않습니다.",
+		RecHighMarkAssist:          "GC 마크 어시스트 비율이 높습니다. 요청 처리 중인 고루틴이 GC 작업에 동원되고 있으니 핫 경로의 할당을 줄이거나 GOGC/GOMEMLIMIT으로 GC 여유를 늘리세요.",
+		RecCPUSaturatedGC:          "프로세스 CPU가 포화 상태이며 GC가 CPU 시간의 상당 부분을 차지해 애플리케이션 작업과 직접 경쟁합니다. 할당률을 줄이거나 GOGC/GOMEMLIMIT을 높여 GC 빈도를 낮추거나 CPU를 증설하세요.",
+		RecNearMemoryLimit:         "메모리 사용량이 컨테이너 메모리 제한에 근접했습니다. 제한에 도달하면 커널이 프로세스를 OOM으로 종료합니다. 라이브 힙을 줄이거나 제한을 늘리세요.",
+		RecSetGOMemLimit:           "컨테이너 메모리 제한이 설정되어 있지만 GOMEMLIMIT이 그보다 낮지 않아 GC가 제한을 고려하지 않고 동작합니다. GOMEMLIMIT을 컨테이너 제한의 약 90%로 설정하세요.",
+		RecGOMAXPROCSAboveCPULimit: "GOMAXPROCS가 컨테이너 CPU 제한보다 커서 GC 워커와 병렬 마킹이 CPU 할당량을 소진해 프로세스가 스로틀링되고 일시 정지가 길어집니다. GOMAXPROCS를 CPU 제한에 맞추세요 (Go 1.25 이상은 자동으로 조정됩니다).",
+		RecNonGoMemoryGrowth:       "Go가 관리하지 않는 메모리(cgo, mmap, Go 외부 스레드)로 인해 프로세스 RSS가 증가하고 있습니다. GC 튜닝으로는 회수할 수 없으니 해제되지 않는 네이티브 할당을 찾아보세요.",
+		RecPoolSmallObjects:        "할당량의 대부분이 작은 객체(32 KB 이하)입니다. 자주 할당되는 타입의 단명 객체는 sync.Pool로 재사용하여 할당률을 줄이세요.",
+		RecReuseLargeBuffers:       "할당량의 대부분이 P별 할당 캐시를 거치지 않는 큰 객체(32 KB 초과)입니다. 미리 크기를 지정한 슬라이스나 풀링된 bytes.Buffer 등으로 요청 간 버퍼를 재사용하세요.",
+	},
+}
+
+// reverse maps English source strings back to their keys so that
+// recommendations stored as plain text can be translated at report time.
+var reverse = func() map[string]Key {
+	m := make(map[string]Key, len(catalog[English]))
+	for k, v := range catalog[English] {
+		m[v] = k
+	}
+	return m
+}()
+
+// T returns the message for key in the given language.
+// Falls back to English when the language or key is not available.
+func T(lang Language, key Key) string {
+	if msgs, ok := catalog[lang]; ok {
+		if msg, ok := msgs[key]; ok {
+			return msg
+		}
+	}
+	return catalog[English][key]
+}
+
+// Translate translates an English catalog string into the given language.
+// A trailing parenthesized detail such as " (R²=0.93)" is kept as-is while the
+// sentence before it is translated. Strings that are not part of the catalog
+// are returned unchanged.
+func Translate(lang Language, english string) string {
+	if lang == English || lang == "" {
+		return english
+	}
+	if key, ok := reverse[english]; ok {
+		return T(lang, key)
+	}
+
+	if strings.HasSuffix(english, ")") {
+		if i := strings.LastIndex(english, " ("); i > 0 {
+			if key, ok := reverse[english[:i]]; ok {
+				return T(lang, key) + english[i:]
+			}
+		}
+	}
+
+	return english
+}
+
+// IsSupported reports whether lang has a catalog
+func IsSupported(lang Language) bool {
+	_, ok := catalog[lang]
+	return ok
+}
+
+// Languages returns all supported languages
+func Languages() []Language {
+	return []Language{English, Korean}
+}
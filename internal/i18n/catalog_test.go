@@ -0,0 +1,56 @@
+package i18n
+
+import "testing"
+
+func TestCatalog_Complete(t *testing.T) {
+	for _, lang := range Languages() {
+		for key := range catalog[English] {
+			if _, ok := catalog[lang][key]; !ok {
+				t.Errorf("language %s is missing key %s", lang, key)
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T(English, SectionRecommendation); got != "Recommendations" {
+		t.Errorf("T(en) = %q, want %q", got, "Recommendations")
+	}
+	if got := T(Korean, SectionRecommendation); got != "권장 사항" {
+		t.Errorf("T(ko) = %q, want %q", got, "권장 사항")
+	}
+	// Unsupported language falls back to English
+	if got := T("fr", SectionRecommendation); got != "Recommendations" {
+		t.Errorf("T(fr) = %q, want English fallback", got)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	english := T(English, RecHighGCFrequency)
+
+	if got := Translate(Korean, english); got != T(Korean, RecHighGCFrequency) {
+		t.Errorf("Translate(ko) = %q, want Korean catalog entry", got)
+	}
+	if got := Translate(English, english); got != english {
+		t.Errorf("Translate(en) should return input unchanged, got %q", got)
+	}
+
+	withDetail := english + " (R²=0.93)"
+	if got := Translate(Korean, withDetail); got != T(Korean, RecHighGCFrequency)+" (R²=0.93)" {
+		t.Errorf("Translate should keep trailing detail, got %q", got)
+	}
+
+	custom := "Some custom recommendation"
+	if got := Translate(Korean, custom); got != custom {
+		t.Errorf("Translate should pass through unknown strings, got %q", got)
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	if !IsSupported(English) || !IsSupported(Korean) {
+		t.Error("English and Korean should be supported")
+	}
+	if IsSupported("xx") {
+		t.Error("Unknown language should not be supported")
+	}
+}
@@ -0,0 +1,47 @@
+// Package ingest guards the reading of untrusted capture files, so corrupted
+// or hostile input can't exhaust memory or block past a deadline.
+package ingest
+
+import (
+	"context"
+	"io"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// reader is an io.Reader bounded by a size limit and a context
+type reader struct {
+	ctx       context.Context
+	r         io.Reader
+	remaining int64
+}
+
+// NewReader returns a reader over r that fails with types.ErrInputTooLarge
+// once more than limit bytes are read, and with the context's error once ctx
+// is done. The context is checked before each read, so a source that blocks
+// inside Read is only abandoned when that read returns.
+func NewReader(ctx context.Context, r io.Reader, limit int64) io.Reader {
+	return &reader{ctx: ctx, r: r, remaining: limit}
+}
+
+// Read implements io.Reader
+func (l *reader) Read(p []byte) (int, error) {
+	if err := l.ctx.Err(); err != nil {
+		return 0, err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	// Read one byte past the limit to tell input that ends exactly at the
+	// limit from input that exceeds it
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	if int64(n) > l.remaining {
+		n = int(l.remaining)
+		err = types.ErrInputTooLarge
+	}
+	l.remaining -= int64(n)
+	return n, err
+}
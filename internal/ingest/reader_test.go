@@ -0,0 +1,48 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestNewReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		limit   int64
+		want    string
+		wantErr error
+	}{
+		{"under limit", "hello", 10, "hello", nil},
+		{"at limit", "hello", 5, "hello", nil},
+		{"over limit", "hello world", 5, "hello", types.ErrInputTooLarge},
+		{"zero limit", "x", 0, "", types.ErrInputTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(NewReader(context.Background(), strings.NewReader(tt.input), tt.limit))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("read %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewReader_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := io.ReadAll(NewReader(ctx, strings.NewReader("hello"), 10))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,257 @@
+// Package promimport reconstructs an approximate GC metrics series from the
+// go_gc_* and go_memstats_* series a Prometheus Go collector exports, read
+// through the Prometheus HTTP API range query endpoint, so incidents can be
+// analyzed after the fact from existing monitoring data.
+package promimport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/ingest"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// DefaultTimeout bounds each range query
+const DefaultTimeout = 30 * time.Second
+
+// rangeQueryPath is the range query endpoint, relative to the server's base URL
+const rangeQueryPath = "/api/v1/query_range"
+
+// seriesSelector matches the Go collector's GC and memory series
+const seriesSelector = `__name__=~"go_gc_.*|go_memstats_.*"`
+
+// setters map series names to the sample field they fill
+var setters = map[string]func(m *types.GCMetrics, v float64){
+	"go_gc_duration_seconds_count":        func(m *types.GCMetrics, v float64) { m.NumGC = uint32(v) },
+	"go_gc_duration_seconds_sum":          func(m *types.GCMetrics, v float64) { m.PauseTotalNs = uint64(v * 1e9) },
+	"go_gc_cycles_forced_gc_cycles_total": func(m *types.GCMetrics, v float64) { m.NumForcedGC = uint32(v) },
+	"go_gc_heap_live_bytes":               func(m *types.GCMetrics, v float64) { m.HeapLive = uint64(v) },
+	"go_gc_gogc_percent":                  setGOGC,
+	"go_gc_gomemlimit_bytes":              setGOMemLimit,
+	"go_memstats_alloc_bytes":             func(m *types.GCMetrics, v float64) { m.Alloc = uint64(v) },
+	"go_memstats_alloc_bytes_total":       func(m *types.GCMetrics, v float64) { m.TotalAlloc = uint64(v) },
+	"go_memstats_sys_bytes":               func(m *types.GCMetrics, v float64) { m.Sys = uint64(v) },
+	"go_memstats_lookups_total":           func(m *types.GCMetrics, v float64) { m.Lookups = uint64(v) },
+	"go_memstats_mallocs_total":           func(m *types.GCMetrics, v float64) { m.Mallocs = uint64(v) },
+	"go_memstats_frees_total":             func(m *types.GCMetrics, v float64) { m.Frees = uint64(v) },
+	"go_memstats_heap_alloc_bytes":        func(m *types.GCMetrics, v float64) { m.HeapAlloc = uint64(v) },
+	"go_memstats_heap_sys_bytes":          func(m *types.GCMetrics, v float64) { m.HeapSys = uint64(v) },
+	"go_memstats_heap_idle_bytes":         func(m *types.GCMetrics, v float64) { m.HeapIdle = uint64(v) },
+	"go_memstats_heap_inuse_bytes":        func(m *types.GCMetrics, v float64) { m.HeapInuse = uint64(v) },
+	"go_memstats_heap_released_bytes":     func(m *types.GCMetrics, v float64) { m.HeapReleased = uint64(v) },
+	"go_memstats_heap_objects":            func(m *types.GCMetrics, v float64) { m.HeapObjects = uint64(v) },
+	"go_memstats_stack_inuse_bytes":       func(m *types.GCMetrics, v float64) { m.StackInuse = uint64(v) },
+	"go_memstats_stack_sys_bytes":         func(m *types.GCMetrics, v float64) { m.StackSys = uint64(v) },
+	"go_memstats_mspan_sys_bytes":         func(m *types.GCMetrics, v float64) { m.MSpanSys = uint64(v) },
+	"go_memstats_mcache_sys_bytes":        func(m *types.GCMetrics, v float64) { m.MCacheSys = uint64(v) },
+	"go_memstats_buck_hash_sys_bytes":     func(m *types.GCMetrics, v float64) { m.BuckHashSys = uint64(v) },
+	"go_memstats_gc_sys_bytes":            func(m *types.GCMetrics, v float64) { m.GCSys = uint64(v) },
+	"go_memstats_other_sys_bytes":         func(m *types.GCMetrics, v float64) { m.OtherSys = uint64(v) },
+	"go_memstats_next_gc_bytes":           func(m *types.GCMetrics, v float64) { m.NextGC = uint64(v) },
+	"go_memstats_gc_cpu_fraction":         func(m *types.GCMetrics, v float64) { m.GCCPUFraction = v },
+	"go_memstats_last_gc_time_seconds": func(m *types.GCMetrics, v float64) {
+		m.LastGC = time.Unix(0, int64(v*1e9))
+	},
+}
+
+// setGOGC fills GOGC. The collector exports GOGC=off as the unsigned
+// reading of -1.
+func setGOGC(m *types.GCMetrics, v float64) {
+	if v > math.MaxInt32 {
+		m.GOGC = -1
+		return
+	}
+	m.GOGC = int(v)
+}
+
+// setGOMemLimit fills GOMemLimit, clamping the float rounding of an unset
+// limit back to math.MaxInt64
+func setGOMemLimit(m *types.GCMetrics, v float64) {
+	if v >= math.MaxInt64 {
+		m.GOMemLimit = math.MaxInt64
+		return
+	}
+	m.GOMemLimit = uint64(v)
+}
+
+// quantileSetters map the quantile label of go_gc_duration_seconds to the
+// pause quantile it fills
+var quantileSetters = map[string]func(q *types.PauseQuantiles, d time.Duration){
+	"0":    func(q *types.PauseQuantiles, d time.Duration) { q.Min = d },
+	"0.25": func(q *types.PauseQuantiles, d time.Duration) { q.P25 = d },
+	"0.5":  func(q *types.PauseQuantiles, d time.Duration) { q.P50 = d },
+	"0.75": func(q *types.PauseQuantiles, d time.Duration) { q.P75 = d },
+	"1":    func(q *types.PauseQuantiles, d time.Duration) { q.Max = d },
+}
+
+// Client runs range queries against a Prometheus server
+type Client struct {
+	url  string
+	http *http.Client
+}
+
+// NewClient creates a client for the Prometheus server at baseURL, e.g.
+// "http://prometheus:9090"
+func NewClient(baseURL string) *Client {
+	return &Client{
+		url:  strings.TrimSuffix(baseURL, "/") + rangeQueryPath,
+		http: &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+// Import queries the Go runtime series of the target picked out by
+// matchers, e.g. `job="api",instance="10.0.0.7:8080"`, from start to end at
+// the given step, and reconstructs a sample per step
+func (c *Client) Import(ctx context.Context, matchers string, start, end time.Time, step time.Duration) ([]*types.GCMetrics, error) {
+	if !end.After(start) {
+		return nil, types.ErrInvalidDuration
+	}
+	if step <= 0 {
+		return nil, types.ErrInvalidInterval
+	}
+
+	selector := seriesSelector
+	if matchers = strings.TrimSpace(matchers); matchers != "" {
+		selector += "," + matchers
+	}
+	query := url.Values{
+		"query": {"{" + selector + "}"},
+		"start": {formatTime(start)},
+		"end":   {formatTime(end)},
+		"step":  {strconv.FormatFloat(step.Seconds(), 'f', -1, 64)},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", types.ErrRemoteUnavailable, err)
+	}
+	defer resp.Body.Close()
+	// Prometheus reports query errors as JSON with a 4xx status
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return nil, fmt.Errorf("%w: %s returned %s", types.ErrRemoteUnavailable, c.url, resp.Status)
+	}
+	return Decode(ctx, resp.Body)
+}
+
+// formatTime formats t as Unix seconds for the Prometheus API
+func formatTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/1e9, 'f', -1, 64)
+}
+
+// response is a Prometheus HTTP API response to a range query
+type response struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Values [][2]any          `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// Decode reconstructs samples from a range query response, bounded by ctx
+// and types.MaxBundleSize. All series must come from one process. Errors
+// wrap types.ErrInvalidPrometheusData.
+func Decode(ctx context.Context, r io.Reader) ([]*types.GCMetrics, error) {
+	var resp response
+	if err := json.NewDecoder(ingest.NewReader(ctx, r, types.MaxBundleSize)).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("%w: %w", types.ErrInvalidPrometheusData, err)
+	}
+	if resp.Status != "success" {
+		return nil, fmt.Errorf("%w: query failed: %s", types.ErrInvalidPrometheusData, resp.Error)
+	}
+	if resp.Data.ResultType != "matrix" {
+		return nil, fmt.Errorf("%w: result type %q, want a range query matrix", types.ErrInvalidPrometheusData, resp.Data.ResultType)
+	}
+
+	samples := make(map[int64]*types.GCMetrics)
+	var target map[string]string
+	for _, series := range resp.Data.Result {
+		name := series.Metric["__name__"]
+		set, quantile := setters[name], series.Metric["quantile"]
+		if set == nil && (name != "go_gc_duration_seconds" || quantileSetters[quantile] == nil) {
+			continue
+		}
+
+		labels := maps.Clone(series.Metric)
+		delete(labels, "__name__")
+		delete(labels, "quantile")
+		if target == nil {
+			target = labels
+		} else if !maps.Equal(target, labels) {
+			return nil, fmt.Errorf("%w: series from more than one process (%v and %v); narrow the matchers",
+				types.ErrInvalidPrometheusData, target, labels)
+		}
+
+		for _, pair := range series.Values {
+			ts, v, err := parseValue(pair)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s: %w", types.ErrInvalidPrometheusData, name, err)
+			}
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			m := samples[ts.UnixMilli()]
+			if m == nil {
+				m = &types.GCMetrics{Timestamp: ts}
+				samples[ts.UnixMilli()] = m
+			}
+			if set != nil {
+				set(m, v)
+				continue
+			}
+			if m.PauseQuantiles == nil {
+				m.PauseQuantiles = &types.PauseQuantiles{}
+			}
+			quantileSetters[quantile](m.PauseQuantiles, time.Duration(v*1e9))
+		}
+	}
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("%w: no Go runtime series", types.ErrInvalidPrometheusData)
+	}
+
+	metrics := slices.SortedFunc(maps.Values(samples), func(a, b *types.GCMetrics) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+	for _, m := range metrics {
+		if q := m.PauseQuantiles; q != nil {
+			q.NumGC = int64(m.NumGC)
+			q.PauseTotal = time.Duration(m.PauseTotalNs)
+		}
+	}
+	return metrics, nil
+}
+
+// parseValue parses a [timestamp, "value"] pair of a range query result
+func parseValue(pair [2]any) (time.Time, float64, error) {
+	ts, ok := pair[0].(float64)
+	if !ok {
+		return time.Time{}, 0, fmt.Errorf("invalid timestamp %v", pair[0])
+	}
+	s, ok := pair[1].(string)
+	if !ok {
+		return time.Time{}, 0, fmt.Errorf("invalid value %v", pair[1])
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	// Timestamps have millisecond precision
+	return time.UnixMilli(int64(math.Round(ts * 1000))), v, nil
+}
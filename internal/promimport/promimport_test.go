@@ -0,0 +1,124 @@
+package promimport
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// rangeResponse is a range query result over two steps for one target
+const rangeResponse = `{
+  "status": "success",
+  "data": {
+    "resultType": "matrix",
+    "result": [
+      {"metric": {"__name__": "go_gc_duration_seconds_count", "job": "api", "instance": "a:8080"},
+       "values": [[1700000000, "100"], [1700000015, "112"]]},
+      {"metric": {"__name__": "go_gc_duration_seconds_sum", "job": "api", "instance": "a:8080"},
+       "values": [[1700000000, "0.05"], [1700000015, "0.062"]]},
+      {"metric": {"__name__": "go_gc_duration_seconds", "quantile": "1", "job": "api", "instance": "a:8080"},
+       "values": [[1700000000, "0.002"], [1700000015, "0.003"]]},
+      {"metric": {"__name__": "go_memstats_heap_alloc_bytes", "job": "api", "instance": "a:8080"},
+       "values": [[1700000000, "1048576"], [1700000015, "NaN"]]},
+      {"metric": {"__name__": "go_memstats_alloc_bytes_total", "job": "api", "instance": "a:8080"},
+       "values": [[1700000000, "5e6"], [1700000015, "8e6"]]},
+      {"metric": {"__name__": "go_gc_gogc_percent", "job": "api", "instance": "a:8080"},
+       "values": [[1700000000, "100"], [1700000015, "18446744073709551615"]]},
+      {"metric": {"__name__": "go_gc_gomemlimit_bytes", "job": "api", "instance": "a:8080"},
+       "values": [[1700000000, "9223372036854775807"], [1700000015, "536870912"]]},
+      {"metric": {"__name__": "go_goroutines", "job": "api", "instance": "a:8080"},
+       "values": [[1700000000, "42"]]}
+    ]
+  }
+}`
+
+func TestDecode(t *testing.T) {
+	metrics, err := Decode(context.Background(), strings.NewReader(rangeResponse))
+	if err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+	if len(metrics) != 2 {
+		t.Fatalf("Decode() = %d samples, want 2", len(metrics))
+	}
+
+	first, last := metrics[0], metrics[1]
+	if !first.Timestamp.Equal(time.Unix(1700000000, 0)) || last.Timestamp.Sub(first.Timestamp) != 15*time.Second {
+		t.Errorf("Timestamps = %v, %v", first.Timestamp, last.Timestamp)
+	}
+	if last.NumGC != 112 || last.PauseTotalNs != 62_000_000 || last.TotalAlloc != 8_000_000 {
+		t.Errorf("Last sample = %+v", last)
+	}
+	if first.HeapAlloc != 1<<20 || last.HeapAlloc != 0 {
+		t.Errorf("HeapAlloc = %d, %d; NaN values should be skipped", first.HeapAlloc, last.HeapAlloc)
+	}
+	if first.GOGC != 100 || last.GOGC != -1 {
+		t.Errorf("GOGC = %d, %d, want 100 and -1 for off", first.GOGC, last.GOGC)
+	}
+	if first.GOMemLimit != math.MaxInt64 || last.GOMemLimit != 512<<20 {
+		t.Errorf("GOMemLimit = %d, %d", first.GOMemLimit, last.GOMemLimit)
+	}
+	if q := last.PauseQuantiles; q == nil || q.Max != 3*time.Millisecond || q.NumGC != 112 {
+		t.Errorf("PauseQuantiles = %+v", q)
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	inputs := []string{
+		"not json",
+		`{"status": "error", "error": "parse error"}`,
+		`{"status": "success", "data": {"resultType": "vector", "result": []}}`,
+		`{"status": "success", "data": {"resultType": "matrix", "result": []}}`,
+		`{"status": "success", "data": {"resultType": "matrix", "result": [
+			{"metric": {"__name__": "go_memstats_sys_bytes", "instance": "a"}, "values": [[1, "1"]]},
+			{"metric": {"__name__": "go_memstats_sys_bytes", "instance": "b"}, "values": [[1, "2"]]}]}}`,
+		`{"status": "success", "data": {"resultType": "matrix", "result": [
+			{"metric": {"__name__": "go_memstats_sys_bytes"}, "values": [[1, 2]]}]}}`,
+	}
+	for _, input := range inputs {
+		if _, err := Decode(context.Background(), strings.NewReader(input)); !errors.Is(err, types.ErrInvalidPrometheusData) {
+			t.Errorf("Decode(%q) error = %v, want ErrInvalidPrometheusData", input, err)
+		}
+	}
+}
+
+func TestClient_Import(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != rangeQueryPath {
+			http.NotFound(w, r)
+			return
+		}
+		query = r.URL.Query().Get("query")
+		if r.URL.Query().Get("step") != "15" {
+			t.Errorf("step = %q, want 15", r.URL.Query().Get("step"))
+		}
+		_, _ = w.Write([]byte(rangeResponse))
+	}))
+	defer srv.Close()
+
+	start := time.Unix(1700000000, 0)
+	metrics, err := NewClient(srv.URL+"/").Import(context.Background(), `job="api"`, start, start.Add(15*time.Second), 15*time.Second)
+	if err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+	if len(metrics) != 2 {
+		t.Errorf("Import() = %d samples, want 2", len(metrics))
+	}
+	if want := `{` + seriesSelector + `,job="api"}`; query != want {
+		t.Errorf("query = %q, want %q", query, want)
+	}
+
+	if _, err := NewClient(srv.URL).Import(context.Background(), "", start, start, time.Second); !errors.Is(err, types.ErrInvalidDuration) {
+		t.Errorf("Import() with an empty range error = %v, want ErrInvalidDuration", err)
+	}
+	if _, err := NewClient(srv.URL).Import(context.Background(), "", start, start.Add(time.Minute), 0); !errors.Is(err, types.ErrInvalidInterval) {
+		t.Errorf("Import() with a zero step error = %v, want ErrInvalidInterval", err)
+	}
+}
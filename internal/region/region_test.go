@@ -0,0 +1,129 @@
+package region
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+var sink [][]byte
+
+func allocate(n, size int) {
+	for i := 0; i < n; i++ {
+		sink = append(sink, make([]byte, size))
+	}
+	sink = nil
+}
+
+func TestTracker_BeginEnd(t *testing.T) {
+	tracker := NewTracker()
+
+	for i := 0; i < 3; i++ {
+		span := tracker.Begin("hot")
+		allocate(100, 1024)
+		span.End()
+	}
+
+	stats := tracker.Stats()
+	if len(stats) != 1 {
+		t.Fatalf("Stats() returned %d regions, want 1", len(stats))
+	}
+
+	hot := stats[0]
+	if hot.Name != "hot" || hot.Calls != 3 {
+		t.Errorf("Region = %+v, want name hot with 3 calls", hot)
+	}
+	if hot.AllocBytes < 3*100*1024 {
+		t.Errorf("AllocBytes = %d, want at least %d", hot.AllocBytes, 3*100*1024)
+	}
+	if hot.AllocObjects < 300 {
+		t.Errorf("AllocObjects = %d, want at least 300", hot.AllocObjects)
+	}
+	if hot.Duration <= 0 || hot.AllocRate <= 0 {
+		t.Errorf("Duration = %v, AllocRate = %v, want both positive", hot.Duration, hot.AllocRate)
+	}
+	if hot.BytesPerCall() != hot.AllocBytes/3 {
+		t.Errorf("BytesPerCall() = %d, want %d", hot.BytesPerCall(), hot.AllocBytes/3)
+	}
+}
+
+func TestTracker_StatsOrdering(t *testing.T) {
+	tracker := NewTracker()
+	tracker.Record("small", &types.GCDelta{Duration: time.Millisecond, AllocBytes: 1024, AllocObjects: 1})
+	tracker.Record("large", &types.GCDelta{Duration: time.Millisecond, AllocBytes: 1024 * 1024, AllocObjects: 1, GCCount: 1})
+
+	stats := tracker.Stats()
+	if len(stats) != 2 || stats[0].Name != "large" || stats[1].Name != "small" {
+		t.Errorf("Stats() should order regions by allocated bytes, got %+v", stats)
+	}
+}
+
+func TestTracker_Record(t *testing.T) {
+	tracker := NewTracker()
+	for i := 0; i < 2; i++ {
+		tracker.Record("/pkg.Service/Get", &types.GCDelta{
+			Duration:   10 * time.Millisecond,
+			GCCount:    1,
+			PauseTime:  200 * time.Microsecond,
+			AllocBytes: 4096,
+		})
+	}
+
+	stats := tracker.Stats()
+	if len(stats) != 1 {
+		t.Fatalf("Stats() returned %d regions, want 1", len(stats))
+	}
+	get := stats[0]
+	if get.Calls != 2 || get.GCCount != 2 || get.PauseTime != 400*time.Microsecond || get.AllocBytes != 8192 {
+		t.Errorf("Region = %+v, want 2 calls, 2 GCs, 400µs paused and 8192 bytes", get)
+	}
+	if get.Duration != 20*time.Millisecond || get.AllocRate != 8192/0.02 {
+		t.Errorf("Duration = %v, AllocRate = %v", get.Duration, get.AllocRate)
+	}
+}
+
+func TestSpan_EndTwice(t *testing.T) {
+	tracker := NewTracker()
+	span := tracker.Begin("once")
+	span.End()
+	span.End()
+
+	if stats := tracker.Stats(); len(stats) != 1 || stats[0].Calls != 1 {
+		t.Errorf("Ending a span twice should record it once, got %+v", stats)
+	}
+
+	var nilSpan *Span
+	nilSpan.End() // must not panic
+}
+
+func TestTracker_Reset(t *testing.T) {
+	tracker := NewTracker()
+	tracker.Begin("r").End()
+	tracker.Reset()
+
+	if stats := tracker.Stats(); len(stats) != 0 {
+		t.Errorf("Stats() after Reset() = %+v, want empty", stats)
+	}
+}
+
+func TestTracker_Concurrent(t *testing.T) {
+	tracker := NewTracker()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				tracker.Begin("concurrent").End()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if stats := tracker.Stats(); len(stats) != 1 || stats[0].Calls != 80 {
+		t.Errorf("Stats() = %+v, want one region with 80 calls", stats)
+	}
+}
@@ -0,0 +1,98 @@
+package region
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// RequestTracker records the GC impact of individual requests and computes
+// distributions over the most recent ones. It is safe for concurrent use.
+type RequestTracker struct {
+	mu     sync.Mutex
+	pauses []time.Duration // ring buffers of the recent requests
+	allocs []uint64
+	next   int
+	total  uint64
+}
+
+// NewRequestTracker creates a tracker keeping the last window requests
+// (default: types.DefaultRequestWindow)
+func NewRequestTracker(window int) *RequestTracker {
+	if window <= 0 {
+		window = types.DefaultRequestWindow
+	}
+	return &RequestTracker{
+		pauses: make([]time.Duration, 0, window),
+		allocs: make([]uint64, 0, window),
+	}
+}
+
+// Record adds one request that overlapped pause of stop-the-world GC time
+// and allocated allocBytes, typically measured with a GCSnapshot delta
+func (t *RequestTracker) Record(pause time.Duration, allocBytes uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.total++
+	if len(t.pauses) < cap(t.pauses) {
+		t.pauses = append(t.pauses, pause)
+		t.allocs = append(t.allocs, allocBytes)
+		return
+	}
+	t.pauses[t.next] = pause
+	t.allocs[t.next] = allocBytes
+	t.next = (t.next + 1) % len(t.pauses)
+}
+
+// Impact returns the distributions over the recent requests, or nil before
+// the first request
+func (t *RequestTracker) Impact() *types.RequestImpact {
+	t.mu.Lock()
+	pauses := slices.Clone(t.pauses)
+	allocs := slices.Clone(t.allocs)
+	total := t.total
+	t.mu.Unlock()
+
+	if len(pauses) == 0 {
+		return nil
+	}
+	slices.Sort(pauses)
+	slices.Sort(allocs)
+
+	impact := &types.RequestImpact{
+		Requests: total,
+		Window:   len(pauses),
+		PauseP50: quantile(pauses, 0.50),
+		PauseP95: quantile(pauses, 0.95),
+		PauseP99: quantile(pauses, 0.99),
+		PauseMax: pauses[len(pauses)-1],
+		AllocP50: quantile(allocs, 0.50),
+		AllocP95: quantile(allocs, 0.95),
+		AllocP99: quantile(allocs, 0.99),
+		AllocMax: allocs[len(allocs)-1],
+	}
+	// Requests that overlapped no GC cycle have no pause and sort first
+	unaffected, _ := slices.BinarySearch(pauses, 1)
+	impact.GCAffected = len(pauses) - unaffected
+	return impact
+}
+
+// Reset discards all recorded requests
+func (t *RequestTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pauses = t.pauses[:0]
+	t.allocs = t.allocs[:0]
+	t.next = 0
+	t.total = 0
+}
+
+// quantile returns the nearest-rank q-quantile of sorted values
+func quantile[T any](sorted []T, q float64) T {
+	i := int(math.Ceil(q*float64(len(sorted)))) - 1
+	return sorted[min(max(i, 0), len(sorted)-1)]
+}
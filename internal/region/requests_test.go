@@ -0,0 +1,74 @@
+package region
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRequestTracker_Impact(t *testing.T) {
+	tracker := NewRequestTracker(0)
+	if tracker.Impact() != nil {
+		t.Error("Impact() before any request should be nil")
+	}
+
+	for i := 1; i <= 100; i++ {
+		var pause time.Duration
+		if i > 90 {
+			pause = time.Duration(i) * time.Microsecond
+		}
+		tracker.Record(pause, uint64(i)*1024)
+	}
+
+	impact := tracker.Impact()
+	if impact.Requests != 100 || impact.Window != 100 || impact.GCAffected != 10 {
+		t.Errorf("Impact = %+v, want 100 requests with 10 affected by GC", impact)
+	}
+	if impact.PauseP50 != 0 || impact.PauseP95 != 95*time.Microsecond || impact.PauseMax != 100*time.Microsecond {
+		t.Errorf("Pause distribution = %v/%v/%v", impact.PauseP50, impact.PauseP95, impact.PauseMax)
+	}
+	if impact.AllocP50 != 50*1024 || impact.AllocP99 != 99*1024 || impact.AllocMax != 100*1024 {
+		t.Errorf("Alloc distribution = %d/%d/%d", impact.AllocP50, impact.AllocP99, impact.AllocMax)
+	}
+}
+
+func TestRequestTracker_Window(t *testing.T) {
+	tracker := NewRequestTracker(10)
+	for i := 1; i <= 25; i++ {
+		tracker.Record(0, uint64(i))
+	}
+
+	impact := tracker.Impact()
+	if impact.Requests != 25 || impact.Window != 10 {
+		t.Errorf("Impact = %+v, want 25 requests over a window of 10", impact)
+	}
+	// Only requests 16-25 remain
+	if impact.AllocP50 != 20 || impact.AllocMax != 25 {
+		t.Errorf("Alloc distribution = %d/%d, want 20/25", impact.AllocP50, impact.AllocMax)
+	}
+
+	tracker.Reset()
+	if tracker.Impact() != nil {
+		t.Error("Impact() after Reset should be nil")
+	}
+}
+
+func TestRequestTracker_Concurrent(t *testing.T) {
+	tracker := NewRequestTracker(100)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				tracker.Record(time.Microsecond, 1)
+				_ = tracker.Impact()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if impact := tracker.Impact(); impact.Requests != 800 || impact.Window != 100 {
+		t.Errorf("Impact = %+v, want 800 requests over a window of 100", impact)
+	}
+}
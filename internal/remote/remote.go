@@ -0,0 +1,174 @@
+// Package remote samples the GC metrics of another Go process over HTTP,
+// from its expvar (/debug/vars) or pprof (/debug/pprof/heap?debug=1) endpoint.
+package remote
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// Format selects the endpoint MemStats are read from
+type Format string
+
+// Supported endpoint formats
+const (
+	FormatExpvar Format = "expvar" // the "memstats" variable of /debug/vars
+	FormatPprof  Format = "pprof"  // the MemStats trailer of /debug/pprof/heap?debug=1
+)
+
+// Endpoint paths, relative to the target's base URL
+const (
+	expvarPath = "/debug/vars"
+	pprofPath  = "/debug/pprof/heap?debug=1"
+)
+
+const (
+	// DefaultTimeout bounds each request to the target
+	DefaultTimeout = 5 * time.Second
+
+	// maxResponseSize bounds the response body read from the target; heap
+	// profiles of large programs can be big, MemStats never are
+	maxResponseSize = 64 << 20
+)
+
+// Client reads MemStats from a remote process
+type Client struct {
+	url    string
+	format Format
+	http   *http.Client
+}
+
+// NewClient creates a client for the process serving debug endpoints at
+// target, e.g. "http://localhost:6060". An empty format means FormatExpvar.
+func NewClient(target string, format Format) (*Client, error) {
+	var path string
+	switch format {
+	case FormatExpvar, "":
+		format, path = FormatExpvar, expvarPath
+	case FormatPprof:
+		path = pprofPath
+	default:
+		return nil, fmt.Errorf("%w: %q", types.ErrUnknownRemoteFormat, format)
+	}
+
+	return &Client{
+		url:    strings.TrimSuffix(target, "/") + path,
+		format: format,
+		http:   &http.Client{Timeout: DefaultTimeout},
+	}, nil
+}
+
+// URL returns the endpoint the client reads from
+func (c *Client) URL() string {
+	return c.url
+}
+
+// Sample fetches the target's current MemStats as a GCMetrics sample
+func (c *Client) Sample(ctx context.Context) (*types.GCMetrics, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", types.ErrRemoteUnavailable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s returned %s", types.ErrRemoteUnavailable, c.url, resp.Status)
+	}
+
+	body := io.LimitReader(resp.Body, maxResponseSize)
+	var ms *runtime.MemStats
+	switch c.format {
+	case FormatPprof:
+		ms, err = parsePprofMemStats(body)
+	default:
+		ms, err = parseExpvarMemStats(body)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", c.url, err)
+	}
+	return types.NewGCMetricsFromMemStats(ms, time.Now()), nil
+}
+
+// parseExpvarMemStats decodes the "memstats" variable of an expvar response
+func parseExpvarMemStats(r io.Reader) (*runtime.MemStats, error) {
+	var vars struct {
+		MemStats *runtime.MemStats `json:"memstats"`
+	}
+	if err := json.NewDecoder(r).Decode(&vars); err != nil {
+		return nil, fmt.Errorf("%w: %w", types.ErrInvalidRemoteData, err)
+	}
+	if vars.MemStats == nil {
+		return nil, fmt.Errorf("%w: no memstats variable", types.ErrInvalidRemoteData)
+	}
+	return vars.MemStats, nil
+}
+
+// parsePprofMemStats decodes the "# runtime.MemStats" trailer of a debug=1
+// heap profile, whose lines read "# Name = value" with arrays as "[a b c]"
+func parsePprofMemStats(r io.Reader) (*runtime.MemStats, error) {
+	var fields bytes.Buffer
+	found := false
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "# runtime.MemStats" {
+			found = true
+			continue
+		}
+		if !found {
+			continue
+		}
+		name, value, ok := strings.Cut(strings.TrimPrefix(line, "# "), " = ")
+		if !ok || !strings.HasPrefix(line, "# ") {
+			continue
+		}
+		if inuse, sys, ok := strings.Cut(value, " / "); ok {
+			// "# Stack = inuse / sys", likewise for MSpan and MCache
+			writeField(&fields, name+"Inuse", inuse)
+			writeField(&fields, name+"Sys", sys)
+			continue
+		}
+		if strings.HasPrefix(value, "[") {
+			value = "[" + strings.Join(strings.Fields(strings.Trim(value, "[]")), ",") + "]"
+		}
+		writeField(&fields, name, value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: no runtime.MemStats section", types.ErrInvalidRemoteData)
+	}
+
+	var ms runtime.MemStats
+	if err := json.Unmarshal([]byte("{"+fields.String()+"}"), &ms); err != nil {
+		return nil, err
+	}
+	return &ms, nil
+}
+
+// writeField appends a JSON object member to b, skipping malformed values
+func writeField(b *bytes.Buffer, name, value string) {
+	if !json.Valid([]byte(value)) {
+		return
+	}
+	if b.Len() > 0 {
+		b.WriteByte(',')
+	}
+	fmt.Fprintf(b, "%q:%s", name, value)
+}
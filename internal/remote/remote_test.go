@@ -0,0 +1,108 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"net/http/pprof"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// newTarget serves this process's debug endpoints like a monitored program
+func newTarget(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.Handle(expvarPath, expvar.Handler())
+	mux.Handle("/debug/pprof/heap", pprof.Handler("heap"))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient_Sample(t *testing.T) {
+	srv := newTarget(t)
+	runtime.GC()
+
+	for _, format := range []Format{FormatExpvar, FormatPprof} {
+		t.Run(string(format), func(t *testing.T) {
+			c, err := NewClient(srv.URL+"/", format)
+			if err != nil {
+				t.Fatalf("NewClient() error: %v", err)
+			}
+			m, err := c.Sample(context.Background())
+			if err != nil {
+				t.Fatalf("Sample() error: %v", err)
+			}
+			if m.NumGC == 0 || m.HeapAlloc == 0 || m.Sys == 0 || m.StackSys == 0 {
+				t.Errorf("Sample() = %+v, want populated MemStats", m)
+			}
+			if len(m.PauseNs) != 256 || m.PauseNs[(m.NumGC+255)%256] == 0 {
+				t.Error("Sample() should carry the pause history")
+			}
+		})
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	if _, err := NewClient("http://localhost", "statsd"); !errors.Is(err, types.ErrUnknownRemoteFormat) {
+		t.Errorf("Expected ErrUnknownRemoteFormat, got %v", err)
+	}
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	c, _ := NewClient(srv.URL, FormatExpvar)
+	if _, err := c.Sample(context.Background()); !errors.Is(err, types.ErrRemoteUnavailable) {
+		t.Errorf("Expected ErrRemoteUnavailable for a 404, got %v", err)
+	}
+
+	srv.Close()
+	if _, err := c.Sample(context.Background()); !errors.Is(err, types.ErrRemoteUnavailable) {
+		t.Errorf("Expected ErrRemoteUnavailable for a stopped target, got %v", err)
+	}
+}
+
+func TestParseMemStats_Malformed(t *testing.T) {
+	if _, err := parseExpvarMemStats(strings.NewReader(`{"cmdline":["app"]}`)); err == nil {
+		t.Error("Expected an error without a memstats variable")
+	}
+	if _, err := parsePprofMemStats(strings.NewReader("heap profile: 0: 0 [0: 0] @ heap/1048576\n")); err == nil {
+		t.Error("Expected an error without a MemStats section")
+	}
+
+	ms, err := parsePprofMemStats(strings.NewReader("# runtime.MemStats\n# HeapAlloc = 42\n# Bogus = ???\n# Stack = 1 / 2\n"))
+	if err != nil || ms.HeapAlloc != 42 || ms.StackInuse != 1 || ms.StackSys != 2 {
+		t.Errorf("parsePprofMemStats() = %+v, %v", ms, err)
+	}
+}
+
+func FuzzParsePprofMemStats(f *testing.F) {
+	f.Add("# runtime.MemStats\n# HeapAlloc = 42\n# Stack = 1 / 2\n# PauseNs = [1 2 3]\n")
+	f.Add("heap profile: 0: 0 [0: 0] @ heap/1048576\n")
+	f.Add("# runtime.MemStats\n# \"x\" = {}\n")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		// Any input must yield MemStats or an error, never a panic
+		ms, err := parsePprofMemStats(strings.NewReader(input))
+		if err == nil && ms == nil {
+			t.Fatal("parsePprofMemStats() returned neither MemStats nor an error")
+		}
+	})
+}
+
+func FuzzParseExpvarMemStats(f *testing.F) {
+	f.Add(`{"memstats": {"HeapAlloc": 42, "PauseNs": [1, 2]}}`)
+	f.Add(`{"cmdline": ["app"]}`)
+
+	f.Fuzz(func(t *testing.T, input string) {
+		ms, err := parseExpvarMemStats(strings.NewReader(input))
+		if err == nil && ms == nil {
+			t.Fatal("parseExpvarMemStats() returned neither MemStats nor an error")
+		}
+	})
+}
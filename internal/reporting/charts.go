@@ -0,0 +1,176 @@
+package reporting
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/i18n"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// chartWidth is the width in columns of sparklines, timelines and bars
+const chartWidth = 60
+
+// sparkLevels are the ASCII sparkline levels, lowest first
+const sparkLevels = "_.-:=+*#"
+
+// pauseBuckets are the pause chart's bucket upper bounds and labels
+var pauseBuckets = []struct {
+	max   time.Duration
+	label string
+}{
+	{time.Millisecond, "0-1ms"},
+	{5 * time.Millisecond, "1-5ms"},
+	{10 * time.Millisecond, "5-10ms"},
+	{50 * time.Millisecond, "10-50ms"},
+	{100 * time.Millisecond, "50-100ms"},
+	{1<<63 - 1, "100ms+"},
+}
+
+// writeCharts writes the heap trend sparkline, pause distribution bar chart
+// and GC timeline, each only when the reporter has the data for it
+func (r *Reporter) writeCharts(b *strings.Builder) {
+	if len(r.metrics) < 2 && len(r.events) == 0 {
+		return
+	}
+	r.writeSection(b, i18n.SectionCharts)
+
+	if len(r.metrics) >= 2 {
+		heap := make([]float64, len(r.metrics))
+		lo, hi := r.metrics[0].HeapAlloc, r.metrics[0].HeapAlloc
+		for i, m := range r.metrics {
+			heap[i] = float64(m.HeapAlloc)
+			lo, hi = min(lo, m.HeapAlloc), max(hi, m.HeapAlloc)
+		}
+		r.writeLabel(b, i18n.LabelHeapTrend)
+		b.WriteString(sparkline(heap, chartWidth))
+		b.WriteString(" (")
+		b.WriteString(r.formatBytes(lo))
+		b.WriteString(" - ")
+		b.WriteString(r.formatBytes(hi))
+		b.WriteString(")\n")
+	}
+
+	if len(r.events) > 0 {
+		b.WriteString(r.t(i18n.LabelPauseChart))
+		b.WriteString(":\n")
+		writePauseChart(b, r.events)
+	}
+
+	if len(r.metrics) >= 2 {
+		r.writeLabel(b, i18n.LabelGCTimeline)
+		b.WriteString(gcTimeline(r.metrics, chartWidth))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+}
+
+// sparkline renders values as at most width ASCII characters, averaging
+// adjacent values when there are more than width of them
+func sparkline(values []float64, width int) string {
+	if len(values) > width {
+		values = downsample(values, width)
+	}
+	if len(values) == 0 {
+		return ""
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+
+	out := make([]byte, len(values))
+	for i, v := range values {
+		level := 0
+		if hi > lo {
+			level = int((v - lo) / (hi - lo) * float64(len(sparkLevels)-1))
+		}
+		out[i] = sparkLevels[level]
+	}
+	return string(out)
+}
+
+// downsample averages values into width buckets
+func downsample(values []float64, width int) []float64 {
+	out := make([]float64, width)
+	for i := range out {
+		lo, hi := i*len(values)/width, (i+1)*len(values)/width
+		var sum float64
+		for _, v := range values[lo:hi] {
+			sum += v
+		}
+		out[i] = sum / float64(hi-lo)
+	}
+	return out
+}
+
+// pauseCounts counts events per pause bucket
+func pauseCounts(events []*types.GCEvent) []int {
+	counts := make([]int, len(pauseBuckets))
+	for _, e := range events {
+		for i, bucket := range pauseBuckets {
+			if e.Duration < bucket.max {
+				counts[i]++
+				break
+			}
+		}
+	}
+	return counts
+}
+
+// writePauseChart writes a bar per pause bucket, scaled to the fullest one
+func writePauseChart(b *strings.Builder, events []*types.GCEvent) {
+	counts := pauseCounts(events)
+	peak := 1
+	for _, c := range counts {
+		peak = max(peak, c)
+	}
+
+	for i, bucket := range pauseBuckets {
+		b.WriteString("  ")
+		b.WriteString(bucket.label)
+		b.WriteString(strings.Repeat(" ", 10-len(bucket.label)))
+		bar := counts[i] * chartWidth / peak
+		if bar == 0 && counts[i] > 0 {
+			bar = 1
+		}
+		b.WriteString(strings.Repeat("#", bar))
+		b.WriteByte(' ')
+		b.WriteString(strconv.Itoa(counts[i]))
+		b.WriteString("\n")
+	}
+}
+
+// gcTimeline renders the GC cycles completed over the samples' window in
+// width time slots, one sparkline level per slot, followed by the busiest
+// slot's count
+func gcTimeline(metrics []*types.GCMetrics, width int) string {
+	first, last := metrics[0], metrics[len(metrics)-1]
+	span := last.Timestamp.Sub(first.Timestamp)
+	if span <= 0 {
+		return ""
+	}
+
+	slots := make([]float64, width)
+	for i := 1; i < len(metrics); i++ {
+		if metrics[i].NumGC < metrics[i-1].NumGC {
+			continue
+		}
+		slot := int(metrics[i].Timestamp.Sub(first.Timestamp) * time.Duration(width-1) / span)
+		slots[min(max(slot, 0), width-1)] += float64(metrics[i].NumGC - metrics[i-1].NumGC)
+	}
+
+	var busiest float64
+	for _, s := range slots {
+		busiest = max(busiest, s)
+	}
+	line := []byte(sparkline(slots, width))
+	for i, s := range slots {
+		if s == 0 {
+			line[i] = ' '
+		}
+	}
+	return "|" + string(line) + "| max " + strconv.Itoa(int(busiest)) + "/slot"
+}
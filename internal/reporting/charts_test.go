@@ -0,0 +1,62 @@
+package reporting
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestGenerateTextReport_Charts(t *testing.T) {
+	metrics := createTestMetrics(120)
+	events := createTestEvents(10)
+	events[9].Duration = 20 * time.Millisecond
+
+	var buf bytes.Buffer
+	reporter := NewWithOptions(createTestAnalysis(), metrics, events, &Options{Charts: true})
+	if err := reporter.GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	output := buf.String()
+
+	for _, want := range []string{"=== Charts ===", "Heap Trend: _", "# (1.0 MB - 12.6 MB)\n", "GC Timeline: |", "  0-1ms     #", " 9\n", "  10-50ms   "} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Report missing %q:\n%s", want, output)
+		}
+	}
+
+	buf.Reset()
+	if err := New(createTestAnalysis(), metrics, events).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	if strings.Contains(buf.String(), "Charts") {
+		t.Error("Charts should be opt-in")
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]float64{0, 1, 2, 3, 4, 5, 6, 7}, 60); got != sparkLevels {
+		t.Errorf("sparkline() = %q, want %q", got, sparkLevels)
+	}
+	if got := sparkline(make([]float64, 500), 60); len(got) != 60 || strings.Trim(got, "_") != "" {
+		t.Errorf("sparkline() of a flat series = %q", got)
+	}
+}
+
+func TestGCTimeline(t *testing.T) {
+	now := time.Now()
+	metrics := []*types.GCMetrics{
+		{NumGC: 0, Timestamp: now},
+		{NumGC: 0, Timestamp: now.Add(time.Second)},
+		{NumGC: 8, Timestamp: now.Add(2 * time.Second)},
+	}
+	got := gcTimeline(metrics, 10)
+	if got != "|         #| max 8/slot" {
+		t.Errorf("gcTimeline() = %q", got)
+	}
+	if got := gcTimeline(metrics[:1], 10); got != "" {
+		t.Errorf("gcTimeline() over an empty window = %q", got)
+	}
+}
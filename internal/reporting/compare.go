@@ -0,0 +1,192 @@
+package reporting
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/i18n"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// comparisonMetric is a row of the comparison report
+type comparisonMetric struct {
+	label  i18n.Key
+	field  string // JSON name, for GCAnalysis.IsUnavailable
+	value  func(*types.GCAnalysis) float64
+	format func(*Reporter, float64) string
+}
+
+// comparisonMetrics are the rows of the comparison report, in order
+var comparisonMetrics = []comparisonMetric{
+	{i18n.LabelGCFrequency, "gc_frequency", func(a *types.GCAnalysis) float64 { return a.GCFrequency }, (*Reporter).formatFrequency},
+	{i18n.LabelAvgGCInterval, "avg_gc_interval", func(a *types.GCAnalysis) float64 { return float64(a.AvgGCInterval) }, (*Reporter).formatDuration},
+	{i18n.LabelGCOverhead, "gc_overhead", func(a *types.GCAnalysis) float64 { return a.GCOverhead }, (*Reporter).formatPercent},
+	{i18n.LabelAvgPause, "avg_pause_time", func(a *types.GCAnalysis) float64 { return float64(a.AvgPauseTime) }, (*Reporter).formatDuration},
+	{i18n.LabelMaxPause, "max_pause_time", func(a *types.GCAnalysis) float64 { return float64(a.MaxPauseTime) }, (*Reporter).formatDuration},
+	{i18n.LabelP95Pause, "p95_pause_time", func(a *types.GCAnalysis) float64 { return float64(a.P95PauseTime) }, (*Reporter).formatDuration},
+	{i18n.LabelP99Pause, "p99_pause_time", func(a *types.GCAnalysis) float64 { return float64(a.P99PauseTime) }, (*Reporter).formatDuration},
+	{i18n.LabelAvgHeap, "avg_heap_size", func(a *types.GCAnalysis) float64 { return float64(a.AvgHeapSize) }, (*Reporter).formatBytesValue},
+	{i18n.LabelMaxHeap, "max_heap_size", func(a *types.GCAnalysis) float64 { return float64(a.MaxHeapSize) }, (*Reporter).formatBytesValue},
+	{i18n.LabelAllocRate, "alloc_rate", func(a *types.GCAnalysis) float64 { return a.AllocRate }, (*Reporter).formatBytesRate},
+	{i18n.LabelMemoryEfficiency, "memory_efficiency", func(a *types.GCAnalysis) float64 { return a.MemoryEfficiency }, (*Reporter).formatPercent},
+}
+
+// GenerateComparisonReport writes two analyses side by side, e.g. before and
+// after a tuning change: one row per metric with both values, aligned in
+// columns, and the relative change marked ▲ when the metric went up and ▼
+// when it went down. The arrows show direction only; whether that is an
+// improvement depends on the metric. Values an analysis could not provide
+// are shown as n/a, without a change.
+// A nil opts uses the default options.
+func GenerateComparisonReport(w io.Writer, before, after *types.GCAnalysis, opts *Options) error {
+	return generateComparison(w, before, after, opts, false)
+}
+
+// GenerateMarkdownComparisonReport is GenerateComparisonReport as a Markdown
+// table, for pull requests, issues and wikis. Cells are padded so the table
+// stays aligned in plain text too.
+func GenerateMarkdownComparisonReport(w io.Writer, before, after *types.GCAnalysis, opts *Options) error {
+	return generateComparison(w, before, after, opts, true)
+}
+
+// generateComparison writes the comparison table as plain text or Markdown
+func generateComparison(w io.Writer, before, after *types.GCAnalysis, opts *Options, markdown bool) error {
+	if before == nil || after == nil {
+		return fmt.Errorf("comparison report: %w", types.ErrNoAnalysisData)
+	}
+
+	r := NewWithOptions(after, nil, nil, opts)
+	rows := make([][]string, 0, len(comparisonMetrics)+1)
+	rows = append(rows, []string{r.t(i18n.ColumnMetric), r.t(i18n.ColumnBefore), r.t(i18n.ColumnAfter), r.t(i18n.ColumnChange)})
+	for _, m := range comparisonMetrics {
+		rows = append(rows, r.comparisonRow(m, before, after))
+	}
+
+	// Markdown needs at least three dashes in each column's delimiter
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell), 3)
+		}
+	}
+
+	b := getBuilder()
+	defer putBuilder(b)
+
+	if markdown {
+		b.WriteString("## ")
+		b.WriteString(r.t(i18n.SectionComparison))
+		b.WriteString("\n\n")
+	} else {
+		r.writeSection(b, i18n.SectionComparison)
+	}
+	for i, row := range rows {
+		writeComparisonRow(b, row, widths, markdown)
+		if i > 0 {
+			continue
+		}
+		rule := make([]string, len(widths))
+		for j, width := range widths {
+			switch {
+			case !markdown:
+				rule[j] = strings.Repeat("-", width)
+			case j == 0:
+				rule[j] = ":" + strings.Repeat("-", width-1)
+			default:
+				rule[j] = strings.Repeat("-", width-1) + ":"
+			}
+		}
+		writeComparisonRow(b, rule, widths, markdown)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// comparisonRow returns the cells of a metric's row: its label, both values
+// and the change
+func (r *Reporter) comparisonRow(m comparisonMetric, before, after *types.GCAnalysis) []string {
+	row := []string{r.t(m.label), r.t(i18n.UnitNotAvailable), r.t(i18n.UnitNotAvailable), ""}
+	if !before.IsUnavailable(m.field) {
+		row[1] = m.format(r, m.value(before))
+	}
+	if !after.IsUnavailable(m.field) {
+		row[2] = m.format(r, m.value(after))
+	}
+	if before.IsUnavailable(m.field) || after.IsUnavailable(m.field) {
+		return row
+	}
+
+	from, to := m.value(before), m.value(after)
+	var change string
+	switch {
+	case to > from:
+		change = "▲"
+	case to < from:
+		change = "▼"
+	default:
+		change = "="
+	}
+	if from != 0 && to != from {
+		pct := (to - from) / from * 100
+		change += " "
+		if pct > 0 {
+			change += "+"
+		}
+		change += r.formatNumber(pct, 1) + "%"
+	}
+	row[3] = change
+	return row
+}
+
+// writeComparisonRow writes a row with each cell padded to its column's
+// width: the first column aligned left and the others right
+func writeComparisonRow(b *strings.Builder, row []string, widths []int, markdown bool) {
+	var line strings.Builder
+	if markdown {
+		line.WriteString("| ")
+	}
+	for i, cell := range row {
+		if i > 0 {
+			if markdown {
+				line.WriteString(" | ")
+			} else {
+				line.WriteString("  ")
+			}
+		}
+		pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+		if i == 0 {
+			line.WriteString(cell + pad)
+		} else {
+			line.WriteString(pad + cell)
+		}
+	}
+	if markdown {
+		line.WriteString(" |")
+	}
+	b.WriteString(strings.TrimRight(line.String(), " "))
+	b.WriteByte('\n')
+}
+
+// formatFrequency formats a rate per second, e.g. "2.50/s"
+func (r *Reporter) formatFrequency(v float64) string {
+	return r.formatNumber(v, 2) + "/s"
+}
+
+// formatDuration formats nanoseconds as a duration rounded to the microsecond
+func (r *Reporter) formatDuration(v float64) string {
+	return time.Duration(v).Round(time.Microsecond).String()
+}
+
+// formatPercent formats a percentage, e.g. "2.50%"
+func (r *Reporter) formatPercent(v float64) string {
+	return r.formatNumber(v, 2) + "%"
+}
+
+// formatBytesValue formats a byte count held in a float64
+func (r *Reporter) formatBytesValue(v float64) string {
+	return r.formatBytes(uint64(v))
+}
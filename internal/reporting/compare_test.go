@@ -0,0 +1,98 @@
+package reporting
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/i18n"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestGenerateComparisonReport(t *testing.T) {
+	before := createTestAnalysis()
+	after := createTestAnalysis()
+	after.GCFrequency = 1.25
+	after.P99PauseTime = 3 * time.Millisecond
+	after.MaxPauseTime = 0
+	after.Unavailable = []string{"max_pause_time"}
+
+	var buf bytes.Buffer
+	if err := GenerateComparisonReport(&buf, before, after, nil); err != nil {
+		t.Fatalf("GenerateComparisonReport() error: %v", err)
+	}
+	report := buf.String()
+	for _, want := range []string{
+		"=== Analysis Comparison ===\n",
+		"GC Frequency           2.50/s    1.25/s   ▼ -50.0%\n",
+		"P99 Pause               1.5ms       3ms  ▲ +100.0%\n",
+		"Max Pause                 2ms       n/a\n",
+		"GC Overhead             2.50%     2.50%          =\n",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, report)
+		}
+	}
+
+	// Every row is as wide as the header and its underline
+	lines := strings.Split(strings.TrimSuffix(report, "\n"), "\n")
+	width := len([]rune(lines[2]))
+	for _, line := range lines[1:] {
+		if n := len([]rune(line)); n > width {
+			t.Errorf("Line %q is %d columns wide, want at most %d", line, n, width)
+		}
+	}
+}
+
+func TestGenerateMarkdownComparisonReport(t *testing.T) {
+	before := createTestAnalysis()
+	after := createTestAnalysis()
+	after.AvgPauseTime = 250 * time.Microsecond
+
+	var buf bytes.Buffer
+	if err := GenerateMarkdownComparisonReport(&buf, before, after, nil); err != nil {
+		t.Fatalf("GenerateMarkdownComparisonReport() error: %v", err)
+	}
+	report := buf.String()
+	for _, want := range []string{
+		"## Analysis Comparison\n\n",
+		"| Metric              |   Before |    After |   Change |\n",
+		"| :------------------ | -------: | -------: | -------: |\n",
+		"| Average Pause       |    500µs |    250µs | ▼ -50.0% |\n",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, report)
+		}
+	}
+	// Header, delimiter and one row per metric
+	if n := strings.Count(report, "\n|"); n != len(comparisonMetrics)+2 {
+		t.Errorf("Table has %d lines, want %d", n, len(comparisonMetrics)+2)
+	}
+}
+
+func TestGenerateComparisonReport_Korean(t *testing.T) {
+	var buf bytes.Buffer
+	err := GenerateComparisonReport(&buf, createTestAnalysis(), createTestAnalysis(), &Options{Language: i18n.Korean})
+	if err != nil {
+		t.Fatalf("GenerateComparisonReport() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "=== 분석 비교 ===\n") {
+		t.Errorf("Report should have the Korean heading, got:\n%s", buf.String())
+	}
+}
+
+func TestGenerateComparisonReport_NoData(t *testing.T) {
+	reports := map[string]func(io.Writer, *types.GCAnalysis, *types.GCAnalysis, *Options) error{
+		"text":     GenerateComparisonReport,
+		"markdown": GenerateMarkdownComparisonReport,
+	}
+	for name, generate := range reports {
+		var buf bytes.Buffer
+		if err := generate(&buf, createTestAnalysis(), nil, nil); !errors.Is(err, types.ErrNoAnalysisData) {
+			t.Errorf("%s: error = %v, want ErrNoAnalysisData", name, err)
+		}
+	}
+}
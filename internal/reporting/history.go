@@ -0,0 +1,82 @@
+package reporting
+
+import (
+	"sync"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// HealthHistory retains the most recent health scores and derives their
+// trend. It is safe for concurrent use.
+type HealthHistory struct {
+	mu     sync.Mutex
+	size   int
+	points []types.HealthPoint // oldest first
+}
+
+// NewHealthHistory creates a history retaining up to size scores, at least one
+func NewHealthHistory(size int) *HealthHistory {
+	return &HealthHistory{size: max(size, 1)}
+}
+
+// Record adds a health check's score and sets its Trend from the scores of
+// the last types.HealthTrendWindow checks. Unknown statuses, computed without
+// an analysis, are not recorded.
+func (h *HealthHistory) Record(status *types.HealthCheckStatus) {
+	if status.Status == "unknown" {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.points) >= h.size {
+		h.points = append(h.points[:0], h.points[len(h.points)-h.size+1:]...)
+	}
+	h.points = append(h.points, types.HealthPoint{
+		Timestamp: status.LastUpdated,
+		Score:     status.Score,
+		Status:    status.Status,
+	})
+	status.Trend = trend(h.points[max(len(h.points)-types.HealthTrendWindow, 0):])
+}
+
+// Points returns a copy of the recorded scores, oldest first
+func (h *HealthHistory) Points() []types.HealthPoint {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]types.HealthPoint(nil), h.points...)
+}
+
+// trend classifies the least-squares change in score across points
+func trend(points []types.HealthPoint) string {
+	n := float64(len(points))
+	if n < 3 {
+		return types.HealthTrendStable
+	}
+
+	var meanX, meanY float64
+	for i, p := range points {
+		meanX += float64(i)
+		meanY += float64(p.Score)
+	}
+	meanX /= n
+	meanY /= n
+
+	var sxx, sxy float64
+	for i, p := range points {
+		dx := float64(i) - meanX
+		sxx += dx * dx
+		sxy += dx * (float64(p.Score) - meanY)
+	}
+	change := sxy / sxx * (n - 1)
+
+	switch {
+	case change >= types.ThresholdHealthTrend:
+		return types.HealthTrendImproving
+	case change <= -types.ThresholdHealthTrend:
+		return types.HealthTrendDegrading
+	default:
+		return types.HealthTrendStable
+	}
+}
@@ -0,0 +1,53 @@
+package reporting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestHealthHistory_Trend(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores []int
+		want   string
+	}{
+		{"too few", []int{100, 50}, types.HealthTrendStable},
+		{"flat", []int{80, 82, 79, 81, 80}, types.HealthTrendStable},
+		{"improving", []int{40, 50, 55, 70, 85}, types.HealthTrendImproving},
+		{"degrading", []int{100, 100, 85, 75, 60}, types.HealthTrendDegrading},
+		// Only the last HealthTrendWindow checks count
+		{"recovered", []int{0, 0, 0, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100}, types.HealthTrendStable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHealthHistory(100)
+			var last *types.HealthCheckStatus
+			for _, score := range tt.scores {
+				last = &types.HealthCheckStatus{Status: "healthy", Score: score, LastUpdated: time.Now()}
+				h.Record(last)
+			}
+			if last.Trend != tt.want {
+				t.Errorf("Trend = %q, want %q", last.Trend, tt.want)
+			}
+		})
+	}
+}
+
+func TestHealthHistory_Points(t *testing.T) {
+	h := NewHealthHistory(3)
+	h.Record(&types.HealthCheckStatus{Status: "unknown"})
+	for score := range 5 {
+		h.Record(&types.HealthCheckStatus{Status: "critical", Score: score})
+	}
+
+	points := h.Points()
+	if len(points) != 3 || points[0].Score != 2 || points[2].Score != 4 {
+		t.Errorf("Points() = %+v, want the last three scores", points)
+	}
+	points[0].Score = 100
+	if h.Points()[0].Score != 2 {
+		t.Error("Points() should return a copy")
+	}
+}
@@ -1,25 +1,25 @@
 package reporting
 
 import (
+	"bytes"
+	"cmp"
 	"encoding/json"
-	"errors"
+	"fmt"
 	"io"
+	"maps"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"text/tabwriter"
 	"time"
+	"unicode/utf8"
 
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/i18n"
 	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
 )
 
-// Report generation errors
-var (
-	ErrNoAnalysisData = errors.New("no analysis data available")
-	ErrNoMetricsData  = errors.New("no metrics data available")
-	ErrNoEventsData   = errors.New("no events data available")
-)
-
 // builderPool provides reusable strings.Builder to reduce allocations
 var builderPool = sync.Pool{
 	New: func() any {
@@ -49,16 +49,174 @@ type Reporter struct {
 	analysis *types.GCAnalysis
 	metrics  []*types.GCMetrics
 	events   []*types.GCEvent
+	lang     i18n.Language
+	numbers  *types.NumberFormat
+	health   types.HealthCheckConfig
+	charts   bool
+}
+
+// Options configures report generation
+type Options struct {
+	// Language selects the language for headings, labels, recommendations and
+	// health check text (default: English).
+	// Unsupported languages fall back to English.
+	Language i18n.Language
+
+	// Numbers sets the precision and rounding of every number in the output,
+	// including byte sizes and JSON floats. Nil keeps each format's default
+	// (e.g. 2 decimals in text reports, 6 in Prometheus metrics).
+	Numbers *types.NumberFormat
+
+	// HealthCheck sets the thresholds and penalties of GenerateHealthCheck
+	// (default: types.DefaultHealthCheckConfig)
+	HealthCheck *types.HealthCheckConfig
+
+	// HealthProfile selects a predefined HealthCheck config when HealthCheck
+	// is nil. Unsupported profiles fall back to the default.
+	HealthProfile types.HealthProfile
+
+	// Charts adds ASCII charts to text reports: a heap trend sparkline and
+	// GC timeline drawn from the metrics, and a pause distribution bar chart
+	// drawn from the events
+	Charts bool
 }
 
 // New creates a new reporter with the provided analysis data.
 // Metrics and events are optional and can be nil.
 func New(analysis *types.GCAnalysis, metrics []*types.GCMetrics, events []*types.GCEvent) *Reporter {
+	return NewWithOptions(analysis, metrics, events, nil)
+}
+
+// NewWithOptions creates a new reporter with the provided analysis data and options.
+// A nil opts is equivalent to calling New.
+func NewWithOptions(analysis *types.GCAnalysis, metrics []*types.GCMetrics, events []*types.GCEvent, opts *Options) *Reporter {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	lang := opts.Language
+	if !i18n.IsSupported(lang) {
+		lang = i18n.DefaultLanguage
+	}
+
+	health, err := types.HealthCheckProfile(opts.HealthProfile)
+	if err != nil {
+		health = types.DefaultHealthCheckConfig()
+	}
+	if opts.HealthCheck != nil {
+		health = *opts.HealthCheck
+	}
+
 	return &Reporter{
 		analysis: analysis,
 		metrics:  metrics,
 		events:   events,
+		lang:     lang,
+		numbers:  opts.Numbers,
+		health:   health,
+		charts:   opts.Charts,
+	}
+}
+
+// t returns the catalog message for key in the reporter's language
+func (r *Reporter) t(key i18n.Key) string {
+	return i18n.T(r.lang, key)
+}
+
+// writeSection writes a localized "=== Heading ===" line
+func (r *Reporter) writeSection(b *strings.Builder, key i18n.Key) {
+	b.WriteString("=== ")
+	b.WriteString(r.t(key))
+	b.WriteString(" ===\n")
+}
+
+// writeLabel writes a localized "Label: " prefix
+func (r *Reporter) writeLabel(b *strings.Builder, key i18n.Key) {
+	b.WriteString(r.t(key))
+	b.WriteString(": ")
+}
+
+// writeColumns writes a tab-separated row of localized column headings and
+// an underline of dashes for each
+func (r *Reporter) writeColumns(b *strings.Builder, keys ...i18n.Key) {
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteByte('\t')
+		}
+		b.WriteString(r.t(key))
+	}
+	b.WriteByte('\n')
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteByte('\t')
+		}
+		b.WriteString(strings.Repeat("-", utf8.RuneCountInString(r.t(key))))
+	}
+	b.WriteByte('\n')
+}
+
+// sizeClassLabel returns the label key for an allocation size class
+func sizeClassLabel(class string) i18n.Key {
+	switch class {
+	case types.SizeClassTiny:
+		return i18n.LabelSizeClassTiny
+	case types.SizeClassSmall:
+		return i18n.LabelSizeClassSmall
+	default:
+		return i18n.LabelSizeClassLarge
+	}
+}
+
+// writePauseShare writes " (X% of pause)" and ends the line
+func (r *Reporter) writePauseShare(b *strings.Builder, share float64) {
+	b.WriteString(" (")
+	b.WriteString(r.formatNumber(share*100, 2))
+	b.WriteString("% ")
+	b.WriteString(r.t(i18n.UnitOfPause))
+	b.WriteString(")\n")
+}
+
+// writePauseOutliers writes the longest pauses with the heap size and
+// allocation rate around each
+func (r *Reporter) writePauseOutliers(b *strings.Builder, o *types.PauseOutliers) {
+	r.writeSection(b, i18n.SectionLongestPauses)
+	r.writeLabel(b, i18n.LabelOutlierThreshold)
+	b.WriteString(o.Threshold.Round(time.Microsecond).String())
+	b.WriteString("\n")
+	r.writeLabel(b, i18n.LabelOutliers)
+	b.WriteString(strconv.Itoa(o.Count))
+	b.WriteString("\n")
+	for i, p := range o.Longest {
+		b.WriteString(strconv.Itoa(i + 1))
+		b.WriteString(". ")
+		b.WriteString(p.Time.Format("15:04:05.000"))
+		b.WriteString(" GC #")
+		b.WriteString(strconv.FormatUint(uint64(p.Sequence), 10))
+		b.WriteString(": ")
+		b.WriteString(p.Duration.Round(time.Microsecond).String())
+		b.WriteString(", ")
+		b.WriteString(r.t(i18n.UnitHeap))
+		b.WriteByte(' ')
+		b.WriteString(r.formatBytes(p.HeapSize))
+		b.WriteString(", ")
+		b.WriteString(r.t(i18n.UnitAlloc))
+		b.WriteByte(' ')
+		b.WriteString(r.formatBytesRate(p.AllocRate))
+		if p.Outlier {
+			b.WriteString(", ")
+			b.WriteString(r.t(i18n.UnitOutlier))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+}
+
+// writeSigned writes a change with an explicit sign
+func (r *Reporter) writeSigned(b *strings.Builder, change float64) {
+	if change >= 0 {
+		b.WriteByte('+')
 	}
+	b.WriteString(r.formatNumber(change, 2))
 }
 
 // GenerateTextReport generates a human-readable text report.
@@ -66,7 +224,7 @@ func New(analysis *types.GCAnalysis, metrics []*types.GCMetrics, events []*types
 // Optimized to reduce allocations by using strings.Builder.
 func (r *Reporter) GenerateTextReport(w io.Writer) error {
 	if r.analysis == nil {
-		return ErrNoAnalysisData
+		return fmt.Errorf("text report: %w", types.ErrNoAnalysisData)
 	}
 
 	b := getBuilder()
@@ -76,94 +234,819 @@ func (r *Reporter) GenerateTextReport(w io.Writer) error {
 	b.Grow(2048)
 
 	// Title
-	b.WriteString("=== Go GC Analysis Report ===\n\n")
+	b.WriteString("=== ")
+	b.WriteString(r.t(i18n.ReportTitle))
+	b.WriteString(" ===\n\n")
 
 	// Analysis period
-	b.WriteString("Analysis Period: ")
+	r.writeLabel(b, i18n.LabelAnalysisPeriod)
 	b.WriteString(r.analysis.Period.Round(time.Second).String())
-	b.WriteString(" (from ")
+	b.WriteString(" (")
+	b.WriteString(r.t(i18n.LabelFrom))
+	b.WriteByte(' ')
 	b.WriteString(r.analysis.StartTime.Format("2006-01-02 15:04:05"))
-	b.WriteString(" to ")
+	b.WriteByte(' ')
+	b.WriteString(r.t(i18n.LabelTo))
+	b.WriteByte(' ')
 	b.WriteString(r.analysis.EndTime.Format("2006-01-02 15:04:05"))
 	b.WriteString(")\n\n")
 
+	// Data Quality Warnings (only when there are any)
+	if len(r.analysis.Warnings) > 0 {
+		r.writeSection(b, i18n.SectionWarnings)
+		r.writeWarnings(b)
+		b.WriteString("\n")
+	}
+
+	// Environment
+	if rt := r.analysis.Runtime; rt != nil {
+		r.writeEnvironment(b, rt)
+		b.WriteString("\n")
+	}
+	if changes := r.analysis.TuningChanges; len(changes) > 0 {
+		r.writeSection(b, i18n.SectionTuningChanges)
+		for _, c := range changes {
+			b.WriteString(c.Timestamp.Format("15:04:05"))
+			b.WriteString("  ")
+			b.WriteString(c.String())
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	if resets := r.analysis.CounterResets; len(resets) > 0 {
+		r.writeSection(b, i18n.SectionCounterResets)
+		for _, reset := range resets {
+			b.WriteString(reset.Timestamp.Format("15:04:05"))
+			b.WriteString("  ")
+			b.WriteString(reset.String())
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
 	// GC Frequency
-	b.WriteString("=== GC Frequency ===\n")
-	b.WriteString("GC Frequency: ")
-	b.WriteString(formatFloat(r.analysis.GCFrequency, 2))
-	b.WriteString(" GCs/second\n")
-	b.WriteString("Average GC Interval: ")
+	r.writeSection(b, i18n.SectionGCFrequency)
+	r.writeLabel(b, i18n.LabelGCFrequency)
+	b.WriteString(r.formatNumber(r.analysis.GCFrequency, 2))
+	b.WriteByte(' ')
+	b.WriteString(r.t(i18n.UnitGCsPerSecond))
+	b.WriteString("\n")
+	r.writeLabel(b, i18n.LabelAvgGCInterval)
 	b.WriteString(r.analysis.AvgGCInterval.Round(time.Millisecond).String())
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if r.analysis.ForcedGCCount > 0 {
+		r.writeLabel(b, i18n.LabelForcedGCs)
+		b.WriteString(strconv.FormatUint(uint64(r.analysis.ForcedGCCount), 10))
+		b.WriteString(" (")
+		b.WriteString(r.formatNumber(r.analysis.ForcedGCRatio*100, 2))
+		b.WriteString("%)\n")
+	}
+	b.WriteString("\n")
 
 	// Pause Times
-	b.WriteString("=== GC Pause Times ===\n")
-	b.WriteString("Average Pause: ")
+	r.writeSection(b, i18n.SectionPauseTimes)
+	r.writeLabel(b, i18n.LabelAvgPause)
 	b.WriteString(r.analysis.AvgPauseTime.Round(time.Microsecond).String())
 	b.WriteString("\n")
-	b.WriteString("Min Pause: ")
-	b.WriteString(r.analysis.MinPauseTime.Round(time.Microsecond).String())
-	b.WriteString("\n")
-	b.WriteString("Max Pause: ")
-	b.WriteString(r.analysis.MaxPauseTime.Round(time.Microsecond).String())
-	b.WriteString("\n")
-	b.WriteString("P95 Pause: ")
-	b.WriteString(r.analysis.P95PauseTime.Round(time.Microsecond).String())
+	if apdex := r.analysis.PauseApdex; apdex != nil {
+		r.writeLabel(b, i18n.LabelPauseApdex)
+		b.WriteString(r.formatNumber(apdex.Score, 2))
+		b.WriteString(" [T=")
+		b.WriteString(apdex.Target.String())
+		b.WriteString("]: ")
+		for i, c := range []struct {
+			n    int
+			unit i18n.Key
+		}{{apdex.Satisfied, i18n.UnitSatisfied}, {apdex.Tolerating, i18n.UnitTolerating}, {apdex.Frustrated, i18n.UnitFrustrated}} {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(strconv.Itoa(c.n))
+			b.WriteByte(' ')
+			b.WriteString(r.t(c.unit))
+		}
+		b.WriteString("\n")
+	}
+	if rs := r.analysis.Robust; rs != nil && rs.Pauses > 0 {
+		r.writeLabel(b, i18n.LabelMedianPause)
+		b.WriteString(rs.MedianPause.Round(time.Microsecond).String())
+		b.WriteString(" (MAD ")
+		b.WriteString(rs.PauseMAD.Round(time.Microsecond).String())
+		b.WriteString(")\n")
+	}
+	r.writePause(b, i18n.LabelMinPause, "min_pause_time", r.analysis.MinPauseTime)
+	r.writePause(b, i18n.LabelMaxPause, "max_pause_time", r.analysis.MaxPauseTime)
+	r.writePause(b, i18n.LabelP95Pause, "p95_pause_time", r.analysis.P95PauseTime)
+	r.writePause(b, i18n.LabelP99Pause, "p99_pause_time", r.analysis.P99PauseTime)
+	if d := r.analysis.IntervalMaxAvgPause; d > 0 {
+		r.writeLabel(b, i18n.LabelIntervalMaxPause)
+		b.WriteString(d.Round(time.Microsecond).String())
+		b.WriteString("\n")
+	}
+	if d := r.analysis.PauseDistribution; d != nil {
+		r.writeLabel(b, i18n.LabelSTWPauses)
+		b.WriteString(strconv.FormatUint(d.Pauses, 10))
+		for _, q := range []struct {
+			name string
+			v    time.Duration
+		}{{"P50", d.P50}, {"P95", d.P95}, {"P99", d.P99}, {"Max", d.Max}} {
+			b.WriteString(", ")
+			b.WriteString(q.name)
+			b.WriteString(" ≤ ")
+			b.WriteString(q.v.Round(time.Microsecond).String())
+		}
+		b.WriteString("\n")
+		if d.EventsIncomplete {
+			b.WriteString(r.t(i18n.MsgPausesIncomplete))
+			b.WriteString("\n")
+		}
+	}
+	if q := r.analysis.PauseQuantiles; q != nil {
+		r.writeLabel(b, i18n.LabelProcessGCs)
+		b.WriteString(strconv.FormatInt(q.NumGC, 10))
+		b.WriteString(" (")
+		b.WriteString(q.PauseTotal.Round(time.Microsecond).String())
+		b.WriteString(")\n")
+		r.writeLabel(b, i18n.LabelRecentPauses)
+		for i, p := range []struct {
+			name string
+			v    time.Duration
+		}{{"P50", q.P50}, {"P95", q.P95}, {"P99", q.P99}, {"Max", q.Max}} {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(p.name)
+			b.WriteByte(' ')
+			b.WriteString(p.v.Round(time.Microsecond).String())
+		}
+		b.WriteString(" (n=")
+		b.WriteString(strconv.Itoa(q.Pauses))
+		b.WriteString(")\n")
+	}
+	if r.analysis.MissedEvents > 0 {
+		r.writeLabel(b, i18n.LabelMissedEvents)
+		b.WriteString(strconv.FormatUint(uint64(r.analysis.MissedEvents), 10))
+		b.WriteString("\n")
+		b.WriteString(r.t(i18n.MsgEventsMissed))
+		b.WriteString("\n")
+	}
 	b.WriteString("\n")
-	b.WriteString("P99 Pause: ")
-	b.WriteString(r.analysis.P99PauseTime.Round(time.Microsecond).String())
-	b.WriteString("\n\n")
+
+	// Longest GC Pauses (only when there are enough events)
+	if o := r.analysis.PauseOutliers; o != nil && len(o.Longest) > 0 {
+		r.writePauseOutliers(b, o)
+	}
+
+	// Minimum Mutator Utilization (only when events are available)
+	if len(r.analysis.MMU) > 0 {
+		r.writeSection(b, i18n.SectionMMU)
+		for _, p := range r.analysis.MMU {
+			b.WriteString(p.Window.String())
+			b.WriteString(": ")
+			b.WriteString(r.formatNumber(p.Utilization*100, 2))
+			b.WriteString("%\n")
+		}
+		b.WriteString("\n")
+	}
+
+	// Pause Breakdown (only when phase data is available, e.g. from gctrace)
+	if p := r.analysis.Phases; p != nil {
+		r.writeSection(b, i18n.SectionPauseBreakdown)
+		r.writeLabel(b, i18n.LabelSweepTermination)
+		b.WriteString(p.AvgSweepTermination.Round(time.Microsecond).String())
+		r.writePauseShare(b, p.SweepTerminationShare)
+		r.writeLabel(b, i18n.LabelConcurrentMark)
+		b.WriteString(p.AvgConcurrentMark.Round(time.Microsecond).String())
+		b.WriteString("\n")
+		r.writeLabel(b, i18n.LabelMarkTermination)
+		b.WriteString(p.AvgMarkTermination.Round(time.Microsecond).String())
+		r.writePauseShare(b, p.MarkTerminationShare)
+		r.writeLabel(b, i18n.LabelSTWShare)
+		b.WriteString(r.formatNumber(p.STWShare*100, 2))
+		b.WriteString("%\n")
+		r.writeLabel(b, i18n.LabelPhaseCycles)
+		b.WriteString(strconv.Itoa(p.Cycles))
+		b.WriteString("\n\n")
+	}
 
 	// Memory Usage
-	b.WriteString("=== Memory Usage ===\n")
-	b.WriteString("Average Heap Size: ")
-	b.WriteString(types.FormatBytes(r.analysis.AvgHeapSize))
+	r.writeSection(b, i18n.SectionMemoryUsage)
+	r.writeLabel(b, i18n.LabelAvgHeap)
+	b.WriteString(r.formatBytes(r.analysis.AvgHeapSize))
 	b.WriteString("\n")
-	b.WriteString("Min Heap Size: ")
-	b.WriteString(types.FormatBytes(r.analysis.MinHeapSize))
+	if rs := r.analysis.Robust; rs != nil {
+		r.writeLabel(b, i18n.LabelMedianHeap)
+		b.WriteString(r.formatBytes(rs.MedianHeap))
+		b.WriteString(" (MAD ")
+		b.WriteString(r.formatBytes(rs.HeapMAD))
+		b.WriteString(")\n")
+	}
+	r.writeLabel(b, i18n.LabelMinHeap)
+	b.WriteString(r.formatBytes(r.analysis.MinHeapSize))
 	b.WriteString("\n")
-	b.WriteString("Max Heap Size: ")
-	b.WriteString(types.FormatBytes(r.analysis.MaxHeapSize))
+	r.writeLabel(b, i18n.LabelMaxHeap)
+	b.WriteString(r.formatBytes(r.analysis.MaxHeapSize))
+	b.WriteString("\n")
+	r.writeLabel(b, i18n.LabelHeapGrowthRate)
+	b.WriteString(r.formatBytesRate(r.analysis.HeapGrowthRate))
+	b.WriteString("\n")
+	if r.analysis.AvgLiveHeap > 0 {
+		r.writeLabel(b, i18n.LabelAvgLiveHeap)
+		b.WriteString(r.formatBytes(r.analysis.AvgLiveHeap))
+		b.WriteString("\n")
+		r.writeLabel(b, i18n.LabelLiveHeapGrowth)
+		b.WriteString(r.formatBytesRate(r.analysis.LiveHeapGrowthRate))
+		b.WriteString("\n")
+	}
+	if limit := r.analysis.MemoryLimit; limit > 0 {
+		r.writeLabel(b, i18n.LabelMemoryLimit)
+		b.WriteString(r.formatBytes(limit))
+		b.WriteString(" (")
+		b.WriteString(r.formatNumber(r.analysis.MemoryLimitUsage*100, 2))
+		b.WriteString("% ")
+		b.WriteString(r.t(i18n.UnitInUse))
+		b.WriteString(")\n")
+	}
+	if p := r.analysis.Periodicity; p != nil && p.Detected {
+		b.WriteString(r.t(i18n.MsgPeriodicWorkload))
+		b.WriteString(" ")
+		b.WriteString(types.FormatShortDuration(p.Period))
+		b.WriteString("\n")
+	}
 	b.WriteString("\n")
-	b.WriteString("Heap Growth Rate: ")
-	b.WriteString(types.FormatBytesRate(r.analysis.HeapGrowthRate))
-	b.WriteString("\n\n")
+
+	// GC Reclamation (only when events carry the heap before and after each cycle)
+	if rc := r.analysis.Reclamation; rc != nil {
+		r.writeSection(b, i18n.SectionReclamation)
+		r.writeLabel(b, i18n.LabelReclaimed)
+		b.WriteString(r.formatBytes(rc.AvgReclaimed))
+		b.WriteString("\n")
+		r.writeLabel(b, i18n.LabelReclaimedTrend)
+		r.writeSigned(b, rc.ReclaimedTrend*100)
+		b.WriteString("%\n")
+		r.writeLabel(b, i18n.LabelSurvivalRatio)
+		b.WriteString(r.formatNumber(rc.AvgSurvivalRatio*100, 2))
+		b.WriteString("%\n")
+		r.writeLabel(b, i18n.LabelSurvivalTrend)
+		r.writeSigned(b, rc.SurvivalTrend*100)
+		b.WriteByte(' ')
+		b.WriteString(r.t(i18n.UnitPoints))
+		b.WriteString("\n")
+		r.writeLabel(b, i18n.LabelReclaimCycles)
+		b.WriteString(strconv.Itoa(rc.Cycles))
+		b.WriteString("\n\n")
+	}
+
+	// Soft Memory Limit (only when GOMEMLIMIT is set)
+	if sl := r.analysis.SoftLimit; sl != nil {
+		r.writeSoftLimit(b, sl)
+	}
+
+	// Resident Memory (only when process RSS was sampled)
+	if rss := r.analysis.RSS; rss != nil {
+		r.writeRSS(b, rss)
+	}
+
+	// OS Paging (only when page faults or swap were sampled)
+	if p := r.analysis.Paging; p != nil {
+		r.writePaging(b, p)
+	}
 
 	// Allocation Stats
-	b.WriteString("=== Allocation Statistics ===\n")
-	b.WriteString("Allocation Rate: ")
-	b.WriteString(types.FormatBytesRate(r.analysis.AllocRate))
+	r.writeSection(b, i18n.SectionAllocations)
+	r.writeLabel(b, i18n.LabelAllocRate)
+	b.WriteString(r.formatBytesRate(r.analysis.AllocRate))
 	b.WriteString("\n")
-	b.WriteString("Total Allocations: ")
+	r.writeLabel(b, i18n.LabelTotalAllocs)
 	b.WriteString(strconv.FormatUint(r.analysis.AllocCount, 10))
 	b.WriteString("\n")
-	b.WriteString("Total Frees: ")
+	r.writeLabel(b, i18n.LabelTotalFrees)
 	b.WriteString(strconv.FormatUint(r.analysis.FreeCount, 10))
 	b.WriteString("\n\n")
 
+	// Allocation Size Classes (only when an allocation profile was provided)
+	if d := r.analysis.SizeClasses; d.Dominant() != nil {
+		r.writeSection(b, i18n.SectionSizeClasses)
+		for _, bucket := range d.Buckets {
+			r.writeLabel(b, sizeClassLabel(bucket.Class))
+			b.WriteString(r.formatNumber(bucket.Share*100, 2))
+			b.WriteString("% (")
+			b.WriteString(r.formatBytes(bucket.Bytes))
+			b.WriteString(")\n")
+		}
+		b.WriteString("\n")
+	}
+
+	// Top Allocation Sites (only when an allocation profile was attributed)
+	if sites := r.analysis.AllocSites; sites.Top() != nil {
+		r.writeSection(b, i18n.SectionAllocSites)
+		for i := range sites.Sites {
+			site := &sites.Sites[i]
+			b.WriteString(strconv.Itoa(i + 1))
+			b.WriteString(". ")
+			b.WriteString(site.Function)
+			b.WriteString(" (")
+			b.WriteString(site.Location())
+			b.WriteString("): ")
+			if sites.Window > 0 {
+				b.WriteString(r.formatBytesRate(site.BytesPerSec))
+			} else {
+				b.WriteString(r.formatBytes(site.Bytes))
+			}
+			b.WriteString(", ")
+			b.WriteString(r.formatNumber(site.Share*100, 2))
+			b.WriteString("%\n")
+		}
+		b.WriteString("\n")
+	}
+
+	// Allocation by Region (only when regions were tracked)
+	if len(r.analysis.Regions) > 0 {
+		r.writeSection(b, i18n.SectionRegions)
+		for i := range r.analysis.Regions {
+			region := &r.analysis.Regions[i]
+			b.WriteString(region.Name)
+			b.WriteString(": ")
+			b.WriteString(r.formatBytesRate(region.AllocRate))
+			b.WriteString(" (")
+			b.WriteString(r.formatBytes(region.BytesPerCall()))
+			b.WriteString(r.t(i18n.UnitPerCall))
+			b.WriteString(", ")
+			b.WriteString(strconv.FormatUint(region.Calls, 10))
+			b.WriteByte(' ')
+			b.WriteString(r.t(i18n.UnitCalls))
+			b.WriteString(", ")
+			b.WriteString(strconv.FormatUint(uint64(region.GCCount), 10))
+			b.WriteByte(' ')
+			b.WriteString(r.t(i18n.UnitGCs))
+			if region.PauseTime > 0 {
+				b.WriteString(", ")
+				b.WriteString(region.PauseTime.Round(time.Microsecond).String())
+				b.WriteByte(' ')
+				b.WriteString(r.t(i18n.UnitPaused))
+			}
+			b.WriteString(")\n")
+		}
+		b.WriteString("\n")
+	}
+
+	// Breakdown by Region Label (only when samples carry labels)
+	if len(r.analysis.RegionBreakdown) > 0 {
+		r.writeSection(b, i18n.SectionRegionLabels)
+		for _, rb := range r.analysis.RegionBreakdown {
+			if rb.Region == "" {
+				b.WriteString(r.t(i18n.LabelUntagged))
+			} else {
+				b.WriteString(rb.Region)
+			}
+			b.WriteString(" (")
+			b.WriteString(rb.Duration.Round(time.Second).String())
+			b.WriteString("): ")
+			r.writeLabel(b, i18n.LabelGCFrequency)
+			b.WriteString(r.formatNumber(rb.GCFrequency, 2))
+			b.WriteByte(' ')
+			b.WriteString(r.t(i18n.UnitGCsPerSecond))
+			b.WriteString(", ")
+			r.writeLabel(b, i18n.LabelAvgPause)
+			b.WriteString(rb.AvgPauseTime.Round(time.Microsecond).String())
+			b.WriteString(", ")
+			r.writeLabel(b, i18n.LabelAllocRate)
+			b.WriteString(r.formatBytesRate(rb.AllocRate))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	// Per-Request GC Impact (only when requests were recorded)
+	if ri := r.analysis.RequestImpact; ri != nil {
+		r.writeSection(b, i18n.SectionRequests)
+		r.writeLabel(b, i18n.LabelRequests)
+		b.WriteString(strconv.FormatUint(ri.Requests, 10))
+		b.WriteString(" (n=")
+		b.WriteString(strconv.Itoa(ri.Window))
+		b.WriteString(")\n")
+		r.writeLabel(b, i18n.LabelGCAffected)
+		b.WriteString(strconv.Itoa(ri.GCAffected))
+		b.WriteString(" (")
+		b.WriteString(r.formatNumber(float64(ri.GCAffected)/float64(ri.Window)*100, 2))
+		b.WriteString("%)\n")
+		r.writeLabel(b, i18n.LabelRequestPauses)
+		for i, p := range []struct {
+			name string
+			v    time.Duration
+		}{{"P50", ri.PauseP50}, {"P95", ri.PauseP95}, {"P99", ri.PauseP99}, {"Max", ri.PauseMax}} {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(p.name)
+			b.WriteByte(' ')
+			b.WriteString(p.v.Round(time.Microsecond).String())
+		}
+		b.WriteString("\n")
+		r.writeLabel(b, i18n.LabelRequestAllocs)
+		for i, p := range []struct {
+			name string
+			v    uint64
+		}{{"P50", ri.AllocP50}, {"P95", ri.AllocP95}, {"P99", ri.AllocP99}, {"Max", ri.AllocMax}} {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(p.name)
+			b.WriteByte(' ')
+			b.WriteString(r.formatBytes(p.v))
+		}
+		b.WriteString("\n\n")
+	}
+
 	// Efficiency Metrics
-	b.WriteString("=== Efficiency Metrics ===\n")
-	b.WriteString("GC Overhead: ")
-	b.WriteString(formatFloat(r.analysis.GCOverhead, 2))
+	r.writeSection(b, i18n.SectionEfficiency)
+	r.writeLabel(b, i18n.LabelGCOverhead)
+	b.WriteString(r.formatNumber(r.analysis.GCOverhead, 2))
+	b.WriteString("%\n")
+	r.writeLabel(b, i18n.LabelMemoryEfficiency)
+	b.WriteString(r.formatNumber(r.analysis.MemoryEfficiency, 2))
 	b.WriteString("%\n")
-	b.WriteString("Memory Efficiency: ")
-	b.WriteString(formatFloat(r.analysis.MemoryEfficiency, 2))
-	b.WriteString("%\n\n")
+	if cpu := r.analysis.GCCPU; cpu != nil {
+		r.writeLabel(b, i18n.LabelGCCPUTime)
+		b.WriteString(r.formatNumber(cpu.TotalSeconds, 2))
+		b.WriteString("s\n")
+		r.writeLabel(b, i18n.LabelMarkAssistShare)
+		b.WriteString(r.formatNumber(cpu.AssistShare*100, 2))
+		b.WriteString("%\n")
+		r.writeLabel(b, i18n.LabelBackgroundShare)
+		b.WriteString(r.formatNumber(cpu.BackgroundShare*100, 2))
+		b.WriteString("%\n")
+	}
+	if p := r.analysis.ProcessCPU; p != nil {
+		if p.Utilization > 0 {
+			r.writeLabel(b, i18n.LabelProcessCPUUtil)
+			b.WriteString(r.formatNumber(p.Utilization*100, 2))
+			b.WriteString("%\n")
+		}
+		if p.GCSeconds > 0 {
+			r.writeLabel(b, i18n.LabelGCShareOfProcess)
+			b.WriteString(r.formatNumber(p.GCShare*100, 2))
+			b.WriteString("%\n")
+		}
+	}
+	b.WriteString("\n")
+
+	if r.charts {
+		r.writeCharts(b)
+	}
 
 	// Recommendations
-	if len(r.analysis.Recommendations) > 0 {
-		b.WriteString("=== Recommendations ===\n")
+	r.writeRecommendations(b)
+
+	// Runtime configuration drift
+	r.writeConfigDrift(b)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeRSS writes the resident memory section, comparing RSS with the memory
+// the Go runtime accounts for
+func (r *Reporter) writeRSS(b *strings.Builder, rss *types.RSSAnalysis) {
+	r.writeSection(b, i18n.SectionRSS)
+	r.writeLabel(b, i18n.LabelAvgRSS)
+	b.WriteString(r.formatBytes(rss.AvgRSS))
+	b.WriteString("\n")
+	r.writeLabel(b, i18n.LabelGoManaged)
+	b.WriteString(r.formatBytes(rss.AvgGoManaged))
+	b.WriteString("\n")
+	r.writeLabel(b, i18n.LabelNonGoMemory)
+	b.WriteString(r.formatBytes(rss.AvgNonGo))
+	b.WriteString(" (")
+	b.WriteString(r.formatNumber(rss.NonGoShare*100, 2))
+	b.WriteString("%)\n")
+	if rss.NonGoGrowing {
+		r.writeLabel(b, i18n.LabelNonGoGrowth)
+		b.WriteString(r.formatBytesRate(rss.NonGoGrowth))
+		b.WriteString("\n")
+	}
+	r.writeLabel(b, i18n.LabelSysBreakdown)
+	sys := &rss.Sys
+	for i, part := range []struct {
+		name  string
+		bytes uint64
+	}{
+		{"heap", sys.Heap},
+		{"stack", sys.Stack},
+		{"mspan", sys.MSpan},
+		{"mcache", sys.MCache},
+		{"buckhash", sys.BuckHash},
+		{"gc", sys.GC},
+		{"other", sys.Other},
+	} {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(part.name)
+		b.WriteByte(' ')
+		b.WriteString(r.formatBytes(part.bytes))
+	}
+	b.WriteString("\n\n")
+}
+
+// writeSoftLimit writes memory pressure against GOMEMLIMIT
+func (r *Reporter) writeSoftLimit(b *strings.Builder, sl *types.SoftLimitAnalysis) {
+	r.writeSection(b, i18n.SectionSoftLimit)
+	r.writeLabel(b, i18n.LabelGOMEMLIMIT)
+	b.WriteString(r.formatBytes(sl.Limit))
+	b.WriteString(" (")
+	b.WriteString(r.t(i18n.UnitPeak))
+	b.WriteByte(' ')
+	b.WriteString(r.formatNumber(sl.PeakRatio*100, 2))
+	b.WriteString("% ")
+	b.WriteString(r.t(i18n.UnitInUse))
+	b.WriteString(")\n")
+	r.writeLabel(b, i18n.LabelTimeNearLimit)
+	b.WriteString(r.formatNumber(sl.NearLimitShare*100, 2))
+	b.WriteString("%\n")
+	if sl.NearLimitShare > 0 {
+		r.writeLabel(b, i18n.LabelGCFreqNearLimit)
+		b.WriteString(r.formatNumber(sl.GCFrequencyNear, 2))
+		b.WriteByte(' ')
+		b.WriteString(r.t(i18n.UnitGCsPerSecond))
+		b.WriteString(" (")
+		b.WriteString(r.formatNumber(sl.GCFrequencyBelow, 2))
+		b.WriteByte(' ')
+		b.WriteString(r.t(i18n.UnitBelow))
+		b.WriteString(")\n")
+	}
+	if sl.GCCPUNear > 0 {
+		r.writeLabel(b, i18n.LabelGCCPUNearLimit)
+		b.WriteString(r.formatNumber(sl.GCCPUNear*100, 2))
+		b.WriteString("%\n")
+	}
+	if sl.SuggestedLimit > 0 {
+		r.writeLabel(b, i18n.LabelSuggestedLimit)
+		b.WriteString(r.formatBytes(sl.SuggestedLimit))
+		b.WriteString("\n")
+	}
+	if sl.DeathSpiral {
+		b.WriteString("⚠️  ")
+		b.WriteString(r.t(i18n.MsgDeathSpiral))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+}
+
+// writePaging writes OS paging over the window and the pauses during it
+func (r *Reporter) writePaging(b *strings.Builder, p *types.PagingAnalysis) {
+	r.writeSection(b, i18n.SectionPaging)
+	r.writeLabel(b, i18n.LabelMajorFaults)
+	b.WriteString(r.formatNumber(p.MajorFaultRate, 2))
+	b.WriteString(r.t(i18n.UnitPerSecond))
+	b.WriteString("\n")
+	if p.PeakSwap > 0 {
+		r.writeLabel(b, i18n.LabelPeakSwap)
+		b.WriteString(r.formatBytes(p.PeakSwap))
+		b.WriteString("\n")
+	}
+	r.writeLabel(b, i18n.LabelTimePaging)
+	b.WriteString(r.formatNumber(p.PagingShare*100, 2))
+	b.WriteString("%\n")
+	if p.PagingShare > 0 {
+		r.writeLabel(b, i18n.LabelPausePaging)
+		b.WriteString(p.AvgPausePaging.Round(time.Microsecond).String())
+		b.WriteString(" (")
+		b.WriteString(p.AvgPauseOther.Round(time.Microsecond).String())
+		b.WriteByte(' ')
+		b.WriteString(r.t(i18n.UnitOtherwise))
+		b.WriteString(")\n")
+	}
+	if p.Coincident {
+		b.WriteString("⚠️  ")
+		b.WriteString(r.t(i18n.MsgPausesPaging))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+}
+
+// writePause writes a pause statistic, or "n/a" when the data could not
+// provide the field with the given JSON name
+func (r *Reporter) writePause(b *strings.Builder, label i18n.Key, field string, d time.Duration) {
+	r.writeLabel(b, label)
+	if r.analysis.IsUnavailable(field) {
+		b.WriteString(r.t(i18n.UnitNotAvailable))
+	} else {
+		b.WriteString(d.Round(time.Microsecond).String())
+	}
+	b.WriteString("\n")
+}
+
+// writeWarnings writes each data quality warning on its own line
+func (r *Reporter) writeWarnings(b *strings.Builder) {
+	for _, w := range r.analysis.Warnings {
+		b.WriteString("⚠️  ")
+		b.WriteString(i18n.Translate(r.lang, w))
+		b.WriteString("\n")
+	}
+}
+
+// writeEnvironment writes the runtime configuration the analysis was captured
+// under, including the container and pod it ran in
+func (r *Reporter) writeEnvironment(b *strings.Builder, rt *types.RuntimeInfo) {
+	r.writeSection(b, i18n.SectionEnvironment)
+	r.writeLabel(b, i18n.LabelGoVersion)
+	b.WriteString(rt.GoVersion)
+	if rt.GOOS != "" {
+		b.WriteString(" (")
+		b.WriteString(rt.GOOS)
+		b.WriteByte('/')
+		b.WriteString(rt.GOARCH)
+		b.WriteByte(')')
+	}
+	b.WriteString("\n")
+	r.writeLabel(b, i18n.LabelGOMAXPROCS)
+	b.WriteString(strconv.Itoa(rt.GOMAXPROCS))
+	if rt.NumCPU > 0 {
+		b.WriteString(" (")
+		b.WriteString(strconv.Itoa(rt.NumCPU))
+		b.WriteByte(' ')
+		b.WriteString(r.t(i18n.UnitCPUs))
+		b.WriteByte(')')
+	}
+	b.WriteString("\n")
+	r.writeLabel(b, i18n.LabelGOGC)
+	b.WriteString(types.FormatGOGC(rt.GOGC))
+	b.WriteString("\n")
+	r.writeLabel(b, i18n.LabelGOMEMLIMIT)
+	b.WriteString(r.formatMemLimit(rt.GOMemLimit))
+	b.WriteString("\n")
+	if rt.CgroupMemoryLimit > 0 {
+		r.writeLabel(b, i18n.LabelMemoryLimit)
+		b.WriteString(r.formatBytes(rt.CgroupMemoryLimit))
+		b.WriteString("\n")
+	}
+	if rt.Kubernetes != nil {
+		r.writeKubernetes(b, rt.Kubernetes)
+	}
+}
+
+// environmentSummary describes the runtime on one line, e.g.
+// "go1.22.0 linux/amd64, GOMAXPROCS=8, GOGC=100, GOMEMLIMIT=none"
+func (r *Reporter) environmentSummary(rt *types.RuntimeInfo) string {
+	var b strings.Builder
+	b.WriteString(rt.GoVersion)
+	if rt.GOOS != "" {
+		b.WriteByte(' ')
+		b.WriteString(rt.GOOS)
+		b.WriteByte('/')
+		b.WriteString(rt.GOARCH)
+	}
+	b.WriteString(", GOMAXPROCS=")
+	b.WriteString(strconv.Itoa(rt.GOMAXPROCS))
+	b.WriteString(", GOGC=")
+	b.WriteString(types.FormatGOGC(rt.GOGC))
+	b.WriteString(", GOMEMLIMIT=")
+	b.WriteString(r.formatMemLimit(rt.GOMemLimit))
+	return b.String()
+}
+
+// formatMemLimit formats a GOMEMLIMIT value like types.FormatMemLimit, in
+// the report's number format
+func (r *Reporter) formatMemLimit(v uint64) string {
+	if v == 0 || v >= math.MaxInt64 {
+		return "none"
+	}
+	return r.formatBytes(v)
+}
+
+// writeKubernetes writes the pod the analysis was captured in and its limits
+func (r *Reporter) writeKubernetes(b *strings.Builder, k *types.KubernetesInfo) {
+	if k.Pod != "" {
+		r.writeLabel(b, i18n.LabelPod)
+		if k.Namespace != "" {
+			b.WriteString(k.Namespace)
+			b.WriteByte('/')
+		}
+		b.WriteString(k.Pod)
+		if k.Node != "" {
+			b.WriteString(" (")
+			b.WriteString(k.Node)
+			b.WriteByte(')')
+		}
+		b.WriteString("\n")
+	}
+	if k.CPULimit > 0 {
+		r.writeLabel(b, i18n.LabelCPULimit)
+		b.WriteString(r.formatNumber(k.CPULimit, 2))
+		b.WriteByte(' ')
+		b.WriteString(r.t(i18n.UnitCores))
+		b.WriteString("\n")
+	}
+	if k.MemoryLimit > 0 {
+		r.writeLabel(b, i18n.LabelPodMemoryLimit)
+		b.WriteString(r.formatBytes(k.MemoryLimit))
+		b.WriteString("\n")
+	}
+}
+
+// writeRecommendations writes the recommendations section, most severe first.
+// Severity labels and codes are shown when detailed recommendations are available.
+func (r *Reporter) writeRecommendations(b *strings.Builder) {
+	details := r.analysis.RecommendationDetails
+	if len(details) == 0 {
+		if len(r.analysis.Recommendations) == 0 {
+			return
+		}
+		r.writeSection(b, i18n.SectionRecommendation)
 		for i, rec := range r.analysis.Recommendations {
 			b.WriteString(strconv.Itoa(i + 1))
 			b.WriteString(". ")
-			b.WriteString(rec)
+			b.WriteString(i18n.Translate(r.lang, rec))
 			b.WriteString("\n")
 		}
 		b.WriteString("\n")
+		return
 	}
 
-	_, err := io.WriteString(w, b.String())
-	return err
+	sorted := slices.Clone(details)
+	slices.SortStableFunc(sorted, func(x, y types.Recommendation) int {
+		return cmp.Compare(y.Severity.Rank(), x.Severity.Rank())
+	})
+
+	r.writeSection(b, i18n.SectionRecommendation)
+	for i, rec := range sorted {
+		b.WriteString(strconv.Itoa(i + 1))
+		b.WriteString(". [")
+		b.WriteString(r.severityLabel(rec.Severity))
+		b.WriteString("] ")
+		if rec.Code != "" {
+			b.WriteString(string(rec.Code))
+			b.WriteString(": ")
+		}
+		b.WriteString(i18n.Translate(r.lang, rec.Message))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+}
+
+// writeConfigDrift warns when the analysis was recorded under different runtime
+// settings than the current process, since its conclusions may not carry over
+func (r *Reporter) writeConfigDrift(b *strings.Builder) {
+	drift := types.DetectConfigDrift(r.analysis.Runtime, types.CurrentRuntimeInfo())
+	if len(drift) == 0 {
+		return
+	}
+
+	r.writeSection(b, i18n.SectionConfigDrift)
+	b.WriteString(r.t(i18n.MsgConfigDrift))
+	b.WriteString("\n")
+	for _, d := range drift {
+		b.WriteString("- ")
+		b.WriteString(d.String())
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+}
+
+// severityLabel returns the localized upper-case label for a severity
+func (r *Reporter) severityLabel(s types.Severity) string {
+	switch s {
+	case types.SeverityCritical:
+		return r.t(i18n.SeverityCritical)
+	case types.SeverityWarning:
+		return r.t(i18n.SeverityWarning)
+	default:
+		return r.t(i18n.SeverityInfo)
+	}
+}
+
+// capitalize upper-cases the first letter of an ASCII sentence
+func capitalize(s string) string {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return s
+	}
+	return string(s[0]-'a'+'A') + s[1:]
+}
+
+// formatNumber formats f with the configured number format, or with the
+// output's default number of decimal places when none is configured
+func (r *Reporter) formatNumber(f float64, decimals int) string {
+	if r.numbers != nil {
+		return r.numbers.Format(f)
+	}
+	return formatFloat(f, decimals)
+}
+
+// formatBytes formats a byte size with the configured number format
+func (r *Reporter) formatBytes(bytes uint64) string {
+	if r.numbers != nil {
+		return types.FormatBytesWith(bytes, *r.numbers)
+	}
+	return types.FormatBytes(bytes)
+}
+
+// formatBytesRate formats a byte rate with the configured number format
+func (r *Reporter) formatBytesRate(bytesPerSecond float64) string {
+	if r.numbers != nil {
+		return types.FormatBytesRateWith(bytesPerSecond, *r.numbers)
+	}
+	return types.FormatBytesRate(bytesPerSecond)
 }
 
 // formatFloat formats a float with the specified number of decimal places
@@ -181,6 +1064,9 @@ type JSONReportOptions struct {
 	IncludeEvents bool
 	// CompactPauseData omits pause slice data from metrics to reduce size
 	CompactPauseData bool
+	// Alerts, when set, are included as the alert history, e.g. from
+	// Monitor.GetAlerts
+	Alerts []*types.AlertRecord
 }
 
 // GenerateJSONReport generates a JSON report
@@ -232,13 +1118,15 @@ func (r *Reporter) GenerateJSONReportWithOptions(w io.Writer, opts JSONReportOpt
 		}
 
 		report = struct {
-			Analysis *types.GCAnalysis `json:"analysis"`
-			Metrics  []compactMetrics  `json:"metrics,omitempty"`
-			Events   []*types.GCEvent  `json:"events,omitempty"`
+			Analysis *types.GCAnalysis    `json:"analysis"`
+			Metrics  []compactMetrics     `json:"metrics,omitempty"`
+			Events   []*types.GCEvent     `json:"events,omitempty"`
+			Alerts   []*types.AlertRecord `json:"alerts,omitempty"`
 		}{
 			Analysis: r.analysis,
 			Metrics:  compact,
 			Events:   events,
+			Alerts:   opts.Alerts,
 		}
 	} else {
 		var metrics []*types.GCMetrics
@@ -252,22 +1140,88 @@ func (r *Reporter) GenerateJSONReportWithOptions(w io.Writer, opts JSONReportOpt
 		}
 
 		report = struct {
-			Analysis *types.GCAnalysis  `json:"analysis"`
-			Metrics  []*types.GCMetrics `json:"metrics,omitempty"`
-			Events   []*types.GCEvent   `json:"events,omitempty"`
+			Analysis *types.GCAnalysis    `json:"analysis"`
+			Metrics  []*types.GCMetrics   `json:"metrics,omitempty"`
+			Events   []*types.GCEvent     `json:"events,omitempty"`
+			Alerts   []*types.AlertRecord `json:"alerts,omitempty"`
 		}{
 			Analysis: r.analysis,
 			Metrics:  metrics,
 			Events:   events,
+			Alerts:   opts.Alerts,
 		}
 	}
 
-	encoder := json.NewEncoder(w)
+	if r.numbers == nil {
+		encoder := json.NewEncoder(w)
+		if opts.Indent {
+			encoder.SetIndent("", "  ")
+		}
+		return encoder.Encode(report)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
 	if opts.Indent {
 		encoder.SetIndent("", "  ")
 	}
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := w.Write(roundJSONFloats(buf.Bytes(), *r.numbers))
+	return err
+}
 
-	return encoder.Encode(report)
+// roundJSONFloats rewrites the non-integer numbers in encoded JSON with the
+// given number format. Trailing zeros are dropped since JSON has no notion of
+// display precision.
+func roundJSONFloats(data []byte, f types.NumberFormat) []byte {
+	out := make([]byte, 0, len(data))
+	inString, escaped := false, false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i
+			for end < len(data) && strings.IndexByte("+-.eE0123456789", data[end]) >= 0 {
+				end++
+			}
+			num := data[i:end]
+			if v, err := strconv.ParseFloat(string(num), 64); err == nil && bytes.ContainsAny(num, ".eE") {
+				out = append(out, trimZeros(f.Format(v))...)
+			} else {
+				out = append(out, num...)
+			}
+			i = end - 1
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// trimZeros removes trailing zeros after the decimal point, and the point itself
+func trimZeros(s string) string {
+	if !strings.Contains(s, ".") {
+		return s
+	}
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimSuffix(s, ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
 }
 
 // GenerateCompactJSONReport generates a compact JSON report without raw data
@@ -283,7 +1237,7 @@ func (r *Reporter) GenerateCompactJSONReport(w io.Writer) error {
 // It displays metrics in a tabulated format for easy reading.
 func (r *Reporter) GenerateTableReport(w io.Writer) error {
 	if len(r.metrics) == 0 {
-		return ErrNoMetricsData
+		return fmt.Errorf("table report: %w", types.ErrNoMetricsData)
 	}
 
 	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', tabwriter.AlignRight)
@@ -292,9 +1246,18 @@ func (r *Reporter) GenerateTableReport(w io.Writer) error {
 	b := getBuilder()
 	defer putBuilder(b)
 
-	// Header
-	b.WriteString("Timestamp\tGC#\tHeap\tSys\tPause\tObjects\tAlloc/s\n")
-	b.WriteString("---------\t---\t----\t---\t-----\t-------\t-------\n")
+	// The environment and warning lines have no tabs, so they don't affect
+	// column widths
+	if r.analysis != nil {
+		if rt := r.analysis.Runtime; rt != nil {
+			r.writeLabel(b, i18n.SummaryEnvironment)
+			b.WriteString(r.environmentSummary(rt))
+			b.WriteString("\n")
+		}
+		r.writeWarnings(b)
+	}
+	r.writeColumns(b, i18n.ColumnTimestamp, i18n.ColumnGCNumber, i18n.ColumnHeap, i18n.ColumnSys,
+		i18n.ColumnPause, i18n.ColumnObjects, i18n.ColumnAllocRate)
 	if _, err := io.WriteString(tw, b.String()); err != nil {
 		return err
 	}
@@ -309,7 +1272,7 @@ func (r *Reporter) GenerateTableReport(w io.Writer) error {
 			if duration > 0 {
 				allocDiff := metrics.TotalAlloc - prev.TotalAlloc
 				rate := float64(allocDiff) / duration.Seconds()
-				allocRate = types.FormatBytesRate(rate)
+				allocRate = r.formatBytesRate(rate)
 			}
 		}
 
@@ -322,9 +1285,9 @@ func (r *Reporter) GenerateTableReport(w io.Writer) error {
 		b.WriteByte('\t')
 		b.WriteString(strconv.FormatUint(uint64(metrics.NumGC), 10))
 		b.WriteByte('\t')
-		b.WriteString(types.FormatBytes(metrics.HeapAlloc))
+		b.WriteString(r.formatBytes(metrics.HeapAlloc))
 		b.WriteByte('\t')
-		b.WriteString(types.FormatBytes(metrics.Sys))
+		b.WriteString(r.formatBytes(metrics.Sys))
 		b.WriteByte('\t')
 		b.WriteString(avgPause.Round(time.Microsecond).String())
 		b.WriteByte('\t')
@@ -346,44 +1309,74 @@ func (r *Reporter) GenerateTableReport(w io.Writer) error {
 // It provides a quick overview of GC performance metrics.
 func (r *Reporter) GenerateSummaryReport(w io.Writer) error {
 	if r.analysis == nil {
-		return ErrNoAnalysisData
+		return fmt.Errorf("summary report: %w", types.ErrNoAnalysisData)
 	}
 
 	b := getBuilder()
 	defer putBuilder(b)
 	b.Grow(512)
 
-	b.WriteString("GC Summary Report\n")
-	b.WriteString("=================\n\n")
+	title := r.t(i18n.SummaryTitle)
+	b.WriteString(title)
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("=", utf8.RuneCountInString(title)))
+	b.WriteString("\n\n")
 
-	b.WriteString("Period: ")
+	if rt := r.analysis.Runtime; rt != nil {
+		r.writeLabel(b, i18n.SummaryEnvironment)
+		b.WriteString(r.environmentSummary(rt))
+		b.WriteString("\n")
+	}
+	r.writeWarnings(b)
+	r.writeLabel(b, i18n.SummaryPeriod)
 	b.WriteString(r.analysis.Period.Round(time.Second).String())
-	b.WriteString(" | GC Frequency: ")
-	b.WriteString(formatFloat(r.analysis.GCFrequency, 1))
-	b.WriteString("/s | Avg Pause: ")
+	b.WriteString(" | ")
+	r.writeLabel(b, i18n.SummaryGCFrequency)
+	b.WriteString(r.formatNumber(r.analysis.GCFrequency, 1))
+	b.WriteString("/s | ")
+	r.writeLabel(b, i18n.SummaryAvgPause)
 	b.WriteString(r.analysis.AvgPauseTime.Round(time.Microsecond).String())
+	if apdex := r.analysis.PauseApdex; apdex != nil {
+		b.WriteString(" | ")
+		r.writeLabel(b, i18n.LabelPauseApdex)
+		b.WriteString(r.formatNumber(apdex.Score, 2))
+	}
 	b.WriteString("\n")
 
-	b.WriteString("Memory: ")
-	b.WriteString(types.FormatBytes(r.analysis.AvgHeapSize))
-	b.WriteString(" avg, ")
-	b.WriteString(types.FormatBytes(r.analysis.MaxHeapSize))
-	b.WriteString(" max | Alloc Rate: ")
-	b.WriteString(types.FormatBytesRate(r.analysis.AllocRate))
+	r.writeLabel(b, i18n.SummaryMemory)
+	b.WriteString(r.formatBytes(r.analysis.AvgHeapSize))
+	b.WriteByte(' ')
+	b.WriteString(r.t(i18n.SummaryAvg))
+	b.WriteString(", ")
+	b.WriteString(r.formatBytes(r.analysis.MaxHeapSize))
+	b.WriteByte(' ')
+	b.WriteString(r.t(i18n.SummaryMax))
+	b.WriteString(" | ")
+	r.writeLabel(b, i18n.SummaryAllocRate)
+	b.WriteString(r.formatBytesRate(r.analysis.AllocRate))
 	b.WriteString("\n")
 
-	b.WriteString("Efficiency: ")
-	b.WriteString(formatFloat(r.analysis.GCOverhead, 1))
-	b.WriteString("% GC overhead, ")
-	b.WriteString(formatFloat(r.analysis.MemoryEfficiency, 1))
-	b.WriteString("% memory efficiency\n\n")
+	r.writeLabel(b, i18n.SummaryEfficiency)
+	b.WriteString(r.formatNumber(r.analysis.GCOverhead, 1))
+	b.WriteString("% ")
+	b.WriteString(r.t(i18n.SummaryGCOverhead))
+	b.WriteString(", ")
+	b.WriteString(r.formatNumber(r.analysis.MemoryEfficiency, 1))
+	b.WriteString("% ")
+	b.WriteString(r.t(i18n.SummaryMemEfficiency))
+	b.WriteString("\n\n")
 
 	if len(r.analysis.Recommendations) > 0 {
-		b.WriteString("⚠️  Issues found: ")
+		b.WriteString("⚠️  ")
+		r.writeLabel(b, i18n.MsgIssuesFound)
 		b.WriteString(strconv.Itoa(len(r.analysis.Recommendations)))
-		b.WriteString(" recommendations\n")
+		b.WriteByte(' ')
+		b.WriteString(r.t(i18n.SummaryRecommendations))
+		b.WriteString("\n")
 	} else {
-		b.WriteString("✅ No performance issues detected\n")
+		b.WriteString("✅ ")
+		b.WriteString(r.t(i18n.MsgNoIssues))
+		b.WriteString("\n")
 	}
 
 	_, err := io.WriteString(w, b.String())
@@ -394,7 +1387,7 @@ func (r *Reporter) GenerateSummaryReport(w io.Writer) error {
 // It displays detailed information about each GC event.
 func (r *Reporter) GenerateEventsReport(w io.Writer) error {
 	if len(r.events) == 0 {
-		return ErrNoEventsData
+		return fmt.Errorf("events report: %w", types.ErrNoEventsData)
 	}
 
 	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', tabwriter.AlignRight)
@@ -403,9 +1396,10 @@ func (r *Reporter) GenerateEventsReport(w io.Writer) error {
 	b := getBuilder()
 	defer putBuilder(b)
 
-	b.WriteString("=== GC Events Report ===\n\n")
-	b.WriteString("Seq#\tStart Time\tDuration\tTrigger\tHeap Before\tHeap After\tReleased\n")
-	b.WriteString("----\t----------\t--------\t-------\t-----------\t----------\t--------\n")
+	r.writeSection(b, i18n.EventsTitle)
+	b.WriteString("\n")
+	r.writeColumns(b, i18n.ColumnSequence, i18n.ColumnStartTime, i18n.ColumnDuration, i18n.ColumnTrigger,
+		i18n.ColumnHeapBefore, i18n.ColumnHeapAfter, i18n.ColumnReleased)
 	if _, err := io.WriteString(tw, b.String()); err != nil {
 		return err
 	}
@@ -416,15 +1410,28 @@ func (r *Reporter) GenerateEventsReport(w io.Writer) error {
 		b.WriteByte('\t')
 		b.WriteString(event.StartTime.Format("15:04:05.000"))
 		b.WriteByte('\t')
+		if event.IsGap() {
+			// Gap markers carry no pause or heap data
+			b.WriteString("-\t")
+			b.WriteString(r.t(i18n.ColumnMissed))
+			b.WriteByte(' ')
+			b.WriteString(strconv.FormatUint(uint64(event.Missed), 10))
+			b.WriteString("\t-\t-\t-\n")
+			if _, err := io.WriteString(tw, b.String()); err != nil {
+				return err
+			}
+			b.Reset()
+			continue
+		}
 		b.WriteString(event.Duration.Round(time.Microsecond).String())
 		b.WriteByte('\t')
 		b.WriteString(event.TriggerReason)
 		b.WriteByte('\t')
-		b.WriteString(types.FormatBytes(event.HeapBefore))
+		b.WriteString(r.formatBytes(event.HeapBefore))
 		b.WriteByte('\t')
-		b.WriteString(types.FormatBytes(event.HeapAfter))
+		b.WriteString(r.formatBytes(event.HeapAfter))
 		b.WriteByte('\t')
-		b.WriteString(types.FormatBytes(event.HeapReleased))
+		b.WriteString(r.formatBytes(event.HeapReleased))
 		b.WriteByte('\n')
 
 		if _, err := io.WriteString(tw, b.String()); err != nil {
@@ -436,79 +1443,182 @@ func (r *Reporter) GenerateEventsReport(w io.Writer) error {
 	return nil
 }
 
+// MetricsOptions configures the Prometheus exposition of GenerateGrafanaMetricsWithOptions
+type MetricsOptions struct {
+	// Namespace is prepended to every metric name with an underscore, e.g.
+	// "myapp" exports myapp_gc_frequency_total. Must be a valid metric name.
+	Namespace string
+
+	// ConstLabels are added to every metric, e.g. {"env": "prod"}. Analysis
+	// and pod labels of the same name take precedence. Names must be valid
+	// Prometheus label names.
+	ConstLabels map[string]string
+
+	// OmitTimestamps leaves out the explicit sample timestamps, so the
+	// scraper records its own. Prometheus drops samples with timestamps older
+	// than its staleness limits, e.g. when an older analysis is exported.
+	OmitTimestamps bool
+}
+
 // GenerateGrafanaMetrics generates metrics in Prometheus/Grafana format.
 // It outputs metrics in the Prometheus exposition format for integration with monitoring systems.
 func (r *Reporter) GenerateGrafanaMetrics(w io.Writer) error {
+	return r.GenerateGrafanaMetricsWithOptions(w, MetricsOptions{})
+}
+
+// GenerateGrafanaMetricsWithOptions generates Prometheus metrics with a
+// namespace, constant labels or without timestamps. Returns an error wrapping
+// types.ErrInvalidNamespace or types.ErrInvalidLabel if opts names are invalid.
+func (r *Reporter) GenerateGrafanaMetricsWithOptions(w io.Writer, opts MetricsOptions) error {
 	if r.analysis == nil {
-		return ErrNoAnalysisData
+		return fmt.Errorf("metrics export: %w", types.ErrNoAnalysisData)
+	}
+	if err := types.ValidateNamespace(opts.Namespace); err != nil {
+		return fmt.Errorf("metrics export: %w", err)
+	}
+	if err := types.ValidateLabels(opts.ConstLabels); err != nil {
+		return fmt.Errorf("metrics export: %w", err)
 	}
 
 	b := getBuilder()
 	defer putBuilder(b)
 	b.Grow(1024)
 
-	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	var timestamp string
+	if !opts.OmitTimestamps {
+		timestamp = " " + strconv.FormatInt(time.Now().UnixMilli(), 10)
+	}
+	labelSet := r.metricLabelSet()
+	if len(opts.ConstLabels) > 0 {
+		merged := maps.Clone(opts.ConstLabels)
+		maps.Copy(merged, labelSet)
+		labelSet = merged
+	}
+	labels := formatLabels(labelSet)
 
-	b.WriteString("# HELP gc_frequency_total Number of garbage collections per second\n")
-	b.WriteString("# TYPE gc_frequency_total gauge\n")
-	b.WriteString("gc_frequency_total ")
-	b.WriteString(formatFloat(r.analysis.GCFrequency, 6))
-	b.WriteByte(' ')
-	b.WriteString(timestamp)
-	b.WriteString("\n\n")
+	// writeMetric writes a gauge with its HELP and TYPE lines
+	writeMetric := func(name, help, labels, value string) {
+		if opts.Namespace != "" {
+			name = opts.Namespace + "_" + name
+		}
+		b.WriteString("# HELP ")
+		b.WriteString(name)
+		b.WriteByte(' ')
+		b.WriteString(help)
+		b.WriteString("\n# TYPE ")
+		b.WriteString(name)
+		b.WriteString(" gauge\n")
+		b.WriteString(name)
+		b.WriteString(labels)
+		b.WriteByte(' ')
+		b.WriteString(value)
+		b.WriteString(timestamp)
+		b.WriteString("\n\n")
+	}
 
-	b.WriteString("# HELP gc_pause_time_avg_seconds Average GC pause time in seconds\n")
-	b.WriteString("# TYPE gc_pause_time_avg_seconds gauge\n")
-	b.WriteString("gc_pause_time_avg_seconds ")
-	b.WriteString(formatFloat(r.analysis.AvgPauseTime.Seconds(), 6))
-	b.WriteByte(' ')
-	b.WriteString(timestamp)
-	b.WriteString("\n\n")
+	writeMetric("gc_frequency_total", "Number of garbage collections per second", labels,
+		r.formatNumber(r.analysis.GCFrequency, 6))
+	writeMetric("gc_pause_time_avg_seconds", "Average GC pause time in seconds", labels,
+		r.formatNumber(r.analysis.AvgPauseTime.Seconds(), 6))
 
-	b.WriteString("# HELP gc_pause_time_p99_seconds P99 GC pause time in seconds\n")
-	b.WriteString("# TYPE gc_pause_time_p99_seconds gauge\n")
-	b.WriteString("gc_pause_time_p99_seconds ")
-	b.WriteString(formatFloat(r.analysis.P99PauseTime.Seconds(), 6))
-	b.WriteByte(' ')
-	b.WriteString(timestamp)
-	b.WriteString("\n\n")
-
-	b.WriteString("# HELP heap_size_avg_bytes Average heap size in bytes\n")
-	b.WriteString("# TYPE heap_size_avg_bytes gauge\n")
-	b.WriteString("heap_size_avg_bytes ")
-	b.WriteString(strconv.FormatUint(r.analysis.AvgHeapSize, 10))
-	b.WriteByte(' ')
-	b.WriteString(timestamp)
-	b.WriteString("\n\n")
+	// An unknown P99 is left out rather than exported as zero
+	if !r.analysis.IsUnavailable("p99_pause_time") {
+		writeMetric("gc_pause_time_p99_seconds", "P99 GC pause time in seconds", labels,
+			r.formatNumber(r.analysis.P99PauseTime.Seconds(), 6))
+	}
 
-	b.WriteString("# HELP allocation_rate_bytes_per_second Allocation rate in bytes per second\n")
-	b.WriteString("# TYPE allocation_rate_bytes_per_second gauge\n")
-	b.WriteString("allocation_rate_bytes_per_second ")
-	b.WriteString(formatFloat(r.analysis.AllocRate, 2))
-	b.WriteByte(' ')
-	b.WriteString(timestamp)
-	b.WriteString("\n\n")
+	if apdex := r.analysis.PauseApdex; apdex != nil {
+		writeMetric("gc_pause_apdex", "Apdex score of GC pauses against the pause target, 0-1", labels,
+			r.formatNumber(apdex.Score, 4))
+	}
 
-	b.WriteString("# HELP gc_overhead_percent GC overhead as percentage of CPU time\n")
-	b.WriteString("# TYPE gc_overhead_percent gauge\n")
-	b.WriteString("gc_overhead_percent ")
-	b.WriteString(formatFloat(r.analysis.GCOverhead, 2))
-	b.WriteByte(' ')
-	b.WriteString(timestamp)
-	b.WriteString("\n\n")
+	writeMetric("gc_analysis_warnings", "Number of data quality problems limiting how far the analysis can be trusted", labels,
+		strconv.Itoa(len(r.analysis.Warnings)))
+	writeMetric("heap_size_avg_bytes", "Average heap size in bytes", labels,
+		strconv.FormatUint(r.analysis.AvgHeapSize, 10))
+	writeMetric("allocation_rate_bytes_per_second", "Allocation rate in bytes per second", labels,
+		r.formatNumber(r.analysis.AllocRate, 2))
+	writeMetric("gc_overhead_percent", "GC overhead as percentage of CPU time", labels,
+		r.formatNumber(r.analysis.GCOverhead, 2))
+
+	if rt := r.analysis.Runtime; rt != nil {
+		info := maps.Clone(labelSet)
+		if info == nil {
+			info = make(map[string]string, 6)
+		}
+		info["go_version"] = rt.GoVersion
+		info["goos"] = rt.GOOS
+		info["goarch"] = rt.GOARCH
+		info["gomaxprocs"] = strconv.Itoa(rt.GOMAXPROCS)
+		info["gogc"] = types.FormatGOGC(rt.GOGC)
+		info["gomemlimit"] = strconv.FormatUint(rt.GOMemLimit, 10)
+
+		writeMetric("gc_runtime_info", "Runtime configuration the analyzed data was captured under", formatLabels(info), "1")
+	}
 
 	_, err := io.WriteString(w, b.String())
 	return err
 }
 
+// metricLabels returns the Prometheus label set identifying the analyzed
+// process: the analysis labels and the pod, e.g.
+// {namespace="prod",pod="api-0",service="api"}, or "" when there are none.
+// Analysis labels take precedence over pod labels of the same name.
+func (r *Reporter) metricLabels() string {
+	return formatLabels(r.metricLabelSet())
+}
+
+// metricLabelSet returns the labels formatted by metricLabels. The map may be
+// shared and must not be modified.
+func (r *Reporter) metricLabelSet() map[string]string {
+	var labels map[string]string
+	if r.analysis.Runtime != nil {
+		labels = r.analysis.Runtime.Kubernetes.Labels()
+	}
+	if len(r.analysis.Labels) > 0 {
+		labels = maps.Clone(labels)
+		if labels == nil {
+			labels = make(map[string]string, len(r.analysis.Labels))
+		}
+		maps.Copy(labels, r.analysis.Labels)
+	}
+	return labels
+}
+
+// formatLabels formats a Prometheus label set sorted by name, or "" when
+// labels is empty
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	names := slices.Sorted(maps.Keys(labels))
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(name)
+		b.WriteString(`="`)
+		b.WriteString(labelValueEscaper.Replace(labels[name]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// labelValueEscaper escapes Prometheus label values
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
 // GenerateHealthCheck generates a health check status based on GC metrics
 func (r *Reporter) GenerateHealthCheck() *types.HealthCheckStatus {
 	if r.analysis == nil {
 		return &types.HealthCheckStatus{
 			Status:      "unknown",
 			Score:       0,
-			Issues:      []string{"No analysis data available"},
-			Summary:     "Unable to determine GC health status",
+			Issues:      []string{r.t(i18n.HealthNoData)},
+			Summary:     r.t(i18n.HealthUnknown),
 			LastUpdated: time.Now(),
 		}
 	}
@@ -517,41 +1627,57 @@ func (r *Reporter) GenerateHealthCheck() *types.HealthCheckStatus {
 		Status:      "healthy",
 		Score:       100,
 		Issues:      make([]string, 0, 6), // Pre-allocate with estimated capacity
+		PauseApdex:  r.analysis.PauseApdex,
 		LastUpdated: time.Now(),
 	}
 
+	cfg := &r.health
+
 	// Check GC frequency
-	if r.analysis.GCFrequency > types.ThresholdGCFrequencyHigh {
-		status.Score -= types.PenaltyGCFrequency
-		status.Issues = append(status.Issues, "High GC frequency")
+	if r.analysis.GCFrequency > cfg.GCFrequencyHigh {
+		status.Score -= cfg.PenaltyGCFrequency
+		status.Issues = append(status.Issues, r.t(i18n.IssueHighGCFrequency))
 	}
 
 	// Check pause times
-	if r.analysis.AvgPauseTime > types.ThresholdAvgPauseLong {
-		status.Score -= types.PenaltyAvgPause
-		status.Issues = append(status.Issues, "Long average pause times")
+	if r.analysis.AvgPauseTime > cfg.AvgPauseLong {
+		status.Score -= cfg.PenaltyAvgPause
+		status.Issues = append(status.Issues, r.t(i18n.IssueLongAvgPause))
 	}
-	if r.analysis.P99PauseTime > types.ThresholdP99PauseVeryLong {
-		status.Score -= types.PenaltyP99Pause
-		status.Issues = append(status.Issues, "Very long P99 pause times")
+	if r.analysis.P99PauseTime > cfg.P99PauseVeryLong {
+		status.Score -= cfg.PenaltyP99Pause
+		status.Issues = append(status.Issues, r.t(i18n.IssueVeryLongP99Pause))
 	}
 
 	// Check GC overhead
-	if r.analysis.GCOverhead > types.ThresholdGCOverheadHigh {
-		status.Score -= types.PenaltyGCOverhead
-		status.Issues = append(status.Issues, "High GC overhead")
+	if r.analysis.GCOverhead > cfg.GCOverheadHigh {
+		status.Score -= cfg.PenaltyGCOverhead
+		status.Issues = append(status.Issues, r.t(i18n.IssueHighGCOverhead))
 	}
 
 	// Check memory efficiency
-	if r.analysis.MemoryEfficiency > 0 && r.analysis.MemoryEfficiency < types.ThresholdMemoryEfficiencyLow {
-		status.Score -= types.PenaltyMemoryEfficiency
-		status.Issues = append(status.Issues, "Low memory efficiency")
+	if r.analysis.MemoryEfficiency > 0 && r.analysis.MemoryEfficiency < cfg.MemoryEfficiencyLow {
+		status.Score -= cfg.PenaltyMemoryEfficiency
+		status.Issues = append(status.Issues, r.t(i18n.IssueLowMemEfficiency))
 	}
 
 	// Check allocation rate
-	if r.analysis.AllocRate > types.ThresholdAllocationRateHigh {
-		status.Score -= types.PenaltyAllocationRate
-		status.Issues = append(status.Issues, "High allocation rate")
+	if r.analysis.AllocRate > cfg.AllocationRateHigh {
+		status.Score -= cfg.PenaltyAllocationRate
+		status.Issues = append(status.Issues, r.t(i18n.IssueHighAllocRate))
+	}
+
+	// Check projected memory exhaustion
+	if f := r.analysis.OOMForecast; f != nil && f.WillExceed &&
+		(f.Exceeded || f.TimeToLimit <= cfg.OOMForecastWarning) {
+		status.Score -= cfg.PenaltyOOMForecast
+		status.Issues = append(status.Issues, capitalize(f.Summary()))
+	}
+
+	// Check usage against the container memory limit
+	if r.analysis.MemoryLimit > 0 && r.analysis.MemoryLimitUsage > cfg.MemoryLimitUsageHigh {
+		status.Score -= cfg.PenaltyMemoryLimitUsage
+		status.Issues = append(status.Issues, r.t(i18n.IssueNearMemoryLimit))
 	}
 
 	// Ensure score doesn't go below 0
@@ -561,15 +1687,15 @@ func (r *Reporter) GenerateHealthCheck() *types.HealthCheckStatus {
 
 	// Determine status based on score
 	switch {
-	case status.Score >= types.HealthScoreHealthy:
+	case status.Score >= cfg.HealthyScore:
 		status.Status = "healthy"
-		status.Summary = "GC performance is good"
-	case status.Score >= types.HealthScoreWarning:
+		status.Summary = r.t(i18n.HealthGood)
+	case status.Score >= cfg.WarningScore:
 		status.Status = "warning"
-		status.Summary = "GC performance needs attention"
+		status.Summary = r.t(i18n.HealthNeedsAttention)
 	default:
 		status.Status = "critical"
-		status.Summary = "GC performance issues detected"
+		status.Summary = r.t(i18n.HealthIssuesDetected)
 	}
 
 	return status
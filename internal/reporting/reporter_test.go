@@ -3,10 +3,15 @@ package reporting
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/i18n"
 	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
 )
 
@@ -131,11 +136,89 @@ func TestGenerateTextReport_NilAnalysis(t *testing.T) {
 	var buf bytes.Buffer
 	err := reporter.GenerateTextReport(&buf)
 
-	if err != ErrNoAnalysisData {
+	if !errors.Is(err, types.ErrNoAnalysisData) {
 		t.Errorf("Expected ErrNoAnalysisData, got %v", err)
 	}
 }
 
+func TestGenerateTextReport_Korean(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.Recommendations = []string{
+		i18n.T(i18n.English, i18n.RecHighGCFrequency),
+		"Custom recommendation",
+	}
+	reporter := NewWithOptions(analysis, nil, nil, &Options{Language: i18n.Korean})
+
+	var buf bytes.Buffer
+	if err := reporter.GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+
+	output := buf.String()
+	expected := []string{
+		i18n.T(i18n.Korean, i18n.ReportTitle),
+		i18n.T(i18n.Korean, i18n.SectionRecommendation),
+		i18n.T(i18n.Korean, i18n.RecHighGCFrequency),
+		"Custom recommendation",
+	}
+	for _, s := range expected {
+		if !strings.Contains(output, s) {
+			t.Errorf("Korean report should contain %q", s)
+		}
+	}
+	if strings.Contains(output, "Go GC Analysis Report") {
+		t.Error("Korean report should not contain the English title")
+	}
+}
+
+func TestGenerateSummaryAndEventsReport_Korean(t *testing.T) {
+	reporter := NewWithOptions(createTestAnalysis(), createTestMetrics(2), createTestEvents(2), &Options{Language: i18n.Korean})
+
+	var buf bytes.Buffer
+	if err := reporter.GenerateSummaryReport(&buf); err != nil {
+		t.Fatalf("GenerateSummaryReport() error: %v", err)
+	}
+	if err := reporter.GenerateEventsReport(&buf); err != nil {
+		t.Fatalf("GenerateEventsReport() error: %v", err)
+	}
+	if err := reporter.GenerateTableReport(&buf); err != nil {
+		t.Fatalf("GenerateTableReport() error: %v", err)
+	}
+
+	output := buf.String()
+	for _, key := range []i18n.Key{i18n.SummaryTitle, i18n.SummaryPeriod, i18n.MsgIssuesFound,
+		i18n.EventsTitle, i18n.ColumnHeapBefore, i18n.ColumnTimestamp} {
+		if !strings.Contains(output, i18n.T(i18n.Korean, key)) {
+			t.Errorf("Korean reports should contain %q", i18n.T(i18n.Korean, key))
+		}
+	}
+	for _, english := range []string{"GC Summary Report", "GC Events Report", "Heap Before"} {
+		if strings.Contains(output, english) {
+			t.Errorf("Korean reports should not contain %q", english)
+		}
+	}
+}
+
+func TestGenerateHealthCheck_Korean(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.GCFrequency = 100
+	health := NewWithOptions(analysis, nil, nil, &Options{Language: i18n.Korean}).GenerateHealthCheck()
+
+	if !slices.Contains(health.Issues, i18n.T(i18n.Korean, i18n.IssueHighGCFrequency)) {
+		t.Errorf("Issues = %v, want the Korean high GC frequency issue", health.Issues)
+	}
+	if health.Status == "" || strings.Contains(health.Summary, "GC performance") {
+		t.Errorf("Summary = %q, want Korean text", health.Summary)
+	}
+}
+
+func TestNewWithOptions_UnsupportedLanguage(t *testing.T) {
+	reporter := NewWithOptions(createTestAnalysis(), nil, nil, &Options{Language: "xx"})
+	if reporter.lang != i18n.English {
+		t.Errorf("Unsupported language should fall back to English, got %q", reporter.lang)
+	}
+}
+
 func TestGenerateJSONReport(t *testing.T) {
 	analysis := createTestAnalysis()
 	metrics := createTestMetrics(3)
@@ -198,6 +281,7 @@ func TestGenerateJSONReportWithOptions(t *testing.T) {
 		opts          JSONReportOptions
 		expectMetrics bool
 		expectEvents  bool
+		expectAlerts  bool
 	}{
 		{
 			name: "all included",
@@ -236,6 +320,13 @@ func TestGenerateJSONReportWithOptions(t *testing.T) {
 			expectMetrics: true,
 			expectEvents:  false,
 		},
+		{
+			name: "alert history",
+			opts: JSONReportOptions{
+				Alerts: []*types.AlertRecord{{Alert: &types.Alert{Type: "pause", Severity: "critical"}}},
+			},
+			expectAlerts: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -261,6 +352,9 @@ func TestGenerateJSONReportWithOptions(t *testing.T) {
 			if hasEvents != tt.expectEvents {
 				t.Errorf("events presence = %v, want %v", hasEvents, tt.expectEvents)
 			}
+			if hasAlerts := result["alerts"] != nil; hasAlerts != tt.expectAlerts {
+				t.Errorf("alerts presence = %v, want %v", hasAlerts, tt.expectAlerts)
+			}
 		})
 	}
 }
@@ -316,7 +410,7 @@ func TestGenerateTableReport_NoMetrics(t *testing.T) {
 	var buf bytes.Buffer
 	err := reporter.GenerateTableReport(&buf)
 
-	if err != ErrNoMetricsData {
+	if !errors.Is(err, types.ErrNoMetricsData) {
 		t.Errorf("Expected ErrNoMetricsData, got %v", err)
 	}
 }
@@ -348,7 +442,7 @@ func TestGenerateSummaryReport_NilAnalysis(t *testing.T) {
 	var buf bytes.Buffer
 	err := reporter.GenerateSummaryReport(&buf)
 
-	if err != ErrNoAnalysisData {
+	if !errors.Is(err, types.ErrNoAnalysisData) {
 		t.Errorf("Expected ErrNoAnalysisData, got %v", err)
 	}
 }
@@ -380,7 +474,7 @@ func TestGenerateEventsReport_NoEvents(t *testing.T) {
 	var buf bytes.Buffer
 	err := reporter.GenerateEventsReport(&buf)
 
-	if err != ErrNoEventsData {
+	if !errors.Is(err, types.ErrNoEventsData) {
 		t.Errorf("Expected ErrNoEventsData, got %v", err)
 	}
 }
@@ -423,17 +517,418 @@ func TestGenerateGrafanaMetrics(t *testing.T) {
 	}
 }
 
+func TestReporter_NumberFormat(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.GCOverhead = 2.3456
+	opts := &Options{Numbers: &types.NumberFormat{Decimals: 1, Rounding: types.RoundTowardZero}}
+	r := NewWithOptions(analysis, nil, nil, opts)
+
+	var text bytes.Buffer
+	if err := r.GenerateTextReport(&text); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	if !strings.Contains(text.String(), "GC Overhead: 2.3%") {
+		t.Errorf("Text report should use the configured precision, got:\n%s", text.String())
+	}
+
+	var prom bytes.Buffer
+	if err := r.GenerateGrafanaMetrics(&prom); err != nil {
+		t.Fatalf("GenerateGrafanaMetrics() error: %v", err)
+	}
+	if !strings.Contains(prom.String(), "gc_overhead_percent 2.3 ") {
+		t.Errorf("Prometheus output should use the configured precision, got:\n%s", prom.String())
+	}
+
+	var js bytes.Buffer
+	if err := r.GenerateCompactJSONReport(&js); err != nil {
+		t.Fatalf("GenerateCompactJSONReport() error: %v", err)
+	}
+	if !strings.Contains(js.String(), `"gc_overhead":2.3,`) {
+		t.Errorf("JSON output should use the configured precision, got:\n%s", js.String())
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(js.Bytes(), &decoded); err != nil {
+		t.Errorf("Rounded JSON should stay valid: %v", err)
+	}
+}
+
+func TestRoundJSONFloats(t *testing.T) {
+	in := `{"a":1.25,"b":-0.001,"c":12,"d":"3.14159","e":1e-7,"f":"\"2.5\"","g":[2.50,100]}`
+	want := `{"a":1.2,"b":0,"c":12,"d":"3.14159","e":0,"f":"\"2.5\"","g":[2.5,100]}`
+	if got := string(roundJSONFloats([]byte(in), types.NumberFormat{Decimals: 1})); got != want {
+		t.Errorf("roundJSONFloats() = %s, want %s", got, want)
+	}
+}
+
+func TestReporter_Kubernetes(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.Runtime = &types.RuntimeInfo{Kubernetes: &types.KubernetesInfo{
+		Pod:         "api-0",
+		Namespace:   "prod",
+		CPULimit:    0.5,
+		MemoryLimit: 256 << 20,
+	}}
+	r := New(analysis, nil, nil)
+
+	var text bytes.Buffer
+	if err := r.GenerateTextReport(&text); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	for _, want := range []string{"Pod: prod/api-0\n", "Pod CPU Limit: 0.50 cores", "Pod Memory Limit: 256.0 MB"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, text.String())
+		}
+	}
+
+	var prom bytes.Buffer
+	if err := r.GenerateGrafanaMetrics(&prom); err != nil {
+		t.Fatalf("GenerateGrafanaMetrics() error: %v", err)
+	}
+	if !strings.Contains(prom.String(), `gc_overhead_percent{namespace="prod",pod="api-0"} 2.5`) {
+		t.Errorf("Metrics should carry pod labels, got:\n%s", prom.String())
+	}
+}
+
+func TestReporter_Environment(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.Runtime = &types.RuntimeInfo{
+		GoVersion:         "go1.22.0",
+		GOOS:              "linux",
+		GOARCH:            "amd64",
+		GOMAXPROCS:        4,
+		NumCPU:            16,
+		GOGC:              -1,
+		GOMemLimit:        512 << 20,
+		CgroupMemoryLimit: 1 << 30,
+		Kubernetes:        &types.KubernetesInfo{Pod: "api-0", Namespace: "prod"},
+	}
+	r := New(analysis, createTestMetrics(3), nil)
+
+	var text bytes.Buffer
+	if err := r.GenerateTextReport(&text); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	want := "=== Environment ===\n" +
+		"Go Version: go1.22.0 (linux/amd64)\n" +
+		"GOMAXPROCS: 4 (16 CPUs)\n" +
+		"GOGC: off\n" +
+		"GOMEMLIMIT: 512.0 MB\n" +
+		"Container Memory Limit: 1.0 GB\n" +
+		"Pod: prod/api-0\n"
+	if !strings.Contains(text.String(), want) {
+		t.Errorf("Text report should contain:\n%s\ngot:\n%s", want, text.String())
+	}
+
+	envLine := "Environment: go1.22.0 linux/amd64, GOMAXPROCS=4, GOGC=off, GOMEMLIMIT=512.0 MB\n"
+	var summary bytes.Buffer
+	if err := r.GenerateSummaryReport(&summary); err != nil {
+		t.Fatalf("GenerateSummaryReport() error: %v", err)
+	}
+	if !strings.Contains(summary.String(), envLine) {
+		t.Errorf("Summary should contain %q, got:\n%s", envLine, summary.String())
+	}
+
+	var table bytes.Buffer
+	if err := r.GenerateTableReport(&table); err != nil {
+		t.Fatalf("GenerateTableReport() error: %v", err)
+	}
+	if !strings.HasPrefix(table.String(), envLine) {
+		t.Errorf("Table should start with %q, got:\n%s", envLine, table.String())
+	}
+
+	var prom bytes.Buffer
+	if err := r.GenerateGrafanaMetrics(&prom); err != nil {
+		t.Fatalf("GenerateGrafanaMetrics() error: %v", err)
+	}
+	wantInfo := `gc_runtime_info{go_version="go1.22.0",goarch="amd64",gogc="off",gomaxprocs="4",gomemlimit="536870912",goos="linux",namespace="prod",pod="api-0"} 1 `
+	if !strings.Contains(prom.String(), wantInfo) {
+		t.Errorf("Metrics should contain %s, got:\n%s", wantInfo, prom.String())
+	}
+	if strings.Contains(prom.String(), `gc_overhead_percent{go_version`) {
+		t.Errorf("Runtime labels should only be on gc_runtime_info, got:\n%s", prom.String())
+	}
+}
+
+func TestGenerateTextReport_TuningChanges(t *testing.T) {
+	analysis := createTestAnalysis()
+	at := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	analysis.TuningChanges = []types.TuningChange{
+		{Setting: "GOGC", Timestamp: at, Before: "100", After: "off"},
+		{Setting: "GOMEMLIMIT", Timestamp: at.Add(time.Minute), Before: "none", After: "512.0 MB"},
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	want := "=== GC Tuning Changes ===\n15:04:05  GOGC: 100 → off\n15:05:05  GOMEMLIMIT: none → 512.0 MB\n"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Report should contain:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
+
+func TestGenerateTextReport_CounterResets(t *testing.T) {
+	analysis := createTestAnalysis()
+	at := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	analysis.CounterResets = []types.CounterReset{{Index: 3, Timestamp: at, Gap: 30 * time.Second}}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	want := "=== Process Restarts ===\n15:04:05  counters reset, 30s since the previous sample\n"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Report should contain:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
+
+func TestReporter_UnavailablePauses(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.MinPauseTime, analysis.P99PauseTime = 0, 0
+	analysis.IntervalMaxAvgPause = 1500 * time.Microsecond
+	analysis.Unavailable = []string{"min_pause_time", "p99_pause_time"}
+	r := New(analysis, nil, nil)
+
+	var text bytes.Buffer
+	if err := r.GenerateTextReport(&text); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	for _, want := range []string{"Min Pause: n/a\n", "P99 Pause: n/a\n", "Max Avg Pause per Interval: 1.5ms\n"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("Text report should contain %q, got:\n%s", want, text.String())
+		}
+	}
+
+	var prom bytes.Buffer
+	if err := r.GenerateGrafanaMetrics(&prom); err != nil {
+		t.Fatalf("GenerateGrafanaMetrics() error: %v", err)
+	}
+	if strings.Contains(prom.String(), "gc_pause_time_p99_seconds") {
+		t.Errorf("Metrics should leave out an unavailable P99, got:\n%s", prom.String())
+	}
+}
+
+func TestReporter_Warnings(t *testing.T) {
+	analysis := createTestAnalysis()
+	warning := i18n.T(i18n.English, i18n.WarnFewSamples) + " (samples: 4)"
+	analysis.Warnings = []string{warning}
+	r := New(analysis, createTestMetrics(4), nil)
+
+	reports := map[string]func(io.Writer) error{
+		"text":    r.GenerateTextReport,
+		"summary": r.GenerateSummaryReport,
+		"table":   r.GenerateTableReport,
+	}
+	for name, generate := range reports {
+		var buf bytes.Buffer
+		if err := generate(&buf); err != nil {
+			t.Fatalf("%s report error: %v", name, err)
+		}
+		if !strings.Contains(buf.String(), "⚠️  "+warning+"\n") {
+			t.Errorf("%s report should show the warning, got:\n%s", name, buf.String())
+		}
+	}
+
+	var text bytes.Buffer
+	if err := r.GenerateTextReport(&text); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	if !strings.Contains(text.String(), "=== Data Quality Warnings ===\n") {
+		t.Errorf("Text report should have a warnings section, got:\n%s", text.String())
+	}
+
+	var prom bytes.Buffer
+	if err := r.GenerateGrafanaMetrics(&prom); err != nil {
+		t.Fatalf("GenerateGrafanaMetrics() error: %v", err)
+	}
+	if !strings.Contains(prom.String(), "gc_analysis_warnings 1 ") {
+		t.Errorf("Metrics should count the warnings, got:\n%s", prom.String())
+	}
+
+	// The message is translated and the detail kept
+	var korean bytes.Buffer
+	if err := NewWithOptions(analysis, nil, nil, &Options{Language: i18n.Korean}).GenerateSummaryReport(&korean); err != nil {
+		t.Fatalf("GenerateSummaryReport() error: %v", err)
+	}
+	if want := i18n.T(i18n.Korean, i18n.WarnFewSamples) + " (samples: 4)"; !strings.Contains(korean.String(), want) {
+		t.Errorf("Korean summary should contain %q, got:\n%s", want, korean.String())
+	}
+}
+
+func TestGenerateTextReport_SoftLimit(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.SoftLimit = &types.SoftLimitAnalysis{
+		Limit:            100 << 20,
+		PeakUsage:        98 << 20,
+		PeakRatio:        0.98,
+		NearLimitShare:   0.5,
+		GCFrequencyNear:  20,
+		GCFrequencyBelow: 1,
+		GCCPUNear:        0.45,
+		Pressured:        true,
+		DeathSpiral:      true,
+		SuggestedLimit:   108 << 20,
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	want := "=== Soft Memory Limit ===\n" +
+		"GOMEMLIMIT: 100.0 MB (peak 98.00% in use)\n" +
+		"Time Near Limit: 50.00%\n" +
+		"GC Frequency Near Limit: 20.00 GCs/second (1.00 below)\n" +
+		"GC CPU Near Limit: 45.00%\n" +
+		"Suggested GOMEMLIMIT: 108.0 MB\n" +
+		"⚠️  GC cycles run back to back near the limit (death spiral)\n"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Report should contain:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
+
+func TestGenerateTextReport_Paging(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.Paging = &types.PagingAnalysis{
+		MajorFaultRate:  25,
+		PeakSwap:        64 << 20,
+		PagingShare:     0.25,
+		AvgPausePaging:  12 * time.Millisecond,
+		AvgPauseOther:   time.Millisecond,
+		SlowIntervals:   4,
+		SlowWhilePaging: 3,
+		Coincident:      true,
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	want := "=== OS Paging ===\n" +
+		"Major Page Faults: 25.00/s\n" +
+		"Peak Swap: 64.0 MB\n" +
+		"Time Paging: 25.00%\n" +
+		"Pause per GC While Paging: 12ms (1ms otherwise)\n" +
+		"⚠️  Long GC pauses coincide with paging; the host is short of memory\n"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Report should contain:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
+
+func TestReporter_Labels(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.Runtime = &types.RuntimeInfo{Kubernetes: &types.KubernetesInfo{Pod: "api-0", Namespace: "prod"}}
+	analysis.Labels = map[string]string{"service": "api", "namespace": "payments", "version": `1.0 "rc"`}
+	r := New(analysis, nil, nil)
+
+	var prom bytes.Buffer
+	if err := r.GenerateGrafanaMetrics(&prom); err != nil {
+		t.Fatalf("GenerateGrafanaMetrics() error: %v", err)
+	}
+	want := `gc_overhead_percent{namespace="payments",pod="api-0",service="api",version="1.0 \"rc\""} 2.5`
+	if !strings.Contains(prom.String(), want) {
+		t.Errorf("Metrics should carry %s, got:\n%s", want, prom.String())
+	}
+
+	var report bytes.Buffer
+	if err := r.GenerateJSONReport(&report, false); err != nil {
+		t.Fatalf("GenerateJSONReport() error: %v", err)
+	}
+	if !strings.Contains(report.String(), `"labels":{"namespace":"payments","service":"api"`) {
+		t.Errorf("JSON report should carry the labels, got:\n%s", report.String())
+	}
+}
+
 func TestGenerateGrafanaMetrics_NilAnalysis(t *testing.T) {
 	reporter := New(nil, nil, nil)
 
 	var buf bytes.Buffer
 	err := reporter.GenerateGrafanaMetrics(&buf)
 
-	if err != ErrNoAnalysisData {
+	if !errors.Is(err, types.ErrNoAnalysisData) {
 		t.Errorf("Expected ErrNoAnalysisData, got %v", err)
 	}
 }
 
+func TestGenerateGrafanaMetrics_TimestampMillis(t *testing.T) {
+	var prom bytes.Buffer
+	before := time.Now().UnixMilli()
+	if err := New(createTestAnalysis(), nil, nil).GenerateGrafanaMetrics(&prom); err != nil {
+		t.Fatalf("GenerateGrafanaMetrics() error: %v", err)
+	}
+	after := time.Now().UnixMilli()
+
+	for _, line := range strings.Split(prom.String(), "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			t.Fatalf("Metric %q should have a name, a value and a timestamp", line)
+		}
+		ts, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			t.Fatalf("Metric %q timestamp: %v", line, err)
+		}
+		// Milliseconds since the epoch, not seconds
+		if ts < before || ts > after {
+			t.Errorf("Metric %q timestamp = %d, want milliseconds in [%d, %d]", line, ts, before, after)
+		}
+	}
+}
+
+func TestGenerateGrafanaMetricsWithOptions(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.Labels = map[string]string{"service": "api", "env": "staging"}
+	analysis.Runtime = &types.RuntimeInfo{GoVersion: "go1.24.0"}
+	r := New(analysis, nil, nil)
+
+	var prom bytes.Buffer
+	err := r.GenerateGrafanaMetricsWithOptions(&prom, MetricsOptions{
+		Namespace:      "myapp",
+		ConstLabels:    map[string]string{"env": "prod", "team": "core"},
+		OmitTimestamps: true,
+	})
+	if err != nil {
+		t.Fatalf("GenerateGrafanaMetricsWithOptions() error: %v", err)
+	}
+	out := prom.String()
+
+	// The analysis label wins over the constant label of the same name, and
+	// the sample ends at its value
+	for _, want := range []string{
+		"# HELP myapp_gc_overhead_percent GC overhead as percentage of CPU time\n",
+		"# TYPE myapp_gc_overhead_percent gauge\n",
+		`myapp_gc_overhead_percent{env="staging",service="api",team="core"} 2.50` + "\n",
+		`myapp_gc_runtime_info{env="staging",`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Metrics should contain %q, got:\n%s", want, out)
+		}
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, "myapp_") {
+			t.Errorf("Metric %q lacks the namespace", line)
+		}
+		if fields := strings.Fields(line[strings.LastIndexByte(line, '}')+1:]); len(fields) != 1 {
+			t.Errorf("Metric %q should have no timestamp", line)
+		}
+	}
+}
+
+func TestGenerateGrafanaMetricsWithOptions_Invalid(t *testing.T) {
+	r := New(createTestAnalysis(), nil, nil)
+	var buf bytes.Buffer
+	if err := r.GenerateGrafanaMetricsWithOptions(&buf, MetricsOptions{Namespace: "my-app"}); !errors.Is(err, types.ErrInvalidNamespace) {
+		t.Errorf("Namespace error = %v, want ErrInvalidNamespace", err)
+	}
+	if err := r.GenerateGrafanaMetricsWithOptions(&buf, MetricsOptions{ConstLabels: map[string]string{"__name__": "x"}}); !errors.Is(err, types.ErrInvalidLabel) {
+		t.Errorf("Label error = %v, want ErrInvalidLabel", err)
+	}
+}
+
 func TestGenerateHealthCheck(t *testing.T) {
 	analysis := createTestAnalysis()
 	reporter := New(analysis, nil, nil)
@@ -548,6 +1043,67 @@ func TestGenerateHealthCheck_Thresholds(t *testing.T) {
 	}
 }
 
+func TestGenerateHealthCheck_Config(t *testing.T) {
+	// 80ms average pauses are fine for a batch job but not for an API
+	analysis := &types.GCAnalysis{
+		GCFrequency:      1.0,
+		AvgPauseTime:     80 * time.Millisecond,
+		P99PauseTime:     90 * time.Millisecond,
+		GCOverhead:       5.0,
+		MemoryEfficiency: 80.0,
+	}
+	if health := New(analysis, nil, nil).GenerateHealthCheck(); health.Score != 100 {
+		t.Errorf("Default config: score = %d, want 100", health.Score)
+	}
+
+	api := types.DefaultHealthCheckConfig()
+	api.AvgPauseLong = 20 * time.Millisecond
+	api.PenaltyAvgPause = 50
+	health := NewWithOptions(analysis, nil, nil, &Options{HealthCheck: &api}).GenerateHealthCheck()
+	if health.Score != 50 || health.Status != "critical" || len(health.Issues) != 1 {
+		t.Errorf("API config: got %+v, want one issue costing 50 points", health)
+	}
+
+	api.WarningScore = 50
+	if health := NewWithOptions(analysis, nil, nil, &Options{HealthCheck: &api}).GenerateHealthCheck(); health.Status != "warning" {
+		t.Errorf("Lower warning score: status = %s, want warning", health.Status)
+	}
+}
+
+func TestGenerateHealthCheck_Profile(t *testing.T) {
+	// 30ms pauses and 20% overhead: costly for an API, fine for a batch job
+	analysis := &types.GCAnalysis{
+		GCFrequency:      1.0,
+		AvgPauseTime:     30 * time.Millisecond,
+		P99PauseTime:     80 * time.Millisecond,
+		GCOverhead:       20.0,
+		MemoryEfficiency: 80.0,
+	}
+	tests := []struct {
+		profile types.HealthProfile
+		want    int
+	}{
+		{"", 100},
+		{types.HealthProfileLatencyCritical, 40},
+		{types.HealthProfileThroughputBatch, 65},
+		{types.HealthProfileMemoryConstrained, 100},
+		{"unknown", 100},
+	}
+	for _, tt := range tests {
+		health := NewWithOptions(analysis, nil, nil, &Options{HealthProfile: tt.profile}).GenerateHealthCheck()
+		if health.Score != tt.want {
+			t.Errorf("Profile %q: score = %d, want %d", tt.profile, health.Score, tt.want)
+		}
+	}
+
+	// An explicit config takes precedence
+	config := types.DefaultHealthCheckConfig()
+	opts := &Options{HealthCheck: &config, HealthProfile: types.HealthProfileLatencyCritical}
+	if health := NewWithOptions(analysis, nil, nil, opts).GenerateHealthCheck(); health.Score != 100 {
+		t.Errorf("HealthCheck with a profile: score = %d, want 100", health.Score)
+	}
+}
+
 // Benchmark tests
 func BenchmarkGenerateTextReport(b *testing.B) {
 	analysis := createTestAnalysis()
@@ -593,3 +1149,648 @@ func BenchmarkGenerateGrafanaMetrics(b *testing.B) {
 		_ = reporter.GenerateGrafanaMetrics(&buf)
 	}
 }
+
+func TestGenerateHealthCheck_OOMForecast(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.OOMForecast = &types.OOMForecast{
+		Limit:       512 * 1024 * 1024,
+		TimeToLimit: 42 * time.Minute,
+		WillExceed:  true,
+	}
+
+	status := New(analysis, nil, nil).GenerateHealthCheck()
+
+	if status.Score != 100-types.PenaltyOOMForecast {
+		t.Errorf("Score = %d, want %d", status.Score, 100-types.PenaltyOOMForecast)
+	}
+	found := false
+	for _, issue := range status.Issues {
+		if issue == "Estimated OOM in ~42 minutes" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Issues should contain OOM estimate, got %v", status.Issues)
+	}
+
+	// Distant projections should not affect health
+	analysis.OOMForecast.TimeToLimit = 48 * time.Hour
+	status = New(analysis, nil, nil).GenerateHealthCheck()
+	if status.Score != 100 {
+		t.Errorf("Distant OOM forecast should not be penalized, got score %d", status.Score)
+	}
+}
+
+func TestGenerateTextReport_SeverityOrder(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.RecommendationDetails = []types.Recommendation{
+		{Severity: types.SeverityInfo, Message: "minor issue"},
+		{Code: types.CodeHighGCOverhead, Severity: types.SeverityCritical, Message: "urgent issue"},
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+
+	output := buf.String()
+	critical := strings.Index(output, "1. [CRITICAL] GC006: urgent issue")
+	info := strings.Index(output, "2. [INFO] minor issue")
+	if critical < 0 || info < 0 {
+		t.Fatalf("Report should list recommendations by severity, got:\n%s", output)
+	}
+}
+
+func TestGenerateTextReport_ConfigDrift(t *testing.T) {
+	analysis := createTestAnalysis()
+
+	// Matching runtime settings produce no drift section
+	analysis.Runtime = types.CurrentRuntimeInfo()
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	if strings.Contains(buf.String(), "Configuration Drift") {
+		t.Error("Report should not contain drift section when settings match")
+	}
+
+	recorded := *analysis.Runtime
+	recorded.GOGC = analysis.Runtime.GOGC + 100
+	analysis.Runtime = &recorded
+
+	buf.Reset()
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "=== Configuration Drift ===") {
+		t.Fatalf("Report should contain drift section, got:\n%s", output)
+	}
+	if !strings.Contains(output, "- GOGC: recorded ") {
+		t.Errorf("Drift section should list GOGC, got:\n%s", output)
+	}
+}
+
+func TestGenerateTextReport_Periodicity(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.Periodicity = &types.PeriodicityAnalysis{Detected: true, Period: 10 * time.Minute, Strength: 0.8}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Periodic workload detected, period ≈ 10m") {
+		t.Errorf("Report should annotate periodic workload, got:\n%s", buf.String())
+	}
+}
+
+func TestGenerateTextReport_Phases(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.Phases = &types.PhaseBreakdown{
+		AvgSweepTermination: 15 * time.Microsecond,
+		AvgConcurrentMark:   890 * time.Microsecond,
+		AvgMarkTermination:  3 * time.Microsecond,
+		Cycles:              4,
+
+		SweepTerminationShare: 0.75,
+		MarkTerminationShare:  0.25,
+		STWShare:              0.02,
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	for _, want := range []string{
+		"=== GC Pause Breakdown ===",
+		"Avg Sweep Termination (STW): 15µs (75.00% of pause)",
+		"Avg Concurrent Mark: 890µs",
+		"Stop-the-World Share of GC Cycle: 2.00%",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestGenerateTextReport_ForcedGCs(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.ForcedGCCount = 4
+	analysis.ForcedGCRatio = 0.25
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Forced GCs: 4 (25.00%)") {
+		t.Errorf("Report should include forced GC count, got:\n%s", buf.String())
+	}
+}
+
+func TestGenerateTextReport_Regions(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.Regions = []types.RegionStats{
+		{Name: "checkout", Calls: 4, Duration: time.Second, AllocBytes: 4 * 1024 * 1024, GCCount: 2, AllocRate: 4 * 1024 * 1024},
+		{Name: "/pkg.Service/Get", Calls: 2, Duration: time.Second, AllocBytes: 1024 * 1024, GCCount: 1, PauseTime: 250 * time.Microsecond, AllocRate: 1024 * 1024},
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	for _, want := range []string{"=== Allocation by Region ===", "checkout: 4.0 MB/s (1.0 MB/call, 4 calls, 2 GCs)",
+		"/pkg.Service/Get: 1.0 MB/s (512.0 KB/call, 2 calls, 1 GCs, 250µs paused)",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestGenerateTextReport_RegionBreakdown(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.RegionBreakdown = []types.RegionBreakdown{
+		{Region: "batch", Duration: 30 * time.Second, GCFrequency: 3.2, AvgPauseTime: 120 * time.Microsecond, AllocRate: 12 * 1024 * 1024},
+		{Duration: time.Minute},
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	for _, want := range []string{
+		"=== Breakdown by Region Label ===",
+		"batch (30s): GC Frequency: 3.20 GCs/second, Average Pause: 120µs, Allocation Rate: 12.0 MB/s",
+		"(untagged) (1m0s)",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestGenerateTextReport_RequestImpact(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.RequestImpact = &types.RequestImpact{
+		Requests:   1500,
+		Window:     1000,
+		GCAffected: 25,
+		PauseP95:   80 * time.Microsecond,
+		PauseP99:   150 * time.Microsecond,
+		PauseMax:   300 * time.Microsecond,
+		AllocP50:   4 * 1024,
+		AllocP95:   64 * 1024,
+		AllocP99:   256 * 1024,
+		AllocMax:   1024 * 1024,
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	for _, want := range []string{
+		"=== Per-Request GC Impact ===",
+		"Requests: 1500 (n=1000)",
+		"Requests Overlapping GC: 25 (2.50%)",
+		"GC Pause per Request: P50 0s, P95 80µs, P99 150µs, Max 300µs",
+		"Allocated per Request: P50 4.0 KB, P95 64.0 KB, P99 256.0 KB, Max 1.0 MB",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestGenerateTextReport_MMU(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.MMU = []types.MMUPoint{
+		{Window: time.Millisecond, Utilization: 0},
+		{Window: 10 * time.Millisecond, Utilization: 0.3},
+		{Window: time.Second, Utilization: 0.993},
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	want := "=== Minimum Mutator Utilization ===\n1ms: 0.00%\n10ms: 30.00%\n1s: 99.30%\n"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Report should contain %q, got:\n%s", want, buf.String())
+	}
+}
+
+func TestPauseApdexOutput(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.PauseApdex = &types.PauseApdex{
+		Score:      0.625,
+		Target:     time.Millisecond,
+		Satisfied:  4,
+		Tolerating: 2,
+		Frustrated: 2,
+	}
+	reporter := New(analysis, nil, nil)
+
+	var buf bytes.Buffer
+	if err := reporter.GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	if want := "Pause Apdex: 0.62 [T=1ms]: 4 satisfied, 2 tolerating, 2 frustrated\n"; !strings.Contains(buf.String(), want) {
+		t.Errorf("Text report should contain %q, got:\n%s", want, buf.String())
+	}
+
+	buf.Reset()
+	if err := reporter.GenerateSummaryReport(&buf); err != nil {
+		t.Fatalf("GenerateSummaryReport() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), " | Pause Apdex: 0.62\n") {
+		t.Errorf("Summary report should contain the Apdex score, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := reporter.GenerateGrafanaMetrics(&buf); err != nil {
+		t.Fatalf("GenerateGrafanaMetrics() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "\ngc_pause_apdex 0.6250 ") {
+		t.Errorf("Prometheus output should contain gc_pause_apdex, got:\n%s", buf.String())
+	}
+
+	if health := reporter.GenerateHealthCheck(); health.PauseApdex != analysis.PauseApdex {
+		t.Errorf("Health PauseApdex = %+v, want the analysis score", health.PauseApdex)
+	}
+}
+
+func TestGenerateTextReport_RobustStats(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.Robust = &types.RobustStats{
+		MedianPause: 105 * time.Microsecond,
+		PauseMAD:    5 * time.Microsecond,
+		Pauses:      100,
+		MedianHeap:  8 * 1024 * 1024,
+		HeapMAD:     512 * 1024,
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	for _, want := range []string{
+		"Median Pause: 105µs (MAD 5µs)\n",
+		"Median Heap Size: 8.0 MB (MAD 512.0 KB)\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestGenerateTextReport_PauseOutliers(t *testing.T) {
+	analysis := createTestAnalysis()
+	at := time.Date(2026, 3, 1, 12, 30, 15, 250*int(time.Millisecond), time.UTC)
+	analysis.PauseOutliers = &types.PauseOutliers{
+		Threshold: 1500 * time.Microsecond,
+		Count:     1,
+		Longest: []types.PauseOutlier{
+			{Sequence: 42, Time: at, Duration: 8 * time.Millisecond, HeapSize: 64 * 1024 * 1024, AllocRate: 12 * 1024 * 1024, Outlier: true},
+			{Sequence: 17, Time: at.Add(-time.Minute), Duration: 900 * time.Microsecond, HeapSize: 32 * 1024 * 1024, AllocRate: 1024 * 1024},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	for _, want := range []string{
+		"=== Longest GC Pauses ===",
+		"Outlier Threshold: 1.5ms",
+		"Outliers: 1",
+		"1. 12:30:15.250 GC #42: 8ms, heap 64.0 MB, alloc 12.0 MB/s, outlier\n",
+		"2. 12:29:15.250 GC #17: 900µs, heap 32.0 MB, alloc 1.0 MB/s\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := New(analysis, nil, nil).GenerateJSONReport(&buf, false); err != nil {
+		t.Fatalf("GenerateJSONReport() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"pause_outliers":{"threshold":1500000,"count":1,"longest":[{"sequence":42,`) {
+		t.Errorf("JSON report should list the longest pauses, got:\n%s", buf.String())
+	}
+}
+
+func TestGenerateTextReport_Reclamation(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.Reclamation = &types.ReclamationAnalysis{
+		Cycles:           30,
+		AvgReclaimed:     3 * 1024 * 1024,
+		AvgSurvivalRatio: 0.45,
+		ReclaimedTrend:   -0.4,
+		SurvivalTrend:    0.25,
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	for _, want := range []string{
+		"=== GC Reclamation ===",
+		"Reclaimed per Cycle: 3.0 MB",
+		"Reclaimed Trend: -40.00%",
+		"Survival Ratio: 45.00%",
+		"Survival Trend: +25.00 pts",
+		"Cycles with Heap Data: 30",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestGenerateTextReport_SizeClasses(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.SizeClasses = &types.SizeClassDistribution{
+		Buckets: []types.SizeClassBucket{
+			{Class: types.SizeClassTiny, Bytes: 1024, Share: 0.1},
+			{Class: types.SizeClassSmall, Bytes: 8 * 1024, Share: 0.8},
+			{Class: types.SizeClassLarge, Bytes: 1024, Share: 0.1},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	for _, want := range []string{"=== Allocation Size Classes ===", "Small (16 B - 32 KB): 80.00% (8.0 KB)"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestGenerateTextReport_GCCPU(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.GCCPU = &types.GCCPUBreakdown{TotalSeconds: 2, AssistShare: 0.4, BackgroundShare: 0.5, PauseShare: 0.1}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Mark Assist Share of GC CPU: 40.00%") {
+		t.Errorf("Report should include mark assist share, got:\n%s", buf.String())
+	}
+}
+
+func TestGenerateTextReport_ProcessCPU(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.GCCPU = &types.GCCPUBreakdown{TotalSeconds: 3}
+	analysis.ProcessCPU = &types.ProcessCPUAnalysis{ProcessSeconds: 15, GCSeconds: 3, GCShare: 0.2, Utilization: 0.9375}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	for _, want := range []string{"GC CPU Time: 3.00s", "Process CPU Utilization: 93.75%", "GC Share of Process CPU: 20.00%"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestGenerateHealthCheck_MemoryLimit(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.MemoryLimit = 512 << 20
+	analysis.MemoryLimitUsage = 0.95
+
+	status := New(analysis, nil, nil).GenerateHealthCheck()
+	if status.Score != 100-types.PenaltyMemoryLimitUsage || !slices.Contains(status.Issues, "Memory usage near container limit") {
+		t.Errorf("Expected container limit penalty, got score %d, issues %v", status.Score, status.Issues)
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Container Memory Limit: 512.0 MB (95.00% in use)") {
+		t.Errorf("Report should include the container limit, got:\n%s", buf.String())
+	}
+}
+
+func TestGenerateUpgradeReport(t *testing.T) {
+	c := &types.UpgradeComparison{
+		FromVersion: "go1.21.0",
+		ToVersion:   "go1.22.0",
+		After:       createTestAnalysis(),
+		Deltas: []types.MetricDelta{
+			{Metric: types.DeltaP99Pause, Before: float64(2 * time.Millisecond), After: float64(time.Millisecond), Change: -0.5},
+			{Metric: types.DeltaGCOverhead, Before: 4, After: 5, Change: 0.25},
+			{Metric: types.DeltaRSS, Before: float64(200 * types.MB), After: float64(150 * types.MB), Change: -0.25},
+		},
+		Notes: []string{"Go 1.22 note"},
+	}
+
+	var buf bytes.Buffer
+	if err := GenerateUpgradeReport(&buf, c, nil); err != nil {
+		t.Fatalf("GenerateUpgradeReport() error: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{
+		"=== Go Runtime Upgrade: go1.21.0 → go1.22.0 ===",
+		"P99 Pause: 2ms → 1ms (-50.0%, improved)",
+		"GC Overhead: 4.00% → 5.00% (+25.0%, regressed)",
+		"Average RSS: 200.0 MB → 150.0 MB (-25.0%, improved)",
+		"- Go 1.22 note",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Report missing %q, got:\n%s", want, output)
+		}
+	}
+
+	if err := GenerateUpgradeReport(&buf, nil, nil); !errors.Is(err, types.ErrNoAnalysisData) {
+		t.Errorf("Expected ErrNoAnalysisData for nil comparison, got %v", err)
+	}
+}
+
+func TestGenerateUpgradeReport_Builds(t *testing.T) {
+	c := &types.UpgradeComparison{
+		FromVersion: "go1.22.0",
+		ToVersion:   "go1.22.0",
+		FromLabel:   "v1.4.0",
+		ToLabel:     "v1.5.0",
+		After:       createTestAnalysis(),
+		Deltas: []types.MetricDelta{
+			{Metric: types.DeltaAvgPause, Before: float64(time.Millisecond), After: float64(time.Millisecond)},
+		},
+		Changes: []types.BuildChange{
+			{Component: "golang.org/x/net", Before: "v0.20.0", After: "v0.21.0"},
+			{Component: "github.com/example/cache", After: "v1.0.0"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := GenerateUpgradeReport(&buf, c, nil); err != nil {
+		t.Fatalf("GenerateUpgradeReport() error: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{
+		"=== Build Comparison: v1.4.0 → v1.5.0 ===",
+		"Average Pause: 1ms → 1ms (0.0%, unchanged)",
+		"=== Build Changes ===",
+		"- golang.org/x/net: v0.20.0 → v0.21.0",
+		"- github.com/example/cache: none → v1.0.0",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Report missing %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestGenerateTextReport_RSS(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.RSS = &types.RSSAnalysis{
+		AvgRSS:       128 << 20,
+		AvgGoManaged: 96 << 20,
+		AvgNonGo:     32 << 20,
+		NonGoShare:   0.25,
+		NonGoGrowth:  1 << 20,
+		NonGoGrowing: true,
+		Sys:          types.SysBreakdown{Heap: 80 << 20, Stack: 4 << 20},
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	for _, want := range []string{
+		"=== Resident Memory vs Go-Managed Memory ===",
+		"Non-Go Memory: 32.0 MB (25.00%)",
+		"Non-Go Growth: ",
+		"Sys Breakdown: heap 80.0 MB, stack 4.0 MB",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestGenerateTextReport_AllocSites(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.AllocSites = &types.AllocSiteReport{
+		Sites: []types.AllocSite{
+			{Function: "main.decode", File: "/src/app/decode.go", Line: 42, Bytes: 60 << 20, BytesPerSec: 2 << 20, Share: 0.75},
+		},
+		Window:     30 * time.Second,
+		TotalBytes: 80 << 20,
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	for _, want := range []string{"=== Top Allocation Sites ===", "1. main.decode (decode.go:42): 2.0 MB/s, 75.00%"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := New(analysis, nil, nil).GenerateJSONReport(&buf, false); err != nil {
+		t.Fatalf("GenerateJSONReport() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"alloc_sites":{"sites":[{"function":"main.decode"`) {
+		t.Errorf("JSON report should carry the allocation sites, got:\n%s", buf.String())
+	}
+}
+
+func TestGenerateTextReport_LiveHeap(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.AvgLiveHeap = 48 << 20
+	analysis.LiveHeapGrowthRate = 512 << 10
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	for _, want := range []string{"Average Live Heap: 48.0 MB", "Live Heap Growth Rate: 512.0 KB/s"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestGenerateTextReport_PauseDistribution(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.PauseDistribution = &types.PauseDistribution{
+		Pauses:           120,
+		P50:              128 * time.Microsecond,
+		P95:              512 * time.Microsecond,
+		P99:              time.Millisecond,
+		Max:              4 * time.Millisecond,
+		EventsIncomplete: true,
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	for _, want := range []string{
+		"Stop-the-World Pauses: 120, P50 ≤ 128µs, P95 ≤ 512µs, P99 ≤ 1ms, Max ≤ 4ms",
+		"GC events missed cycles",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestGenerateReports_MissedEvents(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.MissedEvents = 44
+	events := []*types.GCEvent{{Sequence: 101, StartTime: time.Now(), Missed: 44}}
+
+	var buf bytes.Buffer
+	r := New(analysis, nil, events)
+	if err := r.GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Missed GC Events: 44") {
+		t.Errorf("Report should count missed events, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := r.GenerateEventsReport(&buf); err != nil {
+		t.Fatalf("GenerateEventsReport() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "missed 44") {
+		t.Errorf("Events report should show the gap, got:\n%s", buf.String())
+	}
+}
+
+func TestGenerateTextReport_PauseQuantiles(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.PauseQuantiles = &types.PauseQuantiles{
+		NumGC:      1234,
+		PauseTotal: 56 * time.Millisecond,
+		Pauses:     256,
+		P50:        120 * time.Microsecond,
+		P95:        300 * time.Microsecond,
+		P99:        time.Millisecond,
+		Max:        2 * time.Millisecond,
+	}
+
+	var buf bytes.Buffer
+	if err := New(analysis, nil, nil).GenerateTextReport(&buf); err != nil {
+		t.Fatalf("GenerateTextReport() error: %v", err)
+	}
+	for _, want := range []string{
+		"GCs Since Start: 1234 (56ms)",
+		"Recent Pause Quantiles: P50 120µs, P95 300µs, P99 1ms, Max 2ms (n=256)",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Report should contain %q, got:\n%s", want, buf.String())
+		}
+	}
+}
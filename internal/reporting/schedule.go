@@ -0,0 +1,167 @@
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// ReportFormat selects the report a Schedule emits
+type ReportFormat string
+
+// Scheduled report formats
+const (
+	ReportFormatSummary ReportFormat = "summary"
+	ReportFormatText    ReportFormat = "text"
+	ReportFormatTable   ReportFormat = "table"
+	ReportFormatJSON    ReportFormat = "json"
+)
+
+// scheduleTimeout bounds each webhook delivery
+const scheduleTimeout = 10 * time.Second
+
+// Schedule configures periodic report generation. At least one destination,
+// Writer, Path or WebhookURL, must be set; each report goes to all of them.
+type Schedule struct {
+	// Interval is the time between reports
+	Interval time.Duration
+
+	// Format is the report to generate (default: ReportFormatSummary)
+	Format ReportFormat
+
+	// Options sets the language, number format and charts of the reports.
+	// The monitor's health check settings apply unless it sets its own.
+	Options *Options
+
+	// Writer receives every report in turn, e.g. os.Stdout
+	Writer io.Writer
+
+	// Path is a file replaced with the latest report each time
+	Path string
+
+	// WebhookURL receives a POST with each report as its body
+	WebhookURL string
+}
+
+// Validate checks the schedule, returning an error wrapping
+// types.ErrInvalidReportSchedule if it is malformed
+func (s *Schedule) Validate() error {
+	if s.Interval <= 0 {
+		return fmt.Errorf("%w: interval %v must be positive", types.ErrInvalidReportSchedule, s.Interval)
+	}
+	switch s.Format {
+	case "", ReportFormatSummary, ReportFormatText, ReportFormatTable, ReportFormatJSON:
+	default:
+		return fmt.Errorf("%w: unknown format %q", types.ErrInvalidReportSchedule, s.Format)
+	}
+	if s.Writer == nil && s.Path == "" && s.WebhookURL == "" {
+		return fmt.Errorf("%w: no destination", types.ErrInvalidReportSchedule)
+	}
+	if s.WebhookURL != "" {
+		u, err := url.Parse(s.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: webhook URL %q must be absolute http(s)", types.ErrInvalidReportSchedule, s.WebhookURL)
+		}
+	}
+	return nil
+}
+
+// GenerateReport writes the report of the given format
+func (r *Reporter) GenerateReport(w io.Writer, format ReportFormat) error {
+	switch format {
+	case "", ReportFormatSummary:
+		return r.GenerateSummaryReport(w)
+	case ReportFormatText:
+		return r.GenerateTextReport(w)
+	case ReportFormatTable:
+		return r.GenerateTableReport(w)
+	case ReportFormatJSON:
+		return r.GenerateJSONReport(w, true)
+	default:
+		return fmt.Errorf("%w: unknown format %q", types.ErrInvalidReportSchedule, format)
+	}
+}
+
+// Emit generates a report with r and delivers it to every destination of
+// the schedule, returning the errors of those that failed
+func (s *Schedule) Emit(ctx context.Context, r *Reporter) error {
+	var report bytes.Buffer
+	if err := r.GenerateReport(&report, s.Format); err != nil {
+		return err
+	}
+
+	var errs []error
+	if s.Writer != nil {
+		if _, err := s.Writer.Write(report.Bytes()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if s.Path != "" {
+		errs = append(errs, replaceFile(s.Path, report.Bytes()))
+	}
+	if s.WebhookURL != "" {
+		errs = append(errs, s.post(ctx, report.Bytes()))
+	}
+	return errors.Join(errs...)
+}
+
+// contentType returns the media type of the schedule's reports
+func (s *Schedule) contentType() string {
+	if s.Format == ReportFormatJSON {
+		return "application/json"
+	}
+	return "text/plain; charset=utf-8"
+}
+
+// post sends a report to the webhook
+func (s *Schedule) post(ctx context.Context, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, scheduleTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", s.contentType())
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", s.WebhookURL, resp.Status)
+	}
+	return nil
+}
+
+// replaceFile writes data to a temporary file beside path and renames it
+// over path, so readers never see a partial report
+func replaceFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,127 @@
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestSchedule_Validate(t *testing.T) {
+	var buf bytes.Buffer
+	tests := []struct {
+		name     string
+		schedule Schedule
+		valid    bool
+	}{
+		{"writer", Schedule{Interval: time.Minute, Writer: &buf}, true},
+		{"path and webhook", Schedule{Interval: time.Minute, Format: ReportFormatJSON, Path: "gc.json", WebhookURL: "https://example.com/reports"}, true},
+		{"no interval", Schedule{Writer: &buf}, false},
+		{"no destination", Schedule{Interval: time.Minute}, false},
+		{"unknown format", Schedule{Interval: time.Minute, Writer: &buf, Format: "xml"}, false},
+		{"relative webhook", Schedule{Interval: time.Minute, WebhookURL: "/reports"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schedule.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Validate() error: %v", err)
+			}
+			if !tt.valid && !errors.Is(err, types.ErrInvalidReportSchedule) {
+				t.Errorf("Validate() = %v, want ErrInvalidReportSchedule", err)
+			}
+		})
+	}
+}
+
+func TestGenerateReport_Formats(t *testing.T) {
+	reporter := New(createTestAnalysis(), createTestMetrics(5), nil)
+	for format, want := range map[ReportFormat]string{
+		"":                  "GC Summary",
+		ReportFormatSummary: "GC Summary",
+		ReportFormatText:    "=== Go GC Analysis Report ===",
+		ReportFormatTable:   "Timestamp",
+		ReportFormatJSON:    `"analysis"`,
+	} {
+		var buf bytes.Buffer
+		if err := reporter.GenerateReport(&buf, format); err != nil {
+			t.Fatalf("GenerateReport(%q) error: %v", format, err)
+		}
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("GenerateReport(%q) should contain %q, got:\n%s", format, want, buf.String())
+		}
+	}
+
+	if err := reporter.GenerateReport(io.Discard, "xml"); !errors.Is(err, types.ErrInvalidReportSchedule) {
+		t.Errorf("GenerateReport(xml) = %v, want ErrInvalidReportSchedule", err)
+	}
+}
+
+func TestSchedule_Emit(t *testing.T) {
+	var received []byte
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		contentType = r.Header.Get("Content-Type")
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	path := filepath.Join(t.TempDir(), "gc.json")
+	schedule := &Schedule{
+		Interval:   time.Minute,
+		Format:     ReportFormatJSON,
+		Writer:     &buf,
+		Path:       path,
+		WebhookURL: server.URL,
+	}
+	if err := schedule.Emit(context.Background(), New(createTestAnalysis(), nil, nil)); err != nil {
+		t.Fatalf("Emit() error: %v", err)
+	}
+
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Report file not written: %v", err)
+	}
+	if !json.Valid(buf.Bytes()) || !bytes.Equal(written, buf.Bytes()) || !bytes.Equal(received, buf.Bytes()) {
+		t.Error("Every destination should receive the same JSON report")
+	}
+	if contentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", contentType)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("Temporary files left behind: %v", entries)
+	}
+}
+
+func TestSchedule_EmitErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	schedule := &Schedule{
+		Interval:   time.Minute,
+		Writer:     &buf,
+		Path:       filepath.Join(t.TempDir(), "missing", "gc.txt"),
+		WebhookURL: server.URL,
+	}
+	err := schedule.Emit(context.Background(), New(createTestAnalysis(), nil, nil))
+	if err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("Emit() = %v, want the file and webhook errors", err)
+	}
+	if buf.Len() == 0 {
+		t.Error("Destinations that work should still receive the report")
+	}
+}
@@ -0,0 +1,163 @@
+package reporting
+
+import (
+	"cmp"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/i18n"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// ChartType selects the chart GenerateChartSVG draws
+type ChartType string
+
+// Chart types
+const (
+	ChartTypeHeapTrend      ChartType = "heap_trend"      // HeapAlloc over time, from the metrics
+	ChartTypePauseHistogram ChartType = "pause_histogram" // pause counts per duration bucket, from the events
+)
+
+// ChartOptions configures SVG charts
+type ChartOptions struct {
+	// Width and Height are the chart's size in pixels (default: 800×300)
+	Width, Height int
+
+	// Title is drawn above the chart (default: the chart's localized name)
+	Title string
+}
+
+// Chart layout, in pixels
+const (
+	svgMarginLeft   = 80
+	svgMarginRight  = 20
+	svgMarginTop    = 40
+	svgMarginBottom = 40
+	svgColor        = "#4e79a7"
+)
+
+// GenerateChartSVG writes chart as a standalone SVG document, for embedding
+// in HTML reports and wikis. A nil opts uses the defaults.
+func (r *Reporter) GenerateChartSVG(w io.Writer, chart ChartType, opts *ChartOptions) error {
+	o := ChartOptions{Width: 800, Height: 300}
+	if opts != nil {
+		if opts.Width > 0 {
+			o.Width = opts.Width
+		}
+		if opts.Height > 0 {
+			o.Height = opts.Height
+		}
+		o.Title = opts.Title
+	}
+
+	b := getBuilder()
+	defer putBuilder(b)
+
+	switch chart {
+	case ChartTypeHeapTrend:
+		if len(r.metrics) < 2 {
+			return fmt.Errorf("%s chart: %w", chart, types.ErrNoMetricsData)
+		}
+		r.svgHeapTrend(b, o)
+	case ChartTypePauseHistogram:
+		if len(r.events) == 0 {
+			return fmt.Errorf("%s chart: %w", chart, types.ErrNoEventsData)
+		}
+		r.svgPauseHistogram(b, o)
+	default:
+		return fmt.Errorf("%w: %q", types.ErrUnknownChartType, chart)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// svgStart writes the document header, background and title
+func svgStart(b *strings.Builder, o ChartOptions, title string) {
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		o.Width, o.Height, o.Width, o.Height)
+	fmt.Fprintf(b, `<rect width="%d" height="%d" fill="white"/>`+"\n", o.Width, o.Height)
+	fmt.Fprintf(b, `<text x="%d" y="24" font-size="16" font-weight="bold">%s</text>`+"\n", svgMarginLeft, html.EscapeString(title))
+}
+
+// svgAxes writes the plot area's axes
+func svgAxes(b *strings.Builder, o ChartOptions) {
+	x0, y0 := svgMarginLeft, o.Height-svgMarginBottom
+	fmt.Fprintf(b, `<path d="M%d %d V%d H%d" fill="none" stroke="#333"/>`+"\n", x0, svgMarginTop, y0, o.Width-svgMarginRight)
+}
+
+// svgText writes a text label at x, y
+func svgText(b *strings.Builder, x, y int, anchor, text string) {
+	fmt.Fprintf(b, `<text x="%d" y="%d" text-anchor="%s">%s</text>`+"\n", x, y, anchor, html.EscapeString(text))
+}
+
+// svgHeapTrend draws HeapAlloc over the samples' window as a line
+func (r *Reporter) svgHeapTrend(b *strings.Builder, o ChartOptions) {
+	title := cmp.Or(o.Title, r.t(i18n.LabelHeapTrend))
+	svgStart(b, o, title)
+	svgAxes(b, o)
+
+	first, last := r.metrics[0], r.metrics[len(r.metrics)-1]
+	lo, hi := first.HeapAlloc, first.HeapAlloc
+	for _, m := range r.metrics {
+		lo, hi = min(lo, m.HeapAlloc), max(hi, m.HeapAlloc)
+	}
+	span := last.Timestamp.Sub(first.Timestamp)
+	plotW := float64(o.Width - svgMarginLeft - svgMarginRight)
+	plotH := float64(o.Height - svgMarginTop - svgMarginBottom)
+
+	b.WriteString(`<polyline fill="none" stroke="` + svgColor + `" stroke-width="1.5" points="`)
+	for i, m := range r.metrics {
+		x := float64(i) / float64(len(r.metrics)-1)
+		if span > 0 {
+			x = float64(m.Timestamp.Sub(first.Timestamp)) / float64(span)
+		}
+		y := 0.5
+		if hi > lo {
+			y = float64(m.HeapAlloc-lo) / float64(hi-lo)
+		}
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(strconv.FormatFloat(svgMarginLeft+x*plotW, 'f', 1, 64))
+		b.WriteByte(',')
+		b.WriteString(strconv.FormatFloat(float64(o.Height-svgMarginBottom)-y*plotH, 'f', 1, 64))
+	}
+	b.WriteString("\"/>\n")
+
+	svgText(b, svgMarginLeft-6, svgMarginTop+4, "end", r.formatBytes(hi))
+	svgText(b, svgMarginLeft-6, o.Height-svgMarginBottom, "end", r.formatBytes(lo))
+	svgText(b, svgMarginLeft, o.Height-svgMarginBottom+18, "start", first.Timestamp.Format(time.TimeOnly))
+	svgText(b, o.Width-svgMarginRight, o.Height-svgMarginBottom+18, "end", last.Timestamp.Format(time.TimeOnly))
+	b.WriteString("</svg>\n")
+}
+
+// svgPauseHistogram draws a bar per pause bucket, scaled to the fullest one
+func (r *Reporter) svgPauseHistogram(b *strings.Builder, o ChartOptions) {
+	title := cmp.Or(o.Title, r.t(i18n.LabelPauseChart))
+	svgStart(b, o, title)
+	svgAxes(b, o)
+
+	counts := pauseCounts(r.events)
+	peak := 1
+	for _, c := range counts {
+		peak = max(peak, c)
+	}
+	plotW := o.Width - svgMarginLeft - svgMarginRight
+	plotH := o.Height - svgMarginTop - svgMarginBottom
+	slot := plotW / len(pauseBuckets)
+
+	for i, bucket := range pauseBuckets {
+		h := counts[i] * plotH / peak
+		x := svgMarginLeft + i*slot + slot/8
+		fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+			x, o.Height-svgMarginBottom-h, slot*3/4, h, svgColor)
+		svgText(b, x+slot*3/8, o.Height-svgMarginBottom-h-4, "middle", strconv.Itoa(counts[i]))
+		svgText(b, x+slot*3/8, o.Height-svgMarginBottom+18, "middle", bucket.label)
+	}
+	b.WriteString("</svg>\n")
+}
@@ -0,0 +1,78 @@
+package reporting
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// wellFormed reports whether doc parses as XML
+func wellFormed(doc []byte) error {
+	d := xml.NewDecoder(bytes.NewReader(doc))
+	for {
+		if _, err := d.Token(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func TestGenerateChartSVG(t *testing.T) {
+	events := createTestEvents(10)
+	events[9].Duration = 20 * time.Millisecond
+	reporter := New(nil, createTestMetrics(50), events)
+
+	tests := []struct {
+		chart ChartType
+		want  []string
+	}{
+		{ChartTypeHeapTrend, []string{"<polyline", "Heap Trend", "1.0 MB"}},
+		{ChartTypePauseHistogram, []string{"<rect", "Pause Distribution", "0-1ms", ">9<"}},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := reporter.GenerateChartSVG(&buf, tt.chart, nil); err != nil {
+			t.Fatalf("GenerateChartSVG(%s) error: %v", tt.chart, err)
+		}
+		if err := wellFormed(buf.Bytes()); err != nil {
+			t.Errorf("GenerateChartSVG(%s) is not well-formed: %v", tt.chart, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("GenerateChartSVG(%s) missing %q", tt.chart, want)
+			}
+		}
+	}
+}
+
+func TestGenerateChartSVG_Options(t *testing.T) {
+	var buf bytes.Buffer
+	opts := &ChartOptions{Width: 400, Height: 200, Title: "Heap <prod>"}
+	if err := New(nil, createTestMetrics(5), nil).GenerateChartSVG(&buf, ChartTypeHeapTrend, opts); err != nil {
+		t.Fatalf("GenerateChartSVG() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `width="400" height="200"`) || !strings.Contains(buf.String(), "Heap &lt;prod&gt;") {
+		t.Errorf("Options not applied:\n%s", buf.String())
+	}
+}
+
+func TestGenerateChartSVG_Errors(t *testing.T) {
+	reporter := New(nil, nil, nil)
+	if err := reporter.GenerateChartSVG(io.Discard, ChartTypeHeapTrend, nil); !errors.Is(err, types.ErrNoMetricsData) {
+		t.Errorf("Heap trend without metrics error = %v, want ErrNoMetricsData", err)
+	}
+	if err := reporter.GenerateChartSVG(io.Discard, ChartTypePauseHistogram, nil); !errors.Is(err, types.ErrNoEventsData) {
+		t.Errorf("Pause histogram without events error = %v, want ErrNoEventsData", err)
+	}
+	if err := reporter.GenerateChartSVG(io.Discard, "pie", nil); !errors.Is(err, types.ErrUnknownChartType) {
+		t.Errorf("Unknown chart error = %v, want ErrUnknownChartType", err)
+	}
+}
@@ -0,0 +1,70 @@
+package reporting
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// Template is a parsed report template. Both *text/template.Template and
+// *html/template.Template implement it.
+type Template interface {
+	Execute(w io.Writer, data any) error
+}
+
+// TemplateData is the data a report template is executed with
+type TemplateData struct {
+	Analysis    *types.GCAnalysis
+	Metrics     []*types.GCMetrics
+	Events      []*types.GCEvent
+	Health      *types.HealthCheckStatus
+	GeneratedAt time.Time
+}
+
+// TemplateFuncs returns the functions available to report templates, for
+// passing to Funcs before parsing:
+//
+//	bytes     formats a byte size, e.g. {{bytes .Analysis.AvgHeapSize}}
+//	rate      formats a byte rate, e.g. {{rate .Analysis.AllocRate}}
+//	number    formats a float with the given decimals, e.g. {{number .Analysis.GCOverhead 2}}
+//	duration  rounds a duration to the given unit, e.g. {{duration .Analysis.P99PauseTime "1us"}}
+//	json      encodes a value as JSON
+//
+// Numbers follow the reporter's Numbers option.
+func (r *Reporter) TemplateFuncs() map[string]any {
+	return map[string]any{
+		"bytes":  r.formatBytes,
+		"rate":   r.formatBytesRate,
+		"number": r.formatNumber,
+		"duration": func(d time.Duration, unit string) (time.Duration, error) {
+			m, err := time.ParseDuration(unit)
+			if err != nil {
+				return 0, err
+			}
+			return d.Round(m), nil
+		},
+		"json": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}
+}
+
+// GenerateTemplateReport executes tmpl with the analysis, metrics, events
+// and health check as TemplateData, for reports in an organization's own
+// format. Parse tmpl with TemplateFuncs to use the formatting functions.
+func (r *Reporter) GenerateTemplateReport(w io.Writer, tmpl Template) error {
+	if r.analysis == nil {
+		return fmt.Errorf("template report: %w", types.ErrNoAnalysisData)
+	}
+	return tmpl.Execute(w, &TemplateData{
+		Analysis:    r.analysis,
+		Metrics:     r.metrics,
+		Events:      r.events,
+		Health:      r.GenerateHealthCheck(),
+		GeneratedAt: time.Now(),
+	})
+}
@@ -0,0 +1,58 @@
+package reporting
+
+import (
+	"bytes"
+	"errors"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestGenerateTemplateReport(t *testing.T) {
+	reporter := New(createTestAnalysis(), createTestMetrics(3), createTestEvents(2))
+	tmpl := template.Must(template.New("report").Funcs(reporter.TemplateFuncs()).Parse(
+		`GC {{number .Analysis.GCFrequency 1}}/s, heap {{bytes .Analysis.AvgHeapSize}}, ` +
+			`alloc {{rate .Analysis.AllocRate}}, p99 {{duration .Analysis.P99PauseTime "1ms"}}, ` +
+			`{{len .Metrics}} samples, {{len .Events}} events, health {{.Health.Status}}, ` +
+			`recs {{json .Analysis.Recommendations}}`))
+
+	var buf bytes.Buffer
+	if err := reporter.GenerateTemplateReport(&buf, tmpl); err != nil {
+		t.Fatalf("GenerateTemplateReport() error: %v", err)
+	}
+	want := `GC 2.5/s, heap 10.0 MB, alloc 5.0 MB/s, p99 2ms, 3 samples, 2 events, health ` +
+		reporter.GenerateHealthCheck().Status + `, recs ["Consider increasing GOGC","Review allocation patterns"]`
+	if buf.String() != want {
+		t.Errorf("GenerateTemplateReport() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestGenerateTemplateReport_HTML(t *testing.T) {
+	analysis := createTestAnalysis()
+	analysis.Recommendations = []string{"Use <sync.Pool>"}
+	reporter := NewWithOptions(analysis, nil, nil, &Options{Numbers: &types.NumberFormat{Decimals: 0}})
+	tmpl := htmltemplate.Must(htmltemplate.New("report").Funcs(reporter.TemplateFuncs()).Parse(
+		`<p>{{bytes .Analysis.AvgHeapSize}}</p>{{range .Analysis.Recommendations}}<li>{{.}}</li>{{end}}`))
+
+	var buf bytes.Buffer
+	if err := reporter.GenerateTemplateReport(&buf, tmpl); err != nil {
+		t.Fatalf("GenerateTemplateReport() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "<p>10 MB</p>") || !strings.Contains(buf.String(), "&lt;sync.Pool&gt;") {
+		t.Errorf("GenerateTemplateReport() = %q", buf.String())
+	}
+}
+
+func TestGenerateTemplateReport_Errors(t *testing.T) {
+	tmpl := template.Must(template.New("report").Parse(`{{.Analysis.Missing}}`))
+	if err := New(nil, nil, nil).GenerateTemplateReport(io.Discard, tmpl); !errors.Is(err, types.ErrNoAnalysisData) {
+		t.Errorf("GenerateTemplateReport() without analysis error = %v, want ErrNoAnalysisData", err)
+	}
+	if err := New(createTestAnalysis(), nil, nil).GenerateTemplateReport(io.Discard, tmpl); err == nil {
+		t.Error("Expected the template's execution error")
+	}
+}
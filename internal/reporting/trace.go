@@ -0,0 +1,115 @@
+package reporting
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// Chrome trace-event process and thread the GC timeline is drawn on
+const (
+	tracePID         = 1
+	traceTIDCycles   = 1 // GC cycles and their phases
+	traceProcessName = "Go GC"
+)
+
+// traceEvent is an event of the Chrome trace-event format, as read by
+// chrome://tracing and Perfetto. Timestamps and durations are microseconds.
+type traceEvent struct {
+	Name  string         `json:"name"`
+	Cat   string         `json:"cat,omitempty"`
+	Phase string         `json:"ph"`
+	TS    float64        `json:"ts"`
+	Dur   float64        `json:"dur,omitempty"`
+	Scope string         `json:"s,omitempty"` // of instant events
+	PID   int            `json:"pid"`
+	TID   int            `json:"tid"`
+	Args  map[string]any `json:"args,omitempty"`
+}
+
+// traceMicros converts d to trace-event microseconds
+func traceMicros(d time.Duration) float64 {
+	return float64(d) / float64(time.Microsecond)
+}
+
+// GenerateChromeTrace writes the GC events as Chrome trace-event JSON, one
+// slice per cycle with its phases nested below when known (e.g. from
+// gctrace) and a process-wide marker for each GOGC or GOMEMLIMIT change, so the GC timeline can be opened in chrome://tracing or Perfetto
+// next to application traces. Timestamps are microseconds since the Unix
+// epoch.
+func (r *Reporter) GenerateChromeTrace(w io.Writer) error {
+	if len(r.events) == 0 {
+		return fmt.Errorf("chrome trace: %w", types.ErrNoEventsData)
+	}
+
+	events := make([]traceEvent, 0, 1+len(r.events)*4)
+	events = append(events, traceEvent{
+		Name: "process_name", Phase: "M", PID: tracePID, TID: traceTIDCycles,
+		Args: map[string]any{"name": traceProcessName},
+	})
+
+	for _, e := range r.events {
+		ts := float64(e.StartTime.UnixNano()) / float64(time.Microsecond)
+		if e.IsGap() {
+			events = append(events, traceEvent{
+				Name: "missed " + strconv.FormatUint(uint64(e.Missed), 10) + " GC cycles", Cat: "gc,gap", Phase: "X",
+				TS: ts, Dur: traceMicros(e.EndTime.Sub(e.StartTime)), PID: tracePID, TID: traceTIDCycles,
+			})
+			continue
+		}
+
+		dur := e.EndTime.Sub(e.StartTime)
+		if dur <= 0 {
+			dur = e.Duration
+		}
+		args := map[string]any{
+			"pause_us":    traceMicros(e.Duration),
+			"heap_before": e.HeapBefore,
+			"heap_after":  e.HeapAfter,
+			"trigger":     e.TriggerReason,
+		}
+		if e.Region != "" {
+			args["region"] = e.Region
+		}
+		events = append(events, traceEvent{
+			Name: "GC " + strconv.FormatUint(uint64(e.Sequence), 10), Cat: "gc", Phase: "X",
+			TS: ts, Dur: traceMicros(dur), PID: tracePID, TID: traceTIDCycles, Args: args,
+		})
+
+		if p := e.Phases; p != nil {
+			offset := ts
+			for _, phase := range []struct {
+				name string
+				d    time.Duration
+			}{
+				{"sweep termination (STW)", p.SweepTermination},
+				{"concurrent mark", p.ConcurrentMark},
+				{"mark termination (STW)", p.MarkTermination},
+			} {
+				events = append(events, traceEvent{
+					Name: phase.name, Cat: "gc,phase", Phase: "X",
+					TS: offset, Dur: traceMicros(phase.d), PID: tracePID, TID: traceTIDCycles,
+				})
+				offset += traceMicros(phase.d)
+			}
+		}
+	}
+
+	if r.analysis != nil {
+		for _, c := range r.analysis.TuningChanges {
+			events = append(events, traceEvent{
+				Name: c.String(), Cat: "tuning", Phase: "i", Scope: "p",
+				TS: float64(c.Timestamp.UnixNano()) / float64(time.Microsecond), PID: tracePID, TID: traceTIDCycles,
+			})
+		}
+	}
+
+	return json.NewEncoder(w).Encode(struct {
+		TraceEvents     []traceEvent `json:"traceEvents"`
+		DisplayTimeUnit string       `json:"displayTimeUnit"`
+	}{events, "ms"})
+}
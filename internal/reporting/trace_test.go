@@ -0,0 +1,70 @@
+package reporting
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestGenerateChromeTrace(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	events := []*types.GCEvent{
+		{Sequence: 1, StartTime: start, EndTime: start.Add(500 * time.Microsecond), Duration: 500 * time.Microsecond, TriggerReason: "automatic"},
+		{Sequence: 2, StartTime: start.Add(time.Second), EndTime: start.Add(2 * time.Second), Missed: 3},
+		{
+			Sequence: 5, StartTime: start.Add(3 * time.Second), EndTime: start.Add(3*time.Second + 3*time.Millisecond),
+			Duration: time.Millisecond, Region: "batch",
+			Phases: &types.GCPhases{SweepTermination: 200 * time.Microsecond, ConcurrentMark: 2 * time.Millisecond, MarkTermination: 800 * time.Microsecond},
+		},
+	}
+
+	var buf bytes.Buffer
+	analysis := &types.GCAnalysis{TuningChanges: []types.TuningChange{
+		{Setting: "GOGC", Timestamp: start.Add(2 * time.Second), Before: "100", After: "200"},
+	}}
+	if err := New(analysis, nil, events).GenerateChromeTrace(&buf); err != nil {
+		t.Fatalf("GenerateChromeTrace() error: %v", err)
+	}
+
+	var trace struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Fatalf("Output is not JSON: %v", err)
+	}
+	// Process name, two cycles, a gap, three phases and a tuning change
+	if len(trace.TraceEvents) != 8 {
+		t.Fatalf("Got %d trace events, want 8: %+v", len(trace.TraceEvents), trace.TraceEvents)
+	}
+
+	byName := make(map[string]traceEvent)
+	for _, e := range trace.TraceEvents {
+		byName[e.Name] = e
+	}
+	first := byName["GC 1"]
+	if first.Phase != "X" || first.TS != 1700000000e6 || first.Dur != 500 {
+		t.Errorf("GC 1 = %+v", first)
+	}
+	if gap, ok := byName["missed 3 GC cycles"]; !ok || gap.Dur != 1e6 {
+		t.Errorf("Gap marker = %+v", gap)
+	}
+	if last := byName["GC 5"]; last.Args["region"] != "batch" {
+		t.Errorf("GC 5 args = %v", last.Args)
+	}
+	if tuning := byName["GOGC: 100 → 200"]; tuning.Phase != "i" || tuning.Scope != "p" || tuning.TS != 1700000002e6 {
+		t.Errorf("Tuning change marker = %+v", tuning)
+	}
+	mark := byName["mark termination (STW)"]
+	if mark.TS != 1700000003e6+2200 || mark.Dur != 800 {
+		t.Errorf("Mark termination = %+v", mark)
+	}
+
+	if err := New(nil, nil, nil).GenerateChromeTrace(io.Discard); !errors.Is(err, types.ErrNoEventsData) {
+		t.Errorf("GenerateChromeTrace() without events error = %v, want ErrNoEventsData", err)
+	}
+}
@@ -0,0 +1,118 @@
+package reporting
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/i18n"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// upgradeLabels maps upgrade comparison metrics to their report labels
+var upgradeLabels = map[string]i18n.Key{
+	types.DeltaAvgPause:      i18n.LabelAvgPause,
+	types.DeltaP99Pause:      i18n.LabelP99Pause,
+	types.DeltaGCOverhead:    i18n.LabelGCOverhead,
+	types.DeltaGCFrequency:   i18n.LabelGCFrequency,
+	types.DeltaRuntimeMemory: i18n.LabelRuntimeMemory,
+	types.DeltaRSS:           i18n.LabelAvgRSS,
+}
+
+// GenerateUpgradeReport writes a Go runtime upgrade comparison: each metric
+// before and after the upgrade with its relative change, followed by the
+// build changes and notes on the GC-relevant runtime changes between the two
+// versions. A comparison of two builds under the same Go version is titled
+// with the bundle labels instead.
+// A nil opts uses the default options.
+func GenerateUpgradeReport(w io.Writer, c *types.UpgradeComparison, opts *Options) error {
+	if c == nil {
+		return fmt.Errorf("upgrade report: %w", types.ErrNoAnalysisData)
+	}
+
+	r := NewWithOptions(c.After, nil, nil, opts)
+	b := getBuilder()
+	defer putBuilder(b)
+
+	title, from, to := i18n.SectionRuntimeUpgrade, c.FromVersion, c.ToVersion
+	if from == to {
+		title, from, to = i18n.SectionBuildCompare, c.FromLabel, c.ToLabel
+	}
+	b.WriteString("=== ")
+	b.WriteString(r.t(title))
+	if from != "" || to != "" {
+		b.WriteString(": ")
+		b.WriteString(from)
+		b.WriteString(" → ")
+		b.WriteString(to)
+	}
+	b.WriteString(" ===\n\n")
+
+	for _, d := range c.Deltas {
+		key, ok := upgradeLabels[d.Metric]
+		if !ok {
+			continue
+		}
+		r.writeLabel(b, key)
+		b.WriteString(r.formatDeltaValue(d.Metric, d.Before))
+		b.WriteString(" → ")
+		b.WriteString(r.formatDeltaValue(d.Metric, d.After))
+		if d.Before != 0 {
+			b.WriteString(" (")
+			if d.Change > 0 {
+				b.WriteString("+")
+			}
+			b.WriteString(r.formatNumber(d.Change*100, 1))
+			b.WriteString("%, ")
+			switch {
+			case d.Improved():
+				b.WriteString(r.t(i18n.StatusImproved))
+			case d.After > d.Before:
+				b.WriteString(r.t(i18n.StatusRegressed))
+			default:
+				b.WriteString(r.t(i18n.StatusUnchanged))
+			}
+			b.WriteString(")")
+		}
+		b.WriteString("\n")
+	}
+
+	if len(c.Changes) > 0 {
+		b.WriteString("\n")
+		r.writeSection(b, i18n.SectionBuildChanges)
+		for _, change := range c.Changes {
+			b.WriteString("- ")
+			b.WriteString(change.String())
+			b.WriteString("\n")
+		}
+	}
+
+	if len(c.Notes) > 0 {
+		b.WriteString("\n")
+		r.writeSection(b, i18n.SectionNotes)
+		for _, note := range c.Notes {
+			b.WriteString("- ")
+			b.WriteString(note)
+			b.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatDeltaValue formats a comparison value in the metric's natural unit
+func (r *Reporter) formatDeltaValue(metric string, v float64) string {
+	switch metric {
+	case types.DeltaAvgPause, types.DeltaP99Pause:
+		return time.Duration(v).Round(time.Microsecond).String()
+	case types.DeltaGCOverhead:
+		return r.formatNumber(v, 2) + "%"
+	case types.DeltaGCFrequency:
+		return r.formatNumber(v, 2) + "/s"
+	case types.DeltaRuntimeMemory, types.DeltaRSS:
+		return r.formatBytes(uint64(v))
+	default:
+		return r.formatNumber(v, 2)
+	}
+}
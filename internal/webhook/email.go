@@ -0,0 +1,248 @@
+package webhook
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// DefaultEmailSubject and DefaultEmailBody are the email templates used when
+// EmailConfig leaves them unset
+const (
+	DefaultEmailSubject = `[go-gc-analyzer] {{if .Alert.Resolved}}Resolved{{else}}{{.Alert.Severity}}{{end}}: {{.Alert.Message}}{{with .Source}} on {{.}}{{end}}`
+
+	DefaultEmailBody = `{{if .Alert.Resolved}}Resolved{{else}}Alert{{end}}: {{.Alert.Message}}
+
+Type:      {{.Alert.Type}}
+Severity:  {{.Alert.Severity}}
+Value:     {{printf "%.2f" .Alert.Value}} (threshold {{printf "%.2f" .Alert.Threshold}})
+{{with .Alert.Rule}}Rule:      {{.}}
+{{end}}Time:      {{.Alert.Timestamp.Format "2006-01-02 15:04:05 MST"}}
+{{with .Source}}Host:      {{.}}
+{{end}}{{with .Health}}
+Health: {{.Status}} ({{.Score}}/100) - {{.Summary}}
+{{range .Issues}}  - {{.}}
+{{end}}{{end}}{{with .Report}}
+{{.}}{{end}}`
+)
+
+// EmailConfig configures alert emails sent through an SMTP server
+type EmailConfig struct {
+	// Addr is the SMTP server's host:port. STARTTLS is used when the server
+	// offers it.
+	Addr string
+
+	// Username and Password, when Username is set, authenticate with PLAIN
+	// auth, which requires TLS unless the server is on localhost
+	Username string
+	Password string
+
+	// From and To are the sender and recipient addresses, e.g.
+	// "GC Alerts <gc@example.com>"
+	From string
+	To   []string
+
+	// Subject and Body render the email with text/template, given the
+	// *EmailData (default: DefaultEmailSubject and DefaultEmailBody)
+	Subject string
+	Body    string
+
+	// MinSeverity is the lowest alert severity emailed (default: warning).
+	// Resolutions are emailed for alerts that were.
+	MinSeverity string
+
+	// Timeout, MaxRetries, Backoff, QueueSize and OnError are as for Config.
+	// SMTP 4xx replies are retried, 5xx replies are not.
+	Timeout    time.Duration
+	MaxRetries int
+	Backoff    time.Duration
+	QueueSize  int
+	OnError    func(*types.Alert, error)
+}
+
+// EmailData is what email templates are rendered with
+type EmailData struct {
+	Alert  *types.Alert
+	Source string                   // the sending host
+	Health *types.HealthCheckStatus // nil until samples have been analyzed
+	Report string                   // the summary report; empty until samples have been analyzed
+}
+
+// email renders and sends alert emails
+type email struct {
+	config  EmailConfig
+	host    string
+	from    string   // envelope sender
+	to      []string // envelope recipients
+	source  string
+	subject *template.Template
+	body    *template.Template
+	timeout time.Duration
+
+	// status returns the health check and summary report emails include;
+	// nil when there are none
+	status func() (*types.HealthCheckStatus, string)
+}
+
+// NewEmail creates a sink that emails alerts of at least MinSeverity, and
+// their resolutions. status, when not nil, supplies the health check and
+// summary report included in each email. Returns ErrInvalidEmail if the
+// configuration is malformed.
+func NewEmail(config EmailConfig, status func() (*types.HealthCheckStatus, string)) (*Sink, error) {
+	host, _, err := net.SplitHostPort(config.Addr)
+	if err != nil || host == "" {
+		return nil, fmt.Errorf("%w: SMTP address %q must be host:port", types.ErrInvalidEmail, config.Addr)
+	}
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, fmt.Errorf("%w: sender %q: %w", types.ErrInvalidEmail, config.From, err)
+	}
+	if len(config.To) == 0 {
+		return nil, fmt.Errorf("%w: no recipients", types.ErrInvalidEmail)
+	}
+	to := make([]string, len(config.To))
+	for i, addr := range config.To {
+		parsed, err := mail.ParseAddress(addr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: recipient %q: %w", types.ErrInvalidEmail, addr, err)
+		}
+		to[i] = parsed.Address
+	}
+	config.MinSeverity = cmp.Or(config.MinSeverity, string(types.SeverityWarning))
+	switch types.Severity(config.MinSeverity) {
+	case types.SeverityInfo, types.SeverityWarning, types.SeverityCritical:
+	default:
+		return nil, fmt.Errorf("%w: unknown severity %q", types.ErrInvalidEmail, config.MinSeverity)
+	}
+
+	e := &email{config: config, host: host, from: from.Address, to: to, status: status}
+	e.source, _ = os.Hostname()
+	if e.subject, err = template.New("subject").Parse(cmp.Or(config.Subject, DefaultEmailSubject)); err != nil {
+		return nil, fmt.Errorf("%w: subject: %w", types.ErrInvalidEmail, err)
+	}
+	if e.body, err = template.New("body").Parse(cmp.Or(config.Body, DefaultEmailBody)); err != nil {
+		return nil, fmt.Errorf("%w: body: %w", types.ErrInvalidEmail, err)
+	}
+
+	s := newSink(Config{
+		Timeout:    config.Timeout,
+		MaxRetries: config.MaxRetries,
+		Backoff:    config.Backoff,
+		QueueSize:  config.QueueSize,
+		OnError:    config.OnError,
+	})
+	e.timeout = s.config.Timeout
+	s.render = e.render
+	s.deliver = e.send
+	return s, nil
+}
+
+// render produces the message for an alert, or nil when it is below
+// MinSeverity
+func (e *email) render(alert *types.Alert) ([]byte, error) {
+	if types.Severity(alert.Severity).Rank() < types.Severity(e.config.MinSeverity).Rank() {
+		return nil, nil
+	}
+
+	data := &EmailData{Alert: alert, Source: e.source}
+	if e.status != nil {
+		data.Health, data.Report = e.status()
+	}
+
+	var subject, body bytes.Buffer
+	if err := e.subject.Execute(&subject, data); err != nil {
+		return nil, err
+	}
+	if err := e.body.Execute(&body, data); err != nil {
+		return nil, err
+	}
+
+	// Headers are single lines; the SMTP data writer turns \n into CRLF
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\n", e.config.From)
+	fmt.Fprintf(&msg, "To: %s\n", strings.Join(e.config.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\n", mime.QEncoding.Encode("utf-8", strings.Join(strings.Fields(subject.String()), " ")))
+	fmt.Fprintf(&msg, "Date: %s\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\n\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+// send makes one SMTP delivery attempt, reporting whether a failure is worth
+// retrying
+func (e *email) send(ctx context.Context, msg []byte) (retry bool, err error) {
+	dialer := net.Dialer{Timeout: e.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", e.config.Addr)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(e.timeout))
+
+	c, err := smtp.NewClient(conn, e.host)
+	if err != nil {
+		return transientSMTP(err), err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: e.host}); err != nil {
+			return false, err
+		}
+	}
+	if e.config.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", e.config.Username, e.config.Password, e.host)); err != nil {
+			return transientSMTP(err), err
+		}
+	}
+	if err := c.Mail(e.from); err != nil {
+		return transientSMTP(err), err
+	}
+	for _, to := range e.to {
+		if err := c.Rcpt(to); err != nil {
+			return transientSMTP(err), err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return transientSMTP(err), err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return transientSMTP(err), err
+	}
+	if err := w.Close(); err != nil {
+		return transientSMTP(err), err
+	}
+	// The message is accepted; a failed QUIT does not undo that
+	_ = c.Quit()
+	return false, nil
+}
+
+// transientSMTP reports whether an SMTP failure is worth retrying: 4xx
+// replies and connection errors are; permanent 5xx replies and client-side
+// refusals, such as PLAIN auth without TLS, are not
+func transientSMTP(err error) bool {
+	var reply *textproto.Error
+	if errors.As(err, &reply) {
+		return reply.Code >= 400 && reply.Code < 500
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,223 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// smtpServer is a minimal SMTP server recording the envelopes and messages
+// it accepts. rcptCodes, when set, are the replies to successive RCPT
+// commands, e.g. to simulate a temporary failure.
+type smtpServer struct {
+	addr string
+
+	mu        sync.Mutex
+	rcptCodes []int
+	from      []string
+	to        []string
+	messages  []string
+}
+
+func newSMTPServer(t *testing.T, rcptCodes ...int) *smtpServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	s := &smtpServer{addr: ln.Addr().String(), rcptCodes: rcptCodes}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *smtpServer) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 localhost ready")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(cmd) {
+		case "EHLO", "HELO":
+			_ = tp.PrintfLine("250 localhost")
+		case "MAIL":
+			s.mu.Lock()
+			s.from = append(s.from, arg)
+			s.mu.Unlock()
+			_ = tp.PrintfLine("250 OK")
+		case "RCPT":
+			s.mu.Lock()
+			code := 250
+			if len(s.rcptCodes) > 0 {
+				code, s.rcptCodes = s.rcptCodes[0], s.rcptCodes[1:]
+			}
+			if code == 250 {
+				s.to = append(s.to, arg)
+			}
+			s.mu.Unlock()
+			_ = tp.PrintfLine("%d recipient", code)
+		case "DATA":
+			_ = tp.PrintfLine("354 go ahead")
+			b, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, string(b))
+			s.mu.Unlock()
+			_ = tp.PrintfLine("250 queued")
+		case "QUIT":
+			_ = tp.PrintfLine("221 bye")
+			return
+		default:
+			_ = tp.PrintfLine("250 OK")
+		}
+	}
+}
+
+func (s *smtpServer) received() (from, to, messages []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.from, s.to, s.messages
+}
+
+func TestEmail_Send(t *testing.T) {
+	server := newSMTPServer(t)
+	status := func() (*types.HealthCheckStatus, string) {
+		return &types.HealthCheckStatus{Status: "warning", Score: 70, Summary: "GC performance needs attention"}, "GC Summary Report\n"
+	}
+	s, err := NewEmail(EmailConfig{
+		Addr: server.addr,
+		From: "GC Alerts <gc@example.com>",
+		To:   []string{"oncall@example.com", "Team <team@example.com>"},
+	}, status)
+	if err != nil {
+		t.Fatalf("NewEmail() error: %v", err)
+	}
+	alert := testAlert()
+	alert.Rule = "pause > 100ms"
+	alert.Timestamp = time.Now()
+	if err := s.Send(context.Background(), alert); err != nil {
+		t.Fatalf("Send() error: %v", err)
+	}
+
+	from, to, messages := server.received()
+	if len(from) != 1 || from[0] != "FROM:<gc@example.com>" {
+		t.Errorf("MAIL %v", from)
+	}
+	if len(to) != 2 || to[1] != "TO:<team@example.com>" {
+		t.Errorf("RCPT %v", to)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Got %d messages, want 1", len(messages))
+	}
+	for _, want := range []string{
+		"Subject: [go-gc-analyzer] critical: Long \"GC\" pause",
+		"To: oncall@example.com, Team <team@example.com>\n",
+		"Content-Type: text/plain; charset=utf-8\n",
+		"Rule:      pause > 100ms\n",
+		"Health: warning (70/100) - GC performance needs attention\n",
+		"GC Summary Report\n",
+	} {
+		if !strings.Contains(messages[0], want) {
+			t.Errorf("Message missing %q:\n%s", want, messages[0])
+		}
+	}
+}
+
+func TestEmail_MinSeverity(t *testing.T) {
+	server := newSMTPServer(t)
+	s, err := NewEmail(EmailConfig{Addr: server.addr, From: "gc@example.com", To: []string{"oncall@example.com"}, Subject: "{{.Alert.Severity}}"}, nil)
+	if err != nil {
+		t.Fatalf("NewEmail() error: %v", err)
+	}
+	for _, a := range []*types.Alert{
+		{Type: "overhead", Severity: "info"},
+		{Type: "overhead", Severity: "warning"},
+		{Type: "overhead", Severity: "warning", Resolved: true},
+	} {
+		if err := s.Send(context.Background(), a); err != nil {
+			t.Fatalf("Send() error: %v", err)
+		}
+	}
+	if _, _, messages := server.received(); len(messages) != 2 {
+		t.Errorf("Got %d messages, want the warning and its resolution", len(messages))
+	}
+}
+
+func TestEmail_Retries(t *testing.T) {
+	tests := []struct {
+		name  string
+		codes []int
+		ok    bool
+	}{
+		{"temporary failure", []int{451}, true},
+		{"permanent failure", []int{550}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newSMTPServer(t, tt.codes...)
+			s, err := NewEmail(EmailConfig{
+				Addr:    server.addr,
+				From:    "gc@example.com",
+				To:      []string{"oncall@example.com"},
+				Backoff: time.Millisecond,
+			}, nil)
+			if err != nil {
+				t.Fatalf("NewEmail() error: %v", err)
+			}
+			err = s.Send(context.Background(), testAlert())
+			if tt.ok && err != nil {
+				t.Errorf("Send() error: %v", err)
+			}
+			if !tt.ok && !errors.Is(err, types.ErrWebhookDelivery) {
+				t.Errorf("Send() error = %v, want ErrWebhookDelivery", err)
+			}
+			if _, _, messages := server.received(); tt.ok != (len(messages) == 1) {
+				t.Errorf("Got %d messages", len(messages))
+			}
+		})
+	}
+}
+
+func TestNewEmail_Invalid(t *testing.T) {
+	valid := EmailConfig{Addr: "smtp.example.com:587", From: "gc@example.com", To: []string{"oncall@example.com"}}
+	for name, mutate := range map[string]func(*EmailConfig){
+		"address":   func(c *EmailConfig) { c.Addr = "smtp.example.com" },
+		"sender":    func(c *EmailConfig) { c.From = "not an address" },
+		"recipient": func(c *EmailConfig) { c.To = []string{"oncall"} },
+		"no one":    func(c *EmailConfig) { c.To = nil },
+		"severity":  func(c *EmailConfig) { c.MinSeverity = "urgent" },
+		"template":  func(c *EmailConfig) { c.Body = "{{.Alert" },
+	} {
+		config := valid
+		mutate(&config)
+		if _, err := NewEmail(config, nil); !errors.Is(err, types.ErrInvalidEmail) {
+			t.Errorf("%s: NewEmail() error = %v, want ErrInvalidEmail", name, err)
+		}
+	}
+	if _, err := NewEmail(valid, nil); err != nil {
+		t.Errorf("NewEmail() error: %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package webhook
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// PagerDutyEventsURL is the PagerDuty Events API v2 endpoint
+const PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDutyConfig configures PagerDuty incidents for alerts
+type PagerDutyConfig struct {
+	// RoutingKey is the integration key of an Events API v2 integration
+	RoutingKey string
+
+	// Source identifies the monitored process in incidents (default: the hostname)
+	Source string
+
+	// MinSeverity is the lowest alert severity that triggers an incident
+	// (default: critical)
+	MinSeverity string
+
+	// URL overrides the Events API endpoint, e.g. for the EU service region
+	// (default: PagerDutyEventsURL)
+	URL string
+
+	// OnError, when set, is called for events that could not be delivered
+	OnError func(*types.Alert, error)
+}
+
+// pagerDutyEvent is an Events API v2 event
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"` // trigger or resolve
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"` // trigger only
+}
+
+type pagerDutyPayload struct {
+	Summary       string         `json:"summary"`
+	Source        string         `json:"source"`
+	Severity      string         `json:"severity"` // critical, error, warning or info
+	Timestamp     string         `json:"timestamp"`
+	Component     string         `json:"component"`
+	Class         string         `json:"class"`
+	CustomDetails map[string]any `json:"custom_details,omitempty"`
+}
+
+// maxSummaryLength is the longest summary PagerDuty accepts
+const maxSummaryLength = 1024
+
+// pagerDuty tracks which alerts keep each alert type's incident open. An
+// incident is keyed by alert type, so alerts of the same type raised by
+// several rules share it; it is resolved once every rule that triggered it
+// has cleared. Alerts not raised by rules have no clear condition, so the
+// incidents they trigger stay open until resolved in PagerDuty.
+type pagerDuty struct {
+	config PagerDutyConfig
+
+	mu     sync.Mutex
+	active map[string]map[string]bool // alert type -> triggering rule names
+}
+
+// NewPagerDuty creates a sink that triggers a PagerDuty incident per alert
+// type on alerts of at least MinSeverity, and resolves it once the alerts
+// have resolved. Returns ErrInvalidWebhook if the configuration is malformed.
+func NewPagerDuty(config PagerDutyConfig) (*Sink, error) {
+	if config.RoutingKey == "" {
+		return nil, fmt.Errorf("%w: PagerDuty routing key required", types.ErrInvalidWebhook)
+	}
+	config.MinSeverity = cmp.Or(config.MinSeverity, string(types.SeverityCritical))
+	switch types.Severity(config.MinSeverity) {
+	case types.SeverityInfo, types.SeverityWarning, types.SeverityCritical:
+	default:
+		return nil, fmt.Errorf("%w: unknown severity %q", types.ErrInvalidWebhook, config.MinSeverity)
+	}
+	if config.Source == "" {
+		config.Source, _ = os.Hostname()
+	}
+
+	s, err := New(Config{URL: cmp.Or(config.URL, PagerDutyEventsURL), OnError: config.OnError})
+	if err != nil {
+		return nil, err
+	}
+	pd := &pagerDuty{config: config, active: make(map[string]map[string]bool)}
+	s.render = pd.render
+	return s, nil
+}
+
+// render produces the event for an alert, or nil when it neither triggers
+// nor resolves an incident
+func (pd *pagerDuty) render(alert *types.Alert) ([]byte, error) {
+	event := pagerDutyEvent{
+		RoutingKey: pd.config.RoutingKey,
+		DedupKey:   "go-gc-analyzer/" + pd.config.Source + "/" + alert.Type,
+	}
+
+	pd.mu.Lock()
+	rules := pd.active[alert.Type]
+	if alert.Resolved {
+		if !rules[alert.Rule] {
+			pd.mu.Unlock()
+			return nil, nil
+		}
+		delete(rules, alert.Rule)
+		if len(rules) > 0 {
+			pd.mu.Unlock()
+			return nil, nil
+		}
+		event.EventAction = "resolve"
+	} else {
+		if types.Severity(alert.Severity).Rank() < types.Severity(pd.config.MinSeverity).Rank() {
+			pd.mu.Unlock()
+			return nil, nil
+		}
+		if rules == nil {
+			rules = make(map[string]bool)
+			pd.active[alert.Type] = rules
+		}
+		rules[alert.Rule] = true
+		event.EventAction = "trigger"
+		event.Payload = pd.payload(alert)
+	}
+	pd.mu.Unlock()
+
+	return json.Marshal(event)
+}
+
+// payload describes a triggering alert
+func (pd *pagerDuty) payload(alert *types.Alert) *pagerDutyPayload {
+	summary := fmt.Sprintf("%s (%.2f, threshold %.2f)", alert.Message, alert.Value, alert.Threshold)
+	if len(summary) > maxSummaryLength {
+		summary = summary[:maxSummaryLength]
+	}
+
+	details := map[string]any{"value": alert.Value, "threshold": alert.Threshold}
+	if alert.Rule != "" {
+		details["rule"] = alert.Rule
+	}
+	if len(alert.Labels) > 0 {
+		details["labels"] = alert.Labels
+	}
+
+	return &pagerDutyPayload{
+		Summary:       summary,
+		Source:        pd.config.Source,
+		Severity:      alert.Severity,
+		Timestamp:     alert.Timestamp.Format(time.RFC3339),
+		Component:     "go-gc",
+		Class:         alert.Type,
+		CustomDetails: details,
+	}
+}
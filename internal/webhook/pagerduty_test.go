@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func TestPagerDuty_TriggerAndResolve(t *testing.T) {
+	var events []pagerDutyEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev pagerDutyEvent
+		if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+			t.Errorf("Decode event: %v", err)
+		}
+		events = append(events, ev)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	s, err := NewPagerDuty(PagerDutyConfig{RoutingKey: "key", Source: "api-1", URL: server.URL})
+	if err != nil {
+		t.Fatalf("NewPagerDuty() error: %v", err)
+	}
+
+	alert := func(rule, severity string, resolved bool) *types.Alert {
+		return &types.Alert{Type: "pause", Severity: severity, Message: "Long pause", Value: 750, Threshold: 100, Rule: rule, Resolved: resolved, Labels: map[string]string{"service": "api"}}
+	}
+	steps := []*types.Alert{
+		alert("pause > 100ms", "warning", false), // below MinSeverity
+		alert("pause > 100ms", "critical", false),
+		alert("p99_pause > 50ms", "critical", false),
+		alert("pause > 100ms", "critical", true),  // p99 rule still firing
+		alert("max_pause > 1s", "critical", true), // never triggered
+		alert("p99_pause > 50ms", "critical", true),
+	}
+	for _, a := range steps {
+		if err := s.Send(context.Background(), a); err != nil {
+			t.Fatalf("Send() error: %v", err)
+		}
+	}
+
+	want := []string{"trigger", "trigger", "resolve"}
+	if len(events) != len(want) {
+		t.Fatalf("Got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, ev := range events {
+		if ev.EventAction != want[i] {
+			t.Errorf("Event %d action = %q, want %q", i, ev.EventAction, want[i])
+		}
+		if ev.RoutingKey != "key" || ev.DedupKey != "go-gc-analyzer/api-1/pause" {
+			t.Errorf("Event %d routing key %q, dedup key %q", i, ev.RoutingKey, ev.DedupKey)
+		}
+	}
+	p := events[0].Payload
+	if p == nil || p.Severity != "critical" || p.Source != "api-1" || p.Class != "pause" || p.CustomDetails["rule"] != "pause > 100ms" {
+		t.Errorf("Trigger payload = %+v", p)
+	}
+	if labels, ok := p.CustomDetails["labels"].(map[string]any); !ok || labels["service"] != "api" {
+		t.Errorf("Trigger details should carry the alert's labels, got %+v", p.CustomDetails)
+	}
+	if events[2].Payload != nil {
+		t.Errorf("Resolve event has a payload: %+v", events[2].Payload)
+	}
+}
+
+func TestPagerDuty_MinSeverity(t *testing.T) {
+	var actions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev pagerDutyEvent
+		_ = json.NewDecoder(r.Body).Decode(&ev)
+		actions = append(actions, ev.EventAction)
+	}))
+	defer server.Close()
+
+	s, err := NewPagerDuty(PagerDutyConfig{RoutingKey: "key", MinSeverity: "warning", URL: server.URL})
+	if err != nil {
+		t.Fatalf("NewPagerDuty() error: %v", err)
+	}
+	for _, severity := range []string{"info", "warning"} {
+		if err := s.Send(context.Background(), &types.Alert{Type: "overhead", Severity: severity}); err != nil {
+			t.Fatalf("Send() error: %v", err)
+		}
+	}
+	if len(actions) != 1 || actions[0] != "trigger" {
+		t.Errorf("Actions = %v, want [trigger]", actions)
+	}
+}
+
+func TestNewPagerDuty_Invalid(t *testing.T) {
+	for _, config := range []PagerDutyConfig{
+		{},
+		{RoutingKey: "key", MinSeverity: "urgent"},
+		{RoutingKey: "key", URL: "ftp://example.com"},
+	} {
+		if _, err := NewPagerDuty(config); !errors.Is(err, types.ErrInvalidWebhook) {
+			t.Errorf("NewPagerDuty(%+v) error = %v, want ErrInvalidWebhook", config, err)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Package webhook delivers alerts to HTTP endpoints, as JSON to a generic
+// webhook or to PagerDuty, and by email, retrying failed deliveries with
+// exponential backoff.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"text/template"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// Defaults for unset Config fields
+const (
+	DefaultTimeout    = 5 * time.Second
+	DefaultMaxRetries = 3
+	DefaultBackoff    = time.Second
+	DefaultQueueSize  = 100
+)
+
+// maxBackoff caps the delay between retries
+const maxBackoff = time.Minute
+
+// Config configures a webhook
+type Config struct {
+	// URL receives a POST per alert
+	URL string
+
+	// Headers are added to every request, e.g. Authorization. Content-Type
+	// defaults to application/json.
+	Headers map[string]string
+
+	// Template renders the request body with text/template, given the
+	// *Alert; the json function encodes a value, e.g.
+	// {"text": {{json .Message}}}. Default: the alert as JSON.
+	Template string
+
+	// Timeout bounds each request (default: 5s)
+	Timeout time.Duration
+
+	// MaxRetries is the number of retries after a failed attempt; network
+	// errors, 429 and 5xx responses are retried (default: 3, negative: none)
+	MaxRetries int
+
+	// Backoff is the delay before the first retry, doubled for each
+	// further one (default: 1s)
+	Backoff time.Duration
+
+	// QueueSize bounds the alerts waiting for delivery; alerts raised while
+	// it is full are dropped (default: 100)
+	QueueSize int
+
+	// OnError, when set, is called for alerts that could not be delivered
+	OnError func(*types.Alert, error)
+}
+
+// Sink queues alerts and delivers them while Run is active
+type Sink struct {
+	config Config
+	tmpl   *template.Template
+	client *http.Client
+	queue  chan delivery
+
+	// render produces the request body for an alert; a nil body means the
+	// alert is not for this sink
+	render func(*types.Alert) ([]byte, error)
+
+	// deliver makes one delivery attempt, reporting whether a failure is
+	// worth retrying
+	deliver func(ctx context.Context, body []byte) (retry bool, err error)
+}
+
+// delivery is a rendered alert awaiting delivery
+type delivery struct {
+	alert *types.Alert
+	body  []byte
+}
+
+// New creates a sink for config. Returns ErrInvalidWebhook if the URL or
+// template is malformed.
+func New(config Config) (*Sink, error) {
+	u, err := url.Parse(config.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: URL %q must be absolute http(s)", types.ErrInvalidWebhook, config.URL)
+	}
+
+	s := newSink(config)
+	s.render = s.renderAlert
+	if config.Template != "" {
+		s.tmpl, err = template.New("webhook").Funcs(template.FuncMap{"json": toJSON}).Parse(config.Template)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", types.ErrInvalidWebhook, err)
+		}
+	}
+	return s, nil
+}
+
+// newSink creates a sink POSTing to config.URL, applying defaults for unset
+// fields
+func newSink(config Config) *Sink {
+	s := &Sink{config: config}
+	if s.config.Timeout <= 0 {
+		s.config.Timeout = DefaultTimeout
+	}
+	if s.config.MaxRetries == 0 {
+		s.config.MaxRetries = DefaultMaxRetries
+	}
+	if s.config.Backoff <= 0 {
+		s.config.Backoff = DefaultBackoff
+	}
+	if s.config.QueueSize <= 0 {
+		s.config.QueueSize = DefaultQueueSize
+	}
+
+	s.client = &http.Client{Timeout: s.config.Timeout}
+	s.queue = make(chan delivery, s.config.QueueSize)
+	s.deliver = s.post
+	return s
+}
+
+// Enqueue queues an alert for delivery without blocking. The body is rendered
+// right away, so it reflects the order alerts were raised in.
+func (s *Sink) Enqueue(alert *types.Alert) {
+	body, err := s.render(alert)
+	if err != nil {
+		s.failed(alert, fmt.Errorf("%w: %w", types.ErrWebhookDelivery, err))
+		return
+	}
+	if body == nil {
+		return
+	}
+
+	select {
+	case s.queue <- delivery{alert, body}:
+	default:
+		s.failed(alert, fmt.Errorf("%w: queue full", types.ErrWebhookDelivery))
+	}
+}
+
+// Run delivers queued alerts until ctx is done. Alerts still queued then are
+// delivered by the next Run.
+func (s *Sink) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d := <-s.queue:
+			if err := s.send(ctx, d.body); err != nil {
+				s.failed(d.alert, err)
+			}
+		}
+	}
+}
+
+// Send delivers one alert, retrying failed attempts with exponential backoff.
+// Returns an error wrapping ErrWebhookDelivery once retries are exhausted,
+// or ctx's error if it is done first.
+func (s *Sink) Send(ctx context.Context, alert *types.Alert) error {
+	body, err := s.render(alert)
+	if err != nil {
+		return fmt.Errorf("%w: %w", types.ErrWebhookDelivery, err)
+	}
+	if body == nil {
+		return nil
+	}
+	return s.send(ctx, body)
+}
+
+// send posts a rendered body, retrying failed attempts
+func (s *Sink) send(ctx context.Context, body []byte) error {
+	backoff := s.config.Backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := s.deliver(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= s.config.MaxRetries {
+			return fmt.Errorf("%w: %w", types.ErrWebhookDelivery, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
+
+// post makes one delivery attempt, reporting whether a failure is worth retrying
+func (s *Sink) post(ctx context.Context, body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range s.config.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("%s returned %s", s.config.URL, resp.Status)
+}
+
+// renderAlert renders the generic webhook body. Resolutions are not sent.
+func (s *Sink) renderAlert(alert *types.Alert) ([]byte, error) {
+	if alert.Resolved {
+		return nil, nil
+	}
+	if s.tmpl == nil {
+		return json.Marshal(alert)
+	}
+	var b bytes.Buffer
+	if err := s.tmpl.Execute(&b, alert); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// failed reports an alert that could not be delivered
+func (s *Sink) failed(alert *types.Alert, err error) {
+	if s.config.OnError != nil {
+		s.config.OnError(alert, err)
+	}
+}
+
+// toJSON encodes v for use in templates
+func toJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
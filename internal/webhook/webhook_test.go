@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+func testAlert() *types.Alert {
+	return &types.Alert{Type: "pause", Severity: "critical", Message: `Long "GC" pause`, Value: 750, Threshold: 100}
+}
+
+func TestSink_Send(t *testing.T) {
+	var got types.Alert
+	var auth, contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, contentType = r.Header.Get("Authorization"), r.Header.Get("Content-Type")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer server.Close()
+
+	s, err := New(Config{URL: server.URL, Headers: map[string]string{"Authorization": "Bearer token"}})
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	if err := s.Send(context.Background(), testAlert()); err != nil {
+		t.Fatalf("Send() error: %v", err)
+	}
+	if got.Message != testAlert().Message || got.Value != 750 {
+		t.Errorf("Received %+v", got)
+	}
+	if auth != "Bearer token" || contentType != "application/json" {
+		t.Errorf("Headers: Authorization %q, Content-Type %q", auth, contentType)
+	}
+}
+
+func TestSink_Template(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer server.Close()
+
+	s, err := New(Config{URL: server.URL, Template: `{"text": {{json (printf "[%s] %s" .Severity .Message)}}}`})
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	if err := s.Send(context.Background(), testAlert()); err != nil {
+		t.Fatalf("Send() error: %v", err)
+	}
+	if want := `{"text": "[critical] Long \"GC\" pause"}`; body != want {
+		t.Errorf("Body = %s, want %s", body, want)
+	}
+}
+
+func TestSink_Retries(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		attempts int32
+		ok       bool
+	}{
+		{"recovers", []int{503, 429, 200}, 3, true},
+		{"client error", []int{400}, 1, false},
+		{"exhausted", []int{500, 500, 500}, 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := attempts.Add(1)
+				w.WriteHeader(tt.statuses[min(int(n), len(tt.statuses))-1])
+			}))
+			defer server.Close()
+
+			s, _ := New(Config{URL: server.URL, MaxRetries: 2, Backoff: time.Millisecond})
+			err := s.Send(context.Background(), testAlert())
+			if (err == nil) != tt.ok {
+				t.Errorf("Send() error = %v", err)
+			}
+			if err != nil && !errors.Is(err, types.ErrWebhookDelivery) {
+				t.Errorf("Expected ErrWebhookDelivery, got %v", err)
+			}
+			if attempts.Load() != tt.attempts {
+				t.Errorf("%d attempts, want %d", attempts.Load(), tt.attempts)
+			}
+		})
+	}
+}
+
+func TestSink_RunAndQueue(t *testing.T) {
+	delivered := make(chan struct{}, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered <- struct{}{}
+	}))
+	defer server.Close()
+
+	var dropped atomic.Int32
+	s, _ := New(Config{URL: server.URL, QueueSize: 2, OnError: func(_ *types.Alert, err error) {
+		if errors.Is(err, types.ErrWebhookDelivery) {
+			dropped.Add(1)
+		}
+	}})
+
+	// Nothing is delivered before Run, so the third alert overflows the queue
+	for i := 0; i < 3; i++ {
+		s.Enqueue(testAlert())
+	}
+	if dropped.Load() != 1 {
+		t.Errorf("%d alerts dropped, want 1", dropped.Load())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-delivered:
+		case <-time.After(time.Second):
+			t.Fatalf("Only %d of 2 queued alerts delivered", i)
+		}
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	for _, config := range []Config{
+		{URL: ""},
+		{URL: "localhost:8080/hook"},
+		{URL: "ftp://example.com/hook"},
+		{URL: "http://example.com/hook", Template: "{{.Message"},
+	} {
+		if _, err := New(config); !errors.Is(err, types.ErrInvalidWebhook) {
+			t.Errorf("New(%+v) error = %v, want ErrInvalidWebhook", config, err)
+		}
+	}
+}
@@ -33,16 +33,43 @@
 //
 //	monitor.Start(ctx)
 //	defer monitor.Stop()
+//
+// # API stability
+//
+// The public API consists of this package and pkg/types and follows semantic
+// versioning: within a major version, exported identifiers are not removed or
+// renamed, function signatures do not change, and JSON field names of the
+// exported types stay the same. New fields, functions and options may be added
+// in minor releases. Packages under internal/ are not part of the API.
 package gcanalyzer
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"log/slog"
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/alerting"
 	"github.com/kyungseok-lee/go-gc-analyzer/internal/analysis"
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/baseline"
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/bundle"
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/chaos"
 	"github.com/kyungseok-lee/go-gc-analyzer/internal/collector"
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/config"
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/gctrace"
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/i18n"
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/promimport"
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/region"
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/remote"
 	"github.com/kyungseok-lee/go-gc-analyzer/internal/reporting"
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/webhook"
 	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
 )
 
@@ -54,27 +81,388 @@ const (
 	// Pause time thresholds
 	AlertWarningPauseThreshold  = types.ThresholdPauseWarning  // 100ms
 	AlertCriticalPauseThreshold = types.ThresholdPauseCritical // 500ms
+
+	// GC frequency and heap growth thresholds, over the last AlertWindow
+	AlertGCFrequencyThreshold    = types.ThresholdGCFrequencyHigh    // 10 GCs/s
+	AlertHeapGrowthRateThreshold = types.ThresholdHeapGrowthRateHigh // 10 MB/s
+	AlertWindow                  = types.DefaultAlertWindow          // 1m
 )
 
 // Re-export commonly used types for convenience
 type (
-	GCMetrics         = types.GCMetrics
-	GCAnalysis        = types.GCAnalysis
-	GCEvent           = types.GCEvent
-	MemoryPoint       = types.MemoryPoint
-	HealthCheckStatus = types.HealthCheckStatus
+	GCMetrics             = types.GCMetrics
+	GCAnalysis            = types.GCAnalysis
+	Alert                 = types.Alert
+	AlertRecord           = types.AlertRecord
+	PauseSLOStatus        = types.PauseSLOStatus
+	GCEvent               = types.GCEvent
+	MemoryPoint           = types.MemoryPoint
+	HealthCheckStatus     = types.HealthCheckStatus
+	HealthCheckConfig     = types.HealthCheckConfig
+	HealthPoint           = types.HealthPoint
+	HealthProfile         = types.HealthProfile
+	RetentionPolicy       = types.RetentionPolicy
+	AdaptiveInterval      = types.AdaptiveInterval
+	RetentionTier         = types.RetentionTier
+	MonitorSnapshot       = types.MonitorSnapshot
+	GCSnapshot            = types.GCSnapshot
+	GCDelta               = types.GCDelta
+	Logger                = types.Logger
+	SugaredLogger         = types.SugaredLogger
+	Source                = types.Source
+	SliceSource           = types.SliceSource
+	OOMForecast           = types.OOMForecast
+	RobustStats           = types.RobustStats
+	PauseApdex            = types.PauseApdex
+	MMUPoint              = types.MMUPoint
+	PauseOutliers         = types.PauseOutliers
+	PauseOutlier          = types.PauseOutlier
+	LeakAnalysis          = types.LeakAnalysis
+	ReclamationAnalysis   = types.ReclamationAnalysis
+	SoftLimitAnalysis     = types.SoftLimitAnalysis
+	PagingAnalysis        = types.PagingAnalysis
+	PeriodicityAnalysis   = types.PeriodicityAnalysis
+	Recommendation        = types.Recommendation
+	RecommendationCode    = types.RecommendationCode
+	Severity              = types.Severity
+	RuntimeInfo           = types.RuntimeInfo
+	KubernetesInfo        = types.KubernetesInfo
+	ConfigDrift           = types.ConfigDrift
+	GCPhases              = types.GCPhases
+	PhaseBreakdown        = types.PhaseBreakdown
+	Changepoint           = types.Changepoint
+	TuningChange          = types.TuningChange
+	GCCPUBreakdown        = types.GCCPUBreakdown
+	ProcessCPUAnalysis    = types.ProcessCPUAnalysis
+	SysBreakdown          = types.SysBreakdown
+	RSSAnalysis           = types.RSSAnalysis
+	Bundle                = types.Bundle
+	BuildInfo             = types.BuildInfo
+	BuildChange           = types.BuildChange
+	UpgradeComparison     = types.UpgradeComparison
+	MetricDelta           = types.MetricDelta
+	RegressionPolicy      = types.RegressionPolicy
+	Regression            = types.Regression
+	RegressionError       = types.RegressionError
+	InsufficientDataError = types.InsufficientDataError
+	CounterReset          = types.CounterReset
+	SizeClassBucket       = types.SizeClassBucket
+	SizeClassDistribution = types.SizeClassDistribution
+	MemProfile            = types.MemProfile
+	AllocSite             = types.AllocSite
+	AllocSiteReport       = types.AllocSiteReport
+	PauseHistogram        = types.PauseHistogram
+	PauseDistribution     = types.PauseDistribution
+	PauseQuantiles        = types.PauseQuantiles
+	RegionStats           = types.RegionStats
+	RequestImpact         = types.RequestImpact
+	RegionBreakdown       = types.RegionBreakdown
+	SeasonalDeviation     = types.SeasonalDeviation
+	BaselineSnapshot      = types.BaselineSnapshot
+	BaselineBucket        = types.BaselineBucket
+	AnalyzerOptions       = analysis.Options
+	AlertRule             = alerting.Rule
+	AlertCondition        = alerting.Condition
+	PauseSLO              = alerting.PauseSLO
+	WebhookConfig         = webhook.Config
+	PagerDutyConfig       = webhook.PagerDutyConfig
+	EmailConfig           = webhook.EmailConfig
+	EmailData             = webhook.EmailData
+	ExporterConfig        = config.Exporter
+)
+
+// Severity levels for recommendations
+const (
+	SeverityInfo     = types.SeverityInfo
+	SeverityWarning  = types.SeverityWarning
+	SeverityCritical = types.SeverityCritical
+)
+
+// Recommendation codes reported in Recommendation.Code
+const (
+	CodeHighGCFrequency         = types.CodeHighGCFrequency
+	CodeFrequentForcedGC        = types.CodeFrequentForcedGC
+	CodeLongPause               = types.CodeLongPause
+	CodeVeryLongP99Pause        = types.CodeVeryLongP99Pause
+	CodeHighHeapGrowth          = types.CodeHighHeapGrowth
+	CodeHighGCOverhead          = types.CodeHighGCOverhead
+	CodeCPUSaturatedGC          = types.CodeCPUSaturatedGC
+	CodeLowMemoryEfficiency     = types.CodeLowMemoryEfficiency
+	CodeHighMarkAssist          = types.CodeHighMarkAssist
+	CodeHighAllocationRate      = types.CodeHighAllocationRate
+	CodePoolSmallObjects        = types.CodePoolSmallObjects
+	CodeReuseLargeBuffers       = types.CodeReuseLargeBuffers
+	CodeNonGoMemoryGrowth       = types.CodeNonGoMemoryGrowth
+	CodeNearMemoryLimit         = types.CodeNearMemoryLimit
+	CodeSetGOMemLimit           = types.CodeSetGOMemLimit
+	CodeGOMAXPROCSAboveCPULimit = types.CodeGOMAXPROCSAboveCPULimit
+	CodeConsistentGrowth        = types.CodeConsistentGrowth
+	CodeDecliningReclamation    = types.CodeDecliningReclamation
+)
+
+// GC trigger reasons reported in GCEvent.TriggerReason
+const (
+	TriggerHeapSize  = types.TriggerHeapSize
+	TriggerPeriodic  = types.TriggerPeriodic
+	TriggerForced    = types.TriggerForced
+	TriggerAutomatic = types.TriggerAutomatic
+	TriggerUnknown   = types.TriggerUnknown
+)
+
+// Reporter types for callers that need report options
+type (
+	Reporter          = reporting.Reporter
+	ReportOptions     = reporting.Options
+	JSONReportOptions = reporting.JSONReportOptions
+	MetricsOptions    = reporting.MetricsOptions
+	ChartType         = reporting.ChartType
+	ChartOptions      = reporting.ChartOptions
+	Template          = reporting.Template
+	TemplateData      = reporting.TemplateData
+	ReportSchedule    = reporting.Schedule
+	ReportFormat      = reporting.ReportFormat
+	Language          = i18n.Language
+	NumberFormat      = types.NumberFormat
+	RoundingMode      = types.RoundingMode
+)
+
+// Supported report languages
+const (
+	LanguageEnglish = i18n.English
+	LanguageKorean  = i18n.Korean
+)
+
+// Rounding modes for NumberFormat
+const (
+	RoundHalfEven     = types.RoundHalfEven
+	RoundHalfUp       = types.RoundHalfUp
+	RoundTowardZero   = types.RoundTowardZero
+	RoundAwayFromZero = types.RoundAwayFromZero
+)
+
+// Metrics alert rules can be written against; see AlertRule for units
+const (
+	AlertMetricGCCPUFraction  = alerting.MetricGCCPUFraction
+	AlertMetricGCFrequency    = alerting.MetricGCFrequency
+	AlertMetricHeapAlloc      = alerting.MetricHeapAlloc
+	AlertMetricHeapGrowthRate = alerting.MetricHeapGrowthRate
+	AlertMetricAllocRate      = alerting.MetricAllocRate
+	AlertMetricAvgPause       = alerting.MetricAvgPause
+	AlertMetricP99Pause       = alerting.MetricP99Pause
+	AlertMetricMaxPause       = alerting.MetricMaxPause
+	AlertMetricPause          = alerting.MetricPause
+)
+
+// Size limits on untrusted input; larger input fails with ErrInputTooLarge
+const (
+	MaxGCTraceSize  = types.MaxGCTraceSize
+	MaxBundleSize   = types.MaxBundleSize
+	MaxBaselineSize = types.MaxBaselineSize
+)
+
+// Allocation region tracking
+type (
+	RegionTracker = region.Tracker
+	RegionSpan    = region.Span
+)
+
+// NewRegionTracker creates a tracker that attributes allocations to code
+// regions wrapped in Begin/End. Pass its Stats via AnalyzerOptions.Regions.
+func NewRegionTracker() *RegionTracker {
+	return region.NewTracker()
+}
+
+// ChaosScenario identifies a synthetic failure pattern for Monitor.InjectChaos
+type ChaosScenario = chaos.Scenario
+
+// Chaos scenarios
+const (
+	ChaosLeak       = chaos.Leak       // live heap grows on every sample
+	ChaosPauseStorm = chaos.PauseStorm // every sample completes a GC with a critical pause
+	ChaosThrash     = chaos.Thrash     // many GCs per sample with very high GC CPU usage
+)
+
+// Format is the encoding of a metrics file read by LoadMetrics
+type Format = bundle.Format
+
+// Metrics file formats
+const (
+	FormatJSON  = bundle.FormatJSON  // a capture bundle or a JSON array of samples
+	FormatJSONL = bundle.FormatJSONL // one JSON sample per line
 )
 
-// Re-export commonly used errors
+// RemoteFormat selects the debug endpoint RemoteSampler reads MemStats from
+type RemoteFormat = remote.Format
+
+// Remote endpoint formats
+const (
+	RemoteExpvar = remote.FormatExpvar // the "memstats" variable of /debug/vars
+	RemotePprof  = remote.FormatPprof  // the MemStats trailer of /debug/pprof/heap?debug=1
+)
+
+// RemoteSampler returns a MonitorConfig.Sampler that reads the MemStats of
+// another Go process from the debug endpoints it serves at target, e.g.
+// "http://localhost:6060", for monitoring programs that can't embed the library.
+// An empty format means RemoteExpvar.
+func RemoteSampler(target string, format RemoteFormat) (func(context.Context) (*GCMetrics, error), error) {
+	client, err := remote.NewClient(target, format)
+	if err != nil {
+		return nil, err
+	}
+	return client.Sample, nil
+}
+
+// Re-export commonly used errors. Returned errors usually wrap these with
+// context, so compare with errors.Is.
 var (
-	ErrInsufficientData = types.ErrInsufficientData
+	ErrCollectorAlreadyRunning = types.ErrCollectorAlreadyRunning
+	ErrCollectorNotRunning     = types.ErrCollectorNotRunning
+	ErrInsufficientData        = types.ErrInsufficientData
+	ErrInvalidMemoryLimit      = types.ErrInvalidMemoryLimit
+	ErrInvalidGCTrace          = types.ErrInvalidGCTrace
+	ErrUnknownScenario         = types.ErrUnknownScenario
+	ErrInvalidBundle           = types.ErrInvalidBundle
+	ErrMissingRuntimeInfo      = types.ErrMissingRuntimeInfo
+	ErrSameGoVersion           = types.ErrSameGoVersion
+	ErrBaselineDisabled        = types.ErrBaselineDisabled
+	ErrInvalidBaseline         = types.ErrInvalidBaseline
+	ErrUnknownRemoteFormat     = types.ErrUnknownRemoteFormat
+	ErrRemoteUnavailable       = types.ErrRemoteUnavailable
+	ErrInvalidRemoteData       = types.ErrInvalidRemoteData
+	ErrInputTooLarge           = types.ErrInputTooLarge
+	ErrInvalidAlertRule        = types.ErrInvalidAlertRule
+	ErrInvalidWebhook          = types.ErrInvalidWebhook
+	ErrWebhookDelivery         = types.ErrWebhookDelivery
+	ErrInvalidEmail            = types.ErrInvalidEmail
+	ErrInvalidSLO              = types.ErrInvalidSLO
+	ErrSLODisabled             = types.ErrSLODisabled
+	ErrUnknownHealthProfile    = types.ErrUnknownHealthProfile
+	ErrInvalidConfig           = types.ErrInvalidConfig
+	ErrInvalidRetention        = types.ErrInvalidRetention
+	ErrInvalidLabel            = types.ErrInvalidLabel
+	ErrInvalidNamespace        = types.ErrInvalidNamespace
+	ErrInvalidInterval         = types.ErrInvalidInterval
+	ErrInvalidMetrics          = types.ErrInvalidMetrics
+	ErrUnknownFormat           = types.ErrUnknownFormat
+	ErrInvalidPrometheusData   = types.ErrInvalidPrometheusData
+	ErrInvalidSweep            = types.ErrInvalidSweep
+	ErrRegression              = types.ErrRegression
+	ErrInvalidReportSchedule   = types.ErrInvalidReportSchedule
+	ErrInvalidDuration         = types.ErrInvalidDuration
+	ErrNoAnalysisData          = types.ErrNoAnalysisData
+	ErrNoMetricsData           = types.ErrNoMetricsData
+	ErrNoEventsData            = types.ErrNoEventsData
+	ErrUnknownChartType        = types.ErrUnknownChartType
 )
 
+// Config is a monitoring configuration loaded from a file by LoadConfig
+type Config struct {
+	// Monitor is ready for NewMonitor once callbacks such as OnAlert are set
+	Monitor *MonitorConfig
+
+	// Exporter is where to serve MetricsHandler and HealthHandler, with
+	// defaults applied; nil when the file has no exporter section
+	Exporter *ExporterConfig
+}
+
+// LoadConfig builds a monitor configuration from a YAML or JSON file, so
+// thresholds, alert rules, notifiers and the exporter can be tuned without
+// recompiling. Returns ErrInvalidConfig if the file is malformed, and
+// ErrInvalidAlertRule or ErrUnknownHealthProfile for invalid settings.
+func LoadConfig(path string) (*Config, error) {
+	f, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := f.Rules()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	health, err := f.HealthCheck()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	result := &Config{Monitor: &MonitorConfig{
+		Interval:                 time.Duration(f.Interval),
+		IntervalJitter:           f.IntervalJitter,
+		MaxSamples:               f.MaxSamples,
+		MaxAlerts:                f.MaxAlerts,
+		Labels:                   f.Labels,
+		AlertWebhook:             f.Webhook(),
+		PagerDuty:                f.PagerDuty(),
+		AlertEmail:               f.Email(),
+		AlertCooldown:            time.Duration(f.Alerts.Cooldown),
+		AlertCooldowns:           f.Cooldowns(),
+		PauseSLO:                 f.SLO(),
+		AlertRules:               rules,
+		DisableDefaultAlertRules: f.Alerts.DisableDefaults,
+		MemoryLimit:              uint64(f.MemoryLimit),
+		SeasonalBaseline:         f.SeasonalBaseline,
+		SeasonalLocation:         f.Location(),
+		HealthCheck:              health,
+		SnapshotInterval:         time.Duration(f.SnapshotInterval),
+		ProcessCPU:               f.ProcessCPU,
+		ProcessRSS:               f.ProcessRSS,
+		Paging:                   f.Paging,
+		PauseQuantiles:           f.PauseQuantiles,
+		NotifyGC:                 f.NotifyGC,
+	}}
+	if f.Exporter != nil {
+		exporter := *f.Exporter
+		exporter.Listen = cmp.Or(exporter.Listen, ":9090")
+		exporter.MetricsPath = cmp.Or(exporter.MetricsPath, "/metrics")
+		exporter.HealthPath = cmp.Or(exporter.HealthPath, "/health")
+		result.Exporter = &exporter
+	}
+	return result, nil
+}
+
+// ParseAlertRule parses a rule expression such as
+// "p99_pause > 200ms for 3 consecutive windows" or
+// "heap_growth_rate > 5MB/s over 5m" for Monitor.AddAlertRule.
+// Returns ErrInvalidAlertRule if the expression is malformed.
+func ParseAlertRule(expr string) (AlertRule, error) {
+	return alerting.ParseRule(expr)
+}
+
+// AlertSlope returns the metric name of metric's per-second slope, fitted by
+// least squares over a rule's lookback, e.g. AlertSlope(AlertMetricHeapAlloc)
+// for "slope(heap_alloc)"
+func AlertSlope(metric string) string {
+	return alerting.Slope(metric)
+}
+
+// AlertChange returns the metric name of metric's relative change across a
+// rule's lookback, e.g. AlertChange(AlertMetricGCFrequency) >= 1 when the GC
+// frequency doubled
+func AlertChange(metric string) string {
+	return alerting.Change(metric)
+}
+
+// DefaultAlertRules returns the rules monitors start with unless
+// DisableDefaultAlertRules is set
+func DefaultAlertRules() []AlertRule {
+	return alerting.DefaultRules()
+}
+
 // CollectOnce collects a single GC metrics snapshot
 func CollectOnce() *GCMetrics {
 	return collector.CollectOnce()
 }
 
+// Snapshot reads the runtime's cumulative GC and allocation counters, so
+// the GC impact of an operation can be measured without a collector:
+//
+//	snap := gcanalyzer.Snapshot()
+//	handle(req)
+//	delta := snap.Delta()
+//
+// It briefly stops the world, like ReadMemStats.
+func Snapshot() *GCSnapshot {
+	return types.ReadGCSnapshot()
+}
+
 // CollectForDuration collects GC metrics for a specified duration
 func CollectForDuration(ctx context.Context, duration, interval time.Duration) ([]*GCMetrics, error) {
 	return collector.CollectForDuration(ctx, duration, interval)
@@ -86,24 +474,165 @@ func Analyze(metrics []*GCMetrics) (*GCAnalysis, error) {
 	return analyzer.Analyze()
 }
 
+// AnalyzeLite computes only GC frequency, rates and averages, skipping pause
+// percentiles and derived analyses, for deployments where the full analysis
+// cost matters
+func AnalyzeLite(metrics []*GCMetrics) (*GCAnalysis, error) {
+	return analysis.New(metrics).AnalyzeLite()
+}
+
+// AnalyzeSource analyzes the samples and events read from src until it is
+// exhausted, for custom data origins implementing Source. A nil opts uses
+// the defaults.
+func AnalyzeSource(src Source, opts *AnalyzerOptions) (*GCAnalysis, error) {
+	analyzer, err := analysis.NewFromSource(src, opts)
+	if err != nil {
+		return nil, err
+	}
+	return analyzer.Analyze()
+}
+
+// NewSliceSource creates a Source yielding in-memory samples and events
+func NewSliceSource(metrics []*GCMetrics, events []*GCEvent) *SliceSource {
+	return types.NewSliceSource(metrics, events)
+}
+
 // AnalyzeWithEvents performs analysis with both metrics and events
 func AnalyzeWithEvents(metrics []*GCMetrics, events []*GCEvent) (*GCAnalysis, error) {
 	analyzer := analysis.NewWithEvents(metrics, events)
 	return analyzer.Analyze()
 }
 
+// AnalyzeWithOptions performs analysis with metrics, events and analyzer options
+func AnalyzeWithOptions(metrics []*GCMetrics, events []*GCEvent, opts *AnalyzerOptions) (*GCAnalysis, error) {
+	analyzer := analysis.NewWithOptions(metrics, events, opts)
+	return analyzer.Analyze()
+}
+
+// ParseGCTrace parses GODEBUG=gctrace=1 output into GC events.
+// processStart is the start time of the traced process, since gctrace reports
+// times relative to it. Pass the result via AnalyzerOptions.GCTrace to merge it
+// with in-process metrics and events.
+func ParseGCTrace(r io.Reader, processStart time.Time) ([]*GCEvent, error) {
+	return gctrace.Parse(r, processStart)
+}
+
+// ParseGCTraceContext is ParseGCTrace for untrusted input: reading stops with
+// ctx's error once ctx is done, and with ErrInputTooLarge past MaxGCTraceSize.
+func ParseGCTraceContext(ctx context.Context, r io.Reader, processStart time.Time) ([]*GCEvent, error) {
+	return gctrace.ParseContext(ctx, r, processStart)
+}
+
+// ImportPrometheus reconstructs an approximate sample series from the
+// go_gc_* and go_memstats_* series stored in the Prometheus server at
+// prometheusURL, for analyzing past incidents. matchers pick out a single
+// process, e.g. `job="api",instance="10.0.0.7:8080"`; a sample is built per
+// step from start to end. Prometheus doesn't store individual pauses, so pause
+// statistics are limited to averages and the go_gc_duration_seconds quantiles.
+func ImportPrometheus(ctx context.Context, prometheusURL, matchers string, start, end time.Time, step time.Duration) ([]*GCMetrics, error) {
+	return promimport.NewClient(prometheusURL).Import(ctx, matchers, start, end, step)
+}
+
+// ParsePrometheusRange reconstructs samples from a saved Prometheus range
+// query response, as ImportPrometheus does. Errors wrap ErrInvalidPrometheusData.
+func ParsePrometheusRange(r io.Reader) ([]*GCMetrics, error) {
+	return promimport.Decode(context.Background(), r)
+}
+
+// CurrentRuntimeInfo reads the runtime configuration of the current process.
+// Pass it via AnalyzerOptions.Runtime to record it on an analysis.
+func CurrentRuntimeInfo() *RuntimeInfo {
+	return types.CurrentRuntimeInfo()
+}
+
+// CurrentBuildInfo reads the module versions and VCS revision embedded in the
+// current binary. Set it on a Bundle captured elsewhere to annotate it.
+func CurrentBuildInfo() *BuildInfo {
+	return types.CurrentBuildInfo()
+}
+
+// ReadSizeClasses groups the current process's sampled allocation profile by
+// size class. Pass it via AnalyzerOptions.SizeClasses to get object reuse
+// recommendations for the dominant class.
+func ReadSizeClasses() *SizeClassDistribution {
+	return types.ReadSizeClassDistribution()
+}
+
+// DefaultTopAllocSites is the number of allocation sites monitors report
+const DefaultTopAllocSites = types.DefaultTopAllocSites
+
+// ReadPauseQuantiles reads the current process's pause quantiles with
+// debug.ReadGCStats. Returns nil before the first GC cycle.
+func ReadPauseQuantiles() *PauseQuantiles {
+	return types.ReadPauseQuantiles()
+}
+
+// ReadMemProfile captures the current process's cumulative allocation profile.
+// Capture one before and one after a workload and pass both to AttributeAllocSites.
+func ReadMemProfile() *MemProfile {
+	return types.ReadMemProfile()
+}
+
+// AttributeAllocSites ranks the code locations that allocated the most between
+// two profiles, keeping at most top sites. Pass the result via AnalyzerOptions.AllocSites to add a top
+// allocation sites table to reports.
+func AttributeAllocSites(before, after *MemProfile, top int) *AllocSiteReport {
+	return types.AttributeAllocSites(before, after, top)
+}
+
+// CheckConfigDrift compares the runtime configuration recorded on an analysis
+// with the current process. A non-empty result means the analysis was derived
+// under different GOGC/GOMEMLIMIT/Go version settings than are now in effect.
+func CheckConfigDrift(analysis *GCAnalysis) []ConfigDrift {
+	if analysis == nil {
+		return nil
+	}
+	return types.DetectConfigDrift(analysis.Runtime, types.CurrentRuntimeInfo())
+}
+
 // GenerateTextReport generates a detailed text report
 func GenerateTextReport(analysis *GCAnalysis, metrics []*GCMetrics, events []*GCEvent, w io.Writer) error {
 	reporter := reporting.New(analysis, metrics, events)
 	return reporter.GenerateTextReport(w)
 }
 
+// NewReporter creates a reporter with options such as the output language
+func NewReporter(analysis *GCAnalysis, metrics []*GCMetrics, events []*GCEvent, opts *ReportOptions) *Reporter {
+	return reporting.NewWithOptions(analysis, metrics, events, opts)
+}
+
 // GenerateJSONReport generates a JSON report
 func GenerateJSONReport(analysis *GCAnalysis, metrics []*GCMetrics, events []*GCEvent, w io.Writer, indent bool) error {
 	reporter := reporting.New(analysis, metrics, events)
 	return reporter.GenerateJSONReport(w, indent)
 }
 
+// Chart types for GenerateChartSVG
+const (
+	ChartTypeHeapTrend      = reporting.ChartTypeHeapTrend      // HeapAlloc over time, from the metrics
+	ChartTypePauseHistogram = reporting.ChartTypePauseHistogram // pause counts per duration bucket, from the events
+)
+
+// Report formats for ReportSchedule
+const (
+	ReportFormatSummary = reporting.ReportFormatSummary
+	ReportFormatText    = reporting.ReportFormatText
+	ReportFormatTable   = reporting.ReportFormatTable
+	ReportFormatJSON    = reporting.ReportFormatJSON
+)
+
+// GenerateChartSVG writes a standalone SVG chart drawn from metrics or
+// events, for HTML reports and wikis. A nil opts uses the defaults.
+func GenerateChartSVG(w io.Writer, chart ChartType, metrics []*GCMetrics, events []*GCEvent, opts *ChartOptions) error {
+	return reporting.New(nil, metrics, events).GenerateChartSVG(w, chart, opts)
+}
+
+// ExportChromeTrace writes GC events, with their phases when known, as
+// Chrome trace-event JSON for chrome://tracing or Perfetto
+func ExportChromeTrace(w io.Writer, events []*GCEvent) error {
+	return reporting.New(nil, nil, events).GenerateChromeTrace(w)
+}
+
 // GenerateSummaryReport generates a concise summary report
 func GenerateSummaryReport(analysis *GCAnalysis, w io.Writer) error {
 	reporter := reporting.New(analysis, nil, nil)
@@ -116,10 +645,81 @@ func GenerateHealthCheck(analysis *GCAnalysis) *HealthCheckStatus {
 	return reporter.GenerateHealthCheck()
 }
 
+// DefaultHealthCheckConfig returns the thresholds and penalties health checks
+// use unless ReportOptions.HealthCheck or MonitorConfig.HealthCheck is set
+func DefaultHealthCheckConfig() HealthCheckConfig {
+	return types.DefaultHealthCheckConfig()
+}
+
+// HealthCheckProfile returns the config of a predefined health scoring
+// profile, to adjust further. Returns ErrUnknownHealthProfile for
+// unsupported profiles.
+func HealthCheckProfile(profile HealthProfile) (HealthCheckConfig, error) {
+	return types.HealthCheckProfile(profile)
+}
+
+// DefaultAdaptiveInterval returns sampling every 5s while quiet and every
+// 250ms while GC frequency or allocation rate exceeds the health check's
+// high thresholds
+func DefaultAdaptiveInterval() AdaptiveInterval {
+	return types.DefaultAdaptiveInterval()
+}
+
+// DefaultRetentionPolicy returns 5 minutes at full resolution, then 10s
+// averages for an hour and 1m averages for a day
+func DefaultRetentionPolicy() RetentionPolicy {
+	return types.DefaultRetentionPolicy()
+}
+
 // Monitor provides continuous GC monitoring capabilities
 type Monitor struct {
 	collector *collector.Collector
 	config    *MonitorConfig
+	regions   *region.Tracker
+	requests  *region.RequestTracker
+	logger    Logger
+	seasonal  *baseline.Seasonal
+	rules     *alerting.Engine
+	// conditions tracks the seasonal and OOM forecast alerts, which are not rules
+	conditions *alerting.Conditions
+	cooldown   *alerting.Cooldown
+	history    *alerting.History // nil when MaxAlerts is negative
+	health     *reporting.HealthHistory
+	slo        *alerting.SLOTracker
+	sinks      []*webhook.Sink
+	// configErr is the first alerting configuration error, returned by Start
+	configErr error
+	// lifecycle serializes Start and StopE, so a stop always finds the alert
+	// delivery its start began. It guards stopSinks.
+	lifecycle sync.Mutex
+	// stopSinks stops alert delivery and scheduled reports started by Start
+	stopSinks context.CancelFunc
+
+	// profileStart is the allocation profile when monitoring began, so
+	// allocation sites are ranked by their rate over the monitored window.
+	// Nil when a Sampler monitors another process.
+	profileStart *MemProfile
+
+	mu         sync.Mutex
+	lastMetric *GCMetrics // previous sample, for interval rates
+
+	// snapshot is the latest published state; snapshotMu serializes publishers
+	snapshot   atomic.Pointer[MonitorSnapshot]
+	snapshotMu sync.Mutex
+	// healthStatus is the last known health status, for AlertLogger
+	healthStatus string
+
+	// analysisMu guards the cached analysis, which stays valid until a new
+	// sample arrives
+	analysisMu  sync.Mutex
+	analysis    *GCAnalysis
+	analysisKey analysisKey
+}
+
+// analysisKey identifies the samples an analysis was computed from
+type analysisKey struct {
+	last  time.Time
+	count int
 }
 
 // MonitorConfig holds configuration for continuous monitoring
@@ -127,29 +727,174 @@ type MonitorConfig struct {
 	// Collection interval (default: 1 second)
 	Interval time.Duration
 
+	// IntervalJitter spreads each wait between samples uniformly over the
+	// interval ± this fraction of it, e.g. 0.1, so replicas started together
+	// don't sample in lockstep. Start returns ErrInvalidInterval unless it
+	// is in [0, 1).
+	IntervalJitter float64
+
+	// AdaptiveInterval, when set, replaces Interval: sampling slows to its
+	// Max while GC is quiet and tightens to its Min while GC frequency or
+	// allocation rate spikes, see CollectionInterval. Start returns
+	// ErrInvalidInterval if it is malformed.
+	AdaptiveInterval *AdaptiveInterval
+
 	// Maximum samples to keep in memory (default: 1000)
 	MaxSamples int
 
+	// Labels identify the monitored process, e.g. service, version and
+	// region. They are recorded on analyses, so JSON reports include them,
+	// added to the labels of the Prometheus metrics, and set on alerts.
+	// Start returns ErrInvalidLabel unless the names are valid Prometheus
+	// label names.
+	Labels map[string]string
+
+	// Retention, when set, keeps samples at full resolution for its
+	// FullResolution period, within MaxSamples, and averages older samples
+	// into coarser tiers, so analyses can span hours in bounded memory, see
+	// DefaultRetentionPolicy. Start returns ErrInvalidRetention if it is
+	// malformed.
+	Retention *RetentionPolicy
+
+	// Maximum alerts to keep in the history GetAlerts returns (default: 100;
+	// negative: no history)
+	MaxAlerts int
+
 	// Alert callback function
 	OnAlert func(*Alert)
 
+	// OnAlertResolved is called with a copy of an alert, Resolved set, once
+	// the condition behind it clears
+	OnAlertResolved func(*Alert)
+
+	// AlertLogger, when set, receives a structured record for every alert
+	// and resolution, and for every change of the snapshot's health status.
+	// Critical maps to slog.LevelError, warning to slog.LevelWarn, and
+	// resolutions and healthy statuses to slog.LevelInfo.
+	AlertLogger *slog.Logger
+
+	// Logger receives diagnostics about internal problems: panicking
+	// callbacks, which are recovered, failed samples, GC cycles missed
+	// between samples and undelivered alerts (default: NopLogger).
+	// *slog.Logger implements it; use ZapLogger for zap.
+	Logger Logger
+
+	// AlertWebhook, when set, POSTs every alert to an HTTP endpoint, in the
+	// background while the monitor runs. Start returns ErrInvalidWebhook if
+	// it is malformed.
+	AlertWebhook *WebhookConfig
+
+	// PagerDuty, when set, triggers a PagerDuty incident per alert type on
+	// alerts of at least its MinSeverity, and resolves it once those alerts
+	// have resolved. Start returns ErrInvalidWebhook if it is malformed.
+	PagerDuty *PagerDutyConfig
+
+	// AlertEmail, when set, emails alerts of at least its MinSeverity and
+	// their resolutions, with the latest health check and summary report.
+	// Start returns ErrInvalidEmail if it is malformed.
+	AlertEmail *EmailConfig
+
+	// AlertCooldown suppresses alerts of a type raised less than this long
+	// after the last one of that type, unless their severity is higher, and
+	// their resolutions (default: 0, no cooldown). A suppressed alert that
+	// has not resolved when the cooldown ends is raised then.
+	AlertCooldown time.Duration
+
+	// AlertCooldowns overrides AlertCooldown per alert type, e.g. "pause"
+	AlertCooldowns map[string]time.Duration
+
+	// PauseSLO, when set, classifies windows by their longest pause and
+	// alerts when the objective's error budget burns fast (critical) or slow
+	// (warning), see PauseSLOStatus. Start returns ErrInvalidSLO if it is
+	// malformed.
+	PauseSLO *PauseSLO
+
+	// PauseApdexTarget is the pause satisfying the pause Apdex score reported
+	// on analyses and health checks (default: 1ms)
+	PauseApdexTarget time.Duration
+
+	// AlertRules are registered in addition to the defaults, as if with
+	// AddAlertRule. Start returns ErrInvalidAlertRule if one is malformed.
+	AlertRules []AlertRule
+
+	// DisableDefaultAlertRules starts the monitor without DefaultAlertRules,
+	// so only rules registered with AddAlertRule raise threshold alerts
+	DisableDefaultAlertRules bool
+
 	// Metric collection callback
 	OnMetric func(*GCMetrics)
 
-	// GC event callback
+	// GC event callback; also receives gap markers for cycles whose pause
+	// data was lost, see GCEvent.IsGap
 	OnGCEvent func(*GCEvent)
-}
 
-// Alert represents a GC performance alert
-type Alert struct {
-	Type      string     `json:"type"`     // frequency, pause, overhead, memory
-	Severity  string     `json:"severity"` // info, warning, critical
-	Message   string     `json:"message"`
-	Value     float64    `json:"value"`
-	Threshold float64    `json:"threshold"`
-	Metric    *GCMetrics `json:"metric,omitempty"`
-	Event     *GCEvent   `json:"event,omitempty"`
-	Timestamp time.Time  `json:"timestamp"`
+	// MemoryLimit is the limit used for OOM forecasting. When zero, the
+	// detected container (cgroup) memory limit is used, then GOMEMLIMIT if set;
+	// otherwise forecasting is disabled.
+	MemoryLimit uint64
+
+	// SeasonalBaseline learns a minute-of-day baseline of GC frequency, heap
+	// size and GC CPU fraction, and alerts on deviations from it instead of
+	// static thresholds. Until a minute of the day has been observed on
+	// MinSeasonalDays days, the static thresholds apply.
+	SeasonalBaseline bool
+
+	// SeasonalLocation is the time zone minutes of the day are taken in (default: time.Local)
+	SeasonalLocation *time.Location
+
+	// HealthCheck sets the thresholds and penalties of the snapshot's
+	// health check, see DefaultHealthCheckConfig
+	HealthCheck *HealthCheckConfig
+
+	// HealthProfile selects a predefined HealthCheck config when HealthCheck
+	// is nil. Start returns ErrUnknownHealthProfile for unsupported profiles.
+	HealthProfile HealthProfile
+
+	// SnapshotInterval is the minimum time between analysis refreshes in the
+	// published snapshot; the latest metrics are always current (default: every sample)
+	SnapshotInterval time.Duration
+
+	// ProcessCPU samples the process's OS-reported CPU time so analyses can
+	// relate GC CPU to actual CPU usage and factor CPU saturation into
+	// recommendations (unix platforms only)
+	ProcessCPU bool
+
+	// ProcessRSS samples the process's resident set size so analyses can flag
+	// memory growth outside the Go runtime (Linux only)
+	ProcessRSS bool
+
+	// Paging samples major page faults (unix) and swap usage (Linux) so
+	// analyses can tell pauses caused by a host short of memory from those
+	// GC tuning can fix
+	Paging bool
+
+	// PooledMetrics reuses the pause slices of samples that have left the
+	// MaxSamples window, so steady-state collection allocates almost nothing,
+	// which matters at intervals of milliseconds. GetMetrics then returns
+	// copies; samples passed to OnMetric, and Snapshot().Metrics, stay valid
+	// until MaxSamples newer samples have been collected, so Clone them to
+	// keep them longer. Ignored with a Sampler.
+	PooledMetrics bool
+
+	// PauseQuantiles samples pause quantiles with debug.ReadGCStats, which
+	// cover the runtime's recent pauses even when samples are far apart
+	PauseQuantiles bool
+
+	// NotifyGC also samples as soon as each GC cycle completes, so OnGCEvent
+	// and pause alerts fire within milliseconds instead of at the next
+	// interval. Frequent GCs then fill MaxSamples faster.
+	NotifyGC bool
+
+	// Sampler, when set, produces each sample instead of reading this
+	// process's runtime, e.g. RemoteSampler to monitor another process.
+	// Ticks where it fails are skipped.
+	Sampler func(context.Context) (*GCMetrics, error)
+
+	// ReportSchedule, when set, generates a report of the current analysis
+	// every Interval while the monitor runs and delivers it to the
+	// schedule's writer, file and webhook. Failures are reported to Logger.
+	// Start returns ErrInvalidReportSchedule if it is malformed.
+	ReportSchedule *ReportSchedule
 }
 
 // NewMonitor creates a new continuous GC monitor
@@ -169,23 +914,114 @@ func NewMonitor(config *MonitorConfig) *Monitor {
 		config.MaxSamples = types.DefaultMaxSamples
 	}
 
+	if config.MaxAlerts == 0 {
+		config.MaxAlerts = types.DefaultMaxAlerts
+	}
+
 	monitor := &Monitor{
-		config: config,
+		config:   config,
+		logger:   config.Logger,
+		regions:  region.NewTracker(),
+		requests: region.NewRequestTracker(types.DefaultRequestWindow),
+	}
+	if monitor.logger == nil {
+		monitor.logger = types.NopLogger()
+	}
+	if config.SeasonalBaseline {
+		monitor.seasonal = baseline.NewSeasonal(config.SeasonalLocation)
+	}
+	var rules []AlertRule
+	if !config.DisableDefaultAlertRules {
+		rules = alerting.DefaultRules()
+	}
+	// The default rules are always valid
+	monitor.rules, _ = alerting.NewEngine(rules)
+	for _, rule := range config.AlertRules {
+		if err := monitor.rules.Add(rule); err != nil {
+			monitor.configErr = cmp.Or(monitor.configErr, err)
+		}
+	}
+	monitor.conditions = alerting.NewConditions()
+	monitor.health = reporting.NewHealthHistory(config.MaxSamples)
+	monitor.cooldown = alerting.NewCooldown(config.AlertCooldown, config.AlertCooldowns)
+	if config.MaxAlerts > 0 {
+		monitor.history = alerting.NewHistory(config.MaxAlerts)
+	}
+	if _, err := types.HealthCheckProfile(config.HealthProfile); err != nil {
+		monitor.configErr = cmp.Or(monitor.configErr, err)
+	}
+	if err := types.ValidateLabels(config.Labels); err != nil {
+		monitor.configErr = cmp.Or(monitor.configErr, err)
+	}
+	if config.IntervalJitter < 0 || config.IntervalJitter >= 1 {
+		monitor.configErr = cmp.Or(monitor.configErr, fmt.Errorf("%w: jitter %v outside [0, 1)", ErrInvalidInterval, config.IntervalJitter))
+	}
+	if config.AdaptiveInterval != nil {
+		if err := config.AdaptiveInterval.Validate(); err != nil {
+			monitor.configErr = cmp.Or(monitor.configErr, err)
+		}
+	}
+	if config.Retention != nil {
+		if err := config.Retention.Validate(); err != nil {
+			monitor.configErr = cmp.Or(monitor.configErr, err)
+		}
+	}
+	if config.PauseSLO != nil {
+		var err error
+		if monitor.slo, err = alerting.NewSLOTracker(*config.PauseSLO); err != nil {
+			monitor.configErr = cmp.Or(monitor.configErr, err)
+		}
+	}
+	if config.AlertWebhook != nil {
+		c := *config.AlertWebhook
+		c.OnError = monitor.deliveryFailed("webhook", c.OnError)
+		monitor.addSink(webhook.New(c))
+	}
+	if config.PagerDuty != nil {
+		c := *config.PagerDuty
+		c.OnError = monitor.deliveryFailed("pagerduty", c.OnError)
+		monitor.addSink(webhook.NewPagerDuty(c))
+	}
+	if config.AlertEmail != nil {
+		c := *config.AlertEmail
+		c.OnError = monitor.deliveryFailed("email", c.OnError)
+		monitor.addSink(webhook.NewEmail(c, monitor.emailStatus))
+	}
+	if config.ReportSchedule != nil {
+		if err := config.ReportSchedule.Validate(); err != nil {
+			monitor.configErr = cmp.Or(monitor.configErr, err)
+		}
+	}
+	if config.Sampler == nil {
+		monitor.profileStart = types.ReadMemProfile()
 	}
 
 	// Create collector with alert-enabled callbacks
 	collectorConfig := &collector.Config{
-		Interval:   config.Interval,
-		MaxSamples: config.MaxSamples,
+		Interval:         config.Interval,
+		AdaptiveInterval: config.AdaptiveInterval,
+		IntervalJitter:   config.IntervalJitter,
+		MaxSamples:       config.MaxSamples,
+		Retention:        config.Retention,
+		Labels:           config.Labels,
+		PooledMetrics:    config.PooledMetrics,
+		ProcessCPU:       config.ProcessCPU,
+		ProcessRSS:       config.ProcessRSS,
+		Paging:           config.Paging,
+		Sampler:          config.Sampler,
+		PauseQuantiles:   config.PauseQuantiles,
+		NotifyGC:         config.NotifyGC,
+		Logger:           monitor.logger,
 		OnMetricCollected: func(m *types.GCMetrics) {
 			if config.OnMetric != nil {
-				config.OnMetric(m)
+				monitor.callback("OnMetric", func() { config.OnMetric(m) })
 			}
 			monitor.checkAlerts(m, nil)
+			monitor.publishSnapshot(m)
 		},
 		OnGCEvent: func(e *types.GCEvent) {
 			if config.OnGCEvent != nil {
-				config.OnGCEvent(e)
+				monitor.callback("OnGCEvent", func() { config.OnGCEvent(e) })
 			}
 			monitor.checkAlerts(nil, e)
 		},
@@ -198,12 +1034,115 @@ func NewMonitor(config *MonitorConfig) *Monitor {
 
 // Start begins continuous monitoring
 func (m *Monitor) Start(ctx context.Context) error {
-	return m.collector.Start(ctx)
+	if m.configErr != nil {
+		return m.configErr
+	}
+
+	m.lifecycle.Lock()
+	defer m.lifecycle.Unlock()
+
+	if err := m.collector.Start(ctx); err != nil {
+		return err
+	}
+
+	if len(m.sinks) > 0 || m.config.ReportSchedule != nil {
+		sinkCtx, cancel := context.WithCancel(ctx)
+		m.stopSinks = cancel
+		for _, sink := range m.sinks {
+			go sink.Run(sinkCtx)
+		}
+		if m.config.ReportSchedule != nil {
+			go m.runReports(sinkCtx, m.config.ReportSchedule)
+		}
+	}
+	return nil
+}
+
+// runReports emits a scheduled report every interval until ctx is done
+func (m *Monitor) runReports(ctx context.Context, schedule *ReportSchedule) {
+	ticker := time.NewTicker(schedule.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			switch err := m.EmitReport(ctx); {
+			case errors.Is(err, ErrInsufficientData):
+				m.logger.Debug("scheduled report skipped", "error", err)
+			case err != nil:
+				m.logger.Warn("scheduled report failed", "error", err)
+			}
+		}
+	}
+}
+
+// EmitReport generates a report of the current analysis now and delivers it
+// to the ReportSchedule's destinations, as the schedule does every interval.
+// Returns ErrInvalidReportSchedule unless ReportSchedule is set, and
+// ErrInsufficientData until enough samples have been collected.
+func (m *Monitor) EmitReport(ctx context.Context) error {
+	schedule := m.config.ReportSchedule
+	if schedule == nil {
+		return fmt.Errorf("%w: no schedule configured", ErrInvalidReportSchedule)
+	}
+	analysis, err := m.GetCurrentAnalysis()
+	if err != nil {
+		return err
+	}
+
+	opts := ReportOptions{
+		HealthCheck:   m.config.HealthCheck,
+		HealthProfile: m.config.HealthProfile,
+	}
+	if schedule.Options != nil {
+		opts = *schedule.Options
+		if opts.HealthCheck == nil && opts.HealthProfile == "" {
+			opts.HealthCheck, opts.HealthProfile = m.config.HealthCheck, m.config.HealthProfile
+		}
+	}
+	reporter := reporting.NewWithOptions(analysis, m.GetMetrics(), m.GetEvents(), &opts)
+	return schedule.Emit(ctx, reporter)
 }
 
-// Stop ends continuous monitoring
+// Stop ends continuous monitoring. The monitor can be started again,
+// keeping its history.
 func (m *Monitor) Stop() {
-	m.collector.Stop()
+	_ = m.StopE()
+}
+
+// StopE is Stop, but returns ErrCollectorNotRunning if the monitor was not
+// running, because it was never started, was already stopped, or its
+// context was canceled
+func (m *Monitor) StopE() error {
+	m.lifecycle.Lock()
+	defer m.lifecycle.Unlock()
+
+	err := m.collector.StopE()
+	if m.stopSinks != nil {
+		m.stopSinks()
+		m.stopSinks = nil
+	}
+	return err
+}
+
+// Pause stops taking samples, keeping the collected history, analyses and
+// alert state, until Resume is called, e.g. to exclude a noisy startup or
+// bulk load phase. GC cycles completed while paused are not recorded as
+// events, so they raise no pause alerts. Rates over a window spanning the
+// pause still include its allocations and cycles.
+func (m *Monitor) Pause() {
+	m.collector.Pause()
+}
+
+// Resume resumes taking samples after Pause
+func (m *Monitor) Resume() {
+	m.collector.Resume()
+}
+
+// IsPaused returns whether sampling is paused
+func (m *Monitor) IsPaused() bool {
+	return m.collector.IsPaused()
 }
 
 // IsRunning returns whether the monitor is currently running
@@ -211,6 +1150,12 @@ func (m *Monitor) IsRunning() bool {
 	return m.collector.IsRunning()
 }
 
+// CollectionInterval returns the collection interval in use, which varies
+// with GC activity under an AdaptiveInterval
+func (m *Monitor) CollectionInterval() time.Duration {
+	return m.collector.Interval()
+}
+
 // GetMetrics returns all collected metrics
 func (m *Monitor) GetMetrics() []*GCMetrics {
 	return m.collector.GetMetrics()
@@ -226,71 +1171,667 @@ func (m *Monitor) GetLatestMetrics() *GCMetrics {
 	return m.collector.GetLatestMetrics()
 }
 
-// GetCurrentAnalysis performs analysis on currently collected data
-func (m *Monitor) GetCurrentAnalysis() (*GCAnalysis, error) {
-	metrics := m.collector.GetMetrics()
-	events := m.collector.GetEvents()
+// GetCurrentAnalysis performs analysis on currently collected data. The
+// result is cached until the next sample arrives, so repeated calls are cheap;
+// it is shared between callers and must not be modified. GC events recorded
+// since the last sample are picked up with the next one.
+func (m *Monitor) GetCurrentAnalysis() (result *GCAnalysis, err error) {
+	m.analysisMu.Lock()
+	defer m.analysisMu.Unlock()
+
+	limit := m.memoryLimit()
+	m.collector.ViewMetrics(func(metrics []*GCMetrics) {
+		var key analysisKey
+		if n := len(metrics); n > 0 {
+			key = analysisKey{last: metrics[n-1].Timestamp, count: n}
+		}
+		if m.analysis != nil && key == m.analysisKey {
+			result = m.analysis
+			return
+		}
+		result, err = m.analyze(metrics, m.collector.GetEvents(), limit)
+		if err == nil {
+			m.analysis, m.analysisKey = result, key
+		}
+	})
+	return result, err
+}
 
+// analyze analyzes the given samples and events, forecasting OOM against
+// limit when it is set
+func (m *Monitor) analyze(metrics []*GCMetrics, events []*GCEvent, limit uint64) (*GCAnalysis, error) {
 	if len(metrics) < 2 {
-		return nil, ErrInsufficientData
+		return nil, &InsufficientDataError{Op: "analyze", Samples: len(metrics), Required: 2}
 	}
 
-	analyzer := analysis.NewWithEvents(metrics, events)
-	return analyzer.Analyze()
+	opts := &analysis.Options{
+		Runtime:       m.collector.RuntimeInfo(),
+		Regions:       m.regions.Stats(),
+		RequestImpact: m.requests.Impact(),
+		Labels:        m.collector.Labels(),
+		ApdexTarget:   m.config.PauseApdexTarget,
+		Interval:      m.collector.MaxInterval(),
+	}
+	if m.profileStart != nil {
+		// The local allocation profile only describes this process
+		profile := types.ReadMemProfile()
+		opts.SizeClasses = types.BucketMemProfile(profile.Records, profile.Rate)
+		opts.AllocSites = types.AttributeAllocSites(m.profileStart, profile, types.DefaultTopAllocSites)
+	}
+	analyzer := analysis.NewWithOptions(metrics, events, opts)
+	result, err := analyzer.Analyze()
+	if err != nil {
+		return nil, err
+	}
+
+	if limit > 0 {
+		if forecast, err := analyzer.ForecastOOM(limit); err == nil {
+			result.OOMForecast = forecast
+		}
+	}
+
+	return result, nil
+}
+
+// Snapshot returns the latest published state: the most recent sample with the
+// analysis and health check computed from it. It never blocks or copies, so it
+// suits handlers serving many requests; the result is shared and must not be
+// modified. Returns nil until the first sample is collected.
+func (m *Monitor) Snapshot() *MonitorSnapshot {
+	return m.snapshot.Load()
+}
+
+// publishSnapshot publishes a new snapshot for the given sample, reusing the
+// previous analysis while it is younger than SnapshotInterval
+func (m *Monitor) publishSnapshot(metric *GCMetrics) {
+	m.snapshotMu.Lock()
+	defer m.snapshotMu.Unlock()
+
+	now := time.Now()
+	next := &MonitorSnapshot{Metrics: metric, Timestamp: now}
+	if prev := m.snapshot.Load(); prev != nil && prev.Analysis != nil &&
+		now.Sub(prev.AnalyzedAt) < m.config.SnapshotInterval {
+		next.Analysis, next.Health, next.AnalyzedAt = prev.Analysis, prev.Health, prev.AnalyzedAt
+	} else {
+		next.Analysis, _ = m.GetCurrentAnalysis()
+		next.Health = reporting.NewWithOptions(next.Analysis, nil, nil, &ReportOptions{
+			HealthCheck:   m.config.HealthCheck,
+			HealthProfile: m.config.HealthProfile,
+		}).GenerateHealthCheck()
+		m.health.Record(next.Health)
+		m.logHealthTransition(next.Health)
+		next.AnalyzedAt = now
+	}
+	m.snapshot.Store(next)
+}
+
+// logHealthTransition logs a change of health status to AlertLogger.
+// Unknown statuses, computed without an analysis, are not transitions.
+func (m *Monitor) logHealthTransition(health *HealthCheckStatus) {
+	if health.Status == "unknown" || health.Status == m.healthStatus {
+		return
+	}
+	previous := cmp.Or(m.healthStatus, "unknown")
+	m.healthStatus = health.Status
+	if m.config.AlertLogger != nil {
+		alerting.LogHealthTransition(m.config.AlertLogger, previous, health, m.collector.Labels())
+	}
+}
+
+// GetHealthHistory returns the health scores of the snapshots' health
+// checks, oldest first, up to MaxSamples of them. Each check's Trend is
+// derived from the scores before it.
+func (m *Monitor) GetHealthHistory() []HealthPoint {
+	return m.health.Points()
+}
+
+// BeginRegion opens a span attributing allocations to the named code region
+// until End is called. Regions are reported in GetCurrentAnalysis.
+//
+//	defer monitor.BeginRegion("checkout").End()
+func (m *Monitor) BeginRegion(name string) *RegionSpan {
+	return m.regions.Begin(name)
+}
+
+// regionContextKey is the context key for the active region label
+type regionContextKey struct{}
+
+// WithRegion labels the samples and events the monitor records with name
+// (e.g. "startup", "steady-state", "batch") until the returned context is
+// canceled, so the analysis can be broken down by application phase.
+// Labels nest: when the inner region ends the outer label applies again.
+// Labels are process-wide, not per goroutine.
+func (m *Monitor) WithRegion(ctx context.Context, name string) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	pop := m.collector.PushRegion(name)
+	// Ends the region when the parent is canceled; calling the returned
+	// cancel ends it synchronously
+	context.AfterFunc(ctx, pop)
+	return context.WithValue(ctx, regionContextKey{}, name), func() {
+		cancel()
+		pop()
+	}
+}
+
+// RegionFromContext returns the region label set by Monitor.WithRegion,
+// or "" when ctx carries none
+func RegionFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(regionContextKey{}).(string)
+	return name
+}
+
+// RegionStats returns the allocations attributed to each region so far
+func (m *Monitor) RegionStats() []RegionStats {
+	return m.regions.Stats()
+}
+
+// RecordRequest records the GC impact of one request, measured as the delta
+// of a GCSnapshot taken when it started. The distributions over the last
+// DefaultRequestWindow requests are reported in GetCurrentAnalysis.
+//
+//	snap := gcanalyzer.Snapshot()
+//	handle(req)
+//	monitor.RecordRequest(snap.Delta())
+func (m *Monitor) RecordRequest(d *GCDelta) {
+	m.requests.Record(d.PauseTime, d.AllocBytes)
+}
+
+// TrackCall starts measuring one call to method, typically an RPC, and
+// returns the function that ends it. The call is recorded like RecordRequest
+// and also attributed to method in RegionStats, so GC pauses and allocation
+// can be compared across methods. In a gRPC unary interceptor:
+//
+//	defer monitor.TrackCall(info.FullMethod)()
+//	return handler(ctx, req)
+func (m *Monitor) TrackCall(method string) (end func()) {
+	snap := Snapshot()
+	return func() {
+		d := snap.Delta()
+		m.requests.Record(d.PauseTime, d.AllocBytes)
+		m.regions.Record(method, d)
+	}
+}
+
+// RequestImpact returns the distributions of per-request GC impact, or nil
+// before the first request is recorded
+func (m *Monitor) RequestImpact() *RequestImpact {
+	return m.requests.Impact()
+}
+
+// ForecastOOM projects when memory usage will reach the configured memory limit.
+// Returns ErrInvalidMemoryLimit when no MemoryLimit, container limit or GOMEMLIMIT is set.
+func (m *Monitor) ForecastOOM() (*OOMForecast, error) {
+	limit := m.memoryLimit()
+	var forecast *OOMForecast
+	var err error
+	m.collector.ViewMetrics(func(metrics []*GCMetrics) {
+		forecast, err = analysis.New(metrics).ForecastOOM(limit)
+	})
+	return forecast, err
+}
+
+// InjectChaos feeds steps synthetic samples of the given scenario through the
+// monitor as if they had been collected: OnMetric/OnGCEvent callbacks run and
+// alerts fire exactly as they would for real data, which makes it possible to
+// verify alert rules, notifiers and dashboards end-to-end.
+// Injected samples are marked Synthetic and continue from the latest collected
+// sample. They are stored like any other sample, so use a dedicated monitor if
+// later analysis should not include them.
+// Returns ErrUnknownScenario for unsupported scenarios.
+func (m *Monitor) InjectChaos(scenario ChaosScenario, steps int) error {
+	gen, err := chaos.NewGenerator(scenario, m.collector.GetLatestMetrics(), m.config.Interval)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < steps; i++ {
+		step := gen.Next()
+		for _, event := range step.Events {
+			m.collector.InjectEvent(event)
+		}
+		m.collector.Inject(step.Metrics)
+	}
+	return nil
+}
+
+// memoryLimit returns the configured memory limit, falling back to the
+// container limit and then GOMEMLIMIT. The container limit comes first since
+// it is the hard limit the process is killed at.
+func (m *Monitor) memoryLimit() uint64 {
+	if m.config.MemoryLimit > 0 {
+		return m.config.MemoryLimit
+	}
+	if latest := m.collector.GetLatestMetrics(); latest != nil && latest.CgroupMemoryLimit > 0 {
+		return latest.CgroupMemoryLimit
+	}
+	return types.CurrentMemoryLimit()
+}
+
+// AddAlertRule registers a rule evaluated against every subsequent sample or
+// GC event. Returns ErrInvalidAlertRule if the rule is malformed.
+func (m *Monitor) AddAlertRule(rule AlertRule) error {
+	return m.rules.Add(rule)
+}
+
+// AlertRules returns the monitor's alert rules
+func (m *Monitor) AlertRules() []AlertRule {
+	return m.rules.Rules()
 }
 
 // checkAlerts checks for alert conditions
 func (m *Monitor) checkAlerts(metric *GCMetrics, event *GCEvent) {
-	if m.config.OnAlert == nil {
+	// The seasonal baseline learns from every sample, even without an alert callback
+	var learned []string
+	if metric != nil {
+		learned = m.checkSeasonal(metric)
+	}
+
+	// So does the pause SLO, for PauseSLOStatus
+	var sloAlerts []*Alert
+	if m.slo != nil {
+		if event != nil {
+			m.slo.ObserveEvent(event)
+		}
+		if metric != nil {
+			sloAlerts = m.slo.ObserveSample(metric)
+		}
+	}
+
+	if !m.alertsEnabled() {
 		return
 	}
 
-	// Check metric-based alerts
+	alerts := sloAlerts
 	if metric != nil {
-		// High GC CPU fraction alert
-		if metric.GCCPUFraction > AlertGCCPUFractionThreshold {
-			alert := &Alert{
-				Type:      "overhead",
-				Severity:  "warning",
-				Message:   "High GC CPU overhead detected",
-				Value:     metric.GCCPUFraction * 100,
-				Threshold: AlertGCCPUFractionThreshold * 100,
-				Metric:    metric,
-				Timestamp: time.Now(),
-			}
-			m.config.OnAlert(alert)
+		// A learned seasonal baseline replaces the static thresholds on its series
+		skip := make([]string, 0, len(learned))
+		for _, series := range learned {
+			skip = append(skip, seasonalRuleMetrics[series])
 		}
+		alerts = append(alerts, m.rules.ObserveSample(metric, skip...)...)
 
-		// Rapid heap growth alert
-		// This would require historical data comparison
-		// For simplicity, we'll skip this in the basic implementation
+		// Projected memory exhaustion alert
+		m.checkOOMForecast(metric)
 	}
-
-	// Check event-based alerts
 	if event != nil {
-		// Long pause time alert
-		if event.Duration > AlertWarningPauseThreshold {
+		alerts = append(alerts, m.rules.ObserveEvent(event)...)
+	}
+
+	for _, alert := range alerts {
+		m.raise(alert)
+	}
+
+	// Alerts the cooldown held back fire once it ends if they still hold
+	for _, alert := range m.cooldown.Expired(time.Now()) {
+		m.deliver(alert)
+	}
+}
+
+// addSink registers an alert sink, keeping the first configuration error
+func (m *Monitor) addSink(sink *webhook.Sink, err error) {
+	if err != nil {
+		m.configErr = cmp.Or(m.configErr, err)
+		return
+	}
+	m.sinks = append(m.sinks, sink)
+}
+
+// callback runs the named user callback, logging a panic instead of letting
+// it stop monitoring
+func (m *Monitor) callback(name string, fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.logger.Error("callback panicked", "callback", name, "panic", r)
+		}
+	}()
+	fn()
+}
+
+// deliveryFailed returns an OnError callback for the named alert sink that
+// logs the failure before calling onError, when set
+func (m *Monitor) deliveryFailed(sink string, onError func(*Alert, error)) func(*Alert, error) {
+	return func(alert *Alert, err error) {
+		m.logger.Warn("alert delivery failed", "sink", sink, "alert", alert.Type, "error", err)
+		if onError != nil {
+			onError(alert, err)
+		}
+	}
+}
+
+// emailStatus returns the health check and summary report of the latest
+// snapshot for alert emails
+func (m *Monitor) emailStatus() (*HealthCheckStatus, string) {
+	snapshot := m.Snapshot()
+	if snapshot == nil || snapshot.Analysis == nil {
+		return nil, ""
+	}
+	var report strings.Builder
+	if err := GenerateSummaryReport(snapshot.Analysis, &report); err != nil {
+		return snapshot.Health, ""
+	}
+	return snapshot.Health, report.String()
+}
+
+// GetAlerts returns the alerts raised at or after since, oldest first, with
+// their resolution state. Alerts suppressed by a cooldown are included,
+// marked Suppressed.
+// Returns nil when MaxAlerts is negative.
+func (m *Monitor) GetAlerts(since time.Time) []*AlertRecord {
+	if m.history == nil {
+		return nil
+	}
+	return m.history.Since(since)
+}
+
+// PauseSLOStatus returns the pause SLO's compliance and burn rates.
+// Returns ErrSLODisabled unless PauseSLO is set.
+func (m *Monitor) PauseSLOStatus() (*PauseSLOStatus, error) {
+	if m.slo == nil {
+		return nil, ErrSLODisabled
+	}
+	return m.slo.Status(), nil
+}
+
+// alertsEnabled reports whether raised alerts go anywhere
+func (m *Monitor) alertsEnabled() bool {
+	return m.history != nil || m.config.OnAlert != nil || m.config.OnAlertResolved != nil ||
+		m.config.AlertLogger != nil || len(m.sinks) > 0
+}
+
+// raise delivers an alert unless the cooldown suppresses it, in which case
+// it is only recorded in the history, marked Suppressed
+func (m *Monitor) raise(alert *Alert) {
+	if m.config.PooledMetrics && alert.Metric != nil {
+		// Alerts outlive the pooled sample they were raised for
+		alert.Metric = alert.Metric.Clone()
+	}
+	if alert.Labels == nil {
+		alert.Labels = m.collector.Labels()
+	}
+	if !m.cooldown.Allow(alert) {
+		if m.history != nil {
+			suppressed := *alert
+			suppressed.Suppressed = true
+			m.history.Record(&suppressed)
+		}
+		return
+	}
+	m.deliver(alert)
+}
+
+// deliver records an alert in the history and passes it to the OnAlert or
+// OnAlertResolved callback and the sinks
+func (m *Monitor) deliver(alert *Alert) {
+	if m.history != nil {
+		m.history.Record(alert)
+	}
+	switch {
+	case alert.Resolved && m.config.OnAlertResolved != nil:
+		m.callback("OnAlertResolved", func() { m.config.OnAlertResolved(alert) })
+	case !alert.Resolved && m.config.OnAlert != nil:
+		m.callback("OnAlert", func() { m.config.OnAlert(alert) })
+	}
+	if m.config.AlertLogger != nil {
+		alerting.LogAlert(m.config.AlertLogger, alert)
+	}
+	for _, sink := range m.sinks {
+		sink.Enqueue(alert)
+	}
+}
+
+// ExportBaseline writes the learned seasonal baseline as JSON, e.g. to a file
+// before shutdown. Returns ErrBaselineDisabled unless SeasonalBaseline is set.
+func (m *Monitor) ExportBaseline(w io.Writer) error {
+	if m.seasonal == nil {
+		return ErrBaselineDisabled
+	}
+	return baseline.Write(w, m.seasonal.Snapshot())
+}
+
+// ImportBaseline replaces the learned seasonal baseline with one written by
+// ExportBaseline, so alerting resumes warm after a restart or deploy.
+// Returns ErrBaselineDisabled unless SeasonalBaseline is set.
+func (m *Monitor) ImportBaseline(r io.Reader) error {
+	if m.seasonal == nil {
+		return ErrBaselineDisabled
+	}
+	snap, err := baseline.Read(r)
+	if err != nil {
+		return err
+	}
+	return m.seasonal.Restore(snap)
+}
+
+// seasonalRuleMetrics maps each seasonal baseline series to the alert rule
+// metric whose static thresholds it replaces once learned
+var seasonalRuleMetrics = map[string]string{
+	types.SeriesGCFrequency:   alerting.MetricGCFrequency,
+	types.SeriesHeapAlloc:     alerting.MetricHeapGrowthRate,
+	types.SeriesGCCPUFraction: alerting.MetricGCCPUFraction,
+}
+
+// checkSeasonal compares a sample with the seasonal baseline, raising an alert
+// for each series that deviates from it, then adds the sample to the baseline.
+// Synthetic samples are checked but not learned. It returns the series whose
+// baseline was available for the sample, which replace their static thresholds.
+func (m *Monitor) checkSeasonal(metric *GCMetrics) (learned []string) {
+	if m.seasonal == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	prev := m.lastMetric
+	m.lastMetric = metric
+	m.mu.Unlock()
+
+	type observation struct {
+		series    string
+		alertType string
+		message   string
+		value     float64
+	}
+	observations := make([]observation, 0, 3)
+	if prev != nil && metric.NumGC >= prev.NumGC {
+		if dt := metric.Timestamp.Sub(prev.Timestamp).Seconds(); dt > 0 {
+			observations = append(observations, observation{
+				types.SeriesGCFrequency, "frequency", "GC frequency deviates from seasonal baseline",
+				float64(metric.NumGC-prev.NumGC) / dt,
+			})
+		}
+	}
+	observations = append(observations,
+		observation{types.SeriesHeapAlloc, "memory", "Heap size deviates from seasonal baseline", float64(metric.HeapAlloc)},
+		observation{types.SeriesGCCPUFraction, "overhead", "GC CPU overhead deviates from seasonal baseline", metric.GCCPUFraction},
+	)
+
+	for _, o := range observations {
+		dev, ok := m.seasonal.Check(o.series, metric.Timestamp, o.value)
+		if !metric.Synthetic {
+			m.seasonal.Observe(o.series, metric.Timestamp, o.value)
+		}
+		if ok {
+			learned = append(learned, o.series)
+		}
+		if !m.alertsEnabled() {
+			continue
+		}
+
+		var alert *Alert
+		if ok && dev.Anomalous() {
 			severity := "warning"
-			if event.Duration > AlertCriticalPauseThreshold {
+			if math.Abs(dev.ZScore) > 2*types.ThresholdSeasonalZScore {
 				severity = "critical"
 			}
-
-			alert := &Alert{
-				Type:      "pause",
+			alert = &Alert{
+				Type:      o.alertType,
 				Severity:  severity,
-				Message:   "Long GC pause time detected",
-				Value:     float64(event.Duration.Nanoseconds()) / 1e6, // ms
-				Threshold: float64(AlertWarningPauseThreshold.Milliseconds()),
-				Event:     event,
+				Message:   o.message,
+				Value:     dev.Value,
+				Threshold: dev.Expected + math.Copysign(types.ThresholdSeasonalZScore*dev.StdDev, dev.ZScore),
+				Metric:    metric,
 				Timestamp: time.Now(),
 			}
-			m.config.OnAlert(alert)
+		}
+		if a := m.conditions.Update("seasonal:"+o.series, alert); a != nil {
+			if a.Resolved {
+				a.Value, a.Metric = o.value, metric
+			}
+			m.raise(a)
 		}
 	}
+
+	return learned
+}
+
+// checkOOMForecast raises an alert when the memory limit is projected to be
+// reached within ThresholdOOMForecastWarning, and resolves it once it is not
+func (m *Monitor) checkOOMForecast(metric *GCMetrics) {
+	var alert *Alert
+	forecast, err := m.ForecastOOM()
+	if err == nil && forecast.WillExceed &&
+		(forecast.Exceeded || forecast.TimeToLimit <= types.ThresholdOOMForecastWarning) {
+		severity := "warning"
+		if forecast.Exceeded || forecast.TimeToLimit <= types.ThresholdOOMForecastCritical {
+			severity = "critical"
+		}
+		alert = &Alert{
+			Type:      "memory",
+			Severity:  severity,
+			Message:   "Memory limit approaching: " + forecast.Summary(),
+			Value:     forecast.TimeToLimit.Minutes(),
+			Threshold: types.ThresholdOOMForecastWarning.Minutes(),
+			Metric:    metric,
+			Timestamp: time.Now(),
+		}
+	}
+
+	if a := m.conditions.Update("oom_forecast", alert); a != nil {
+		if a.Resolved {
+			a.Metric = metric
+		}
+		m.raise(a)
+	}
 }
 
 // Utility functions for easy access to analysis features
 
+// ForecastOOM projects when memory usage in the given metrics will reach limit
+func ForecastOOM(metrics []*GCMetrics, limit uint64) (*OOMForecast, error) {
+	return analysis.New(metrics).ForecastOOM(limit)
+}
+
+// DetectChangepoints finds samples where allocation rate or average pause per
+// GC shifted significantly, useful for correlating regressions with deploys
+func DetectChangepoints(metrics []*GCMetrics) ([]Changepoint, error) {
+	return analysis.New(metrics).DetectChangepoints()
+}
+
+// NewBundle packages collected metrics and events with the current runtime
+// configuration and build information so the capture can be stored and
+// compared later
+func NewBundle(label string, metrics []*GCMetrics, events []*GCEvent) *Bundle {
+	return bundle.New(label, metrics, events)
+}
+
+// WriteBundle writes a capture bundle as JSON
+func WriteBundle(w io.Writer, b *Bundle) error {
+	return bundle.Write(w, b)
+}
+
+// ReadBundle reads a capture bundle written by WriteBundle
+func ReadBundle(r io.Reader) (*Bundle, error) {
+	return bundle.Read(r)
+}
+
+// ReadBundleContext is ReadBundle for untrusted input: reading stops with
+// ctx's error once ctx is done, and with ErrInputTooLarge past MaxBundleSize.
+// All errors wrap ErrInvalidBundle.
+func ReadBundleContext(ctx context.Context, r io.Reader) (*Bundle, error) {
+	return bundle.ReadContext(ctx, r)
+}
+
+// WriteMetrics writes samples in format, e.g. from Monitor.GetMetrics, for
+// LoadMetrics to read back. An empty format means FormatJSON.
+func WriteMetrics(w io.Writer, metrics []*GCMetrics, format Format) error {
+	return bundle.WriteMetrics(w, metrics, format)
+}
+
+// LoadMetrics reads samples written by WriteMetrics, or those of a capture
+// bundle for FormatJSON, so a capture from production can be re-analyzed
+// locally with different options. An empty format means FormatJSON.
+func LoadMetrics(r io.Reader, format Format) ([]*GCMetrics, error) {
+	return bundle.ReadMetrics(context.Background(), r, format)
+}
+
+// LoadMetricsContext is LoadMetrics for untrusted input: reading stops with
+// ctx's error once ctx is done, and with ErrInputTooLarge past MaxBundleSize.
+// Errors other than ErrUnknownFormat wrap ErrInvalidMetrics.
+func LoadMetricsContext(ctx context.Context, r io.Reader, format Format) ([]*GCMetrics, error) {
+	return bundle.ReadMetrics(ctx, r, format)
+}
+
+// SaveBinary writes samples and events in a compact binary encoding, several
+// times smaller and faster to load than JSON for long series with pause data
+func SaveBinary(w io.Writer, metrics []*GCMetrics, events []*GCEvent) error {
+	return bundle.WriteBinary(w, metrics, events)
+}
+
+// LoadBinary reads samples and events written by SaveBinary
+func LoadBinary(r io.Reader) ([]*GCMetrics, []*GCEvent, error) {
+	return bundle.ReadBinary(context.Background(), r)
+}
+
+// LoadBinaryContext is LoadBinary for untrusted input: reading stops with
+// ctx's error once ctx is done, and with ErrInputTooLarge past MaxBundleSize.
+// All errors wrap ErrInvalidMetrics.
+func LoadBinaryContext(ctx context.Context, r io.Reader) ([]*GCMetrics, []*GCEvent, error) {
+	return bundle.ReadBinary(ctx, r)
+}
+
+// CompareUpgrade compares two bundles captured under different Go versions
+func CompareUpgrade(before, after *Bundle) (*UpgradeComparison, error) {
+	return analysis.CompareUpgrade(before, after)
+}
+
+// CompareBuilds compares two bundles captured from different builds, such as
+// before and after a dependency upgrade, under the same or different Go
+// versions; the comparison lists the changed module versions
+func CompareBuilds(before, after *Bundle) (*UpgradeComparison, error) {
+	return analysis.CompareBuilds(before, after)
+}
+
+// DefaultRegressionPolicy returns the regression tolerances CheckRegression
+// uses in CI unless adjusted: 20% growth in pause percentiles (of at least
+// 1ms), GC frequency and allocation rate
+func DefaultRegressionPolicy() RegressionPolicy {
+	return types.DefaultRegressionPolicy()
+}
+
+// CheckRegression compares current against baseline, e.g. a run of the same
+// benchmark on the main branch, and returns a *RegressionError matching
+// ErrRegression that lists every metric grown beyond policy's tolerances
+func CheckRegression(baseline, current *GCAnalysis, policy RegressionPolicy) error {
+	return analysis.CheckRegression(baseline, current, policy)
+}
+
+// GenerateUpgradeReport generates a Go runtime upgrade or build comparison
+// report
+func GenerateUpgradeReport(comparison *UpgradeComparison, w io.Writer) error {
+	return reporting.GenerateUpgradeReport(w, comparison, nil)
+}
+
+// GenerateComparisonReport writes two analyses side by side, e.g. before and
+// after a tuning change, with each metric's change marked ▲ or ▼
+func GenerateComparisonReport(w io.Writer, before, after *GCAnalysis) error {
+	return reporting.GenerateComparisonReport(w, before, after, nil)
+}
+
+// GenerateMarkdownComparisonReport is GenerateComparisonReport as a Markdown
+// table, for pull requests and issues
+func GenerateMarkdownComparisonReport(w io.Writer, before, after *GCAnalysis) error {
+	return reporting.GenerateMarkdownComparisonReport(w, before, after, nil)
+}
+
 // GetMemoryTrend returns memory trend analysis for the given metrics
 func GetMemoryTrend(metrics []*GCMetrics) []MemoryPoint {
 	analyzer := analysis.New(metrics)
@@ -302,3 +1843,14 @@ func GetPauseTimeDistribution(events []*GCEvent) map[string]int {
 	analyzer := analysis.NewWithEvents(nil, events)
 	return analyzer.GetPauseTimeDistribution()
 }
+
+// NopLogger returns a Logger that discards everything, the default for
+// MonitorConfig.Logger
+func NopLogger() Logger {
+	return types.NopLogger()
+}
+
+// ZapLogger adapts a *zap.SugaredLogger, e.g. zap.L().Sugar(), to Logger
+func ZapLogger(s SugaredLogger) Logger {
+	return types.ZapLogger(s)
+}
@@ -0,0 +1,43 @@
+package gcanalyzer
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/internal/reporting"
+)
+
+// MetricsHandler serves the latest analysis in the Prometheus text format.
+// It reads the published snapshot, so scrapes never block collection.
+// Samples carry no timestamps, so the scraper records the scrape time.
+// Responds 503 until enough samples have been collected to analyze.
+func (m *Monitor) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		s := m.Snapshot()
+		if s == nil || s.Analysis == nil {
+			http.Error(w, ErrInsufficientData.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		opts := reporting.MetricsOptions{OmitTimestamps: true}
+		_ = reporting.New(s.Analysis, nil, nil).GenerateGrafanaMetricsWithOptions(w, opts)
+	})
+}
+
+// HealthHandler serves the latest health check as JSON. It responds 503 when
+// the status is critical, so it can back liveness or readiness probes, and
+// 200 otherwise, including while the status is still unknown at startup.
+func (m *Monitor) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		health := GenerateHealthCheck(nil)
+		if s := m.Snapshot(); s != nil && s.Health != nil {
+			health = s.Health
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if health.Status == "critical" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(health)
+	})
+}
@@ -0,0 +1,88 @@
+// Package gctest reports GC behavior from benchmarks as custom metrics, so GC
+// regressions show up next to ns/op in benchstat comparisons, and asserts on
+// the GC behavior of code under test.
+//
+//	func BenchmarkParse(b *testing.B) {
+//		input := loadInput()
+//		gctest.ReportGCMetrics(b)
+//		for i := 0; i < b.N; i++ {
+//			parse(input)
+//		}
+//	}
+//
+// Assertions run a function and fail the test when its GC impact exceeds a
+// limit. The runtime's counters are process-wide, so avoid running them in
+// parallel with other tests.
+//
+//	gctest.AssertMaxPause(t, func() { cache.Rebuild() }, 10*time.Millisecond)
+package gctest
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// Custom benchmark metric units reported by ReportGCMetrics
+const (
+	UnitGCCount    = "gc-count/op"
+	UnitPauseNs    = "pause-ns/op"
+	UnitAllocBytes = "bytes-allocated/op"
+)
+
+// ReportGCMetrics snapshots the GC counters and, when the benchmark function
+// returns, reports the GC cycles, stop-the-world pause time and bytes
+// allocated per iteration. Call it after any setup that should not be
+// measured; it does not follow b.ResetTimer.
+func ReportGCMetrics(b *testing.B) {
+	b.Helper()
+	snap := gcanalyzer.Snapshot()
+	b.Cleanup(func() {
+		delta := snap.Delta()
+		n := float64(b.N)
+		b.ReportMetric(float64(delta.GCCount)/n, UnitGCCount)
+		b.ReportMetric(float64(delta.PauseTime.Nanoseconds())/n, UnitPauseNs)
+		b.ReportMetric(float64(delta.AllocBytes)/n, UnitAllocBytes)
+	})
+}
+
+// AssertNoRegression fails the test, listing each regressed metric, when
+// current regressed from baseline beyond policy's tolerances
+func AssertNoRegression(t testing.TB, baseline, current *gcanalyzer.GCAnalysis, policy gcanalyzer.RegressionPolicy) {
+	t.Helper()
+	if err := gcanalyzer.CheckRegression(baseline, current, policy); err != nil {
+		t.Error(err)
+	}
+}
+
+// AssertMaxPause runs fn and fails the test when a GC cycle during it paused
+// the world for longer than limit
+func AssertMaxPause(t testing.TB, fn func(), limit time.Duration) {
+	t.Helper()
+	snap := gcanalyzer.Snapshot()
+	fn()
+	delta := snap.Delta()
+	if delta.MaxPause > limit {
+		t.Errorf("GC paused for %v during %d cycles, limit %v", delta.MaxPause, delta.GCCount, limit)
+	}
+}
+
+// AssertNoHeapGrowth runs fn and fails the test when the live heap afterwards
+// is larger than before by more than tolerance, a fraction of the live heap
+// before, e.g. 0.1 for 10%. The heap is collected before and after fn, so
+// only memory fn left reachable counts.
+func AssertNoHeapGrowth(t testing.TB, fn func(), tolerance float64) {
+	t.Helper()
+	runtime.GC()
+	snap := gcanalyzer.Snapshot()
+	fn()
+	runtime.GC()
+	delta := snap.Delta()
+	if float64(delta.HeapGrowth) > float64(snap.HeapAlloc)*tolerance {
+		t.Errorf("live heap grew by %s from %s, tolerance %.1f%%",
+			types.FormatBytes(uint64(delta.HeapGrowth)), types.FormatBytes(snap.HeapAlloc), tolerance*100)
+	}
+}
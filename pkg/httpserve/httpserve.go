@@ -0,0 +1,141 @@
+// Package httpserve provides Kubernetes liveness and readiness probe handlers
+// driven by a Monitor's GC health, so degraded GC health can take a pod out
+// of rotation, and a restart can recover a pod whose GC has collapsed.
+//
+//	http.Handle("/livez", httpserve.LivenessHandler(monitor))
+//	http.Handle("/readyz", httpserve.ReadinessHandler(monitor, nil))
+//
+// Both handlers read the monitor's published snapshot, so probes never block
+// collection. They respond 200 or 503 with a ProbeStatus JSON body.
+//
+// Middleware records the GC impact of each request on a Monitor:
+//
+//	http.ListenAndServe(":8080", httpserve.Middleware(monitor, mux))
+package httpserve
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// LivenessMinScore is the health score below which a critical pod fails its
+// liveness probe: nearly every check failing, which a restart may clear
+const LivenessMinScore = 20
+
+// ReadinessConfig holds the rules a pod must pass to be ready. The zero value
+// is ready unless health is critical.
+type ReadinessConfig struct {
+	// MinScore is the lowest ready health score (default: types.HealthScoreWarning)
+	MinScore int
+
+	// MaxP99Pause, when set, is not ready while the P99 pause exceeds it
+	MaxP99Pause time.Duration
+
+	// MaxGCOverhead, when set, is not ready while the percentage of CPU time
+	// spent in GC exceeds it
+	MaxGCOverhead float64
+
+	// AlertTypes are not ready while an alert of one of these types, e.g.
+	// "pause" or "slo", is active in the monitor's alert history
+	AlertTypes []string
+
+	// RequireAnalysis is not ready until enough samples have been collected
+	// to analyze; otherwise the pod is ready while health is unknown
+	RequireAnalysis bool
+}
+
+// ProbeStatus is the JSON body of a probe response
+type ProbeStatus struct {
+	OK      bool                     `json:"ok"`
+	Reasons []string                 `json:"reasons,omitempty"` // why the probe fails
+	Health  *types.HealthCheckStatus `json:"health"`
+}
+
+// LivenessHandler responds 503 when GC health is critical with a score below
+// LivenessMinScore or the memory limit has been reached, and 200 otherwise,
+// including while health is still unknown at startup
+func LivenessHandler(monitor *gcanalyzer.Monitor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		status := probe(monitor)
+		if h := status.Health; h.Status == "critical" && h.Score < LivenessMinScore {
+			status.Reasons = append(status.Reasons, fmt.Sprintf("health score %d below %d", h.Score, LivenessMinScore))
+		}
+		if s := monitor.Snapshot(); s != nil && s.Analysis != nil {
+			if f := s.Analysis.OOMForecast; f != nil && f.Exceeded {
+				status.Reasons = append(status.Reasons, f.Summary())
+			}
+		}
+		write(w, status)
+	})
+}
+
+// ReadinessHandler responds 503 while any rule of config fails, and 200
+// otherwise. A nil config is the zero value.
+func ReadinessHandler(monitor *gcanalyzer.Monitor, config *ReadinessConfig) http.Handler {
+	cfg := ReadinessConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.MinScore == 0 {
+		cfg.MinScore = types.HealthScoreWarning
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		status := probe(monitor)
+
+		var analysis *types.GCAnalysis
+		if s := monitor.Snapshot(); s != nil {
+			analysis = s.Analysis
+		}
+		switch {
+		case analysis == nil:
+			if cfg.RequireAnalysis {
+				status.Reasons = append(status.Reasons, "insufficient data for analysis")
+			}
+		default:
+			if status.Health.Score < cfg.MinScore {
+				status.Reasons = append(status.Reasons, fmt.Sprintf("health score %d below %d", status.Health.Score, cfg.MinScore))
+			}
+			if cfg.MaxP99Pause > 0 && analysis.P99PauseTime > cfg.MaxP99Pause {
+				status.Reasons = append(status.Reasons, fmt.Sprintf("P99 pause %v above %v", analysis.P99PauseTime, cfg.MaxP99Pause))
+			}
+			if cfg.MaxGCOverhead > 0 && analysis.GCOverhead > cfg.MaxGCOverhead {
+				status.Reasons = append(status.Reasons, fmt.Sprintf("GC overhead %.1f%% above %.1f%%", analysis.GCOverhead, cfg.MaxGCOverhead))
+			}
+		}
+
+		if len(cfg.AlertTypes) > 0 {
+			for _, record := range monitor.GetAlerts(time.Time{}) {
+				if record.Active() && slices.Contains(cfg.AlertTypes, record.Alert.Type) {
+					status.Reasons = append(status.Reasons, "active alert: "+record.Alert.Message)
+				}
+			}
+		}
+		write(w, status)
+	})
+}
+
+// probe returns a passing status with the latest health check
+func probe(monitor *gcanalyzer.Monitor) *ProbeStatus {
+	health := gcanalyzer.GenerateHealthCheck(nil)
+	if s := monitor.Snapshot(); s != nil && s.Health != nil {
+		health = s.Health
+	}
+	return &ProbeStatus{Health: health}
+}
+
+// write responds with status, failing it if there are reasons
+func write(w http.ResponseWriter, status *ProbeStatus) {
+	status.OK = len(status.Reasons) == 0
+	w.Header().Set("Content-Type", "application/json")
+	if !status.OK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(status)
+}
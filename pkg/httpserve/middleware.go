@@ -0,0 +1,24 @@
+package httpserve
+
+import (
+	"net/http"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+)
+
+// Middleware records the GC pause time overlapping each request and the
+// bytes allocated while it ran on monitor, see Monitor.RecordRequest. The
+// distributions appear in the monitor's analyses and reports.
+//
+// The counters are process-wide, so with concurrent requests each one is
+// also charged with allocations made by the others meanwhile; the
+// distributions describe the GC cost a request experiences rather than the
+// cost it causes. Reading the counters briefly stops the world twice per
+// request, which suits moderate request rates or sampled routes.
+func Middleware(monitor *gcanalyzer.Monitor, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		snap := gcanalyzer.Snapshot()
+		defer func() { monitor.RecordRequest(snap.Delta()) }()
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,186 @@
+// Package tui renders a live GC dashboard in the terminal from a Monitor, for
+// quick interactive debugging over SSH: a heap sparkline, GC frequency, pause
+// percentiles and the latest recommendations.
+//
+//	err := tui.Run(ctx, os.Stdout, monitor, nil)
+//
+// The dashboard is drawn with plain ANSI escape sequences and needs no
+// terminal library.
+package tui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// ANSI escape sequences
+const (
+	clearScreen = "\x1b[H\x1b[2J"
+	hideCursor  = "\x1b[?25l"
+	showCursor  = "\x1b[?25h"
+	bold        = "\x1b[1m"
+	reset       = "\x1b[0m"
+)
+
+// sparkBars are the sparkline levels, lowest first
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// Config holds dashboard settings
+type Config struct {
+	// Refresh is how often the dashboard is redrawn (default: 1 second)
+	Refresh time.Duration
+
+	// Width is the number of columns of the heap sparkline (default: 60)
+	Width int
+
+	// MaxRecommendations is the number of recommendations shown (default: 3)
+	MaxRecommendations int
+}
+
+// DefaultConfig returns the default dashboard configuration
+func DefaultConfig() *Config {
+	return &Config{
+		Refresh:            time.Second,
+		Width:              60,
+		MaxRecommendations: 3,
+	}
+}
+
+// withDefaults returns config with unset fields filled in from DefaultConfig
+func (c *Config) withDefaults() *Config {
+	d := DefaultConfig()
+	if c == nil {
+		return d
+	}
+	out := *c
+	if out.Refresh <= 0 {
+		out.Refresh = d.Refresh
+	}
+	if out.Width <= 0 {
+		out.Width = d.Width
+	}
+	if out.MaxRecommendations <= 0 {
+		out.MaxRecommendations = d.MaxRecommendations
+	}
+	return &out
+}
+
+// Run redraws the dashboard for monitor on w every Refresh until ctx is
+// done. The monitor must be started separately. A nil config uses the
+// defaults.
+func Run(ctx context.Context, w io.Writer, monitor *gcanalyzer.Monitor, config *Config) error {
+	config = config.withDefaults()
+	if _, err := io.WriteString(w, hideCursor); err != nil {
+		return err
+	}
+	defer func() { _, _ = io.WriteString(w, showCursor) }()
+
+	ticker := time.NewTicker(config.Refresh)
+	defer ticker.Stop()
+	for {
+		if _, err := io.WriteString(w, clearScreen); err != nil {
+			return err
+		}
+		if err := Render(w, monitor, config); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Render writes a single dashboard frame for monitor to w. A nil config
+// uses the defaults.
+func Render(w io.Writer, monitor *gcanalyzer.Monitor, config *Config) error {
+	config = config.withDefaults()
+	bw := bufio.NewWriter(w)
+
+	snapshot := monitor.Snapshot()
+	fmt.Fprintf(bw, "%sgo-gc-analyzer%s  %s", bold, reset, time.Now().Format(time.DateTime))
+	if snapshot != nil && snapshot.Health != nil {
+		fmt.Fprintf(bw, "  health: %s (%d)", snapshot.Health.Status, snapshot.Health.Score)
+	}
+	bw.WriteString("\n\n")
+
+	var analysis *types.GCAnalysis
+	if snapshot != nil {
+		analysis = snapshot.Analysis
+	}
+	if analysis == nil {
+		bw.WriteString("Collecting samples...\n")
+		return bw.Flush()
+	}
+
+	metrics := monitor.GetMetrics()
+	heap := make([]uint64, len(metrics))
+	for i, m := range metrics {
+		heap[i] = m.HeapAlloc
+	}
+	fmt.Fprintf(bw, "Heap   %s  %s (min %s, max %s)\n",
+		Sparkline(heap, config.Width), types.FormatBytes(snapshot.Metrics.HeapAlloc),
+		types.FormatBytes(analysis.MinHeapSize), types.FormatBytes(analysis.MaxHeapSize))
+	fmt.Fprintf(bw, "GC     %.2f/s, every %v, overhead %.2f%%\n",
+		analysis.GCFrequency, analysis.AvgGCInterval.Round(time.Millisecond), analysis.GCOverhead)
+	fmt.Fprintf(bw, "Pause  avg %v  p95 %v  p99 %v  max %v\n",
+		analysis.AvgPauseTime, analysis.P95PauseTime, analysis.P99PauseTime, analysis.MaxPauseTime)
+	fmt.Fprintf(bw, "Alloc  %s\n", types.FormatBytesRate(analysis.AllocRate))
+
+	if recs := analysis.Recommendations; len(recs) > 0 {
+		bw.WriteString("\nRecommendations\n")
+		for _, rec := range recs[:min(len(recs), config.MaxRecommendations)] {
+			fmt.Fprintf(bw, "  - %s\n", rec)
+		}
+	}
+	return bw.Flush()
+}
+
+// Sparkline renders values as a line of at most width bar characters,
+// averaging adjacent values when there are more than width of them
+func Sparkline(values []uint64, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = downsample(values, width)
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+
+	var b strings.Builder
+	b.Grow(len(values) * 3)
+	for _, v := range values {
+		level := 0
+		if hi > lo {
+			level = int(float64(v-lo) / float64(hi-lo) * float64(len(sparkBars)-1))
+		}
+		b.WriteRune(sparkBars[level])
+	}
+	return b.String()
+}
+
+// downsample averages values into width buckets
+func downsample(values []uint64, width int) []uint64 {
+	out := make([]uint64, width)
+	for i := range out {
+		lo, hi := i*len(values)/width, (i+1)*len(values)/width
+		var sum uint64
+		for _, v := range values[lo:hi] {
+			sum += v
+		}
+		out[i] = sum / uint64(hi-lo)
+	}
+	return out
+}
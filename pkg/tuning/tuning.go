@@ -0,0 +1,133 @@
+// Package tuning runs GOGC experiments in the current process. Each stage of
+// a sweep applies a GOGC value with debug.SetGCPercent, measures the GC while
+// the process runs its normal workload, and the stages are ranked against
+// each other.
+//
+//	result, err := tuning.Sweep(ctx, []int{50, 100, 200, 400}, 30*time.Second)
+//	if err == nil {
+//		fmt.Println("best GOGC:", result.Best().GOGC)
+//	}
+//
+// Setting the GOGC environment variable with os.Setenv has no effect on a
+// running process: the runtime reads it only at startup.
+package tuning
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/types"
+)
+
+// minSampleInterval bounds how often short stages are sampled
+const minSampleInterval = 10 * time.Millisecond
+
+// samplesPerStage is the number of samples taken in each stage
+const samplesPerStage = 20
+
+// Stage is the measurement of one GOGC value
+type Stage struct {
+	GOGC     int
+	Analysis *types.GCAnalysis
+
+	// Cost ranks the stage within its sweep, lower is better: the sum of its
+	// GC overhead, P99 pause and max heap size, each as a fraction of the
+	// highest value any stage in the sweep measured
+	Cost float64
+}
+
+// Result is the outcome of a sweep
+type Result struct {
+	// Stages holds one stage per GOGC value, lowest cost first
+	Stages []Stage
+
+	// OriginalGOGC is the GOGC value in effect before the sweep, which is
+	// restored when it ends
+	OriginalGOGC int
+}
+
+// Best returns the lowest-cost stage, or nil when there are none
+func (r *Result) Best() *Stage {
+	if len(r.Stages) == 0 {
+		return nil
+	}
+	return &r.Stages[0]
+}
+
+// Sweep applies each GOGC value in turn for perStage, measuring the process's
+// GC behavior under whatever workload it runs meanwhile, and returns the
+// stages ranked by cost. The original GOGC is restored when the sweep ends,
+// including on error. Returns ErrInvalidSweep when values is empty or
+// perStage is not positive, and the context's error if it is canceled.
+func Sweep(ctx context.Context, values []int, perStage time.Duration) (*Result, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%w: no GOGC values", types.ErrInvalidSweep)
+	}
+	if perStage <= 0 {
+		return nil, fmt.Errorf("%w: stage duration %v must be positive", types.ErrInvalidSweep, perStage)
+	}
+
+	interval := max(perStage/samplesPerStage, minSampleInterval)
+	original := debug.SetGCPercent(values[0])
+	defer debug.SetGCPercent(original)
+
+	result := &Result{
+		Stages:       make([]Stage, 0, len(values)),
+		OriginalGOGC: original,
+	}
+	for _, gogc := range values {
+		debug.SetGCPercent(gogc)
+		// Start each stage from a collected heap so earlier stages' garbage
+		// isn't charged to it
+		runtime.GC()
+
+		metrics, err := gcanalyzer.CollectForDuration(ctx, perStage, interval)
+		if err != nil {
+			return nil, err
+		}
+		analysis, err := gcanalyzer.Analyze(metrics)
+		if err != nil {
+			return nil, fmt.Errorf("GOGC=%d: %w", gogc, err)
+		}
+		result.Stages = append(result.Stages, Stage{GOGC: gogc, Analysis: analysis})
+	}
+
+	rank(result.Stages)
+	return result, nil
+}
+
+// rank computes each stage's cost and sorts the stages by it, keeping the
+// sweep order between equal costs
+func rank(stages []Stage) {
+	var maxOverhead, maxP99, maxHeap float64
+	for _, s := range stages {
+		maxOverhead = max(maxOverhead, s.Analysis.GCOverhead)
+		maxP99 = max(maxP99, float64(s.Analysis.P99PauseTime))
+		maxHeap = max(maxHeap, float64(s.Analysis.MaxHeapSize))
+	}
+
+	for i := range stages {
+		a := stages[i].Analysis
+		stages[i].Cost = share(a.GCOverhead, maxOverhead) +
+			share(float64(a.P99PauseTime), maxP99) +
+			share(float64(a.MaxHeapSize), maxHeap)
+	}
+
+	slices.SortStableFunc(stages, func(x, y Stage) int {
+		return cmp.Compare(x.Cost, y.Cost)
+	})
+}
+
+// share returns v as a fraction of highest, or 0 when nothing was measured
+func share(v, highest float64) float64 {
+	if highest <= 0 {
+		return 0
+	}
+	return v / highest
+}
@@ -0,0 +1,53 @@
+package types
+
+import "time"
+
+// Alert represents a GC performance alert
+type Alert struct {
+	Type       string            `json:"type"`     // frequency, pause, overhead, memory, slo
+	Severity   string            `json:"severity"` // info, warning, critical
+	Message    string            `json:"message"`
+	Value      float64           `json:"value"`
+	Threshold  float64           `json:"threshold"`
+	Rule       string            `json:"rule,omitempty"`       // name of the alert rule that raised it
+	Resolved   bool              `json:"resolved,omitempty"`   // the rule's condition has cleared
+	Suppressed bool              `json:"suppressed,omitempty"` // held back by a cooldown, as kept in the alert history
+	Labels     map[string]string `json:"labels,omitempty"`     // the monitor's labels
+	Metric     *GCMetrics        `json:"metric,omitempty"`
+	Event      *GCEvent          `json:"event,omitempty"`
+	Timestamp  time.Time         `json:"timestamp"`
+}
+
+// AlertRecord is an alert in a monitor's alert history
+type AlertRecord struct {
+	Alert      *Alert     `json:"alert"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"` // nil while the alert is active
+}
+
+// Active reports whether the alert's condition still holds
+func (r *AlertRecord) Active() bool {
+	return r.ResolvedAt == nil
+}
+
+// PauseSLOStatus reports a pause SLO's compliance and error budget burn
+// rates over the windows a monitor has classified
+type PauseSLOStatus struct {
+	Objective float64       `json:"objective"`
+	MaxPause  time.Duration `json:"max_pause"`
+	Window    time.Duration `json:"window"`
+
+	// Windows and BadWindows count the closed windows within the slow burn
+	// range, and those whose longest pause reached MaxPause
+	Windows    int     `json:"windows"`
+	BadWindows int     `json:"bad_windows"`
+	Compliance float64 `json:"compliance"` // fraction of good windows; 1 without any
+
+	// BudgetSpent is the fraction of the error budget the bad windows used,
+	// above 1 once the objective is missed
+	BudgetSpent float64 `json:"budget_spent"`
+
+	FastBurnRate      float64 `json:"fast_burn_rate"`
+	FastBurnThreshold float64 `json:"fast_burn_threshold"`
+	SlowBurnRate      float64 `json:"slow_burn_rate"`
+	SlowBurnThreshold float64 `json:"slow_burn_threshold"`
+}
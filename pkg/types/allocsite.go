@@ -0,0 +1,150 @@
+package types
+
+import (
+	"cmp"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MemProfile is the process's cumulative allocation profile at one point in
+// time. Two of them bound a collection window for AttributeAllocSites.
+type MemProfile struct {
+	At      time.Time
+	Rate    int // runtime.MemProfileRate at capture time
+	Records []runtime.MemProfileRecord
+}
+
+// ReadMemProfile captures the current process's allocation profile. The
+// runtime publishes profile data at the end of each GC cycle, so allocations
+// since the last GC are not yet included.
+func ReadMemProfile() *MemProfile {
+	return &MemProfile{
+		At:      time.Now(),
+		Rate:    runtime.MemProfileRate,
+		Records: readMemProfileRecords(),
+	}
+}
+
+// AllocSite is a code location and the allocations made there during a window
+type AllocSite struct {
+	Function      string  `json:"function"`
+	File          string  `json:"file"`
+	Line          int     `json:"line"`
+	Bytes         uint64  `json:"bytes"`   // estimated bytes allocated during the window
+	Objects       uint64  `json:"objects"` // estimated objects allocated during the window
+	BytesPerSec   float64 `json:"bytes_per_sec"`
+	ObjectsPerSec float64 `json:"objects_per_sec"`
+	Share         float64 `json:"share"` // share of bytes allocated at all sites, 0-1
+}
+
+// Location returns the site as "file.go:42"
+func (s *AllocSite) Location() string {
+	return filepath.Base(s.File) + ":" + strconv.Itoa(s.Line)
+}
+
+// AllocSiteReport ranks the code locations allocating the most during a window
+type AllocSiteReport struct {
+	Sites      []AllocSite   `json:"sites"` // highest allocation rate first
+	Window     time.Duration `json:"window"`
+	TotalBytes uint64        `json:"total_bytes"` // estimated bytes allocated at all sites
+	SampleRate int           `json:"sample_rate"` // runtime.MemProfileRate at capture time
+}
+
+// Top returns the site allocating the most, or nil when nothing was sampled
+func (r *AllocSiteReport) Top() *AllocSite {
+	if r == nil || len(r.Sites) == 0 {
+		return nil
+	}
+	return &r.Sites[0]
+}
+
+// AttributeAllocSites ranks the code locations that allocated between two
+// profiles of the same process, keeping at most top sites. Each record is
+// attributed to its first frame outside the runtime, so allocations made by
+// make, new or append count against their caller. A nil before attributes
+// everything allocated since process start, without rates.
+func AttributeAllocSites(before, after *MemProfile, top int) *AllocSiteReport {
+	if after == nil {
+		return nil
+	}
+	report := &AllocSiteReport{SampleRate: after.Rate}
+	if before != nil {
+		report.Window = after.At.Sub(before.At)
+	}
+
+	type counts struct{ objects, bytes int64 }
+	var baseline map[[32]uintptr]counts
+	if before != nil {
+		baseline = make(map[[32]uintptr]counts, len(before.Records))
+		for i := range before.Records {
+			rec := &before.Records[i]
+			baseline[rec.Stack0] = counts{rec.AllocObjects, rec.AllocBytes}
+		}
+	}
+
+	sites := make(map[runtime.Frame]*AllocSite)
+	for i := range after.Records {
+		rec := &after.Records[i]
+		prev := baseline[rec.Stack0]
+		objects, bytes := scaleHeapSample(rec.AllocObjects-prev.objects, rec.AllocBytes-prev.bytes, after.Rate)
+		if objects <= 0 || bytes <= 0 {
+			continue
+		}
+
+		frame := allocFrame(rec.Stack())
+		key := runtime.Frame{Function: frame.Function, File: frame.File, Line: frame.Line}
+		site, ok := sites[key]
+		if !ok {
+			site = &AllocSite{Function: frame.Function, File: frame.File, Line: frame.Line}
+			sites[key] = site
+		}
+		site.Objects += uint64(objects)
+		site.Bytes += uint64(bytes)
+		report.TotalBytes += uint64(bytes)
+	}
+
+	report.Sites = make([]AllocSite, 0, len(sites))
+	seconds := report.Window.Seconds()
+	for _, site := range sites {
+		if seconds > 0 {
+			site.BytesPerSec = float64(site.Bytes) / seconds
+			site.ObjectsPerSec = float64(site.Objects) / seconds
+		}
+		site.Share = float64(site.Bytes) / float64(report.TotalBytes)
+		report.Sites = append(report.Sites, *site)
+	}
+	slices.SortFunc(report.Sites, func(x, y AllocSite) int {
+		if c := cmp.Compare(y.Bytes, x.Bytes); c != 0 {
+			return c
+		}
+		return cmp.Compare(x.Function+x.Location(), y.Function+y.Location())
+	})
+	if top > 0 && len(report.Sites) > top {
+		report.Sites = report.Sites[:top]
+	}
+
+	return report
+}
+
+// allocFrame returns the first frame of stack outside the runtime package,
+// or the innermost frame when every frame is in the runtime
+func allocFrame(stack []uintptr) runtime.Frame {
+	frames := runtime.CallersFrames(stack)
+	var first runtime.Frame
+	for i := 0; ; i++ {
+		frame, more := frames.Next()
+		if i == 0 {
+			first = frame
+		}
+		if !strings.HasPrefix(frame.Function, "runtime.") {
+			return frame
+		}
+		if !more {
+			return first
+		}
+	}
+}
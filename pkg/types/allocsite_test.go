@@ -0,0 +1,70 @@
+package types
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+var allocSink [][]byte
+
+//go:noinline
+func allocHotSpot(n int) {
+	for range n {
+		allocSink = append(allocSink, make([]byte, 4096))
+	}
+}
+
+func TestAttributeAllocSites(t *testing.T) {
+	defer func(rate int) { runtime.MemProfileRate = rate }(runtime.MemProfileRate)
+	runtime.MemProfileRate = 1
+
+	runtime.GC()
+	before := ReadMemProfile()
+	allocHotSpot(1000)
+	allocSink = nil
+	// Profile data is published at the end of a GC cycle
+	runtime.GC()
+	after := ReadMemProfile()
+	after.At = before.At.Add(2 * time.Second)
+
+	report := AttributeAllocSites(before, after, 5)
+	top := report.Top()
+	if top == nil {
+		t.Fatal("Expected allocation sites")
+	}
+	if !strings.HasSuffix(top.Function, ".allocHotSpot") {
+		t.Fatalf("Top site = %s (%s), want allocHotSpot; sites: %+v", top.Function, top.Location(), report.Sites)
+	}
+	if !strings.HasPrefix(top.Location(), "allocsite_test.go:") {
+		t.Errorf("Location() = %q, want allocsite_test.go:<line>", top.Location())
+	}
+	if top.Objects < 1000 || top.Bytes < 1000*4096 {
+		t.Errorf("Top site = %d objects, %d bytes; want at least 1000 x 4 KB", top.Objects, top.Bytes)
+	}
+	if want := float64(top.Bytes) / 2; top.BytesPerSec != want {
+		t.Errorf("BytesPerSec = %v, want %v over the 2s window", top.BytesPerSec, want)
+	}
+	if top.Share <= 0 || top.Share > 1 || len(report.Sites) > 5 {
+		t.Errorf("Share = %v with %d sites", top.Share, len(report.Sites))
+	}
+	if report.Window != 2*time.Second || report.SampleRate != 1 {
+		t.Errorf("Window = %v, SampleRate = %d", report.Window, report.SampleRate)
+	}
+}
+
+func TestAttributeAllocSites_NoWindow(t *testing.T) {
+	if AttributeAllocSites(nil, nil, 5) != nil {
+		t.Error("Expected no report without a profile")
+	}
+
+	report := AttributeAllocSites(nil, &MemProfile{At: time.Now(), Rate: 1}, 5)
+	if report.Top() != nil || report.Window != 0 {
+		t.Errorf("Empty profile should have no sites, got %+v", report)
+	}
+	var nilReport *AllocSiteReport
+	if nilReport.Top() != nil {
+		t.Error("Nil report should have no top site")
+	}
+}
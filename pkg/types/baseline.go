@@ -0,0 +1,41 @@
+package types
+
+import "time"
+
+// BaselineFormatVersion is the current baseline snapshot format version
+const BaselineFormatVersion = 1
+
+// Limits on baseline snapshots read by ImportBaseline. A snapshot holds at
+// most one bucket per minute of the day for each series.
+const (
+	MaxBaselineSize   = 16 << 20
+	MaxBaselineSeries = 64
+)
+
+// BaselineSnapshot is an exported seasonal baseline, so adaptive alerting can
+// resume after a restart or be shared with another instance instead of
+// relearning from scratch
+type BaselineSnapshot struct {
+	FormatVersion int       `json:"format_version"`
+	Location      string    `json:"location"` // time zone the minutes of day are in
+	ExportedAt    time.Time `json:"exported_at"`
+
+	// Series maps a series name (see Series* constants) to its learned minutes;
+	// minutes never observed are omitted
+	Series map[string][]BaselineBucket `json:"series"`
+}
+
+// BaselineBucket is the learned state of one minute of the day
+type BaselineBucket struct {
+	Minute  int     `json:"minute"` // 0-1439
+	Count   uint64  `json:"count"`
+	Mean    float64 `json:"mean"`
+	M2      float64 `json:"m2"`       // sum of squared deviations from the mean
+	Days    int     `json:"days"`     // distinct days observed
+	LastDay int     `json:"last_day"` // days since the Unix epoch of the last observation
+
+	// Alert bounds derived from the statistics above at export time, for
+	// consumers of the file; they are recomputed on import
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+}
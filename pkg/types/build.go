@@ -0,0 +1,106 @@
+package types
+
+import (
+	"runtime/debug"
+	"slices"
+)
+
+// BuildInfo records the build a dataset was captured from, so captures of
+// the same Go version can still be told apart, e.g. before and after a
+// dependency upgrade
+type BuildInfo struct {
+	Path     string            `json:"path,omitempty"`     // main module path
+	Version  string            `json:"version,omitempty"`  // main module version
+	Revision string            `json:"revision,omitempty"` // VCS revision
+	Modified bool              `json:"modified,omitempty"` // built from a dirty working tree
+	Deps     map[string]string `json:"deps,omitempty"`     // module path to version
+}
+
+// BuildChange describes a build component that differs between two captures.
+// Before or After is empty when the component was added or removed.
+type BuildChange struct {
+	Component string `json:"component"`
+	Before    string `json:"before,omitempty"`
+	After     string `json:"after,omitempty"`
+}
+
+// String returns a description such as "golang.org/x/net: v0.20.0 → v0.21.0"
+func (c BuildChange) String() string {
+	return c.Component + ": " + orNone(c.Before) + " → " + orNone(c.After)
+}
+
+// CurrentBuildInfo reads the build information embedded in the current
+// binary. Returns nil when the binary was built without module support.
+func CurrentBuildInfo() *BuildInfo {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+
+	b := &BuildInfo{
+		Path:    info.Main.Path,
+		Version: info.Main.Version,
+	}
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			b.Revision = s.Value
+		case "vcs.modified":
+			b.Modified = s.Value == "true"
+		}
+	}
+	if len(info.Deps) > 0 {
+		b.Deps = make(map[string]string, len(info.Deps))
+		for _, d := range info.Deps {
+			if d.Replace != nil {
+				d = d.Replace
+			}
+			b.Deps[d.Path] = d.Version
+		}
+	}
+	return b
+}
+
+// DiffBuilds returns the build components that differ between two captures:
+// the main module version and revision, followed by changed, added and
+// removed dependencies sorted by module path.
+// Returns nil when either side is nil or nothing differs.
+func DiffBuilds(before, after *BuildInfo) []BuildChange {
+	if before == nil || after == nil {
+		return nil
+	}
+
+	var changes []BuildChange
+	if before.Version != after.Version {
+		changes = append(changes, BuildChange{"version", before.Version, after.Version})
+	}
+	if before.Revision != after.Revision {
+		changes = append(changes, BuildChange{"revision", before.Revision, after.Revision})
+	}
+
+	paths := make([]string, 0, len(before.Deps)+len(after.Deps))
+	for path := range before.Deps {
+		paths = append(paths, path)
+	}
+	for path := range after.Deps {
+		if _, ok := before.Deps[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+	for _, path := range paths {
+		if from, to := before.Deps[path], after.Deps[path]; from != to {
+			changes = append(changes, BuildChange{path, from, to})
+		}
+	}
+
+	return changes
+}
+
+// orNone returns s, or "none" when it is empty
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
@@ -0,0 +1,47 @@
+package types
+
+import "testing"
+
+func TestDiffBuilds(t *testing.T) {
+	before := &BuildInfo{
+		Version:  "v1.4.0",
+		Revision: "abc123",
+		Deps: map[string]string{
+			"golang.org/x/net":  "v0.20.0",
+			"golang.org/x/sync": "v0.6.0",
+			"golang.org/x/text": "v0.14.0",
+		},
+	}
+
+	if changes := DiffBuilds(before, nil); changes != nil {
+		t.Errorf("DiffBuilds with nil after = %v, want nil", changes)
+	}
+	if changes := DiffBuilds(before, before); len(changes) != 0 {
+		t.Errorf("DiffBuilds with identical builds = %v, want none", changes)
+	}
+
+	after := &BuildInfo{
+		Version:  "v1.4.0",
+		Revision: "def456",
+		Deps: map[string]string{
+			"github.com/example/cache": "v1.0.0",
+			"golang.org/x/net":         "v0.21.0",
+			"golang.org/x/text":        "v0.14.0",
+		},
+	}
+	changes := DiffBuilds(before, after)
+	want := []string{
+		"revision: abc123 → def456",
+		"github.com/example/cache: none → v1.0.0",
+		"golang.org/x/net: v0.20.0 → v0.21.0",
+		"golang.org/x/sync: v0.6.0 → none",
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("DiffBuilds returned %d changes, want %d: %v", len(changes), len(want), changes)
+	}
+	for i, w := range want {
+		if got := changes[i].String(); got != w {
+			t.Errorf("changes[%d] = %q, want %q", i, got, w)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package types
+
+// BundleFormatVersion is the current capture bundle format version
+const BundleFormatVersion = 1
+
+// MaxBundleSize bounds the size of a capture bundle read by ReadBundle
+const MaxBundleSize = 256 << 20
+
+// Bundle is a self-describing capture of GC data together with the runtime
+// configuration it was recorded under, so captures from different builds or
+// Go versions can be compared later
+type Bundle struct {
+	FormatVersion int          `json:"format_version"`
+	Label         string       `json:"label,omitempty"` // free-form, e.g. a build or deploy identifier
+	Runtime       *RuntimeInfo `json:"runtime,omitempty"`
+	Build         *BuildInfo   `json:"build,omitempty"`
+	Metrics       []*GCMetrics `json:"metrics"`
+	Events        []*GCEvent   `json:"events,omitempty"`
+}
+
+// Upgrade comparison metric names
+const (
+	DeltaAvgPause      = "avg_pause"      // nanoseconds
+	DeltaP99Pause      = "p99_pause"      // nanoseconds
+	DeltaGCOverhead    = "gc_overhead"    // percent of CPU
+	DeltaGCFrequency   = "gc_frequency"   // GCs per second
+	DeltaRuntimeMemory = "runtime_memory" // average Sys minus HeapReleased, in bytes, when RSS is unavailable
+	DeltaRSS           = "rss"            // average ProcessRSS, in bytes
+)
+
+// MetricDelta compares one metric between two captures. For every metric in
+// an upgrade comparison lower values are better.
+type MetricDelta struct {
+	Metric string  `json:"metric"`
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+	Change float64 `json:"change"` // (After-Before)/Before, zero when Before is zero
+}
+
+// Improved reports whether the metric decreased
+func (d MetricDelta) Improved() bool {
+	return d.After < d.Before
+}
+
+// UpgradeComparison describes how GC behavior changed between two captures,
+// recorded under different Go versions or from different builds
+type UpgradeComparison struct {
+	FromVersion string        `json:"from_version"`
+	ToVersion   string        `json:"to_version"`
+	FromLabel   string        `json:"from_label,omitempty"`
+	ToLabel     string        `json:"to_label,omitempty"`
+	Before      *GCAnalysis   `json:"before"`
+	After       *GCAnalysis   `json:"after"`
+	Deltas      []MetricDelta `json:"deltas"`
+	Changes     []BuildChange `json:"changes,omitempty"` // build differences between the captures
+	Notes       []string      `json:"notes,omitempty"`   // version-aware commentary
+}
@@ -0,0 +1,109 @@
+//go:build linux
+
+package types
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// cgroupUnlimited is the smallest cgroup v1 limit treated as "no limit";
+// v1 reports an unset limit as a page-aligned LONG_MAX
+const cgroupUnlimited = 1 << 62
+
+// ReadCgroupMemoryLimit returns the memory limit of the cgroup the current
+// process runs in, checking cgroup v2 first and then v1. ok is false when no
+// limit is set or the platform has no cgroups.
+func ReadCgroupMemoryLimit() (limit uint64, ok bool) {
+	return readCgroupMemoryLimit("/")
+}
+
+// readCgroupMemoryLimit reads the memory limit with procfs and cgroupfs
+// mounted under root
+func readCgroupMemoryLimit(root string) (uint64, bool) {
+	v1Path, v2Path, found := parseProcCgroup(filepath.Join(root, "proc/self/cgroup"))
+	if !found {
+		return 0, false
+	}
+	base := filepath.Join(root, "sys/fs/cgroup")
+
+	// cgroup v2: the effective limit is the smallest along the hierarchy
+	if v2Path != "" {
+		var limit uint64
+		for dir := v2Path; ; dir = filepath.Dir(dir) {
+			if v, ok := readCgroupValue(filepath.Join(base, dir, "memory.max")); ok && (limit == 0 || v < limit) {
+				limit = v
+			}
+			if dir == "/" || dir == "." {
+				break
+			}
+		}
+		if limit > 0 {
+			return limit, true
+		}
+	}
+
+	// cgroup v1: inside a container the memory hierarchy is usually mounted
+	// at the process's own cgroup, so fall back to the mount root
+	if v1Path != "" {
+		for _, dir := range []string{v1Path, "/"} {
+			if v, ok := readCgroupValue(filepath.Join(base, "memory", dir, "memory.limit_in_bytes")); ok {
+				return v, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// parseProcCgroup returns the process's cgroup v1 memory controller path and
+// cgroup v2 path from /proc/self/cgroup
+func parseProcCgroup(path string) (v1, v2 string, ok bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", "", false
+	}
+	defer f.Close()
+
+	// Lines are hierarchy-ID:controller-list:path
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		switch {
+		case fields[0] == "0" && fields[1] == "":
+			v2 = fields[2]
+		case containsField(fields[1], "memory"):
+			v1 = fields[2]
+		}
+	}
+	return v1, v2, v1 != "" || v2 != ""
+}
+
+// containsField reports whether the comma-separated list contains name
+func containsField(list, name string) bool {
+	for _, f := range strings.Split(list, ",") {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// readCgroupValue reads a memory limit file; "max" and v1's unset value mean no limit
+func readCgroupValue(path string) (uint64, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(string(bytes.TrimSpace(data)), 10, 64)
+	if err != nil || v == 0 || v >= cgroupUnlimited {
+		return 0, false
+	}
+	return v, true
+}
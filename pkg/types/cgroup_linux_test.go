@@ -0,0 +1,85 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeCgroupFS creates the given files under a temporary root
+func writeCgroupFS(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestReadCgroupMemoryLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  uint64
+		ok    bool
+	}{
+		{
+			name: "v2 container",
+			files: map[string]string{
+				"proc/self/cgroup":         "0::/\n",
+				"sys/fs/cgroup/memory.max": "536870912\n",
+			},
+			want: 512 << 20, ok: true,
+		},
+		{
+			name: "v2 unlimited",
+			files: map[string]string{
+				"proc/self/cgroup":         "0::/\n",
+				"sys/fs/cgroup/memory.max": "max\n",
+			},
+		},
+		{
+			name: "v2 limit set on a parent",
+			files: map[string]string{
+				"proc/self/cgroup":                           "0::/kubepods/pod1/app\n",
+				"sys/fs/cgroup/kubepods/pod1/memory.max":     "1073741824\n",
+				"sys/fs/cgroup/kubepods/pod1/app/memory.max": "max\n",
+			},
+			want: 1 << 30, ok: true,
+		},
+		{
+			name: "v1 container",
+			files: map[string]string{
+				"proc/self/cgroup":                           "4:memory:/docker/abc\n3:cpu,cpuacct:/docker/abc\n",
+				"sys/fs/cgroup/memory/memory.limit_in_bytes": "268435456\n",
+			},
+			want: 256 << 20, ok: true,
+		},
+		{
+			name: "v1 unlimited",
+			files: map[string]string{
+				"proc/self/cgroup":                           "4:memory:/\n",
+				"sys/fs/cgroup/memory/memory.limit_in_bytes": "9223372036854771712\n",
+			},
+		},
+		{
+			name:  "no cgroups",
+			files: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := readCgroupMemoryLimit(writeCgroupFS(t, tt.files))
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("readCgroupMemoryLimit() = %d, %v; want %d, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
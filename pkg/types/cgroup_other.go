@@ -0,0 +1,10 @@
+//go:build !linux
+
+package types
+
+// ReadCgroupMemoryLimit returns the memory limit of the cgroup the current
+// process runs in, checking cgroup v2 first and then v1. ok is false when no
+// limit is set or the platform has no cgroups.
+func ReadCgroupMemoryLimit() (limit uint64, ok bool) {
+	return 0, false
+}
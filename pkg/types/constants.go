@@ -8,12 +8,27 @@ const (
 	// GC frequency thresholds (GCs per second)
 	ThresholdGCFrequencyHigh = 10.0
 
+	// Forced GC thresholds
+	ThresholdForcedGCRatioHigh = 0.2 // 20% of GC cycles forced by runtime.GC
+	MinForcedGCs               = 3   // ignore the odd one-off runtime.GC call
+
 	// Pause time thresholds
 	ThresholdAvgPauseLong     = 100 * time.Millisecond
 	ThresholdP99PauseVeryLong = 500 * time.Millisecond
 	ThresholdPauseWarning     = 100 * time.Millisecond
 	ThresholdPauseCritical    = 500 * time.Millisecond
 
+	// Pause Apdex: pauses up to the target satisfy, up to the tolerating
+	// factor times the target are tolerated, and longer ones frustrate
+	DefaultPauseApdexTarget = time.Millisecond
+	ApdexToleratingFactor   = 4
+
+	// Pause outliers: pauses more than this many interquartile ranges above
+	// the 75th percentile
+	ThresholdPauseOutlierIQR = 3.0
+	MinPausesForOutliers     = 10 // pauses needed for meaningful quartiles
+	TopPauseCount            = 10 // longest pauses listed in analyses
+
 	// Memory thresholds (bytes per second)
 	ThresholdHeapGrowthRateHigh = 10 * 1024 * 1024  // 10 MB/s
 	ThresholdAllocationRateHigh = 100 * 1024 * 1024 // 100 MB/s
@@ -22,15 +37,83 @@ const (
 	ThresholdGCOverheadHigh      = 25.0 // 25%
 	ThresholdMemoryEfficiencyLow = 50.0 // 50%
 	ThresholdGCCPUFractionAlert  = 0.25 // 25%
+	ThresholdGCCPUFractionClear  = 0.20 // 20%, where a firing GC CPU alert resolves
+	ThresholdMarkAssistShareHigh = 0.25 // 25% of GC CPU spent in mutator assists
+
+	// Process CPU thresholds (share of CPU available to GOMAXPROCS)
+	ThresholdCPUSaturation     = 0.9 // process is CPU-bound
+	ThresholdCPUUtilizationLow = 0.5 // plenty of idle CPU for background GC work
+	ThresholdGCShareSaturated  = 0.1 // GC share of process CPU worth acting on when saturated
+
+	// Non-Go memory growth: fitted growth over the window relative to average RSS
+	ThresholdNonGoGrowth = 0.1 // 10%
+
+	// Allocation size classes
+	ThresholdSizeClassDominant = 0.6 // share of allocated bytes for one class to be dominant
 
 	// Growth trend thresholds
 	ThresholdConsistentGrowth  = 0.1 // 10% consistent growth
 	MinSamplesForTrendAnalysis = 10
 
+	// Reclamation trend: fitted drop in bytes reclaimed per cycle over the
+	// window, relative to the first cycles
+	ThresholdReclamationDecline = 0.2 // 20%
+
+	// Leak detection confidence (R² of the post-GC floor regression)
+	LeakHighConfidenceR2   = 0.8
+	LeakMediumConfidenceR2 = 0.5
+
+	// Periodicity detection
+	MinSamplesForPeriodicity  = 2 * MinSamplesForTrendAnalysis
+	ThresholdPeriodicStrength = 0.5 // minimum autocorrelation at the detected period
+
+	// Changepoint detection
+	MinChangepointSegment     = 5   // minimum intervals on each side of a changepoint
+	ThresholdChangepointShift = 0.5 // minimum relative change in segment means (50%)
+	ThresholdChangepointScore = 4.0 // minimum Welch t-score between segments
+
+	// OOM forecast settings
+	MinSamplesForForecast        = 3
+	OOMForecastWindow            = 60 // most recent samples used for the fit
+	ThresholdOOMForecastWarning  = time.Hour
+	ThresholdOOMForecastCritical = 15 * time.Minute
+
+	// Container memory limit
+	ThresholdMemoryLimitUsageHigh = 0.9 // share of the cgroup limit in use
+
+	// Soft memory limit (GOMEMLIMIT) pressure
+	ThresholdSoftLimitNear        = 0.9  // share of GOMEMLIMIT in use that counts as near the limit
+	ThresholdSoftLimitPressure    = 0.25 // share of time near the limit worth acting on
+	ThresholdDeathSpiralGCCPU     = 0.4  // GC share of CPU near the limit; the GC CPU limiter caps it at 50%
+	ThresholdDeathSpiralFrequency = 5.0  // GC frequency near the limit relative to below it
+	SoftLimitTargetUsage          = 0.8  // share of a suggested limit the peak usage should take
+
+	// OS paging (major page faults and swap)
+	ThresholdMajorFaultRate = 10.0 // major page faults per second that count as paging
+	PagingSlowPauseFactor   = 2.0  // pause per GC over an interval, relative to the window average, that counts as slow
+
+	// Data quality: a sample interval this many times the median interval is
+	// a gap in sampling
+	ThresholdSampleGapFactor = 10
+
+	// Seasonal baseline alerting
+	MinSeasonalDays           = 3   // days a minute-of-day bucket must be observed before it is used
+	ThresholdSeasonalZScore   = 4.0 // standard deviations from the seasonal mean to alert
+	SeasonalMinRelativeStdDev = 0.1 // floor on the standard deviation, relative to the mean
+
+	// Recommendation severity ratios (observed value / threshold)
+	SeverityWarningRatio  = 1.5
+	SeverityCriticalRatio = 3.0
+
 	// Health score thresholds
 	HealthScoreHealthy = 80
 	HealthScoreWarning = 60
 
+	// Health score trend: the fitted change in score across the most recent
+	// health checks that counts as improving or degrading
+	HealthTrendWindow    = 10
+	ThresholdHealthTrend = 5.0
+
 	// Health check penalties
 	PenaltyGCFrequency      = 15
 	PenaltyAvgPause         = 20
@@ -38,8 +121,17 @@ const (
 	PenaltyGCOverhead       = 25
 	PenaltyMemoryEfficiency = 15
 	PenaltyAllocationRate   = 10
+	PenaltyOOMForecast      = 30
+	PenaltyMemoryLimitUsage = 20
 
 	// Default configuration values
 	DefaultCollectionInterval = time.Second
 	DefaultMaxSamples         = 1000
+	DefaultMaxAlerts          = 100
+	DefaultTopAllocSites      = 10
+	DefaultRequestWindow      = 10000       // recent requests per-request distributions cover
+	DefaultAlertWindow        = time.Minute // lookback of the default GC frequency and heap growth alert rules
 )
+
+// DefaultMMUWindows are the window sizes of minimum mutator utilization curves
+var DefaultMMUWindows = []time.Duration{time.Millisecond, 10 * time.Millisecond, 100 * time.Millisecond, time.Second}
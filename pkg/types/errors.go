@@ -1,12 +1,70 @@
 package types
 
-import "errors"
+import (
+	"errors"
+	"strconv"
+)
 
-// Common errors used by the analyzer package
+// Sentinel errors returned across the module. Most are wrapped with context,
+// such as the operation that failed or the offending value, so compare them
+// with errors.Is rather than ==; the sentinels themselves never change.
 var (
 	ErrCollectorAlreadyRunning = errors.New("collector is already running")
 	ErrCollectorNotRunning     = errors.New("collector is not running")
 	ErrInsufficientData        = errors.New("insufficient data for analysis")
+	ErrNoAnalysisData          = errors.New("no analysis data available")
+	ErrNoMetricsData           = errors.New("no metrics data available")
+	ErrNoEventsData            = errors.New("no events data available")
+	ErrUnknownChartType        = errors.New("unknown chart type")
 	ErrInvalidDuration         = errors.New("invalid duration specified")
 	ErrInvalidInterval         = errors.New("invalid interval specified")
+	ErrInvalidMemoryLimit      = errors.New("invalid memory limit specified")
+	ErrInvalidGCTrace          = errors.New("invalid gctrace line")
+	ErrUnknownScenario         = errors.New("unknown chaos scenario")
+	ErrInvalidBundle           = errors.New("invalid capture bundle")
+	ErrMissingRuntimeInfo      = errors.New("runtime metadata missing")
+	ErrSameGoVersion           = errors.New("captures were recorded under the same Go version")
+	ErrBaselineDisabled        = errors.New("seasonal baseline is not enabled")
+	ErrInvalidBaseline         = errors.New("invalid baseline snapshot")
+	ErrUnknownRemoteFormat     = errors.New("unknown remote source format")
+	ErrRemoteUnavailable       = errors.New("remote target unavailable")
+	ErrInvalidRemoteData       = errors.New("invalid remote target response")
+	ErrInputTooLarge           = errors.New("input exceeds size limit")
+	ErrInvalidAlertRule        = errors.New("invalid alert rule")
+	ErrInvalidWebhook          = errors.New("invalid webhook configuration")
+	ErrWebhookDelivery         = errors.New("webhook delivery failed")
+	ErrInvalidEmail            = errors.New("invalid email configuration")
+	ErrInvalidSLO              = errors.New("invalid pause SLO")
+	ErrSLODisabled             = errors.New("pause SLO is not configured")
+	ErrUnknownHealthProfile    = errors.New("unknown health profile")
+	ErrInvalidConfig           = errors.New("invalid configuration file")
+	ErrInvalidRetention        = errors.New("invalid retention policy")
+	ErrInvalidLabel            = errors.New("invalid label name")
+	ErrInvalidNamespace        = errors.New("invalid metric namespace")
+	ErrInvalidMetrics          = errors.New("invalid metrics file")
+	ErrUnknownFormat           = errors.New("unknown metrics file format")
+	ErrInvalidPrometheusData   = errors.New("invalid Prometheus query result")
+	ErrInvalidSweep            = errors.New("invalid GOGC sweep")
+	ErrRegression              = errors.New("GC metrics regressed")
+	ErrInvalidReportSchedule   = errors.New("invalid report schedule")
 )
+
+// InsufficientDataError is returned when an operation has fewer samples than
+// it needs. It matches ErrInsufficientData with errors.Is.
+type InsufficientDataError struct {
+	Op       string // operation that failed, e.g. "analyze"
+	Samples  int    // samples available
+	Required int    // samples the operation needs
+}
+
+// Error returns a description such as
+// "analyze: insufficient data for analysis (1 of 2 samples)"
+func (e *InsufficientDataError) Error() string {
+	return e.Op + ": " + ErrInsufficientData.Error() +
+		" (" + strconv.Itoa(e.Samples) + " of " + strconv.Itoa(e.Required) + " samples)"
+}
+
+// Unwrap returns ErrInsufficientData
+func (e *InsufficientDataError) Unwrap() error {
+	return ErrInsufficientData
+}
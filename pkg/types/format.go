@@ -1,7 +1,10 @@
 package types
 
 import (
+	"math"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // Size units for formatting
@@ -30,6 +33,11 @@ var byteUnits = []struct {
 // FormatBytes formats bytes into human-readable format (KB, MB, GB, etc.)
 // Optimized to reduce allocations by avoiding fmt.Sprintf where possible.
 func FormatBytes(bytes uint64) string {
+	return FormatBytesWith(bytes, NumberFormat{Decimals: 1})
+}
+
+// FormatBytesWith formats bytes like FormatBytes with the given precision and rounding
+func FormatBytesWith(bytes uint64, f NumberFormat) string {
 	if bytes < 1024 {
 		return strconv.FormatUint(bytes, 10) + " B"
 	}
@@ -38,7 +46,7 @@ func FormatBytes(bytes uint64) string {
 	for _, unit := range byteUnits {
 		if b >= unit.threshold {
 			value := float64(bytes) / unit.divisor
-			return formatFloat(value, 1) + unit.suffix
+			return f.Format(value) + unit.suffix
 		}
 	}
 
@@ -47,10 +55,67 @@ func FormatBytes(bytes uint64) string {
 
 // FormatBytesRate formats bytes per second into human-readable format
 func FormatBytesRate(bytesPerSecond float64) string {
+	return FormatBytesRateWith(bytesPerSecond, NumberFormat{Decimals: 1})
+}
+
+// FormatBytesRateWith formats bytes per second like FormatBytesRate with the
+// given precision and rounding
+func FormatBytesRateWith(bytesPerSecond float64, f NumberFormat) string {
 	if bytesPerSecond < 0 {
 		return "0 B/s"
 	}
-	return FormatBytes(uint64(bytesPerSecond)) + "/s"
+	return FormatBytesWith(uint64(bytesPerSecond), f) + "/s"
+}
+
+// RoundingMode selects how numbers are rounded to a fixed number of decimal places
+type RoundingMode string
+
+// Rounding modes
+const (
+	RoundHalfEven     RoundingMode = "half_even"      // ties to even (default)
+	RoundHalfUp       RoundingMode = "half_up"        // ties away from zero
+	RoundTowardZero   RoundingMode = "toward_zero"    // truncate
+	RoundAwayFromZero RoundingMode = "away_from_zero" // any remainder rounds up in magnitude
+)
+
+// NumberFormat controls the precision and rounding of formatted numbers, so
+// outputs stay stable for diffing and golden-file tests
+type NumberFormat struct {
+	// Decimals is the number of digits after the decimal point
+	Decimals int
+	// Rounding selects how values are rounded to Decimals (default: RoundHalfEven)
+	Rounding RoundingMode
+}
+
+// Round rounds v to f.Decimals places using f.Rounding
+func (f NumberFormat) Round(v float64) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return v
+	}
+	scale := math.Pow10(max(f.Decimals, 0))
+	switch f.Rounding {
+	case RoundHalfUp:
+		return math.Round(v*scale) / scale
+	case RoundTowardZero:
+		return math.Trunc(v*scale) / scale
+	case RoundAwayFromZero:
+		if v < 0 {
+			return math.Floor(v*scale) / scale
+		}
+		return math.Ceil(v*scale) / scale
+	default:
+		return math.RoundToEven(v*scale) / scale
+	}
+}
+
+// Format formats v with f.Decimals places, rounded using f.Rounding
+func (f NumberFormat) Format(v float64) string {
+	decimals := max(f.Decimals, 0)
+	if f.Rounding == "" || f.Rounding == RoundHalfEven {
+		// strconv rounds the exact binary value, ties to even
+		return formatFloat(v, decimals)
+	}
+	return formatFloat(f.Round(v), decimals)
 }
 
 // formatFloat formats a float with specified decimal places
@@ -58,3 +123,51 @@ func FormatBytesRate(bytesPerSecond float64) string {
 func formatFloat(value float64, decimals int) string {
 	return strconv.FormatFloat(value, 'f', decimals, 64)
 }
+
+// formatSigned formats a change with one decimal place and an explicit sign
+func formatSigned(value float64) string {
+	if value >= 0 {
+		return "+" + formatFloat(value, 1)
+	}
+	return formatFloat(value, 1)
+}
+
+// FormatApproxDuration formats a duration as a rounded, human-friendly
+// approximation such as "~42 minutes" or "~3 hours".
+func FormatApproxDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return approx(int64(d/time.Second), "second")
+	case d < 2*time.Hour:
+		return approx(int64((d+30*time.Second)/time.Minute), "minute")
+	case d < 48*time.Hour:
+		return approx(int64((d+30*time.Minute)/time.Hour), "hour")
+	default:
+		return approx(int64((d+12*time.Hour)/(24*time.Hour)), "day")
+	}
+}
+
+// FormatShortDuration formats a duration rounded to the second without
+// trailing zero units, e.g. "10m", "1h30m" or "45s".
+func FormatShortDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.String()
+	}
+	s := d.Round(time.Second).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// approx formats "~N unit(s)"
+func approx(n int64, unit string) string {
+	s := "~" + strconv.FormatInt(n, 10) + " " + unit
+	if n != 1 {
+		s += "s"
+	}
+	return s
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"runtime/debug"
+	"time"
+)
+
+// PauseQuantiles summarizes GC pauses as reported by runtime/debug.ReadGCStats.
+// NumGC and PauseTotal cover the whole process lifetime; the quantiles cover
+// the most recent pauses the runtime still holds (at most PauseBufferSize),
+// independent of how often samples were taken.
+type PauseQuantiles struct {
+	NumGC      int64         `json:"num_gc"`
+	PauseTotal time.Duration `json:"pause_total"`
+	Pauses     int           `json:"pauses"` // pauses the quantiles are computed over
+
+	Min time.Duration `json:"min"`
+	P25 time.Duration `json:"p25"`
+	P50 time.Duration `json:"p50"`
+	P75 time.Duration `json:"p75"`
+	P95 time.Duration `json:"p95"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+// ReadPauseQuantiles reads pause quantiles with debug.ReadGCStats. Returns nil
+// before the first GC cycle.
+func ReadPauseQuantiles() *PauseQuantiles {
+	// 101 quantiles give every percentile: q[i] is the i-th percentile
+	stats := debug.GCStats{PauseQuantiles: make([]time.Duration, 101)}
+	debug.ReadGCStats(&stats)
+	return pauseQuantilesFromStats(&stats)
+}
+
+// pauseQuantilesFromStats converts GCStats read with 101 pause quantiles
+func pauseQuantilesFromStats(stats *debug.GCStats) *PauseQuantiles {
+	q := stats.PauseQuantiles
+	if stats.NumGC == 0 || len(stats.Pause) == 0 || len(q) != 101 {
+		return nil
+	}
+	return &PauseQuantiles{
+		NumGC:      stats.NumGC,
+		PauseTotal: stats.PauseTotal,
+		Pauses:     len(stats.Pause),
+		Min:        q[0],
+		P25:        q[25],
+		P50:        q[50],
+		P75:        q[75],
+		P95:        q[95],
+		P99:        q[99],
+		Max:        q[100],
+	}
+}
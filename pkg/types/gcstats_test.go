@@ -0,0 +1,46 @@
+package types
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+	"time"
+)
+
+func TestPauseQuantilesFromStats(t *testing.T) {
+	stats := &debug.GCStats{
+		NumGC:          1000,
+		PauseTotal:     time.Second,
+		Pause:          make([]time.Duration, PauseBufferSize),
+		PauseQuantiles: make([]time.Duration, 101),
+	}
+	for i := range stats.PauseQuantiles {
+		stats.PauseQuantiles[i] = time.Duration(i) * time.Microsecond
+	}
+
+	q := pauseQuantilesFromStats(stats)
+	if q == nil {
+		t.Fatal("Expected quantiles")
+	}
+	if q.NumGC != 1000 || q.PauseTotal != time.Second || q.Pauses != PauseBufferSize {
+		t.Errorf("Lifetime totals = %+v", q)
+	}
+	if q.Min != 0 || q.P50 != 50*time.Microsecond || q.P99 != 99*time.Microsecond || q.Max != 100*time.Microsecond {
+		t.Errorf("Quantiles = %+v", q)
+	}
+
+	if pauseQuantilesFromStats(&debug.GCStats{PauseQuantiles: make([]time.Duration, 101)}) != nil {
+		t.Error("Expected nil before the first GC")
+	}
+}
+
+func TestReadPauseQuantiles(t *testing.T) {
+	runtime.GC()
+	q := ReadPauseQuantiles()
+	if q == nil || q.NumGC == 0 || q.Pauses == 0 {
+		t.Fatalf("Expected quantiles after a GC, got %+v", q)
+	}
+	if q.Min > q.P50 || q.P50 > q.P99 || q.P99 > q.Max {
+		t.Errorf("Quantiles out of order: %+v", q)
+	}
+}
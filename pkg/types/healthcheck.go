@@ -0,0 +1,134 @@
+package types
+
+import "time"
+
+// HealthCheckConfig sets the thresholds a health check reports issues at and
+// the score penalty of each issue, so e.g. a latency-sensitive API can flag
+// shorter pauses than a batch job. Start from DefaultHealthCheckConfig.
+type HealthCheckConfig struct {
+	// Thresholds beyond which an issue is reported
+	GCFrequencyHigh      float64       // GCs per second
+	AvgPauseLong         time.Duration // average pause
+	P99PauseVeryLong     time.Duration // 99th percentile pause
+	GCOverheadHigh       float64       // percentage of CPU time spent in GC
+	MemoryEfficiencyLow  float64       // percentage, reported below it
+	AllocationRateHigh   float64       // bytes per second
+	OOMForecastWarning   time.Duration // projected time to the memory limit, reported at or below it
+	MemoryLimitUsageHigh float64       // share of the container memory limit in use
+
+	// Penalties subtracted from a score of 100 per issue; zero still reports
+	// the issue without affecting the score
+	PenaltyGCFrequency      int
+	PenaltyAvgPause         int
+	PenaltyP99Pause         int
+	PenaltyGCOverhead       int
+	PenaltyMemoryEfficiency int
+	PenaltyAllocationRate   int
+	PenaltyOOMForecast      int
+	PenaltyMemoryLimitUsage int
+
+	// Minimum scores for the healthy and warning statuses; lower is critical
+	HealthyScore int
+	WarningScore int
+}
+
+// DefaultHealthCheckConfig returns the thresholds and penalties health checks
+// use unless configured otherwise
+func DefaultHealthCheckConfig() HealthCheckConfig {
+	return HealthCheckConfig{
+		GCFrequencyHigh:      ThresholdGCFrequencyHigh,
+		AvgPauseLong:         ThresholdAvgPauseLong,
+		P99PauseVeryLong:     ThresholdP99PauseVeryLong,
+		GCOverheadHigh:       ThresholdGCOverheadHigh,
+		MemoryEfficiencyLow:  ThresholdMemoryEfficiencyLow,
+		AllocationRateHigh:   ThresholdAllocationRateHigh,
+		OOMForecastWarning:   ThresholdOOMForecastWarning,
+		MemoryLimitUsageHigh: ThresholdMemoryLimitUsageHigh,
+
+		PenaltyGCFrequency:      PenaltyGCFrequency,
+		PenaltyAvgPause:         PenaltyAvgPause,
+		PenaltyP99Pause:         PenaltyP99Pause,
+		PenaltyGCOverhead:       PenaltyGCOverhead,
+		PenaltyMemoryEfficiency: PenaltyMemoryEfficiency,
+		PenaltyAllocationRate:   PenaltyAllocationRate,
+		PenaltyOOMForecast:      PenaltyOOMForecast,
+		PenaltyMemoryLimitUsage: PenaltyMemoryLimitUsage,
+
+		HealthyScore: HealthScoreHealthy,
+		WarningScore: HealthScoreWarning,
+	}
+}
+
+// HealthProfile names a predefined HealthCheckConfig for a kind of workload
+type HealthProfile string
+
+// Predefined health scoring profiles
+const (
+	// HealthProfileDefault is DefaultHealthCheckConfig
+	HealthProfileDefault HealthProfile = "default"
+
+	// HealthProfileLatencyCritical suits request-serving services: pauses of
+	// tens of milliseconds weigh most, GC overhead and memory less
+	HealthProfileLatencyCritical HealthProfile = "latency-critical"
+
+	// HealthProfileThroughputBatch suits batch jobs: long pauses and high
+	// allocation rates are tolerated, GC overhead eating throughput is not
+	HealthProfileThroughputBatch HealthProfile = "throughput-batch"
+
+	// HealthProfileMemoryConstrained suits processes near a memory limit:
+	// heap efficiency and headroom weigh most, GC overhead spent keeping the
+	// heap small less
+	HealthProfileMemoryConstrained HealthProfile = "memory-constrained"
+)
+
+// HealthProfiles returns all predefined profiles
+func HealthProfiles() []HealthProfile {
+	return []HealthProfile{
+		HealthProfileDefault,
+		HealthProfileLatencyCritical,
+		HealthProfileThroughputBatch,
+		HealthProfileMemoryConstrained,
+	}
+}
+
+// HealthCheckProfile returns the config of a predefined profile; an empty
+// profile is the default. Returns ErrUnknownHealthProfile for unsupported
+// profiles.
+func HealthCheckProfile(profile HealthProfile) (HealthCheckConfig, error) {
+	config := DefaultHealthCheckConfig()
+	switch profile {
+	case "", HealthProfileDefault:
+	case HealthProfileLatencyCritical:
+		config.AvgPauseLong = 10 * time.Millisecond
+		config.P99PauseVeryLong = 50 * time.Millisecond
+		config.PenaltyAvgPause = 30
+		config.PenaltyP99Pause = 30
+		config.PenaltyGCOverhead = 15
+		config.PenaltyMemoryEfficiency = 5
+	case HealthProfileThroughputBatch:
+		config.AvgPauseLong = 500 * time.Millisecond
+		config.P99PauseVeryLong = 2 * time.Second
+		config.GCOverheadHigh = 15
+		config.AllocationRateHigh = 1024 * 1024 * 1024 // 1 GB/s
+		config.PenaltyAvgPause = 5
+		config.PenaltyP99Pause = 5
+		config.PenaltyGCOverhead = 35
+		config.PenaltyGCFrequency = 20
+		config.PenaltyMemoryEfficiency = 10
+		config.PenaltyAllocationRate = 5
+	case HealthProfileMemoryConstrained:
+		config.GCOverheadHigh = 35
+		config.MemoryEfficiencyLow = 70
+		config.OOMForecastWarning = 4 * time.Hour
+		config.MemoryLimitUsageHigh = 0.8
+		config.PenaltyAvgPause = 10
+		config.PenaltyP99Pause = 5
+		config.PenaltyGCOverhead = 15
+		config.PenaltyMemoryEfficiency = 25
+		config.PenaltyOOMForecast = 35
+		config.PenaltyMemoryLimitUsage = 30
+	default:
+		return HealthCheckConfig{}, ErrUnknownHealthProfile
+	}
+	return config, nil
+}
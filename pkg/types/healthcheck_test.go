@@ -0,0 +1,26 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHealthCheckProfile(t *testing.T) {
+	for _, profile := range HealthProfiles() {
+		config, err := HealthCheckProfile(profile)
+		if err != nil {
+			t.Errorf("HealthCheckProfile(%q) error: %v", profile, err)
+			continue
+		}
+		if config.HealthyScore != HealthScoreHealthy || config.WarningScore != HealthScoreWarning {
+			t.Errorf("Profile %q changed the status scores: %+v", profile, config)
+		}
+	}
+
+	if config, _ := HealthCheckProfile(""); config != DefaultHealthCheckConfig() {
+		t.Errorf("Empty profile = %+v, want the default config", config)
+	}
+	if _, err := HealthCheckProfile("realtime"); !errors.Is(err, ErrUnknownHealthProfile) {
+		t.Errorf("Expected ErrUnknownHealthProfile, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// AdaptiveInterval samples slowly while GC is quiet and tightens the
+// interval when GC frequency or allocation rate spikes, so incidents are
+// captured in detail without the overhead of sampling fast all the time.
+// Zero fields take the values of DefaultAdaptiveInterval.
+type AdaptiveInterval struct {
+	// Min is the interval while either rate is above its threshold
+	Min time.Duration
+
+	// Max is the interval while GC is quiet. The interval doubles back
+	// towards it with each quiet sample after a spike.
+	Max time.Duration
+
+	// GCFrequency is the GCs per second above which sampling tightens
+	GCFrequency float64
+
+	// AllocationRate is the bytes per second above which sampling tightens
+	AllocationRate float64
+}
+
+// DefaultAdaptiveInterval returns sampling every 5s while quiet and every
+// 250ms while GC frequency or allocation rate exceeds the health check's
+// high thresholds
+func DefaultAdaptiveInterval() AdaptiveInterval {
+	return AdaptiveInterval{
+		Min:            250 * time.Millisecond,
+		Max:            5 * time.Second,
+		GCFrequency:    ThresholdGCFrequencyHigh,
+		AllocationRate: ThresholdAllocationRateHigh,
+	}
+}
+
+// WithDefaults returns a copy with zero fields set from DefaultAdaptiveInterval
+func (a AdaptiveInterval) WithDefaults() AdaptiveInterval {
+	def := DefaultAdaptiveInterval()
+	if a.Min == 0 {
+		a.Min = def.Min
+	}
+	if a.Max == 0 {
+		a.Max = def.Max
+	}
+	if a.GCFrequency == 0 {
+		a.GCFrequency = def.GCFrequency
+	}
+	if a.AllocationRate == 0 {
+		a.AllocationRate = def.AllocationRate
+	}
+	return a
+}
+
+// Validate checks that the intervals are positive and ordered and the
+// thresholds non-negative, after applying defaults. Returns an error
+// wrapping ErrInvalidInterval otherwise.
+func (a AdaptiveInterval) Validate() error {
+	a = a.WithDefaults()
+	switch {
+	case a.Min < 0 || a.Max < a.Min:
+		return fmt.Errorf("%w: adaptive interval range %v-%v", ErrInvalidInterval, a.Min, a.Max)
+	case a.GCFrequency < 0 || a.AllocationRate < 0:
+		return fmt.Errorf("%w: negative adaptive interval threshold", ErrInvalidInterval)
+	}
+	return nil
+}
+
+// Next returns the interval to use after a sample showing the given rates,
+// given the current interval
+func (a AdaptiveInterval) Next(current time.Duration, gcFrequency, allocationRate float64) time.Duration {
+	if gcFrequency > a.GCFrequency || allocationRate > a.AllocationRate {
+		return a.Min
+	}
+	return min(max(current*2, a.Min), a.Max)
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveInterval(t *testing.T) {
+	a := AdaptiveInterval{GCFrequency: 5}.WithDefaults()
+	if a.Min != 250*time.Millisecond || a.Max != 5*time.Second || a.GCFrequency != 5 || a.AllocationRate != ThresholdAllocationRateHigh {
+		t.Fatalf("WithDefaults() = %+v", a)
+	}
+
+	tests := []struct {
+		current     time.Duration
+		gcFrequency float64
+		allocRate   float64
+		want        time.Duration
+	}{
+		{5 * time.Second, 6, 0, 250 * time.Millisecond},
+		{5 * time.Second, 0, 200 << 20, 250 * time.Millisecond},
+		{250 * time.Millisecond, 1, 0, 500 * time.Millisecond},
+		{4 * time.Second, 1, 0, 5 * time.Second},
+		{5 * time.Second, 5, 0, 5 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := a.Next(tt.current, tt.gcFrequency, tt.allocRate); got != tt.want {
+			t.Errorf("Next(%v, %v, %v) = %v, want %v", tt.current, tt.gcFrequency, tt.allocRate, got, tt.want)
+		}
+	}
+}
+
+func TestAdaptiveInterval_Validate(t *testing.T) {
+	if err := (AdaptiveInterval{}).Validate(); err != nil {
+		t.Errorf("Validate() of the defaults error: %v", err)
+	}
+	for _, a := range []AdaptiveInterval{
+		{Min: time.Second, Max: time.Millisecond},
+		{Min: -time.Second},
+		{AllocationRate: -1},
+	} {
+		if err := a.Validate(); !errors.Is(err, ErrInvalidInterval) {
+			t.Errorf("Validate(%+v) = %v, want ErrInvalidInterval", a, err)
+		}
+	}
+}
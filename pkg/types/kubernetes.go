@@ -0,0 +1,135 @@
+package types
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Environment variables read for Kubernetes context. Expose them in the pod
+// spec through the Downward API, e.g.
+//
+//	env:
+//	- name: POD_NAME
+//	  valueFrom: {fieldRef: {fieldPath: metadata.name}}
+//	- name: MEMORY_LIMIT
+//	  valueFrom: {resourceFieldRef: {resource: limits.memory}}
+const (
+	EnvPodName       = "POD_NAME"
+	EnvPodNamespace  = "POD_NAMESPACE"
+	EnvNodeName      = "NODE_NAME"
+	EnvContainerName = "CONTAINER_NAME"
+	EnvCPULimit      = "CPU_LIMIT"
+	EnvCPURequest    = "CPU_REQUEST"
+	EnvMemoryLimit   = "MEMORY_LIMIT"
+	EnvMemoryRequest = "MEMORY_REQUEST"
+)
+
+// serviceAccountNamespaceFile holds the pod's namespace when a service
+// account token is mounted
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// KubernetesInfo identifies the pod a process runs in and its resources
+type KubernetesInfo struct {
+	Pod           string  `json:"pod,omitempty"`
+	Namespace     string  `json:"namespace,omitempty"`
+	Node          string  `json:"node,omitempty"`
+	Container     string  `json:"container,omitempty"`
+	CPULimit      float64 `json:"cpu_limit,omitempty"` // cores
+	CPURequest    float64 `json:"cpu_request,omitempty"`
+	MemoryLimit   uint64  `json:"memory_limit,omitempty"` // bytes
+	MemoryRequest uint64  `json:"memory_request,omitempty"`
+}
+
+// Labels returns the identifying fields as metric labels, omitting empty ones
+func (k *KubernetesInfo) Labels() map[string]string {
+	if k == nil {
+		return nil
+	}
+	labels := make(map[string]string, 4)
+	for name, value := range map[string]string{
+		"pod":       k.Pod,
+		"namespace": k.Namespace,
+		"node":      k.Node,
+		"container": k.Container,
+	} {
+		if value != "" {
+			labels[name] = value
+		}
+	}
+	return labels
+}
+
+// ReadKubernetesInfo reads the pod context from Downward API environment
+// variables (see EnvPodName and friends), falling back to the hostname and the
+// service account namespace. Returns nil outside Kubernetes.
+func ReadKubernetesInfo() *KubernetesInfo {
+	return readKubernetesInfo(os.Getenv, serviceAccountNamespaceFile)
+}
+
+// readKubernetesInfo reads the pod context using getenv and the given
+// namespace file
+func readKubernetesInfo(getenv func(string) string, namespaceFile string) *KubernetesInfo {
+	if getenv("KUBERNETES_SERVICE_HOST") == "" && getenv(EnvPodName) == "" {
+		return nil
+	}
+
+	k := &KubernetesInfo{
+		Pod:       getenv(EnvPodName),
+		Namespace: getenv(EnvPodNamespace),
+		Node:      getenv(EnvNodeName),
+		Container: getenv(EnvContainerName),
+	}
+	if k.Pod == "" {
+		// Pods use their name as the hostname
+		k.Pod = getenv("HOSTNAME")
+	}
+	if k.Namespace == "" {
+		if data, err := os.ReadFile(namespaceFile); err == nil {
+			k.Namespace = strings.TrimSpace(string(data))
+		}
+	}
+
+	k.CPULimit, _ = ParseQuantity(getenv(EnvCPULimit))
+	k.CPURequest, _ = ParseQuantity(getenv(EnvCPURequest))
+	if v, ok := ParseQuantity(getenv(EnvMemoryLimit)); ok {
+		k.MemoryLimit = uint64(v)
+	}
+	if v, ok := ParseQuantity(getenv(EnvMemoryRequest)); ok {
+		k.MemoryRequest = uint64(v)
+	}
+	return k
+}
+
+// quantitySuffixes maps Kubernetes quantity suffixes to multipliers
+var quantitySuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40}, {"Pi", 1 << 50},
+	{"n", 1e-9}, {"u", 1e-6}, {"m", 1e-3},
+	{"k", 1e3}, {"K", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12}, {"P", 1e15},
+}
+
+// ParseQuantity parses a Kubernetes resource quantity such as "500m", "2",
+// "512Mi" or "1G". ok is false for empty or malformed input.
+func ParseQuantity(s string) (v float64, ok bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, false
+	}
+	multiplier := 1.0
+	for _, q := range quantitySuffixes {
+		if strings.HasSuffix(s, q.suffix) {
+			s = strings.TrimSuffix(s, q.suffix)
+			multiplier = q.multiplier
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+		return 0, false
+	}
+	return v * multiplier, true
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64
+		ok    bool
+	}{
+		{"2", 2, true},
+		{"500m", 0.5, true},
+		{"512Mi", 512 << 20, true},
+		{"1G", 1e9, true},
+		{"1.5Gi", 1.5 * (1 << 30), true},
+		{"", 0, false},
+		{"lots", 0, false},
+		{"-1", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseQuantity(tt.input)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseQuantity(%q) = %v, %v; want %v, %v", tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestReadKubernetesInfo(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+	nsFile := filepath.Join(t.TempDir(), "namespace")
+	if err := os.WriteFile(nsFile, []byte("payments\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if k := readKubernetesInfo(env(nil), nsFile); k != nil {
+		t.Errorf("Expected nil outside Kubernetes, got %+v", k)
+	}
+
+	k := readKubernetesInfo(env(map[string]string{
+		"KUBERNETES_SERVICE_HOST": "10.0.0.1",
+		"HOSTNAME":                "api-7f9c",
+		EnvNodeName:               "node-1",
+		EnvCPULimit:               "2",
+		EnvMemoryLimit:            "536870912",
+		EnvMemoryRequest:          "256Mi",
+	}), nsFile)
+	want := &KubernetesInfo{
+		Pod:           "api-7f9c",
+		Namespace:     "payments",
+		Node:          "node-1",
+		CPULimit:      2,
+		MemoryLimit:   512 << 20,
+		MemoryRequest: 256 << 20,
+	}
+	if k == nil || *k != *want {
+		t.Errorf("readKubernetesInfo() = %+v, want %+v", k, want)
+	}
+
+	labels := k.Labels()
+	if !maps.Equal(labels, map[string]string{"pod": "api-7f9c", "namespace": "payments", "node": "node-1"}) {
+		t.Errorf("Labels() = %v", labels)
+	}
+	var nilInfo *KubernetesInfo
+	if nilInfo.Labels() != nil {
+		t.Error("Labels() on nil info should be nil")
+	}
+}
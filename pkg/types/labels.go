@@ -0,0 +1,35 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// labelName matches valid label names, as in the Prometheus data model
+var labelName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// metricName matches valid metric names, as in the Prometheus data model
+var metricName = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// ValidateLabels checks that every label name is a valid Prometheus label
+// name not reserved for internal use (leading "__"). Returns an error
+// wrapping ErrInvalidLabel otherwise.
+func ValidateLabels(labels map[string]string) error {
+	for name := range labels {
+		if !labelName.MatchString(name) || strings.HasPrefix(name, "__") {
+			return fmt.Errorf("%w: %q", ErrInvalidLabel, name)
+		}
+	}
+	return nil
+}
+
+// ValidateNamespace checks that a metric namespace is empty or a valid
+// Prometheus metric name. Returns an error wrapping ErrInvalidNamespace
+// otherwise.
+func ValidateNamespace(namespace string) error {
+	if namespace != "" && !metricName.MatchString(namespace) {
+		return fmt.Errorf("%w: %q", ErrInvalidNamespace, namespace)
+	}
+	return nil
+}
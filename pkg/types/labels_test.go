@@ -0,0 +1,30 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateLabels(t *testing.T) {
+	if err := ValidateLabels(map[string]string{"service": "api", "_shard": "1", "region2": ""}); err != nil {
+		t.Errorf("ValidateLabels() error: %v", err)
+	}
+	for _, name := range []string{"", "service-name", "2region", "__name__", "a.b"} {
+		if err := ValidateLabels(map[string]string{name: "x"}); !errors.Is(err, ErrInvalidLabel) {
+			t.Errorf("ValidateLabels(%q) = %v, want ErrInvalidLabel", name, err)
+		}
+	}
+}
+
+func TestValidateNamespace(t *testing.T) {
+	for _, ns := range []string{"", "myapp", "my_app:v2", "_internal"} {
+		if err := ValidateNamespace(ns); err != nil {
+			t.Errorf("ValidateNamespace(%q) error: %v", ns, err)
+		}
+	}
+	for _, ns := range []string{"my-app", "2app", "a.b", "app "} {
+		if err := ValidateNamespace(ns); !errors.Is(err, ErrInvalidNamespace) {
+			t.Errorf("ValidateNamespace(%q) = %v, want ErrInvalidNamespace", ns, err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package types
+
+// Logger receives diagnostics about internal problems that would otherwise
+// go unnoticed, such as panicking callbacks, failed samples, missed GC
+// cycles and undelivered alerts. Arguments after the message alternate keys
+// and values. *slog.Logger implements it; use ZapLogger for zap.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// NopLogger returns a Logger that discards everything, the default
+func NopLogger() Logger {
+	return nopLogger{}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Info(string, ...any)  {}
+func (nopLogger) Warn(string, ...any)  {}
+func (nopLogger) Error(string, ...any) {}
+
+// SugaredLogger is the structured logging subset of *zap.SugaredLogger
+type SugaredLogger interface {
+	Debugw(msg string, keysAndValues ...any)
+	Infow(msg string, keysAndValues ...any)
+	Warnw(msg string, keysAndValues ...any)
+	Errorw(msg string, keysAndValues ...any)
+}
+
+// ZapLogger adapts a *zap.SugaredLogger, e.g. zap.L().Sugar(), to Logger
+// without this module depending on zap
+func ZapLogger(s SugaredLogger) Logger {
+	return zapLogger{s}
+}
+
+type zapLogger struct{ s SugaredLogger }
+
+func (z zapLogger) Debug(msg string, args ...any) { z.s.Debugw(msg, args...) }
+func (z zapLogger) Info(msg string, args ...any)  { z.s.Infow(msg, args...) }
+func (z zapLogger) Warn(msg string, args ...any)  { z.s.Warnw(msg, args...) }
+func (z zapLogger) Error(msg string, args ...any) { z.s.Errorw(msg, args...) }
@@ -1,7 +1,9 @@
 package types
 
 import (
+	"math"
 	"runtime"
+	"slices"
 	"sync"
 	"time"
 )
@@ -24,11 +26,16 @@ var pauseSlicePool = sync.Pool{
 type GCMetrics struct {
 	// Basic GC stats
 	NumGC        uint32    `json:"num_gc"`
+	NumForcedGC  uint32    `json:"num_forced_gc"` // GCs forced by runtime.GC or debug.FreeOSMemory
 	PauseTotalNs uint64    `json:"pause_total_ns"`
 	PauseNs      []uint64  `json:"pause_ns"`
 	PauseEnd     []uint64  `json:"pause_end"`
 	LastGC       time.Time `json:"last_gc"`
 
+	// PauseHistogram is the cumulative distribution of stop-the-world pauses
+	// from runtime/metrics, complete even when PauseNs has wrapped
+	PauseHistogram *PauseHistogram `json:"pause_histogram,omitempty"`
+
 	// Memory stats
 	Alloc      uint64 `json:"alloc"`
 	TotalAlloc uint64 `json:"total_alloc"`
@@ -45,17 +52,84 @@ type GCMetrics struct {
 	HeapReleased uint64 `json:"heap_released"`
 	HeapObjects  uint64 `json:"heap_objects"`
 
+	// HeapLive is the heap marked live by the last completed GC cycle, from
+	// runtime/metrics. Unlike HeapAlloc it doesn't include garbage allocated
+	// since, so it only changes at GC boundaries.
+	HeapLive uint64 `json:"heap_live,omitempty"`
+
 	// Stack stats
 	StackInuse uint64 `json:"stack_inuse"`
 	StackSys   uint64 `json:"stack_sys"`
 
+	// Runtime-internal memory obtained from the OS; with HeapSys and StackSys
+	// these make up Sys
+	MSpanSys    uint64 `json:"mspan_sys"`
+	MCacheSys   uint64 `json:"mcache_sys"`
+	BuckHashSys uint64 `json:"buck_hash_sys"`
+	GCSys       uint64 `json:"gc_sys"`
+	OtherSys    uint64 `json:"other_sys"`
+
 	// GC performance metrics
 	NextGC        uint64  `json:"next_gc"`
-	GCCPUFraction float64 `json:"gc_cpu_fraction"`
+	GCCPUFraction float64 `json:"gc_cpu_fraction"` // since the process started
+
+	// GC CPU breakdown from runtime/metrics (cumulative CPU seconds)
+	GCMarkAssistCPU    float64 `json:"gc_mark_assist_cpu,omitempty"`    // mutator goroutines drafted into marking
+	GCMarkDedicatedCPU float64 `json:"gc_mark_dedicated_cpu,omitempty"` // dedicated and fractional background workers
+	GCMarkIdleCPU      float64 `json:"gc_mark_idle_cpu,omitempty"`      // marking on otherwise idle Ps
+	GCPauseCPU         float64 `json:"gc_pause_cpu,omitempty"`          // stop-the-world pauses
+	GCTotalCPU         float64 `json:"gc_total_cpu,omitempty"`
+	TotalCPU           float64 `json:"total_cpu,omitempty"` // all CPU available to the process
+
+	// GC cycle counters from runtime/metrics
+	GCCyclesAutomatic uint64 `json:"gc_cycles_automatic,omitempty"`
+	GCCyclesForced    uint64 `json:"gc_cycles_forced,omitempty"`
+
+	// GC tuning in effect when the sample was taken, from runtime/metrics, so
+	// changes made with debug.SetGCPercent or debug.SetMemoryLimit during a
+	// run show up. GOGC is -1 when GC is off; GOMemLimit is math.MaxInt64
+	// when no limit is set. Both are zero when unknown.
+	GOGC       int    `json:"gogc,omitempty"`
+	GOMemLimit uint64 `json:"gomemlimit,omitempty"`
+
+	// ProcessCPUSeconds is the OS-reported CPU time (user + system) of the
+	// process since start. Only sampled when process CPU sampling is enabled.
+	ProcessCPUSeconds float64 `json:"process_cpu_seconds,omitempty"`
+
+	// ProcessRSS is the OS-reported resident set size of the process in
+	// bytes. Only sampled when process memory sampling is enabled.
+	ProcessRSS uint64 `json:"process_rss,omitempty"`
+
+	// MajorPageFaults counts the page faults of the process that had to read
+	// from disk or swap since start, and SwapUsed is the bytes of the process
+	// swapped out. Only sampled when paging sampling is enabled, which sets
+	// PagingSampled if the platform provided either, so that a host that
+	// never paged is told apart from one that was not sampled.
+	MajorPageFaults uint64 `json:"major_page_faults,omitempty"`
+	SwapUsed        uint64 `json:"swap_used,omitempty"`
+	PagingSampled   bool   `json:"paging_sampled,omitempty"`
+
+	// CgroupMemoryLimit is the memory limit of the container (cgroup) the
+	// process runs in, in bytes. Zero when there is none or it is unknown.
+	CgroupMemoryLimit uint64 `json:"cgroup_memory_limit,omitempty"`
+
+	// PauseQuantiles holds debug.ReadGCStats pause quantiles. Only sampled
+	// when pause quantile collection is enabled.
+	PauseQuantiles *PauseQuantiles `json:"pause_quantiles,omitempty"`
 
 	// Collection timestamp
 	Timestamp time.Time `json:"timestamp"`
 
+	// Synthetic marks samples injected for testing (e.g. by chaos mode)
+	Synthetic bool `json:"synthetic,omitempty"`
+
+	// Region is the application phase label active when the sample was taken
+	Region string `json:"region,omitempty"`
+
+	// CompactedSamples is the number of samples averaged into this one by a
+	// retention policy; zero for samples as collected
+	CompactedSamples int `json:"compacted_samples,omitempty"`
+
 	// pooled indicates whether this metrics uses pooled slices
 	pooled bool
 
@@ -74,6 +148,8 @@ type GCAnalysis struct {
 	// GC frequency analysis
 	GCFrequency   float64       `json:"gc_frequency"` // GCs per second
 	AvgGCInterval time.Duration `json:"avg_gc_interval"`
+	ForcedGCCount uint32        `json:"forced_gc_count"` // GCs forced by application code
+	ForcedGCRatio float64       `json:"forced_gc_ratio"` // forced GCs / all GCs, 0-1
 
 	// Pause time analysis
 	AvgPauseTime time.Duration `json:"avg_pause_time"`
@@ -82,35 +158,695 @@ type GCAnalysis struct {
 	P95PauseTime time.Duration `json:"p95_pause_time"`
 	P99PauseTime time.Duration `json:"p99_pause_time"`
 
+	// IntervalMaxAvgPause is the highest average pause per GC over a sample
+	// interval, from PauseTotalNs deltas. Set only when individual pauses are
+	// unavailable, as a lower bound on the longest pause.
+	IntervalMaxAvgPause time.Duration `json:"interval_max_avg_pause,omitempty"`
+
+	// Unavailable lists the JSON names of fields the data could not provide,
+	// e.g. pause percentiles from lite samples without events. Their zero
+	// values mean "unknown", not zero.
+	Unavailable []string `json:"unavailable,omitempty"`
+
+	// PauseDistribution covers every stop-the-world pause in the window, from
+	// the runtime's pause histogram, when samples carry it
+	PauseDistribution *PauseDistribution `json:"pause_distribution,omitempty"`
+
+	// PauseApdex scores pauses against a target, when events are available
+	PauseApdex *PauseApdex `json:"pause_apdex,omitempty"`
+
+	// MMU is the minimum mutator utilization curve, when events are available
+	MMU []MMUPoint `json:"mmu,omitempty"`
+
+	// PauseOutliers lists the longest pauses with the conditions around them,
+	// when events are available
+	PauseOutliers *PauseOutliers `json:"pause_outliers,omitempty"`
+
+	// MissedEvents counts GC cycles recorded only as gap markers, because more
+	// completed between two samples than the runtime's pause buffer holds
+	MissedEvents uint32 `json:"missed_events,omitempty"`
+
+	// PauseQuantiles are the process's pause quantiles as of the last sample,
+	// when samples carry them
+	PauseQuantiles *PauseQuantiles `json:"pause_quantiles,omitempty"`
+
 	// Memory analysis
 	AvgHeapSize    uint64  `json:"avg_heap_size"`
 	MaxHeapSize    uint64  `json:"max_heap_size"`
 	MinHeapSize    uint64  `json:"min_heap_size"`
 	HeapGrowthRate float64 `json:"heap_growth_rate"` // bytes per second
 
+	// Robust holds median-based pause and heap statistics, when requested
+	Robust *RobustStats `json:"robust,omitempty"`
+
+	// Live heap analysis, when samples carry HeapLive
+	AvgLiveHeap        uint64  `json:"avg_live_heap,omitempty"`
+	LiveHeapGrowthRate float64 `json:"live_heap_growth_rate,omitempty"` // bytes per second
+
 	// Allocation analysis
 	AllocRate  float64 `json:"alloc_rate"`  // bytes per second
 	AllocCount uint64  `json:"alloc_count"` // total allocations
 	FreeCount  uint64  `json:"free_count"`  // total frees
 
 	// Efficiency metrics
-	GCOverhead       float64 `json:"gc_overhead"`       // percentage of CPU time spent in GC
+	GCOverhead       float64 `json:"gc_overhead"`       // percentage of CPU time spent in GC over the period
 	MemoryEfficiency float64 `json:"memory_efficiency"` // ratio of heap in use to heap allocated
 
-	// Recommendations
+	// Recommendations, ordered by severity (most severe first)
 	Recommendations []string `json:"recommendations"`
+
+	// RecommendationDetails holds the same recommendations with their severity
+	RecommendationDetails []Recommendation `json:"recommendation_details,omitempty"`
+
+	// Warnings describe data quality problems that make the numbers above
+	// less trustworthy, such as too few samples or missing pause data
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Runtime records the runtime configuration the data was captured under, when known
+	Runtime *RuntimeInfo `json:"runtime,omitempty"`
+
+	// Labels identify the monitored process, e.g. its service and version
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// TuningChanges lists GOGC and GOMEMLIMIT changes made during the window,
+	// when samples record them
+	TuningChanges []TuningChange `json:"tuning_changes,omitempty"`
+
+	// CounterResets lists the samples where cumulative counters dropped
+	// because the process restarted. Counters after a reset are rebased onto
+	// those before it, so rates span the whole window.
+	CounterResets []CounterReset `json:"counter_resets,omitempty"`
+
+	// LeakDetection holds the regression over the post-GC heap floor
+	LeakDetection *LeakAnalysis `json:"leak_detection,omitempty"`
+
+	// Reclamation tracks the bytes each GC cycle reclaimed, when events carry
+	// the heap before and after the cycle
+	Reclamation *ReclamationAnalysis `json:"reclamation,omitempty"`
+
+	// Periodicity describes a recurring heap pattern such as a scheduled batch job
+	Periodicity *PeriodicityAnalysis `json:"periodicity,omitempty"`
+
+	// Phases is set when events carry phase data (e.g. from a gctrace log)
+	Phases *PhaseBreakdown `json:"phases,omitempty"`
+
+	// GCCPU splits GC CPU time into background marking, mark assists and pauses.
+	// Nil when the samples carry no runtime/metrics CPU data.
+	GCCPU *GCCPUBreakdown `json:"gc_cpu,omitempty"`
+
+	// ProcessCPU relates GC CPU time to the process's OS-reported CPU usage.
+	// Nil unless the samples carry process CPU time.
+	ProcessCPU *ProcessCPUAnalysis `json:"process_cpu,omitempty"`
+
+	// RSS compares the process's resident memory with Go-managed memory.
+	// Nil unless the samples carry RSS.
+	RSS *RSSAnalysis `json:"rss,omitempty"`
+
+	// Regions holds allocations attributed to tagged code regions, when tracked
+	Regions []RegionStats `json:"regions,omitempty"`
+
+	// RegionBreakdown splits the analysis by region label, when samples carry one
+	RegionBreakdown []RegionBreakdown `json:"region_breakdown,omitempty"`
+
+	// RequestImpact holds the GC impact of individual requests, when recorded
+	RequestImpact *RequestImpact `json:"request_impact,omitempty"`
+
+	// SizeClasses is the sampled allocation profile grouped by size class, when provided
+	SizeClasses *SizeClassDistribution `json:"size_classes,omitempty"`
+
+	// AllocSites ranks the code locations allocating the most, when provided
+	AllocSites *AllocSiteReport `json:"alloc_sites,omitempty"`
+
+	// MemoryLimit is the container memory limit reported by the latest
+	// sample, and MemoryLimitUsage the share of it in use at that time (0-1)
+	MemoryLimit      uint64  `json:"memory_limit,omitempty"`
+	MemoryLimitUsage float64 `json:"memory_limit_usage,omitempty"`
+
+	// OOMForecast is set when a memory limit is known (see Analyzer.ForecastOOM)
+	OOMForecast *OOMForecast `json:"oom_forecast,omitempty"`
+
+	// SoftLimit describes how close memory usage ran to GOMEMLIMIT, when a
+	// limit is set
+	SoftLimit *SoftLimitAnalysis `json:"soft_limit,omitempty"`
+
+	// Paging relates GC pauses to OS paging. Nil unless paging was sampled
+	// (or the samples carry major page faults or swap usage).
+	Paging *PagingAnalysis `json:"paging,omitempty"`
+}
+
+// IsUnavailable reports whether the field with the given JSON name, such as
+// "p99_pause_time", could not be computed from the data
+func (a *GCAnalysis) IsUnavailable(field string) bool {
+	return slices.Contains(a.Unavailable, field)
+}
+
+// GCCPUBreakdown describes where GC CPU time went over the analysis window.
+// Shares are fractions of total GC CPU time (0-1).
+type GCCPUBreakdown struct {
+	TotalSeconds    float64 `json:"total_seconds"`    // GC CPU seconds over the window
+	AssistShare     float64 `json:"assist_share"`     // mark assists performed by mutator goroutines
+	BackgroundShare float64 `json:"background_share"` // dedicated, fractional and idle mark workers
+	PauseShare      float64 `json:"pause_share"`      // stop-the-world pauses
+	AutomaticCycles uint64  `json:"automatic_cycles"`
+	ForcedCycles    uint64  `json:"forced_cycles"`
+}
+
+// ProcessCPUAnalysis relates GC CPU time to the CPU the process actually used
+type ProcessCPUAnalysis struct {
+	ProcessSeconds float64 `json:"process_seconds"`     // OS-reported CPU time over the window
+	GCSeconds      float64 `json:"gc_seconds"`          // GC CPU time over the window (runtime estimate)
+	GCShare        float64 `json:"gc_share"`            // GCSeconds / ProcessSeconds, 0-1
+	Utilization    float64 `json:"utilization"`         // ProcessSeconds / CPU available to GOMAXPROCS or the container, typically 0-1
+	Saturated      bool    `json:"saturated"`           // Utilization above ThresholdCPUSaturation
+	CPULimit       float64 `json:"cpu_limit,omitempty"` // container CPU limit in cores, when it bounds Utilization
+}
+
+// SysBreakdown splits the memory the Go runtime obtained from the OS (Sys)
+type SysBreakdown struct {
+	Heap     uint64 `json:"heap"`
+	Stack    uint64 `json:"stack"`
+	MSpan    uint64 `json:"mspan"`
+	MCache   uint64 `json:"mcache"`
+	BuckHash uint64 `json:"buck_hash"` // profiling bucket hash table
+	GC       uint64 `json:"gc"`        // GC metadata
+	Other    uint64 `json:"other"`
+	Released uint64 `json:"released"` // heap returned to the OS, included in Heap but not resident
+}
+
+// RSSAnalysis compares the process's resident set size with the memory the Go
+// runtime manages. Memory outside it (cgo, mmap, non-Go threads) cannot be
+// reclaimed by tuning the GC.
+type RSSAnalysis struct {
+	AvgRSS       uint64       `json:"avg_rss"`
+	MaxRSS       uint64       `json:"max_rss"`
+	AvgGoManaged uint64       `json:"avg_go_managed"` // Sys - HeapReleased
+	AvgNonGo     uint64       `json:"avg_non_go"`     // RSS beyond Go-managed memory, zero when RSS is lower
+	NonGoShare   float64      `json:"non_go_share"`   // AvgNonGo / AvgRSS, 0-1
+	NonGoGrowth  float64      `json:"non_go_growth"`  // bytes per second (fitted slope)
+	RSquared     float64      `json:"r_squared"`      // goodness of fit of the growth, 0-1
+	NonGoGrowing bool         `json:"non_go_growing"` // unexplained non-Go growth detected
+	Sys          SysBreakdown `json:"sys"`            // from the last sample
+}
+
+// Severity classifies how urgent a recommendation or alert is
+type Severity string
+
+// Severity levels, from least to most urgent
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Rank returns a sortable rank for the severity (higher is more urgent)
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityCritical:
+		return 2
+	case SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ClassifySeverity classifies an observed value that exceeds threshold by how
+// far past the threshold it is. For metrics where lower is worse, pass the
+// threshold as observed and the observed value as threshold.
+func ClassifySeverity(observed, threshold float64) Severity {
+	if threshold <= 0 {
+		return SeverityWarning
+	}
+
+	ratio := observed / threshold
+	switch {
+	case ratio >= SeverityCriticalRatio:
+		return SeverityCritical
+	case ratio >= SeverityWarningRatio:
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
+
+// RecommendationCode identifies the kind of a recommendation. Codes are stable
+// across releases so automation can act on specific findings.
+type RecommendationCode string
+
+// Recommendation codes
+const (
+	CodeHighGCFrequency         RecommendationCode = "GC001"
+	CodeFrequentForcedGC        RecommendationCode = "GC002"
+	CodeLongPause               RecommendationCode = "GC003"
+	CodeVeryLongP99Pause        RecommendationCode = "GC004"
+	CodeHighHeapGrowth          RecommendationCode = "GC005"
+	CodeHighGCOverhead          RecommendationCode = "GC006"
+	CodeCPUSaturatedGC          RecommendationCode = "GC007"
+	CodeLowMemoryEfficiency     RecommendationCode = "GC008"
+	CodeHighMarkAssist          RecommendationCode = "GC009"
+	CodeHighAllocationRate      RecommendationCode = "GC010"
+	CodePoolSmallObjects        RecommendationCode = "GC011"
+	CodeReuseLargeBuffers       RecommendationCode = "GC012"
+	CodeNonGoMemoryGrowth       RecommendationCode = "GC013"
+	CodeNearMemoryLimit         RecommendationCode = "GC014"
+	CodeSetGOMemLimit           RecommendationCode = "GC015"
+	CodeGOMAXPROCSAboveCPULimit RecommendationCode = "GC016"
+	CodeConsistentGrowth        RecommendationCode = "GC017"
+	CodeDecliningReclamation    RecommendationCode = "GC018"
+	CodeSoftLimitPressure       RecommendationCode = "GC019"
+	CodeGCDeathSpiral           RecommendationCode = "GC020"
+	CodeHostPaging              RecommendationCode = "GC021"
+)
+
+// Recommendation is a single performance recommendation. Message is the full
+// sentence shown in reports and in GCAnalysis.Recommendations; the other
+// fields break it down for automation.
+type Recommendation struct {
+	Code            RecommendationCode `json:"code,omitempty"`
+	Severity        Severity           `json:"severity"`
+	Title           string             `json:"title,omitempty"`            // e.g. "High GC frequency"
+	Detail          string             `json:"detail,omitempty"`           // what was found and why it matters
+	SuggestedAction string             `json:"suggested_action,omitempty"` // what to change
+	Evidence        string             `json:"evidence,omitempty"`         // the observed values behind the finding
+	Message         string             `json:"message"`
+}
+
+// String returns the recommendation as "GC001: High GC frequency", or the
+// message when it has no code
+func (r Recommendation) String() string {
+	if r.Code == "" {
+		return r.Message
+	}
+	return string(r.Code) + ": " + r.Title
+}
+
+// Confidence expresses how much statistical backing a finding has
+type Confidence string
+
+// Confidence levels
+const (
+	ConfidenceLow    Confidence = "low"
+	ConfidenceMedium Confidence = "medium"
+	ConfidenceHigh   Confidence = "high"
+)
+
+// Leak analysis data sources
+const (
+	LeakSourceLiveHeap   = "live_heap"   // the live heap marked by each GC cycle
+	LeakSourcePostGC     = "post_gc"     // samples taken right after GC cycles
+	LeakSourceAllSamples = "all_samples" // fallback when too few GC cycles were observed
+)
+
+// LeakAnalysis holds the result of a linear regression over heap usage after GC
+type LeakAnalysis struct {
+	Slope          float64    `json:"slope"`           // bytes per second
+	RSquared       float64    `json:"r_squared"`       // goodness of fit, 0-1
+	RelativeGrowth float64    `json:"relative_growth"` // fitted growth over the window as a fraction of the starting floor
+	Samples        int        `json:"samples"`
+	Source         string     `json:"source"`
+	Confidence     Confidence `json:"confidence"`
+	Suspected      bool       `json:"suspected"`
+
+	// Periodic is set when the heap follows a periodic pattern. CycleGrowth then
+	// holds the growth of the lowest floor between the first and last full period,
+	// which separates a real leak from a window that ends mid-cycle.
+	Periodic    bool    `json:"periodic,omitempty"`
+	CycleGrowth float64 `json:"cycle_growth,omitempty"`
+}
+
+// Evidence returns the quantitative backing for a leak finding,
+// e.g. "floor +1.2 MB/s, R²=0.93, confidence high"
+func (l *LeakAnalysis) Evidence() string {
+	s := "floor +" + FormatBytesRate(l.Slope) +
+		", R²=" + formatFloat(l.RSquared, 2) +
+		", confidence " + string(l.Confidence)
+	if l.Periodic {
+		s += ", per-period floor +" + formatFloat(l.CycleGrowth*100, 1) + "%"
+	}
+	return s
+}
+
+// ReclamationAnalysis describes how much each GC cycle reclaimed over the
+// analysis window. Trends come from linear regressions over the cycles in
+// sequence order.
+type ReclamationAnalysis struct {
+	Cycles           int     `json:"cycles"`             // cycles with heap before and live heap
+	AvgReclaimed     uint64  `json:"avg_reclaimed"`      // bytes reclaimed per cycle
+	AvgSurvivalRatio float64 `json:"avg_survival_ratio"` // live heap / heap before, 0-1
+
+	// ReclaimedTrend is the fitted change in reclaimed bytes per cycle over
+	// the window as a fraction of the first fitted value, negative when
+	// cycles reclaim less. RSquared is the goodness of that fit.
+	ReclaimedTrend float64 `json:"reclaimed_trend"`
+	RSquared       float64 `json:"r_squared"`
+
+	// SurvivalTrend is the fitted change in the survival ratio over the window
+	SurvivalTrend float64 `json:"survival_trend"`
+
+	// Declining is set when cycles reclaim progressively less while more of
+	// the heap survives them, the signature of a growing live set
+	Declining bool `json:"declining"`
+}
+
+// Evidence returns the quantitative backing for a reclamation finding,
+// e.g. "reclaimed per cycle -40.0%, survival ratio +25.0 pts, R²=0.91, 30 cycles"
+func (r *ReclamationAnalysis) Evidence() string {
+	return "reclaimed per cycle " + formatSigned(r.ReclaimedTrend*100) + "%" +
+		", survival ratio " + formatSigned(r.SurvivalTrend*100) + " pts" +
+		", R²=" + formatFloat(r.RSquared, 2) +
+		", " + formatFloat(float64(r.Cycles), 0) + " cycles"
+}
+
+// SoftLimitAnalysis describes memory pressure against the soft memory limit
+// (GOMEMLIMIT). Near the limit the GC runs more often to stay below it; when
+// the live heap alone approaches the limit, cycles run back to back and GC
+// takes up to half the CPU (a "death spiral").
+type SoftLimitAnalysis struct {
+	Limit     uint64  `json:"limit"`      // GOMEMLIMIT at the end of the window
+	PeakUsage uint64  `json:"peak_usage"` // Go-managed memory (Sys - HeapReleased)
+	PeakRatio float64 `json:"peak_ratio"` // peak usage / limit
+
+	// NearLimitShare is the share of the window with usage above
+	// ThresholdSoftLimitNear of the limit (0-1)
+	NearLimitShare float64 `json:"near_limit_share"`
+
+	// GC frequency near the limit and below it, in GCs per second
+	GCFrequencyNear  float64 `json:"gc_frequency_near"`
+	GCFrequencyBelow float64 `json:"gc_frequency_below"`
+
+	// GCCPUNear is GC's share of CPU time near the limit (0-1), zero when
+	// samples carry no runtime/metrics CPU data
+	GCCPUNear float64 `json:"gc_cpu_near,omitempty"`
+
+	// Pressured is set when usage stays near the limit long enough to drive
+	// extra GC cycles; DeathSpiral when cycles run back to back there
+	Pressured   bool `json:"pressured"`
+	DeathSpiral bool `json:"death_spiral"`
+
+	// SuggestedLimit is a GOMEMLIMIT leaving headroom above the peak usage,
+	// capped below the container limit. Zero when no higher limit fits.
+	SuggestedLimit uint64 `json:"suggested_limit,omitempty"`
+}
+
+// Evidence returns the quantitative backing for a soft limit finding, e.g.
+// "near GOMEMLIMIT 512.0 MB 40.0% of the time, peak 98.0%, GC 12.0/s near
+// vs 1.0/s below, GC CPU 45.0% near the limit"
+func (s *SoftLimitAnalysis) Evidence() string {
+	e := "near GOMEMLIMIT " + FormatBytes(s.Limit) + " " + formatFloat(s.NearLimitShare*100, 1) + "% of the time" +
+		", peak " + formatFloat(s.PeakRatio*100, 1) + "%" +
+		", GC " + formatFloat(s.GCFrequencyNear, 1) + "/s near vs " + formatFloat(s.GCFrequencyBelow, 1) + "/s below"
+	if s.GCCPUNear > 0 {
+		e += ", GC CPU " + formatFloat(s.GCCPUNear*100, 1) + "% near the limit"
+	}
+	if s.SuggestedLimit > 0 {
+		e += ", suggested GOMEMLIMIT " + FormatBytes(s.SuggestedLimit)
+	}
+	return e
+}
+
+// PagingAnalysis relates GC pauses to OS paging. A process whose pages are
+// swapped out stalls on major page faults, and pauses stretch with it; GC
+// tuning cannot fix that, the host needs more memory.
+type PagingAnalysis struct {
+	MajorFaultRate float64 `json:"major_fault_rate"`    // major page faults per second over the window
+	PeakSwap       uint64  `json:"peak_swap,omitempty"` // bytes of the process swapped out
+	PagingShare    float64 `json:"paging_share"`        // share of the window spent paging (0-1)
+
+	// Average pause per GC over intervals spent paging and the others
+	AvgPausePaging time.Duration `json:"avg_pause_paging"`
+	AvgPauseOther  time.Duration `json:"avg_pause_other"`
+
+	// SlowIntervals counts sample intervals whose pause per GC is
+	// PagingSlowPauseFactor times the window average or more, and
+	// SlowWhilePaging those of them spent paging
+	SlowIntervals   int `json:"slow_intervals"`
+	SlowWhilePaging int `json:"slow_while_paging"`
+
+	// Coincident is set when most slow intervals were spent paging
+	Coincident bool `json:"coincident"`
+}
+
+// Evidence returns the quantitative backing for a paging finding, e.g.
+// "3 of 4 slow intervals while paging, pause per GC 12ms paging vs 1ms
+// otherwise, 25.0 major faults/s, swap 64.0 MB"
+func (p *PagingAnalysis) Evidence() string {
+	e := formatFloat(float64(p.SlowWhilePaging), 0) + " of " + formatFloat(float64(p.SlowIntervals), 0) + " slow intervals while paging" +
+		", pause per GC " + p.AvgPausePaging.Round(time.Microsecond).String() + " paging vs " +
+		p.AvgPauseOther.Round(time.Microsecond).String() + " otherwise" +
+		", " + formatFloat(p.MajorFaultRate, 1) + " major faults/s"
+	if p.PeakSwap > 0 {
+		e += ", swap " + FormatBytes(p.PeakSwap)
+	}
+	return e
+}
+
+// PeriodicityAnalysis holds the result of autocorrelation over heap usage
+type PeriodicityAnalysis struct {
+	Detected bool          `json:"detected"`
+	Period   time.Duration `json:"period"`   // dominant period, zero when not detected
+	Strength float64       `json:"strength"` // autocorrelation at the period, 0-1
+	Samples  int           `json:"samples"`
+}
+
+// Summary returns a short description such as
+// "periodic workload detected, period ≈ 10m"
+func (p *PeriodicityAnalysis) Summary() string {
+	if p == nil || !p.Detected {
+		return "no periodic workload detected"
+	}
+	return "periodic workload detected, period ≈ " + FormatShortDuration(p.Period)
+}
+
+// Changepoint metric names
+const (
+	ChangepointAllocRate = "alloc_rate" // bytes allocated per second
+	ChangepointAvgPause  = "avg_pause"  // average pause per GC cycle, in nanoseconds
+)
+
+// Changepoint marks a sample where a metric's level shifted significantly
+type Changepoint struct {
+	Metric    string    `json:"metric"`
+	Index     int       `json:"index"` // index of the first sample after the shift
+	Timestamp time.Time `json:"timestamp"`
+	Before    float64   `json:"before"` // mean of the segment before the shift
+	After     float64   `json:"after"`  // mean of the segment after the shift
+	Change    float64   `json:"change"` // (After-Before)/Before, zero when Before is zero
+}
+
+// TuningChange marks a sample where GOGC or GOMEMLIMIT changed, e.g. through
+// debug.SetGCPercent or debug.SetMemoryLimit
+type TuningChange struct {
+	Setting   string    `json:"setting"` // "GOGC" or "GOMEMLIMIT"
+	Index     int       `json:"index"`   // index of the first sample with the new value
+	Timestamp time.Time `json:"timestamp"`
+	Before    string    `json:"before"`
+	After     string    `json:"after"`
+}
+
+// String returns a description such as "GOGC: 100 → 200"
+func (c TuningChange) String() string {
+	return c.Setting + ": " + c.Before + " → " + c.After
+}
+
+// CounterReset marks a sample where cumulative counters such as NumGC and
+// TotalAlloc started over, i.e. the process restarted since the previous one
+type CounterReset struct {
+	Index     int           `json:"index"` // index of the first sample after the restart
+	Timestamp time.Time     `json:"timestamp"`
+	Gap       time.Duration `json:"gap"` // time since the previous sample, during which the restart happened
 }
 
-// GCEvent represents a single garbage collection event
+// String returns a description such as "counters reset, 30s since the
+// previous sample"
+func (r CounterReset) String() string {
+	return "counters reset, " + r.Gap.String() + " since the previous sample"
+}
+
+// OOMForecast represents a projection of when memory usage will reach a limit
+type OOMForecast struct {
+	Limit        uint64        `json:"limit"`
+	CurrentUsage uint64        `json:"current_usage"`
+	GrowthRate   float64       `json:"growth_rate"`   // bytes per second (fitted slope)
+	RSquared     float64       `json:"r_squared"`     // goodness of fit, 0-1
+	TimeToLimit  time.Duration `json:"time_to_limit"` // zero when not growing or already exceeded
+	WillExceed   bool          `json:"will_exceed"`
+	Exceeded     bool          `json:"exceeded"`
+	EstimatedAt  time.Time     `json:"estimated_at"`
+}
+
+// Summary returns a short human-readable description of the forecast,
+// e.g. "estimated OOM in ~42 minutes".
+func (f *OOMForecast) Summary() string {
+	switch {
+	case f == nil || !f.WillExceed:
+		return "no OOM expected at current growth rate"
+	case f.Exceeded:
+		return "memory limit already reached"
+	default:
+		return "estimated OOM in " + FormatApproxDuration(f.TimeToLimit)
+	}
+}
+
+// GCEvent represents a single garbage collection event.
+//
+// Runtime events estimate the heap fields from the samples around the
+// cycle: HeapBefore from the heap goal it was triggered against, and
+// HeapAfter and HeapLive from the live heap it left. gctrace reports them
+// exactly.
 type GCEvent struct {
 	Sequence      uint32        `json:"sequence"`
 	StartTime     time.Time     `json:"start_time"`
 	EndTime       time.Time     `json:"end_time"`
 	Duration      time.Duration `json:"duration"`
-	HeapBefore    uint64        `json:"heap_before"`
-	HeapAfter     uint64        `json:"heap_after"`
-	HeapReleased  uint64        `json:"heap_released"`
+	HeapBefore    uint64        `json:"heap_before"`         // heap when the cycle started
+	HeapAfter     uint64        `json:"heap_after"`          // heap when the cycle ended
+	HeapReleased  uint64        `json:"heap_released"`       // bytes the cycle reclaimed: HeapBefore - HeapLive
+	HeapLive      uint64        `json:"heap_live,omitempty"` // heap the cycle marked live
+	HeapGoal      uint64        `json:"heap_goal,omitempty"` // heap goal, from gctrace
 	TriggerReason string        `json:"trigger_reason"`
+	Source        string        `json:"source,omitempty"`
+	Phases        *GCPhases     `json:"phases,omitempty"` // wall-clock phase durations, from gctrace
+	Region        string        `json:"region,omitempty"` // application phase label active when recorded
+
+	// Missed is set on gap markers: the number of cycles, starting at
+	// Sequence, that completed without their pause data being recorded
+	Missed uint32 `json:"missed,omitempty"`
+}
+
+// IsGap reports whether the event is a marker for missed cycles rather than
+// a recorded GC cycle
+func (e *GCEvent) IsGap() bool {
+	return e.Missed > 0
+}
+
+// PauseBufferSize is the number of recent pauses the runtime keeps in
+// MemStats.PauseNs and PauseEnd; older entries are overwritten
+const PauseBufferSize = 256
+
+// GC event sources
+const (
+	EventSourceRuntime   = "runtime"   // derived from in-process runtime samples
+	EventSourceGCTrace   = "gctrace"   // parsed from GODEBUG=gctrace=1 output
+	EventSourceMerged    = "merged"    // in-process event enriched with gctrace data
+	EventSourceSynthetic = "synthetic" // injected for testing (e.g. by chaos mode)
+)
+
+// MaxGCTraceSize bounds the gctrace output read by ParseGCTrace, about a
+// million GC cycles
+const MaxGCTraceSize = 256 << 20
+
+// GC trigger reasons
+const (
+	TriggerHeapSize  = "heap_size" // the heap reached the goal set by GOGC/GOMEMLIMIT
+	TriggerPeriodic  = "periodic"  // no GC for the runtime's 2 minute period
+	TriggerForced    = "forced"    // runtime.GC or debug.FreeOSMemory
+	TriggerAutomatic = "automatic" // started by the runtime, reason not known
+	TriggerUnknown   = "unknown"   // forced and automatic cycles completed in the same sample
+)
+
+// GCPhases holds the wall-clock duration of each phase of a GC cycle.
+// The runtime does not expose per-cycle phases through MemStats or
+// runtime/metrics, so they are only available from gctrace logs.
+type GCPhases struct {
+	SweepTermination time.Duration `json:"sweep_termination"` // stop-the-world
+	ConcurrentMark   time.Duration `json:"concurrent_mark"`
+	MarkTermination  time.Duration `json:"mark_termination"` // stop-the-world
+}
+
+// STW returns the time the program was stopped during the cycle
+func (p *GCPhases) STW() time.Duration {
+	return p.SweepTermination + p.MarkTermination
+}
+
+// Total returns the wall-clock duration of the cycle
+func (p *GCPhases) Total() time.Duration {
+	return p.SweepTermination + p.ConcurrentMark + p.MarkTermination
+}
+
+// PauseDistribution summarizes individual stop-the-world pauses from the
+// runtime's pause histogram. Durations are bucket upper bounds, so they may
+// overstate a pause slightly but never understate it.
+type PauseDistribution struct {
+	Pauses uint64        `json:"pauses"` // typically two per GC cycle
+	P50    time.Duration `json:"p50"`
+	P95    time.Duration `json:"p95"`
+	P99    time.Duration `json:"p99"`
+	Max    time.Duration `json:"max"`
+
+	// EventsIncomplete is set when GC events missed cycles in the window, in
+	// which case the pause percentiles and maximum account for this distribution
+	EventsIncomplete bool `json:"events_incomplete,omitempty"`
+}
+
+// PauseApdex is an Apdex score over GC pauses: pauses up to Target satisfy,
+// pauses up to ApdexToleratingFactor × Target are tolerated and longer ones
+// frustrate. Score is (satisfied + tolerating/2) / pauses, from 0 (every
+// pause frustrating) to 1 (every pause satisfying).
+type PauseApdex struct {
+	Score      float64       `json:"score"`
+	Target     time.Duration `json:"target"`
+	Satisfied  int           `json:"satisfied"`
+	Tolerating int           `json:"tolerating"`
+	Frustrated int           `json:"frustrated"`
+}
+
+// MMUPoint is one point of a minimum mutator utilization curve: the smallest
+// share of any Window-long span of the analysis period left to the
+// application rather than GC pauses, from 0 (some span was entirely paused)
+// to 1 (no span overlapped a pause)
+type MMUPoint struct {
+	Window      time.Duration `json:"window"`
+	Utilization float64       `json:"utilization"`
+}
+
+// RobustStats summarizes pause times and heap size by their median and
+// median absolute deviation (MAD). Unlike the mean, the median ignores a
+// handful of extreme values: one 2s pause among thousands of 100µs pauses
+// leaves it at 100µs.
+type RobustStats struct {
+	MedianPause time.Duration `json:"median_pause"`
+	PauseMAD    time.Duration `json:"pause_mad"`
+	Pauses      int           `json:"pauses"`
+
+	MedianHeap uint64 `json:"median_heap"` // HeapAlloc
+	HeapMAD    uint64 `json:"heap_mad"`
+}
+
+// PauseOutliers lists the longest GC pauses of the analysis window and flags
+// those far above the typical pause
+type PauseOutliers struct {
+	// Threshold is P75 + ThresholdPauseOutlierIQR × IQR of the window's
+	// pauses; pauses above it are outliers
+	Threshold time.Duration `json:"threshold"`
+	Count     int           `json:"count"` // outliers in the window
+
+	// Longest holds up to TopPauseCount pauses, longest first
+	Longest []PauseOutlier `json:"longest"`
+}
+
+// PauseOutlier is one long GC pause with the conditions around it
+type PauseOutlier struct {
+	Sequence  uint32        `json:"sequence"`
+	Time      time.Time     `json:"time"`
+	Duration  time.Duration `json:"duration"`
+	HeapSize  uint64        `json:"heap_size"`  // heap when the cycle started, or at the preceding sample
+	AllocRate float64       `json:"alloc_rate"` // bytes per second over the sample interval around the pause
+	Outlier   bool          `json:"outlier"`    // above PauseOutliers.Threshold
+}
+
+// PhaseBreakdown summarizes GC phase durations across the events that carry them
+type PhaseBreakdown struct {
+	AvgSweepTermination time.Duration `json:"avg_sweep_termination"`
+	AvgConcurrentMark   time.Duration `json:"avg_concurrent_mark"`
+	AvgMarkTermination  time.Duration `json:"avg_mark_termination"`
+	Cycles              int           `json:"cycles"`
+
+	// Shares of stop-the-world pause time (0-1)
+	SweepTerminationShare float64 `json:"sweep_termination_share"`
+	MarkTerminationShare  float64 `json:"mark_termination_share"`
+
+	// STWShare is the fraction of GC cycle wall-clock time the program was stopped
+	STWShare float64 `json:"stw_share"`
 }
 
 // MemoryPoint represents a point in memory usage trend
@@ -119,15 +855,43 @@ type MemoryPoint struct {
 	HeapAlloc uint64    `json:"heap_alloc"`
 	HeapSys   uint64    `json:"heap_sys"`
 	HeapInuse uint64    `json:"heap_inuse"`
+	HeapLive  uint64    `json:"heap_live,omitempty"`
 }
 
 // HealthCheckStatus represents the health status based on GC analysis
 type HealthCheckStatus struct {
-	Status      string    `json:"status"` // healthy, warning, critical
-	Score       int       `json:"score"`  // 0-100
-	Issues      []string  `json:"issues"`
-	Summary     string    `json:"summary"`
-	LastUpdated time.Time `json:"last_updated"`
+	Status      string      `json:"status"` // healthy, warning, critical
+	Score       int         `json:"score"`  // 0-100
+	Issues      []string    `json:"issues"`
+	Summary     string      `json:"summary"`
+	Trend       string      `json:"trend,omitempty"`       // improving, degrading, stable; set by monitors
+	PauseApdex  *PauseApdex `json:"pause_apdex,omitempty"` // pause satisfaction, when events are available
+	LastUpdated time.Time   `json:"last_updated"`
+}
+
+// Health score trends
+const (
+	HealthTrendImproving = "improving"
+	HealthTrendDegrading = "degrading"
+	HealthTrendStable    = "stable"
+)
+
+// HealthPoint is a health score in a monitor's health history
+type HealthPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Score     int       `json:"score"`
+	Status    string    `json:"status"`
+}
+
+// MonitorSnapshot is the monitor's most recent state, published as a whole
+// after each sample so readers such as HTTP handlers never block collection.
+// Snapshots are shared and must not be modified.
+type MonitorSnapshot struct {
+	Metrics    *GCMetrics         `json:"metrics"`
+	Analysis   *GCAnalysis        `json:"analysis,omitempty"` // nil until enough samples are collected
+	Health     *HealthCheckStatus `json:"health"`
+	AnalyzedAt time.Time          `json:"analyzed_at"` // when Analysis and Health were computed
+	Timestamp  time.Time          `json:"timestamp"`   // when the snapshot was published
 }
 
 // NewGCMetrics creates a new GCMetrics from runtime.MemStats
@@ -136,6 +900,16 @@ func NewGCMetrics() *GCMetrics {
 	var m runtime.MemStats
 	runtime.ReadMemStats(&m)
 
+	result := NewGCMetricsFromMemStats(&m, time.Now())
+	result.readRuntimeMetrics()
+
+	return result
+}
+
+// NewGCMetricsFromMemStats creates a GCMetrics from MemStats read at the given
+// time, e.g. those published by another process through expvar. Fields that
+// come from runtime/metrics are left zero.
+func NewGCMetricsFromMemStats(m *runtime.MemStats, at time.Time) *GCMetrics {
 	// Create new slices and copy data to avoid sharing memory
 	pauseNs := make([]uint64, len(m.PauseNs))
 	copy(pauseNs, m.PauseNs[:])
@@ -145,6 +919,7 @@ func NewGCMetrics() *GCMetrics {
 
 	return &GCMetrics{
 		NumGC:         m.NumGC,
+		NumForcedGC:   m.NumForcedGC,
 		PauseTotalNs:  m.PauseTotalNs,
 		PauseNs:       pauseNs,
 		PauseEnd:      pauseEnd,
@@ -163,9 +938,14 @@ func NewGCMetrics() *GCMetrics {
 		HeapObjects:   m.HeapObjects,
 		StackInuse:    m.StackInuse,
 		StackSys:      m.StackSys,
+		MSpanSys:      m.MSpanSys,
+		MCacheSys:     m.MCacheSys,
+		BuckHashSys:   m.BuckHashSys,
+		GCSys:         m.GCSys,
+		OtherSys:      m.OtherSys,
 		NextGC:        m.NextGC,
 		GCCPUFraction: m.GCCPUFraction,
-		Timestamp:     time.Now(),
+		Timestamp:     at,
 		pooled:        false,
 	}
 }
@@ -193,8 +973,9 @@ func NewGCMetricsPooled() *GCMetrics {
 	copy(pauseNsWrapper.data, m.PauseNs[:])
 	copy(pauseEndWrapper.data, m.PauseEnd[:])
 
-	return &GCMetrics{
+	result := &GCMetrics{
 		NumGC:           m.NumGC,
+		NumForcedGC:     m.NumForcedGC,
 		PauseTotalNs:    m.PauseTotalNs,
 		PauseNs:         pauseNsWrapper.data,
 		PauseEnd:        pauseEndWrapper.data,
@@ -213,6 +994,11 @@ func NewGCMetricsPooled() *GCMetrics {
 		HeapObjects:     m.HeapObjects,
 		StackInuse:      m.StackInuse,
 		StackSys:        m.StackSys,
+		MSpanSys:        m.MSpanSys,
+		MCacheSys:       m.MCacheSys,
+		BuckHashSys:     m.BuckHashSys,
+		GCSys:           m.GCSys,
+		OtherSys:        m.OtherSys,
 		NextGC:          m.NextGC,
 		GCCPUFraction:   m.GCCPUFraction,
 		Timestamp:       time.Now(),
@@ -220,6 +1006,9 @@ func NewGCMetricsPooled() *GCMetrics {
 		pauseNsWrapper:  pauseNsWrapper,
 		pauseEndWrapper: pauseEndWrapper,
 	}
+	result.readRuntimeMetrics()
+
+	return result
 }
 
 // Release returns pooled slices back to the pool.
@@ -244,6 +1033,9 @@ func (m *GCMetrics) Release() {
 		m.PauseEnd = nil
 	}
 
+	releasePauseHistogram(m.PauseHistogram)
+	m.PauseHistogram = nil
+
 	m.pooled = false
 }
 
@@ -253,8 +1045,9 @@ func NewGCMetricsLite() *GCMetrics {
 	var m runtime.MemStats
 	runtime.ReadMemStats(&m)
 
-	return &GCMetrics{
+	result := &GCMetrics{
 		NumGC:         m.NumGC,
+		NumForcedGC:   m.NumForcedGC,
 		PauseTotalNs:  m.PauseTotalNs,
 		PauseNs:       nil, // Skip pause data
 		PauseEnd:      nil,
@@ -273,11 +1066,19 @@ func NewGCMetricsLite() *GCMetrics {
 		HeapObjects:   m.HeapObjects,
 		StackInuse:    m.StackInuse,
 		StackSys:      m.StackSys,
+		MSpanSys:      m.MSpanSys,
+		MCacheSys:     m.MCacheSys,
+		BuckHashSys:   m.BuckHashSys,
+		GCSys:         m.GCSys,
+		OtherSys:      m.OtherSys,
 		NextGC:        m.NextGC,
 		GCCPUFraction: m.GCCPUFraction,
 		Timestamp:     time.Now(),
 		pooled:        false,
 	}
+	result.readRuntimeMetrics()
+
+	return result
 }
 
 // ToBytes converts size values to human-readable byte format
@@ -310,5 +1111,88 @@ func (m *GCMetrics) Clone() *GCMetrics {
 		copy(clone.PauseEnd, m.PauseEnd)
 	}
 
+	clone.PauseHistogram = m.PauseHistogram.Clone()
+	if m.PauseQuantiles != nil {
+		q := *m.PauseQuantiles
+		clone.PauseQuantiles = &q
+	}
+
 	return &clone
 }
+
+// RegionStats holds the allocations attributed to a tagged code region
+type RegionStats struct {
+	Name         string        `json:"name"`
+	Calls        uint64        `json:"calls"`
+	Duration     time.Duration `json:"duration"` // total time spent inside the region
+	AllocBytes   uint64        `json:"alloc_bytes"`
+	AllocObjects uint64        `json:"alloc_objects"`
+	GCCount      uint32        `json:"gc_count"`   // GC cycles completed while the region was open
+	PauseTime    time.Duration `json:"pause_time"` // stop-the-world time of those cycles
+	AllocRate    float64       `json:"alloc_rate"` // bytes per second spent inside the region
+}
+
+// BytesPerCall returns the average bytes allocated per call
+func (r *RegionStats) BytesPerCall() uint64 {
+	if r.Calls == 0 {
+		return 0
+	}
+	return r.AllocBytes / r.Calls
+}
+
+// RequestImpact holds the distributions of per-request GC impact over the
+// most recent requests. Pause time is the stop-the-world time of GC cycles
+// that completed while a request was in flight, whether or not the request
+// itself was running at that moment.
+type RequestImpact struct {
+	Requests   uint64 `json:"requests"`    // requests recorded in total
+	Window     int    `json:"window"`      // recent requests the distributions cover
+	GCAffected int    `json:"gc_affected"` // requests in the window overlapping at least one GC cycle
+
+	PauseP50 time.Duration `json:"pause_p50"`
+	PauseP95 time.Duration `json:"pause_p95"`
+	PauseP99 time.Duration `json:"pause_p99"`
+	PauseMax time.Duration `json:"pause_max"`
+
+	AllocP50 uint64 `json:"alloc_p50"` // bytes allocated per request
+	AllocP95 uint64 `json:"alloc_p95"`
+	AllocP99 uint64 `json:"alloc_p99"`
+	AllocMax uint64 `json:"alloc_max"`
+}
+
+// RegionBreakdown summarizes GC behavior over the sample intervals recorded
+// under one region label. Intervals are attributed to the label of the sample
+// that ends them; the empty label collects untagged intervals.
+type RegionBreakdown struct {
+	Region       string        `json:"region"`
+	Samples      int           `json:"samples"`
+	Duration     time.Duration `json:"duration"`
+	GCCount      uint32        `json:"gc_count"`
+	GCFrequency  float64       `json:"gc_frequency"` // GCs per second
+	AvgPauseTime time.Duration `json:"avg_pause_time"`
+	AvgHeapSize  uint64        `json:"avg_heap_size"`
+	AllocRate    float64       `json:"alloc_rate"` // bytes per second
+}
+
+// Seasonal baseline series
+const (
+	SeriesGCFrequency   = "gc_frequency"    // GCs per second over the sample interval
+	SeriesHeapAlloc     = "heap_alloc"      // bytes
+	SeriesGCCPUFraction = "gc_cpu_fraction" // 0-1
+)
+
+// SeasonalDeviation describes how far a sample is from the learned
+// minute-of-day baseline of a series
+type SeasonalDeviation struct {
+	Series      string  `json:"series"`
+	MinuteOfDay int     `json:"minute_of_day"`
+	Value       float64 `json:"value"`
+	Expected    float64 `json:"expected"` // baseline mean for this minute of day
+	StdDev      float64 `json:"std_dev"`  // baseline standard deviation, after the relative floor
+	ZScore      float64 `json:"z_score"`
+}
+
+// Anomalous reports whether the deviation exceeds ThresholdSeasonalZScore
+func (d SeasonalDeviation) Anomalous() bool {
+	return math.Abs(d.ZScore) > ThresholdSeasonalZScore
+}
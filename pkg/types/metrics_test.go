@@ -1,6 +1,9 @@
 package types
 
 import (
+	"errors"
+	"fmt"
+	"runtime"
 	"testing"
 	"time"
 )
@@ -265,6 +268,118 @@ func TestFormatBytesRate(t *testing.T) {
 	}
 }
 
+func TestNumberFormat(t *testing.T) {
+	tests := []struct {
+		format NumberFormat
+		input  float64
+		want   string
+	}{
+		{NumberFormat{Decimals: 2}, 1.005, "1.00"}, // 1.005 is just below the tie in binary
+		{NumberFormat{Decimals: 1}, 0.25, "0.2"},
+		{NumberFormat{Decimals: 1, Rounding: RoundHalfUp}, 0.25, "0.3"},
+		{NumberFormat{Decimals: 1, Rounding: RoundHalfUp}, -0.25, "-0.3"},
+		{NumberFormat{Decimals: 2, Rounding: RoundTowardZero}, 2.349, "2.34"},
+		{NumberFormat{Decimals: 2, Rounding: RoundTowardZero}, -2.349, "-2.34"},
+		{NumberFormat{Decimals: 1, Rounding: RoundAwayFromZero}, 2.01, "2.1"},
+		{NumberFormat{Decimals: 0, Rounding: RoundAwayFromZero}, -2.01, "-3"},
+		{NumberFormat{Decimals: -1}, 7.6, "8"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.format.Format(tt.input); got != tt.want {
+			t.Errorf("%+v.Format(%v) = %s, want %s", tt.format, tt.input, got, tt.want)
+		}
+	}
+
+	if got := FormatBytesWith(1536*1024, NumberFormat{Decimals: 3}); got != "1.500 MB" {
+		t.Errorf("FormatBytesWith() = %s, want 1.500 MB", got)
+	}
+	if got := FormatBytesRateWith(1100, NumberFormat{Rounding: RoundAwayFromZero}); got != "2 KB/s" {
+		t.Errorf("FormatBytesRateWith() = %s, want 2 KB/s", got)
+	}
+}
+
+func TestFormatApproxDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{30 * time.Second, "~30 seconds"},
+		{42 * time.Minute, "~42 minutes"},
+		{61 * time.Second, "~1 minute"},
+		{5 * time.Hour, "~5 hours"},
+		{72 * time.Hour, "~3 days"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatApproxDuration(tt.d); got != tt.want {
+			t.Errorf("FormatApproxDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestFormatShortDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{45 * time.Second, "45s"},
+		{10 * time.Minute, "10m"},
+		{90 * time.Second, "1m30s"},
+		{time.Hour, "1h"},
+		{90 * time.Minute, "1h30m"},
+		{10*time.Minute + 200*time.Millisecond, "10m"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatShortDuration(tt.d); got != tt.want {
+			t.Errorf("FormatShortDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestOOMForecast_Summary(t *testing.T) {
+	var nilForecast *OOMForecast
+	if got := nilForecast.Summary(); got != "no OOM expected at current growth rate" {
+		t.Errorf("nil Summary() = %q", got)
+	}
+
+	f := &OOMForecast{WillExceed: true, TimeToLimit: 42 * time.Minute}
+	if got := f.Summary(); got != "estimated OOM in ~42 minutes" {
+		t.Errorf("Summary() = %q", got)
+	}
+
+	f = &OOMForecast{WillExceed: true, Exceeded: true}
+	if got := f.Summary(); got != "memory limit already reached" {
+		t.Errorf("exceeded Summary() = %q", got)
+	}
+}
+
+func TestClassifySeverity(t *testing.T) {
+	tests := []struct {
+		observed, threshold float64
+		want                Severity
+	}{
+		{11, 10, SeverityInfo},
+		{15, 10, SeverityWarning},
+		{29, 10, SeverityWarning},
+		{30, 10, SeverityCritical},
+		{1, 0, SeverityWarning},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifySeverity(tt.observed, tt.threshold); got != tt.want {
+			t.Errorf("ClassifySeverity(%v, %v) = %q, want %q", tt.observed, tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestSeverity_Rank(t *testing.T) {
+	if !(SeverityCritical.Rank() > SeverityWarning.Rank() && SeverityWarning.Rank() > SeverityInfo.Rank()) {
+		t.Error("Severity ranks should be ordered info < warning < critical")
+	}
+}
+
 func TestHealthCheckStatus(t *testing.T) {
 	status := &HealthCheckStatus{
 		Status:      "healthy",
@@ -366,6 +481,21 @@ func TestErrors(t *testing.T) {
 	}
 }
 
+func TestInsufficientDataError(t *testing.T) {
+	var err error = &InsufficientDataError{Op: "analyze", Samples: 1, Required: 2}
+	if !errors.Is(err, ErrInsufficientData) {
+		t.Errorf("errors.Is(%v, ErrInsufficientData) = false", err)
+	}
+	if want := "analyze: insufficient data for analysis (1 of 2 samples)"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	var target *InsufficientDataError
+	if !errors.As(fmt.Errorf("monitor: %w", err), &target) || target.Samples != 1 {
+		t.Errorf("errors.As() through wrapping = %+v", target)
+	}
+}
+
 // Benchmark tests
 func BenchmarkNewGCMetrics(b *testing.B) {
 	for i := 0; i < b.N; i++ {
@@ -405,3 +535,154 @@ func BenchmarkFormatBytes(b *testing.B) {
 		}
 	}
 }
+
+func TestDetectConfigDrift(t *testing.T) {
+	recorded := &RuntimeInfo{GoVersion: "go1.22.0", GOMAXPROCS: 8, GOGC: 100}
+
+	if drift := DetectConfigDrift(recorded, nil); drift != nil {
+		t.Errorf("DetectConfigDrift with nil current = %v, want nil", drift)
+	}
+
+	same := *recorded
+	if drift := DetectConfigDrift(recorded, &same); len(drift) != 0 {
+		t.Errorf("DetectConfigDrift with identical settings = %v, want none", drift)
+	}
+
+	current := &RuntimeInfo{GoVersion: "go1.23.0", GOMAXPROCS: 8, GOGC: -1, GOMemLimit: 512 * 1024 * 1024}
+	drift := DetectConfigDrift(recorded, current)
+	want := []string{
+		"Go version: recorded go1.22.0, current go1.23.0",
+		"GOGC: recorded 100, current off",
+		"GOMEMLIMIT: recorded none, current 512.0 MB",
+	}
+	if len(drift) != len(want) {
+		t.Fatalf("DetectConfigDrift returned %d entries, want %d: %v", len(drift), len(want), drift)
+	}
+	for i, w := range want {
+		if got := drift[i].String(); got != w {
+			t.Errorf("drift[%d] = %q, want %q", i, got, w)
+		}
+	}
+}
+
+func TestCurrentRuntimeInfo(t *testing.T) {
+	info := CurrentRuntimeInfo()
+	if info.GoVersion == "" || info.GOMAXPROCS <= 0 || info.NumCPU <= 0 {
+		t.Errorf("CurrentRuntimeInfo() = %+v, want populated fields", info)
+	}
+}
+
+func TestNewGCMetrics_RuntimeMetrics(t *testing.T) {
+	runtime.GC()
+	m := NewGCMetricsLite()
+	if m.TotalCPU <= 0 {
+		t.Errorf("TotalCPU = %v, want > 0", m.TotalCPU)
+	}
+	if m.GCCyclesForced == 0 {
+		t.Error("GCCyclesForced should count the runtime.GC() call")
+	}
+	if m.GOGC != CurrentGCPercent() {
+		t.Errorf("GOGC = %d, want %d", m.GOGC, CurrentGCPercent())
+	}
+	if m.GOMemLimit == 0 {
+		t.Error("GOMemLimit should be read, math.MaxInt64 when unset")
+	}
+}
+
+func TestBucketMemProfile(t *testing.T) {
+	records := []runtime.MemProfileRecord{
+		{AllocBytes: 8 * 100, AllocObjects: 100},        // tiny
+		{AllocBytes: 1024 * 100, AllocObjects: 100},     // small
+		{AllocBytes: 64 * 1024 * 10, AllocObjects: 10},  // large
+		{AllocBytes: 256 * 1000, AllocObjects: 1000},    // small
+		{AllocBytes: 0, AllocObjects: 0, FreeBytes: 42}, // ignored
+	}
+
+	d := BucketMemProfile(records, 1)
+	if d.Records != len(records) || len(d.Buckets) != 3 {
+		t.Fatalf("BucketMemProfile() = %+v", d)
+	}
+
+	wantBytes := map[string]uint64{
+		SizeClassTiny:  800,
+		SizeClassSmall: 1024*100 + 256*1000,
+		SizeClassLarge: 64 * 1024 * 10,
+	}
+	var share float64
+	for _, b := range d.Buckets {
+		if b.Bytes != wantBytes[b.Class] {
+			t.Errorf("%s bytes = %d, want %d", b.Class, b.Bytes, wantBytes[b.Class])
+		}
+		share += b.Share
+	}
+	if share < 0.999 || share > 1.001 {
+		t.Errorf("Shares should sum to 1, got %v", share)
+	}
+	if dom := d.Dominant(); dom == nil || dom.Class != SizeClassLarge {
+		t.Errorf("Dominant() = %+v, want large", dom)
+	}
+}
+
+func TestBucketMemProfile_Scaling(t *testing.T) {
+	// Small objects are sampled far less often than large ones, so their
+	// counts must be scaled up more
+	records := []runtime.MemProfileRecord{
+		{AllocBytes: 64 * 10, AllocObjects: 10},
+		{AllocBytes: 1 << 20, AllocObjects: 1},
+	}
+
+	d := BucketMemProfile(records, 512*1024)
+	small, large := d.Buckets[1], d.Buckets[2]
+	if small.Objects <= 10 {
+		t.Errorf("Small objects should be scaled up, got %d", small.Objects)
+	}
+	if large.Objects != 1 {
+		t.Errorf("Large objects should be nearly unscaled, got %d", large.Objects)
+	}
+}
+
+func TestSizeClassDistribution_DominantEmpty(t *testing.T) {
+	var d *SizeClassDistribution
+	if d.Dominant() != nil {
+		t.Error("Nil distribution should have no dominant class")
+	}
+	if BucketMemProfile(nil, 1).Dominant() != nil {
+		t.Error("Empty profile should have no dominant class")
+	}
+}
+
+func TestReadSizeClassDistribution(t *testing.T) {
+	d := ReadSizeClassDistribution()
+	if d == nil || len(d.Buckets) != 3 {
+		t.Fatalf("ReadSizeClassDistribution() = %+v", d)
+	}
+	if d.SampleRate != runtime.MemProfileRate {
+		t.Errorf("SampleRate = %d, want %d", d.SampleRate, runtime.MemProfileRate)
+	}
+}
+
+func TestReadProcessCPU(t *testing.T) {
+	seconds, ok := ReadProcessCPU()
+	if runtime.GOOS == "windows" || runtime.GOOS == "js" || runtime.GOOS == "wasip1" || runtime.GOOS == "plan9" {
+		if ok {
+			t.Errorf("ReadProcessCPU() should be unsupported on %s", runtime.GOOS)
+		}
+		return
+	}
+	if !ok || seconds <= 0 {
+		t.Errorf("ReadProcessCPU() = %v, %v; want positive CPU time", seconds, ok)
+	}
+}
+
+func TestReadProcessRSS(t *testing.T) {
+	rss, ok := ReadProcessRSS()
+	if runtime.GOOS != "linux" {
+		if ok {
+			t.Errorf("ReadProcessRSS() should be unsupported on %s", runtime.GOOS)
+		}
+		return
+	}
+	if !ok || rss == 0 {
+		t.Errorf("ReadProcessRSS() = %v, %v; want positive RSS", rss, ok)
+	}
+}
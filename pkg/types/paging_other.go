@@ -0,0 +1,10 @@
+//go:build !unix
+
+package types
+
+// ReadMajorPageFaults returns the number of major page faults (faults that
+// had to read a page from disk or swap) the current process has incurred
+// since it started. ok is false when the platform does not support it.
+func ReadMajorPageFaults() (faults uint64, ok bool) {
+	return 0, false
+}
@@ -0,0 +1,16 @@
+//go:build unix
+
+package types
+
+import "syscall"
+
+// ReadMajorPageFaults returns the number of major page faults (faults that
+// had to read a page from disk or swap) the current process has incurred
+// since it started. ok is false when the platform does not support it.
+func ReadMajorPageFaults() (faults uint64, ok bool) {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		return 0, false
+	}
+	return uint64(ru.Majflt), true
+}
@@ -0,0 +1,161 @@
+package types
+
+import (
+	"math"
+	"runtime/metrics"
+	"slices"
+	"sync"
+	"time"
+)
+
+// PauseHistogram is the runtime's cumulative distribution of individual
+// stop-the-world GC pauses. Unlike the PauseNs ring buffer it never loses
+// pauses, however many GC cycles complete between samples, at the cost of
+// bucketed rather than exact durations. Each GC cycle typically pauses twice.
+type PauseHistogram struct {
+	Counts  []uint64  `json:"counts"`
+	Buckets []float64 `json:"buckets"` // boundaries in seconds, len(Counts)+1
+}
+
+// pauseBuckets caches the runtime's bucket boundaries, which are fixed for
+// the life of the process, so samples share one copy
+var (
+	pauseBucketsOnce sync.Once
+	pauseBuckets     []float64
+)
+
+// newPauseHistogram copies a runtime/metrics histogram, whose memory is
+// reused by later reads. The infinite outer boundaries are clamped to finite
+// values so the histogram can be encoded as JSON.
+func newPauseHistogram(h *metrics.Float64Histogram) *PauseHistogram {
+	if !cachePauseBuckets(h) {
+		return nil
+	}
+	return &PauseHistogram{Counts: slices.Clone(h.Counts), Buckets: pauseBuckets}
+}
+
+// cachePauseBuckets caches the bucket boundaries of h on first use and
+// reports whether h matches them
+func cachePauseBuckets(h *metrics.Float64Histogram) bool {
+	pauseBucketsOnce.Do(func() {
+		pauseBuckets = slices.Clone(h.Buckets)
+		if n := len(pauseBuckets); n > 0 {
+			pauseBuckets[0] = max(pauseBuckets[0], 0)
+			pauseBuckets[n-1] = min(pauseBuckets[n-1], math.MaxFloat64)
+		}
+	})
+	return len(pauseBuckets) == len(h.Counts)+1
+}
+
+// pauseHistogramPool provides reusable histograms for pooled metrics
+var pauseHistogramPool = sync.Pool{
+	New: func() any { return &PauseHistogram{} },
+}
+
+// newPauseHistogramPooled is newPauseHistogram with a histogram from
+// pauseHistogramPool, to be returned with releasePauseHistogram
+func newPauseHistogramPooled(h *metrics.Float64Histogram) *PauseHistogram {
+	if !cachePauseBuckets(h) {
+		return nil
+	}
+	pooled, ok := pauseHistogramPool.Get().(*PauseHistogram)
+	if !ok {
+		pooled = &PauseHistogram{}
+	}
+	pooled.Counts = append(pooled.Counts[:0], h.Counts...)
+	pooled.Buckets = pauseBuckets
+	return pooled
+}
+
+// releasePauseHistogram returns a histogram from newPauseHistogramPooled
+func releasePauseHistogram(h *PauseHistogram) {
+	if h != nil {
+		pauseHistogramPool.Put(h)
+	}
+}
+
+// Clone returns a copy of h that shares its immutable bucket boundaries
+func (h *PauseHistogram) Clone() *PauseHistogram {
+	if h == nil {
+		return nil
+	}
+	return &PauseHistogram{Counts: slices.Clone(h.Counts), Buckets: h.Buckets}
+}
+
+// Sub returns the pauses recorded since prev, or nil when the two histograms
+// don't come from the same process. A nil prev returns a copy of h.
+func (h *PauseHistogram) Sub(prev *PauseHistogram) *PauseHistogram {
+	if h == nil {
+		return nil
+	}
+	if prev == nil {
+		return h.Clone()
+	}
+	if len(prev.Counts) != len(h.Counts) {
+		return nil
+	}
+
+	diff := &PauseHistogram{Counts: make([]uint64, len(h.Counts)), Buckets: h.Buckets}
+	for i, c := range h.Counts {
+		if c < prev.Counts[i] {
+			return nil
+		}
+		diff.Counts[i] = c - prev.Counts[i]
+	}
+	return diff
+}
+
+// Total returns the number of pauses recorded
+func (h *PauseHistogram) Total() uint64 {
+	if h == nil {
+		return 0
+	}
+	var total uint64
+	for _, c := range h.Counts {
+		total += c
+	}
+	return total
+}
+
+// Quantile returns the pause duration at quantile q (0-1), as the upper
+// boundary of the bucket holding it, so it never understates a pause
+func (h *PauseHistogram) Quantile(q float64) time.Duration {
+	total := h.Total()
+	if total == 0 {
+		return 0
+	}
+	rank := uint64(math.Ceil(q * float64(total)))
+	rank = max(rank, 1)
+
+	var seen uint64
+	for i, c := range h.Counts {
+		seen += c
+		if seen >= rank {
+			return h.bucketBound(i)
+		}
+	}
+	return h.bucketBound(len(h.Counts) - 1)
+}
+
+// Max returns the upper boundary of the highest non-empty bucket
+func (h *PauseHistogram) Max() time.Duration {
+	if h == nil {
+		return 0
+	}
+	for i := len(h.Counts) - 1; i >= 0; i-- {
+		if h.Counts[i] > 0 {
+			return h.bucketBound(i)
+		}
+	}
+	return 0
+}
+
+// bucketBound returns the upper boundary of bucket i as a duration. The
+// unbounded last bucket reports its lower boundary instead.
+func (h *PauseHistogram) bucketBound(i int) time.Duration {
+	bound := h.Buckets[i+1]
+	if i == len(h.Counts)-1 {
+		bound = h.Buckets[i]
+	}
+	return time.Duration(bound * float64(time.Second))
+}
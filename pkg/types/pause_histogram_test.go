@@ -0,0 +1,82 @@
+package types
+
+import (
+	"encoding/json"
+	"math"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// testPauseHistogram returns a histogram with buckets of 1ms, 2ms, 4ms, 8ms and
+// an unbounded last bucket, holding the given counts
+func testPauseHistogram(counts ...uint64) *PauseHistogram {
+	return &PauseHistogram{
+		Counts:  counts,
+		Buckets: []float64{0, 0.001, 0.002, 0.004, 0.008, math.MaxFloat64},
+	}
+}
+
+func TestPauseHistogram_Sub(t *testing.T) {
+	before := testPauseHistogram(10, 5, 0, 0, 0)
+	after := testPauseHistogram(110, 5, 3, 1, 0)
+
+	diff := after.Sub(before)
+	if diff.Total() != 104 {
+		t.Fatalf("Total() = %d, want 104", diff.Total())
+	}
+	if got := diff.Quantile(0.5); got != time.Millisecond {
+		t.Errorf("Quantile(0.5) = %v, want 1ms", got)
+	}
+	if got := diff.Quantile(0.99); got != 4*time.Millisecond {
+		t.Errorf("Quantile(0.99) = %v, want 4ms", got)
+	}
+	if got := diff.Max(); got != 8*time.Millisecond {
+		t.Errorf("Max() = %v, want 8ms", got)
+	}
+
+	if after.Sub(testPauseHistogram(1, 2, 3)) != nil {
+		t.Error("Histograms with different buckets should not subtract")
+	}
+	if before.Sub(after) != nil {
+		t.Error("Decreasing counts mean another process; Sub() should return nil")
+	}
+	if got := after.Sub(nil); got.Total() != after.Total() {
+		t.Errorf("Sub(nil) total = %d, want %d", got.Total(), after.Total())
+	}
+}
+
+func TestPauseHistogram_Unbounded(t *testing.T) {
+	h := testPauseHistogram(0, 0, 0, 0, 2)
+	if got := h.Max(); got != 8*time.Millisecond {
+		t.Errorf("Max() = %v, want the last bucket's lower bound 8ms", got)
+	}
+
+	var empty *PauseHistogram
+	if empty.Total() != 0 || empty.Max() != 0 || empty.Quantile(0.99) != 0 {
+		t.Error("A nil histogram should be empty")
+	}
+}
+
+func TestNewGCMetrics_PauseHistogram(t *testing.T) {
+	runtime.GC()
+	m := NewGCMetrics()
+	h := m.PauseHistogram
+	if h == nil || h.Total() == 0 {
+		t.Fatalf("Expected a pause histogram with pauses after a GC, got %+v", h)
+	}
+	if len(h.Buckets) != len(h.Counts)+1 {
+		t.Errorf("%d buckets for %d counts", len(h.Buckets), len(h.Counts))
+	}
+
+	// Boundaries are clamped so samples can be stored as JSON
+	if _, err := json.Marshal(m); err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+
+	clone := m.Clone()
+	clone.PauseHistogram.Counts[0]++
+	if clone.PauseHistogram.Counts[0] == h.Counts[0] {
+		t.Error("Clone() should copy the histogram counts")
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package types
+
+// ReadProcessCPU returns the CPU time (user + system) consumed by the current
+// process since it started, in seconds. ok is false when the platform does
+// not support it.
+func ReadProcessCPU() (seconds float64, ok bool) {
+	return 0, false
+}
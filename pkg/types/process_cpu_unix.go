@@ -0,0 +1,22 @@
+//go:build unix
+
+package types
+
+import "syscall"
+
+// ReadProcessCPU returns the CPU time (user + system) consumed by the current
+// process since it started, in seconds. ok is false when the platform does
+// not support it.
+func ReadProcessCPU() (seconds float64, ok bool) {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		return 0, false
+	}
+	return timevalSeconds(ru.Utime) + timevalSeconds(ru.Stime), true
+}
+
+// timevalSeconds converts a syscall.Timeval to seconds
+func timevalSeconds(tv syscall.Timeval) float64 {
+	sec, nsec := tv.Unix()
+	return float64(sec) + float64(nsec)/1e9
+}
@@ -0,0 +1,60 @@
+//go:build linux
+
+package types
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"strconv"
+)
+
+// ReadProcessRSS returns the resident set size of the current process in
+// bytes. ok is false when the platform does not support it.
+func ReadProcessRSS() (rss uint64, ok bool) {
+	// /proc/self/statm: size resident shared text lib data dt (in pages)
+	data, err := os.ReadFile("/proc/self/statm")
+	if err != nil {
+		return 0, false
+	}
+	fields := bytes.Fields(data)
+	if len(fields) < 2 {
+		return 0, false
+	}
+	pages, err := strconv.ParseUint(string(fields[1]), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return pages * uint64(os.Getpagesize()), true
+}
+
+// ReadProcessSwap returns the bytes of the current process's memory swapped
+// out. ok is false when the platform does not support it.
+func ReadProcessSwap() (swap uint64, ok bool) {
+	f, err := os.Open("/proc/self/status")
+	if err != nil {
+		return 0, false
+	}
+	defer f.Close()
+	return parseVmSwap(bufio.NewScanner(f))
+}
+
+// parseVmSwap reads the "VmSwap:   1234 kB" line of /proc/<pid>/status
+func parseVmSwap(scanner *bufio.Scanner) (uint64, bool) {
+	for scanner.Scan() {
+		rest, found := bytes.CutPrefix(scanner.Bytes(), []byte("VmSwap:"))
+		if !found {
+			continue
+		}
+		fields := bytes.Fields(rest)
+		if len(fields) == 0 {
+			return 0, false
+		}
+		kb, err := strconv.ParseUint(string(fields[0]), 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		return kb * 1024, true
+	}
+	return 0, false
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package types
+
+// ReadProcessRSS returns the resident set size of the current process in
+// bytes. ok is false when the platform does not support it.
+func ReadProcessRSS() (rss uint64, ok bool) {
+	return 0, false
+}
+
+// ReadProcessSwap returns the bytes of the current process's memory swapped
+// out. ok is false when the platform does not support it.
+func ReadProcessSwap() (swap uint64, ok bool) {
+	return 0, false
+}
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Regression check metric names, in addition to the upgrade comparison's
+const (
+	DeltaP95Pause  = "p95_pause"  // nanoseconds
+	DeltaAllocRate = "alloc_rate" // bytes per second
+)
+
+// RegressionPolicy sets how much each metric may grow over the baseline before
+// CheckRegression fails. Tolerances are relative, e.g. 0.1 allows 10% growth;
+// a zero tolerance disables that check.
+type RegressionPolicy struct {
+	P95Pause    float64 `json:"p95_pause,omitempty"`
+	P99Pause    float64 `json:"p99_pause,omitempty"`
+	GCFrequency float64 `json:"gc_frequency,omitempty"`
+	AllocRate   float64 `json:"alloc_rate,omitempty"`
+
+	// MinPauseIncrease ignores pause regressions smaller than this in
+	// absolute terms, which are usually scheduling noise (default: none)
+	MinPauseIncrease time.Duration `json:"min_pause_increase,omitempty"`
+}
+
+// DefaultRegressionPolicy returns a policy allowing 20% growth in pause
+// percentiles of at least 1ms, and 20% growth in GC frequency and
+// allocation rate
+func DefaultRegressionPolicy() RegressionPolicy {
+	return RegressionPolicy{
+		P95Pause:         0.2,
+		P99Pause:         0.2,
+		GCFrequency:      0.2,
+		AllocRate:        0.2,
+		MinPauseIncrease: time.Millisecond,
+	}
+}
+
+// Regression is a metric that grew beyond its tolerance
+type Regression struct {
+	MetricDelta
+	Tolerance float64 `json:"tolerance"`
+}
+
+// RegressionError is returned by CheckRegression when metrics regressed. It
+// matches ErrRegression with errors.Is.
+type RegressionError struct {
+	Regressions []Regression
+}
+
+// Error lists every regression, e.g. "GC metrics regressed: p99_pause +45.0%
+// (2ms -> 2.9ms, tolerance 20.0%)"
+func (e *RegressionError) Error() string {
+	var b strings.Builder
+	b.WriteString(ErrRegression.Error())
+	for i, r := range e.Regressions {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString("; ")
+		}
+		b.WriteString(r.Metric)
+		b.WriteString(" +")
+		b.WriteString(formatFloat(r.Change*100, 1))
+		b.WriteString("% (")
+		b.WriteString(formatDeltaValue(r.Metric, r.Before))
+		b.WriteString(" -> ")
+		b.WriteString(formatDeltaValue(r.Metric, r.After))
+		b.WriteString(", tolerance ")
+		b.WriteString(formatFloat(r.Tolerance*100, 1))
+		b.WriteString("%)")
+	}
+	return b.String()
+}
+
+// Unwrap returns ErrRegression
+func (e *RegressionError) Unwrap() error {
+	return ErrRegression
+}
+
+// formatDeltaValue formats a metric value in its natural unit
+func formatDeltaValue(metric string, v float64) string {
+	switch metric {
+	case DeltaAvgPause, DeltaP95Pause, DeltaP99Pause:
+		return time.Duration(v).Round(time.Microsecond).String()
+	case DeltaGCFrequency:
+		return formatFloat(v, 2) + "/s"
+	case DeltaAllocRate:
+		return FormatBytesRate(v)
+	case DeltaRuntimeMemory, DeltaRSS:
+		return FormatBytes(uint64(v))
+	default:
+		return strconv.FormatFloat(v, 'g', 4, 64)
+	}
+}
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetentionPolicy keeps recent samples at full resolution and compacts older
+// ones into averages over coarser windows, so hours of history fit in
+// bounded memory. Start from DefaultRetentionPolicy.
+type RetentionPolicy struct {
+	// FullResolution is how far back from the newest sample samples are
+	// kept as collected, within the collector's MaxSamples
+	FullResolution time.Duration
+
+	// Tiers hold compacted samples beyond FullResolution, finest first
+	Tiers []RetentionTier
+}
+
+// RetentionTier compacts the samples of each Resolution window into one
+type RetentionTier struct {
+	// Resolution is the window averaged into each compacted sample
+	Resolution time.Duration
+
+	// Retention is how far back from the newest sample compacted samples
+	// are kept
+	Retention time.Duration
+}
+
+// DefaultRetentionPolicy returns 5 minutes at full resolution, then 10s
+// averages for an hour and 1m averages for a day
+func DefaultRetentionPolicy() RetentionPolicy {
+	return RetentionPolicy{
+		FullResolution: 5 * time.Minute,
+		Tiers: []RetentionTier{
+			{Resolution: 10 * time.Second, Retention: time.Hour},
+			{Resolution: time.Minute, Retention: 24 * time.Hour},
+		},
+	}
+}
+
+// Validate checks that each tier is coarser and retained longer than the
+// one before it. Returns an error wrapping ErrInvalidRetention otherwise.
+func (p *RetentionPolicy) Validate() error {
+	if p.FullResolution <= 0 {
+		return fmt.Errorf("%w: full resolution period must be positive", ErrInvalidRetention)
+	}
+	prev := RetentionTier{Retention: p.FullResolution}
+	for i, tier := range p.Tiers {
+		switch {
+		case tier.Resolution <= prev.Resolution:
+			return fmt.Errorf("%w: tier %d resolution %v must be positive and coarser than the previous tier", ErrInvalidRetention, i, tier.Resolution)
+		case tier.Retention <= prev.Retention:
+			return fmt.Errorf("%w: tier %d retention %v must be longer than the previous tier", ErrInvalidRetention, i, tier.Retention)
+		case tier.Retention < tier.Resolution:
+			return fmt.Errorf("%w: tier %d retention %v is shorter than its resolution", ErrInvalidRetention, i, tier.Retention)
+		}
+		prev = tier
+	}
+	return nil
+}
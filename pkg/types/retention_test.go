@@ -0,0 +1,35 @@
+package types
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetentionPolicy_Validate(t *testing.T) {
+	def := DefaultRetentionPolicy()
+	if err := def.Validate(); err != nil {
+		t.Errorf("DefaultRetentionPolicy() invalid: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		policy RetentionPolicy
+	}{
+		{"no full resolution", RetentionPolicy{}},
+		{"zero resolution", RetentionPolicy{FullResolution: time.Minute, Tiers: []RetentionTier{{Retention: time.Hour}}}},
+		{"retention within full resolution", RetentionPolicy{FullResolution: time.Hour, Tiers: []RetentionTier{{Resolution: time.Second, Retention: time.Minute}}}},
+		{"finer tier after coarser", RetentionPolicy{FullResolution: time.Minute, Tiers: []RetentionTier{
+			{Resolution: time.Minute, Retention: time.Hour},
+			{Resolution: time.Second, Retention: 2 * time.Hour},
+		}}},
+		{"retention shorter than resolution", RetentionPolicy{FullResolution: time.Second, Tiers: []RetentionTier{{Resolution: time.Hour, Retention: time.Minute}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Validate(); !errors.Is(err, ErrInvalidRetention) {
+				t.Errorf("Validate() = %v, want ErrInvalidRetention", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package types
+
+import (
+	"math"
+	"runtime"
+	"runtime/debug"
+	"runtime/metrics"
+	"strconv"
+	"time"
+)
+
+// RuntimeInfo records the Go runtime configuration a dataset was captured under.
+// Conclusions drawn from GC data depend heavily on these settings.
+type RuntimeInfo struct {
+	GoVersion  string    `json:"go_version"`
+	GOOS       string    `json:"goos"`
+	GOARCH     string    `json:"goarch"`
+	GOMAXPROCS int       `json:"gomaxprocs"`
+	NumCPU     int       `json:"num_cpu,omitempty"` // logical CPUs usable by the process
+	GOGC       int       `json:"gogc"`              // -1 when GC is disabled
+	GOMemLimit uint64    `json:"gomemlimit"`        // 0 when no limit is set
+	CapturedAt time.Time `json:"captured_at"`       // when the information was read
+
+	// CgroupMemoryLimit is the container (cgroup) memory limit, 0 when unlimited
+	// or not running in a container
+	CgroupMemoryLimit uint64 `json:"cgroup_memory_limit,omitempty"`
+
+	// Kubernetes is the pod context, when running in Kubernetes
+	Kubernetes *KubernetesInfo `json:"kubernetes,omitempty"`
+}
+
+// ConfigDrift describes a runtime setting that differs between recorded
+// analysis metadata and the current process
+type ConfigDrift struct {
+	Setting  string `json:"setting"`
+	Recorded string `json:"recorded"`
+	Current  string `json:"current"`
+}
+
+// String returns a description such as "GOGC: recorded 100, current 200"
+func (d ConfigDrift) String() string {
+	return d.Setting + ": recorded " + d.Recorded + ", current " + d.Current
+}
+
+// CurrentRuntimeInfo reads the runtime configuration of the current process
+func CurrentRuntimeInfo() *RuntimeInfo {
+	cgroupLimit, _ := ReadCgroupMemoryLimit()
+	return &RuntimeInfo{
+		GoVersion:         runtime.Version(),
+		GOOS:              runtime.GOOS,
+		GOARCH:            runtime.GOARCH,
+		GOMAXPROCS:        runtime.GOMAXPROCS(0),
+		NumCPU:            runtime.NumCPU(),
+		GOGC:              CurrentGCPercent(),
+		GOMemLimit:        CurrentMemoryLimit(),
+		CapturedAt:        time.Now(),
+		CgroupMemoryLimit: cgroupLimit,
+		Kubernetes:        ReadKubernetesInfo(),
+	}
+}
+
+// CurrentGCPercent returns the GOGC value of the current process, or -1 when GC is off.
+// It reads runtime/metrics instead of calling debug.SetGCPercent, which would
+// briefly change the setting.
+func CurrentGCPercent() int {
+	sample := []metrics.Sample{{Name: "/gc/gogc:percent"}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 100
+	}
+	v := int64(sample[0].Value.Uint64())
+	if v < 0 {
+		return -1
+	}
+	return int(v)
+}
+
+// CurrentMemoryLimit returns the soft memory limit (GOMEMLIMIT) of the current process.
+// Returns 0 when no limit is configured.
+func CurrentMemoryLimit() uint64 {
+	// A negative input queries the limit without changing it
+	limit := debug.SetMemoryLimit(-1)
+	if limit <= 0 || limit == math.MaxInt64 {
+		return 0
+	}
+	return uint64(limit)
+}
+
+// DetectConfigDrift compares recorded runtime metadata with another runtime
+// (usually CurrentRuntimeInfo) and returns the settings that differ.
+// Returns nil when either side is nil or nothing differs.
+func DetectConfigDrift(recorded, current *RuntimeInfo) []ConfigDrift {
+	if recorded == nil || current == nil {
+		return nil
+	}
+
+	var drift []ConfigDrift
+	if recorded.GoVersion != current.GoVersion {
+		drift = append(drift, ConfigDrift{"Go version", recorded.GoVersion, current.GoVersion})
+	}
+	if recorded.GOGC != current.GOGC {
+		drift = append(drift, ConfigDrift{"GOGC", FormatGOGC(recorded.GOGC), FormatGOGC(current.GOGC)})
+	}
+	if recorded.GOMemLimit != current.GOMemLimit {
+		drift = append(drift, ConfigDrift{"GOMEMLIMIT", FormatMemLimit(recorded.GOMemLimit), FormatMemLimit(current.GOMemLimit)})
+	}
+	if recorded.GOMAXPROCS != current.GOMAXPROCS {
+		drift = append(drift, ConfigDrift{"GOMAXPROCS", strconv.Itoa(recorded.GOMAXPROCS), strconv.Itoa(current.GOMAXPROCS)})
+	}
+
+	return drift
+}
+
+// FormatGOGC formats a GOGC value the way the environment variable spells it
+func FormatGOGC(v int) string {
+	if v < 0 {
+		return "off"
+	}
+	return strconv.Itoa(v)
+}
+
+// FormatMemLimit formats a memory limit, using "none" for an unset limit
+// (zero or math.MaxInt64)
+func FormatMemLimit(v uint64) string {
+	if v == 0 || v >= math.MaxInt64 {
+		return "none"
+	}
+	return FormatBytes(v)
+}
@@ -0,0 +1,107 @@
+package types
+
+import (
+	"runtime/metrics"
+	"sync"
+)
+
+// runtime/metrics names read with each sample
+const (
+	metricGCMarkAssistCPU    = "/cpu/classes/gc/mark/assist:cpu-seconds"
+	metricGCMarkDedicatedCPU = "/cpu/classes/gc/mark/dedicated:cpu-seconds"
+	metricGCMarkIdleCPU      = "/cpu/classes/gc/mark/idle:cpu-seconds"
+	metricGCPauseCPU         = "/cpu/classes/gc/pause:cpu-seconds"
+	metricGCTotalCPU         = "/cpu/classes/gc/total:cpu-seconds"
+	metricTotalCPU           = "/cpu/classes/total:cpu-seconds"
+	metricGCCyclesAutomatic  = "/gc/cycles/automatic:gc-cycles"
+	metricGCCyclesForced     = "/gc/cycles/forced:gc-cycles"
+	metricGCHeapLive         = "/gc/heap/live:bytes"
+	metricGCPercent          = "/gc/gogc:percent"
+	metricGCMemoryLimit      = "/gc/gomemlimit:bytes"
+
+	// Identical to the deprecated /gc/pauses:seconds
+	metricGCPauses = "/sched/pauses/total/gc:seconds"
+)
+
+// runtimeSamplesPool provides reusable runtime/metrics sample slices
+var runtimeSamplesPool = sync.Pool{
+	New: func() any {
+		names := []string{
+			metricGCMarkAssistCPU,
+			metricGCMarkDedicatedCPU,
+			metricGCMarkIdleCPU,
+			metricGCPauseCPU,
+			metricGCTotalCPU,
+			metricTotalCPU,
+			metricGCCyclesAutomatic,
+			metricGCCyclesForced,
+			metricGCHeapLive,
+			metricGCPercent,
+			metricGCMemoryLimit,
+			metricGCPauses,
+		}
+		samples := make([]metrics.Sample, len(names))
+		for i, name := range names {
+			samples[i].Name = name
+		}
+		return &samples
+	},
+}
+
+// readRuntimeMetrics fills the GC CPU breakdown, cycle counters, live heap,
+// GC tuning and pause histogram from runtime/metrics. Metrics not supported by the running
+// Go version stay zero.
+func (m *GCMetrics) readRuntimeMetrics() {
+	samplesPtr, ok := runtimeSamplesPool.Get().(*[]metrics.Sample)
+	if !ok {
+		return
+	}
+	defer runtimeSamplesPool.Put(samplesPtr)
+
+	samples := *samplesPtr
+	metrics.Read(samples)
+
+	for _, s := range samples {
+		switch s.Value.Kind() {
+		case metrics.KindFloat64:
+			v := s.Value.Float64()
+			switch s.Name {
+			case metricGCMarkAssistCPU:
+				m.GCMarkAssistCPU = v
+			case metricGCMarkDedicatedCPU:
+				m.GCMarkDedicatedCPU = v
+			case metricGCMarkIdleCPU:
+				m.GCMarkIdleCPU = v
+			case metricGCPauseCPU:
+				m.GCPauseCPU = v
+			case metricGCTotalCPU:
+				m.GCTotalCPU = v
+			case metricTotalCPU:
+				m.TotalCPU = v
+			}
+		case metrics.KindUint64:
+			v := s.Value.Uint64()
+			switch s.Name {
+			case metricGCCyclesAutomatic:
+				m.GCCyclesAutomatic = v
+			case metricGCCyclesForced:
+				m.GCCyclesForced = v
+			case metricGCHeapLive:
+				m.HeapLive = v
+			case metricGCPercent:
+				// GOGC=off reads as a negative value stored in a uint64
+				m.GOGC = max(int(int64(v)), -1)
+			case metricGCMemoryLimit:
+				m.GOMemLimit = v
+			}
+		case metrics.KindFloat64Histogram:
+			if s.Name == metricGCPauses {
+				if m.pooled {
+					m.PauseHistogram = newPauseHistogramPooled(s.Value.Float64Histogram())
+				} else {
+					m.PauseHistogram = newPauseHistogram(s.Value.Float64Histogram())
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package types
+
+import (
+	"math"
+	"runtime"
+)
+
+// Allocation size classes, following the runtime allocator's own split
+const (
+	SizeClassTiny  = "tiny"  // noscan objects under 16 B, packed by the tiny allocator
+	SizeClassSmall = "small" // up to 32 KB, served from per-P span caches
+	SizeClassLarge = "large" // over 32 KB, allocated directly from the heap
+)
+
+// Size class upper bounds in bytes (inclusive for small, exclusive for tiny)
+const (
+	TinyAllocMaxSize  = 16
+	SmallAllocMaxSize = 32 << 10
+)
+
+// SizeClassBucket aggregates sampled allocations of one size class
+type SizeClassBucket struct {
+	Class   string  `json:"class"`
+	Objects uint64  `json:"objects"` // estimated allocated objects
+	Bytes   uint64  `json:"bytes"`   // estimated allocated bytes
+	Share   float64 `json:"share"`   // share of allocated bytes across all buckets, 0-1
+}
+
+// SizeClassDistribution is the sampled allocation profile grouped by size class.
+// Buckets are always ordered tiny, small, large.
+type SizeClassDistribution struct {
+	Buckets    []SizeClassBucket `json:"buckets"`
+	Records    int               `json:"records"`     // profile records aggregated
+	SampleRate int               `json:"sample_rate"` // runtime.MemProfileRate at capture time
+}
+
+// Dominant returns the bucket with the largest share of allocated bytes,
+// or nil when nothing was sampled
+func (d *SizeClassDistribution) Dominant() *SizeClassBucket {
+	if d == nil {
+		return nil
+	}
+	var dominant *SizeClassBucket
+	for i := range d.Buckets {
+		if d.Buckets[i].Bytes > 0 && (dominant == nil || d.Buckets[i].Bytes > dominant.Bytes) {
+			dominant = &d.Buckets[i]
+		}
+	}
+	return dominant
+}
+
+// SizeClassOf returns the size class an object of the given size falls into
+func SizeClassOf(size uint64) string {
+	switch {
+	case size < TinyAllocMaxSize:
+		return SizeClassTiny
+	case size <= SmallAllocMaxSize:
+		return SizeClassSmall
+	default:
+		return SizeClassLarge
+	}
+}
+
+// BucketMemProfile groups memory profile records by size class. Each record
+// is classified by its average object size, and its counts are scaled up to
+// undo the sampling bias towards large allocations, as pprof does.
+// rate is the runtime.MemProfileRate the records were sampled at.
+func BucketMemProfile(records []runtime.MemProfileRecord, rate int) *SizeClassDistribution {
+	d := &SizeClassDistribution{
+		Buckets: []SizeClassBucket{
+			{Class: SizeClassTiny},
+			{Class: SizeClassSmall},
+			{Class: SizeClassLarge},
+		},
+		Records:    len(records),
+		SampleRate: rate,
+	}
+
+	var total uint64
+	for i := range records {
+		objects, bytes := scaleHeapSample(records[i].AllocObjects, records[i].AllocBytes, rate)
+		if objects <= 0 || bytes <= 0 {
+			continue
+		}
+
+		var bucket *SizeClassBucket
+		switch SizeClassOf(uint64(bytes / objects)) {
+		case SizeClassTiny:
+			bucket = &d.Buckets[0]
+		case SizeClassSmall:
+			bucket = &d.Buckets[1]
+		default:
+			bucket = &d.Buckets[2]
+		}
+		bucket.Objects += uint64(objects)
+		bucket.Bytes += uint64(bytes)
+		total += uint64(bytes)
+	}
+
+	if total > 0 {
+		for i := range d.Buckets {
+			d.Buckets[i].Share = float64(d.Buckets[i].Bytes) / float64(total)
+		}
+	}
+
+	return d
+}
+
+// ReadSizeClassDistribution buckets the current process's allocation profile.
+// The profile is cumulative since process start and is only populated when
+// runtime.MemProfileRate is non-zero.
+func ReadSizeClassDistribution() *SizeClassDistribution {
+	return BucketMemProfile(readMemProfileRecords(), runtime.MemProfileRate)
+}
+
+// readMemProfileRecords returns every record of the current memory profile
+func readMemProfileRecords() []runtime.MemProfileRecord {
+	n, _ := runtime.MemProfile(nil, true)
+	for {
+		// Leave headroom for records added between the two calls
+		records := make([]runtime.MemProfileRecord, n+50)
+		var ok bool
+		if n, ok = runtime.MemProfile(records, true); ok {
+			return records[:n]
+		}
+	}
+}
+
+// scaleHeapSample estimates the unsampled object count and size of a profile
+// record. An allocation of size s is sampled with probability 1-exp(-s/rate).
+func scaleHeapSample(count, size int64, rate int) (int64, int64) {
+	if count <= 0 || size <= 0 {
+		return 0, 0
+	}
+	if rate <= 1 {
+		// Every allocation was recorded
+		return count, size
+	}
+
+	avgSize := float64(size) / float64(count)
+	scale := 1 / (1 - math.Exp(-avgSize/float64(rate)))
+	return int64(float64(count) * scale), int64(float64(size) * scale)
+}
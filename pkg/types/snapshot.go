@@ -0,0 +1,84 @@
+package types
+
+import (
+	"runtime"
+	"time"
+)
+
+// GCSnapshot is a reading of the runtime's cumulative GC and allocation
+// counters. The delta between two snapshots measures the GC impact of the
+// work done in between, such as a request or batch, without a collector.
+type GCSnapshot struct {
+	Timestamp    time.Time `json:"timestamp"`
+	NumGC        uint32    `json:"num_gc"`
+	NumForcedGC  uint32    `json:"num_forced_gc"`
+	PauseTotalNs uint64    `json:"pause_total_ns"`
+	TotalAlloc   uint64    `json:"total_alloc"`
+	Mallocs      uint64    `json:"mallocs"`
+	Frees        uint64    `json:"frees"`
+	HeapAlloc    uint64    `json:"heap_alloc"`
+
+	// pauseNs holds the recent pause durations, for the longest pause of a delta
+	pauseNs [256]uint64
+}
+
+// GCDelta is the GC activity between two snapshots. Counters are
+// process-wide, so work done by other goroutines in between counts too.
+type GCDelta struct {
+	Duration     time.Duration `json:"duration"`
+	GCCount      uint32        `json:"gc_count"`
+	ForcedGCs    uint32        `json:"forced_gcs"`
+	PauseTime    time.Duration `json:"pause_time"`    // total stop-the-world pause time
+	MaxPause     time.Duration `json:"max_pause"`     // longest pause, over the last 256 cycles at most
+	AllocBytes   uint64        `json:"alloc_bytes"`   // bytes allocated
+	AllocObjects uint64        `json:"alloc_objects"` // objects allocated
+	FreedObjects uint64        `json:"freed_objects"`
+	HeapGrowth   int64         `json:"heap_growth"` // change in heap bytes allocated, negative when it shrank
+}
+
+// ReadGCSnapshot reads the current counters. It reads runtime.MemStats,
+// which briefly stops the world, so measure coarse units of work such as a
+// request rather than tight loops.
+func ReadGCSnapshot() *GCSnapshot {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	return &GCSnapshot{
+		Timestamp:    time.Now(),
+		NumGC:        m.NumGC,
+		NumForcedGC:  m.NumForcedGC,
+		PauseTotalNs: m.PauseTotalNs,
+		TotalAlloc:   m.TotalAlloc,
+		Mallocs:      m.Mallocs,
+		Frees:        m.Frees,
+		HeapAlloc:    m.HeapAlloc,
+		pauseNs:      m.PauseNs,
+	}
+}
+
+// Delta returns the GC activity from s until now
+func (s *GCSnapshot) Delta() *GCDelta {
+	return s.DeltaTo(ReadGCSnapshot())
+}
+
+// DeltaTo returns the GC activity from s until a later snapshot
+func (s *GCSnapshot) DeltaTo(later *GCSnapshot) *GCDelta {
+	d := &GCDelta{
+		Duration:     later.Timestamp.Sub(s.Timestamp),
+		GCCount:      later.NumGC - s.NumGC,
+		ForcedGCs:    later.NumForcedGC - s.NumForcedGC,
+		PauseTime:    time.Duration(later.PauseTotalNs - s.PauseTotalNs),
+		AllocBytes:   later.TotalAlloc - s.TotalAlloc,
+		AllocObjects: later.Mallocs - s.Mallocs,
+		FreedObjects: later.Frees - s.Frees,
+		HeapGrowth:   int64(later.HeapAlloc) - int64(s.HeapAlloc),
+	}
+
+	// The most recent pause is at PauseNs[(NumGC+255)%256]
+	cycles := min(d.GCCount, uint32(len(later.pauseNs)))
+	for i := uint32(0); i < cycles; i++ {
+		pause := time.Duration(later.pauseNs[(later.NumGC-i+255)%256])
+		d.MaxPause = max(d.MaxPause, pause)
+	}
+	return d
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+var snapshotSink [][]byte
+
+func TestGCSnapshot_Delta(t *testing.T) {
+	snap := ReadGCSnapshot()
+	for i := 0; i < 100; i++ {
+		snapshotSink = append(snapshotSink, make([]byte, 1024))
+	}
+	runtime.GC()
+	runtime.GC()
+	d := snap.Delta()
+	snapshotSink = nil
+
+	if d.GCCount < 2 || d.ForcedGCs < 2 {
+		t.Errorf("Expected at least 2 forced GCs, got %d (%d forced)", d.GCCount, d.ForcedGCs)
+	}
+	if d.AllocBytes < 100*1024 || d.AllocObjects < 100 {
+		t.Errorf("Expected at least 100 KB in 100 objects, got %d bytes in %d objects", d.AllocBytes, d.AllocObjects)
+	}
+	if d.PauseTime <= 0 || d.MaxPause <= 0 || d.MaxPause > d.PauseTime {
+		t.Errorf("Unexpected pause time %v with max %v", d.PauseTime, d.MaxPause)
+	}
+	if d.Duration <= 0 {
+		t.Errorf("Expected a positive duration, got %v", d.Duration)
+	}
+}
+
+func TestGCSnapshot_DeltaTo(t *testing.T) {
+	start := time.Now()
+	before := &GCSnapshot{Timestamp: start, NumGC: 255, PauseTotalNs: 1000, TotalAlloc: 500, HeapAlloc: 400}
+	after := &GCSnapshot{Timestamp: start.Add(time.Second), NumGC: 257, PauseTotalNs: 1900, TotalAlloc: 800, HeapAlloc: 300}
+	after.pauseNs[254] = 5000 // cycle 255, before the window
+	after.pauseNs[255] = 600  // cycle 256
+	after.pauseNs[0] = 300    // cycle 257
+
+	d := before.DeltaTo(after)
+	if d.GCCount != 2 || d.PauseTime != 900 || d.MaxPause != 600 || d.AllocBytes != 300 || d.HeapGrowth != -100 || d.Duration != time.Second {
+		t.Errorf("DeltaTo() = %+v", d)
+	}
+}
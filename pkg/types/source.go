@@ -0,0 +1,64 @@
+package types
+
+import (
+	"errors"
+	"io"
+)
+
+// Source yields GC data from an arbitrary origin, such as an APM export or a
+// vendor format, so it can be analyzed without changes to this module.
+// Each call to Next returns the next sample, the next event, or both, in
+// chronological order; either may be nil. Next returns io.EOF once the source
+// is exhausted.
+type Source interface {
+	Next() (*GCMetrics, *GCEvent, error)
+}
+
+// SliceSource is a Source over in-memory samples and events
+type SliceSource struct {
+	metrics []*GCMetrics
+	events  []*GCEvent
+}
+
+// NewSliceSource creates a Source yielding metrics and events in order
+func NewSliceSource(metrics []*GCMetrics, events []*GCEvent) *SliceSource {
+	return &SliceSource{metrics: metrics, events: events}
+}
+
+// Next implements Source
+func (s *SliceSource) Next() (*GCMetrics, *GCEvent, error) {
+	if len(s.metrics) == 0 && len(s.events) == 0 {
+		return nil, nil, io.EOF
+	}
+	var m *GCMetrics
+	var e *GCEvent
+	if len(s.metrics) > 0 {
+		m, s.metrics = s.metrics[0], s.metrics[1:]
+	}
+	if len(s.events) > 0 {
+		e, s.events = s.events[0], s.events[1:]
+	}
+	return m, e, nil
+}
+
+// ReadSource drains src, collecting its samples and events. It returns the
+// first error other than io.EOF.
+func ReadSource(src Source) ([]*GCMetrics, []*GCEvent, error) {
+	var metrics []*GCMetrics
+	var events []*GCEvent
+	for {
+		m, e, err := src.Next()
+		if errors.Is(err, io.EOF) {
+			return metrics, events, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if m != nil {
+			metrics = append(metrics, m)
+		}
+		if e != nil {
+			events = append(events, e)
+		}
+	}
+}
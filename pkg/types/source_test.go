@@ -0,0 +1,51 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestSliceSource(t *testing.T) {
+	metrics := []*GCMetrics{{NumGC: 1}, {NumGC: 2}, {NumGC: 3}}
+	events := []*GCEvent{{Sequence: 1}}
+
+	gotMetrics, gotEvents, err := ReadSource(NewSliceSource(metrics, events))
+	if err != nil {
+		t.Fatalf("ReadSource() error: %v", err)
+	}
+	if len(gotMetrics) != 3 || gotMetrics[2].NumGC != 3 {
+		t.Errorf("Metrics = %+v", gotMetrics)
+	}
+	if len(gotEvents) != 1 || gotEvents[0].Sequence != 1 {
+		t.Errorf("Events = %+v", gotEvents)
+	}
+}
+
+// failingSource yields one sample and then fails
+type failingSource struct{ done bool }
+
+func (s *failingSource) Next() (*GCMetrics, *GCEvent, error) {
+	if s.done {
+		return nil, nil, errors.New("connection reset")
+	}
+	s.done = true
+	return &GCMetrics{}, nil, nil
+}
+
+// wrappedEOFSource reports exhaustion with a wrapped io.EOF
+type wrappedEOFSource struct{}
+
+func (wrappedEOFSource) Next() (*GCMetrics, *GCEvent, error) {
+	return nil, nil, fmt.Errorf("export drained: %w", io.EOF)
+}
+
+func TestReadSource_Errors(t *testing.T) {
+	if _, _, err := ReadSource(&failingSource{}); err == nil || err.Error() != "connection reset" {
+		t.Errorf("ReadSource() error = %v, want the source's error", err)
+	}
+	if metrics, _, err := ReadSource(wrappedEOFSource{}); err != nil || len(metrics) != 0 {
+		t.Errorf("ReadSource() = %d samples, %v; want a wrapped io.EOF to end the source", len(metrics), err)
+	}
+}
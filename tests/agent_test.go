@@ -0,0 +1,280 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+)
+
+func TestRemoteSampler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+	target := httptest.NewServer(mux)
+	defer target.Close()
+
+	sample, err := gcanalyzer.RemoteSampler(target.URL, gcanalyzer.RemoteExpvar)
+	if err != nil {
+		t.Fatalf("RemoteSampler() error: %v", err)
+	}
+
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{
+		Interval:   10 * time.Millisecond,
+		MaxSamples: 100,
+		Sampler:    sample,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := monitor.Start(ctx); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer monitor.Stop()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(monitor.GetMetrics()) < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	metrics := monitor.GetMetrics()
+	if len(metrics) < 3 {
+		t.Fatalf("Expected samples from the remote target, got %d", len(metrics))
+	}
+	if metrics[0].Sys == 0 || metrics[0].HeapAlloc == 0 {
+		t.Errorf("Remote sample is missing MemStats: %+v", metrics[0])
+	}
+}
+
+func TestRemoteSampler_Errors(t *testing.T) {
+	if _, err := gcanalyzer.RemoteSampler("http://localhost", "xml"); !errors.Is(err, gcanalyzer.ErrUnknownRemoteFormat) {
+		t.Errorf("Expected ErrUnknownRemoteFormat, got %v", err)
+	}
+
+	target := httptest.NewServer(http.NotFoundHandler())
+	defer target.Close()
+	sample, err := gcanalyzer.RemoteSampler(target.URL, gcanalyzer.RemotePprof)
+	if err != nil {
+		t.Fatalf("RemoteSampler() error: %v", err)
+	}
+	if _, err := sample(context.Background()); !errors.Is(err, gcanalyzer.ErrRemoteUnavailable) {
+		t.Errorf("Expected ErrRemoteUnavailable, got %v", err)
+	}
+}
+
+func TestMonitor_MetricsHandler(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{Interval: time.Second})
+
+	rec := httptest.NewRecorder()
+	monitor.MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 before any analysis, got %d", rec.Code)
+	}
+
+	if err := monitor.InjectChaos(gcanalyzer.ChaosThrash, 5); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+	rec = httptest.NewRecorder()
+	monitor.MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	if !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain") {
+		t.Errorf("Unexpected content type %q", rec.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(rec.Body.String(), "gc_frequency_total") {
+		t.Errorf("Expected Prometheus metrics, got:\n%s", rec.Body.String())
+	}
+	// A live scrape leaves the timestamp to the scraper
+	for _, line := range strings.Split(rec.Body.String(), "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// Drop the labels, which may contain spaces, leaving a name and a value
+		if i := strings.LastIndexByte(line, '}'); i >= 0 {
+			line = line[:strings.IndexByte(line, '{')] + line[i+1:]
+		}
+		if fields := strings.Fields(line); len(fields) != 2 {
+			t.Errorf("Metric %q should have no timestamp", line)
+		}
+	}
+}
+
+func TestMonitor_HealthHandler(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{Interval: time.Second})
+
+	rec := httptest.NewRecorder()
+	monitor.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	var health gcanalyzer.HealthCheckStatus
+	if err := json.Unmarshal(rec.Body.Bytes(), &health); err != nil {
+		t.Fatalf("Invalid health JSON: %v", err)
+	}
+	if rec.Code != http.StatusOK || health.Status != "unknown" {
+		t.Errorf("Expected 200 unknown at startup, got %d %q", rec.Code, health.Status)
+	}
+
+	if err := monitor.InjectChaos(gcanalyzer.ChaosThrash, 10); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+	rec = httptest.NewRecorder()
+	monitor.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if err := json.Unmarshal(rec.Body.Bytes(), &health); err != nil {
+		t.Fatalf("Invalid health JSON: %v", err)
+	}
+	want := http.StatusOK
+	if health.Status == "critical" {
+		want = http.StatusServiceUnavailable
+	}
+	if rec.Code != want {
+		t.Errorf("Status %q should respond %d, got %d", health.Status, want, rec.Code)
+	}
+}
+
+func TestMonitor_HealthCheckConfig(t *testing.T) {
+	// The leak scenario pauses for 1ms per GC, well within the default limit
+	config := gcanalyzer.DefaultHealthCheckConfig()
+	config.AvgPauseLong = 500 * time.Microsecond
+	config.PenaltyAvgPause = 100
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{Interval: time.Second, HealthCheck: &config})
+	if err := monitor.InjectChaos(gcanalyzer.ChaosLeak, 5); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	s := monitor.Snapshot()
+	if s == nil || s.Health == nil {
+		t.Fatal("Expected a health check in the snapshot")
+	}
+	if s.Health.Status != "critical" || s.Health.Score != 0 {
+		t.Errorf("Expected the configured pause penalty to fail the check, got %+v", s.Health)
+	}
+}
+
+func TestMonitor_HealthProfile_Unknown(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{HealthProfile: "realtime"})
+	if err := monitor.Start(context.Background()); !errors.Is(err, gcanalyzer.ErrUnknownHealthProfile) {
+		monitor.Stop()
+		t.Errorf("Expected ErrUnknownHealthProfile, got %v", err)
+	}
+}
+
+func TestMonitor_Retention_Invalid(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{Retention: &gcanalyzer.RetentionPolicy{}})
+	if err := monitor.Start(context.Background()); !errors.Is(err, gcanalyzer.ErrInvalidRetention) {
+		monitor.Stop()
+		t.Errorf("Expected ErrInvalidRetention, got %v", err)
+	}
+}
+
+func TestMonitor_AdaptiveInterval(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{AdaptiveInterval: &gcanalyzer.AdaptiveInterval{}})
+	if got := monitor.CollectionInterval(); got != gcanalyzer.DefaultAdaptiveInterval().Max {
+		t.Errorf("Expected collection to start at the quiet interval, got %v", got)
+	}
+
+	monitor = gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{AdaptiveInterval: &gcanalyzer.AdaptiveInterval{Min: time.Second, Max: time.Millisecond}})
+	if err := monitor.Start(context.Background()); !errors.Is(err, gcanalyzer.ErrInvalidInterval) {
+		monitor.Stop()
+		t.Errorf("Expected ErrInvalidInterval, got %v", err)
+	}
+}
+
+func TestMonitor_IntervalJitter_Invalid(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{IntervalJitter: 1.5})
+	if err := monitor.Start(context.Background()); !errors.Is(err, gcanalyzer.ErrInvalidInterval) {
+		monitor.Stop()
+		t.Errorf("Expected ErrInvalidInterval, got %v", err)
+	}
+}
+
+func TestMonitor_PauseResume(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{Interval: 5 * time.Millisecond})
+	if err := monitor.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer monitor.Stop()
+
+	monitor.Pause()
+	if !monitor.IsPaused() {
+		t.Fatal("Expected the monitor to be paused")
+	}
+	time.Sleep(10 * time.Millisecond) // let an in-flight sample land
+	count := len(monitor.GetMetrics())
+	time.Sleep(30 * time.Millisecond)
+	if got := len(monitor.GetMetrics()); got != count {
+		t.Errorf("Collected %d samples while paused", got-count)
+	}
+
+	monitor.Resume()
+	time.Sleep(30 * time.Millisecond)
+	if monitor.IsPaused() || len(monitor.GetMetrics()) <= count {
+		t.Error("Expected sampling to resume, keeping earlier samples")
+	}
+}
+
+func TestMonitor_AnalysisCache(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{Interval: 5 * time.Millisecond})
+	if err := monitor.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer monitor.Stop()
+
+	time.Sleep(30 * time.Millisecond)
+	monitor.Pause()
+	time.Sleep(10 * time.Millisecond) // let an in-flight sample land
+
+	first, err := monitor.GetCurrentAnalysis()
+	if err != nil {
+		t.Fatalf("GetCurrentAnalysis() error: %v", err)
+	}
+	if again, _ := monitor.GetCurrentAnalysis(); again != first {
+		t.Error("Expected the cached analysis while no samples arrive")
+	}
+
+	monitor.Resume()
+	time.Sleep(30 * time.Millisecond)
+	if next, _ := monitor.GetCurrentAnalysis(); next == first {
+		t.Error("Expected new samples to invalidate the cached analysis")
+	}
+}
+
+func TestMonitor_Restart(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{Interval: 5 * time.Millisecond})
+	for i := 0; i < 2; i++ {
+		if err := monitor.Start(context.Background()); err != nil {
+			t.Fatalf("Start() %d error: %v", i, err)
+		}
+		time.Sleep(20 * time.Millisecond)
+		if err := monitor.StopE(); err != nil {
+			t.Errorf("StopE() %d error: %v", i, err)
+		}
+	}
+	if err := monitor.StopE(); !errors.Is(err, gcanalyzer.ErrCollectorNotRunning) {
+		t.Errorf("StopE() on a stopped monitor = %v, want ErrCollectorNotRunning", err)
+	}
+}
+
+func TestMonitor_GetHealthHistory(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{Interval: time.Second})
+	if got := monitor.GetHealthHistory(); len(got) != 0 {
+		t.Errorf("Expected no history before any sample, got %+v", got)
+	}
+
+	if err := monitor.InjectChaos(gcanalyzer.ChaosThrash, 10); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+	history := monitor.GetHealthHistory()
+	if len(history) == 0 {
+		t.Fatal("Expected health scores once samples are analyzed")
+	}
+	latest := monitor.Snapshot().Health
+	if last := history[len(history)-1]; last.Score != latest.Score || last.Status != latest.Status {
+		t.Errorf("Last point %+v does not match the snapshot's health %+v", last, latest)
+	}
+	if latest.Trend == "" {
+		t.Error("Expected a trend on the snapshot's health check")
+	}
+}
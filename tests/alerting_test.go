@@ -0,0 +1,311 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+)
+
+func TestMonitor_AddAlertRule(t *testing.T) {
+	var mu sync.Mutex
+	var alerts []*gcanalyzer.Alert
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{
+		Interval:                 time.Second,
+		DisableDefaultAlertRules: true,
+		OnAlert: func(a *gcanalyzer.Alert) {
+			mu.Lock()
+			alerts = append(alerts, a)
+			mu.Unlock()
+		},
+	})
+	if len(monitor.AlertRules()) != 0 {
+		t.Fatalf("Expected no rules, got %+v", monitor.AlertRules())
+	}
+
+	rule, err := gcanalyzer.ParseAlertRule("heap_growth_rate > 32MB/s over 3s for 2 windows")
+	if err != nil {
+		t.Fatalf("ParseAlertRule() error: %v", err)
+	}
+	if err := monitor.AddAlertRule(rule); err != nil {
+		t.Fatalf("AddAlertRule() error: %v", err)
+	}
+	if err := monitor.AddAlertRule(gcanalyzer.AlertRule{Metric: "latency"}); !errors.Is(err, gcanalyzer.ErrInvalidAlertRule) {
+		t.Errorf("Expected ErrInvalidAlertRule, got %v", err)
+	}
+
+	// The leak scenario grows the heap by 64 MB per one-second sample
+	if err := monitor.InjectChaos(gcanalyzer.ChaosLeak, 10); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(alerts) != 1 {
+		t.Fatalf("Expected one alert while the condition holds, got %d", len(alerts))
+	}
+	a := alerts[0]
+	if a.Rule != rule.Name || a.Type != "memory" || a.Severity != "warning" || a.Value != 64<<20 {
+		t.Errorf("Unexpected alert %+v", a)
+	}
+}
+
+func TestMonitor_DefaultAlertRules(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(nil)
+	if got, want := len(monitor.AlertRules()), len(gcanalyzer.DefaultAlertRules()); got != want {
+		t.Errorf("Monitor has %d rules, want the %d defaults", got, want)
+	}
+}
+
+// collectAlerts returns a monitor config recording alerts and resolutions
+func collectAlerts(mu *sync.Mutex, alerts *[]*gcanalyzer.Alert) *gcanalyzer.MonitorConfig {
+	record := func(a *gcanalyzer.Alert) {
+		mu.Lock()
+		*alerts = append(*alerts, a)
+		mu.Unlock()
+	}
+	return &gcanalyzer.MonitorConfig{Interval: time.Second, OnAlert: record, OnAlertResolved: record}
+}
+
+func TestMonitor_OOMForecastTransitions(t *testing.T) {
+	var mu sync.Mutex
+	var alerts []*gcanalyzer.Alert
+	config := collectAlerts(&mu, &alerts)
+	config.MemoryLimit = 1 << 30
+	monitor := gcanalyzer.NewMonitor(config)
+
+	if err := monitor.InjectChaos(gcanalyzer.ChaosLeak, 10); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var severities []string
+	for _, a := range alerts {
+		if a.Type == "memory" {
+			severities = append(severities, a.Severity)
+		}
+	}
+	// One alert when the forecast crosses each threshold, not one per sample
+	if len(severities) == 0 || len(severities) > 2 || severities[len(severities)-1] != "critical" {
+		t.Errorf("Memory alerts = %v, want at most a warning then a critical", severities)
+	}
+}
+
+func TestMonitor_AlertCooldown(t *testing.T) {
+	for _, tt := range []struct {
+		cooldown time.Duration
+		want     int
+	}{
+		{0, 2},
+		{time.Hour, 1},
+	} {
+		var mu sync.Mutex
+		var alerts []*gcanalyzer.Alert
+		config := collectAlerts(&mu, &alerts)
+		config.DisableDefaultAlertRules = true
+		config.AlertCooldowns = map[string]time.Duration{"memory": tt.cooldown}
+		monitor := gcanalyzer.NewMonitor(config)
+
+		// Both rules raise warning memory alerts as the heap leaks
+		for _, expr := range []string{"heap_alloc > 256MB", "heap_growth_rate > 32MB/s"} {
+			rule, err := gcanalyzer.ParseAlertRule(expr)
+			if err != nil {
+				t.Fatalf("ParseAlertRule() error: %v", err)
+			}
+			if err := monitor.AddAlertRule(rule); err != nil {
+				t.Fatalf("AddAlertRule() error: %v", err)
+			}
+		}
+		if err := monitor.InjectChaos(gcanalyzer.ChaosLeak, 10); err != nil {
+			t.Fatalf("InjectChaos() error: %v", err)
+		}
+
+		mu.Lock()
+		if len(alerts) != tt.want {
+			t.Errorf("Cooldown %v: got %d alerts, want %d", tt.cooldown, len(alerts), tt.want)
+		}
+		mu.Unlock()
+
+		// Suppressed alerts stay in the history, marked as such
+		var suppressed int
+		records := monitor.GetAlerts(time.Time{})
+		for _, r := range records {
+			if r.Alert.Suppressed {
+				suppressed++
+			}
+		}
+		if len(records) != 2 || suppressed != 2-tt.want {
+			t.Errorf("Cooldown %v: history has %d alerts, %d suppressed, want 2 and %d", tt.cooldown, len(records), suppressed, 2-tt.want)
+		}
+	}
+}
+
+func TestMonitor_AlertCooldownExpiry(t *testing.T) {
+	var mu sync.Mutex
+	var alerts []*gcanalyzer.Alert
+	config := collectAlerts(&mu, &alerts)
+	config.DisableDefaultAlertRules = true
+	config.AlertCooldowns = map[string]time.Duration{"memory": 50 * time.Millisecond}
+	monitor := gcanalyzer.NewMonitor(config)
+	for _, expr := range []string{"heap_alloc > 256MB", "heap_growth_rate > 32MB/s"} {
+		rule, err := gcanalyzer.ParseAlertRule(expr)
+		if err != nil {
+			t.Fatalf("ParseAlertRule() error: %v", err)
+		}
+		if err := monitor.AddAlertRule(rule); err != nil {
+			t.Fatalf("AddAlertRule() error: %v", err)
+		}
+	}
+	if err := monitor.InjectChaos(gcanalyzer.ChaosLeak, 10); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	// Both conditions still hold once the cooldown has run out, so the
+	// suppressed alert is delivered with the next sample
+	time.Sleep(100 * time.Millisecond)
+	if err := monitor.InjectChaos(gcanalyzer.ChaosLeak, 1); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	rules := make(map[string]bool)
+	for _, a := range alerts {
+		rules[a.Rule] = true
+	}
+	if len(alerts) != 2 || len(rules) != 2 {
+		t.Errorf("Got %d alerts from %d rules, want one from each of 2", len(alerts), len(rules))
+	}
+}
+
+func TestMonitor_GetAlerts(t *testing.T) {
+	// No callbacks: the history alone keeps alerts
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{Interval: time.Second})
+	start := time.Now()
+	if err := monitor.InjectChaos(gcanalyzer.ChaosPauseStorm, 3); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	records := monitor.GetAlerts(start)
+	if len(records) == 0 {
+		t.Fatal("Expected the pause storm in the alert history")
+	}
+	if a := records[0].Alert; a.Type != "pause" || !records[0].Active() {
+		t.Errorf("Unexpected record %+v", records[0])
+	}
+	if got := monitor.GetAlerts(time.Now().Add(time.Minute)); len(got) != 0 {
+		t.Errorf("Expected no alerts in the future, got %d", len(got))
+	}
+
+	disabled := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{Interval: time.Second, MaxAlerts: -1})
+	if err := disabled.InjectChaos(gcanalyzer.ChaosPauseStorm, 3); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+	if got := disabled.GetAlerts(time.Time{}); got != nil {
+		t.Errorf("Expected no history with negative MaxAlerts, got %d records", len(got))
+	}
+}
+
+func TestMonitor_PauseSLO(t *testing.T) {
+	var mu sync.Mutex
+	var alerts []*gcanalyzer.Alert
+	config := collectAlerts(&mu, &alerts)
+	config.Interval = time.Minute
+	config.DisableDefaultAlertRules = true
+	config.PauseSLO = &gcanalyzer.PauseSLO{MaxPause: 50 * time.Millisecond, Objective: 0.99}
+	monitor := gcanalyzer.NewMonitor(config)
+
+	// Every one-minute window of the storm has a 750ms pause
+	if err := monitor.InjectChaos(gcanalyzer.ChaosPauseStorm, 10); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	mu.Lock()
+	if len(alerts) != 1 || alerts[0].Type != "slo" || alerts[0].Severity != "critical" {
+		t.Errorf("Expected one critical SLO alert, got %+v", alerts)
+	}
+	mu.Unlock()
+
+	status, err := monitor.PauseSLOStatus()
+	if err != nil {
+		t.Fatalf("PauseSLOStatus() error: %v", err)
+	}
+	if status.Windows == 0 || status.BadWindows != status.Windows || status.Compliance != 0 {
+		t.Errorf("Expected only bad windows, got %+v", status)
+	}
+	if status.FastBurnRate < status.FastBurnThreshold {
+		t.Errorf("FastBurnRate %v below threshold %v", status.FastBurnRate, status.FastBurnThreshold)
+	}
+
+	if _, err := gcanalyzer.NewMonitor(nil).PauseSLOStatus(); !errors.Is(err, gcanalyzer.ErrSLODisabled) {
+		t.Errorf("Expected ErrSLODisabled, got %v", err)
+	}
+	invalid := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{PauseSLO: &gcanalyzer.PauseSLO{Objective: 99}})
+	if err := invalid.Start(context.Background()); !errors.Is(err, gcanalyzer.ErrInvalidSLO) {
+		t.Errorf("Expected ErrInvalidSLO, got %v", err)
+	}
+}
+
+func TestMonitor_Labels(t *testing.T) {
+	var mu sync.Mutex
+	var alerts []*gcanalyzer.Alert
+	config := collectAlerts(&mu, &alerts)
+	config.Labels = map[string]string{"service": "checkout", "version": "1.4.2"}
+	monitor := gcanalyzer.NewMonitor(config)
+
+	if err := monitor.InjectChaos(gcanalyzer.ChaosThrash, 10); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	mu.Lock()
+	if len(alerts) == 0 {
+		t.Fatal("Expected alerts from the thrash scenario")
+	}
+	for _, a := range alerts {
+		if a.Labels["service"] != "checkout" || a.Labels["version"] != "1.4.2" {
+			t.Errorf("Alert %q has labels %v", a.Message, a.Labels)
+		}
+	}
+	mu.Unlock()
+
+	if got := monitor.Snapshot().Analysis.Labels; got["service"] != "checkout" {
+		t.Errorf("Analysis labels = %v", got)
+	}
+	rec := httptest.NewRecorder()
+	monitor.MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if !strings.Contains(rec.Body.String(), `gc_overhead_percent{service="checkout",version="1.4.2"}`) {
+		t.Errorf("Metrics should carry the labels, got:\n%s", rec.Body.String())
+	}
+}
+
+func TestMonitor_Labels_Invalid(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{Labels: map[string]string{"service-name": "api"}})
+	if err := monitor.Start(context.Background()); !errors.Is(err, gcanalyzer.ErrInvalidLabel) {
+		monitor.Stop()
+		t.Errorf("Expected ErrInvalidLabel, got %v", err)
+	}
+}
+
+func TestMonitor_AlertRulesConfig(t *testing.T) {
+	rule, err := gcanalyzer.ParseAlertRule("heap_alloc > 1GB")
+	if err != nil {
+		t.Fatalf("ParseAlertRule() error: %v", err)
+	}
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{DisableDefaultAlertRules: true, AlertRules: []gcanalyzer.AlertRule{rule}})
+	if got := monitor.AlertRules(); len(got) != 1 || got[0].Name != rule.Name {
+		t.Errorf("AlertRules() = %+v, want the configured rule", got)
+	}
+
+	invalid := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{AlertRules: []gcanalyzer.AlertRule{{Metric: "latency"}}})
+	if err := invalid.Start(context.Background()); !errors.Is(err, gcanalyzer.ErrInvalidAlertRule) {
+		invalid.Stop()
+		t.Errorf("Expected ErrInvalidAlertRule, got %v", err)
+	}
+}
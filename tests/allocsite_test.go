@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+)
+
+var allocSink []byte
+
+func TestMonitor_AllocSites(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{Interval: time.Second})
+	for range 1000 {
+		allocSink = make([]byte, 64<<10)
+	}
+	runtime.GC()
+	if err := monitor.InjectChaos(gcanalyzer.ChaosLeak, 3); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	analysis, err := monitor.GetCurrentAnalysis()
+	if err != nil {
+		t.Fatalf("GetCurrentAnalysis() error: %v", err)
+	}
+	sites := analysis.AllocSites
+	if sites.Top() == nil || sites.Window <= 0 {
+		t.Fatalf("Expected allocation sites over the monitored window, got %+v", sites)
+	}
+	if len(sites.Sites) > gcanalyzer.DefaultTopAllocSites {
+		t.Errorf("Expected at most %d sites, got %d", gcanalyzer.DefaultTopAllocSites, len(sites.Sites))
+	}
+}
+
+func TestMonitor_AllocSitesRemote(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{
+		Interval: time.Second,
+		Sampler:  func(context.Context) (*gcanalyzer.GCMetrics, error) { return gcanalyzer.CollectOnce(), nil },
+	})
+	if err := monitor.InjectChaos(gcanalyzer.ChaosLeak, 3); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	analysis, err := monitor.GetCurrentAnalysis()
+	if err != nil {
+		t.Fatalf("GetCurrentAnalysis() error: %v", err)
+	}
+	if analysis.AllocSites != nil || analysis.SizeClasses != nil {
+		t.Error("The local allocation profile doesn't describe a remote process")
+	}
+}
@@ -1,7 +1,12 @@
 package tests
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
+	"text/template"
 	"time"
 
 	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
@@ -197,3 +202,53 @@ func TestGCMetrics_ToDuration(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateChartSVG(t *testing.T) {
+	var buf bytes.Buffer
+	err := gcanalyzer.GenerateChartSVG(&buf, gcanalyzer.ChartTypeHeapTrend, generateTestMetrics(20), nil, &gcanalyzer.ChartOptions{Width: 640})
+	if err != nil {
+		t.Fatalf("GenerateChartSVG() error: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "<svg ") || !strings.Contains(buf.String(), `width="640"`) {
+		t.Errorf("Expected a 640px wide SVG document, got:\n%s", buf.String())
+	}
+
+	err = gcanalyzer.GenerateChartSVG(&buf, gcanalyzer.ChartTypePauseHistogram, nil, nil, nil)
+	if !errors.Is(err, gcanalyzer.ErrNoEventsData) {
+		t.Errorf("Expected ErrNoEventsData, got %v", err)
+	}
+}
+
+func TestExportChromeTrace(t *testing.T) {
+	var buf bytes.Buffer
+	if err := gcanalyzer.ExportChromeTrace(&buf, generateTestEvents(5)); err != nil {
+		t.Fatalf("ExportChromeTrace() error: %v", err)
+	}
+	var trace struct {
+		TraceEvents []map[string]any `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Fatalf("Output is not JSON: %v", err)
+	}
+	if len(trace.TraceEvents) != 6 {
+		t.Errorf("Got %d trace events, want a process name and 5 cycles", len(trace.TraceEvents))
+	}
+}
+
+func TestGenerateTemplateReport(t *testing.T) {
+	analysis, err := gcanalyzer.Analyze(generateTestMetrics(10))
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	reporter := gcanalyzer.NewReporter(analysis, nil, nil, nil)
+	tmpl := template.Must(template.New("gc").Funcs(reporter.TemplateFuncs()).Parse(
+		`heap={{bytes .Analysis.AvgHeapSize}} health={{.Health.Status}}`))
+
+	var buf bytes.Buffer
+	if err := reporter.GenerateTemplateReport(&buf, tmpl); err != nil {
+		t.Fatalf("GenerateTemplateReport() error: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "heap=") || !strings.Contains(buf.String(), " health=") {
+		t.Errorf("GenerateTemplateReport() = %q", buf.String())
+	}
+}
@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+)
+
+// Compile-time checks for the v1 public API. Changing any of these signatures
+// is a breaking change and requires a new major version.
+var (
+	_ func() *gcanalyzer.GCMetrics                                                                        = gcanalyzer.CollectOnce
+	_ func(context.Context, time.Duration, time.Duration) ([]*gcanalyzer.GCMetrics, error)                = gcanalyzer.CollectForDuration
+	_ func([]*gcanalyzer.GCMetrics) (*gcanalyzer.GCAnalysis, error)                                       = gcanalyzer.Analyze
+	_ func([]*gcanalyzer.GCMetrics, []*gcanalyzer.GCEvent) (*gcanalyzer.GCAnalysis, error)                = gcanalyzer.AnalyzeWithEvents
+	_ func(*gcanalyzer.GCAnalysis, []*gcanalyzer.GCMetrics, []*gcanalyzer.GCEvent, io.Writer) error       = gcanalyzer.GenerateTextReport
+	_ func(*gcanalyzer.GCAnalysis, []*gcanalyzer.GCMetrics, []*gcanalyzer.GCEvent, io.Writer, bool) error = gcanalyzer.GenerateJSONReport
+	_ func(*gcanalyzer.GCAnalysis, io.Writer) error                                                       = gcanalyzer.GenerateSummaryReport
+	_ func(*gcanalyzer.GCAnalysis) *gcanalyzer.HealthCheckStatus                                          = gcanalyzer.GenerateHealthCheck
+	_ func(*gcanalyzer.MonitorConfig) *gcanalyzer.Monitor                                                 = gcanalyzer.NewMonitor
+	_ func([]*gcanalyzer.GCMetrics) []gcanalyzer.MemoryPoint                                              = gcanalyzer.GetMemoryTrend
+	_ func([]*gcanalyzer.GCEvent) map[string]int                                                          = gcanalyzer.GetPauseTimeDistribution
+
+	_ func(*gcanalyzer.Monitor, context.Context) error          = (*gcanalyzer.Monitor).Start
+	_ func(*gcanalyzer.Monitor)                                 = (*gcanalyzer.Monitor).Stop
+	_ func(*gcanalyzer.Monitor) bool                            = (*gcanalyzer.Monitor).IsRunning
+	_ func(*gcanalyzer.Monitor) []*gcanalyzer.GCMetrics         = (*gcanalyzer.Monitor).GetMetrics
+	_ func(*gcanalyzer.Monitor) []*gcanalyzer.GCEvent           = (*gcanalyzer.Monitor).GetEvents
+	_ func(*gcanalyzer.Monitor) *gcanalyzer.GCMetrics           = (*gcanalyzer.Monitor).GetLatestMetrics
+	_ func(*gcanalyzer.Monitor) (*gcanalyzer.GCAnalysis, error) = (*gcanalyzer.Monitor).GetCurrentAnalysis
+)
+
+// jsonKeys returns the top-level keys v marshals to
+func jsonKeys(t *testing.T, v any) map[string]bool {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	keys := make(map[string]bool, len(m))
+	for k := range m {
+		keys[k] = true
+	}
+	return keys
+}
+
+// TestAPICompat_JSONFields guards the serialized field names of the public
+// types, which stored reports and dashboards depend on
+func TestAPICompat_JSONFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		keys  []string
+	}{
+		{
+			name:  "GCMetrics",
+			value: &gcanalyzer.GCMetrics{},
+			keys: []string{
+				"num_gc", "pause_total_ns", "heap_alloc", "heap_sys", "heap_idle",
+				"heap_inuse", "heap_released", "heap_objects", "total_alloc",
+				"mallocs", "frees", "gc_cpu_fraction", "next_gc", "last_gc", "timestamp",
+			},
+		},
+		{
+			name:  "GCAnalysis",
+			value: &gcanalyzer.GCAnalysis{},
+			keys: []string{
+				"period", "start_time", "end_time", "gc_frequency", "avg_gc_interval",
+				"avg_pause_time", "min_pause_time", "max_pause_time", "p95_pause_time",
+				"p99_pause_time", "avg_heap_size", "max_heap_size", "min_heap_size",
+				"heap_growth_rate", "alloc_rate", "alloc_count", "free_count",
+				"gc_overhead", "memory_efficiency", "recommendations",
+			},
+		},
+		{
+			name:  "GCEvent",
+			value: &gcanalyzer.GCEvent{},
+			keys: []string{
+				"sequence", "start_time", "end_time", "duration", "heap_before",
+				"heap_after", "heap_released", "trigger_reason",
+			},
+		},
+		{
+			name:  "HealthCheckStatus",
+			value: &gcanalyzer.HealthCheckStatus{},
+			keys:  []string{"status", "score", "issues", "summary", "last_updated"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := jsonKeys(t, tt.value)
+			for _, k := range tt.keys {
+				if !got[k] {
+					t.Errorf("%s is missing JSON field %q", tt.name, k)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+)
+
+func TestMonitor_SeasonalBaseline_ColdStart(t *testing.T) {
+	// Without learned history the static thresholds still apply
+	var mu sync.Mutex
+	var alerts []*gcanalyzer.Alert
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{
+		Interval:         time.Second,
+		SeasonalBaseline: true,
+		OnAlert: func(a *gcanalyzer.Alert) {
+			mu.Lock()
+			alerts = append(alerts, a)
+			mu.Unlock()
+		},
+	})
+
+	if err := monitor.InjectChaos(gcanalyzer.ChaosThrash, 5); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, a := range alerts {
+		if a.Type == "overhead" {
+			return
+		}
+	}
+	t.Errorf("Expected the static overhead alert before the baseline is learned, got %d alerts", len(alerts))
+}
+
+func TestMonitor_SeasonalBaseline_LearnedBurst(t *testing.T) {
+	// A GC burst the baseline has learned for this time of day is expected:
+	// neither the static frequency and overhead thresholds nor the baseline
+	// itself should alert on it
+	var mu sync.Mutex
+	var alerts []*gcanalyzer.Alert
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{
+		Interval:         time.Second,
+		SeasonalBaseline: true,
+		OnAlert: func(a *gcanalyzer.Alert) {
+			mu.Lock()
+			alerts = append(alerts, a)
+			mu.Unlock()
+		},
+	})
+
+	// The thrash scenario runs 20 GCs a second at 60% GC CPU with a 128 MB heap
+	learned := func(mean float64) []gcanalyzer.BaselineBucket {
+		buckets := make([]gcanalyzer.BaselineBucket, 24*60)
+		for minute := range buckets {
+			buckets[minute] = gcanalyzer.BaselineBucket{Minute: minute, Count: 30, Mean: mean, Days: 30}
+		}
+		return buckets
+	}
+	snap := gcanalyzer.BaselineSnapshot{
+		FormatVersion: 1,
+		Series: map[string][]gcanalyzer.BaselineBucket{
+			"gc_frequency":    learned(20),
+			"heap_alloc":      learned(128 * 1024 * 1024),
+			"gc_cpu_fraction": learned(0.6),
+		},
+	}
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(&snap); err != nil {
+		t.Fatalf("Encode() error: %v", err)
+	}
+	if err := monitor.ImportBaseline(&buf); err != nil {
+		t.Fatalf("ImportBaseline() error: %v", err)
+	}
+
+	// Long enough for the one-minute frequency rule to evaluate
+	if err := monitor.InjectChaos(gcanalyzer.ChaosThrash, 90); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, a := range alerts {
+		switch a.Type {
+		case "frequency", "overhead", "memory":
+			t.Errorf("Unexpected %s alert for a learned burst: %s", a.Type, a.Message)
+		}
+	}
+}
+
+func TestMonitor_ExportImportBaseline(t *testing.T) {
+	var buf bytes.Buffer
+
+	disabled := gcanalyzer.NewMonitor(nil)
+	if err := disabled.ExportBaseline(&buf); !errors.Is(err, gcanalyzer.ErrBaselineDisabled) {
+		t.Errorf("ExportBaseline() error = %v, want ErrBaselineDisabled", err)
+	}
+
+	source := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{SeasonalBaseline: true})
+	if err := source.ExportBaseline(&buf); err != nil {
+		t.Fatalf("ExportBaseline() error: %v", err)
+	}
+
+	target := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{SeasonalBaseline: true})
+	if err := target.ImportBaseline(&buf); err != nil {
+		t.Errorf("ImportBaseline() error: %v", err)
+	}
+	if err := target.ImportBaseline(strings.NewReader(`{"format_version": 99}`)); !errors.Is(err, gcanalyzer.ErrInvalidBaseline) {
+		t.Errorf("ImportBaseline() error = %v, want ErrInvalidBaseline", err)
+	}
+}
@@ -132,6 +132,22 @@ func BenchmarkAnalyzer_AnalyzeLargeDataset(b *testing.B) {
 	}
 }
 
+// BenchmarkAnalyzer_AnalyzeLite measures lite analysis performance with large dataset
+func BenchmarkAnalyzer_AnalyzeLite(b *testing.B) {
+	metrics := generateTestMetrics(1000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		analysis, err := gcanalyzer.AnalyzeLite(metrics)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if analysis == nil {
+			b.Fatal("Expected analysis")
+		}
+	}
+}
+
 // BenchmarkAnalyzer_GetMemoryTrend measures memory trend calculation performance
 func BenchmarkAnalyzer_GetMemoryTrend(b *testing.B) {
 	metrics := generateTestMetrics(100)
@@ -0,0 +1,255 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+)
+
+func TestMonitor_InjectChaos(t *testing.T) {
+	tests := []struct {
+		scenario  gcanalyzer.ChaosScenario
+		alertType string
+		severity  string
+	}{
+		{gcanalyzer.ChaosPauseStorm, "pause", "critical"},
+		{gcanalyzer.ChaosThrash, "overhead", "warning"},
+		{gcanalyzer.ChaosLeak, "memory", "critical"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.scenario), func(t *testing.T) {
+			var mu sync.Mutex
+			var alerts []*gcanalyzer.Alert
+			monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{
+				Interval:    time.Second,
+				MemoryLimit: 1 << 30,
+				OnAlert: func(a *gcanalyzer.Alert) {
+					mu.Lock()
+					alerts = append(alerts, a)
+					mu.Unlock()
+				},
+			})
+
+			if err := monitor.InjectChaos(tt.scenario, 10); err != nil {
+				t.Fatalf("InjectChaos() error: %v", err)
+			}
+
+			metrics := monitor.GetMetrics()
+			if len(metrics) != 10 {
+				t.Fatalf("Expected 10 injected samples, got %d", len(metrics))
+			}
+			if !metrics[0].Synthetic {
+				t.Error("Injected samples should be marked synthetic")
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, a := range alerts {
+				if a.Type == tt.alertType && a.Severity == tt.severity {
+					return
+				}
+			}
+			t.Errorf("Expected a %s %s alert, got %d alerts", tt.severity, tt.alertType, len(alerts))
+		})
+	}
+}
+
+func TestMonitor_InjectChaos_UnknownScenario(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(nil)
+	if err := monitor.InjectChaos("meltdown", 1); !errors.Is(err, gcanalyzer.ErrUnknownScenario) {
+		t.Errorf("Expected ErrUnknownScenario, got %v", err)
+	}
+}
+
+func TestMonitor_AlertLogger(t *testing.T) {
+	var mu sync.Mutex
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&lockedWriter{mu: &mu, w: &buf}, nil))
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{
+		Interval:    time.Second,
+		AlertLogger: logger,
+	})
+
+	if err := monitor.InjectChaos(gcanalyzer.ChaosPauseStorm, 10); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var pauseAlert, transition bool
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var record map[string]any
+		if err := dec.Decode(&record); err != nil {
+			t.Fatalf("Invalid log record: %v", err)
+		}
+		switch {
+		case record["type"] == "pause" && record["level"] == "ERROR":
+			pauseAlert = true
+		case record["msg"] == "GC health status changed" && record["status"] != nil:
+			transition = true
+		}
+	}
+	if !pauseAlert {
+		t.Error("Expected a critical pause alert logged at ERROR")
+	}
+	if !transition {
+		t.Error("Expected a health status transition record")
+	}
+}
+
+// lockedWriter serializes writes from the monitor's goroutines
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// fakeSugared records the calls of a zap-style sugared logger
+type fakeSugared struct{ calls []string }
+
+func (f *fakeSugared) Debugw(msg string, _ ...any) { f.calls = append(f.calls, "debug "+msg) }
+func (f *fakeSugared) Infow(msg string, _ ...any)  { f.calls = append(f.calls, "info "+msg) }
+func (f *fakeSugared) Warnw(msg string, _ ...any)  { f.calls = append(f.calls, "warn "+msg) }
+func (f *fakeSugared) Errorw(msg string, _ ...any) { f.calls = append(f.calls, "error "+msg) }
+
+func TestMonitor_LoggerRecoversCallbackPanics(t *testing.T) {
+	sugared := &fakeSugared{}
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{
+		Interval: time.Second,
+		Logger:   gcanalyzer.ZapLogger(sugared),
+		OnAlert:  func(*gcanalyzer.Alert) { panic("alert handler bug") },
+		OnMetric: func(*gcanalyzer.GCMetrics) { panic("metric handler bug") },
+	})
+
+	if err := monitor.InjectChaos(gcanalyzer.ChaosPauseStorm, 10); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	// Alerts are still recorded and snapshots published after the panics
+	if len(monitor.GetAlerts(time.Time{})) == 0 {
+		t.Error("Expected alerts to be recorded despite the panicking OnAlert")
+	}
+	if monitor.Snapshot() == nil {
+		t.Error("Expected a snapshot despite the panicking OnMetric")
+	}
+	if len(sugared.calls) == 0 || sugared.calls[0] != "error callback panicked" {
+		t.Errorf("Expected panics logged at error, got %v", sugared.calls)
+	}
+}
+
+func TestMonitor_ReportSchedule(t *testing.T) {
+	var mu sync.Mutex
+	var buf bytes.Buffer
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{
+		Interval: time.Hour,
+		ReportSchedule: &gcanalyzer.ReportSchedule{
+			Interval: 10 * time.Millisecond,
+			Format:   gcanalyzer.ReportFormatJSON,
+			Writer:   &lockedWriter{mu: &mu, w: &buf},
+		},
+	})
+	if err := monitor.InjectChaos(gcanalyzer.ChaosLeak, 5); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	if err := monitor.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer monitor.Stop()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		mu.Lock()
+		n := buf.Len()
+		mu.Unlock()
+		if n > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("No scheduled report was written")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	monitor.Stop()
+	mu.Lock()
+	defer mu.Unlock()
+	var report map[string]any
+	if err := json.NewDecoder(&buf).Decode(&report); err != nil || report["analysis"] == nil {
+		t.Errorf("Expected a JSON report with an analysis, got error %v", err)
+	}
+}
+
+func TestMonitor_ConcurrentStartStop(t *testing.T) {
+	var mu sync.Mutex
+	var buf bytes.Buffer
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{
+		Interval: time.Hour,
+		ReportSchedule: &gcanalyzer.ReportSchedule{
+			Interval: time.Millisecond,
+			Writer:   &lockedWriter{mu: &mu, w: &buf},
+		},
+	})
+	if err := monitor.InjectChaos(gcanalyzer.ChaosLeak, 5); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = monitor.Start(context.Background())
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				monitor.Stop()
+			}
+		}()
+	}
+	wg.Wait()
+	monitor.Stop()
+
+	// Every run's scheduled reports stopped with it
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	written := buf.Len()
+	mu.Unlock()
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if buf.Len() != written {
+		t.Error("Scheduled reports continued after the monitor stopped")
+	}
+}
+
+func TestMonitor_ReportScheduleInvalid(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{
+		ReportSchedule: &gcanalyzer.ReportSchedule{Interval: time.Minute},
+	})
+	if err := monitor.Start(context.Background()); !errors.Is(err, gcanalyzer.ErrInvalidReportSchedule) {
+		t.Errorf("Start() = %v, want ErrInvalidReportSchedule", err)
+	}
+
+	if err := gcanalyzer.NewMonitor(nil).EmitReport(context.Background()); !errors.Is(err, gcanalyzer.ErrInvalidReportSchedule) {
+		t.Errorf("EmitReport() without a schedule = %v, want ErrInvalidReportSchedule", err)
+	}
+}
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+)
+
+// writeConfig writes a configuration file to a temporary directory
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := writeConfig(t, "gc.yaml", `
+interval: 2s
+labels: {service: checkout}
+max_samples: 500
+alerts:
+  cooldown: 1m
+  rules:
+    - heap_growth_rate > 32MB/s over 3s
+health:
+  profile: throughput-batch
+exporter:
+  listen: ":9191"
+`)
+	config, err := gcanalyzer.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error: %v", err)
+	}
+	m := config.Monitor
+	if m.Interval != 2*time.Second || m.MaxSamples != 500 || m.AlertCooldown != time.Minute || m.Labels["service"] != "checkout" {
+		t.Errorf("Unexpected monitor config %+v", m)
+	}
+	if m.HealthCheck == nil || m.HealthCheck.GCOverheadHigh != 15 {
+		t.Errorf("Expected the throughput-batch health config, got %+v", m.HealthCheck)
+	}
+	if e := config.Exporter; e == nil || e.Listen != ":9191" || e.MetricsPath != "/metrics" || e.HealthPath != "/health" {
+		t.Errorf("Unexpected exporter %+v", e)
+	}
+
+	monitor := gcanalyzer.NewMonitor(m)
+	if got, want := len(monitor.AlertRules()), len(gcanalyzer.DefaultAlertRules())+1; got != want {
+		t.Errorf("Monitor has %d rules, want %d", got, want)
+	}
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name, content string
+		want          error
+	}{
+		{"gc.yaml", "intervall: 1s\n", gcanalyzer.ErrInvalidConfig},
+		{"gc.json", `{"interval": 1}`, gcanalyzer.ErrInvalidConfig},
+		{"gc.yaml", "alerts:\n  rules: [latency > 1s]\n", gcanalyzer.ErrInvalidAlertRule},
+		{"gc.yaml", "health:\n  profile: realtime\n", gcanalyzer.ErrUnknownHealthProfile},
+	}
+	for _, tt := range tests {
+		if _, err := gcanalyzer.LoadConfig(writeConfig(t, tt.name, tt.content)); !errors.Is(err, tt.want) {
+			t.Errorf("LoadConfig(%q) = %v, want %v", tt.content, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gctest"
+)
+
+var gctestSink []byte
+
+func TestReportGCMetrics(t *testing.T) {
+	result := testing.Benchmark(func(b *testing.B) {
+		gctest.ReportGCMetrics(b)
+		for i := 0; i < b.N; i++ {
+			gctestSink = make([]byte, 4096)
+			if i%100 == 0 {
+				runtime.GC()
+			}
+		}
+	})
+
+	for _, unit := range []string{gctest.UnitGCCount, gctest.UnitPauseNs, gctest.UnitAllocBytes} {
+		if _, ok := result.Extra[unit]; !ok {
+			t.Errorf("Benchmark result should report %s, got %v", unit, result.Extra)
+		}
+	}
+	if alloc := result.Extra[gctest.UnitAllocBytes]; alloc < 4096 {
+		t.Errorf("%s = %v, want at least 4096", gctest.UnitAllocBytes, alloc)
+	}
+	if gcs := result.Extra[gctest.UnitGCCount]; gcs <= 0 {
+		t.Errorf("%s = %v, want the forced GCs counted", gctest.UnitGCCount, gcs)
+	}
+}
+
+func TestCheckRegression(t *testing.T) {
+	baseline, err := gcanalyzer.Analyze(generateTestMetrics(10))
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	policy := gcanalyzer.DefaultRegressionPolicy()
+	gctest.AssertNoRegression(t, baseline, baseline, policy)
+
+	current := *baseline
+	current.AllocRate = baseline.AllocRate * 2
+	err = gcanalyzer.CheckRegression(baseline, &current, policy)
+	var regErr *gcanalyzer.RegressionError
+	if !errors.As(err, &regErr) || !errors.Is(err, gcanalyzer.ErrRegression) {
+		t.Fatalf("CheckRegression() error = %v, want a RegressionError", err)
+	}
+	if len(regErr.Regressions) != 1 || regErr.Regressions[0].Metric != "alloc_rate" {
+		t.Errorf("Regressions = %+v, want only alloc_rate", regErr.Regressions)
+	}
+}
+
+// recordingTB records test failures instead of failing the test
+type recordingTB struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Error(args ...any) {
+	r.failures = append(r.failures, fmt.Sprint(args...))
+}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+var leaked [][]byte
+
+func TestAssertNoHeapGrowth(t *testing.T) {
+	gctest.AssertNoHeapGrowth(t, func() {
+		gctestSink = make([]byte, 8<<20)
+		gctestSink = nil
+	}, 0.1)
+
+	rec := &recordingTB{TB: t}
+	gctest.AssertNoHeapGrowth(rec, func() {
+		leaked = append(leaked, make([]byte, 64<<20))
+	}, 0.1)
+	leaked = nil
+	if len(rec.failures) != 1 || !strings.Contains(rec.failures[0], "live heap grew") {
+		t.Errorf("Expected a heap growth failure, got %q", rec.failures)
+	}
+}
+
+func TestAssertMaxPause(t *testing.T) {
+	gctest.AssertMaxPause(t, func() {}, time.Second)
+
+	rec := &recordingTB{TB: t}
+	gctest.AssertMaxPause(rec, func() { runtime.GC() }, 0)
+	if len(rec.failures) != 1 || !strings.Contains(rec.failures[0], "GC paused for") {
+		t.Errorf("Expected a pause failure, got %q", rec.failures)
+	}
+}
+
+func TestAssertNoRegression_Fails(t *testing.T) {
+	baseline := &gcanalyzer.GCAnalysis{GCFrequency: 1}
+	rec := &recordingTB{TB: t}
+	gctest.AssertNoRegression(rec, baseline, &gcanalyzer.GCAnalysis{GCFrequency: 2}, gcanalyzer.DefaultRegressionPolicy())
+	if len(rec.failures) != 1 || !strings.Contains(rec.failures[0], "gc_frequency") {
+		t.Errorf("Expected a regression failure, got %q", rec.failures)
+	}
+}
@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/httpserve"
+)
+
+// serveProbe calls a probe handler, returning the status code and body
+func serveProbe(t *testing.T, h http.Handler) (int, httpserve.ProbeStatus) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	var status httpserve.ProbeStatus
+	if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+		t.Fatalf("Invalid probe JSON: %v", err)
+	}
+	if status.OK != (rec.Code == http.StatusOK) {
+		t.Errorf("Body ok=%v disagrees with status code %d", status.OK, rec.Code)
+	}
+	return rec.Code, status
+}
+
+func TestReadinessHandler(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{Interval: time.Second})
+
+	if code, _ := serveProbe(t, httpserve.ReadinessHandler(monitor, nil)); code != http.StatusOK {
+		t.Errorf("Expected ready while health is unknown, got %d", code)
+	}
+	strict := httpserve.ReadinessHandler(monitor, &httpserve.ReadinessConfig{RequireAnalysis: true})
+	if code, _ := serveProbe(t, strict); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected not ready without analysis, got %d", code)
+	}
+
+	// 750ms pauses raise pause alerts
+	if err := monitor.InjectChaos(gcanalyzer.ChaosPauseStorm, 5); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+	for _, config := range []*httpserve.ReadinessConfig{
+		{MaxP99Pause: 100 * time.Millisecond},
+		{AlertTypes: []string{"pause"}},
+	} {
+		code, status := serveProbe(t, httpserve.ReadinessHandler(monitor, config))
+		if code != http.StatusServiceUnavailable || len(status.Reasons) == 0 || status.Health == nil {
+			t.Errorf("Config %+v: expected not ready with reasons, got %d %+v", config, code, status)
+		}
+	}
+	if code, status := serveProbe(t, httpserve.ReadinessHandler(monitor, &httpserve.ReadinessConfig{MinScore: 1})); code != http.StatusOK {
+		t.Errorf("Expected ready with a low MinScore, got %d %+v", code, status)
+	}
+}
+
+func TestLivenessHandler(t *testing.T) {
+	config := gcanalyzer.DefaultHealthCheckConfig()
+	config.PenaltyP99Pause = 100
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{Interval: time.Second, HealthCheck: &config})
+
+	if code, _ := serveProbe(t, httpserve.LivenessHandler(monitor)); code != http.StatusOK {
+		t.Errorf("Expected live at startup, got %d", code)
+	}
+
+	if err := monitor.InjectChaos(gcanalyzer.ChaosPauseStorm, 5); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+	code, status := serveProbe(t, httpserve.LivenessHandler(monitor))
+	if code != http.StatusServiceUnavailable || status.Health.Score >= httpserve.LivenessMinScore {
+		t.Errorf("Expected a failing liveness probe at score 0, got %d %+v", code, status)
+	}
+}
+
+var requestSink []byte
+
+func TestMiddleware(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{Interval: time.Second})
+	if monitor.RequestImpact() != nil {
+		t.Error("RequestImpact() before any request should be nil")
+	}
+
+	handler := httpserve.Middleware(monitor, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestSink = make([]byte, 1<<20)
+		if r.URL.Path == "/gc" {
+			runtime.GC()
+		}
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	for _, path := range []string{"/", "/", "/gc"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusTeapot {
+			t.Errorf("Middleware changed status to %d", rec.Code)
+		}
+	}
+
+	impact := monitor.RequestImpact()
+	if impact == nil || impact.Requests != 3 || impact.Window != 3 {
+		t.Fatalf("RequestImpact() = %+v, want 3 requests", impact)
+	}
+	if impact.GCAffected < 1 || impact.PauseMax <= 0 {
+		t.Errorf("The forced GC should be recorded, got %+v", impact)
+	}
+	if impact.AllocP50 < 1<<20 {
+		t.Errorf("AllocP50 = %d, want at least the 1 MB allocated per request", impact.AllocP50)
+	}
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+)
+
+func TestSaveLoadBinary(t *testing.T) {
+	metrics := []*gcanalyzer.GCMetrics{{NumGC: 3, PauseNs: make([]uint64, 256), Timestamp: time.Now()}}
+	metrics[0].PauseNs[2] = 250_000
+	events := []*gcanalyzer.GCEvent{{Sequence: 3, Duration: 250 * time.Microsecond}}
+
+	var buf bytes.Buffer
+	if err := gcanalyzer.SaveBinary(&buf, metrics, events); err != nil {
+		t.Fatalf("SaveBinary() error: %v", err)
+	}
+	gotMetrics, gotEvents, err := gcanalyzer.LoadBinary(&buf)
+	if err != nil {
+		t.Fatalf("LoadBinary() error: %v", err)
+	}
+	if len(gotMetrics) != 1 || gotMetrics[0].PauseNs[2] != 250_000 {
+		t.Errorf("Metrics not preserved: %+v", gotMetrics)
+	}
+	if len(gotEvents) != 1 || gotEvents[0].Duration != 250*time.Microsecond {
+		t.Errorf("Events not preserved: %+v", gotEvents)
+	}
+}
+
+func TestLoadMetrics_Reanalyze(t *testing.T) {
+	now := time.Now()
+	metrics := []*gcanalyzer.GCMetrics{
+		{NumGC: 10, HeapAlloc: 1 << 20, TotalAlloc: 5 << 20, Timestamp: now},
+		{NumGC: 15, HeapAlloc: 2 << 20, TotalAlloc: 10 << 20, Timestamp: now.Add(10 * time.Second)},
+	}
+	want, err := gcanalyzer.Analyze(metrics)
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := gcanalyzer.WriteMetrics(&buf, metrics, gcanalyzer.FormatJSONL); err != nil {
+		t.Fatalf("WriteMetrics() error: %v", err)
+	}
+	loaded, err := gcanalyzer.LoadMetrics(&buf, gcanalyzer.FormatJSONL)
+	if err != nil {
+		t.Fatalf("LoadMetrics() error: %v", err)
+	}
+
+	got, err := gcanalyzer.AnalyzeWithOptions(loaded, nil, &gcanalyzer.AnalyzerOptions{
+		Labels: map[string]string{"source": "prod"},
+	})
+	if err != nil {
+		t.Fatalf("AnalyzeWithOptions() error: %v", err)
+	}
+	if got.GCFrequency != want.GCFrequency || got.AllocRate != want.AllocRate || got.Period != want.Period {
+		t.Errorf("Re-analysis differs: got %v/%v/%v, want %v/%v/%v",
+			got.GCFrequency, got.AllocRate, got.Period, want.GCFrequency, want.AllocRate, want.Period)
+	}
+	if got.Labels["source"] != "prod" {
+		t.Errorf("Labels = %v", got.Labels)
+	}
+}
+
+func TestParsePrometheusRange_Analyze(t *testing.T) {
+	const response = `{"status": "success", "data": {"resultType": "matrix", "result": [
+		{"metric": {"__name__": "go_gc_duration_seconds_count", "job": "api"}, "values": [[1700000000, "100"], [1700000010, "110"]]},
+		{"metric": {"__name__": "go_gc_duration_seconds_sum", "job": "api"}, "values": [[1700000000, "0.1"], [1700000010, "0.11"]]},
+		{"metric": {"__name__": "go_memstats_alloc_bytes_total", "job": "api"}, "values": [[1700000000, "1e8"], [1700000010, "2e8"]]},
+		{"metric": {"__name__": "go_memstats_heap_alloc_bytes", "job": "api"}, "values": [[1700000000, "4e7"], [1700000010, "5e7"]]}
+	]}}`
+
+	metrics, err := gcanalyzer.ParsePrometheusRange(strings.NewReader(response))
+	if err != nil {
+		t.Fatalf("ParsePrometheusRange() error: %v", err)
+	}
+	analysis, err := gcanalyzer.Analyze(metrics)
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	if analysis.GCFrequency != 1 {
+		t.Errorf("GCFrequency = %v, want 1", analysis.GCFrequency)
+	}
+	if analysis.AvgPauseTime != time.Millisecond {
+		t.Errorf("AvgPauseTime = %v, want 1ms", analysis.AvgPauseTime)
+	}
+	if analysis.AllocRate != 1e7 {
+		t.Errorf("AllocRate = %v, want 1e7", analysis.AllocRate)
+	}
+}
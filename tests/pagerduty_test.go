@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+)
+
+func TestMonitor_PagerDuty(t *testing.T) {
+	actions := make(chan string, 32)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev struct {
+			EventAction string `json:"event_action"`
+			DedupKey    string `json:"dedup_key"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+			t.Errorf("Decode event: %v", err)
+		}
+		if ev.DedupKey != "go-gc-analyzer/test/pause" {
+			t.Errorf("Dedup key = %q", ev.DedupKey)
+		}
+		actions <- ev.EventAction
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	resolved := make(chan *gcanalyzer.Alert, 32)
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{
+		Interval:        time.Hour,
+		NotifyGC:        true,
+		PagerDuty:       &gcanalyzer.PagerDutyConfig{RoutingKey: "key", Source: "test", URL: server.URL},
+		OnAlertResolved: func(a *gcanalyzer.Alert) { resolved <- a },
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := monitor.Start(ctx); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer monitor.Stop()
+
+	if err := monitor.InjectChaos(gcanalyzer.ChaosPauseStorm, 3); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+	expectAction(t, actions, "trigger")
+
+	// A real, short pause clears the pause rule and resolves the incident
+	runtime.GC()
+	expectAction(t, actions, "resolve")
+	select {
+	case a := <-resolved:
+		if !a.Resolved || a.Type != "pause" {
+			t.Errorf("Unexpected resolved alert %+v", a)
+		}
+	default:
+		t.Error("OnAlertResolved not called")
+	}
+}
+
+func expectAction(t *testing.T, actions <-chan string, want string) {
+	t.Helper()
+	select {
+	case got := <-actions:
+		if got != want {
+			t.Errorf("Event action = %q, want %q", got, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("No %s event delivered", want)
+	}
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+)
+
+var regionSink []byte
+
+func TestMonitor_Regions(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(nil)
+
+	for i := 0; i < 5; i++ {
+		span := monitor.BeginRegion("encode")
+		regionSink = make([]byte, 64*1024)
+		span.End()
+	}
+
+	// Synthetic samples give the analysis enough data without waiting
+	if err := monitor.InjectChaos(gcanalyzer.ChaosLeak, 3); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	analysis, err := monitor.GetCurrentAnalysis()
+	if err != nil {
+		t.Fatalf("GetCurrentAnalysis() error: %v", err)
+	}
+	if len(analysis.Regions) != 1 {
+		t.Fatalf("Expected 1 region in analysis, got %d", len(analysis.Regions))
+	}
+
+	region := analysis.Regions[0]
+	if region.Name != "encode" || region.Calls != 5 {
+		t.Errorf("Region = %+v, want encode with 5 calls", region)
+	}
+	if region.AllocBytes < 5*64*1024 {
+		t.Errorf("AllocBytes = %d, want at least %d", region.AllocBytes, 5*64*1024)
+	}
+}
+
+func TestRegionTracker(t *testing.T) {
+	tracker := gcanalyzer.NewRegionTracker()
+	tracker.Begin("a").End()
+
+	metrics := []*gcanalyzer.GCMetrics{gcanalyzer.CollectOnce(), gcanalyzer.CollectOnce()}
+	analysis, err := gcanalyzer.AnalyzeWithOptions(metrics, nil, &gcanalyzer.AnalyzerOptions{
+		Regions: tracker.Stats(),
+	})
+	if err != nil {
+		t.Fatalf("AnalyzeWithOptions() error: %v", err)
+	}
+	if len(analysis.Regions) != 1 || analysis.Regions[0].Name != "a" {
+		t.Errorf("Regions = %+v, want region a", analysis.Regions)
+	}
+}
+
+func TestMonitor_WithRegion(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(nil)
+
+	ctx, end := monitor.WithRegion(context.Background(), "startup")
+	if got := gcanalyzer.RegionFromContext(ctx); got != "startup" {
+		t.Errorf("RegionFromContext() = %q, want startup", got)
+	}
+	if err := monitor.InjectChaos(gcanalyzer.ChaosLeak, 3); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+	end()
+
+	_, end = monitor.WithRegion(context.Background(), "steady")
+	if err := monitor.InjectChaos(gcanalyzer.ChaosLeak, 3); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+	end()
+
+	analysis, err := monitor.GetCurrentAnalysis()
+	if err != nil {
+		t.Fatalf("GetCurrentAnalysis() error: %v", err)
+	}
+
+	var names []string
+	for _, rb := range analysis.RegionBreakdown {
+		names = append(names, rb.Region)
+	}
+	if len(names) != 2 || names[0] != "startup" || names[1] != "steady" {
+		t.Errorf("RegionBreakdown regions = %q, want [startup steady]", names)
+	}
+}
+
+func TestMonitor_TrackCall(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(nil)
+
+	for _, method := range []string{"/pkg.Service/Get", "/pkg.Service/Get", "/pkg.Service/List"} {
+		end := monitor.TrackCall(method)
+		regionSink = make([]byte, 32*1024)
+		end()
+	}
+
+	stats := monitor.RegionStats()
+	if len(stats) != 2 {
+		t.Fatalf("Expected 2 methods in RegionStats, got %+v", stats)
+	}
+	calls := map[string]uint64{}
+	for _, s := range stats {
+		calls[s.Name] = s.Calls
+		if s.AllocBytes < s.Calls*32*1024 {
+			t.Errorf("%s AllocBytes = %d, want at least %d", s.Name, s.AllocBytes, s.Calls*32*1024)
+		}
+	}
+	if calls["/pkg.Service/Get"] != 2 || calls["/pkg.Service/List"] != 1 {
+		t.Errorf("Calls per method = %v, want Get 2 and List 1", calls)
+	}
+
+	if impact := monitor.RequestImpact(); impact == nil || impact.Requests != 3 {
+		t.Errorf("RequestImpact() = %+v, want 3 requests", impact)
+	}
+}
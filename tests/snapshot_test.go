@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+)
+
+func TestMonitor_Snapshot(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{Interval: time.Second})
+	if s := monitor.Snapshot(); s != nil {
+		t.Fatalf("Expected no snapshot before the first sample, got %+v", s)
+	}
+
+	if err := monitor.InjectChaos(gcanalyzer.ChaosThrash, 1); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+	first := monitor.Snapshot()
+	if first == nil || first.Metrics == nil {
+		t.Fatal("Expected a snapshot after the first sample")
+	}
+	if first.Analysis != nil || first.Health == nil || first.Health.Status != "unknown" {
+		t.Errorf("One sample is too few to analyze, got analysis %v, health %+v", first.Analysis, first.Health)
+	}
+
+	if err := monitor.InjectChaos(gcanalyzer.ChaosThrash, 5); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+	s := monitor.Snapshot()
+	if s.Analysis == nil || s.Health == nil || s.Health.Status == "unknown" {
+		t.Fatalf("Expected analysis and health once enough samples exist, got %+v", s)
+	}
+	if s.Metrics != monitor.GetMetrics()[5] {
+		t.Error("Snapshot should carry the latest sample")
+	}
+	if first.Analysis != nil || first == s {
+		t.Error("Published snapshots must not change")
+	}
+}
+
+func TestMonitor_SnapshotInterval(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{
+		Interval:         time.Second,
+		SnapshotInterval: time.Hour,
+	})
+	if err := monitor.InjectChaos(gcanalyzer.ChaosLeak, 3); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+	before := monitor.Snapshot()
+
+	if err := monitor.InjectChaos(gcanalyzer.ChaosLeak, 3); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+	after := monitor.Snapshot()
+	if after.Analysis != before.Analysis || after.AnalyzedAt != before.AnalyzedAt {
+		t.Error("Analysis should be reused within the snapshot interval")
+	}
+	if after.Metrics == before.Metrics {
+		t.Error("Latest metrics should be refreshed with every sample")
+	}
+}
+
+func TestMonitor_SnapshotConcurrentReaders(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{Interval: time.Second})
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					if s := monitor.Snapshot(); s != nil && s.Health == nil {
+						t.Error("Snapshot published without health")
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 20; i++ {
+		if err := monitor.InjectChaos(gcanalyzer.ChaosPauseStorm, 1); err != nil {
+			t.Fatalf("InjectChaos() error: %v", err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
+
+var snapshotSink []byte
+
+func TestSnapshot_Delta(t *testing.T) {
+	snap := gcanalyzer.Snapshot()
+	for i := 0; i < 64; i++ {
+		snapshotSink = make([]byte, 64*1024)
+	}
+	runtime.GC()
+	delta := snap.Delta()
+
+	if delta.GCCount < 1 || delta.PauseTime <= 0 {
+		t.Errorf("Expected the forced GC and its pause, got %+v", delta)
+	}
+	if delta.AllocBytes < 64*64*1024 {
+		t.Errorf("Expected at least 4 MB allocated, got %d", delta.AllocBytes)
+	}
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+)
+
+// apmSource reads a vendor export with one "unix_seconds num_gc heap_alloc"
+// line per sample, the way a third-party importer would plug in
+type apmSource struct {
+	scanner *bufio.Scanner
+}
+
+func (s *apmSource) Next() (*gcanalyzer.GCMetrics, *gcanalyzer.GCEvent, error) {
+	if !s.scanner.Scan() {
+		if err := s.scanner.Err(); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, io.EOF
+	}
+	fields := strings.Fields(s.scanner.Text())
+	var values [3]uint64
+	for i := range values {
+		v, err := strconv.ParseUint(fields[i], 10, 64)
+		if err != nil {
+			return nil, nil, err
+		}
+		values[i] = v
+	}
+	return &gcanalyzer.GCMetrics{
+		Timestamp: time.Unix(int64(values[0]), 0),
+		NumGC:     uint32(values[1]),
+		HeapAlloc: values[2],
+	}, nil, nil
+}
+
+func TestAnalyzeSource(t *testing.T) {
+	export := "1700000000 10 1048576\n1700000005 15 2097152\n1700000010 20 3145728\n"
+	var src gcanalyzer.Source = &apmSource{scanner: bufio.NewScanner(strings.NewReader(export))}
+
+	analysis, err := gcanalyzer.AnalyzeSource(src, &gcanalyzer.AnalyzerOptions{
+		Labels: map[string]string{"source": "apm"},
+	})
+	if err != nil {
+		t.Fatalf("AnalyzeSource() error: %v", err)
+	}
+	if analysis.Period != 10*time.Second || analysis.GCFrequency != 1 {
+		t.Errorf("Period = %v, GCFrequency = %v", analysis.Period, analysis.GCFrequency)
+	}
+	if analysis.MaxHeapSize != 3<<20 || analysis.Labels["source"] != "apm" {
+		t.Errorf("MaxHeapSize = %d, Labels = %v", analysis.MaxHeapSize, analysis.Labels)
+	}
+
+	bad := &apmSource{scanner: bufio.NewScanner(strings.NewReader("1700000000 x 0\n"))}
+	if _, err := gcanalyzer.AnalyzeSource(bad, nil); err == nil {
+		t.Error("Expected the source's parse error")
+	}
+}
+
+func TestAnalyzeSource_SliceSource(t *testing.T) {
+	metrics := generateTestMetrics(20)
+	want, err := gcanalyzer.Analyze(metrics)
+	if err != nil {
+		t.Fatalf("Analyze() error: %v", err)
+	}
+	got, err := gcanalyzer.AnalyzeSource(gcanalyzer.NewSliceSource(metrics, nil), nil)
+	if err != nil {
+		t.Fatalf("AnalyzeSource() error: %v", err)
+	}
+	if got.GCFrequency != want.GCFrequency || got.AvgHeapSize != want.AvgHeapSize {
+		t.Errorf("AnalyzeSource() differs from Analyze(): %v/%d vs %v/%d",
+			got.GCFrequency, got.AvgHeapSize, want.GCFrequency, want.AvgHeapSize)
+	}
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/tui"
+)
+
+func TestTUI_Render(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(nil)
+
+	var buf bytes.Buffer
+	if err := tui.Render(&buf, monitor, nil); err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Collecting samples") {
+		t.Errorf("Expected a placeholder before the first analysis, got:\n%s", buf.String())
+	}
+
+	if err := monitor.InjectChaos(gcanalyzer.ChaosThrash, 10); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+	buf.Reset()
+	if err := tui.Render(&buf, monitor, &tui.Config{Width: 20}); err != nil {
+		t.Fatalf("Render() error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"health: ", "Heap ", "GC ", "Pause ", "p99 ", "Recommendations"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Dashboard missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestTUI_Sparkline(t *testing.T) {
+	if got := tui.Sparkline([]uint64{1, 2, 3, 4, 5, 6, 7, 8}, 10); got != "▁▂▃▄▅▆▇█" {
+		t.Errorf("Sparkline() = %q", got)
+	}
+	if got := tui.Sparkline([]uint64{5, 5, 5}, 10); got != "▁▁▁" {
+		t.Errorf("Sparkline() of a flat series = %q", got)
+	}
+
+	values := make([]uint64, 1000)
+	for i := range values {
+		values[i] = uint64(i)
+	}
+	if got := tui.Sparkline(values, 40); utf8.RuneCountInString(got) != 40 {
+		t.Errorf("Sparkline() = %d columns, want 40", utf8.RuneCountInString(got))
+	}
+	if got := tui.Sparkline(nil, 10); got != "" {
+		t.Errorf("Sparkline(nil) = %q", got)
+	}
+}
+
+func TestTUI_Run(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var buf bytes.Buffer
+	if err := tui.Run(ctx, &buf, gcanalyzer.NewMonitor(nil), &tui.Config{Refresh: 10 * time.Millisecond}); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if frames := strings.Count(buf.String(), "go-gc-analyzer"); frames < 2 {
+		t.Errorf("Run() drew %d frames, want several", frames)
+	}
+	if !strings.HasSuffix(buf.String(), "\x1b[?25h") {
+		t.Error("Run() should restore the cursor on exit")
+	}
+}
@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/tuning"
+)
+
+// allocate keeps the heap churning until ctx is done
+func allocate(ctx context.Context) {
+	var keep [][]byte
+	for ctx.Err() == nil {
+		keep = append(keep, make([]byte, 64*1024))
+		if len(keep) > 64 {
+			keep = keep[:0]
+		}
+		time.Sleep(100 * time.Microsecond)
+	}
+}
+
+func TestSweep(t *testing.T) {
+	original := debug.SetGCPercent(100)
+	defer debug.SetGCPercent(original)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go allocate(ctx)
+
+	result, err := tuning.Sweep(ctx, []int{25, 400}, 300*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Sweep() error: %v", err)
+	}
+	if len(result.Stages) != 2 {
+		t.Fatalf("Got %d stages, want 2", len(result.Stages))
+	}
+	for i, stage := range result.Stages {
+		if stage.Analysis == nil {
+			t.Errorf("Stage %d has no analysis", i)
+		}
+		if i > 0 && stage.Cost < result.Stages[i-1].Cost {
+			t.Errorf("Stages are not ranked by cost: %v after %v", stage.Cost, result.Stages[i-1].Cost)
+		}
+	}
+	if result.OriginalGOGC != 100 {
+		t.Errorf("OriginalGOGC = %d, want 100", result.OriginalGOGC)
+	}
+	if gogc := debug.SetGCPercent(100); gogc != 100 {
+		t.Errorf("GOGC after sweep = %d, want the original 100", gogc)
+	}
+}
+
+func TestSweep_Errors(t *testing.T) {
+	ctx := context.Background()
+	if _, err := tuning.Sweep(ctx, nil, time.Second); !errors.Is(err, gcanalyzer.ErrInvalidSweep) {
+		t.Errorf("Sweep() without values error = %v, want ErrInvalidSweep", err)
+	}
+	if _, err := tuning.Sweep(ctx, []int{100}, 0); !errors.Is(err, gcanalyzer.ErrInvalidSweep) {
+		t.Errorf("Sweep() with zero stage error = %v, want ErrInvalidSweep", err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := tuning.Sweep(canceled, []int{100}, time.Second); !errors.Is(err, context.Canceled) {
+		t.Errorf("Sweep() with canceled context error = %v, want context.Canceled", err)
+	}
+}
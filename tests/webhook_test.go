@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyungseok-lee/go-gc-analyzer/pkg/gcanalyzer"
+)
+
+func TestMonitor_AlertWebhook(t *testing.T) {
+	received := make(chan *gcanalyzer.Alert, 32)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var a gcanalyzer.Alert
+		if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+			t.Errorf("Decode alert: %v", err)
+		}
+		received <- &a
+	}))
+	defer server.Close()
+
+	// No OnAlert callback: the webhook alone receives alerts
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{
+		Interval:     time.Hour,
+		AlertWebhook: &gcanalyzer.WebhookConfig{URL: server.URL},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := monitor.Start(ctx); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	defer monitor.Stop()
+
+	if err := monitor.InjectChaos(gcanalyzer.ChaosPauseStorm, 3); err != nil {
+		t.Fatalf("InjectChaos() error: %v", err)
+	}
+
+	select {
+	case a := <-received:
+		if a.Type != "pause" || a.Severity != "critical" {
+			t.Errorf("Unexpected alert %+v", a)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("No alert delivered to the webhook")
+	}
+}
+
+func TestMonitor_AlertWebhook_Invalid(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{
+		AlertWebhook: &gcanalyzer.WebhookConfig{URL: "not a url"},
+	})
+	if err := monitor.Start(context.Background()); !errors.Is(err, gcanalyzer.ErrInvalidWebhook) {
+		t.Errorf("Start() error = %v, want ErrInvalidWebhook", err)
+	}
+	if monitor.IsRunning() {
+		t.Error("Monitor should not run with an invalid webhook")
+	}
+}
+
+func TestMonitor_AlertEmail_Invalid(t *testing.T) {
+	monitor := gcanalyzer.NewMonitor(&gcanalyzer.MonitorConfig{
+		AlertEmail: &gcanalyzer.EmailConfig{Addr: "smtp.example.com:587", From: "gc@example.com"},
+	})
+	if err := monitor.Start(context.Background()); !errors.Is(err, gcanalyzer.ErrInvalidEmail) {
+		t.Errorf("Start() error = %v, want ErrInvalidEmail", err)
+	}
+	if monitor.IsRunning() {
+		t.Error("Monitor should not run with an invalid email configuration")
+	}
+}